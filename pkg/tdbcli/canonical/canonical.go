@@ -0,0 +1,76 @@
+// Package canonical produces deterministic JSON encodings so exported data can
+// be diffed, hashed, and compared byte-for-byte.
+//
+// Object keys are sorted, insignificant whitespace is removed, HTML characters
+// are not escaped, and numbers are written exactly as they appeared in the
+// input rather than being round-tripped through float64.
+package canonical
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Marshal encodes v as canonical JSON.
+func Marshal(v any) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return Canonicalize(raw)
+}
+
+// MarshalIndent encodes v as canonical JSON with the given indentation.
+func MarshalIndent(v any, prefix, indent string) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return CanonicalizeIndent(raw, prefix, indent)
+}
+
+// Canonicalize rewrites a JSON document into its canonical compact form.
+func Canonicalize(raw []byte) ([]byte, error) {
+	return encode(raw, "", "")
+}
+
+// CanonicalizeIndent rewrites a JSON document with sorted keys and indentation.
+func CanonicalizeIndent(raw []byte, prefix, indent string) ([]byte, error) {
+	return encode(raw, prefix, indent)
+}
+
+// Equal reports whether two JSON documents are semantically identical.
+func Equal(a, b []byte) (bool, error) {
+	left, err := Canonicalize(a)
+	if err != nil {
+		return false, err
+	}
+	right, err := Canonicalize(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(left, right), nil
+}
+
+func encode(raw []byte, prefix, indent string) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, fmt.Errorf("canonical: decode: %w", err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("canonical: unexpected data after top-level value")
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if prefix != "" || indent != "" {
+		enc.SetIndent(prefix, indent)
+	}
+	if err := enc.Encode(value); err != nil {
+		return nil, fmt.Errorf("canonical: encode: %w", err)
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
@@ -0,0 +1,42 @@
+package canonical
+
+import "testing"
+
+func TestCanonicalize(t *testing.T) {
+	got, err := Canonicalize([]byte(`{ "b": 1, "a": {"d": [3, 2], "c": "<x>"}, "n": 12345678901234567890 }`))
+	if err != nil {
+		t.Fatalf("Canonicalize returned error: %v", err)
+	}
+	want := `{"a":{"c":"<x>","d":[3,2]},"b":1,"n":12345678901234567890}`
+	if string(got) != want {
+		t.Fatalf("unexpected canonical form:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestCanonicalizeIndent(t *testing.T) {
+	got, err := CanonicalizeIndent([]byte(`{"b":1,"a":2}`), "", "  ")
+	if err != nil {
+		t.Fatalf("CanonicalizeIndent returned error: %v", err)
+	}
+	want := "{\n  \"a\": 2,\n  \"b\": 1\n}"
+	if string(got) != want {
+		t.Fatalf("unexpected indented form: %q", got)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	same, err := Equal([]byte(`{"a":1,"b":[1,2]}`), []byte(`{"b":[1, 2],"a":1}`))
+	if err != nil || !same {
+		t.Fatalf("expected documents to be equal (err=%v)", err)
+	}
+	same, err = Equal([]byte(`{"a":1}`), []byte(`{"a":1.0}`))
+	if err != nil || same {
+		t.Fatalf("expected differing number literals to compare unequal (err=%v)", err)
+	}
+}
+
+func TestCanonicalizeRejectsTrailingData(t *testing.T) {
+	if _, err := Canonicalize([]byte(`{} {}`)); err == nil {
+		t.Fatalf("expected error for trailing data")
+	}
+}
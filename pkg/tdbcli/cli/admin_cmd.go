@@ -3,6 +3,7 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +27,8 @@ func registerAdminCommands(root *cobra.Command, env *Environment) {
 	}
 	adminTenantsCmd.AddCommand(newAdminTenantListCommand(env))
 	adminTenantsCmd.AddCommand(newAdminTenantCreateCommand(env))
+	adminTenantsCmd.AddCommand(newAdminTenantSetLimitsCommand(env))
+	adminTenantsCmd.AddCommand(newAdminTenantDeleteCommand(env))
 
 	adminKeysCmd := &cobra.Command{
 		Use:   "keys",
@@ -34,9 +37,12 @@ func registerAdminCommands(root *cobra.Command, env *Environment) {
 	adminKeysCmd.AddCommand(newAdminKeyListCommand(env))
 	adminKeysCmd.AddCommand(newAdminKeyCreateCommand(env))
 	adminKeysCmd.AddCommand(newAdminKeyRevokeCommand(env))
+	adminKeysCmd.AddCommand(newAdminKeyRevokeManyCommand(env))
+	adminKeysCmd.AddCommand(newAdminKeyReassignCommand(env))
 
 	adminCmd.AddCommand(adminTenantsCmd)
 	adminCmd.AddCommand(adminKeysCmd)
+	adminCmd.AddCommand(newAdminReportCommand(env))
 
 	root.AddCommand(adminCmd)
 }
@@ -141,15 +147,15 @@ Optionally generate and store an API key for the new tenant for immediate use. T
 			if err != nil {
 				return err
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "Created tenant %s (%s)\n", tenant.Name, tenant.ID)
+			fmt.Fprintf(cmd.OutOrStdout(), tr("Created tenant %s (%s)\n"), tenant.Name, tenant.ID)
 			if generatedKey != nil {
-				fmt.Fprintf(cmd.OutOrStdout(), "Generated key: %s (prefix %s)\n", generatedKey.APIKey, generatedKey.Prefix)
+				fmt.Fprintf(cmd.OutOrStdout(), tr("Generated key: %s (prefix %s)\n"), generatedKey.APIKey, generatedKey.Prefix)
 				if strings.TrimSpace(saveAlias) != "" {
 					entry := configpkg.APIKeyEntry{Key: generatedKey.APIKey, Prefix: generatedKey.Prefix}
 					if err := storeAPIKey(envCtx, tenant.ID, saveAlias, entry, setDefault, strings.TrimSpace(tenantLabel)); err != nil {
 						return fmt.Errorf("tenant created but failed to store key: %w", err)
 					}
-					fmt.Fprintf(cmd.OutOrStdout(), "Stored generated key as %s\n", saveAlias)
+					fmt.Fprintf(cmd.OutOrStdout(), tr("Stored generated key as %s\n"), saveAlias)
 				}
 			}
 			return nil
@@ -166,6 +172,170 @@ Optionally generate and store an API key for the new tenant for immediate use. T
 	return cmd
 }
 
+func newAdminTenantSetLimitsCommand(env *Environment) *cobra.Command {
+	var rateLimit string
+	var dailyRequests string
+	var storage string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "set-limits <tenant-id>",
+		Short: "Update tenant rate, request, and storage limits",
+		Long: `Update quota limits for a tenant and show the before and after values.
+
+Rate limits accept a count per second, minute, or hour (e.g. 10/s, 600/m, 36000/h)
+and are stored per minute. Storage accepts human-friendly sizes such as 500MB or 50GB.
+Limits that are not specified are left unchanged.`,
+		Example: `  # Set all limits
+  tdb admin tenants set-limits tn_123 \
+    --rate-limit 600/m \
+    --daily-requests 1000000 \
+    --storage 50GB \
+    --admin-secret $ADMIN_SECRET
+
+  # Preview a storage change without applying it
+  tdb admin tenants set-limits tn_123 --storage 100GB --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantID := strings.TrimSpace(args[0])
+			if tenantID == "" {
+				return errors.New("tenant id cannot be empty")
+			}
+			req, err := buildTenantLimitsRequest(rateLimit, dailyRequests, storage)
+			if err != nil {
+				return err
+			}
+			if req.RateLimitPerMinute == nil && req.RequestDailyLimit == nil && req.StorageBytesLimit == nil {
+				return errors.New("specify at least one of --rate-limit, --daily-requests, or --storage")
+			}
+			client, err := adminClientForTenant(envCtx, tenantID)
+			if err != nil {
+				return err
+			}
+			tenants, err := client.ListTenants(cmd.Context())
+			if err != nil {
+				return err
+			}
+			var before *clientpkg.Tenant
+			for i := range tenants {
+				if tenants[i].ID == tenantID {
+					before = &tenants[i]
+					break
+				}
+			}
+			if before == nil {
+				return fmt.Errorf("tenant %s not found", tenantID)
+			}
+
+			after := *before
+			if req.RateLimitPerMinute != nil {
+				after.RateLimitPerMinute = req.RateLimitPerMinute
+			}
+			if req.RequestDailyLimit != nil {
+				after.RequestDailyLimit = req.RequestDailyLimit
+			}
+			if req.StorageBytesLimit != nil {
+				after.StorageBytesLimit = req.StorageBytesLimit
+			}
+			if !dryRun {
+				updated, err := client.UpdateTenant(cmd.Context(), tenantID, req)
+				if err != nil {
+					return err
+				}
+				after = *updated
+			}
+
+			if dryRun {
+				fmt.Fprintf(cmd.OutOrStdout(), "Planned limit changes for tenant %s (%s):\n", before.Name, before.ID)
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), "Updated limits for tenant %s (%s):\n", before.Name, before.ID)
+			}
+			renderTable(cmd, []string{"LIMIT", "BEFORE", "AFTER"}, tenantLimitRows(*before, after))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&rateLimit, "rate-limit", "", "Request rate limit (e.g. 600/m, 10/s, 36000/h)")
+	cmd.Flags().StringVar(&dailyRequests, "daily-requests", "", "Maximum requests per day")
+	cmd.Flags().StringVar(&storage, "storage", "", "Storage limit (e.g. 500MB, 50GB)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the planned changes without applying them")
+
+	return cmd
+}
+
+// buildTenantLimitsRequest parses limit flags, leaving empty values unset.
+func buildTenantLimitsRequest(rateLimit, dailyRequests, storage string) (clientpkg.UpdateTenantRequest, error) {
+	var req clientpkg.UpdateTenantRequest
+	if strings.TrimSpace(rateLimit) != "" {
+		perMinute, err := parseRateLimit(rateLimit)
+		if err != nil {
+			return req, err
+		}
+		req.RateLimitPerMinute = &perMinute
+	}
+	if trimmed := strings.TrimSpace(dailyRequests); trimmed != "" {
+		cleaned := strings.NewReplacer(",", "", "_", "").Replace(trimmed)
+		daily, err := strconv.Atoi(cleaned)
+		if err != nil || daily <= 0 {
+			return req, fmt.Errorf("invalid --daily-requests %q", dailyRequests)
+		}
+		req.RequestDailyLimit = &daily
+	}
+	if trimmed := strings.TrimSpace(storage); trimmed != "" {
+		bytes, err := humanize.ParseBytes(trimmed)
+		if err != nil || bytes == 0 {
+			return req, fmt.Errorf("invalid --storage %q", storage)
+		}
+		limit := int64(bytes)
+		req.StorageBytesLimit = &limit
+	}
+	return req, nil
+}
+
+// parseRateLimit converts values like 600, 600/m, 10/s, or 36000/h to requests
+// per minute. Hourly rates round up so a non-zero limit never becomes zero.
+func parseRateLimit(raw string) (int, error) {
+	trimmed := strings.ToLower(strings.TrimSpace(raw))
+	countPart, unit, _ := strings.Cut(trimmed, "/")
+	count, err := strconv.Atoi(strings.TrimSpace(countPart))
+	if err != nil || count <= 0 {
+		return 0, fmt.Errorf("invalid --rate-limit %q (expected e.g. 600/m)", raw)
+	}
+	switch strings.TrimSpace(unit) {
+	case "", "m", "min", "minute":
+		return count, nil
+	case "s", "sec", "second":
+		return count * 60, nil
+	case "h", "hr", "hour":
+		return (count + 59) / 60, nil
+	}
+	return 0, fmt.Errorf("invalid --rate-limit unit %q (use s, m, or h)", unit)
+}
+
+func tenantLimitRows(before, after clientpkg.Tenant) [][]string {
+	intLimit := func(v *int, suffix string) string {
+		if v == nil {
+			return "unlimited"
+		}
+		return humanize.Comma(int64(*v)) + suffix
+	}
+	bytesLimit := func(v *int64) string {
+		if v == nil {
+			return "unlimited"
+		}
+		return formatBytes(*v)
+	}
+	return [][]string{
+		{"Rate limit", intLimit(before.RateLimitPerMinute, "/min"), intLimit(after.RateLimitPerMinute, "/min")},
+		{"Daily requests", intLimit(before.RequestDailyLimit, ""), intLimit(after.RequestDailyLimit, "")},
+		{"Storage", bytesLimit(before.StorageBytesLimit), bytesLimit(after.StorageBytesLimit)},
+	}
+}
+
 func newAdminKeyListCommand(env *Environment) *cobra.Command {
 	var tenantID string
 	var appID string
@@ -184,7 +354,7 @@ func newAdminKeyListCommand(env *Environment) *cobra.Command {
 				return err
 			}
 			if !cmd.Flags().Changed("tenant") {
-				fmt.Fprintf(cmd.OutOrStdout(), "Using default tenant %s\n", tenantIDTrim)
+				fmt.Fprintf(cmd.OutOrStdout(), tr("Using default tenant %s\n"), tenantIDTrim)
 			}
 			client, err := adminClientFromEnv(envCtx)
 			if err != nil {
@@ -233,9 +403,9 @@ func newAdminKeyCreateCommand(env *Environment) *cobra.Command {
 				return err
 			}
 			if !cmd.Flags().Changed("tenant") {
-				fmt.Fprintf(cmd.OutOrStdout(), "Using default tenant %s\n", tenantIDTrim)
+				fmt.Fprintf(cmd.OutOrStdout(), tr("Using default tenant %s\n"), tenantIDTrim)
 			}
-			client, err := adminClientFromEnv(envCtx)
+			client, err := adminClientForTenant(envCtx, tenantIDTrim)
 			if err != nil {
 				return err
 			}
@@ -244,12 +414,12 @@ func newAdminKeyCreateCommand(env *Environment) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "Generated key: %s (prefix %s)\n", generated.APIKey, generated.Prefix)
+			fmt.Fprintf(cmd.OutOrStdout(), tr("Generated key: %s (prefix %s)\n"), generated.APIKey, generated.Prefix)
 			if alias := strings.TrimSpace(saveAlias); alias != "" {
 				if err := persistGeneratedKey(envCtx, tenantIDTrim, alias, generated, desc, setDefault, tenantLabel); err != nil {
 					return err
 				}
-				fmt.Fprintf(cmd.OutOrStdout(), "Stored generated key as %s\n", alias)
+				fmt.Fprintf(cmd.OutOrStdout(), tr("Stored generated key as %s\n"), alias)
 			}
 			return nil
 		},
@@ -266,7 +436,7 @@ func newAdminKeyCreateCommand(env *Environment) *cobra.Command {
 }
 
 func newAdminKeyRevokeCommand(env *Environment) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "revoke <prefix>",
 		Short: "Revoke an API key by prefix",
 		Args:  cobra.ExactArgs(1),
@@ -275,24 +445,36 @@ func newAdminKeyRevokeCommand(env *Environment) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			client, err := adminClientFromEnv(envCtx)
+			// The key's tenant is only known when the key is stored in the config.
+			keyTenant := tenantForKeyPrefix(envCtx, args[0])
+			client, err := adminClientForTenant(envCtx, keyTenant)
 			if err != nil {
 				return err
 			}
+			var details []string
+			if keyTenant != "" {
+				details = append(details, "Tenant: "+keyTenant)
+			}
+			if err := confirmDestructive(cmd, envCtx, "revoke API key "+strings.TrimSpace(args[0]), details...); err != nil {
+				return err
+			}
 			if err := client.RevokeKey(cmd.Context(), strings.TrimSpace(args[0])); err != nil {
 				return err
 			}
+			forgetAuthStatuses(env, strings.TrimSpace(args[0]))
 			fmt.Fprintf(cmd.OutOrStdout(), "Revoked key with prefix %s\n", args[0])
 			return nil
 		},
 	}
+	bindSafeModeYes(cmd)
+	return cmd
 }
 
 func formatTime(t time.Time) string {
 	if t.IsZero() {
 		return "-"
 	}
-	return t.Local().Format("2006 Jan 02 03:04 PM")
+	return activeTimeDisplay.table(t)
 }
 
 func normalizeOptionalString(value string) *string {
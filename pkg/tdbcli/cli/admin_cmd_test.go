@@ -0,0 +1,66 @@
+package cli
+
+import "testing"
+
+func TestParseRateLimit(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{in: "600", want: 600},
+		{in: "600/m", want: 600},
+		{in: "10/s", want: 600},
+		{in: "36000/h", want: 600},
+		{in: "30/h", want: 1},
+		{in: "0/m", wantErr: true},
+		{in: "600/d", wantErr: true},
+		{in: "fast", wantErr: true},
+	}
+	for _, tc := range cases {
+		got, err := parseRateLimit(tc.in)
+		if tc.wantErr {
+			if err == nil {
+				t.Fatalf("%s: expected error", tc.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.in, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s: expected %d, got %d", tc.in, tc.want, got)
+		}
+	}
+}
+
+func TestBuildTenantLimitsRequest(t *testing.T) {
+	req, err := buildTenantLimitsRequest("600/m", "1,000,000", "50GB")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.RateLimitPerMinute == nil || *req.RateLimitPerMinute != 600 {
+		t.Fatalf("unexpected rate limit: %v", req.RateLimitPerMinute)
+	}
+	if req.RequestDailyLimit == nil || *req.RequestDailyLimit != 1000000 {
+		t.Fatalf("unexpected daily limit: %v", req.RequestDailyLimit)
+	}
+	if req.StorageBytesLimit == nil || *req.StorageBytesLimit != 50_000_000_000 {
+		t.Fatalf("unexpected storage limit: %v", req.StorageBytesLimit)
+	}
+
+	req, err = buildTenantLimitsRequest("", "", "2GiB")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.RateLimitPerMinute != nil || req.RequestDailyLimit != nil {
+		t.Fatalf("expected unset limits to stay nil, got %+v", req)
+	}
+	if *req.StorageBytesLimit != 2<<30 {
+		t.Fatalf("unexpected storage limit: %d", *req.StorageBytesLimit)
+	}
+
+	if _, err := buildTenantLimitsRequest("", "lots", ""); err == nil {
+		t.Fatalf("expected error for invalid daily requests")
+	}
+}
@@ -0,0 +1,217 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func newAdminKeyReassignCommand(env *Environment) *cobra.Command {
+	var tenantID string
+	var appID string
+	var tenantWide bool
+	var replace bool
+	var keepOld bool
+	var confirm bool
+
+	cmd := &cobra.Command{
+		Use:   "reassign <prefix>",
+		Short: "Move an API key to another application scope",
+		Long: `Re-scope an API key to another application (or, with --tenant-wide, to the
+whole tenant).
+
+The key is moved in place when the server supports it. Otherwise, or with
+--replace, a replacement key is created with the new scope, stored under every
+local alias that held the old key (keeping default-key settings), and the old
+key is revoked unless --keep-old is given. Without --confirm the plan is only
+printed.`,
+		Example: `  # Review the plan
+  tdb admin keys reassign tdb_ab12 --tenant tn_123 --app-id app_new
+
+  # Apply it
+  tdb admin keys reassign tdb_ab12 --tenant tn_123 --app-id app_new --confirm`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			prefix := strings.TrimSpace(args[0])
+			if prefix == "" {
+				return errors.New("key prefix cannot be empty")
+			}
+			target := normalizeOptionalString(appID)
+			if (target == nil) == !tenantWide {
+				return errors.New("specify exactly one of --app-id or --tenant-wide")
+			}
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenant, err := resolveTenantID(envCtx, tenantID)
+			if err != nil {
+				return err
+			}
+			client, err := adminClientForTenant(envCtx, tenant)
+			if err != nil {
+				return err
+			}
+			keys, err := client.ListKeys(cmd.Context(), tenant, nil)
+			if err != nil {
+				return err
+			}
+			var key *clientpkg.APIKey
+			for i := range keys {
+				if keys[i].Prefix == prefix {
+					key = &keys[i]
+					break
+				}
+			}
+			if key == nil {
+				return &ExitError{Code: ExitCodeNotFound, Err: fmt.Errorf("key %s not found in tenant %s", prefix, tenant)}
+			}
+			if key.RevokedAt != nil {
+				return fmt.Errorf("key %s is revoked", prefix)
+			}
+			from, to := keyScopeLabel(key.AppID), keyScopeLabel(target)
+			if from == to {
+				fmt.Fprintf(cmd.OutOrStdout(), "Key %s is already scoped to %s\n", prefix, to)
+				return nil
+			}
+			aliases := storedAliasesForPrefix(envCtx.Config, tenant, prefix)
+
+			out := cmd.OutOrStdout()
+			if !confirm {
+				fmt.Fprintf(out, "Key %s: %s -> %s\n", prefix, from, to)
+				if !replace {
+					fmt.Fprintln(out, "Will reassign the key in place, or fall back to the steps below if the server does not support it:")
+				}
+				fmt.Fprintf(out, "  1. Create a replacement key scoped to %s\n", to)
+				if len(aliases) > 0 {
+					fmt.Fprintf(out, "  2. Store it under local alias(es) %s\n", strings.Join(aliases, ", "))
+				} else {
+					fmt.Fprintln(out, "  2. Print it (no local alias holds the old key)")
+				}
+				if keepOld {
+					fmt.Fprintf(out, "  3. Keep %s active (--keep-old)\n", prefix)
+				} else {
+					fmt.Fprintf(out, "  3. Revoke %s\n", prefix)
+				}
+				fmt.Fprintln(out, "Re-run with --confirm to apply.")
+				return nil
+			}
+
+			if !replace {
+				updated, err := client.ReassignKey(cmd.Context(), prefix, target)
+				if err == nil {
+					for _, alias := range aliases {
+						entry := envCtx.Config.Tenants[tenant].Keys[alias]
+						entry.AppID = valueOrEmpty(updated.AppID)
+						if target != nil && entry.AppID == "" {
+							entry.AppID = *target
+						}
+						envCtx.Config.Tenants[tenant].Keys[alias] = entry
+					}
+					if len(aliases) > 0 {
+						if err := envCtx.Save(); err != nil {
+							return fmt.Errorf("key reassigned but failed to update stored aliases: %w", err)
+						}
+					}
+					fmt.Fprintf(out, "Reassigned key %s to %s\n", prefix, to)
+					return nil
+				}
+				if !isUnsupportedEndpointError(err) {
+					return err
+				}
+				fmt.Fprintln(cmd.ErrOrStderr(), "Server does not support in-place reassignment; creating a replacement key")
+			}
+
+			description := ""
+			if key.Description != nil {
+				description = *key.Description
+			}
+			req, desc := buildCreateKeyRequest(valueOrEmpty(target), description)
+			generated, err := client.GenerateKey(cmd.Context(), tenant, req)
+			if err != nil {
+				return fmt.Errorf("create replacement key: %w", err)
+			}
+			fmt.Fprintf(out, "Created replacement key %s scoped to %s\n", generated.Prefix, to)
+			if len(aliases) == 0 {
+				fmt.Fprintf(out, "Replacement key: %s\n", generated.APIKey)
+			} else {
+				for _, alias := range aliases {
+					entry := configpkg.APIKeyEntry{Key: generated.APIKey, Prefix: generated.Prefix, AppID: valueOrEmpty(target), Description: desc}
+					envCtx.Config.Tenants[tenant].Keys[alias] = entry
+				}
+				if err := envCtx.Save(); err != nil {
+					return fmt.Errorf("replacement key %s created but failed to store it (key: %s): %w", generated.Prefix, generated.APIKey, err)
+				}
+				fmt.Fprintf(out, "Stored replacement key as %s\n", strings.Join(aliases, ", "))
+			}
+			if keepOld {
+				fmt.Fprintf(out, "Kept %s active; revoke it with `tdb admin keys revoke %s` once clients have switched\n", prefix, prefix)
+				return nil
+			}
+			if err := client.RevokeKey(cmd.Context(), prefix); err != nil {
+				return fmt.Errorf("replacement key is in place but revoking %s failed: %w", prefix, err)
+			}
+			forgetAuthStatuses(env, prefix)
+			fmt.Fprintf(out, "Revoked key %s\n", prefix)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&tenantID, "tenant", "", "Tenant ID (defaults to your configured default tenant when omitted)")
+	cmd.Flags().StringVar(&appID, "app-id", "", "Application ID to scope the key to")
+	cmd.Flags().BoolVar(&tenantWide, "tenant-wide", false, "Remove the application scope so the key covers the whole tenant")
+	cmd.Flags().BoolVar(&replace, "replace", false, "Always create a replacement key instead of reassigning in place")
+	cmd.Flags().BoolVar(&keepOld, "keep-old", false, "Do not revoke the old key after creating a replacement")
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Apply the change (default only prints the plan)")
+
+	return cmd
+}
+
+// storedAliasesForPrefix returns the local aliases of a tenant whose stored
+// key has the given prefix.
+func storedAliasesForPrefix(cfg *configpkg.Config, tenantID, prefix string) []string {
+	if cfg == nil {
+		return nil
+	}
+	var aliases []string
+	for alias, entry := range cfg.Tenants[tenantID].Keys {
+		if entry.Prefix == prefix || (entry.Prefix == "" && strings.HasPrefix(entry.Key, prefix)) {
+			aliases = append(aliases, alias)
+		}
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+func keyScopeLabel(appID *string) string {
+	if appID == nil || strings.TrimSpace(*appID) == "" {
+		return "tenant"
+	}
+	return "app " + strings.TrimSpace(*appID)
+}
+
+func valueOrEmpty(ptr *string) string {
+	if ptr == nil {
+		return ""
+	}
+	return strings.TrimSpace(*ptr)
+}
+
+// isUnsupportedEndpointError reports whether err means the server does not
+// implement the endpoint (404 or 405).
+func isUnsupportedEndpointError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if isNotFoundError(err) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "405") || strings.Contains(msg, "method not allowed")
+}
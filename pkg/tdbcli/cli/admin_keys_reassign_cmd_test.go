@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestAdminKeyReassign(t *testing.T) {
+	oldApp := "app_old"
+	desc := "ingest worker"
+	inPlace := false
+	var generated []clientpkg.CreateAPIKeyRequest
+	var patched []map[string]any
+	var revoked []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/tenants/tn_1/keys":
+			_ = json.NewEncoder(w).Encode([]clientpkg.APIKey{{Prefix: "tdb_old", TenantID: "tn_1", AppID: &oldApp, Description: &desc}})
+		case r.Method == http.MethodPatch && r.URL.Path == "/admin/keys/tdb_old":
+			if !inPlace {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			patched = append(patched, body)
+			app, _ := body["app_id"].(string)
+			_ = json.NewEncoder(w).Encode(clientpkg.APIKey{Prefix: "tdb_old", AppID: &app})
+		case r.Method == http.MethodPost && r.URL.Path == "/admin/tenants/tn_1/keys":
+			var req clientpkg.CreateAPIKeyRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			generated = append(generated, req)
+			_ = json.NewEncoder(w).Encode(clientpkg.GeneratedKey{APIKey: "tdb_newsecret", Prefix: "tdb_new", AppID: req.AppID})
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/admin/keys/"):
+			revoked = append(revoked, strings.TrimPrefix(r.URL.Path, "/admin/keys/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	newEnv := func() *Environment {
+		return &Environment{
+			ConfigPath: filepath.Join(t.TempDir(), "config.yaml"),
+			Config: &configpkg.Config{
+				Endpoint:    srv.URL,
+				AdminSecret: "secret",
+				Tenants: map[string]configpkg.TenantConfig{
+					"tn_1": {DefaultKey: "worker", Keys: map[string]configpkg.APIKeyEntry{
+						"worker": {Key: "tdb_oldsecret", Prefix: "tdb_old", AppID: oldApp},
+						"other":  {Key: "tdb_othersecret", Prefix: "tdb_oth"},
+					}},
+				},
+			},
+		}
+	}
+	run := func(env *Environment, args ...string) (string, error) {
+		cmd := newAdminKeyReassignCommand(env)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetArgs(append([]string{"tdb_old", "--tenant", "tn_1"}, args...))
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&bytes.Buffer{})
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	env := newEnv()
+	out, err := run(env, "--app-id", "app_new")
+	if err != nil {
+		t.Fatalf("plan failed: %v", err)
+	}
+	if !strings.Contains(out, "app app_old -> app app_new") || !strings.Contains(out, "alias(es) worker") || len(generated)+len(revoked) != 0 {
+		t.Fatalf("unexpected plan:\n%s", out)
+	}
+
+	out, err = run(env, "--app-id", "app_new", "--confirm")
+	if err != nil {
+		t.Fatalf("reassign failed: %v\n%s", err, out)
+	}
+	if len(generated) != 1 || *generated[0].AppID != "app_new" || *generated[0].Description != desc {
+		t.Fatalf("unexpected replacement request %+v", generated)
+	}
+	entry := env.Config.Tenants["tn_1"].Keys["worker"]
+	if entry.Key != "tdb_newsecret" || entry.Prefix != "tdb_new" || entry.AppID != "app_new" || env.Config.Tenants["tn_1"].DefaultKey != "worker" {
+		t.Fatalf("alias not updated: %+v", entry)
+	}
+	if env.Config.Tenants["tn_1"].Keys["other"].Key != "tdb_othersecret" {
+		t.Fatal("unrelated alias changed")
+	}
+	if len(revoked) != 1 || revoked[0] != "tdb_old" {
+		t.Fatalf("expected old key revoked, got %v", revoked)
+	}
+
+	inPlace = true
+	env = newEnv()
+	if _, err := run(env, "--app-id", "app_new", "--confirm"); err != nil {
+		t.Fatalf("in-place reassign failed: %v", err)
+	}
+	if len(patched) != 1 || patched[0]["app_id"] != "app_new" || len(generated) != 1 || len(revoked) != 1 {
+		t.Fatalf("expected only an in-place update, got patched=%v generated=%d revoked=%v", patched, len(generated), revoked)
+	}
+	if entry := env.Config.Tenants["tn_1"].Keys["worker"]; entry.Key != "tdb_oldsecret" || entry.AppID != "app_new" {
+		t.Fatalf("alias scope not updated: %+v", entry)
+	}
+
+	if _, err := run(newEnv(), "--confirm"); err == nil || !strings.Contains(err.Error(), "exactly one of --app-id or --tenant-wide") {
+		t.Fatalf("expected scope flag error, got %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// keyRevocationResult is the outcome of revoking one key with revoke-many.
+type keyRevocationResult struct {
+	Prefix      string     `json:"prefix"`
+	Description string     `json:"description,omitempty"`
+	AppID       string     `json:"app_id,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+}
+
+func newAdminKeyRevokeManyCommand(env *Environment) *cobra.Command {
+	var tenantID string
+	var appID string
+	var olderThan string
+	var unusedFor string
+	var confirm bool
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "revoke-many",
+		Short: "Revoke API keys matching age and usage filters",
+		Long: `List a tenant's active API keys that match every given filter and, with
+--confirm, revoke them one by one with a per-key result.
+
+--older-than matches keys created before the cutoff. --unused-for matches keys
+not used since the cutoff, including keys created before it that were never
+used. Without --confirm the matching keys are only listed.`,
+		Example: `  # Review keys older than 180 days that have not been used for 90 days
+  tdb admin keys revoke-many --tenant tn_123 --older-than 180d --unused-for 90d
+
+  # Revoke them for one application
+  tdb admin keys revoke-many --tenant tn_123 --app-id app_456 --older-than 180d --unused-for 90d --confirm`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(olderThan) == "" && strings.TrimSpace(unusedFor) == "" {
+				return errors.New("specify --older-than and/or --unused-for")
+			}
+			now := time.Now().UTC()
+			var createdBefore, usedBefore time.Time
+			if strings.TrimSpace(olderThan) != "" {
+				d, err := parseFlexibleDurationArg(olderThan)
+				if err != nil || d <= 0 {
+					return fmt.Errorf("invalid --older-than %q", olderThan)
+				}
+				createdBefore = now.Add(-d)
+			}
+			if strings.TrimSpace(unusedFor) != "" {
+				d, err := parseFlexibleDurationArg(unusedFor)
+				if err != nil || d <= 0 {
+					return fmt.Errorf("invalid --unused-for %q", unusedFor)
+				}
+				usedBefore = now.Add(-d)
+			}
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenant, err := resolveTenantID(envCtx, tenantID)
+			if err != nil {
+				return err
+			}
+			client, err := adminClientForTenant(envCtx, tenant)
+			if err != nil {
+				return err
+			}
+			keys, err := client.ListKeys(cmd.Context(), tenant, normalizeOptionalString(appID))
+			if err != nil {
+				return err
+			}
+			selected := selectKeysForRevocation(keys, createdBefore, usedBefore)
+			results := make([]keyRevocationResult, 0, len(selected))
+			for _, key := range selected {
+				result := keyRevocationResult{
+					Prefix:     key.Prefix,
+					CreatedAt:  key.CreatedAt,
+					LastUsedAt: key.LastUsedAt,
+					Status:     "pending",
+				}
+				if key.Description != nil {
+					result.Description = strings.TrimSpace(*key.Description)
+				}
+				if key.AppID != nil {
+					result.AppID = strings.TrimSpace(*key.AppID)
+				}
+				results = append(results, result)
+			}
+
+			failed := 0
+			if confirm && len(results) > 0 {
+				prefixes := make([]string, 0, len(results))
+				for _, result := range results {
+					prefixes = append(prefixes, result.Prefix)
+				}
+				if err := confirmDestructive(cmd, envCtx, fmt.Sprintf("revoke %d API keys of tenant %s", len(results), tenant), prefixes...); err != nil {
+					return err
+				}
+				for i := range results {
+					if err := client.RevokeKey(cmd.Context(), results[i].Prefix); err != nil {
+						results[i].Status = "failed"
+						results[i].Error = err.Error()
+						failed++
+						continue
+					}
+					results[i].Status = "revoked"
+					forgetAuthStatuses(env, results[i].Prefix)
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			if raw {
+				if err := printJSON(cmd, results); err != nil {
+					return err
+				}
+			} else if len(results) == 0 {
+				fmt.Fprintf(out, "No active keys in tenant %s match the filters\n", tenant)
+			} else {
+				rows := make([][]string, 0, len(results))
+				for _, result := range results {
+					status := strings.ToUpper(result.Status)
+					if result.Error != "" {
+						status += ": " + result.Error
+					}
+					rows = append(rows, []string{
+						result.Prefix,
+						valueOrDash(result.Description),
+						valueOrDash(result.AppID),
+						formatCreatedWithAge(result.CreatedAt),
+						formatRelativeTimePtr(result.LastUsedAt, "never"),
+						status,
+					})
+				}
+				renderTable(cmd, []string{"PREFIX", "DESCRIPTION", "APP", "CREATED", "LAST USED", "STATUS"}, rows)
+				if confirm {
+					fmt.Fprintf(out, "\nRevoked %d of %d matching keys\n", len(results)-failed, len(results))
+				} else {
+					fmt.Fprintf(out, "\n%d key(s) match. Re-run with --confirm to revoke them.\n", len(results))
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d keys failed to revoke", failed, len(results))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&tenantID, "tenant", "", "Tenant ID (defaults to your configured default tenant when omitted)")
+	cmd.Flags().StringVar(&appID, "app-id", "", "Only consider keys of this application ID")
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "Only keys created more than this long ago (e.g. 180d, 4320h)")
+	cmd.Flags().StringVar(&unusedFor, "unused-for", "", "Only keys not used for this long (e.g. 90d); never-used keys count from creation")
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Revoke the matching keys (default only lists them)")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the per-key results as JSON")
+	bindSafeModeYes(cmd)
+
+	return cmd
+}
+
+// selectKeysForRevocation returns the active keys created before createdBefore
+// and last used (or, if never used, created) before usedBefore, oldest first.
+// A zero cutoff disables that filter.
+func selectKeysForRevocation(keys []clientpkg.APIKey, createdBefore, usedBefore time.Time) []clientpkg.APIKey {
+	var selected []clientpkg.APIKey
+	for _, key := range keys {
+		if key.RevokedAt != nil {
+			continue
+		}
+		if !createdBefore.IsZero() && !key.CreatedAt.Before(createdBefore) {
+			continue
+		}
+		if !usedBefore.IsZero() {
+			lastActivity := key.CreatedAt
+			if key.LastUsedAt != nil {
+				lastActivity = *key.LastUsedAt
+			}
+			if !lastActivity.Before(usedBefore) {
+				continue
+			}
+		}
+		selected = append(selected, key)
+	}
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].CreatedAt.Before(selected[j].CreatedAt)
+	})
+	return selected
+}
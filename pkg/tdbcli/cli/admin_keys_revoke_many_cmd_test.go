@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestSelectKeysForRevocation(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(d int) time.Time { return now.AddDate(0, 0, -d) }
+	ptr := func(v time.Time) *time.Time { return &v }
+	keys := []clientpkg.APIKey{
+		{Prefix: "recent", CreatedAt: daysAgo(10)},
+		{Prefix: "old-active", CreatedAt: daysAgo(400), LastUsedAt: ptr(daysAgo(1))},
+		{Prefix: "old-idle", CreatedAt: daysAgo(300), LastUsedAt: ptr(daysAgo(120))},
+		{Prefix: "old-never", CreatedAt: daysAgo(200)},
+		{Prefix: "old-revoked", CreatedAt: daysAgo(500), RevokedAt: ptr(daysAgo(5))},
+	}
+	prefixes := func(keys []clientpkg.APIKey) string {
+		out := make([]string, 0, len(keys))
+		for _, key := range keys {
+			out = append(out, key.Prefix)
+		}
+		return strings.Join(out, ",")
+	}
+
+	if got := prefixes(selectKeysForRevocation(keys, daysAgo(180), daysAgo(90))); got != "old-idle,old-never" {
+		t.Fatalf("unexpected selection with both filters: %s", got)
+	}
+	if got := prefixes(selectKeysForRevocation(keys, daysAgo(180), time.Time{})); got != "old-active,old-idle,old-never" {
+		t.Fatalf("unexpected selection by age: %s", got)
+	}
+	if got := prefixes(selectKeysForRevocation(keys, time.Time{}, daysAgo(90))); got != "old-idle,old-never" {
+		t.Fatalf("unexpected selection by usage: %s", got)
+	}
+}
+
+func TestAdminKeysRevokeMany(t *testing.T) {
+	created := time.Now().UTC().AddDate(-1, 0, 0)
+	keys := []clientpkg.APIKey{
+		{Prefix: "tdb_old1", TenantID: "tn_1", Scope: "tenant", CreatedAt: created},
+		{Prefix: "tdb_old2", TenantID: "tn_1", Scope: "tenant", CreatedAt: created.Add(time.Hour)},
+		{Prefix: "tdb_new", TenantID: "tn_1", Scope: "tenant", CreatedAt: time.Now().UTC()},
+	}
+	var mu sync.Mutex
+	var revoked []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/tenants/tn_1/keys":
+			_ = json.NewEncoder(w).Encode(keys)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/admin/keys/"):
+			prefix := strings.TrimPrefix(r.URL.Path, "/admin/keys/")
+			if prefix == "tdb_old2" {
+				http.Error(w, "key is locked", http.StatusConflict)
+				return
+			}
+			mu.Lock()
+			revoked = append(revoked, prefix)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	run := func(args ...string) (string, error) {
+		env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL, AdminSecret: "secret"}}
+		cmd := newAdminKeyRevokeManyCommand(env)
+		cmd.SilenceUsage = true
+		cmd.SetArgs(append([]string{"--tenant", "tn_1", "--older-than", "180d"}, args...))
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&bytes.Buffer{})
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	out, err := run()
+	if err != nil {
+		t.Fatalf("preview failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "2 key(s) match") || strings.Contains(out, "tdb_new") || len(revoked) != 0 {
+		t.Fatalf("unexpected preview (revoked %v):\n%s", revoked, out)
+	}
+
+	out, err = run("--confirm", "--raw")
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 keys failed") {
+		t.Fatalf("expected partial failure, got %v\n%s", err, out)
+	}
+	var results []keyRevocationResult
+	if err := json.Unmarshal([]byte(out), &results); err != nil {
+		t.Fatalf("decode results: %v\n%s", err, out)
+	}
+	if len(results) != 2 || results[0].Status != "revoked" || results[1].Status != "failed" || !strings.Contains(results[1].Error, "locked") {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if len(revoked) != 1 || revoked[0] != "tdb_old1" {
+		t.Fatalf("unexpected revocations: %v", revoked)
+	}
+
+	if _, err := run("--older-than", ""); err == nil {
+		t.Fatalf("expected an error without filters")
+	}
+}
@@ -0,0 +1,288 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const adminReportKeyDescription = "tdb admin report (temporary)"
+
+// tenantReportResult holds one tenant's report rows or the reason it failed.
+type tenantReportResult struct {
+	tenant clientpkg.Tenant
+	rows   []map[string]any
+	err    error
+}
+
+func newAdminReportCommand(env *Environment) *cobra.Command {
+	var queryFile string
+	var tenants string
+	var collection string
+	var limit int
+	var format string
+	var outPath string
+	var concurrency int
+	var noMint bool
+
+	cmd := &cobra.Command{
+		Use:   "report --query-file <body.json> --collection <name>",
+		Short: "Run the same report query across many tenants",
+		Long: `Execute one report query (the same JSON body accepted by
+"tdb tenant documents report --file") against several tenants and merge the
+rows, prefixing each with tenant_id and tenant_name columns.
+
+Each tenant is queried with its default key stored in the config. Tenants
+without a stored key, or whose stored key is rejected (HTTP 401/403), get a
+temporary key minted with the admin secret, which is revoked once its query
+finishes; use --no-mint to skip those tenants instead. Other failures are
+reported as they are, without minting.
+A tenant whose query fails is reported on stderr and the others are still
+merged; the command then exits non-zero.`,
+		Example: `  # Events per type across every tenant, as CSV
+  tdb admin report --query-file events-by-type.json --tenants all --collection events --format csv > events.csv
+
+  # Two tenants, JSON output
+  tdb admin report --query-file body.json --tenants tn_1,tn_2 --collection orders --format json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(collection) == "" {
+				return errors.New("--collection is required")
+			}
+			if strings.TrimSpace(queryFile) == "" {
+				return errors.New("--query-file is required")
+			}
+			switch format {
+			case "table", "csv", "json":
+			default:
+				return fmt.Errorf("unsupported --format %q (supported: table, csv, json)", format)
+			}
+			if concurrency < 1 {
+				concurrency = 1
+			}
+			body, err := readAdminReportBody(queryFile)
+			if err != nil {
+				return err
+			}
+			admin, err := adminClientFromEnv(envCtx)
+			if err != nil {
+				return err
+			}
+			all, err := admin.ListTenants(cmd.Context())
+			if err != nil {
+				return err
+			}
+			selected, err := selectReportTenants(all, tenants)
+			if err != nil {
+				return err
+			}
+
+			results := make([]tenantReportResult, len(selected))
+			var wg sync.WaitGroup
+			sem := make(chan struct{}, concurrency)
+			for i, tenant := range selected {
+				wg.Add(1)
+				go func(i int, tenant clientpkg.Tenant) {
+					defer wg.Done()
+					sem <- struct{}{}
+					defer func() { <-sem }()
+					rows, err := runTenantReport(cmd.Context(), cmd.ErrOrStderr(), envCtx, tenant.ID, !noMint, clientpkg.ReportQueryParams{
+						Collection: collection,
+						Limit:      limit,
+						Body:       cloneReportBody(body),
+					})
+					results[i] = tenantReportResult{tenant: tenant, rows: rows, err: err}
+				}(i, tenant)
+			}
+			wg.Wait()
+
+			var merged []map[string]any
+			failed := 0
+			for _, result := range results {
+				if result.err != nil {
+					failed++
+					fmt.Fprintf(cmd.ErrOrStderr(), "warning: tenant %s: %v\n", result.tenant.ID, result.err)
+					continue
+				}
+				for _, row := range result.rows {
+					tagged := make(map[string]any, len(row)+2)
+					for key, value := range row {
+						tagged[key] = value
+					}
+					tagged["tenant_id"] = result.tenant.ID
+					tagged["tenant_name"] = result.tenant.Name
+					merged = append(merged, tagged)
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			if strings.TrimSpace(outPath) != "" {
+				f, err := os.Create(outPath)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				out = f
+			}
+			if err := writeAdminReport(cmd, out, format, merged); err != nil {
+				return err
+			}
+			if failed > 0 {
+				return fmt.Errorf("report failed for %d of %d tenants", failed, len(selected))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&queryFile, "query-file", "", "Path to the report query JSON body")
+	cmd.Flags().StringVar(&tenants, "tenants", "all", "Tenants to query: all, or a comma-separated list of tenant IDs or names")
+	cmd.Flags().StringVar(&collection, "collection", "", "Collection to report on in every tenant")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Override the row limit per tenant")
+	cmd.Flags().StringVar(&format, "format", "table", "Output format: table, csv, or json")
+	cmd.Flags().StringVar(&outPath, "out", "", "Write the merged result to a file instead of stdout")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 4, "Number of tenants queried in parallel")
+	cmd.Flags().BoolVar(&noMint, "no-mint", false, "Skip tenants without a stored API key instead of minting a temporary one")
+	return cmd
+}
+
+func readAdminReportBody(path string) (map[string]any, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var body map[string]any
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return nil, fmt.Errorf("invalid report query payload in %s: %w", path, err)
+	}
+	return body, nil
+}
+
+// cloneReportBody gives each tenant its own top-level map, since ReportQuery
+// callers may add keys to it.
+func cloneReportBody(body map[string]any) map[string]any {
+	clone := make(map[string]any, len(body))
+	for key, value := range body {
+		clone[key] = value
+	}
+	return clone
+}
+
+// selectReportTenants resolves --tenants against the admin tenant list,
+// matching IDs or names.
+func selectReportTenants(all []clientpkg.Tenant, spec string) ([]clientpkg.Tenant, error) {
+	if strings.EqualFold(strings.TrimSpace(spec), "all") {
+		if len(all) == 0 {
+			return nil, errors.New("no tenants found")
+		}
+		return all, nil
+	}
+	refs := splitCommaList(spec)
+	if len(refs) == 0 {
+		return nil, errors.New("--tenants is required (all or a comma-separated list)")
+	}
+	selected := make([]clientpkg.Tenant, 0, len(refs))
+	for _, ref := range refs {
+		found := false
+		for _, tenant := range all {
+			if tenant.ID == ref || strings.EqualFold(tenant.Name, ref) {
+				selected = append(selected, tenant)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("tenant %q not found", ref)
+		}
+	}
+	return selected, nil
+}
+
+// runTenantReport runs the query as one tenant with its stored key. Only when
+// the tenant has no stored key, or the server rejects it with 401/403, is a
+// temporary admin-minted key used instead; it is revoked afterwards. Any other
+// failure is returned as is.
+func runTenantReport(ctx context.Context, stderr io.Writer, envCtx *Environment, tenantID string, mint bool, params clientpkg.ReportQueryParams) ([]map[string]any, error) {
+	endpoint, err := ensureEndpoint(envCtx)
+	if err != nil {
+		return nil, err
+	}
+	tenantClient, _, keyErr := tenantClientFromEnv(envCtx, tenantID, "", "")
+	if keyErr == nil {
+		resp, err := tenantClient.ReportQuery(ctx, params)
+		if err == nil {
+			return resp.Data, nil
+		}
+		var authErr *clientpkg.AuthError
+		if !mint || !errors.As(err, &authErr) {
+			return nil, err
+		}
+		fmt.Fprintf(stderr, "warning: tenant %s: stored key was rejected (HTTP %d); using a temporary key\n", tenantID, authErr.StatusCode)
+	} else if !mint {
+		return nil, fmt.Errorf("no stored API key (%v)", keyErr)
+	}
+	// Minting is a write, refused for read-only tenants like any other.
+	admin, err := adminClientForTenant(envCtx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	generated, err := admin.GenerateKey(ctx, tenantID, clientpkg.CreateAPIKeyRequest{Description: normalizeOptionalString(adminReportKeyDescription)})
+	if err != nil {
+		return nil, fmt.Errorf("mint temporary key: %w", err)
+	}
+	defer func() {
+		if err := admin.RevokeKey(context.WithoutCancel(ctx), generated.Prefix); err != nil {
+			fmt.Fprintf(stderr, "warning: tenant %s: revoke temporary key %s: %v\n", tenantID, generated.Prefix, err)
+		}
+	}()
+	if tenantClient, err = clientpkg.NewTenantClient(endpoint, generated.APIKey, clientOptions()...); err != nil {
+		return nil, err
+	}
+	resp, err := tenantClient.ReportQuery(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func writeAdminReport(cmd *cobra.Command, out io.Writer, format string, rows []map[string]any) error {
+	if format == "json" {
+		if rows == nil {
+			rows = []map[string]any{}
+		}
+		encoded, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(encoded))
+		return err
+	}
+	if len(rows) == 0 && format == "table" {
+		fmt.Fprintln(out, "No rows returned")
+		return nil
+	}
+	headers := []string{"tenant_id", "tenant_name"}
+	for _, column := range savedQueryResultColumns(rows) {
+		if column != "tenant_id" && column != "tenant_name" {
+			headers = append(headers, column)
+		}
+	}
+	style := tableStyleFor(cmd)
+	if format == "csv" {
+		style = tableStyleCSV
+	}
+	renderTableStyle(out, style, headers, savedQueryResultRows(rows, headers))
+	return nil
+}
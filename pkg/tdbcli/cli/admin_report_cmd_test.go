@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestAdminReportFansInAcrossTenants(t *testing.T) {
+	keyTenants := map[string]string{"tdb_stored": "tn_1"}
+	var mu sync.Mutex
+	var revoked []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/tenants":
+			_ = json.NewEncoder(w).Encode([]clientpkg.Tenant{{ID: "tn_1", Name: "Acme"}, {ID: "tn_2", Name: "Globex"}, {ID: "tn_3", Name: "Initech"}})
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/admin/tenants/"):
+			tenantID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/admin/tenants/"), "/keys")
+			key := "tdb_minted_" + tenantID
+			keyTenants[key] = tenantID
+			_ = json.NewEncoder(w).Encode(clientpkg.GeneratedKey{APIKey: key, Prefix: "pfx_" + tenantID})
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/admin/keys/"):
+			revoked = append(revoked, strings.TrimPrefix(r.URL.Path, "/admin/keys/"))
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/query":
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["collection"] != "events" || body["groupBy"] == nil {
+				http.Error(w, "unexpected body", http.StatusBadRequest)
+				return
+			}
+			switch keyTenants[r.Header.Get("X-API-Key")] {
+			case "tn_1":
+				_ = json.NewEncoder(w).Encode(clientpkg.ReportQueryResponse{Data: []map[string]any{{"type": "login", "count": 3}}})
+			case "tn_2":
+				_ = json.NewEncoder(w).Encode(clientpkg.ReportQueryResponse{Data: []map[string]any{{"type": "login", "count": 5}, {"type": "signup", "count": 1}}})
+			default:
+				http.Error(w, "collection events not found", http.StatusNotFound)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	queryFile := filepath.Join(dir, "body.json")
+	if err := os.WriteFile(queryFile, []byte(`{"groupBy":["type"],"aggregate":[{"operation":"count"}]}`), 0o600); err != nil {
+		t.Fatalf("write query: %v", err)
+	}
+	run := func(args ...string) (string, string, error) {
+		env := &Environment{Config: &configpkg.Config{
+			Endpoint:    srv.URL,
+			AdminSecret: "secret",
+			Tenants: map[string]configpkg.TenantConfig{
+				"tn_1": {DefaultKey: "main", Keys: map[string]configpkg.APIKeyEntry{"main": {Key: "tdb_stored"}}},
+			},
+		}}
+		cmd := newAdminReportCommand(env)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetArgs(append([]string{"--query-file", queryFile, "--collection", "events"}, args...))
+		var out, stderr bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&stderr)
+		err := cmd.Execute()
+		return out.String(), stderr.String(), err
+	}
+
+	out, _, err := run("--tenants", "tn_1,Globex", "--format", "csv")
+	if err != nil {
+		t.Fatalf("report failed: %v", err)
+	}
+	want := "tenant_id,tenant_name,count,type\ntn_1,Acme,3,login\ntn_2,Globex,5,login\ntn_2,Globex,1,signup\n"
+	if out != want {
+		t.Fatalf("unexpected CSV:\n%s\nwant:\n%s", out, want)
+	}
+	if len(revoked) != 1 || revoked[0] != "pfx_tn_2" {
+		t.Fatalf("expected only the minted key to be revoked, got %v", revoked)
+	}
+
+	out, stderr, err := run("--tenants", "all", "--format", "json", "--no-mint")
+	if err == nil || !strings.Contains(err.Error(), "report failed for 2 of 3 tenants") {
+		t.Fatalf("expected partial failure, got %v", err)
+	}
+	if !strings.Contains(stderr, "tenant tn_2: no stored API key") {
+		t.Fatalf("expected a warning for skipped tenants, got %q", stderr)
+	}
+	var rows []map[string]any
+	if err := json.Unmarshal([]byte(out), &rows); err != nil || len(rows) != 1 || rows[0]["tenant_id"] != "tn_1" {
+		t.Fatalf("unexpected JSON rows %s (%v)", out, err)
+	}
+
+	if _, _, err := run("--tenants", "tn_9"); err == nil || !strings.Contains(err.Error(), `tenant "tn_9" not found`) {
+		t.Fatalf("expected unknown tenant error, got %v", err)
+	}
+}
+
+func TestAdminReportMintsOnlyWhenStoredKeyIsRejected(t *testing.T) {
+	var mu sync.Mutex
+	var minted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/tenants":
+			_ = json.NewEncoder(w).Encode([]clientpkg.Tenant{{ID: "tn_1", Name: "Acme"}, {ID: "tn_2", Name: "Globex"}})
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/admin/tenants/"):
+			tenantID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/admin/tenants/"), "/keys")
+			minted = append(minted, tenantID)
+			_ = json.NewEncoder(w).Encode(clientpkg.GeneratedKey{APIKey: "tdb_minted", Prefix: "pfx_" + tenantID})
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/admin/keys/"):
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/query":
+			switch r.Header.Get("X-API-Key") {
+			case "tdb_minted":
+				_ = json.NewEncoder(w).Encode(clientpkg.ReportQueryResponse{Data: []map[string]any{{"count": 1}}})
+			case "tdb_revoked":
+				http.Error(w, "invalid api key", http.StatusUnauthorized)
+			default:
+				http.Error(w, "database unavailable", http.StatusInternalServerError)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	queryFile := filepath.Join(t.TempDir(), "body.json")
+	if err := os.WriteFile(queryFile, []byte(`{"groupBy":["type"]}`), 0o600); err != nil {
+		t.Fatalf("write query: %v", err)
+	}
+	env := &Environment{Config: &configpkg.Config{
+		Endpoint:    srv.URL,
+		AdminSecret: "secret",
+		Tenants: map[string]configpkg.TenantConfig{
+			"tn_1": {DefaultKey: "main", Keys: map[string]configpkg.APIKeyEntry{"main": {Key: "tdb_revoked"}}},
+			"tn_2": {DefaultKey: "main", Keys: map[string]configpkg.APIKeyEntry{"main": {Key: "tdb_flaky"}}},
+		},
+	}}
+	cmd := newAdminReportCommand(env)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	cmd.SetArgs([]string{"--query-file", queryFile, "--collection", "events", "--tenants", "all", "--format", "json"})
+	var out, stderr bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&stderr)
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "report failed for 1 of 2 tenants") {
+		t.Fatalf("expected the server error to fail one tenant, got %v", err)
+	}
+	if len(minted) != 1 || minted[0] != "tn_1" {
+		t.Fatalf("expected a key to be minted only for the rejected tenant, got %v", minted)
+	}
+	if !strings.Contains(stderr.String(), "tenant tn_1: stored key was rejected (HTTP 401)") || !strings.Contains(stderr.String(), "database unavailable") {
+		t.Fatalf("unexpected warnings %q", stderr.String())
+	}
+}
@@ -0,0 +1,288 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const tenantExportManifestFile = "manifest.json"
+
+// tenantExportManifest describes a full tenant export written before deletion.
+type tenantExportManifest struct {
+	TenantID    string                `json:"tenant_id"`
+	ExportedAt  time.Time             `json:"exported_at"`
+	Collections []tenantExportedTable `json:"collections"`
+}
+
+// tenantExportedTable records one collection file of a tenant export.
+type tenantExportedTable struct {
+	Collection   string `json:"collection"`
+	CollectionID string `json:"collection_id"`
+	AppID        string `json:"app_id,omitempty"`
+	Deleted      bool   `json:"deleted,omitempty"`
+	File         string `json:"file"`
+	Documents    int    `json:"documents"`
+	SHA256       string `json:"sha256"`
+}
+
+func newAdminTenantDeleteCommand(env *Environment) *cobra.Command {
+	var exportDir string
+	var keyAlias string
+	var apiKey string
+	var confirm bool
+
+	cmd := &cobra.Command{
+		Use:   "delete <tenant-id>",
+		Short: "Delete a tenant after exporting and verifying all of its data",
+		Long: `Permanently delete a tenant. Before anything is deleted, every collection of the
+tenant, including soft-deleted collections and documents, is exported to
+--export-first as one JSONL file per collection plus a manifest.json with
+document counts and SHA-256 checksums. The live documents exported from each
+collection must match the count the server reports, and each file is read back
+and checked against the manifest; the tenant is only deleted when the export
+verifies.
+
+The export uses the tenant's stored API key (or --key / --api-key), which must
+not be scoped to an application, and the deletion uses the admin secret.`,
+		Example: `  # Decommission a tenant, keeping a verified export
+  tdb admin tenants delete tn_123 --export-first dump/tn_123 --confirm`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tenantID := strings.TrimSpace(args[0])
+			if tenantID == "" {
+				return errors.New("tenant id cannot be empty")
+			}
+			if strings.TrimSpace(exportDir) == "" {
+				return errors.New("--export-first is required; tenants are only deleted after a verified export")
+			}
+			if !confirm {
+				return errors.New("use --confirm to acknowledge irreversible tenant deletion")
+			}
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			if tenantReadOnly(envCtx, tenantID) {
+				// Refuse before the export rather than after it.
+				return &clientpkg.ReadOnlyError{Method: http.MethodDelete, Path: "/admin/tenants/" + tenantID}
+			}
+			admin, err := adminClientForTenant(envCtx, tenantID)
+			if err != nil {
+				return err
+			}
+			tenants, err := admin.ListTenants(cmd.Context())
+			if err != nil {
+				return err
+			}
+			var tenant *clientpkg.Tenant
+			for i := range tenants {
+				if tenants[i].ID == tenantID {
+					tenant = &tenants[i]
+					break
+				}
+			}
+			if tenant == nil {
+				return fmt.Errorf("tenant %s not found", tenantID)
+			}
+			tenantClient, _, err := tenantClientFromEnv(envCtx, tenantID, strings.TrimSpace(keyAlias), strings.TrimSpace(apiKey))
+			if err != nil {
+				return fmt.Errorf("tenant credentials are needed for the export: %w", err)
+			}
+			// An application key only sees that application's collections.
+			status, err := tenantClient.AuthStatus(cmd.Context(), "")
+			if err != nil {
+				return fmt.Errorf("check the export key: %w", err)
+			}
+			if appID := strings.TrimSpace(status.AppID); appID != "" {
+				return fmt.Errorf("the export key is scoped to application %s; use a tenant-wide key so every collection is exported", appID)
+			}
+
+			dir := filepath.Clean(strings.TrimSpace(exportDir))
+			if err := ensureEmptyDir(dir); err != nil {
+				return err
+			}
+			manifest, err := exportTenant(cmd.Context(), cmd.ErrOrStderr(), tenantClient, tenantID, dir)
+			if err != nil {
+				return fmt.Errorf("export failed; tenant not deleted: %w", err)
+			}
+			if err := verifyTenantExport(dir, manifest); err != nil {
+				return fmt.Errorf("export verification failed; tenant not deleted: %w", err)
+			}
+			documents := 0
+			for _, table := range manifest.Collections {
+				documents += table.Documents
+			}
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Exported and verified %d documents from %d collection(s) to %s\n", documents, len(manifest.Collections), dir)
+			if err := confirmDestructive(cmd, envCtx, fmt.Sprintf("delete tenant %s (%s)", tenant.Name, tenant.ID), fmt.Sprintf("Collections: %d", len(manifest.Collections)), fmt.Sprintf("Documents: %d", documents)); err != nil {
+				return err
+			}
+
+			if err := admin.DeleteTenant(cmd.Context(), tenantID); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Deleted tenant %s (%s)\n", tenant.Name, tenant.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&exportDir, "export-first", "", "Empty directory to export the tenant's collections to before deleting (required)")
+	cmd.Flags().StringVar(&keyAlias, "key", "", "Stored key alias used for the export")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "Raw tenant API key used for the export (overrides stored keys)")
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm irreversible tenant deletion")
+	bindSafeModeYes(cmd)
+
+	return cmd
+}
+
+// ensureEmptyDir creates dir, refusing to reuse one that already has entries
+// so an export is never mixed with older files.
+func ensureEmptyDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err == nil && len(entries) > 0 {
+		return fmt.Errorf("export directory %s is not empty", dir)
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return os.MkdirAll(dir, 0o755)
+}
+
+// exportTenant writes every collection of the tenant, including soft-deleted
+// collections and documents, to dir and records the files in manifest.json.
+func exportTenant(ctx context.Context, progress io.Writer, tenantClient *clientpkg.TenantClient, tenantID, dir string) (*tenantExportManifest, error) {
+	collections, err := tenantClient.ListCollectionsWithParams(ctx, clientpkg.ListCollectionsParams{IncludeDeleted: true})
+	if err != nil {
+		return nil, fmt.Errorf("list collections: %w", err)
+	}
+	manifest := &tenantExportManifest{TenantID: tenantID, ExportedAt: time.Now().UTC()}
+	for _, col := range collections {
+		table := tenantExportedTable{Collection: col.Name, CollectionID: col.ID, Deleted: col.DeletedAt != nil}
+		if col.AppID != nil {
+			table.AppID = strings.TrimSpace(*col.AppID)
+		}
+		table.File = tenantExportFileName(col, table.AppID)
+		if err := exportTenantCollection(ctx, tenantClient, dir, &table); err != nil {
+			return nil, fmt.Errorf("collection %s: %w", col.Name, err)
+		}
+		fmt.Fprintf(progress, "Exported %d documents from %s\n", table.Documents, col.Name)
+		manifest.Collections = append(manifest.Collections, table)
+	}
+	encoded, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, tenantExportManifestFile), append(encoded, '\n'), 0o600); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// tenantExportFileName places application collections in a directory per
+// application and falls back to the collection ID for unsafe names.
+func tenantExportFileName(col clientpkg.Collection, appID string) string {
+	name := col.Name
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		name = col.ID
+	}
+	if appID != "" && appID == filepath.Base(appID) {
+		return filepath.Join(appID, name+".jsonl")
+	}
+	return name + ".jsonl"
+}
+
+// exportTenantCollection writes one collection and checks that its live
+// documents match the server's count, so a listing cut short is caught.
+func exportTenantCollection(ctx context.Context, tenantClient *clientpkg.TenantClient, dir string, table *tenantExportedTable) error {
+	path := filepath.Join(dir, table.File)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+	hash := sha256.New()
+	writer := bufio.NewWriter(io.MultiWriter(file, hash))
+	it := tenantClient.DocumentsIterator(ctx, table.Collection, clientpkg.ListDocumentsParams{AppID: table.AppID, IncludeDeleted: true})
+	defer it.Close()
+	live := 0
+	for it.Next() {
+		doc := it.Document()
+		if doc.DeletedAt == nil {
+			live++
+		}
+		line, err := buildExportPayload(doc, true, false)
+		if err != nil {
+			return err
+		}
+		if _, err := writer.Write(append(line, '\n')); err != nil {
+			return err
+		}
+		table.Documents++
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	expected, err := tenantClient.CountDocuments(ctx, table.Collection, table.AppID)
+	if err != nil {
+		return fmt.Errorf("count documents: %w", err)
+	}
+	if int64(live) != expected {
+		return fmt.Errorf("exported %d live documents but the server reports %d", live, expected)
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		return err
+	}
+	table.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	return file.Close()
+}
+
+// verifyTenantExport reads every exported file back and checks that each line
+// is valid JSON and that line counts and checksums match the manifest.
+func verifyTenantExport(dir string, manifest *tenantExportManifest) error {
+	for _, table := range manifest.Collections {
+		raw, err := os.ReadFile(filepath.Join(dir, table.File))
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(raw)
+		if got := hex.EncodeToString(sum[:]); got != table.SHA256 {
+			return fmt.Errorf("%s: checksum mismatch", table.File)
+		}
+		lines := 0
+		for _, line := range bytes.Split(raw, []byte("\n")) {
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+			if !json.Valid(line) {
+				return fmt.Errorf("%s: line %d is not valid JSON", table.File, lines+1)
+			}
+			lines++
+		}
+		if lines != table.Documents {
+			return fmt.Errorf("%s: expected %d documents, found %d", table.File, table.Documents, lines)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestAdminTenantsDeleteExportsFirst(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	app := srv.AddApplication(clientpkg.Application{Name: "billing"})
+	srv.AddCollection(clientpkg.Collection{Name: "users"})
+	srv.AddCollection(clientpkg.Collection{Name: "invoices", AppID: &app.ID})
+	srv.AddDocument("users", map[string]any{"name": "ada"})
+	srv.AddDocument("users", map[string]any{"name": "grace"})
+	srv.AddDocument("invoices", map[string]any{"total": 42})
+
+	var deleted atomic.Bool
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/tenants":
+			_ = json.NewEncoder(w).Encode([]clientpkg.Tenant{{ID: "tn_test", Name: "Acme"}})
+		case r.Method == http.MethodDelete && r.URL.Path == "/admin/tenants/tn_test":
+			deleted.Store(true)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			tenantRoutes.ServeHTTP(w, r)
+		}
+	})
+
+	run := func(args ...string) (string, error) {
+		env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL, AdminSecret: "secret"}}
+		cmd := newAdminTenantDeleteCommand(env)
+		cmd.SilenceUsage = true
+		cmd.SetArgs(append([]string{"tn_test", "--api-key", clienttest.APIKey}, args...))
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&bytes.Buffer{})
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	dir := filepath.Join(t.TempDir(), "dump")
+	if _, err := run("--confirm"); err == nil || !strings.Contains(err.Error(), "--export-first is required") {
+		t.Fatalf("expected export requirement, got %v", err)
+	}
+	if _, err := run("--export-first", dir); err == nil || !strings.Contains(err.Error(), "--confirm") {
+		t.Fatalf("expected confirm requirement, got %v", err)
+	}
+	if deleted.Load() {
+		t.Fatalf("tenant deleted without a confirmed export")
+	}
+
+	out, err := run("--export-first", dir, "--confirm")
+	if err != nil {
+		t.Fatalf("delete failed: %v\n%s", err, out)
+	}
+	if !deleted.Load() || !strings.Contains(out, "Exported and verified 3 documents from 2 collection(s)") || !strings.Contains(out, "Deleted tenant Acme (tn_test)") {
+		t.Fatalf("unexpected output (deleted=%v):\n%s", deleted.Load(), out)
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, tenantExportManifestFile))
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	var manifest tenantExportManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		t.Fatalf("decode manifest: %v", err)
+	}
+	files := map[string]int{}
+	for _, table := range manifest.Collections {
+		files[table.File] = table.Documents
+	}
+	if files["users.jsonl"] != 2 || files[filepath.Join(app.ID, "invoices.jsonl")] != 1 {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+
+	deleted.Store(false)
+	if _, err := run("--export-first", dir, "--confirm"); err == nil || !strings.Contains(err.Error(), "not empty") || deleted.Load() {
+		t.Fatalf("expected refusal to reuse a non-empty export directory, got %v (deleted=%v)", err, deleted.Load())
+	}
+}
+
+func TestVerifyTenantExportDetectsTampering(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "users.jsonl"), []byte("{\"id\":\"1\"}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	manifest := &tenantExportManifest{Collections: []tenantExportedTable{{Collection: "users", File: "users.jsonl", Documents: 1, SHA256: "0000"}}}
+	if err := verifyTenantExport(dir, manifest); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch, got %v", err)
+	}
+}
+
+func TestAdminTenantsDeleteExportIsComplete(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users"})
+	srv.AddCollection(clientpkg.Collection{Name: "archive"})
+	srv.AddDocument("users", map[string]any{"name": "ada"})
+	srv.AddDocument("archive", map[string]any{"name": "old"})
+	if err := srv.TenantClient(t).DeleteCollection(context.Background(), "archive", ""); err != nil {
+		t.Fatalf("DeleteCollection: %v", err)
+	}
+
+	var deleted atomic.Bool
+	var shortCount atomic.Bool
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/tenants":
+			_ = json.NewEncoder(w).Encode([]clientpkg.Tenant{{ID: "tn_test", Name: "Acme"}})
+		case r.Method == http.MethodDelete && r.URL.Path == "/admin/tenants/tn_test":
+			deleted.Store(true)
+			w.WriteHeader(http.StatusNoContent)
+		case shortCount.Load() && strings.HasSuffix(r.URL.Path, "/users/documents/count"):
+			_ = json.NewEncoder(w).Encode(map[string]int{"count": 2})
+		default:
+			tenantRoutes.ServeHTTP(w, r)
+		}
+	})
+	run := func(dir string) error {
+		env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL, AdminSecret: "secret"}}
+		cmd := newAdminTenantDeleteCommand(env)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetArgs([]string{"tn_test", "--api-key", clienttest.APIKey, "--export-first", dir, "--confirm"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		return cmd.Execute()
+	}
+
+	srv.SetAuthStatus(clientpkg.AuthStatus{TenantID: "tn_test", AppID: "app_1"})
+	if err := run(filepath.Join(t.TempDir(), "scoped")); err == nil || !strings.Contains(err.Error(), "scoped to application app_1") || deleted.Load() {
+		t.Fatalf("expected an app-scoped key to be refused, got %v (deleted=%v)", err, deleted.Load())
+	}
+	srv.SetAuthStatus(clientpkg.AuthStatus{TenantID: "tn_test"})
+
+	shortCount.Store(true)
+	if err := run(filepath.Join(t.TempDir(), "short")); err == nil || !strings.Contains(err.Error(), "server reports 2") || deleted.Load() {
+		t.Fatalf("expected a count mismatch to stop the delete, got %v (deleted=%v)", err, deleted.Load())
+	}
+	shortCount.Store(false)
+
+	dir := filepath.Join(t.TempDir(), "dump")
+	if err := run(dir); err != nil || !deleted.Load() {
+		t.Fatalf("delete failed: %v (deleted=%v)", err, deleted.Load())
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, tenantExportManifestFile))
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	var manifest tenantExportManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		t.Fatalf("decode manifest: %v", err)
+	}
+	var archived *tenantExportedTable
+	for i := range manifest.Collections {
+		if manifest.Collections[i].Collection == "archive" {
+			archived = &manifest.Collections[i]
+		}
+	}
+	if archived == nil || !archived.Deleted || archived.Documents != 1 {
+		t.Fatalf("expected the soft-deleted collection in the export, got %+v", manifest.Collections)
+	}
+}
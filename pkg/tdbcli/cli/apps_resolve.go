@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// matchApplicationRef resolves ref to an application ID. IDs match exactly;
+// names match case-insensitively and must be unique.
+func matchApplicationRef(apps []clientpkg.Application, ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return "", nil
+	}
+	var matches []clientpkg.Application
+	for _, app := range apps {
+		if app.ID == ref {
+			return app.ID, nil
+		}
+		if strings.EqualFold(strings.TrimSpace(app.Name), ref) {
+			matches = append(matches, app)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("application %q not found (use an application ID or name from `tdb tenant apps list`)", ref)
+	case 1:
+		return matches[0].ID, nil
+	}
+	ids := make([]string, 0, len(matches))
+	for _, app := range matches {
+		ids = append(ids, app.ID)
+	}
+	sort.Strings(ids)
+	return "", fmt.Errorf("application name %q is ambiguous; matches %s (pass the ID instead)", ref, strings.Join(ids, ", "))
+}
+
+const (
+	applicationsCacheFile = "applications-cache.json"
+	applicationsCacheTTL  = 10 * time.Minute
+)
+
+// applicationsCacheEntry remembers a tenant's application IDs and names so
+// --app can accept names without listing applications on every invocation.
+type applicationsCacheEntry struct {
+	FetchedAt    time.Time               `json:"fetched_at"`
+	Applications []clientpkg.Application `json:"applications"`
+}
+
+// resolveApplicationRef maps an application ID or name to its ID. Fresh cache
+// entries are consulted first; on a miss the applications are listed again.
+// When they cannot be listed, or the listing is empty (for example with an
+// app-scoped key), ref is assumed to already be an ID.
+func resolveApplicationRef(ctx context.Context, env *Environment, tenantID string, tenantClient *clientpkg.TenantClient, ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return "", nil
+	}
+	path := applicationsCachePath(env)
+	key := applicationsCacheKey(env, tenantID)
+	cache := loadCacheFile[applicationsCacheEntry](path)
+	if entry, ok := cache[key]; ok && time.Since(entry.FetchedAt) < applicationsCacheTTL {
+		if id, err := matchApplicationRef(entry.Applications, ref); err == nil {
+			return id, nil
+		}
+	}
+	apps, err := tenantClient.ListApplications(ctx)
+	if err != nil || len(apps) == 0 {
+		return ref, nil
+	}
+	if path != "" {
+		trimmed := make([]clientpkg.Application, 0, len(apps))
+		for _, app := range apps {
+			trimmed = append(trimmed, clientpkg.Application{ID: app.ID, Name: app.Name})
+		}
+		cache[key] = applicationsCacheEntry{FetchedAt: time.Now().UTC(), Applications: trimmed}
+		_ = storeCacheFile(path, cache)
+	}
+	return matchApplicationRef(apps, ref)
+}
+
+func applicationsCachePath(env *Environment) string {
+	return cacheFilePath(env, applicationsCacheFile)
+}
+
+// cacheFilePath places a cache file next to the config file, or returns ""
+// when there is no config path to anchor it.
+func cacheFilePath(env *Environment, name string) string {
+	if env == nil || strings.TrimSpace(env.ConfigPath) == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(env.ConfigPath), name)
+}
+
+func applicationsCacheKey(env *Environment, tenantID string) string {
+	endpoint := ""
+	if env != nil && env.Config != nil {
+		endpoint = strings.TrimSpace(env.Config.Endpoint)
+	}
+	return endpoint + "|" + strings.TrimSpace(tenantID)
+}
+
+// loadCacheFile reads a JSON cache keyed by endpoint and tenant. Missing or
+// unreadable files yield an empty cache.
+func loadCacheFile[T any](path string) map[string]T {
+	cache := make(map[string]T)
+	if path == "" {
+		return cache
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(raw, &cache); err != nil {
+		return make(map[string]T)
+	}
+	return cache
+}
+
+func storeCacheFile[T any](path string, cache map[string]T) error {
+	encoded, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, encoded, 0o600)
+}
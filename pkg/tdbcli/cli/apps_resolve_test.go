@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestAppNameResolutionIsCached(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	billing := srv.AddApplication(clientpkg.Application{Name: "billing-service"})
+	srv.AddApplication(clientpkg.Application{Name: "search"})
+	srv.AddApplication(clientpkg.Application{Name: "Search"})
+	srv.AddCollection(clientpkg.Collection{Name: "invoices", AppID: &billing.ID})
+	srv.AddCollection(clientpkg.Collection{Name: "settings"})
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	run := func(args ...string) (string, error) {
+		t.Helper()
+		env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}, ConfigPath: configPath}
+		out, errOut, err := runCommandWithEnv(t, env, newTenantCollectionsListCommand, args...)
+		return errOut + out, err
+	}
+
+	for _, flag := range []string{"--app", "--app-id"} {
+		out, err := run(flag, "billing-service")
+		if err != nil {
+			t.Fatalf("%s billing-service failed: %v\n%s", flag, err, out)
+		}
+		if !strings.Contains(out, "invoices") || strings.Contains(out, "settings") {
+			t.Fatalf("%s: expected only billing collections:\n%s", flag, out)
+		}
+	}
+	lists := 0
+	for _, req := range srv.Requests() {
+		if req == "GET /api/applications" {
+			lists++
+		}
+	}
+	if lists != 1 {
+		t.Fatalf("expected applications to be listed once, got %d", lists)
+	}
+
+	if _, err := run("--app", "search"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("expected ambiguity error, got %v", err)
+	}
+	if _, err := run("--app", "search", "--app-id", "billing-service"); err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+		t.Fatalf("expected combination error, got %v", err)
+	}
+}
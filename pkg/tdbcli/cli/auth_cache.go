@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const (
+	authCacheFile = "auth-cache.json"
+	authCacheTTL  = time.Minute
+)
+
+// authCacheEntry stores the /api/me answer for one API key, so commands that
+// check a key in quick succession do not call the API every time. Keys are
+// identified by a hash; the key itself is never written to the cache.
+type authCacheEntry struct {
+	FetchedAt time.Time            `json:"fetched_at"`
+	Status    clientpkg.AuthStatus `json:"status"`
+}
+
+// authCacheMemory holds the entries seen by this process, in front of the
+// on-disk cache.
+var (
+	authCacheMu     sync.Mutex
+	authCacheMemory = map[string]authCacheEntry{}
+)
+
+func authCacheKey(env *Environment, apiKey, appID string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(apiKey)))
+	return applicationsCacheKey(env, hex.EncodeToString(sum[:16])) + "|" + strings.TrimSpace(appID)
+}
+
+// cachedAuthStatus returns the status of apiKey while the cached answer is
+// younger than authCacheTTL and calls /api/me otherwise. refresh skips the
+// lookup but still caches the new answer. Failures are never cached.
+func cachedAuthStatus(ctx context.Context, env *Environment, tenantClient *clientpkg.TenantClient, apiKey, appID string, refresh bool) (*clientpkg.AuthStatus, error) {
+	key := authCacheKey(env, apiKey, appID)
+	path := cacheFilePath(env, authCacheFile)
+	if !refresh {
+		if entry, ok := lookupAuthCache(path, key); ok {
+			return &entry.Status, nil
+		}
+	}
+	status, err := tenantClient.AuthStatus(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	entry := authCacheEntry{FetchedAt: time.Now().UTC(), Status: *status}
+	authCacheMu.Lock()
+	defer authCacheMu.Unlock()
+	authCacheMemory[key] = entry
+	if path != "" {
+		cache := loadCacheFile[authCacheEntry](path)
+		for cached, old := range cache {
+			if time.Since(old.FetchedAt) >= authCacheTTL {
+				delete(cache, cached)
+			}
+		}
+		cache[key] = entry
+		_ = storeCacheFile(path, cache)
+	}
+	return status, nil
+}
+
+func lookupAuthCache(path, key string) (authCacheEntry, bool) {
+	authCacheMu.Lock()
+	defer authCacheMu.Unlock()
+	entry, ok := authCacheMemory[key]
+	if !ok {
+		entry, ok = loadCacheFile[authCacheEntry](path)[key]
+		if ok {
+			authCacheMemory[key] = entry
+		}
+	}
+	if !ok || time.Since(entry.FetchedAt) >= authCacheTTL {
+		return authCacheEntry{}, false
+	}
+	return entry, true
+}
+
+// forgetAuthStatuses drops cached statuses of the key with the given prefix,
+// or of every key when prefix is empty, and returns how many were dropped.
+func forgetAuthStatuses(env *Environment, prefix string) int {
+	prefix = strings.TrimSpace(prefix)
+	matches := func(entry authCacheEntry) bool {
+		return prefix == "" || strings.EqualFold(entry.Status.KeyPrefix, prefix)
+	}
+	authCacheMu.Lock()
+	defer authCacheMu.Unlock()
+	dropped := map[string]struct{}{}
+	for key, entry := range authCacheMemory {
+		if matches(entry) {
+			delete(authCacheMemory, key)
+			dropped[key] = struct{}{}
+		}
+	}
+	if path := cacheFilePath(env, authCacheFile); path != "" {
+		cache := loadCacheFile[authCacheEntry](path)
+		changed := false
+		for key, entry := range cache {
+			if matches(entry) {
+				delete(cache, key)
+				dropped[key] = struct{}{}
+				changed = true
+			}
+		}
+		if changed {
+			_ = storeCacheFile(path, cache)
+		}
+	}
+	return len(dropped)
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestAuthStatusCache(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	calls := 0
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/me" {
+			tenantRoutes.ServeHTTP(w, r)
+			return
+		}
+		calls++
+		_ = json.NewEncoder(w).Encode(clientpkg.AuthStatus{TenantID: "tn_test", Status: "active", KeyPrefix: "tdb_test"})
+	})
+	dir := t.TempDir()
+	env := &Environment{ConfigPath: filepath.Join(dir, "config.yaml"), Config: &configpkg.Config{Endpoint: srv.URL}}
+	resetMemory := func() {
+		authCacheMu.Lock()
+		authCacheMemory = map[string]authCacheEntry{}
+		authCacheMu.Unlock()
+	}
+	t.Cleanup(resetMemory)
+	checkKey := func(args ...string) {
+		t.Helper()
+		out, _, err := runCommandWithEnv(t, env, newTenantAuthCommand, append([]string{"--raw"}, args...)...)
+		if err != nil {
+			t.Fatalf("tenant auth failed: %v", err)
+		}
+		if !strings.Contains(out, `"tenant_id": "tn_test"`) {
+			t.Fatalf("unexpected output:\n%s", out)
+		}
+	}
+
+	checkKey()
+	checkKey()
+	if calls != 1 {
+		t.Fatalf("expected the second check to be cached, got %d calls", calls)
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, authCacheFile))
+	if err != nil {
+		t.Fatalf("read cache: %v", err)
+	}
+	if strings.Contains(string(raw), clienttest.APIKey) {
+		t.Fatalf("cache file contains the API key:\n%s", raw)
+	}
+
+	// A new process reads the answer from disk.
+	resetMemory()
+	checkKey()
+	if calls != 1 {
+		t.Fatalf("expected the on-disk cache to be used, got %d calls", calls)
+	}
+	checkKey("--refresh")
+	if calls != 2 {
+		t.Fatalf("expected --refresh to call /api/me, got %d calls", calls)
+	}
+
+	refresh := func(args ...string) string {
+		t.Helper()
+		cmd := newAuthRefreshCommand(env)
+		cmd.SetArgs(args)
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("auth refresh failed: %v", err)
+		}
+		return out.String()
+	}
+	if out := refresh("--prefix", "tdb_other"); !strings.Contains(out, "Forgot 0 cached") {
+		t.Fatalf("expected other keys to be kept, got %q", out)
+	}
+	if out := refresh("--prefix", "tdb_test"); !strings.Contains(out, "Forgot 1 cached") {
+		t.Fatalf("expected the key to be forgotten, got %q", out)
+	}
+	checkKey()
+	if calls != 3 {
+		t.Fatalf("expected a call after auth refresh, got %d calls", calls)
+	}
+}
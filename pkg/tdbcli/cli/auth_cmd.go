@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newAuthCommand(env *Environment) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Manage cached API key verification",
+	}
+	cmd.AddCommand(newAuthRefreshCommand(env))
+	return cmd
+}
+
+func newAuthRefreshCommand(env *Environment) *cobra.Command {
+	var prefix string
+
+	cmd := &cobra.Command{
+		Use:   "refresh",
+		Short: "Forget cached API key statuses so the next check calls /api/me",
+		Long: fmt.Sprintf(`Commands that verify an API key (tenant auth, config set api-key) cache the
+/api/me answer per key for %s, in memory and next to the config file, so tight
+loops do not spend rate limit on it. Run refresh after a key was revoked,
+rotated, or rescoped elsewhere to drop the cached answers immediately.
+
+The cache holds a hash of each key, never the key itself. Keys revoked through
+tdb admin keys are forgotten automatically.`, authCacheTTL),
+		Example: `  # Forget every cached key status
+  tdb auth refresh
+
+  # Forget only the key with this prefix
+  tdb auth refresh --prefix tdb_ab12`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			dropped := forgetAuthStatuses(envCtx, prefix)
+			fmt.Fprintf(cmd.OutOrStdout(), "Forgot %d cached key status(es)\n", dropped)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Only forget the key with this prefix")
+	return cmd
+}
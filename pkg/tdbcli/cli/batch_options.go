@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// batchOptions holds the --concurrency, --rate and --retries flags shared by
+// the bulk document commands, which run their requests through a
+// clientpkg.BatchExecutor.
+type batchOptions struct {
+	concurrency int
+	rate        float64
+	retries     int
+}
+
+func (b *batchOptions) bind(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&b.concurrency, "concurrency", 1, "Requests to run in parallel (output order is not preserved above 1)")
+	cmd.Flags().Float64Var(&b.rate, "rate", 0, "Maximum requests started per second (0 = unlimited)")
+	cmd.Flags().IntVar(&b.retries, "retries", 0, "Retry each failed item this many times on transient errors (429, 502-504, timeouts)")
+}
+
+func (b *batchOptions) validate() error {
+	if b.concurrency < 1 {
+		return errors.New("--concurrency must be at least 1")
+	}
+	if b.rate < 0 {
+		return errors.New("--rate cannot be negative")
+	}
+	if b.retries < 0 {
+		return errors.New("--retries cannot be negative")
+	}
+	return nil
+}
+
+func (b *batchOptions) executor() clientpkg.BatchExecutor {
+	return clientpkg.BatchExecutor{Concurrency: b.concurrency, RatePerSecond: b.rate, MaxRetries: b.retries}
+}
+
+// serializeOutput makes the command's writers safe for the concurrent workers
+// started when --concurrency is above 1, so lines are never interleaved.
+func (b *batchOptions) serializeOutput(cmd *cobra.Command) {
+	if b.concurrency <= 1 {
+		return
+	}
+	mu := &sync.Mutex{}
+	cmd.SetOut(&lockedWriter{mu: mu, w: cmd.OutOrStdout()})
+	cmd.SetErr(&lockedWriter{mu: mu, w: cmd.ErrOrStderr()})
+}
+
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
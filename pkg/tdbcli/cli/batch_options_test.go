@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestDocumentsSyncConcurrency(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "products", PrimaryKeyField: "sku"})
+	srv.AddDocument("products", map[string]any{"sku": "sku-0", "price": 1})
+
+	docs := make([]map[string]any, 20)
+	for i := range docs {
+		docs[i] = map[string]any{"sku": fmt.Sprintf("sku-%d", i), "price": 2}
+	}
+	payload, _ := json.Marshal(docs)
+
+	_, errOut, err := runCommand(t, srv, newTenantDocumentsSyncCommand, "products", "--data", string(payload), "--concurrency", "4", "--rate", "1000", "--retries", "1")
+	if err != nil {
+		t.Fatalf("sync failed: %v\n%s", err, errOut)
+	}
+	if !strings.Contains(errOut, "created 19, updated 1, unchanged 0") {
+		t.Fatalf("unexpected summary: %s", errOut)
+	}
+	if n := len(srv.Documents("products")); n != 20 {
+		t.Fatalf("expected 20 documents, got %d", n)
+	}
+
+	if _, _, err := runCommand(t, srv, newTenantDocumentsSyncCommand, "products", "--data", string(payload), "--concurrency", "0"); err == nil || !strings.Contains(err.Error(), "--concurrency must be at least 1") {
+		t.Fatalf("expected concurrency validation error, got %v", err)
+	}
+}
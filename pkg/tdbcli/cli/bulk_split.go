@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// bulkSender sends one bulk request under an idempotency key.
+type bulkSender func(ctx context.Context, key string, chunk []byte) ([]clientpkg.Document, error)
+
+// bulkAutoSplit retries bulk requests rejected with 413 Payload Too Large by
+// halving the batch, down to single documents, and remembers the request
+// sizes the server accepted and rejected.
+type bulkAutoSplit struct {
+	disabled bool
+
+	mu           sync.Mutex
+	splits       int
+	accepted     int64
+	acceptedDocs int
+	rejected     int64
+}
+
+// send posts chunk, a JSON array, splitting it when the server rejects it as
+// too large. Halves get the key with "-a" or "-b" appended, so a rerun that
+// splits the same way is deduplicated by the server. Documents inserted
+// before a failure are returned with the error.
+func (s *bulkAutoSplit) send(ctx context.Context, key string, chunk []byte, sender bulkSender) ([]clientpkg.Document, error) {
+	docs, err := sender(ctx, key, chunk)
+	var tooLarge *clientpkg.PayloadTooLargeError
+	if err == nil {
+		s.record(int64(len(chunk)), len(docs), nil)
+		return docs, nil
+	}
+	if s.disabled || !errors.As(err, &tooLarge) {
+		return nil, err
+	}
+	var items []json.RawMessage
+	if decodeErr := json.Unmarshal(chunk, &items); decodeErr != nil {
+		return nil, err
+	}
+	s.record(int64(len(chunk)), 0, tooLarge)
+	if len(items) <= 1 {
+		return nil, fmt.Errorf("a single document of %s is too large for the server: %w", humanize.IBytes(uint64(len(chunk))), err)
+	}
+	half := len(items) / 2
+	var inserted []clientpkg.Document
+	for i, part := range [][]json.RawMessage{items[:half], items[half:]} {
+		encoded, encodeErr := json.Marshal(part)
+		if encodeErr != nil {
+			return inserted, encodeErr
+		}
+		docs, err := s.send(ctx, key+"-"+string(rune('a'+i)), encoded, sender)
+		inserted = append(inserted, docs...)
+		if err != nil {
+			return inserted, err
+		}
+	}
+	return inserted, nil
+}
+
+func (s *bulkAutoSplit) record(size int64, docs int, rejected *clientpkg.PayloadTooLargeError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rejected != nil {
+		s.splits++
+		if s.rejected == 0 || size < s.rejected {
+			s.rejected = size
+		}
+		return
+	}
+	if size > s.accepted {
+		s.accepted, s.acceptedDocs = size, docs
+	}
+}
+
+// report describes the payload limit discovered by splitting, if any.
+func (s *bulkAutoSplit) report(w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.splits == 0 {
+		return
+	}
+	fmt.Fprintf(w, "Requests rejected as too large (413) and split in half: %d. ", s.splits)
+	if s.accepted > 0 {
+		fmt.Fprintf(w, "Largest accepted request: %s (%d documents); ", humanize.IBytes(uint64(s.accepted)), s.acceptedDocs)
+	}
+	fmt.Fprintf(w, "smallest rejected: %s.\n", humanize.IBytes(uint64(s.rejected)))
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestBulkCreateSplitsPayloadTooLarge(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "events"})
+	const maxBody = 200
+	var keys []string
+	routes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/documents/bulk") {
+			body, _ := io.ReadAll(r.Body)
+			if len(body) > maxBody {
+				http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		routes.ServeHTTP(w, r)
+	})
+	var docs []string
+	for i := 0; i < 10; i++ {
+		docs = append(docs, fmt.Sprintf(`{"n":%d,"pad":"%s"}`, i, strings.Repeat("x", 40)))
+	}
+	payload := "[" + strings.Join(docs, ",") + "]"
+	run := func(args ...string) (string, string, error) {
+		return runCommand(t, srv, newTenantDocumentsBulkCreateCommand, append([]string{"events", "--data", payload, "--idempotency-key", "load"}, args...)...)
+	}
+
+	if _, _, err := run("--no-auto-split"); err == nil || !strings.Contains(err.Error(), "payload too large") {
+		t.Fatalf("expected the 413 to fail the run with --no-auto-split, got %v", err)
+	}
+	out, errOut, err := run()
+	if err != nil {
+		t.Fatalf("bulk-create failed: %v\n%s", err, errOut)
+	}
+	if !strings.Contains(out, "Inserted 10 documents") || len(srv.Documents("events")) != 10 {
+		t.Fatalf("expected all documents inserted:\n%s", out)
+	}
+	if !strings.Contains(errOut, "rejected as too large (413) and split in half: 3.") || !strings.Contains(errOut, "Largest accepted request: 172 B (3 documents)") {
+		t.Fatalf("expected the discovered limit to be reported:\n%s", errOut)
+	}
+	if strings.Join(keys, ",") != "load-a-a,load-a-b,load-b-a,load-b-b" {
+		t.Fatalf("unexpected idempotency keys %v", keys)
+	}
+}
+
+func TestBulkAutoSplitSingleDocumentTooLarge(t *testing.T) {
+	split := &bulkAutoSplit{}
+	sender := func(_ context.Context, _ string, _ []byte) ([]clientpkg.Document, error) {
+		return nil, &clientpkg.PayloadTooLargeError{Body: "too large"}
+	}
+	_, err := split.send(context.Background(), "k", []byte(`[{"big":true}]`), sender)
+	if err == nil || !strings.Contains(err.Error(), "a single document of 14 B is too large") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,261 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const (
+	capabilitiesCacheFile = "capabilities.json"
+	capabilitiesCacheTTL  = 24 * time.Hour
+)
+
+// capabilitiesCacheEntry stores the probed capabilities for a single endpoint.
+type capabilitiesCacheEntry struct {
+	Capabilities clientpkg.ServerCapabilities `json:"capabilities"`
+	Detected     bool                         `json:"detected"`
+	FetchedAt    time.Time                    `json:"fetched_at"`
+}
+
+func newCapabilitiesCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var refresh bool
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "capabilities",
+		Short: "Probe and cache server limits and supported features",
+		Long: `Query the server for its maximum page size, maximum bulk size, streaming
+support, and available endpoints. Results are cached per endpoint next to the
+config file for 24 hours and used to auto-tune page and batch sizes in list,
+export, and bulk-create when the corresponding flags are not set explicitly.`,
+		Example: `  # Show cached capabilities (probing when missing or stale)
+  tdb capabilities
+
+  # Force a fresh probe
+  tdb capabilities --refresh`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			endpoint, err := ensureEndpoint(envCtx)
+			if err != nil {
+				return err
+			}
+			entry := cachedCapabilities(envCtx)
+			if refresh || entry == nil || time.Since(entry.FetchedAt) > capabilitiesCacheTTL {
+				tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+				if err != nil {
+					return err
+				}
+				entry, err = probeCapabilities(cmd.Context(), tenantClient)
+				if err != nil {
+					return err
+				}
+				if err := storeCapabilities(envCtx, endpoint, *entry); err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to cache capabilities: %v\n", err)
+				}
+			}
+			if raw {
+				return printJSON(cmd, entry)
+			}
+			caps := entry.Capabilities
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "ENDPOINT: %s\n", endpoint)
+			if !entry.Detected {
+				fmt.Fprintln(out, "DETECTED: no (server does not advertise capabilities; CLI defaults apply)")
+			} else {
+				fmt.Fprintln(out, "DETECTED: yes")
+			}
+			if caps.Version != "" {
+				fmt.Fprintf(out, "SERVER VERSION: %s\n", caps.Version)
+			}
+			fmt.Fprintf(out, "DEFAULT PAGE SIZE: %s\n", formatCapabilityLimit(caps.DefaultPageSize))
+			fmt.Fprintf(out, "MAX PAGE SIZE: %s\n", formatCapabilityLimit(caps.MaxPageSize))
+			fmt.Fprintf(out, "MAX BULK SIZE: %s\n", formatCapabilityLimit(caps.MaxBulkSize))
+			fmt.Fprintf(out, "STREAMING EXPORT: %t\n", caps.StreamingExport)
+			if len(caps.Endpoints) > 0 {
+				fmt.Fprintf(out, "ENDPOINTS: %s\n", strings.Join(caps.Endpoints, ", "))
+			}
+			if len(caps.Features) > 0 {
+				fmt.Fprintf(out, "FEATURES: %s\n", strings.Join(caps.Features, ", "))
+			}
+			fmt.Fprintf(out, "FETCHED: %s\n", formatTime(entry.FetchedAt))
+			return nil
+		},
+	}
+
+	auth.bind(cmd)
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "Ignore the cache and probe the server again")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON output")
+	return cmd
+}
+
+func formatCapabilityLimit(value int) string {
+	if value <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d", value)
+}
+
+func probeCapabilities(ctx context.Context, tenantClient *clientpkg.TenantClient) (*capabilitiesCacheEntry, error) {
+	entry := &capabilitiesCacheEntry{FetchedAt: time.Now().UTC()}
+	caps, err := tenantClient.Capabilities(ctx)
+	if err != nil {
+		if isNotFoundError(err) {
+			return entry, nil
+		}
+		return nil, err
+	}
+	entry.Capabilities = *caps
+	entry.Detected = true
+	return entry, nil
+}
+
+func capabilitiesCachePath(env *Environment) string {
+	if env == nil || strings.TrimSpace(env.ConfigPath) == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(env.ConfigPath), capabilitiesCacheFile)
+}
+
+func loadCapabilitiesCache(path string) (map[string]capabilitiesCacheEntry, error) {
+	cache := make(map[string]capabilitiesCacheEntry)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cache, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &cache); err != nil {
+		return nil, fmt.Errorf("parse capabilities cache: %w", err)
+	}
+	return cache, nil
+}
+
+func storeCapabilities(env *Environment, endpoint string, entry capabilitiesCacheEntry) error {
+	path := capabilitiesCachePath(env)
+	if path == "" {
+		return errors.New("config path unknown")
+	}
+	cache, err := loadCapabilitiesCache(path)
+	if err != nil {
+		cache = make(map[string]capabilitiesCacheEntry)
+	}
+	cache[strings.TrimSpace(endpoint)] = entry
+	encoded, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, encoded, 0o600)
+}
+
+// cachedCapabilities returns the cached capabilities for the active endpoint, or
+// nil when nothing has been probed yet. Stale entries are still returned; the
+// capabilities command is responsible for refreshing them.
+func cachedCapabilities(env *Environment) *capabilitiesCacheEntry {
+	if env == nil || env.Config == nil {
+		return nil
+	}
+	path := capabilitiesCachePath(env)
+	if path == "" {
+		return nil
+	}
+	cache, err := loadCapabilitiesCache(path)
+	if err != nil {
+		return nil
+	}
+	entry, ok := cache[strings.TrimSpace(env.Config.Endpoint)]
+	if !ok {
+		return nil
+	}
+	return &entry
+}
+
+// currentCapabilities returns the cached capabilities for the active endpoint,
+// probing the server and refreshing the cache when they are missing or stale.
+func currentCapabilities(ctx context.Context, env *Environment, tenantClient *clientpkg.TenantClient) (*capabilitiesCacheEntry, error) {
+	entry := cachedCapabilities(env)
+	if entry != nil && time.Since(entry.FetchedAt) <= capabilitiesCacheTTL {
+		return entry, nil
+	}
+	entry, err := probeCapabilities(ctx, tenantClient)
+	if err != nil {
+		return nil, err
+	}
+	if env != nil && env.Config != nil {
+		_ = storeCapabilities(env, env.Config.Endpoint, *entry)
+	}
+	return entry, nil
+}
+
+// autoTunePageSize applies detected server limits to a page size. Explicitly
+// requested sizes are only clamped to the server maximum; implicit defaults are
+// replaced by the server default when one is advertised.
+func autoTunePageSize(requested int, explicit bool, entry *capabilitiesCacheEntry) int {
+	if entry == nil || !entry.Detected {
+		return requested
+	}
+	size := requested
+	if !explicit && entry.Capabilities.DefaultPageSize > 0 {
+		size = entry.Capabilities.DefaultPageSize
+	}
+	if limit := entry.Capabilities.MaxPageSize; limit > 0 && size > limit {
+		size = limit
+	}
+	return size
+}
+
+func tunePageSize(cmd *cobra.Command, env *Environment, flagName string, requested int) int {
+	explicit := false
+	if flag := cmd.Flags().Lookup(flagName); flag != nil {
+		explicit = flag.Changed
+	}
+	tuned := autoTunePageSize(requested, explicit, cachedCapabilities(env))
+	if explicit && tuned != requested {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Clamping --%s to server maximum %d\n", flagName, tuned)
+	}
+	return tuned
+}
+
+// chunkJSONArray splits a JSON array payload into batches of at most size items.
+func chunkJSONArray(payload []byte, size int) ([][]byte, error) {
+	if size <= 0 {
+		return [][]byte{payload}, nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(payload, &items); err != nil {
+		return nil, fmt.Errorf("payload must be a JSON array: %w", err)
+	}
+	if len(items) <= size {
+		return [][]byte{payload}, nil
+	}
+	chunks := make([][]byte, 0, (len(items)+size-1)/size)
+	for start := 0; start < len(items); start += size {
+		end := start + size
+		if end > len(items) {
+			end = len(items)
+		}
+		encoded, err := json.Marshal(items[start:end])
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, encoded)
+	}
+	return chunks, nil
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+func TestAutoTunePageSize(t *testing.T) {
+	detected := &capabilitiesCacheEntry{Detected: true, Capabilities: clientpkg.ServerCapabilities{DefaultPageSize: 200, MaxPageSize: 500}}
+	cases := []struct {
+		name      string
+		requested int
+		explicit  bool
+		entry     *capabilitiesCacheEntry
+		want      int
+	}{
+		{name: "no cache", requested: 100, entry: nil, want: 100},
+		{name: "not detected", requested: 100, entry: &capabilitiesCacheEntry{}, want: 100},
+		{name: "implicit uses server default", requested: 100, entry: detected, want: 200},
+		{name: "explicit kept", requested: 300, explicit: true, entry: detected, want: 300},
+		{name: "explicit clamped", requested: 1000, explicit: true, entry: detected, want: 500},
+	}
+	for _, tc := range cases {
+		if got := autoTunePageSize(tc.requested, tc.explicit, tc.entry); got != tc.want {
+			t.Fatalf("%s: expected %d, got %d", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestChunkJSONArray(t *testing.T) {
+	chunks, err := chunkJSONArray([]byte(`[{"a":1},{"a":2},{"a":3}]`), 2)
+	if err != nil {
+		t.Fatalf("chunkJSONArray returned error: %v", err)
+	}
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 chunks, got %d", len(chunks))
+	}
+	if string(chunks[0]) != `[{"a":1},{"a":2}]` || string(chunks[1]) != `[{"a":3}]` {
+		t.Fatalf("unexpected chunks: %s | %s", chunks[0], chunks[1])
+	}
+	single, err := chunkJSONArray([]byte(`[1,2]`), 0)
+	if err != nil || len(single) != 1 {
+		t.Fatalf("expected payload unchanged without a size, got %v (%v)", single, err)
+	}
+}
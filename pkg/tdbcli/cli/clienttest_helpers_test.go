@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+// fakeTenantID is the tenant commands authenticate as against the fake server.
+const fakeTenantID = "tn_test"
+
+// runCommand runs the command newCmd builds against srv, authenticated with
+// the fake server's API key, and returns what it wrote to stdout and stderr.
+// Stdin is empty, so prompts see no answer.
+func runCommand(t *testing.T, srv *clienttest.Server, newCmd func(*Environment) *cobra.Command, args ...string) (string, string, error) {
+	t.Helper()
+	return runCommandWithEnv(t, &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}, newCmd, args...)
+}
+
+// runCommandWithEnv is runCommand for tests that share an environment, such
+// as a config file, between runs.
+func runCommandWithEnv(t *testing.T, env *Environment, newCmd func(*Environment) *cobra.Command, args ...string) (string, string, error) {
+	t.Helper()
+	cmd := newCmd(env)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	cmd.SetArgs(append(append([]string(nil), args...), "--tenant", fakeTenantID, "--api-key", clienttest.APIKey))
+	cmd.SetIn(&bytes.Buffer{})
+	var out, errOut bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	err := cmd.Execute()
+	return out.String(), errOut.String(), err
+}
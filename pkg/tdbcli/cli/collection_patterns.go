@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const collectionPatternsUsage = "exact names or IDs, globs such as 'events_*', or regular expressions prefixed with re:"
+
+// collectionPattern selects collections by exact name or ID, by shell glob
+// (matched against the name), or by a "re:" prefixed regular expression.
+type collectionPattern struct {
+	raw  string
+	glob bool
+	re   *regexp.Regexp
+}
+
+func parseCollectionPatterns(values []string) ([]collectionPattern, error) {
+	patterns := make([]collectionPattern, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		pattern := collectionPattern{raw: value}
+		switch {
+		case strings.HasPrefix(value, "re:"):
+			re, err := regexp.Compile(strings.TrimPrefix(value, "re:"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid collection pattern %q: %w", value, err)
+			}
+			pattern.re = re
+		case strings.ContainsAny(value, "*?["):
+			if _, err := path.Match(value, ""); err != nil {
+				return nil, fmt.Errorf("invalid collection pattern %q: %w", value, err)
+			}
+			pattern.glob = true
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+func (p collectionPattern) matches(col clientpkg.Collection) bool {
+	switch {
+	case p.re != nil:
+		return p.re.MatchString(col.Name)
+	case p.glob:
+		ok, _ := path.Match(p.raw, col.Name)
+		return ok
+	default:
+		return col.Name == p.raw || col.ID == p.raw
+	}
+}
+
+// selectCollections returns the collections matched by values in pattern
+// order, without duplicates. Every pattern must match at least one collection
+// so typos do not silently select nothing. No patterns selects everything.
+func selectCollections(all []clientpkg.Collection, values []string) ([]clientpkg.Collection, error) {
+	patterns, err := parseCollectionPatterns(values)
+	if err != nil {
+		return nil, err
+	}
+	if len(patterns) == 0 {
+		return all, nil
+	}
+	seen := make(map[string]bool, len(all))
+	var selected []clientpkg.Collection
+	var missing []string
+	for _, pattern := range patterns {
+		found := false
+		for _, col := range all {
+			if !pattern.matches(col) {
+				continue
+			}
+			found = true
+			if !seen[col.ID] {
+				seen[col.ID] = true
+				selected = append(selected, col)
+			}
+		}
+		if !found {
+			missing = append(missing, pattern.raw)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("unknown collection(s): %s", strings.Join(missing, ", "))
+	}
+	return selected, nil
+}
+
+// printCollectionSelection lists the collections a --dry-run would act on.
+func printCollectionSelection(cmd *cobra.Command, collections []clientpkg.Collection, raw bool) error {
+	if raw {
+		return printJSON(cmd, collections)
+	}
+	rows := make([][]string, 0, len(collections))
+	for _, col := range collections {
+		rows = append(rows, []string{col.Name, col.ID, fmt.Sprintf("%d", col.DocumentCount), formatBytes(col.StorageBytes)})
+	}
+	renderTable(cmd, []string{"NAME", "ID", "DOCUMENTS", "STORAGE"}, rows)
+	fmt.Fprintf(cmd.OutOrStdout(), "\n%d collection(s) matched\n", len(collections))
+	return nil
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestSelectCollections(t *testing.T) {
+	all := []clientpkg.Collection{
+		{ID: "c1", Name: "users"},
+		{ID: "c2", Name: "orders"},
+		{ID: "c3", Name: "events_2024"},
+		{ID: "c4", Name: "events_2025"},
+	}
+	names := func(cols []clientpkg.Collection) string {
+		out := make([]string, 0, len(cols))
+		for _, col := range cols {
+			out = append(out, col.Name)
+		}
+		return strings.Join(out, ",")
+	}
+	cases := []struct {
+		patterns []string
+		want     string
+		wantErr  string
+	}{
+		{patterns: nil, want: "users,orders,events_2024,events_2025"},
+		{patterns: []string{"orders", "c1"}, want: "orders,users"},
+		{patterns: []string{"events_*"}, want: "events_2024,events_2025"},
+		{patterns: []string{"re:^events_\\d+5$", "events_*"}, want: "events_2025,events_2024"},
+		{patterns: []string{"missing", "logs_*"}, wantErr: "unknown collection(s): missing, logs_*"},
+		{patterns: []string{"re:("}, wantErr: "invalid collection pattern"},
+		{patterns: []string{"events_["}, wantErr: "invalid collection pattern"},
+	}
+	for _, tc := range cases {
+		got, err := selectCollections(all, tc.patterns)
+		if tc.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("selectCollections(%v): expected error containing %q, got %v", tc.patterns, tc.wantErr, err)
+			}
+			continue
+		}
+		if err != nil || names(got) != tc.want {
+			t.Fatalf("selectCollections(%v) = %s, %v; want %s", tc.patterns, names(got), err, tc.want)
+		}
+	}
+}
+
+func TestSnapshotsCreateDryRunListsMatches(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "events_2024"})
+	srv.AddCollection(clientpkg.Collection{Name: "events_2025"})
+	srv.AddCollection(clientpkg.Collection{Name: "users"})
+
+	text, errOut, err := runCommand(t, srv, newTenantSnapshotsCreateCommand, "--collections", "events_*", "--exclude", "events_2024", "--name", "nightly", "--dry-run")
+	if err != nil {
+		t.Fatalf("snapshots create failed: %v\n%s", err, errOut)
+	}
+	if !strings.Contains(text, "events_2025") || strings.Contains(text, "events_2024") || strings.Contains(text, "users") {
+		t.Fatalf("unexpected dry-run selection:\n%s", text)
+	}
+	if !strings.Contains(text, "1 collection(s) matched") {
+		t.Fatalf("missing match count:\n%s", text)
+	}
+	for _, req := range srv.Requests() {
+		if strings.HasPrefix(req, "POST") {
+			t.Fatalf("dry run should not create snapshots, saw %s", req)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const (
+	completionCacheFile    = "completion-cache.json"
+	completionCacheTTL     = 30 * time.Second
+	completionFetchTimeout = 5 * time.Second
+	completionSnapshotPage = 50
+)
+
+// completionCacheEntry holds the candidates offered for one kind of value, so
+// repeated <TAB> presses do not call the API each time.
+type completionCacheEntry struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Values    []string  `json:"values"`
+}
+
+// cachedCompletions returns fresh cached candidates for kind, or calls fetch
+// and caches its result. Errors yield no candidates rather than noise in the
+// shell.
+func cachedCompletions(env *Environment, tenantID, appID, kind string, fetch func(ctx context.Context) ([]string, error)) []string {
+	path := cacheFilePath(env, completionCacheFile)
+	key := applicationsCacheKey(env, tenantID) + "|" + strings.TrimSpace(appID) + "|" + kind
+	cache := loadCacheFile[completionCacheEntry](path)
+	if entry, ok := cache[key]; ok && time.Since(entry.FetchedAt) < completionCacheTTL {
+		return entry.Values
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), completionFetchTimeout)
+	defer cancel()
+	values, err := fetch(ctx)
+	if err != nil {
+		return nil
+	}
+	if path != "" {
+		cache[key] = completionCacheEntry{FetchedAt: time.Now().UTC(), Values: values}
+		_ = storeCacheFile(path, cache)
+	}
+	return values
+}
+
+// filterCompletions keeps candidates whose value (the part before the tab
+// separating cobra descriptions) starts with toComplete.
+func filterCompletions(values []string, toComplete string) []string {
+	var matches []string
+	for _, value := range values {
+		candidate, _, _ := strings.Cut(value, "\t")
+		if strings.HasPrefix(candidate, toComplete) {
+			matches = append(matches, value)
+		}
+	}
+	return matches
+}
+
+// completionClient resolves the tenant client for a completion function
+// without a command, so the stored app scope notice is not written to stdout
+// where the shell would read it back as a candidate.
+func completionClient(env *Environment, auth *authFlags) (*clientpkg.TenantClient, string, error) {
+	tenantClient, _, tenantID, err := auth.resolveTenantClient(env, nil)
+	return tenantClient, tenantID, err
+}
+
+// savedQueryCompletion completes the saved query argument of queries
+// get/execute/delete: IDs described by their name, or names with --by-name.
+func savedQueryCompletion(env *Environment, auth *authFlags) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		envCtx, err := requireEnvironment(env)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		tenantClient, tenantID, err := completionClient(envCtx, auth)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		byName, _ := cmd.Flags().GetBool("by-name")
+		kind := "saved-query-ids"
+		if byName {
+			kind = "saved-query-names"
+		}
+		values := cachedCompletions(envCtx, tenantID, auth.appID, kind, func(ctx context.Context) ([]string, error) {
+			docs, err := tenantClient.ListSavedQueries(ctx, auth.appID)
+			if err != nil {
+				return nil, err
+			}
+			values := make([]string, 0, len(docs))
+			for _, doc := range docs {
+				name := ""
+				if sq, err := parseSavedQueryDocument(doc); err == nil {
+					name = strings.TrimSpace(sq.Name)
+				}
+				switch {
+				case byName && name != "":
+					values = append(values, name)
+				case !byName && name != "":
+					values = append(values, doc.ID+"\t"+name)
+				case !byName:
+					values = append(values, doc.ID)
+				}
+			}
+			return values, nil
+		})
+		return filterCompletions(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// snapshotIDCompletion completes --snapshot with the most recent snapshot IDs,
+// described by name and collection.
+func snapshotIDCompletion(env *Environment, auth *authFlags) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		envCtx, err := requireEnvironment(env)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		tenantClient, tenantID, err := completionClient(envCtx, auth)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		values := cachedCompletions(envCtx, tenantID, "", "snapshot-ids", func(ctx context.Context) ([]string, error) {
+			snapshots, err := tenantClient.ListSnapshots(ctx, "", completionSnapshotPage, 0)
+			if err != nil {
+				return nil, err
+			}
+			values := make([]string, 0, len(snapshots))
+			for _, snapshot := range snapshots {
+				values = append(values, fmt.Sprintf("%s\t%s (%s, %s)", snapshot.ID, snapshot.Name, snapshot.CollectionName, snapshot.CreatedAt.Format(time.DateOnly)))
+			}
+			return values, nil
+		})
+		return filterCompletions(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// snapshotKeyIDCompletion completes snapshot encryption key IDs, including
+// rotated keys, which are still needed to restore older snapshots.
+func snapshotKeyIDCompletion(env *Environment, auth *authFlags) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		envCtx, err := requireEnvironment(env)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		tenantClient, tenantID, err := completionClient(envCtx, auth)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		values := cachedCompletions(envCtx, tenantID, "", "snapshot-key-ids", func(ctx context.Context) ([]string, error) {
+			keys, err := tenantClient.ListSnapshotKeys(ctx)
+			if err != nil {
+				return nil, err
+			}
+			values := make([]string, 0, len(keys))
+			for _, key := range keys {
+				values = append(values, fmt.Sprintf("%s\t%s (%s, v%d)", key.ID, key.Name, key.Status, key.Version))
+			}
+			return values, nil
+		})
+		return filterCompletions(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestDynamicCompletions(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	query := srv.AddSavedQuery(clientpkg.SavedQuery{Name: "active-users", Collection: "users"})
+	var snapshotLists atomic.Int32
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/snapshots" {
+			snapshotLists.Add(1)
+			_ = json.NewEncoder(w).Encode(clientpkg.SnapshotListResponse{Items: []clientpkg.Snapshot{
+				{ID: "snap_1", Name: "nightly", CollectionName: "users", CreatedAt: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)},
+				{ID: "snap_2", Name: "before-migration", CollectionName: "orders", CreatedAt: time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC)},
+			}})
+			return
+		}
+		tenantRoutes.ServeHTTP(w, r)
+	})
+
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	cfg := &configpkg.Config{Endpoint: srv.URL}
+	if err := cfg.Save(filepath.Join(configDir, "tdb", "config.yaml")); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	complete := func(args ...string) []string {
+		t.Helper()
+		root := NewRootCommand()
+		root.SetArgs(append([]string{"__complete"}, args...))
+		var out bytes.Buffer
+		root.SetOut(&out)
+		root.SetErr(&bytes.Buffer{})
+		if err := root.Execute(); err != nil {
+			t.Fatalf("complete %v: %v", args, err)
+		}
+		var values []string
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			if !strings.HasPrefix(line, ":") {
+				values = append(values, line)
+			}
+		}
+		return values
+	}
+	auth := []string{"--tenant", "tn_test", "--api-key", clienttest.APIKey}
+
+	got := complete(append([]string{"tenant", "queries", "get"}, append(auth, "")...)...)
+	if len(got) != 1 || got[0] != query.ID+"\tactive-users" {
+		t.Fatalf("unexpected saved query completions %q", got)
+	}
+	got = complete(append([]string{"tenant", "queries", "execute", "--by-name"}, append(auth, "act")...)...)
+	if len(got) != 1 || got[0] != "active-users" {
+		t.Fatalf("unexpected saved query name completions %q", got)
+	}
+
+	got = complete(append([]string{"tenant", "snapshots", "delete"}, append(auth, "--snapshot", "snap_2")...)...)
+	if len(got) != 1 || got[0] != "snap_2\tbefore-migration (orders, 2025-05-02)" {
+		t.Fatalf("unexpected snapshot completions %q", got)
+	}
+	complete(append([]string{"tenant", "snapshots", "get"}, append(auth, "--snapshot", "")...)...)
+	if n := snapshotLists.Load(); n != 1 {
+		t.Fatalf("expected the second completion to be served from the cache, got %d API calls", n)
+	}
+}
+
+func TestDynamicCompletionsWithStoredAppScope(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	query := srv.AddSavedQuery(clientpkg.SavedQuery{Name: "active-users", Collection: "users"})
+
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	cfg := &configpkg.Config{
+		Endpoint:      srv.URL,
+		DefaultTenant: "tn_test",
+		Tenants: map[string]configpkg.TenantConfig{
+			"tn_test": {DefaultKey: "main", Keys: map[string]configpkg.APIKeyEntry{"main": {Key: clienttest.APIKey, AppID: "app_1"}}},
+		},
+	}
+	if err := cfg.Save(filepath.Join(configDir, "tdb", "config.yaml")); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+
+	root := NewRootCommand()
+	root.SetArgs([]string{"__complete", "tenant", "queries", "get", ""})
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&bytes.Buffer{})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if strings.Contains(out.String(), "Using stored app scope") {
+		t.Fatalf("scope notice leaked into completions:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), query.ID+"\tactive-users") {
+		t.Fatalf("expected saved query completion:\n%s", out.String())
+	}
+}
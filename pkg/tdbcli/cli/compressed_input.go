@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// readPayloadSource reads a --file or --stdin payload, decompressing gzip and
+// zstd input on the fly. Compression is detected from the leading magic bytes
+// so piped dumps work too; name is the file path ("" for stdin) and is only
+// used to reject files whose .gz/.zst extension does not match their content.
+func readPayloadSource(name string, r io.Reader) ([]byte, error) {
+	src, err := openPayloadSource(name, r)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+	content, err := io.ReadAll(src)
+	if err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// openPayloadSource wraps r in a streaming decompressor when it starts with
+// gzip or zstd magic bytes, so compressed input is never held in memory.
+func openPayloadSource(name string, r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(len(zstdMagic))
+	ext := strings.ToLower(filepath.Ext(name))
+	switch {
+	case bytes.HasPrefix(head, gzipMagic):
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("decompress gzip input: %w", err)
+		}
+		return errorPrefixReader{gz, "decompress gzip input"}, nil
+	case bytes.HasPrefix(head, zstdMagic):
+		zr, err := zstd.NewReader(br, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("decompress zstd input: %w", err)
+		}
+		return errorPrefixReader{zstdReadCloser{zr}, "decompress zstd input"}, nil
+	case ext == ".gz" || ext == ".gzip":
+		return nil, fmt.Errorf("%s has a %s extension but is not gzip data", name, ext)
+	case ext == ".zst" || ext == ".zstd":
+		return nil, fmt.Errorf("%s has a %s extension but is not zstd data", name, ext)
+	}
+	return io.NopCloser(br), nil
+}
+
+// zstdReadCloser adapts a zstd decoder, whose Close returns nothing, to
+// io.ReadCloser.
+type zstdReadCloser struct {
+	*zstd.Decoder
+}
+
+func (z zstdReadCloser) Close() error {
+	z.Decoder.Close()
+	return nil
+}
+
+// errorPrefixReader labels read errors so a corrupt stream says which
+// decompressor failed.
+type errorPrefixReader struct {
+	io.ReadCloser
+	prefix string
+}
+
+func (r errorPrefixReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if err != nil && err != io.EOF {
+		err = fmt.Errorf("%s: %w", r.prefix, err)
+	}
+	return n, err
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func gzipBytes(t *testing.T, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadPayloadSourceDetectsCompression(t *testing.T) {
+	got, err := readPayloadSource("", bytes.NewReader(gzipBytes(t, `[{"a":1}]`)))
+	if err != nil || string(got) != `[{"a":1}]` {
+		t.Fatalf("gzip stdin: got %q, %v", got, err)
+	}
+	got, err = readPayloadSource("plain.json", strings.NewReader(`{"a":1}`))
+	if err != nil || string(got) != `{"a":1}` {
+		t.Fatalf("plain input: got %q, %v", got, err)
+	}
+	if _, err := readPayloadSource("dump.json.gz", strings.NewReader(`{"a":1}`)); err == nil || !strings.Contains(err.Error(), "not gzip data") {
+		t.Fatalf("expected extension mismatch error, got %v", err)
+	}
+
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compressed := enc.EncodeAll([]byte(`[{"z":1}]`), nil)
+	got, err = readPayloadSource("dump.json.zst", bytes.NewReader(compressed))
+	if err != nil || string(got) != `[{"z":1}]` {
+		t.Fatalf("zstd input: got %q, %v", got, err)
+	}
+	if _, err := readPayloadSource("dump.json.zst", bytes.NewReader(compressed[:len(compressed)-4])); err == nil || !strings.Contains(err.Error(), "decompress zstd input") {
+		t.Fatalf("expected a truncated zstd stream to fail, got %v", err)
+	}
+}
+
+func TestBulkCreateAndSyncAcceptGzipInput(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "products"})
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+
+	cmd := newTenantDocumentsBulkCreateCommand(env)
+	cmd.SetArgs([]string{"products", "--tenant", "tn_test", "--api-key", clienttest.APIKey, "--stdin"})
+	cmd.SetIn(bytes.NewReader(gzipBytes(t, `[{"sku":"a"},{"sku":"b"}]`)))
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("bulk-create from gzipped stdin failed: %v\n%s", err, out.String())
+	}
+	if n := len(srv.Documents("products")); n != 2 {
+		t.Fatalf("expected 2 documents, got %d", n)
+	}
+
+	path := filepath.Join(t.TempDir(), "products.json.gz")
+	if err := os.WriteFile(path, gzipBytes(t, `[{"sku":"c"}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, errOut, err := runCommandWithEnv(t, env, newTenantDocumentsSyncCommand, "products", "--file", path, "--key-field", "sku"); err != nil {
+		t.Fatalf("sync from .gz file failed: %v\n%s", err, errOut)
+	}
+	if n := len(srv.Documents("products")); n != 3 {
+		t.Fatalf("expected 3 documents after sync, got %d", n)
+	}
+}
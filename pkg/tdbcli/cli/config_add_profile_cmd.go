@@ -0,0 +1,225 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const maxSharedProfileSize = 1 << 20
+
+// sharedProfile is a team-published profile definition. It names the key a
+// member should use but never carries secrets; unknown fields (such as a raw
+// api_key or admin_secret) are rejected when it is parsed.
+type sharedProfile struct {
+	Name     string           `yaml:"name"`
+	Endpoint string           `yaml:"endpoint"`
+	Tenant   string           `yaml:"tenant"`
+	AppID    string           `yaml:"app_id"`
+	ReadOnly bool             `yaml:"read_only"`
+	Key      sharedProfileKey `yaml:"key"`
+}
+
+// sharedProfileKey refers to the API key by its local alias and prefix.
+type sharedProfileKey struct {
+	Alias       string `yaml:"alias"`
+	Prefix      string `yaml:"prefix"`
+	Description string `yaml:"description"`
+}
+
+func newConfigAddProfileCommand(env *Environment) *cobra.Command {
+	var fromURL string
+	var verifySHA string
+	var replaceEndpoint bool
+	var use bool
+
+	cmd := &cobra.Command{
+		Use:   "add-profile --from-url URL",
+		Short: "Add a team-shared profile definition from a URL",
+		Long: `Fetch a shared profile definition and merge it into the local config.
+
+A shared profile names the endpoint, tenant, application, and the API key to use
+(by alias and prefix) but contains no secrets; definitions with extra fields,
+such as a raw key, are rejected. Pass --verify-sha with the published SHA-256 of
+the file to make sure it has not been tampered with.
+
+The tenant is added (existing keys are kept). When a key with the referenced
+alias is already stored it becomes the tenant default; otherwise the command
+prints the store-key invocation that completes the setup. A profile with
+read_only: true makes the tenant read-only: commands using it refuse to modify
+data. Re-adding a profile never lifts read-only mode.
+
+Profile format:
+  name: Production (read-only)
+  endpoint: https://tdb.example.com
+  tenant: tn_123
+  app_id: app_reporting        # optional
+  read_only: true              # optional
+  key:
+    alias: prod-readonly
+    prefix: tdb_ab12           # optional
+    description: Reporting key # optional`,
+		Example: `  # Onboard with the team's read-only production profile
+  tdb config add-profile --from-url https://internal/configs/prod-readonly.yaml \
+    --verify-sha 3f5a...e91c
+
+  # Add it and make it the active tenant
+  tdb config add-profile --from-url https://internal/configs/prod-readonly.yaml --use`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target := strings.TrimSpace(fromURL)
+			if target == "" {
+				return errors.New("--from-url is required")
+			}
+			if !strings.HasPrefix(target, "http://") && !strings.HasPrefix(target, "https://") {
+				return errors.New("--from-url must be an http(s) URL")
+			}
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			raw, err := fetchSharedProfile(cmd.Context(), target)
+			if err != nil {
+				return err
+			}
+			if expected := strings.ToLower(strings.TrimSpace(verifySHA)); expected != "" {
+				sum := sha256.Sum256(raw)
+				if actual := hex.EncodeToString(sum[:]); actual != expected {
+					return fmt.Errorf("profile checksum mismatch: expected %s, got %s", expected, actual)
+				}
+			} else {
+				fmt.Fprintln(cmd.ErrOrStderr(), "Warning: --verify-sha not given; the profile contents are not verified")
+			}
+			profile, err := parseSharedProfile(raw)
+			if err != nil {
+				return err
+			}
+
+			cfg := envCtx.Config
+			current := strings.TrimRight(strings.TrimSpace(cfg.Endpoint), "/")
+			if current != "" && current != profile.Endpoint && !replaceEndpoint {
+				return fmt.Errorf("config endpoint is %s but the profile uses %s (re-run with --replace-endpoint to switch)", current, profile.Endpoint)
+			}
+			cfg.Endpoint = profile.Endpoint
+
+			tc := cfg.EnsureTenant(profile.Tenant)
+			if tc.Name == "" {
+				tc.Name = profile.Name
+			}
+			if profile.ReadOnly {
+				tc.ReadOnly = true
+			}
+			alias := profile.Key.Alias
+			entry, stored := tc.Keys[alias]
+			stored = stored && strings.TrimSpace(entry.Key) != ""
+			if stored {
+				if entry.AppID == "" {
+					entry.AppID = profile.AppID
+				}
+				if entry.Prefix == "" {
+					entry.Prefix = profile.Key.Prefix
+				}
+				if entry.Description == "" {
+					entry.Description = profile.Key.Description
+				}
+				tc.Keys[alias] = entry
+				tc.DefaultKey = alias
+			}
+			cfg.UpdateTenant(profile.Tenant, tc)
+			if use || strings.TrimSpace(cfg.DefaultTenant) == "" {
+				cfg.DefaultTenant = profile.Tenant
+			}
+			if err := envCtx.Save(); err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Added profile %s (tenant %s, endpoint %s)\n", tc.Name, profile.Tenant, profile.Endpoint)
+			if tc.ReadOnly {
+				fmt.Fprintln(out, "The tenant is read-only; commands that modify its data are refused")
+			}
+			if stored {
+				fmt.Fprintf(out, "Using stored key %s as the tenant default\n", alias)
+				return nil
+			}
+			hint := fmt.Sprintf("tdb config store-key %s %s --stdin --default", profile.Tenant, alias)
+			if profile.Key.Prefix != "" {
+				hint += " --prefix " + profile.Key.Prefix
+			}
+			if profile.AppID != "" {
+				hint += " --app-id " + profile.AppID
+			}
+			fmt.Fprintf(out, "Key %s is not stored yet; paste it into:\n  %s\n", alias, hint)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&fromURL, "from-url", "", "URL of the shared profile definition (YAML)")
+	cmd.Flags().StringVar(&verifySHA, "verify-sha", "", "Expected SHA-256 (hex) of the profile file")
+	cmd.Flags().BoolVar(&replaceEndpoint, "replace-endpoint", false, "Switch the configured endpoint to the profile's endpoint")
+	cmd.Flags().BoolVar(&use, "use", false, "Make the profile's tenant the default tenant")
+
+	return cmd
+}
+
+func fetchSharedProfile(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := (&http.Client{Timeout: 30 * time.Second}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch profile: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("fetch profile: %s", resp.Status)
+	}
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxSharedProfileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("fetch profile: %w", err)
+	}
+	if len(raw) > maxSharedProfileSize {
+		return nil, fmt.Errorf("profile exceeds %d bytes", maxSharedProfileSize)
+	}
+	return raw, nil
+}
+
+// parseSharedProfile decodes and validates a shared profile definition.
+func parseSharedProfile(raw []byte) (sharedProfile, error) {
+	var profile sharedProfile
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(&profile); err != nil {
+		return sharedProfile{}, fmt.Errorf("parse profile (shared profiles may only contain name, endpoint, tenant, app_id, read_only and key alias/prefix/description): %w", err)
+	}
+	profile.Name = strings.TrimSpace(profile.Name)
+	profile.Endpoint = strings.TrimRight(strings.TrimSpace(profile.Endpoint), "/")
+	profile.Tenant = strings.TrimSpace(profile.Tenant)
+	profile.AppID = strings.TrimSpace(profile.AppID)
+	profile.Key.Alias = strings.TrimSpace(profile.Key.Alias)
+	profile.Key.Prefix = strings.TrimSpace(profile.Key.Prefix)
+	profile.Key.Description = strings.TrimSpace(profile.Key.Description)
+	switch {
+	case profile.Endpoint == "":
+		return sharedProfile{}, errors.New("profile is missing endpoint")
+	case profile.Tenant == "":
+		return sharedProfile{}, errors.New("profile is missing tenant")
+	case profile.Key.Alias == "":
+		return sharedProfile{}, errors.New("profile is missing key.alias")
+	}
+	if profile.Name == "" {
+		profile.Name = profile.Tenant
+	}
+	return profile, nil
+}
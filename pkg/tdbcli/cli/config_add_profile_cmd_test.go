@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+const testSharedProfile = `name: Production (read-only)
+endpoint: https://tdb.example.com/
+tenant: tn_prod
+app_id: app_reporting
+read_only: true
+key:
+  alias: prod-readonly
+  prefix: tdb_ab12
+`
+
+func serveSharedProfile(t *testing.T, body string) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL + "/prod-readonly.yaml"
+}
+
+func runConfigAddProfile(env *Environment, args ...string) (string, error) {
+	cmd := newConfigAddProfileCommand(env)
+	cmd.SilenceUsage = true
+	cmd.SetArgs(args)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestConfigAddProfileMergesSharedProfile(t *testing.T) {
+	url := serveSharedProfile(t, testSharedProfile)
+	sum := sha256.Sum256([]byte(testSharedProfile))
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	env := &Environment{ConfigPath: cfgPath, Config: &configpkg.Config{Tenants: map[string]configpkg.TenantConfig{}}}
+
+	out, err := runConfigAddProfile(env, "--from-url", url, "--verify-sha", hex.EncodeToString(sum[:]))
+	if err != nil {
+		t.Fatalf("add-profile failed: %v", err)
+	}
+	if !strings.Contains(out, "tdb config store-key tn_prod prod-readonly --stdin --default --prefix tdb_ab12 --app-id app_reporting") {
+		t.Fatalf("expected store-key hint, got %s", out)
+	}
+	saved, err := configpkg.Load(cfgPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if saved.Endpoint != "https://tdb.example.com" || saved.DefaultTenant != "tn_prod" || saved.Tenants["tn_prod"].Name != "Production (read-only)" || !saved.Tenants["tn_prod"].ReadOnly {
+		t.Fatalf("unexpected config: %+v", saved)
+	}
+
+	// Once the key is stored locally, re-adding the profile makes it the default.
+	env.Config.Tenants["tn_prod"].Keys["prod-readonly"] = configpkg.APIKeyEntry{Key: "tdb_ab12_secret"}
+	out, err = runConfigAddProfile(env, "--from-url", url)
+	if err != nil {
+		t.Fatalf("add-profile failed: %v", err)
+	}
+	if !strings.Contains(out, "Using stored key prod-readonly") {
+		t.Fatalf("expected stored key to be used, got %s", out)
+	}
+	entry := env.Config.Tenants["tn_prod"].Keys["prod-readonly"]
+	if env.Config.Tenants["tn_prod"].DefaultKey != "prod-readonly" || entry.AppID != "app_reporting" || entry.Prefix != "tdb_ab12" {
+		t.Fatalf("unexpected tenant config: %+v", env.Config.Tenants["tn_prod"])
+	}
+}
+
+func TestConfigAddProfileRejectsUnsafeProfiles(t *testing.T) {
+	cases := map[string]struct {
+		body string
+		args []string
+		cfg  configpkg.Config
+		want string
+	}{
+		"checksum mismatch": {
+			body: testSharedProfile,
+			args: []string{"--verify-sha", strings.Repeat("0", 64)},
+			want: "checksum mismatch",
+		},
+		"raw secret": {
+			body: testSharedProfile + "  key: tdb_ab12_secret\n",
+			want: "shared profiles may only contain",
+		},
+		"endpoint conflict": {
+			body: testSharedProfile,
+			cfg:  configpkg.Config{Endpoint: "https://other.example.com"},
+			want: "--replace-endpoint",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := tc.cfg
+			env := &Environment{ConfigPath: filepath.Join(t.TempDir(), "config.yaml"), Config: &cfg}
+			args := append([]string{"--from-url", serveSharedProfile(t, tc.body)}, tc.args...)
+			if _, err := runConfigAddProfile(env, args...); err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
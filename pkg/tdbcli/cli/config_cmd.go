@@ -28,6 +28,7 @@ func registerConfigCommands(root *cobra.Command, env *Environment) {
 	cfgCmd.AddCommand(newConfigUseCommand(env))
 	cfgCmd.AddCommand(newConfigSwitchCommand(env))
 	cfgCmd.AddCommand(newConfigListCommand(env))
+	cfgCmd.AddCommand(newConfigAddProfileCommand(env))
 
 	root.AddCommand(cfgCmd)
 }
@@ -36,7 +37,7 @@ func newConfigShowCommand(env *Environment) *cobra.Command {
 	return &cobra.Command{
 		Use:   "show",
 		Short: "Print the current CLI config as YAML",
-		Long:  `Display the current TinyDB CLI configuration including endpoint, stored API keys, and tenant settings. Admin secrets are masked for security.`,
+		Long:  `Display the current TinyDB CLI configuration including endpoint, stored API keys, and tenant settings. Admin secrets and extra_headers values are masked for security.`,
 		Example: `  # Show current configuration
   tdb config show
 
@@ -49,6 +50,7 @@ func newConfigShowCommand(env *Environment) *cobra.Command {
 			}
 			display := *env.Config
 			display.AdminSecret = env.Config.MaskedAdminSecret()
+			display.ExtraHeaders = env.Config.MaskedExtraHeaders()
 			data, err := yaml.Marshal(display)
 			if err != nil {
 				return err
@@ -170,7 +172,7 @@ func newConfigDeleteKeyCommand(env *Environment) *cobra.Command {
 func newConfigSetCommand(env *Environment) *cobra.Command {
 	return &cobra.Command{
 		Use:   "set <field> [values...]",
-		Short: "Update core CLI settings (endpoint, admin-secret, api-key, default-key, tenant-name, default-tenant)",
+		Short: "Update core CLI settings (endpoint, admin-secret, api-key, default-key, tenant-name, default-tenant, time-format, timezone, language, safe-mode, read-only, warn-latency, warn-size)",
 		Args:  cobra.MinimumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			envCtx, err := requireEnvironment(env)
@@ -225,11 +227,11 @@ func newConfigSetCommand(env *Environment) *cobra.Command {
 				if err != nil {
 					return err
 				}
-				tenantClient, err := clientpkg.NewTenantClient(endpoint, apiKey)
+				tenantClient, err := clientpkg.NewTenantClient(endpoint, apiKey, clientOptions()...)
 				if err != nil {
 					return fmt.Errorf("create tenant client: %w", err)
 				}
-				status, err := tenantClient.AuthStatus(cmd.Context(), "")
+				status, err := cachedAuthStatus(cmd.Context(), envCtx, tenantClient, apiKey, "", false)
 				if err != nil {
 					return fmt.Errorf("api key verification failed: %w", err)
 				}
@@ -284,8 +286,127 @@ func newConfigSetCommand(env *Environment) *cobra.Command {
 					return err
 				}
 				fmt.Fprintf(cmd.OutOrStdout(), "Tenant %s labeled as %s\n", tenantID, name)
+			case "time-format", "time_format":
+				if len(args) != 2 {
+					return errors.New("usage: tdb config set time-format <local|rfc3339|unix|relative>")
+				}
+				format, err := parseTimeFormat(args[1])
+				if err != nil {
+					return err
+				}
+				envCtx.Config.TimeFormat = format
+				if err := envCtx.Save(); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Time format set to %s\n", format)
+			case "timezone":
+				if len(args) != 2 {
+					return errors.New("usage: tdb config set timezone <zone>")
+				}
+				zone := strings.TrimSpace(args[1])
+				if _, err := parseTimezone(zone); err != nil {
+					return err
+				}
+				envCtx.Config.Timezone = zone
+				if err := envCtx.Save(); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Timezone set to %s\n", zone)
+			case "language", "lang":
+				if len(args) != 2 {
+					return errors.New("usage: tdb config set language <en|km|zh>")
+				}
+				language, err := parseLanguage(args[1])
+				if err != nil {
+					return err
+				}
+				envCtx.Config.Language = language
+				if err := envCtx.Save(); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Language set to %s\n", language)
+			case "safe-mode", "safe_mode":
+				if len(args) != 2 {
+					return errors.New("usage: tdb config set safe-mode <on|off>")
+				}
+				switch strings.ToLower(strings.TrimSpace(args[1])) {
+				case "on", "true", "yes":
+					envCtx.Config.SafeMode = true
+				case "off", "false", "no":
+					envCtx.Config.SafeMode = false
+				default:
+					return fmt.Errorf("invalid safe-mode value %q: expected on or off", args[1])
+				}
+				if err := envCtx.Save(); err != nil {
+					return err
+				}
+				if envCtx.Config.SafeMode {
+					fmt.Fprintln(cmd.OutOrStdout(), "Safe mode enabled; destructive commands now ask for confirmation")
+				} else {
+					fmt.Fprintln(cmd.OutOrStdout(), "Safe mode disabled")
+				}
+			case "read-only", "read_only":
+				if len(args) != 2 {
+					return errors.New("usage: tdb config set read-only <on|off>")
+				}
+				switch strings.ToLower(strings.TrimSpace(args[1])) {
+				case "on", "true", "yes":
+					envCtx.Config.ReadOnly = true
+				case "off", "false", "no":
+					envCtx.Config.ReadOnly = false
+				default:
+					return fmt.Errorf("invalid read-only value %q: expected on or off", args[1])
+				}
+				if err := envCtx.Save(); err != nil {
+					return err
+				}
+				if envCtx.Config.ReadOnly {
+					fmt.Fprintln(cmd.OutOrStdout(), "Read-only mode enabled; commands that modify data are now refused")
+				} else {
+					fmt.Fprintln(cmd.OutOrStdout(), "Read-only mode disabled")
+				}
+			case "warn-latency", "warn_latency":
+				if len(args) != 2 {
+					return errors.New("usage: tdb config set warn-latency <duration|0>")
+				}
+				latency, err := parseWarnLatency(args[1])
+				if err != nil {
+					return err
+				}
+				envCtx.Config.WarnLatency = ""
+				if latency > 0 {
+					envCtx.Config.WarnLatency = latency.String()
+				}
+				if err := envCtx.Save(); err != nil {
+					return err
+				}
+				if latency > 0 {
+					fmt.Fprintf(cmd.OutOrStdout(), "Latency warning threshold set to %s\n", latency)
+				} else {
+					fmt.Fprintln(cmd.OutOrStdout(), "Latency warnings disabled")
+				}
+			case "warn-size", "warn_size":
+				if len(args) != 2 {
+					return errors.New("usage: tdb config set warn-size <size|0>")
+				}
+				size, err := parseWarnSize(args[1])
+				if err != nil {
+					return err
+				}
+				envCtx.Config.WarnSize = ""
+				if size > 0 {
+					envCtx.Config.WarnSize = strings.TrimSpace(args[1])
+				}
+				if err := envCtx.Save(); err != nil {
+					return err
+				}
+				if size > 0 {
+					fmt.Fprintf(cmd.OutOrStdout(), "Response size warning threshold set to %s\n", envCtx.Config.WarnSize)
+				} else {
+					fmt.Fprintln(cmd.OutOrStdout(), "Response size warnings disabled")
+				}
 			default:
-				return fmt.Errorf("unknown config field %q; supported values: endpoint, admin-secret, api-key, default-key, tenant-name, default-tenant", field)
+				return fmt.Errorf("unknown config field %q; supported values: endpoint, admin-secret, api-key, default-key, tenant-name, default-tenant, time-format, timezone, language, safe-mode, read-only, warn-latency, warn-size", field)
 			}
 			return nil
 		},
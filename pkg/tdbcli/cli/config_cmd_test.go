@@ -2,13 +2,14 @@ package cli
 
 import (
 	"bytes"
-	"fmt"
 	"net/http"
-	"net/http/httptest"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
 	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
 )
 
@@ -57,28 +58,29 @@ func TestConfigSetAPIKey(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			apiKey := "test-api-key"
+			apiKey := clienttest.APIKey
+			srv := clienttest.NewServer(t)
+			srv.SetAuthStatus(clientpkg.AuthStatus{
+				TenantID:   tc.tenantID,
+				TenantName: tc.tenantName,
+				AppID:      tc.appID,
+				AppName:    tc.appName,
+				Status:     "active",
+				Scope:      tc.scope,
+				KeyPrefix:  tc.keyPrefix,
+			})
 			var receivedKey string
-
-			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				if r.URL.Path != "/api/me" {
-					t.Fatalf("unexpected path: %s", r.URL.Path)
-				}
-				if r.Method != http.MethodGet {
-					t.Fatalf("unexpected method: %s", r.Method)
-				}
+			routes := srv.Config.Handler
+			srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				receivedKey = r.Header.Get("X-API-Key")
-				w.Header().Set("Content-Type", "application/json")
-				fmt.Fprintf(w, `{"tenant_id":"%s","tenant_name":"%s","app_id":"%s","app_name":"%s","status":"active","scope":"%s","key_prefix":"%s"}`,
-					tc.tenantID, tc.tenantName, tc.appID, tc.appName, tc.scope, tc.keyPrefix)
-			}))
-			defer server.Close()
+				routes.ServeHTTP(w, r)
+			})
 
 			cfgPath := filepath.Join(t.TempDir(), "config.yaml")
 			env := &Environment{
 				ConfigPath: cfgPath,
 				Config: &configpkg.Config{
-					Endpoint: server.URL,
+					Endpoint: srv.URL,
 				},
 			}
 
@@ -94,6 +96,9 @@ func TestConfigSetAPIKey(t *testing.T) {
 				t.Fatalf("cmd.Execute() error = %v, output: %s", err, out.String())
 			}
 
+			if got := srv.Requests(); !slices.Equal(got, []string{"GET /api/me"}) {
+				t.Fatalf("expected a single key status check, got %v", got)
+			}
 			if receivedKey != apiKey {
 				t.Fatalf("expected api key header %q, got %q", apiKey, receivedKey)
 			}
@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+// configReloadDebounce collapses the burst of events an editor or an atomic
+// rename produces into one reload.
+const configReloadDebounce = 250 * time.Millisecond
+
+// configWatcher lets long-running commands pick up config file edits, such
+// as a rotated API key or a new endpoint, without a restart. It watches the
+// config file with fsnotify and signals changes(); commands then call reload,
+// which swaps env.Config when the contents really differ. --endpoint and
+// --admin-secret overrides given on the command line survive a reload.
+type configWatcher struct {
+	env                 *Environment
+	path                string
+	sum                 [sha256.Size]byte
+	endpointOverride    string
+	adminSecretOverride string
+
+	fsw     *fsnotify.Watcher
+	changed chan struct{}
+	once    sync.Once
+}
+
+// newConfigWatcher snapshots the config file behind env and starts watching
+// it. It returns nil when the environment was not loaded from a file, which
+// makes every method a no-op. When the file cannot be watched a warning is
+// written to warn and reload still works when called explicitly.
+func newConfigWatcher(env *Environment, warn func(error)) *configWatcher {
+	if env == nil || env.Config == nil || strings.TrimSpace(env.ConfigPath) == "" {
+		return nil
+	}
+	w := &configWatcher{env: env, path: env.ConfigPath, changed: make(chan struct{}, 1)}
+	raw, _ := readConfigFile(w.path)
+	w.sum = sha256.Sum256(raw)
+	if onDisk, err := configpkg.Load(w.path); err == nil {
+		if env.Config.Endpoint != onDisk.Endpoint {
+			w.endpointOverride = env.Config.Endpoint
+		}
+		if env.Config.AdminSecret != onDisk.AdminSecret {
+			w.adminSecretOverride = env.Config.AdminSecret
+		}
+	}
+	if err := w.watch(); err != nil && warn != nil {
+		warn(fmt.Errorf("cannot watch %s for changes: %w", w.path, err))
+	}
+	return w
+}
+
+// watch follows the directory holding the config file, so replacing the file
+// by rename (as editors and config set do) is noticed too.
+func (w *configWatcher) watch() error {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := fsw.Add(filepath.Dir(w.path)); err != nil {
+		_ = fsw.Close()
+		return err
+	}
+	w.fsw = fsw
+	name := filepath.Clean(w.path)
+	go func() {
+		var debounce *time.Timer
+		for {
+			select {
+			case event, ok := <-fsw.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != name || event.Op == fsnotify.Chmod {
+					continue
+				}
+				if debounce != nil {
+					debounce.Stop()
+				}
+				debounce = time.AfterFunc(configReloadDebounce, func() {
+					select {
+					case w.changed <- struct{}{}:
+					default:
+					}
+				})
+			case _, ok := <-fsw.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// changes delivers a value after the config file was modified. It never
+// fires for a nil watcher.
+func (w *configWatcher) changes() <-chan struct{} {
+	if w == nil {
+		return nil
+	}
+	return w.changed
+}
+
+// Close stops watching the file.
+func (w *configWatcher) Close() {
+	if w == nil || w.fsw == nil {
+		return
+	}
+	w.once.Do(func() { _ = w.fsw.Close() })
+}
+
+// reload reloads the config when the file changed since the last load and
+// reports whether it did. On a parse error the current config stays active.
+func (w *configWatcher) reload() (bool, error) {
+	if w == nil {
+		return false, nil
+	}
+	raw, err := readConfigFile(w.path)
+	if err != nil {
+		return false, err
+	}
+	sum := sha256.Sum256(raw)
+	if bytes.Equal(sum[:], w.sum[:]) {
+		return false, nil
+	}
+	cfg, err := configpkg.Load(w.path)
+	if err != nil {
+		return false, err
+	}
+	if w.endpointOverride != "" {
+		cfg.Endpoint = w.endpointOverride
+	}
+	if w.adminSecretOverride != "" {
+		cfg.AdminSecret = w.adminSecretOverride
+	}
+	w.sum = sum
+	w.env.Config = cfg
+	return true, nil
+}
+
+// reloadTenantClient reloads the config and resolves a new tenant client from
+// it, logging the outcome to stderr. It returns nil when nothing changed or
+// the new config is unusable, in which case the current client stays active.
+func (w *configWatcher) reloadTenantClient(cmd *cobra.Command, auth *authFlags) *clientpkg.TenantClient {
+	reloaded, err := w.reload()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Config reload failed, keeping current credentials: %v\n", err)
+		return nil
+	}
+	if !reloaded {
+		return nil
+	}
+	next, _, _, err := auth.resolveTenantClient(w.env, cmd)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Config reloaded but credentials could not be resolved, keeping current ones: %v\n", err)
+		return nil
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Config file changed; reloaded credentials from %s\n", w.path)
+	return next
+}
+
+// readConfigFile reads the config file, treating a missing file as empty
+// like configpkg.Load does.
+func readConfigFile(path string) ([]byte, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return raw, err
+}
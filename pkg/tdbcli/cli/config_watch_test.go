@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestConfigWatcherReloadKeepsFlagOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := (&configpkg.Config{Endpoint: "https://old.example.com", AdminSecret: "s1"}).Save(path); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	env := &Environment{ConfigPath: path, Config: &configpkg.Config{Endpoint: "http://localhost:8080", AdminSecret: "s1"}}
+	watcher := newConfigWatcher(env, func(err error) { t.Errorf("watch: %v", err) })
+	defer watcher.Close()
+
+	if reloaded, err := watcher.reload(); err != nil || reloaded {
+		t.Fatalf("expected no reload for an unchanged file, got %v, %v", reloaded, err)
+	}
+	if err := (&configpkg.Config{Endpoint: "https://new.example.com", AdminSecret: "s2"}).Save(path); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	select {
+	case <-watcher.changes():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected a change notification after saving the config")
+	}
+	reloaded, err := watcher.reload()
+	if err != nil || !reloaded {
+		t.Fatalf("expected reload, got %v, %v", reloaded, err)
+	}
+	if env.Config.Endpoint != "http://localhost:8080" {
+		t.Fatalf("expected --endpoint override to survive reload, got %s", env.Config.Endpoint)
+	}
+	if env.Config.AdminSecret != "s2" {
+		t.Fatalf("expected admin secret from the file, got %s", env.Config.AdminSecret)
+	}
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+func newDevCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dev",
+		Short: "Tools for developing the CLI itself",
+	}
+	cmd.AddCommand(newDevVerifyAPICommand())
+	return cmd
+}
+
+func newDevVerifyAPICommand() *cobra.Command {
+	var specPath string
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "verify-api",
+		Short: "Check the client's endpoints and fields against an OpenAPI spec",
+		Long: `Compare every endpoint the Go client calls (pkg/tdbcli/client) with an
+OpenAPI 3 spec (YAML or JSON) published by a server release, and report drift
+before users hit it at runtime:
+
+  - paths or methods the spec no longer declares
+  - query parameters the client sends that the spec does not declare
+  - request and response fields the client uses that the spec lacks, with a
+    hint when a similarly named field looks like a rename
+  - required request fields the client never sends
+
+Fields the spec adds but the client ignores are not reported. The command
+exits non-zero when it finds mismatches, so it can gate CI.`,
+		Example: `  # Check against the spec shipped with a server release
+  tdb dev verify-api --openapi openapi.yaml
+
+  # Machine-readable report
+  tdb dev verify-api --openapi openapi.json --raw`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := strings.TrimSpace(specPath)
+			if path == "" {
+				return errors.New("--openapi is required")
+			}
+			content, err := os.ReadFile(filepath.Clean(path))
+			if err != nil {
+				return fmt.Errorf("read OpenAPI spec: %w", err)
+			}
+			spec, err := parseOpenAPISpec(content)
+			if err != nil {
+				return err
+			}
+			endpoints := clientpkg.Endpoints()
+			mismatches := checkClientAgainstSpec(spec, endpoints)
+			if raw {
+				if mismatches == nil {
+					mismatches = []apiMismatch{}
+				}
+				if err := printJSON(cmd, mismatches); err != nil {
+					return err
+				}
+			} else if len(mismatches) > 0 {
+				rows := make([][]string, len(mismatches))
+				for i, m := range mismatches {
+					rows[i] = []string{m.Endpoint, m.Operation, m.Problem}
+				}
+				renderTable(cmd, []string{"ENDPOINT", "OPERATION", "PROBLEM"}, rows)
+			}
+			if len(mismatches) > 0 {
+				return fmt.Errorf("%d mismatch(es) between the client and %s", len(mismatches), path)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "All %d client endpoints match %s\n", len(endpoints), path)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&specPath, "openapi", "", "Path to the server's OpenAPI 3 spec (YAML or JSON)")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print mismatches as JSON")
+	_ = cmd.MarkFlagRequired("openapi")
+	return cmd
+}
@@ -0,0 +1,14 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/diff"
+)
+
+// writeDiff prints a field-level diff to the command's output, colored when
+// the output is a terminal and NO_COLOR is unset.
+func writeDiff(cmd *cobra.Command, changes []diff.Change, indent string) error {
+	out := cmd.OutOrStdout()
+	return diff.Write(out, changes, diff.Options{Color: enableColor && isTerminalWriter(out), Indent: indent})
+}
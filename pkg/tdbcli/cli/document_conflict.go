@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const showExistingFlagUsage = "On a conflict, fetch and print the existing conflicting document"
+
+// explainConflict prints the details of a 409 conflict to stderr and, with
+// showExisting, the conflicting document. It always returns err so callers can
+// use it as `return explainConflict(...)`.
+func explainConflict(cmd *cobra.Command, tenantClient *clientpkg.TenantClient, collection, appID string, err error, showExisting bool) error {
+	var conflict *clientpkg.ConflictError
+	if !errors.As(err, &conflict) {
+		return err
+	}
+	out := cmd.ErrOrStderr()
+	if conflict.Structured() {
+		fmt.Fprintf(out, "Conflict in %s:\n", collection)
+		if conflict.Reason != "" {
+			fmt.Fprintf(out, "  Reason:    %s\n", conflict.Reason)
+		}
+		if conflict.DocumentID != "" {
+			fmt.Fprintf(out, "  Document:  %s\n", conflict.DocumentID)
+		}
+		if conflict.Key != "" {
+			fmt.Fprintf(out, "  Key:       %s\n", conflict.Key)
+		}
+		if len(conflict.Fields) > 0 {
+			fmt.Fprintf(out, "  Fields:    %s\n", strings.Join(conflict.Fields, ", "))
+		}
+	}
+	if !showExisting {
+		if conflict.Structured() {
+			fmt.Fprintln(out, "Re-run with --show-existing to print the conflicting document.")
+		}
+		return err
+	}
+	var existing *clientpkg.Document
+	var fetchErr error
+	switch {
+	case conflict.DocumentID != "":
+		existing, fetchErr = tenantClient.GetDocument(cmd.Context(), collection, conflict.DocumentID, appID)
+	case conflict.Key != "":
+		existing, fetchErr = tenantClient.GetDocumentByPrimaryKey(cmd.Context(), collection, conflict.Key, appID)
+	default:
+		fmt.Fprintln(out, "The server did not identify the conflicting document.")
+		return err
+	}
+	if fetchErr != nil {
+		fmt.Fprintf(out, "Could not fetch the existing document: %v\n", fetchErr)
+		return err
+	}
+	encoded, marshalErr := json.MarshalIndent(makeDocumentPretty(*existing), "", "  ")
+	if marshalErr != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Existing document:\n%s\n", encoded)
+	return err
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestDocumentsCreateShowsConflictDetails(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users"})
+	existing := srv.AddDocument("users", map[string]any{"email": "ada@example.com"})
+
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/api/collections/users/documents" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"error":       "duplicate primary key",
+				"code":        "duplicate_key",
+				"document_id": existing.ID,
+				"fields":      []string{"email"},
+			})
+			return
+		}
+		tenantRoutes.ServeHTTP(w, r)
+	})
+
+	run := func(args ...string) (string, error) {
+		_, errOut, err := runCommand(t, srv, newTenantDocumentsCreateCommand, append([]string{"users", "--data", `{"email":"ada@example.com"}`}, args...)...)
+		return errOut, err
+	}
+
+	stderr, err := run()
+	if err == nil || !strings.Contains(err.Error(), "conflicts with document "+existing.ID) {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+	if !strings.Contains(stderr, "Conflict in users") || !strings.Contains(stderr, "Fields:    email") || !strings.Contains(stderr, "--show-existing") {
+		t.Fatalf("unexpected conflict details:\n%s", stderr)
+	}
+
+	stderr, err = run("--show-existing")
+	if err == nil {
+		t.Fatalf("expected conflict error")
+	}
+	if !strings.Contains(stderr, "Existing document:") || !strings.Contains(stderr, "ada@example.com") {
+		t.Fatalf("expected existing document in output:\n%s", stderr)
+	}
+}
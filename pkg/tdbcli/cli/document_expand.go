@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const expandFlagUsage = "Embed referenced documents under _expanded, as field:collection pairs (e.g. author_id:users,order_id:orders)"
+
+const (
+	expandedKey             = "_expanded"
+	expandLookupConcurrency = 8
+)
+
+// documentExpansion resolves the reference stored in Field against documents
+// of Collection.
+type documentExpansion struct {
+	Field      string
+	Collection string
+}
+
+// parseExpandFlag parses --expand values such as "author_id:users,order_id:orders".
+// Fields may be dotted paths into nested data.
+func parseExpandFlag(raw string) ([]documentExpansion, error) {
+	var specs []documentExpansion
+	seen := map[string]struct{}{}
+	for _, item := range splitCommaList(raw) {
+		field, collection, ok := strings.Cut(item, ":")
+		field, collection = strings.TrimSpace(field), strings.TrimSpace(collection)
+		if !ok || field == "" || collection == "" {
+			return nil, fmt.Errorf("invalid --expand entry %q (expected field:collection)", item)
+		}
+		if _, dup := seen[field]; dup {
+			return nil, fmt.Errorf("field %s is expanded more than once", field)
+		}
+		seen[field] = struct{}{}
+		specs = append(specs, documentExpansion{Field: field, Collection: collection})
+	}
+	return specs, nil
+}
+
+// expandedDocument is what a resolved reference embeds under _expanded.
+type expandedDocument struct {
+	ID   string `json:"id"`
+	Key  string `json:"key,omitempty"`
+	Data any    `json:"data"`
+}
+
+type expandLookup struct {
+	collection string
+	ref        string
+}
+
+// expandDocuments resolves the references of every document and embeds the
+// results under _expanded in the document data. References are deduplicated
+// and batched per collection, so each distinct one is fetched once; the API
+// has no multi-document read, so a batch is fetched with bounded concurrency.
+// A reference is looked up as a document ID first and then as a primary key.
+// References that cannot be resolved embed null and are reported on warn.
+func expandDocuments(ctx context.Context, tenantClient *clientpkg.TenantClient, appID string, docs []clientpkg.Document, specs []documentExpansion, warn io.Writer) error {
+	if len(specs) == 0 || len(docs) == 0 {
+		return nil
+	}
+	decoded := make([]map[string]any, len(docs))
+	pending := map[expandLookup]struct{}{}
+	for i := range docs {
+		var data map[string]any
+		if err := json.Unmarshal([]byte(docs[i].Data), &data); err != nil || data == nil {
+			continue
+		}
+		decoded[i] = data
+		for _, spec := range specs {
+			for _, ref := range expandReferences(lookupExpandPath(data, spec.Field)) {
+				pending[expandLookup{spec.Collection, ref}] = struct{}{}
+			}
+		}
+	}
+
+	batches := map[string][]string{}
+	for lookup := range pending {
+		batches[lookup.collection] = append(batches[lookup.collection], lookup.ref)
+	}
+	collections := make([]string, 0, len(batches))
+	for collection := range batches {
+		collections = append(collections, collection)
+	}
+	sort.Strings(collections)
+
+	resolved := make(map[expandLookup]*expandedDocument, len(pending))
+	for _, collection := range collections {
+		refs := batches[collection]
+		sort.Strings(refs)
+		docs, err := fetchExpandBatch(ctx, tenantClient, appID, collection, refs)
+		if err != nil {
+			return err
+		}
+		for i, ref := range refs {
+			if docs[i] == nil {
+				fmt.Fprintf(warn, "Warning: %s %s not found; expanded as null\n", collection, ref)
+				continue
+			}
+			resolved[expandLookup{collection, ref}] = &expandedDocument{ID: docs[i].ID, Key: docs[i].Key, Data: jsonStringToInterface(docs[i].Data)}
+		}
+	}
+
+	for i, data := range decoded {
+		if data == nil {
+			continue
+		}
+		expanded := map[string]any{}
+		for _, spec := range specs {
+			value := lookupExpandPath(data, spec.Field)
+			if value == nil {
+				continue
+			}
+			if items, ok := value.([]any); ok {
+				out := make([]any, 0, len(items))
+				for _, ref := range expandReferences(items) {
+					out = append(out, resolved[expandLookup{spec.Collection, ref}])
+				}
+				expanded[spec.Field] = out
+				continue
+			}
+			refs := expandReferences(value)
+			if len(refs) == 1 {
+				expanded[spec.Field] = resolved[expandLookup{spec.Collection, refs[0]}]
+			}
+		}
+		data[expandedKey] = expanded
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		docs[i].Data = string(encoded)
+	}
+	return nil
+}
+
+// expansionSelectFields adds the top-level field of each expansion to a
+// server-side selection, so references are fetched even when --select leaves
+// them out.
+func expansionSelectFields(fields []string, specs []documentExpansion) []string {
+	for _, spec := range specs {
+		top, _, _ := strings.Cut(spec.Field, ".")
+		if !slices.Contains(fields, top) {
+			fields = append(fields, top)
+		}
+	}
+	return fields
+}
+
+// fetchExpandBatch looks up refs in collection, each as a document ID and
+// then as a primary key, with at most expandLookupConcurrency requests in
+// flight. The result is aligned with refs, with nil for references that do
+// not exist.
+func fetchExpandBatch(ctx context.Context, tenantClient *clientpkg.TenantClient, appID, collection string, refs []string) ([]*clientpkg.Document, error) {
+	docs := make([]*clientpkg.Document, len(refs))
+	errs := make([]error, len(refs))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, expandLookupConcurrency)
+	for i, ref := range refs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, ref string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			doc, err := tenantClient.GetDocument(ctx, collection, ref, appID)
+			if err != nil && isNotFoundError(err) {
+				doc, err = tenantClient.GetDocumentByPrimaryKey(ctx, collection, ref, appID)
+			}
+			if err != nil && !isNotFoundError(err) {
+				errs[i] = fmt.Errorf("expand %s %s: %w", collection, ref, err)
+				return
+			}
+			if err == nil {
+				docs[i] = doc
+			}
+		}(i, ref)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
+
+// lookupExpandPath returns the value at a dotted path, or nil.
+func lookupExpandPath(data map[string]any, path string) any {
+	var current any = data
+	for _, part := range strings.Split(path, ".") {
+		obj, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = obj[part]
+	}
+	return current
+}
+
+// expandReferences turns a reference value (string, number, or an array of
+// them) into lookup strings.
+func expandReferences(value any) []string {
+	switch typed := value.(type) {
+	case string:
+		if trimmed := strings.TrimSpace(typed); trimmed != "" {
+			return []string{trimmed}
+		}
+	case float64:
+		return []string{strconv.FormatFloat(typed, 'f', -1, 64)}
+	case []any:
+		var refs []string
+		for _, item := range typed {
+			refs = append(refs, expandReferences(item)...)
+		}
+		return refs
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestParseExpandFlag(t *testing.T) {
+	specs, err := parseExpandFlag("author_id:users, meta.order:orders")
+	if err != nil || len(specs) != 2 || specs[1] != (documentExpansion{Field: "meta.order", Collection: "orders"}) {
+		t.Fatalf("unexpected specs %+v (%v)", specs, err)
+	}
+	for _, raw := range []string{"author_id", "author_id:", "a:users,a:orders"} {
+		if _, err := parseExpandFlag(raw); err == nil {
+			t.Fatalf("expected %q to be rejected", raw)
+		}
+	}
+}
+
+func TestDocumentsListExpandsReferences(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users"})
+	srv.AddCollection(clientpkg.Collection{Name: "posts"})
+	ada := srv.AddDocument("users", map[string]any{"name": "ada"})
+	grace := srv.AddDocument("users", map[string]any{"name": "grace"})
+	srv.AddDocument("posts", map[string]any{"title": "a", "author_id": ada.ID, "reviewers": []string{grace.ID}})
+	srv.AddDocument("posts", map[string]any{"title": "b", "author_id": ada.ID})
+	srv.AddDocument("posts", map[string]any{"title": "c", "author_id": "usr_missing"})
+
+	var lookups atomic.Int32
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/collections/users/documents/") {
+			lookups.Add(1)
+		}
+		tenantRoutes.ServeHTTP(w, r)
+	})
+
+	out, stderr, err := runCommand(t, srv, newTenantDocumentsListCommand, "posts", "--sort", "created_at", "--expand", "author_id:users,reviewers:users")
+	if err != nil {
+		t.Fatalf("list failed: %v\n%s", err, stderr)
+	}
+
+	var resp struct {
+		Items []struct {
+			Data map[string]any `json:"data"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil || len(resp.Items) != 3 {
+		t.Fatalf("expected JSON output with 3 items: %v\n%s", err, out)
+	}
+	expanded := resp.Items[0].Data[expandedKey].(map[string]any)
+	author := expanded["author_id"].(map[string]any)
+	if author["id"] != ada.ID || author["data"].(map[string]any)["name"] != "ada" {
+		t.Fatalf("unexpected author expansion %v", author)
+	}
+	reviewers := expanded["reviewers"].([]any)
+	if len(reviewers) != 1 || reviewers[0].(map[string]any)["id"] != grace.ID {
+		t.Fatalf("unexpected reviewers expansion %v", reviewers)
+	}
+	if missing := resp.Items[2].Data[expandedKey].(map[string]any); missing["author_id"] != nil {
+		t.Fatalf("expected null for a missing reference, got %v", missing["author_id"])
+	}
+	if !strings.Contains(stderr, "users usr_missing not found") {
+		t.Fatalf("expected a warning for the missing reference, got %q", stderr)
+	}
+	// ada is referenced twice but fetched once; the missing ID is tried as an ID and a primary key.
+	if n := lookups.Load(); n != 3 && n != 4 {
+		t.Fatalf("expected deduplicated lookups, got %d", n)
+	}
+}
+
+func TestDocumentsListExpandsBeforeSelect(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users"})
+	srv.AddCollection(clientpkg.Collection{Name: "posts"})
+	ada := srv.AddDocument("users", map[string]any{"name": "ada"})
+	srv.AddDocument("posts", map[string]any{"title": "a", "body": "long", "author_id": ada.ID})
+
+	var selects []string
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/collections/posts/documents" {
+			selects = append(selects, r.URL.Query().Get("select"))
+		}
+		tenantRoutes.ServeHTTP(w, r)
+	})
+
+	out, stderr, err := runCommand(t, srv, newTenantDocumentsListCommand, "posts", "--select", "title", "--expand", "author_id:users")
+	if err != nil {
+		t.Fatalf("list failed: %v\n%s", err, stderr)
+	}
+	if len(selects) != 1 || selects[0] != "title,author_id" {
+		t.Fatalf("expected the reference field to be fetched, got selects %q", selects)
+	}
+	var resp struct {
+		Items []struct {
+			Data map[string]any `json:"data"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil || len(resp.Items) != 1 {
+		t.Fatalf("expected JSON output with 1 item: %v\n%s", err, out)
+	}
+	data := resp.Items[0].Data
+	author, _ := data[expandedKey].(map[string]any)["author_id"].(map[string]any)
+	if data["title"] != "a" || data["author_id"] != nil || data["body"] != nil || author["id"] != ada.ID {
+		t.Fatalf("expected title and the expanded author only, got %v", data)
+	}
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const maxDocSizeFlagUsage = "Maximum document size to accept, e.g. 8MB (0 disables the limit; defaults to the client limit of 4MB)"
+
+// parseMaxDocSize parses the --max-doc-size flag. The boolean reports whether
+// the flag was set; a zero limit means unlimited.
+func parseMaxDocSize(raw string) (int64, bool, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return 0, false, nil
+	}
+	size, err := humanize.ParseBytes(trimmed)
+	if err != nil || size > math.MaxInt64 {
+		return 0, false, fmt.Errorf("invalid --max-doc-size %q", raw)
+	}
+	return int64(size), true, nil
+}
+
+// withDocumentSizeLimit applies a --max-doc-size limit to requests made with
+// the returned context. perResponse scales the limit for responses carrying
+// several documents, such as list pages.
+func withDocumentSizeLimit(ctx context.Context, limit int64, set bool, perResponse int) context.Context {
+	if !set {
+		return ctx
+	}
+	if limit > 0 && perResponse > 1 {
+		if limit > math.MaxInt64/int64(perResponse) {
+			limit = 0
+		} else {
+			limit *= int64(perResponse)
+		}
+	}
+	return clientpkg.WithMaxDocumentSize(ctx, limit)
+}
+
+// explainDocumentSizeError turns a client size-limit error into guidance about
+// the --max-doc-size flag.
+func explainDocumentSizeError(err error) error {
+	var tooLarge *clientpkg.ResponseTooLargeError
+	if errors.As(err, &tooLarge) {
+		return fmt.Errorf("document response exceeds %s; raise --max-doc-size to fetch it: %w", humanize.IBytes(uint64(tooLarge.Limit)), err)
+	}
+	return err
+}
+
+func checkDocumentSize(id string, size int, limit int64) error {
+	if limit > 0 && int64(size) > limit {
+		return fmt.Errorf("document %s is %s, exceeding --max-doc-size %s", id, humanize.IBytes(uint64(size)), humanize.IBytes(uint64(limit)))
+	}
+	return nil
+}
+
+// copyNDJSONLines copies newline-delimited records from src to dst in bounded
+// chunks, so a multi-megabyte record never has to be held in memory. Blank
+// lines are dropped and each record is terminated by a single newline. It
+// returns the number of records copied.
+func copyNDJSONLines(dst io.Writer, src io.Reader, maxLineBytes int64, onLine func()) (int, error) {
+	reader := bufio.NewReaderSize(src, 64<<10)
+	lines := 0
+	var lineLen int64
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		if err != nil && !errors.Is(err, bufio.ErrBufferFull) && !errors.Is(err, io.EOF) {
+			return lines, err
+		}
+		lineLen += int64(len(chunk))
+		if maxLineBytes > 0 && lineLen > maxLineBytes {
+			return lines, fmt.Errorf("record %d exceeds --max-doc-size %s", lines+1, humanize.IBytes(uint64(maxLineBytes)))
+		}
+		if errors.Is(err, bufio.ErrBufferFull) {
+			if _, werr := dst.Write(chunk); werr != nil {
+				return lines, werr
+			}
+			continue
+		}
+		partial := lineLen > int64(len(chunk))
+		if partial {
+			chunk = bytes.TrimRight(chunk, " \t\r\n")
+		} else {
+			chunk = bytes.TrimSpace(chunk)
+		}
+		if len(chunk) > 0 || partial {
+			if _, werr := dst.Write(chunk); werr != nil {
+				return lines, werr
+			}
+			if _, werr := io.WriteString(dst, "\n"); werr != nil {
+				return lines, werr
+			}
+			lines++
+			if onLine != nil {
+				onLine()
+			}
+		}
+		lineLen = 0
+		if errors.Is(err, io.EOF) {
+			return lines, nil
+		}
+	}
+}
+
+// readNDJSONLine reads one record from r, failing once it grows beyond
+// maxLineBytes instead of buffering it without bound. It returns io.EOF once
+// the input is exhausted.
+func readNDJSONLine(r *bufio.Reader, maxLineBytes int64) ([]byte, error) {
+	var line []byte
+	for {
+		chunk, err := r.ReadSlice('\n')
+		line = append(line, chunk...)
+		if maxLineBytes > 0 && int64(len(line)) > maxLineBytes {
+			return nil, fmt.Errorf("record exceeds --max-doc-size %s", humanize.IBytes(uint64(maxLineBytes)))
+		}
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue
+		}
+		if errors.Is(err, io.EOF) && len(line) > 0 {
+			return line, nil
+		}
+		return line, err
+	}
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+func TestParseMaxDocSize(t *testing.T) {
+	cases := []struct {
+		raw   string
+		limit int64
+		set   bool
+		fails bool
+	}{
+		{raw: "", limit: 0, set: false},
+		{raw: "0", limit: 0, set: true},
+		{raw: "8MiB", limit: 8 << 20, set: true},
+		{raw: "512KB", limit: 512000, set: true},
+		{raw: "lots", fails: true},
+	}
+	for _, tc := range cases {
+		limit, set, err := parseMaxDocSize(tc.raw)
+		if tc.fails {
+			if err == nil {
+				t.Fatalf("expected %q to fail", tc.raw)
+			}
+			continue
+		}
+		if err != nil || limit != tc.limit || set != tc.set {
+			t.Fatalf("parseMaxDocSize(%q) = %d, %v, %v", tc.raw, limit, set, err)
+		}
+	}
+}
+
+func TestCopyNDJSONLinesStreamsLargeRecords(t *testing.T) {
+	huge := `{"data":"` + strings.Repeat("x", 200<<10) + `"}`
+	input := "{\"a\":1}\n\n  \n" + huge + "\r\n{\"b\":2}"
+	var out bytes.Buffer
+	seen := 0
+	lines, err := copyNDJSONLines(&out, strings.NewReader(input), 0, func() { seen++ })
+	if err != nil {
+		t.Fatalf("copyNDJSONLines: %v", err)
+	}
+	if lines != 3 || seen != 3 {
+		t.Fatalf("expected 3 records, got %d (callbacks %d)", lines, seen)
+	}
+	want := "{\"a\":1}\n" + huge + "\n{\"b\":2}\n"
+	if out.String() != want {
+		t.Fatalf("unexpected output (len %d, want %d)", out.Len(), len(want))
+	}
+
+	_, err = copyNDJSONLines(io.Discard, strings.NewReader(input), 100<<10, nil)
+	if err == nil || !strings.Contains(err.Error(), "record 2 exceeds --max-doc-size") {
+		t.Fatalf("expected size error, got %v", err)
+	}
+}
+
+func TestReadNDJSONLineLimit(t *testing.T) {
+	reader := bufio.NewReaderSize(strings.NewReader("short\n"+strings.Repeat("y", 100)+"\n"), 16)
+	line, err := readNDJSONLine(reader, 50)
+	if err != nil || string(line) != "short\n" {
+		t.Fatalf("unexpected first line %q, %v", line, err)
+	}
+	if _, err := readNDJSONLine(reader, 50); err == nil || !strings.Contains(err.Error(), "--max-doc-size") {
+		t.Fatalf("expected size error, got %v", err)
+	}
+}
+
+func TestExplainDocumentSizeError(t *testing.T) {
+	err := explainDocumentSizeError(fmt.Errorf("wrapped: %w", &clientpkg.ResponseTooLargeError{Limit: 1 << 20}))
+	if !strings.Contains(err.Error(), "raise --max-doc-size") || !strings.Contains(err.Error(), "1.0 MiB") {
+		t.Fatalf("unexpected message %q", err)
+	}
+	plain := errors.New("boom")
+	if explainDocumentSizeError(plain) != plain {
+		t.Fatalf("expected unrelated errors to pass through")
+	}
+	if err := checkDocumentSize("doc_1", 2048, 1024); err == nil || !strings.Contains(err.Error(), "doc_1 is 2.0 KiB") {
+		t.Fatalf("unexpected size check %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// documentTimeRangeFlags binds --created-since, --created-before and
+// --updated-since (alias --modified-since). Each accepts an RFC 3339 timestamp, a date (YYYY-MM-DD), or
+// a duration before now such as 24h or 7d.
+type documentTimeRangeFlags struct {
+	createdSince  string
+	createdBefore string
+	updatedSince  string
+	modifiedSince string
+}
+
+// documentTimeRange holds the parsed bounds; nil bounds are unset.
+type documentTimeRange struct {
+	CreatedSince  *time.Time
+	CreatedBefore *time.Time
+	UpdatedSince  *time.Time
+}
+
+func (f *documentTimeRangeFlags) bind(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.createdSince, "created-since", "", "Only documents created at or after this time (RFC3339, YYYY-MM-DD, or duration like 24h, 7d)")
+	cmd.Flags().StringVar(&f.createdBefore, "created-before", "", "Only documents created before this time (RFC3339, YYYY-MM-DD, or duration like 24h, 7d)")
+	cmd.Flags().StringVar(&f.updatedSince, "updated-since", "", "Only documents updated at or after this time (RFC3339, YYYY-MM-DD, or duration like 24h, 7d)")
+	cmd.Flags().StringVar(&f.modifiedSince, "modified-since", "", "Alias for --updated-since")
+}
+
+func (f documentTimeRangeFlags) resolve(now time.Time) (documentTimeRange, error) {
+	var r documentTimeRange
+	updatedFlag, updated := "--updated-since", f.updatedSince
+	if strings.TrimSpace(f.modifiedSince) != "" {
+		if strings.TrimSpace(updated) != "" {
+			return r, fmt.Errorf("use only one of --updated-since or --modified-since")
+		}
+		updatedFlag, updated = "--modified-since", f.modifiedSince
+	}
+	for _, bound := range []struct {
+		flag  string
+		value string
+		dst   **time.Time
+	}{
+		{"--created-since", f.createdSince, &r.CreatedSince},
+		{"--created-before", f.createdBefore, &r.CreatedBefore},
+		{updatedFlag, updated, &r.UpdatedSince},
+	} {
+		trimmed := strings.TrimSpace(bound.value)
+		if trimmed == "" {
+			continue
+		}
+		ts, err := parseAuditTimeArg(trimmed, now)
+		if err != nil {
+			return r, fmt.Errorf("invalid %s value %q: %w", bound.flag, trimmed, err)
+		}
+		ts = ts.UTC()
+		*bound.dst = &ts
+	}
+	if r.CreatedSince != nil && r.CreatedBefore != nil && !r.CreatedSince.Before(*r.CreatedBefore) {
+		return r, fmt.Errorf("--created-since (%s) must be before --created-before (%s)", r.CreatedSince.Format(time.RFC3339), r.CreatedBefore.Format(time.RFC3339))
+	}
+	return r, nil
+}
+
+func (r documentTimeRange) active() bool {
+	return r.CreatedSince != nil || r.CreatedBefore != nil || r.UpdatedSince != nil
+}
+
+// apply sets the bounds as list query parameters.
+func (r documentTimeRange) apply(params *clientpkg.ListDocumentsParams) {
+	params.CreatedSince = r.CreatedSince
+	params.CreatedBefore = r.CreatedBefore
+	params.UpdatedSince = r.UpdatedSince
+}
+
+// whereClause renders the bounds as report body conditions on created_at and
+// updated_at.
+func (r documentTimeRange) whereClause() map[string]any {
+	var conditions []any
+	if r.CreatedSince != nil || r.CreatedBefore != nil {
+		created := map[string]any{}
+		if r.CreatedSince != nil {
+			created["gte"] = r.CreatedSince.Format(time.RFC3339)
+		}
+		if r.CreatedBefore != nil {
+			created["lt"] = r.CreatedBefore.Format(time.RFC3339)
+		}
+		conditions = append(conditions, map[string]any{"created_at": created})
+	}
+	if r.UpdatedSince != nil {
+		conditions = append(conditions, map[string]any{"updated_at": map[string]any{"gte": r.UpdatedSince.Format(time.RFC3339)}})
+	}
+	if len(conditions) == 0 {
+		return nil
+	}
+	return map[string]any{"and": conditions}
+}
+
+// countDocumentsInRange counts documents inside the time range with a report
+// query, since the count endpoint takes no filters.
+func countDocumentsInRange(cmd *cobra.Command, tenantClient *clientpkg.TenantClient, collection, appID string, r documentTimeRange) (int64, error) {
+	body := map[string]any{
+		"where":     r.whereClause(),
+		"aggregate": []map[string]any{{"operation": "count", "alias": "count"}},
+	}
+	resp, err := tenantClient.ReportQuery(cmd.Context(), clientpkg.ReportQueryParams{AppID: appID, Collection: collection, Body: body})
+	if err != nil {
+		return 0, err
+	}
+	if len(resp.Data) == 0 {
+		return 0, nil
+	}
+	switch count := resp.Data[0]["count"].(type) {
+	case float64:
+		return int64(count), nil
+	case nil:
+		return 0, fmt.Errorf("report response did not include a count")
+	default:
+		return 0, fmt.Errorf("unexpected count value %v", count)
+	}
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestDocumentTimeRangeResolve(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	got, err := documentTimeRangeFlags{createdSince: "7d", createdBefore: "2025-03-10", updatedSince: "2025-03-09T06:00:00+02:00"}.resolve(now)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if !got.CreatedSince.Equal(now.Add(-7*24*time.Hour)) || !got.CreatedBefore.Equal(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)) || !got.UpdatedSince.Equal(time.Date(2025, 3, 9, 4, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected range: %+v", got)
+	}
+	encoded, _ := json.Marshal(got.whereClause())
+	want := `{"and":[{"created_at":{"gte":"2025-03-03T12:00:00Z","lt":"2025-03-10T00:00:00Z"}},{"updated_at":{"gte":"2025-03-09T04:00:00Z"}}]}`
+	if string(encoded) != want {
+		t.Fatalf("got %s, want %s", encoded, want)
+	}
+
+	if _, err := (documentTimeRangeFlags{createdSince: "2025-03-10", createdBefore: "2025-03-01"}).resolve(now); err == nil {
+		t.Fatalf("expected error for an empty created range")
+	}
+	if _, err := (documentTimeRangeFlags{updatedSince: "last week"}).resolve(now); err == nil || !strings.Contains(err.Error(), "--updated-since") {
+		t.Fatalf("expected invalid --updated-since error, got %v", err)
+	}
+	if r, err := (documentTimeRangeFlags{}).resolve(now); err != nil || r.active() {
+		t.Fatalf("expected an inactive range, got %+v (%v)", r, err)
+	}
+}
+
+func TestDocumentsListAndCountTimeRange(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "events"})
+	for day, name := range []string{"old", "mid", "new"} {
+		created := time.Date(2025, 1, day+1, 0, 0, 0, 0, time.UTC)
+		srv.SetClock(func() time.Time { return created })
+		srv.AddDocument("events", map[string]any{"name": name})
+	}
+
+	var reportBody map[string]any
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/api/query" {
+			_ = json.NewDecoder(r.Body).Decode(&reportBody)
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": []map[string]any{{"count": 2}}})
+			return
+		}
+		tenantRoutes.ServeHTTP(w, r)
+	})
+
+	out, errOut, err := runCommand(t, srv, newTenantDocumentsListCommand, "events", "--raw", "--created-since", "2025-01-02", "--created-before", "2025-01-03")
+	if err != nil {
+		t.Fatalf("list failed: %v\n%s", err, errOut)
+	}
+	if !strings.Contains(out, "mid") || strings.Contains(out, "old") || strings.Contains(out, `\"new\"`) {
+		t.Fatalf("expected only the middle document:\n%s", out)
+	}
+
+	out, errOut, err = runCommand(t, srv, newTenantDocumentsCountCommand, "events", "--created-since", "2025-01-02")
+	if err != nil {
+		t.Fatalf("count failed: %v\n%s", err, errOut)
+	}
+	if !strings.Contains(out, "Documents: 2") {
+		t.Fatalf("unexpected count output:\n%s", out)
+	}
+	where, _ := json.Marshal(reportBody["where"])
+	if string(where) != `{"and":[{"created_at":{"gte":"2025-01-02T00:00:00Z"}}]}` {
+		t.Fatalf("unexpected report where clause: %s", where)
+	}
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const stateFileFlagUsage = "Track the newest updated_at in this file and resume from it on the next run (incremental extract)"
+
+// documentWatermark is the --state-file checkpoint of an incremental extract:
+// the newest updated_at seen for a collection by the last successful run and
+// the highest document ID at that instant. The watermark is exclusive: the
+// next run skips documents at or before (ModifiedSince, LastID).
+type documentWatermark struct {
+	Collection    string    `json:"collection"`
+	ModifiedSince time.Time `json:"modified_since"`
+	LastID        string    `json:"last_id,omitempty"`
+	LastRun       time.Time `json:"last_run"`
+	Documents     int       `json:"documents"`
+}
+
+// incrementalExtract tracks the high-watermark of a list or export run.
+type incrementalExtract struct {
+	path  string
+	state documentWatermark
+	seen  int
+	// resumed holds the watermark the run started from, when it came from the
+	// state file rather than an explicit --updated-since.
+	resumed *documentWatermark
+}
+
+// startIncrementalExtract loads the state file and, unless --updated-since
+// or --modified-since was given explicitly, resumes from its watermark. It
+// returns nil when path is empty. The first run, without a state file,
+// extracts everything.
+func startIncrementalExtract(path, collection string, bounds *documentTimeRange) (*incrementalExtract, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, nil
+	}
+	inc := &incrementalExtract{path: path, state: documentWatermark{Collection: collection}}
+	raw, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return inc, nil
+	case err != nil:
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &inc.state); err != nil {
+		return nil, fmt.Errorf("parse state file %s: %w", path, err)
+	}
+	if inc.state.Collection != "" && inc.state.Collection != collection {
+		return nil, fmt.Errorf("state file %s tracks collection %s, not %s", path, inc.state.Collection, collection)
+	}
+	inc.state.Collection = collection
+	if bounds.UpdatedSince == nil && !inc.state.ModifiedSince.IsZero() {
+		since := inc.state.ModifiedSince
+		bounds.UpdatedSince = &since
+		resumed := inc.state
+		inc.resumed = &resumed
+	}
+	return inc, nil
+}
+
+// skip reports whether doc was already extracted by the run that wrote the
+// watermark. The server's updated_since filter is inclusive, so documents at
+// the watermark itself come back and are told apart by their ID.
+func (inc *incrementalExtract) skip(doc clientpkg.Document) bool {
+	if inc == nil || inc.resumed == nil {
+		return false
+	}
+	at := doc.UpdatedAt
+	return at.Before(inc.resumed.ModifiedSince) || (at.Equal(inc.resumed.ModifiedSince) && doc.ID <= inc.resumed.LastID)
+}
+
+func (inc *incrementalExtract) observe(doc clientpkg.Document) {
+	if inc == nil {
+		return
+	}
+	inc.seen++
+	switch at := doc.UpdatedAt; {
+	case at.After(inc.state.ModifiedSince):
+		inc.state.ModifiedSince = at.UTC()
+		inc.state.LastID = doc.ID
+	case at.Equal(inc.state.ModifiedSince) && doc.ID > inc.state.LastID:
+		inc.state.LastID = doc.ID
+	}
+}
+
+// finish records the new watermark. It is only called once the run's output
+// has been written, so a failed run is retried from the previous watermark.
+func (inc *incrementalExtract) finish(now time.Time) error {
+	if inc == nil {
+		return nil
+	}
+	inc.state.LastRun = now.UTC()
+	inc.state.Documents = inc.seen
+	encoded, err := json.MarshalIndent(inc.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(inc.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return err
+		}
+	}
+	tmp := inc.path + ".tmp"
+	if err := os.WriteFile(tmp, append(encoded, '\n'), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, inc.path)
+}
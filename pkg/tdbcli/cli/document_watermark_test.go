@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestIncrementalExportStateFile(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "orders"})
+	clock := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	srv.SetClock(func() time.Time { return clock })
+	addAt := func(day int, n int) {
+		clock = time.Date(2026, 3, day, 9, 0, 0, 0, time.UTC)
+		srv.AddDocument("orders", map[string]any{"n": n})
+	}
+	addAt(1, 1)
+	addAt(2, 2)
+
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "orders.state.json")
+	run := func(newCmd func(*Environment) *cobra.Command, args ...string) (string, error) {
+		out, _, err := runCommand(t, srv, newCmd, append([]string{"orders", "--state-file", statePath}, args...)...)
+		return out, err
+	}
+	exported := func(out string) []float64 {
+		var ns []float64
+		scanner := bufio.NewScanner(strings.NewReader(out))
+		for scanner.Scan() {
+			var row map[string]any
+			if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+				t.Fatalf("decode %q: %v", scanner.Text(), err)
+			}
+			ns = append(ns, row["n"].(float64))
+		}
+		return ns
+	}
+	readState := func() documentWatermark {
+		raw, err := os.ReadFile(statePath)
+		if err != nil {
+			t.Fatalf("read state: %v", err)
+		}
+		var state documentWatermark
+		if err := json.Unmarshal(raw, &state); err != nil {
+			t.Fatalf("decode state: %v", err)
+		}
+		return state
+	}
+
+	out, err := run(newTenantDocumentsExportCommand, "--stream")
+	if err != nil {
+		t.Fatalf("first export failed: %v", err)
+	}
+	if got := exported(out); len(got) != 2 {
+		t.Fatalf("expected a full first extract, got %v", got)
+	}
+	if state := readState(); !state.ModifiedSince.Equal(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)) || state.Collection != "orders" || state.Documents != 2 {
+		t.Fatalf("unexpected state %+v", state)
+	}
+
+	addAt(3, 3)
+	out, err = run(newTenantDocumentsExportCommand)
+	if err != nil {
+		t.Fatalf("second export failed: %v", err)
+	}
+	// The watermark is exclusive, so the boundary document is not extracted again.
+	if got := exported(out); len(got) != 1 || got[0] != 3 {
+		t.Fatalf("expected only documents since the watermark, got %v", got)
+	}
+	if state := readState(); !state.ModifiedSince.Equal(time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("watermark not advanced: %+v", state)
+	}
+
+	out, err = run(newTenantDocumentsExportCommand)
+	if err != nil || len(exported(out)) != 0 {
+		t.Fatalf("expected nothing new, got %v\n%s", err, out)
+	}
+	if state := readState(); !state.ModifiedSince.Equal(time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC)) || state.LastID == "" {
+		t.Fatalf("watermark changed without new documents: %+v", state)
+	}
+
+	if _, err := run(newTenantDocumentsListCommand, "--sort", "-created_at"); err == nil || !strings.Contains(err.Error(), "drop --sort") {
+		t.Fatalf("expected sort conflict, got %v", err)
+	}
+	if _, err := run(newTenantDocumentsListCommand, "--updated-since", "1d", "--modified-since", "2d"); err == nil || !strings.Contains(err.Error(), "only one of --updated-since or --modified-since") {
+		t.Fatalf("expected alias conflict, got %v", err)
+	}
+	if _, err := startIncrementalExtract(statePath, "users", &documentTimeRange{}); err == nil || !strings.Contains(err.Error(), "tracks collection orders") {
+		t.Fatalf("expected collection mismatch, got %v", err)
+	}
+}
+
+func TestIncrementalListBreaksTiesByID(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	clock := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	srv.SetClock(func() time.Time { return clock })
+	var ids []string
+	for i := 0; i < 3; i++ {
+		ids = append(ids, srv.AddDocument("orders", map[string]any{"n": i}).ID)
+	}
+	statePath := filepath.Join(t.TempDir(), "orders.state.json")
+	list := func() []string {
+		out, _, err := runCommand(t, srv, newTenantDocumentsListCommand, "orders", "--state-file", statePath, "--limit", "2", "--raw")
+		if err != nil {
+			t.Fatalf("list failed: %v", err)
+		}
+		var resp clientpkg.DocumentListResponse
+		if err := json.Unmarshal([]byte(out), &resp); err != nil {
+			t.Fatalf("decode %s: %v", out, err)
+		}
+		var got []string
+		for _, doc := range resp.Items {
+			got = append(got, doc.ID)
+		}
+		return got
+	}
+
+	// All three share one updated_at, so only the ID tells the runs apart.
+	if got := list(); strings.Join(got, ",") != strings.Join(ids[:2], ",") {
+		t.Fatalf("first run: expected %v, got %v", ids[:2], got)
+	}
+	if got := list(); len(got) != 1 || got[0] != ids[2] {
+		t.Fatalf("second run: expected only %s, got %v", ids[2], got)
+	}
+	if got := list(); len(got) != 0 {
+		t.Fatalf("third run: expected nothing new, got %v", got)
+	}
+}
@@ -0,0 +1,29 @@
+package cli
+
+import "fmt"
+
+// Exit codes with a meaning beyond plain failure. Anything else exits 1.
+const (
+	ExitCodeNotFound    = 4
+	ExitCodeTimedOut    = 124
+	ExitCodeInterrupted = 130
+)
+
+// ExitError asks the process to exit with Code. A nil Err exits silently,
+// which lets scripting commands signal their answer through the exit status
+// alone.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
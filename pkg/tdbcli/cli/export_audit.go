@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const exportAuditKey = "_audit"
+
+// exportAuditEntry is one audit log entry as embedded under _audit, with the
+// old and new data decoded instead of kept as JSON strings.
+type exportAuditEntry struct {
+	ID              uint      `json:"id"`
+	Operation       string    `json:"operation"`
+	Actor           string    `json:"actor,omitempty"`
+	DocumentVersion int64     `json:"document_version"`
+	CreatedAt       time.Time `json:"created_at"`
+	OldData         any       `json:"old_data,omitempty"`
+	NewData         any       `json:"new_data,omitempty"`
+}
+
+// attachAuditTrails fetches the audit history of every document, with at most
+// workers requests in flight, and embeds it oldest first under _audit in the
+// document data. reshape is applied to the old and new data of each entry so
+// fields dropped from the export (--exclude) do not leak through the history.
+func attachAuditTrails(ctx context.Context, tenantClient *clientpkg.TenantClient, appID string, docs []clientpkg.Document, workers int, reshape func(string) (string, error)) error {
+	until := time.Now().UTC()
+	trails := make([][]clientpkg.AuditLog, len(docs))
+	errs := make([]error, len(docs))
+	sem := make(chan struct{}, max(workers, 1))
+	var wg sync.WaitGroup
+	for i := range docs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			base := clientpkg.ListAuditLogsParams{AppID: appID, DocumentID: docs[i].ID}
+			trails[i], errs[i] = fetchAuditWindow(ctx, tenantClient.ListAuditLogs, base, nil, until, auditMaxPageSize)
+		}()
+	}
+	wg.Wait()
+
+	for i := range docs {
+		if errs[i] != nil {
+			return fmt.Errorf("fetch audit history of %s: %w", docs[i].ID, errs[i])
+		}
+		sortAuditEntries(trails[i])
+		entries := make([]exportAuditEntry, 0, len(trails[i]))
+		for _, log := range trails[i] {
+			entry := exportAuditEntry{ID: log.ID, Operation: log.Operation, Actor: log.Actor, DocumentVersion: log.DocumentVersion, CreatedAt: log.CreatedAt}
+			var err error
+			if entry.OldData, err = reshapeAuditData(log.OldData, reshape); err != nil {
+				return fmt.Errorf("reshape audit entry %d of %s: %w", log.ID, docs[i].ID, err)
+			}
+			if entry.NewData, err = reshapeAuditData(log.NewData, reshape); err != nil {
+				return fmt.Errorf("reshape audit entry %d of %s: %w", log.ID, docs[i].ID, err)
+			}
+			entries = append(entries, entry)
+		}
+		decoded, _ := decodeJSONPreservingNumbers([]byte(docs[i].Data))
+		data, ok := decoded.(map[string]any)
+		if !ok {
+			data = map[string]any{}
+		}
+		data[exportAuditKey] = entries
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		docs[i].Data = string(encoded)
+	}
+	return nil
+}
+
+func reshapeAuditData(raw string, reshape func(string) (string, error)) (any, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	shaped, err := reshape(raw)
+	if err != nil {
+		return nil, err
+	}
+	value, err := decodeJSONPreservingNumbers([]byte(shaped))
+	if err != nil {
+		return shaped, nil
+	}
+	return value, nil
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestExportWithAuditEmbedsHistory(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "contracts"})
+	first := srv.AddDocument("contracts", map[string]any{"title": "MSA", "secret": "s3"})
+	second := srv.AddDocument("contracts", map[string]any{"title": "NDA"})
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	history := map[string][]clientpkg.AuditLog{
+		first.ID: {
+			{ID: 2, DocumentID: first.ID, Operation: "update", DocumentVersion: 2, CreatedAt: base.Add(time.Hour), OldData: `{"title":"Draft","secret":"s1"}`, NewData: `{"title":"MSA","secret":"s3"}`},
+			{ID: 1, DocumentID: first.ID, Operation: "create", DocumentVersion: 1, CreatedAt: base, NewData: `{"title":"Draft","secret":"s1"}`},
+		},
+	}
+	var inFlight, peak atomic.Int32
+	routes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/audit" {
+			routes.ServeHTTP(w, r)
+			return
+		}
+		if n := inFlight.Add(1); n > peak.Load() {
+			peak.Store(n)
+		}
+		defer inFlight.Add(-1)
+		time.Sleep(5 * time.Millisecond)
+		_ = json.NewEncoder(w).Encode(clientpkg.AuditLogListResponse{Items: history[r.URL.Query().Get("document_id")]})
+	})
+
+	out, _, err := runCommand(t, srv, newTenantDocumentsExportCommand, "contracts", "--with-audit", "--audit-workers", "1", "--exclude", "secret", "--stream")
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if peak.Load() > 1 {
+		t.Fatalf("expected at most 1 concurrent audit request, saw %d", peak.Load())
+	}
+	if strings.Contains(out, "secret") || strings.Contains(out, "s1") {
+		t.Fatalf("excluded field leaked through audit history:\n%s", out)
+	}
+
+	exported := map[string]map[string]any{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var data map[string]any
+		if err := json.Unmarshal([]byte(line), &data); err != nil {
+			t.Fatalf("decode line %q: %v", line, err)
+		}
+		exported[data["title"].(string)] = data
+	}
+	trail, _ := exported["MSA"][exportAuditKey].([]any)
+	if len(trail) != 2 {
+		t.Fatalf("expected 2 audit entries for %s, got %v", first.ID, exported["MSA"])
+	}
+	oldest := trail[0].(map[string]any)
+	if oldest["operation"] != "create" || oldest["new_data"].(map[string]any)["title"] != "Draft" {
+		t.Fatalf("expected oldest entry first with decoded data, got %v", oldest)
+	}
+	if audit, ok := exported["NDA"][exportAuditKey].([]any); !ok || len(audit) != 0 {
+		t.Fatalf("expected empty audit history for %s, got %v", second.ID, exported["NDA"])
+	}
+}
@@ -0,0 +1,190 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// exportChecksumHeader carries the SHA-256 of a streamed export body when the
+// server computes one.
+const exportChecksumHeader = "X-Content-SHA256"
+
+// exportChecksums is the sidecar written next to an export by
+// --checksum-every. Checkpoints hold the running SHA-256 of the file after
+// every Interval records, so a corrupted transfer is pinned to the block of
+// records where it first differs.
+type exportChecksums struct {
+	Collection  string                     `json:"collection"`
+	CreatedAt   time.Time                  `json:"created_at"`
+	Algorithm   string                     `json:"algorithm"`
+	Interval    int                        `json:"interval"`
+	Records     int                        `json:"records"`
+	Bytes       int64                      `json:"bytes"`
+	SHA256      string                     `json:"sha256"`
+	Checkpoints []exportChecksumCheckpoint `json:"checkpoints"`
+}
+
+type exportChecksumCheckpoint struct {
+	Records int    `json:"records"`
+	SHA256  string `json:"sha256"`
+}
+
+// checksumSidecarPath is where the checksums for an export file are stored.
+func checksumSidecarPath(path string) string {
+	return path + ".sha256.json"
+}
+
+// exportChecksumWriter hashes JSONL output on its way to w and records a
+// checkpoint at every interval-th record boundary.
+type exportChecksumWriter struct {
+	w      io.Writer
+	hasher hash.Hash
+	sums   exportChecksums
+}
+
+func newExportChecksumWriter(w io.Writer, collection string, interval int) *exportChecksumWriter {
+	return &exportChecksumWriter{
+		w:      w,
+		hasher: sha256.New(),
+		sums:   exportChecksums{Collection: collection, Algorithm: "sha256", Interval: interval, Checkpoints: []exportChecksumCheckpoint{}},
+	}
+}
+
+func (c *exportChecksumWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.observe(p[:n])
+	return n, err
+}
+
+func (c *exportChecksumWriter) observe(p []byte) {
+	c.sums.Bytes += int64(len(p))
+	for len(p) > 0 {
+		idx := bytes.IndexByte(p, '\n')
+		if idx < 0 {
+			c.hasher.Write(p)
+			return
+		}
+		c.hasher.Write(p[:idx+1])
+		p = p[idx+1:]
+		c.sums.Records++
+		if c.sums.Records%c.sums.Interval == 0 {
+			c.sums.Checkpoints = append(c.sums.Checkpoints, exportChecksumCheckpoint{Records: c.sums.Records, SHA256: hex.EncodeToString(c.hasher.Sum(nil))})
+		}
+	}
+}
+
+// finish writes the sidecar for the export at path. The caller must flush any
+// buffered output first.
+func (c *exportChecksumWriter) finish(path string) (string, error) {
+	c.sums.SHA256 = hex.EncodeToString(c.hasher.Sum(nil))
+	c.sums.CreatedAt = time.Now().UTC()
+	encoded, err := json.MarshalIndent(c.sums, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	sidecar := checksumSidecarPath(path)
+	return sidecar, os.WriteFile(sidecar, append(encoded, '\n'), 0o644)
+}
+
+// loadExportChecksums reads the sidecar for a --file input. An explicit path
+// must exist; otherwise the default sidecar is used when present, and nil is
+// returned when there is none.
+func loadExportChecksums(filePath, explicit string) (*exportChecksums, string, error) {
+	path := strings.TrimSpace(explicit)
+	if path == "" {
+		if strings.TrimSpace(filePath) == "" {
+			return nil, "", nil
+		}
+		path = checksumSidecarPath(strings.TrimSpace(filePath))
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return nil, "", nil
+		}
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("read checksum file: %w", err)
+	}
+	var sums exportChecksums
+	if err := json.Unmarshal(raw, &sums); err != nil {
+		return nil, "", fmt.Errorf("decode checksum file %s: %w", path, err)
+	}
+	if !strings.EqualFold(sums.Algorithm, "sha256") || sums.Interval < 1 {
+		return nil, "", fmt.Errorf("checksum file %s: unsupported algorithm %q or interval %d", path, sums.Algorithm, sums.Interval)
+	}
+	return &sums, path, nil
+}
+
+// verify replays content through the running hash and fails on the first
+// checkpoint that differs, then checks the record count and final hash.
+func (s *exportChecksums) verify(content []byte) error {
+	hasher := sha256.New()
+	records := 0
+	next := 0
+	rest := content
+	for len(rest) > 0 {
+		idx := bytes.IndexByte(rest, '\n')
+		if idx < 0 {
+			hasher.Write(rest)
+			break
+		}
+		hasher.Write(rest[:idx+1])
+		rest = rest[idx+1:]
+		records++
+		if next < len(s.Checkpoints) && s.Checkpoints[next].Records == records {
+			if got := hex.EncodeToString(hasher.Sum(nil)); got != s.Checkpoints[next].SHA256 {
+				return fmt.Errorf("checksum mismatch in records %d-%d: the file is corrupted", records-s.Interval+1, records)
+			}
+			next++
+		}
+	}
+	if records != s.Records || int64(len(content)) != s.Bytes {
+		return fmt.Errorf("expected %d records (%d bytes) but found %d records (%d bytes): the file is truncated or corrupted", s.Records, s.Bytes, records, len(content))
+	}
+	if got := hex.EncodeToString(hasher.Sum(nil)); got != s.SHA256 {
+		return fmt.Errorf("checksum mismatch in records %d-%d: the file is corrupted", records-records%s.Interval+1, records)
+	}
+	return nil
+}
+
+// finishExportChecksums flushes buffered output and writes the sidecar for
+// --checksum-every, reporting its path on stderr.
+func finishExportChecksums(cmd *cobra.Command, out *bufio.Writer, checksums *exportChecksumWriter, outPath string) error {
+	if checksums == nil {
+		return nil
+	}
+	if err := out.Flush(); err != nil {
+		return err
+	}
+	sidecar, err := checksums.finish(filepath.Clean(strings.TrimSpace(outPath)))
+	if err != nil {
+		return fmt.Errorf("write checksum file: %w", err)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Wrote %d checksum checkpoints to %s\n", len(checksums.sums.Checkpoints), sidecar)
+	return nil
+}
+
+// verifyStreamChecksum compares the hash of a streamed export body with the
+// value the server sent in exportChecksumHeader.
+func verifyStreamChecksum(expected string, received hash.Hash) error {
+	if received == nil {
+		return nil
+	}
+	got := hex.EncodeToString(received.Sum(nil))
+	if !strings.EqualFold(strings.TrimSpace(expected), got) {
+		return fmt.Errorf("export stream corrupted in transit: server sent sha256 %s but received %s (discard the output and retry)", strings.TrimSpace(expected), got)
+	}
+	return nil
+}
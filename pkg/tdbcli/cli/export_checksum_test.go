@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestExportChecksumsVerifiedBeforeCompare(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "products", PrimaryKeyField: "sku"})
+	for i := 0; i < 5; i++ {
+		srv.AddDocument("products", map[string]any{"sku": fmt.Sprintf("sku-%d", i), "price": i})
+	}
+	outPath := filepath.Join(t.TempDir(), "products.jsonl")
+
+	if _, stderr, err := runCommand(t, srv, newTenantDocumentsExportCommand, "products", "--page-size", "2", "--include-meta", "--out", outPath, "--checksum-every", "2"); err != nil {
+		t.Fatalf("export failed: %v\n%s", err, stderr)
+	}
+	sums, _, err := loadExportChecksums(outPath, "")
+	if err != nil || sums == nil {
+		t.Fatalf("expected checksum sidecar, got %v", err)
+	}
+	if sums.Records != 5 || len(sums.Checkpoints) != 2 || sums.Checkpoints[1].Records != 4 {
+		t.Fatalf("unexpected checksums %+v", sums)
+	}
+
+	verify := func() (string, error) {
+		out, _, err := runCommand(t, srv, newTenantDocumentsVerifyCommand, "products", "--manifest", checksumSidecarPath(outPath))
+		return out, err
+	}
+	if out, err := verify(); err != nil || !strings.Contains(out, "Verified 5 documents of products") {
+		t.Fatalf("expected verified export, got %v\n%s", err, out)
+	}
+
+	content, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	corrupted := bytes.Replace(content, []byte(`"sku-2"`), []byte(`"sku-X"`), 1)
+	if bytes.Equal(corrupted, content) {
+		t.Fatalf("export does not contain sku-2:\n%s", content)
+	}
+	if err := os.WriteFile(outPath, corrupted, 0o644); err != nil {
+		t.Fatalf("write export: %v", err)
+	}
+	requests := len(srv.Requests())
+	if _, err := verify(); err == nil || !strings.Contains(err.Error(), "checksum mismatch in records") {
+		t.Fatalf("expected checksum failure, got %v", err)
+	}
+	if len(srv.Requests()) != requests {
+		t.Fatalf("expected no requests after a failed verification")
+	}
+}
+
+func TestStreamExportRejectsServerChecksumMismatch(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "events"})
+	srv.AddDocument("events", map[string]any{"n": 1})
+	routes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/export") {
+			w.Header().Set(exportChecksumHeader, strings.Repeat("0", 64))
+		}
+		routes.ServeHTTP(w, r)
+	})
+
+	if _, _, err := runCommand(t, srv, newTenantDocumentsExportCommand, "events", "--stream"); err == nil || !strings.Contains(err.Error(), "corrupted in transit") {
+		t.Fatalf("expected checksum mismatch error, got %v", err)
+	}
+}
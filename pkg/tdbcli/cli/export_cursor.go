@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// readCursorFile returns the cursor stored by a previous incremental export,
+// or "" when the file does not exist yet (first run exports everything).
+func readCursorFile(path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(raw)), nil
+}
+
+// writeCursorFile stores the next cursor through a temporary file so an
+// interrupted run never leaves a truncated cursor behind.
+func writeCursorFile(path, cursor string) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return err
+		}
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(cursor+"\n"), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestStreamingExportCursorFile(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	var cursors []string
+	fail := false
+	caughtUp, emptyPage := false, false
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/collections/events/export" {
+			tenantRoutes.ServeHTTP(w, r)
+			return
+		}
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+		if fail {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		if !caughtUp {
+			w.Header().Set("X-Next-Cursor", fmt.Sprintf("c%d", len(cursors)))
+		}
+		if emptyPage {
+			return
+		}
+		fmt.Fprintf(w, "{\"id\":\"doc_%d\",\"data\":{\"n\":%d}}\n", len(cursors), len(cursors))
+	})
+
+	dir := t.TempDir()
+	cursorPath := filepath.Join(dir, "state", "events.cursor")
+	var stderr string
+	run := func(extra ...string) error {
+		var err error
+		_, stderr, err = runCommand(t, srv, newTenantDocumentsExportCommand, append([]string{"events", "--cursor-file", cursorPath}, extra...)...)
+		return err
+	}
+	readCursor := func() string {
+		raw, err := os.ReadFile(cursorPath)
+		if err != nil {
+			t.Fatalf("read cursor file: %v", err)
+		}
+		return strings.TrimSpace(string(raw))
+	}
+
+	if err := run("--stream"); err != nil {
+		t.Fatalf("first export failed: %v", err)
+	}
+	if err := run("--stream"); err != nil {
+		t.Fatalf("second export failed: %v", err)
+	}
+	if strings.Join(cursors, ",") != ",c1" || readCursor() != "c2" {
+		t.Fatalf("unexpected cursors sent %q, stored %q", cursors, readCursor())
+	}
+
+	caughtUp, emptyPage = true, true
+	if err := run("--stream"); err != nil || !strings.Contains(stderr, "Caught up: no new documents") || readCursor() != "c2" {
+		t.Fatalf("expected a caught-up run to keep the cursor: %v\n%s", err, stderr)
+	}
+	emptyPage = false
+	if err := run("--stream"); err != nil || !strings.Contains(stderr, "sent no X-Next-Cursor after 1 documents") || readCursor() != "c2" {
+		t.Fatalf("expected a warning when documents arrive without a cursor: %v\n%s", err, stderr)
+	}
+	caughtUp = false
+
+	fail = true
+	if err := run("--stream"); err == nil {
+		t.Fatal("expected failed export")
+	}
+	if readCursor() != "c2" {
+		t.Fatalf("failed run must not advance the cursor, got %q", readCursor())
+	}
+
+	if err := run(); err == nil || !strings.Contains(err.Error(), "requires streaming export") {
+		t.Fatalf("expected streaming requirement error, got %v", err)
+	}
+	if err := run("--stream", "--cursor", "x"); err == nil || !strings.Contains(err.Error(), "only one of --cursor or --cursor-file") {
+		t.Fatalf("expected conflicting cursor error, got %v", err)
+	}
+}
@@ -0,0 +1,251 @@
+package cli
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	versionpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/version"
+)
+
+const lineageManifestKind = "tdb.lineage"
+
+// lineageManifest records where an export came from and every import that
+// later loaded it, so a copy of the data can be traced back to its source.
+// Export writes it with --manifest; sync --manifest verifies the file against
+// it and appends an import record.
+type lineageManifest struct {
+	Kind           string               `json:"kind"`
+	Version        int                  `json:"version"`
+	Source         lineageLocation      `json:"source"`
+	Output         string               `json:"output,omitempty"`
+	Format         string               `json:"format"`
+	Streamed       bool                 `json:"streamed"`
+	Filters        []string             `json:"filters,omitempty"`
+	Select         []string             `json:"select,omitempty"`
+	IncludeDeleted bool                 `json:"include_deleted,omitempty"`
+	CreatedSince   *time.Time           `json:"created_since,omitempty"`
+	CreatedBefore  *time.Time           `json:"created_before,omitempty"`
+	UpdatedSince   *time.Time           `json:"updated_since,omitempty"`
+	CursorStart    string               `json:"cursor_start,omitempty"`
+	CursorNext     string               `json:"cursor_next,omitempty"`
+	LastDocument   string               `json:"last_document,omitempty"`
+	Documents      int                  `json:"documents"`
+	Bytes          int64                `json:"bytes"`
+	SHA256         string               `json:"sha256"`
+	Parts          []exportManifestPart `json:"parts,omitempty"`
+	CLIVersion     string               `json:"cli_version"`
+	StartedAt      time.Time            `json:"started_at"`
+	FinishedAt     time.Time            `json:"finished_at"`
+	Imports        []lineageImport      `json:"imports,omitempty"`
+}
+
+// lineageLocation identifies a collection on a server.
+type lineageLocation struct {
+	Endpoint   string `json:"endpoint"`
+	TenantID   string `json:"tenant_id"`
+	AppID      string `json:"app_id,omitempty"`
+	Collection string `json:"collection"`
+}
+
+// lineageImport records one sync of the exported data into a collection.
+type lineageImport struct {
+	Target     lineageLocation `json:"target"`
+	File       string          `json:"file"`
+	SHA256     string          `json:"sha256"`
+	Mode       string          `json:"mode"`
+	Documents  int             `json:"documents"`
+	Created    int             `json:"created"`
+	Updated    int             `json:"updated"`
+	Unchanged  int             `json:"unchanged"`
+	Failed     int             `json:"failed"`
+	Complete   bool            `json:"complete"`
+	CLIVersion string          `json:"cli_version"`
+	StartedAt  time.Time       `json:"started_at"`
+	FinishedAt time.Time       `json:"finished_at"`
+}
+
+// lineageWriter hashes export output on its way to w.
+type lineageWriter struct {
+	w      io.Writer
+	hasher hash.Hash
+	bytes  int64
+}
+
+func (l *lineageWriter) Write(p []byte) (int, error) {
+	n, err := l.w.Write(p)
+	l.hasher.Write(p[:n])
+	l.bytes += int64(n)
+	return n, err
+}
+
+// exportLineage collects the manifest for an export run with --manifest.
+type exportLineage struct {
+	path     string
+	manifest lineageManifest
+	writer   *lineageWriter
+}
+
+func newExportLineage(path string, source lineageLocation) *exportLineage {
+	return &exportLineage{
+		path: filepath.Clean(path),
+		manifest: lineageManifest{
+			Kind:       lineageManifestKind,
+			Version:    1,
+			Source:     source,
+			CLIVersion: versionpkg.Display(),
+			StartedAt:  time.Now().UTC(),
+		},
+	}
+}
+
+// track hashes everything written to w. A nil lineage returns w unchanged.
+func (l *exportLineage) track(w io.Writer) io.Writer {
+	if l == nil {
+		return w
+	}
+	l.writer = &lineageWriter{w: w, hasher: sha256.New()}
+	return l.writer
+}
+
+// finish completes the manifest and writes it through a temporary file. The
+// caller must flush any buffered output first.
+func (l *exportLineage) finish(cmd *cobra.Command, documents int, splitter *exportSplitter) error {
+	if l == nil {
+		return nil
+	}
+	l.manifest.Documents = documents
+	if l.writer != nil {
+		l.manifest.Bytes = l.writer.bytes
+		l.manifest.SHA256 = hex.EncodeToString(l.writer.hasher.Sum(nil))
+	}
+	if splitter != nil {
+		l.manifest.Parts = splitter.manifest.Parts
+	}
+	l.manifest.FinishedAt = time.Now().UTC()
+	if err := writeJSONFileAtomic(l.path, &l.manifest); err != nil {
+		return fmt.Errorf("write lineage manifest: %w", err)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Wrote lineage manifest %s\n", l.path)
+	return nil
+}
+
+// writeJSONFileAtomic writes value as indented JSON through a temporary
+// file, so an interrupted run never leaves a truncated manifest behind.
+func writeJSONFileAtomic(path string, value any) error {
+	encoded, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(encoded, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func loadLineageManifest(path string) (*lineageManifest, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read lineage manifest: %w", err)
+	}
+	var manifest lineageManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, fmt.Errorf("decode lineage manifest %s: %w", path, err)
+	}
+	if manifest.Kind != lineageManifestKind {
+		return nil, fmt.Errorf("%s is not a lineage manifest (kind %q)", path, manifest.Kind)
+	}
+	return &manifest, nil
+}
+
+// verifyLineage checks the content of a sync --file against the export
+// manifest. A split export is matched against the part with the same file
+// name. It returns the SHA-256 of the payload.
+func verifyLineage(manifest *lineageManifest, filePath string, payload []byte, documents int) (string, error) {
+	sum := sha256.Sum256(payload)
+	got := hex.EncodeToString(sum[:])
+	wantSum, wantBytes, wantDocs := manifest.SHA256, manifest.Bytes, manifest.Documents
+	if len(manifest.Parts) > 0 {
+		found := false
+		for _, part := range manifest.Parts {
+			if part.File == filepath.Base(filePath) {
+				wantSum, wantBytes, wantDocs, found = part.SHA256, part.Bytes, part.Records, true
+				break
+			}
+		}
+		if !found {
+			return "", fmt.Errorf("%s is not one of the %d parts listed in the manifest", filepath.Base(filePath), len(manifest.Parts))
+		}
+	}
+	if int64(len(payload)) != wantBytes || !strings.EqualFold(got, wantSum) {
+		return "", fmt.Errorf("expected sha256 %s (%d bytes) but found %s (%d bytes): the file was modified or is not the exported copy", wantSum, wantBytes, got, len(payload))
+	}
+	if documents != wantDocs {
+		return "", fmt.Errorf("expected %d documents but found %d", wantDocs, documents)
+	}
+	return got, nil
+}
+
+// verifyPayloadLineage re-reads a sync --file byte for byte, as the export
+// wrote it, and checks it against the manifest at manifestPath.
+func verifyPayloadLineage(cmd *cobra.Command, manifestPath, filePath string, documents int) (*lineageManifest, string, error) {
+	manifest, err := loadLineageManifest(manifestPath)
+	if err != nil {
+		return nil, "", err
+	}
+	clean := filepath.Clean(strings.TrimSpace(filePath))
+	file, err := os.Open(clean)
+	if err != nil {
+		return nil, "", err
+	}
+	defer file.Close()
+	content, err := readPayloadSource(clean, file)
+	if err != nil {
+		return nil, "", err
+	}
+	sum, err := verifyLineage(manifest, clean, content, documents)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s failed verification against lineage manifest %s: %w", clean, manifestPath, err)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Verified %d documents against lineage manifest %s (exported from %s/%s)\n", documents, manifestPath, manifest.Source.TenantID, manifest.Source.Collection)
+	return manifest, sum, nil
+}
+
+// recordLineageImport appends an import record to the manifest at path.
+func recordLineageImport(cmd *cobra.Command, path string, manifest *lineageManifest, record lineageImport) error {
+	record.CLIVersion = versionpkg.Display()
+	record.FinishedAt = time.Now().UTC()
+	manifest.Imports = append(manifest.Imports, record)
+	if err := writeJSONFileAtomic(path, manifest); err != nil {
+		return fmt.Errorf("record import in lineage manifest: %w", err)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Recorded import %d in lineage manifest %s\n", len(manifest.Imports), path)
+	return nil
+}
+
+// finishExportLineage flushes buffered output and writes the --manifest file.
+func finishExportLineage(cmd *cobra.Command, out *bufio.Writer, lineage *exportLineage, documents int, splitter *exportSplitter) error {
+	if lineage == nil {
+		return nil
+	}
+	if err := out.Flush(); err != nil {
+		return err
+	}
+	return lineage.finish(cmd, documents, splitter)
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestExportLineageManifestVerifiedAndRecordedBySync(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "products", PrimaryKeyField: "sku"})
+	for i := 0; i < 3; i++ {
+		srv.AddDocument("products", map[string]any{"sku": fmt.Sprintf("sku-%d", i), "price": i})
+	}
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "products.json")
+	manifestPath := filepath.Join(dir, "products.lineage.json")
+
+	if _, stderr, err := runCommand(t, srv, newTenantDocumentsExportCommand, "products", "--filter-raw", "price=1", "--filter-raw", "price=2", "--format", "json", "--out", outPath, "--manifest", manifestPath); err != nil {
+		t.Fatalf("export failed: %v\n%s", err, stderr)
+	}
+	manifest, err := loadLineageManifest(manifestPath)
+	if err != nil {
+		t.Fatalf("load manifest: %v", err)
+	}
+	content, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	if manifest.Source.Endpoint != srv.URL || manifest.Source.TenantID != "tn_test" || manifest.Source.Collection != "products" {
+		t.Fatalf("unexpected source %+v", manifest.Source)
+	}
+	var exported []json.RawMessage
+	if err := json.Unmarshal(content, &exported); err != nil {
+		t.Fatalf("decode export: %v\n%s", err, content)
+	}
+	if manifest.Documents != len(exported) || manifest.Bytes != int64(len(content)) || len(manifest.SHA256) != 64 {
+		t.Fatalf("manifest does not describe the export: %+v\n%s", manifest, content)
+	}
+	if len(manifest.Filters) != 2 || manifest.CLIVersion == "" || manifest.FinishedAt.Before(manifest.StartedAt) {
+		t.Fatalf("unexpected manifest %+v", manifest)
+	}
+
+	sync := func() (string, error) {
+		_, errOut, err := runCommand(t, srv, newTenantDocumentsSyncCommand, "products", "--file", outPath, "--manifest", manifestPath)
+		return errOut, err
+	}
+	if out, err := sync(); err != nil || !strings.Contains(out, "Verified "+fmt.Sprint(manifest.Documents)+" documents against lineage manifest") {
+		t.Fatalf("expected verified sync, got %v\n%s", err, out)
+	}
+	recorded, err := loadLineageManifest(manifestPath)
+	if err != nil {
+		t.Fatalf("reload manifest: %v", err)
+	}
+	if len(recorded.Imports) != 1 {
+		t.Fatalf("expected one import record, got %+v", recorded.Imports)
+	}
+	record := recorded.Imports[0]
+	if !record.Complete || record.Target.Collection != "products" || record.SHA256 != manifest.SHA256 || record.Unchanged != manifest.Documents {
+		t.Fatalf("unexpected import record %+v", record)
+	}
+
+	tampered := bytes.Replace(content, []byte("]"), []byte(`,{"sku":"sku-9"}]`), 1)
+	if err := os.WriteFile(outPath, tampered, 0o644); err != nil {
+		t.Fatalf("write export: %v", err)
+	}
+	requests := len(srv.Requests())
+	if _, err := sync(); err == nil || !strings.Contains(err.Error(), "failed verification against lineage manifest") {
+		t.Fatalf("expected verification failure, got %v", err)
+	}
+	if len(srv.Requests()) != requests {
+		t.Fatalf("expected no requests after a failed verification")
+	}
+}
+
+func TestVerifyLineageMatchesSplitParts(t *testing.T) {
+	payload := []byte(`{"id":1}` + "\n")
+	manifest := &lineageManifest{Parts: []exportManifestPart{{File: "events-00002.jsonl", Records: 1, Bytes: int64(len(payload)), SHA256: "4a6fb4d0b3a8e54cbfa6c44fa0f2d35cb0e2da2f1ac3f1ef0a0e0d0b31f4d9c1"}}}
+	if _, err := verifyLineage(manifest, "exports/events-00001.jsonl", payload, 1); err == nil || !strings.Contains(err.Error(), "not one of the 1 parts") {
+		t.Fatalf("expected unknown part error, got %v", err)
+	}
+	if _, err := verifyLineage(manifest, "exports/events-00002.jsonl", payload, 1); err == nil || !strings.Contains(err.Error(), "was modified") {
+		t.Fatalf("expected checksum error, got %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// exportShaping describes client-side reshaping applied to exported document
+// data: removing excluded fields and flattening nested objects into keys joined
+// by Separator (e.g. address.city).
+type exportShaping struct {
+	Exclude   []string
+	Flatten   bool
+	Separator string
+}
+
+func newExportShaping(exclude string, flatten bool, separator string) (exportShaping, error) {
+	shaping := exportShaping{Exclude: splitCommaList(exclude), Flatten: flatten, Separator: separator}
+	if flatten && separator == "" {
+		return shaping, errors.New("--separator cannot be empty when --flatten is set")
+	}
+	return shaping, nil
+}
+
+func (s exportShaping) active() bool {
+	return s.Flatten || len(s.Exclude) > 0
+}
+
+// apply reshapes a decoded document payload. Non-object values are returned
+// unchanged. Exclusions use dotted paths into nested objects and, when
+// flattening, may also name a flattened key directly.
+func (s exportShaping) apply(value any) any {
+	obj, ok := value.(map[string]any)
+	if !ok || !s.active() {
+		return value
+	}
+	for _, path := range s.Exclude {
+		deleteDottedPath(obj, strings.Split(path, "."))
+	}
+	if !s.Flatten {
+		return obj
+	}
+	flat := make(map[string]any, len(obj))
+	flattenObject(flat, "", obj, s.Separator)
+	for _, path := range s.Exclude {
+		delete(flat, path)
+	}
+	return flat
+}
+
+// applyJSON reshapes a raw JSON document payload, preserving number precision.
+func (s exportShaping) applyJSON(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	if !s.active() || trimmed == "" {
+		return raw, nil
+	}
+	value, err := decodeJSONPreservingNumbers([]byte(trimmed))
+	if err != nil {
+		return "", err
+	}
+	encoded, err := json.Marshal(s.apply(value))
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// shapeStreamedExportLine converts one line of a streaming export into its output
+// form: unwrapping the data payload unless metadata is requested and applying
+// shaping. Lines that cannot be decoded are passed through unchanged.
+func shapeStreamedExportLine(line []byte, includeMeta, pretty bool, shaping exportShaping) []byte {
+	if includeMeta && !shaping.active() {
+		return line
+	}
+	decoded, err := decodeJSONPreservingNumbers(line)
+	if err != nil {
+		return line
+	}
+	parsed, ok := decoded.(map[string]any)
+	if !ok {
+		return line
+	}
+	var value any
+	dataVal, hasData := parsed["data"]
+	switch {
+	case hasData && includeMeta:
+		parsed["data"] = shaping.apply(dataVal)
+		value = parsed
+	case hasData:
+		value = shaping.apply(dataVal)
+	case shaping.active():
+		value = shaping.apply(parsed)
+	default:
+		return line
+	}
+	var encoded []byte
+	if pretty {
+		encoded, err = json.MarshalIndent(value, "", "  ")
+	} else {
+		encoded, err = json.Marshal(value)
+	}
+	if err != nil {
+		return line
+	}
+	return encoded
+}
+
+func decodeJSONPreservingNumbers(raw []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func deleteDottedPath(obj map[string]any, parts []string) {
+	if len(parts) == 0 {
+		return
+	}
+	if len(parts) == 1 {
+		delete(obj, parts[0])
+		return
+	}
+	if next, ok := obj[parts[0]].(map[string]any); ok {
+		deleteDottedPath(next, parts[1:])
+	}
+}
+
+// flattenObject writes nested object fields into out using joined keys. Arrays
+// and empty objects are kept as values so no data is lost.
+func flattenObject(out map[string]any, prefix string, obj map[string]any, separator string) {
+	for key, value := range obj {
+		name := key
+		if prefix != "" {
+			name = prefix + separator + key
+		}
+		if nested, ok := value.(map[string]any); ok && len(nested) > 0 {
+			flattenObject(out, name, nested, separator)
+			continue
+		}
+		out[name] = value
+	}
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExportShapingFlattenAndExclude(t *testing.T) {
+	shaping, err := newExportShaping("password,profile.ssn", true, "_")
+	if err != nil {
+		t.Fatalf("newExportShaping: %v", err)
+	}
+	out, err := shaping.applyJSON(`{"name":"Ada","password":"x","profile":{"ssn":"123","address":{"city":"Paris"},"tags":["a"],"empty":{}},"balance":12345678901234567890}`)
+	if err != nil {
+		t.Fatalf("applyJSON: %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	want := map[string]any{
+		"name":                 "Ada",
+		"profile_address_city": "Paris",
+		"profile_tags":         []any{"a"},
+		"profile_empty":        map[string]any{},
+		"balance":              float64(12345678901234567890),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected output:\n got: %v\nwant: %v", got, want)
+	}
+	if !strings.Contains(out, "12345678901234567890") {
+		t.Fatalf("expected number precision to be preserved, got %s", out)
+	}
+}
+
+func TestExportShapingExcludeFlattenedKey(t *testing.T) {
+	shaping, _ := newExportShaping("meta.source", true, ".")
+	out, err := shaping.applyJSON(`{"meta":{"source":"web","region":"eu"}}`)
+	if err != nil {
+		t.Fatalf("applyJSON: %v", err)
+	}
+	if out != `{"meta.region":"eu"}` {
+		t.Fatalf("unexpected output %s", out)
+	}
+}
+
+func TestExportShapingRequiresSeparator(t *testing.T) {
+	if _, err := newExportShaping("", true, ""); err == nil {
+		t.Fatalf("expected error for empty separator")
+	}
+}
+
+func TestShapeStreamedExportLine(t *testing.T) {
+	line := []byte(`{"id":"d1","data":{"a":{"b":1},"secret":true}}`)
+
+	plain := shapeStreamedExportLine(line, false, false, exportShaping{})
+	if string(plain) != `{"a":{"b":1},"secret":true}` {
+		t.Fatalf("expected data to be unwrapped, got %s", plain)
+	}
+
+	meta := shapeStreamedExportLine(line, true, false, exportShaping{})
+	if string(meta) != string(line) {
+		t.Fatalf("expected line unchanged with metadata, got %s", meta)
+	}
+
+	shaping := exportShaping{Exclude: []string{"secret"}, Flatten: true, Separator: "."}
+	shaped := shapeStreamedExportLine(line, true, false, shaping)
+	if string(shaped) != `{"data":{"a.b":1},"id":"d1"}` {
+		t.Fatalf("unexpected shaped line %s", shaped)
+	}
+}
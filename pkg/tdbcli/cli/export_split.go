@@ -0,0 +1,201 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+// exportManifest describes the part files written by a split export.
+type exportManifest struct {
+	Collection string               `json:"collection"`
+	CreatedAt  time.Time            `json:"created_at"`
+	Records    int                  `json:"records"`
+	Bytes      int64                `json:"bytes"`
+	Parts      []exportManifestPart `json:"parts"`
+}
+
+type exportManifestPart struct {
+	File    string `json:"file"`
+	Records int    `json:"records"`
+	Bytes   int64  `json:"bytes"`
+	SHA256  string `json:"sha256"`
+}
+
+// exportSplitter is an io.Writer for JSONL output that rolls into numbered
+// part files (events-00001.jsonl, ...) once a part reaches the record or size
+// limit. Records are never split across parts: input is buffered up to each
+// newline, so callers may write in arbitrary chunks.
+type exportSplitter struct {
+	dir, stem, ext string
+	maxBytes       int64
+	maxRecords     int
+
+	pending  []byte
+	file     *os.File
+	hasher   hash.Hash
+	part     exportManifestPart
+	manifest exportManifest
+}
+
+func parseExportSplit(sizeRaw string, count int) (int64, error) {
+	if count < 0 {
+		return 0, errors.New("--split-count must be positive")
+	}
+	trimmed := strings.TrimSpace(sizeRaw)
+	if trimmed == "" {
+		return 0, nil
+	}
+	size, err := humanize.ParseBytes(trimmed)
+	if err != nil || size == 0 || size > 1<<62 {
+		return 0, fmt.Errorf("invalid --split-size %q (use a size such as 100MB)", sizeRaw)
+	}
+	return int64(size), nil
+}
+
+// newExportSplitter splits output named after outPath: a.jsonl becomes
+// a-00001.jsonl, a-00002.jsonl, ... plus a.manifest.json.
+func newExportSplitter(outPath, collection string, maxBytes int64, maxRecords int) (*exportSplitter, error) {
+	clean := filepath.Clean(strings.TrimSpace(outPath))
+	dir := filepath.Dir(clean)
+	if dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	base := filepath.Base(clean)
+	ext := filepath.Ext(base)
+	if ext == "" {
+		ext = ".jsonl"
+	}
+	return &exportSplitter{
+		dir:        dir,
+		stem:       strings.TrimSuffix(base, filepath.Ext(base)),
+		ext:        ext,
+		maxBytes:   maxBytes,
+		maxRecords: maxRecords,
+		manifest:   exportManifest{Collection: collection, Parts: []exportManifestPart{}},
+	}, nil
+}
+
+func (s *exportSplitter) Write(p []byte) (int, error) {
+	s.pending = append(s.pending, p...)
+	for {
+		idx := bytes.IndexByte(s.pending, '\n')
+		if idx < 0 {
+			return len(p), nil
+		}
+		if err := s.writeRecord(s.pending[:idx+1]); err != nil {
+			return 0, err
+		}
+		s.pending = s.pending[idx+1:]
+	}
+}
+
+func (s *exportSplitter) writeRecord(record []byte) error {
+	if s.file != nil && s.full(int64(len(record))) {
+		if err := s.closePart(); err != nil {
+			return err
+		}
+	}
+	if s.file == nil {
+		name := fmt.Sprintf("%s-%05d%s", s.stem, len(s.manifest.Parts)+1, s.ext)
+		file, err := os.Create(filepath.Join(s.dir, name))
+		if err != nil {
+			return err
+		}
+		s.file = file
+		s.hasher = sha256.New()
+		s.part = exportManifestPart{File: name}
+	}
+	if _, err := s.file.Write(record); err != nil {
+		return err
+	}
+	s.hasher.Write(record)
+	s.part.Records++
+	s.part.Bytes += int64(len(record))
+	return nil
+}
+
+// full reports whether the open part cannot take another record of size n.
+// A single record larger than --split-size still gets a part of its own.
+func (s *exportSplitter) full(n int64) bool {
+	if s.maxRecords > 0 && s.part.Records >= s.maxRecords {
+		return true
+	}
+	return s.maxBytes > 0 && s.part.Bytes > 0 && s.part.Bytes+n > s.maxBytes
+}
+
+func (s *exportSplitter) closePart() error {
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	if err != nil {
+		return err
+	}
+	s.part.SHA256 = hex.EncodeToString(s.hasher.Sum(nil))
+	s.manifest.Parts = append(s.manifest.Parts, s.part)
+	s.manifest.Records += s.part.Records
+	s.manifest.Bytes += s.part.Bytes
+	return nil
+}
+
+// finish writes any unterminated last record, closes the open part, and
+// writes the manifest, returning its path.
+func (s *exportSplitter) finish() (string, error) {
+	if len(bytes.TrimSpace(s.pending)) > 0 {
+		if err := s.writeRecord(append(s.pending, '\n')); err != nil {
+			return "", err
+		}
+	}
+	s.pending = nil
+	if err := s.closePart(); err != nil {
+		return "", err
+	}
+	s.manifest.CreatedAt = time.Now().UTC()
+	encoded, err := json.MarshalIndent(s.manifest, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(s.dir, s.stem+".manifest.json")
+	return path, os.WriteFile(path, append(encoded, '\n'), 0o644)
+}
+
+// abort closes the open part without writing a manifest, for failed exports.
+func (s *exportSplitter) abort() {
+	if s.file != nil {
+		_ = s.file.Close()
+		s.file = nil
+	}
+}
+
+// finishExportSplit flushes buffered output into the splitter, completes the
+// last part and the manifest, and reports them on stderr.
+func finishExportSplit(cmd *cobra.Command, out *bufio.Writer, splitter *exportSplitter) error {
+	if splitter == nil {
+		return nil
+	}
+	if err := out.Flush(); err != nil {
+		return err
+	}
+	manifestPath, err := splitter.finish()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Wrote %d part files (manifest %s)\n", len(splitter.manifest.Parts), manifestPath)
+	return nil
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestDocumentsExportSplitCount(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "events"})
+	for i := 0; i < 5; i++ {
+		srv.AddDocument("events", map[string]any{"n": i})
+	}
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "events.jsonl")
+
+	_, stderr, err := runCommand(t, srv, newTenantDocumentsExportCommand, "events", "--page-size", "2", "--out", outPath, "--split-count", "2")
+	if err != nil {
+		t.Fatalf("export failed: %v\n%s", err, stderr)
+	}
+	if !strings.Contains(stderr, "Wrote 3 part files") {
+		t.Fatalf("unexpected stderr %q", stderr)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "events.manifest.json"))
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	var manifest exportManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		t.Fatalf("decode manifest: %v", err)
+	}
+	if manifest.Collection != "events" || manifest.Records != 5 || len(manifest.Parts) != 3 {
+		t.Fatalf("unexpected manifest %+v", manifest)
+	}
+	wantRecords := []int{2, 2, 1}
+	for i, part := range manifest.Parts {
+		content, err := os.ReadFile(filepath.Join(dir, part.File))
+		if err != nil {
+			t.Fatalf("read part: %v", err)
+		}
+		sum := sha256.Sum256(content)
+		if part.SHA256 != hex.EncodeToString(sum[:]) || part.Bytes != int64(len(content)) {
+			t.Fatalf("part %s does not match its manifest entry", part.File)
+		}
+		lines := 0
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		for scanner.Scan() {
+			if !json.Valid(scanner.Bytes()) {
+				t.Fatalf("part %s has a broken record %q", part.File, scanner.Text())
+			}
+			lines++
+		}
+		if lines != wantRecords[i] || part.Records != wantRecords[i] {
+			t.Fatalf("part %s: expected %d records, got %d lines / %d in manifest", part.File, wantRecords[i], lines, part.Records)
+		}
+	}
+	if manifest.Parts[0].File != "events-00001.jsonl" {
+		t.Fatalf("unexpected part name %s", manifest.Parts[0].File)
+	}
+}
+
+func TestExportSplitterSizeKeepsRecordsWhole(t *testing.T) {
+	dir := t.TempDir()
+	splitter, err := newExportSplitter(filepath.Join(dir, "a.jsonl"), "a", 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Written in odd chunks; each record is 6 bytes, so parts hold one record.
+	for _, chunk := range []string{`{"a"`, ":1}\n{\"b\":2}\n{", `"c":3}`} {
+		if _, err := splitter.Write([]byte(chunk)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := splitter.finish(); err != nil {
+		t.Fatal(err)
+	}
+	if len(splitter.manifest.Parts) != 3 || splitter.manifest.Records != 3 {
+		t.Fatalf("unexpected manifest %+v", splitter.manifest)
+	}
+	last, _ := os.ReadFile(filepath.Join(dir, "a-00003.jsonl"))
+	if string(last) != "{\"c\":3}\n" {
+		t.Fatalf("unexpected last part %q", last)
+	}
+	if _, err := parseExportSplit("lots", 0); err == nil {
+		t.Fatal("expected invalid --split-size to fail")
+	}
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// activeHeaders holds the extra request headers from --header and the
+// extra_headers config map, sent with every API request. A name given with
+// --header replaces the configured value.
+var activeHeaders http.Header
+
+// parseHeaderFlag parses repeated --header "Name: value" flags.
+func parseHeaderFlag(values []string) (http.Header, error) {
+	parsed := http.Header{}
+	for _, header := range values {
+		name, value, ok := strings.Cut(header, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("invalid --header %q (expected \"Name: value\")", header)
+		}
+		parsed.Add(name, strings.TrimSpace(value))
+	}
+	return parsed, nil
+}
+
+// resolveExtraHeaders merges the extra_headers config map with --header flags.
+func resolveExtraHeaders(flags []string, configured map[string]string) (http.Header, error) {
+	headers := http.Header{}
+	for name, value := range configured {
+		if strings.TrimSpace(name) == "" || strings.ContainsAny(strings.TrimSpace(name), " \t:") {
+			return nil, fmt.Errorf("invalid extra_headers name %q in config", name)
+		}
+		headers.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	parsed, err := parseHeaderFlag(flags)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range parsed {
+		headers[name] = values
+	}
+	if len(headers) == 0 {
+		return nil, nil
+	}
+	return headers, nil
+}
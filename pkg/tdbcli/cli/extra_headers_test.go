@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestExtraHeadersSentWithEveryRequest(t *testing.T) {
+	defer func() { activeHeaders = nil }()
+	srv := clienttest.NewServer(t)
+	srv.AddDocument("users", map[string]any{"name": "Ann"})
+	var seen []http.Header
+	routes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Clone())
+		routes.ServeHTTP(w, r)
+	})
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	config := "endpoint: " + srv.URL + "\nextra_headers:\n  X-Org-Token: from-config\n  X-Region: eu\n"
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	run := func(args ...string) (string, error) {
+		root := NewRootCommand()
+		root.SetArgs(append([]string{"--config", configPath}, args...))
+		var out bytes.Buffer
+		root.SetOut(&out)
+		root.SetErr(&bytes.Buffer{})
+		err := root.Execute()
+		return out.String(), err
+	}
+
+	if _, err := run("tenant", "documents", "list", "users", "--header", "X-Org-Token: abc", "--header", "X-Trace: 1", "--tenant", "tn_test", "--api-key", clienttest.APIKey); err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(seen) == 0 {
+		t.Fatal("expected requests to reach the server")
+	}
+	for _, h := range seen {
+		if h.Get("X-Org-Token") != "abc" || h.Get("X-Region") != "eu" || h.Get("X-Trace") != "1" || h.Get("X-API-Key") != clienttest.APIKey {
+			t.Fatalf("unexpected request headers %v", h)
+		}
+	}
+
+	out, err := run("config", "show")
+	if err != nil {
+		t.Fatalf("config show failed: %v", err)
+	}
+	if strings.Contains(out, "from-config") || !strings.Contains(out, "X-Org-Token") {
+		t.Fatalf("expected masked extra_headers in config show:\n%s", out)
+	}
+
+	if _, err := run("tenant", "documents", "list", "users", "--header", "no-colon", "--tenant", "tn_test", "--api-key", clienttest.APIKey); err == nil || !strings.Contains(err.Error(), "invalid --header") {
+		t.Fatalf("expected an invalid header error, got %v", err)
+	}
+}
@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const (
+	filterRawFlagUsage = "Filter predicate field=value matched as an exact string, skipping schema type coercion (repeatable)"
+
+	schemaCacheFile = "schema-cache.json"
+	schemaCacheTTL  = 10 * time.Minute
+)
+
+// Filter value types derived from a collection's JSON schema.
+const (
+	filterTypeString   = ""
+	filterTypeNumber   = "number"
+	filterTypeInteger  = "integer"
+	filterTypeBoolean  = "boolean"
+	filterTypeDate     = "date"
+	filterTypeDateTime = "date-time"
+)
+
+// schemaCacheEntry remembers the filterable field types of one collection.
+type schemaCacheEntry struct {
+	FetchedAt time.Time         `json:"fetched_at"`
+	Types     map[string]string `json:"types"`
+}
+
+// typedFilters holds --filter values coerced to the types declared by the
+// collection schema, alongside --filter-raw values kept as exact strings.
+type typedFilters struct {
+	Values map[string]any
+	Raw    map[string]string
+}
+
+// queryParams renders the filters as f.<field> query values. Coerced values
+// use a canonical form (30.0 becomes 30, TRUE becomes true, dates become
+// RFC 3339) so they compare equal to the stored values.
+func (f typedFilters) queryParams() map[string]string {
+	out := make(map[string]string, len(f.Values)+len(f.Raw))
+	for field, value := range f.Values {
+		out[field] = formatFilterValue(value)
+	}
+	for field, value := range f.Raw {
+		out[field] = value
+	}
+	return out
+}
+
+// whereClause renders the filters as equality conditions for a report body.
+func (f typedFilters) whereClause() map[string]any {
+	fields := make([]string, 0, len(f.Values)+len(f.Raw))
+	values := make(map[string]any, len(f.Values)+len(f.Raw))
+	for field, value := range f.Values {
+		fields = append(fields, field)
+		values[field] = value
+	}
+	for field, value := range f.Raw {
+		if _, ok := values[field]; !ok {
+			fields = append(fields, field)
+		}
+		values[field] = value
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	sort.Strings(fields)
+	conditions := make([]any, 0, len(fields))
+	for _, field := range fields {
+		conditions = append(conditions, map[string]any{field: map[string]any{"eq": values[field]}})
+	}
+	return map[string]any{"and": conditions}
+}
+
+// resolveTypedFilters parses --filter and --filter-raw flags. --filter values
+// are coerced using the collection schema, which is fetched only when filters
+// are present and cached next to the config file. Without a schema, values
+// are sent unchanged.
+func resolveTypedFilters(ctx context.Context, env *Environment, tenantClient *clientpkg.TenantClient, tenantID, collection, appID string, filters, rawFilters []string) (typedFilters, error) {
+	var result typedFilters
+	parsed, err := parseFilterFlags(filters)
+	if err != nil {
+		return result, err
+	}
+	raw, err := parseFilterFlags(rawFilters)
+	if err != nil {
+		return result, err
+	}
+	result.Raw = raw
+	result.Values = make(map[string]any, len(parsed))
+	if len(parsed) == 0 {
+		return result, nil
+	}
+	types := collectionFieldTypes(ctx, env, tenantClient, tenantID, collection, appID)
+	for field, value := range parsed {
+		coerced, err := coerceFilterValue(value, types[field])
+		if err != nil {
+			return result, fmt.Errorf("filter %s: %w (use --filter-raw to match the exact string)", field, err)
+		}
+		result.Values[field] = coerced
+	}
+	return result, nil
+}
+
+// coerceFilterValue converts a filter value to the given schema type.
+func coerceFilterValue(value, fieldType string) (any, error) {
+	switch fieldType {
+	case filterTypeNumber, filterTypeInteger:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", value)
+		}
+		if fieldType == filterTypeInteger && number != float64(int64(number)) {
+			return nil, fmt.Errorf("%q is not an integer", value)
+		}
+		return number, nil
+	case filterTypeBoolean:
+		switch strings.ToLower(value) {
+		case "yes", "y", "on":
+			return true, nil
+		case "no", "n", "off":
+			return false, nil
+		}
+		flag, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a boolean", value)
+		}
+		return flag, nil
+	case filterTypeDate:
+		ts, err := parseFilterTime(value)
+		if err != nil {
+			return nil, err
+		}
+		return ts.Format(time.DateOnly), nil
+	case filterTypeDateTime:
+		ts, err := parseFilterTime(value)
+		if err != nil {
+			return nil, err
+		}
+		return ts.UTC().Format(time.RFC3339Nano), nil
+	}
+	return value, nil
+}
+
+func parseFilterTime(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, time.DateTime, "2006-01-02T15:04:05", time.DateOnly} {
+		if ts, err := time.Parse(layout, value); err == nil {
+			return ts, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a date (use YYYY-MM-DD or RFC 3339)", value)
+}
+
+func formatFilterValue(value any) string {
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(typed)
+	}
+	return fmt.Sprint(value)
+}
+
+// collectionFieldTypes returns the schema types of a collection's fields,
+// consulting the schema cache first. Lookup failures yield no types so
+// filters fall back to plain strings. The cache is skipped when no tenant can
+// be resolved, so schemas of different tenants never share an entry.
+func collectionFieldTypes(ctx context.Context, env *Environment, tenantClient *clientpkg.TenantClient, tenantID, collection, appID string) map[string]string {
+	path := cacheFilePath(env, schemaCacheFile)
+	resolved, err := resolveTenantID(env, tenantID)
+	if err != nil {
+		path = ""
+	}
+	key := applicationsCacheKey(env, resolved) + "|" + strings.TrimSpace(appID) + "|" + collection
+	cache := loadCacheFile[schemaCacheEntry](path)
+	if entry, ok := cache[key]; ok && time.Since(entry.FetchedAt) < schemaCacheTTL {
+		return entry.Types
+	}
+	col, err := tenantClient.GetCollection(ctx, collection, appID)
+	if err != nil || col == nil {
+		return nil
+	}
+	types := extractSchemaFieldTypes(col.SchemaJSON)
+	if path != "" {
+		cache[key] = schemaCacheEntry{FetchedAt: time.Now().UTC(), Types: types}
+		_ = storeCacheFile(path, cache)
+	}
+	return types
+}
+
+// extractSchemaFieldTypes maps dotted field paths to their filter type for
+// the scalar properties of a JSON schema.
+func extractSchemaFieldTypes(schemaJSON string) map[string]string {
+	trimmed := strings.TrimSpace(schemaJSON)
+	if trimmed == "" {
+		return nil
+	}
+	var decoded any
+	if err := json.Unmarshal([]byte(trimmed), &decoded); err != nil {
+		return nil
+	}
+	types := make(map[string]string)
+	collectSchemaFieldTypes(decoded, "", types)
+	return types
+}
+
+func collectSchemaFieldTypes(node any, path string, types map[string]string) {
+	obj, ok := node.(map[string]any)
+	if !ok {
+		return
+	}
+	if path != "" {
+		if fieldType := schemaFilterType(obj); fieldType != filterTypeString {
+			types[path] = fieldType
+		}
+	}
+	props, ok := obj["properties"].(map[string]any)
+	if !ok {
+		return
+	}
+	for key, child := range props {
+		next := key
+		if path != "" {
+			next = path + "." + key
+		}
+		collectSchemaFieldTypes(child, next, types)
+	}
+}
+
+// schemaFilterType reads "type" (a string or a list that may include "null")
+// and "format" from a schema node.
+func schemaFilterType(node map[string]any) string {
+	var declared []string
+	switch typed := node["type"].(type) {
+	case string:
+		declared = []string{typed}
+	case []any:
+		for _, item := range typed {
+			if s, ok := item.(string); ok && s != "null" {
+				declared = append(declared, s)
+			}
+		}
+	}
+	if len(declared) != 1 {
+		return filterTypeString
+	}
+	switch declared[0] {
+	case filterTypeNumber, filterTypeInteger, filterTypeBoolean:
+		return declared[0]
+	case "string":
+		if format, _ := node["format"].(string); format == filterTypeDate || format == filterTypeDateTime {
+			return format
+		}
+	}
+	return filterTypeString
+}
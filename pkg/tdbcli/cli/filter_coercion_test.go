@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+const filterTestSchema = `{"type":"object","properties":{"age":{"type":"integer"},"score":{"type":["number","null"]},"active":{"type":"boolean"},"born":{"type":"string","format":"date"},"seen_at":{"type":"string","format":"date-time"},"profile":{"type":"object","properties":{"level":{"type":"number"}}},"name":{"type":"string"}}}`
+
+func TestExtractSchemaFieldTypes(t *testing.T) {
+	got := extractSchemaFieldTypes(filterTestSchema)
+	want := map[string]string{"age": "integer", "score": "number", "active": "boolean", "born": "date", "seen_at": "date-time", "profile.level": "number"}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected types: %v", got)
+	}
+	for field, typ := range want {
+		if got[field] != typ {
+			t.Fatalf("%s: got %q, want %q", field, got[field], typ)
+		}
+	}
+}
+
+func TestCoerceFilterValue(t *testing.T) {
+	cases := []struct {
+		value string
+		typ   string
+		want  string
+		err   bool
+	}{
+		{value: "30.0", typ: "integer", want: "30"},
+		{value: "30.5", typ: "integer", err: true},
+		{value: "1e3", typ: "number", want: "1000"},
+		{value: "abc", typ: "number", err: true},
+		{value: "TRUE", typ: "boolean", want: "true"},
+		{value: "no", typ: "boolean", want: "false"},
+		{value: "maybe", typ: "boolean", err: true},
+		{value: "2025-01-02T10:00:00+07:00", typ: "date", want: "2025-01-02"},
+		{value: "2025-01-02", typ: "date-time", want: "2025-01-02T00:00:00Z"},
+		{value: "2025-01-02T10:00:00+07:00", typ: "date-time", want: "2025-01-02T03:00:00Z"},
+		{value: "yesterday", typ: "date-time", err: true},
+		{value: "30.0", typ: "", want: "30.0"},
+	}
+	for _, tc := range cases {
+		got, err := coerceFilterValue(tc.value, tc.typ)
+		if tc.err {
+			if err == nil {
+				t.Fatalf("%s as %s: expected error, got %v", tc.value, tc.typ, got)
+			}
+			continue
+		}
+		if err != nil || formatFilterValue(got) != tc.want {
+			t.Fatalf("%s as %s: got %v (%v), want %s", tc.value, tc.typ, got, err, tc.want)
+		}
+	}
+}
+
+func TestTypedFiltersWhereClause(t *testing.T) {
+	filters := typedFilters{Values: map[string]any{"age": 30.0, "active": true}, Raw: map[string]string{"code": "007"}}
+	encoded, _ := json.Marshal(filters.whereClause())
+	want := `{"and":[{"active":{"eq":true}},{"age":{"eq":30}},{"code":{"eq":"007"}}]}`
+	if string(encoded) != want {
+		t.Fatalf("got %s, want %s", encoded, want)
+	}
+	if (typedFilters{}).whereClause() != nil {
+		t.Fatalf("expected no where clause without filters")
+	}
+}
+
+func TestDocumentsListCoercesFilters(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users", SchemaJSON: filterTestSchema})
+	srv.AddDocument("users", map[string]any{"name": "ada", "age": 30, "active": true})
+	srv.AddDocument("users", map[string]any{"name": "grace", "age": 41, "active": true})
+
+	run := func(args ...string) (string, error) {
+		out, _, err := runCommand(t, srv, newTenantDocumentsListCommand, append([]string{"users", "--raw"}, args...)...)
+		return out, err
+	}
+
+	out, err := run("--filter", "age=30.0", "--filter", "active=TRUE")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if !strings.Contains(out, "ada") || strings.Contains(out, "grace") {
+		t.Fatalf("expected only ada to match:\n%s", out)
+	}
+	out, err = run("--filter-raw", "age=30.0")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if strings.Contains(out, "ada") {
+		t.Fatalf("expected --filter-raw to match the exact string:\n%s", out)
+	}
+	if _, err := run("--filter", "age=thirty"); err == nil || !strings.Contains(err.Error(), "--filter-raw") {
+		t.Fatalf("expected coercion error, got %v", err)
+	}
+}
+
+func TestCollectionFieldTypesCacheNeedsTenant(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users", SchemaJSON: filterTestSchema})
+	dir := t.TempDir()
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}, ConfigPath: filepath.Join(dir, "config.yaml")}
+	client := srv.TenantClient(t)
+
+	if types := collectionFieldTypes(context.Background(), env, client, "", "users", ""); types["age"] != "integer" {
+		t.Fatalf("expected schema types without a tenant, got %v", types)
+	}
+	if _, err := os.Stat(filepath.Join(dir, schemaCacheFile)); !os.IsNotExist(err) {
+		t.Fatalf("expected no cache entry without a tenant, got %v", err)
+	}
+
+	env.Config.DefaultTenant = fakeTenantID
+	collectionFieldTypes(context.Background(), env, client, "", "users", "")
+	cache := loadCacheFile[schemaCacheEntry](filepath.Join(dir, schemaCacheFile))
+	if _, ok := cache[applicationsCacheKey(env, fakeTenantID)+"||users"]; !ok || len(cache) != 1 {
+		t.Fatalf("expected the entry under the default tenant, got %v", cache)
+	}
+}
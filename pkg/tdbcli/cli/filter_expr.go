@@ -0,0 +1,388 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const (
+	filterOrFlagUsage   = "Match any of these comma-separated field=value conditions; groups are ANDed with each other and with --filter (repeatable)"
+	filterExprFlagUsage = "Boolean filter expression such as '(status=active OR status=pending) AND region=KH'"
+)
+
+// filterExprFlags binds --filter-or and --filter-expr.
+type filterExprFlags struct {
+	orGroups []string
+	expr     string
+}
+
+func (f *filterExprFlags) bind(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&f.orGroups, "filter-or", nil, filterOrFlagUsage)
+	cmd.Flags().StringVar(&f.expr, "filter-expr", "", filterExprFlagUsage)
+}
+
+func (f filterExprFlags) active() bool {
+	return len(f.orGroups) > 0 || strings.TrimSpace(f.expr) != ""
+}
+
+// describe returns the flags as they were given, for manifests.
+func (f filterExprFlags) describe() []string {
+	var out []string
+	for _, group := range f.orGroups {
+		out = append(out, "or:"+group)
+	}
+	if trimmed := strings.TrimSpace(f.expr); trimmed != "" {
+		out = append(out, "expr:"+trimmed)
+	}
+	return out
+}
+
+// resolve parses the flags into one expression and coerces its values to the
+// schema types of the collection, like --filter. It returns nil when neither
+// flag is set.
+func (f filterExprFlags) resolve(ctx context.Context, env *Environment, tenantClient *clientpkg.TenantClient, tenantID, collection, appID string) (*filterExpr, error) {
+	var parts []*filterExpr
+	for _, group := range f.orGroups {
+		var alternatives []*filterExpr
+		for _, item := range splitCommaList(group) {
+			cond, err := parseFilterCondition(item)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --filter-or %q: %w", group, err)
+			}
+			alternatives = append(alternatives, cond)
+		}
+		if len(alternatives) == 0 {
+			return nil, fmt.Errorf("invalid --filter-or %q: expected field=value conditions", group)
+		}
+		parts = append(parts, combineFilterExpr("or", alternatives))
+	}
+	if trimmed := strings.TrimSpace(f.expr); trimmed != "" {
+		parsed, err := parseFilterExpr(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --filter-expr: %w", err)
+		}
+		parts = append(parts, parsed)
+	}
+	if len(parts) == 0 {
+		return nil, nil
+	}
+	expr := combineFilterExpr("and", parts)
+	types := collectionFieldTypes(ctx, env, tenantClient, tenantID, collection, appID)
+	if err := expr.coerce(types); err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+// filterExpr is a tree of field=value conditions joined by "and" or "or".
+// Op is empty for a condition.
+type filterExpr struct {
+	Op       string
+	Children []*filterExpr
+	Field    string
+	Value    any
+}
+
+// combineFilterExpr joins parts with op, flattening nested nodes of the same
+// operator.
+func combineFilterExpr(op string, parts []*filterExpr) *filterExpr {
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	node := &filterExpr{Op: op}
+	for _, part := range parts {
+		if part.Op == op {
+			node.Children = append(node.Children, part.Children...)
+			continue
+		}
+		node.Children = append(node.Children, part)
+	}
+	return node
+}
+
+func parseFilterCondition(text string) (*filterExpr, error) {
+	field, value, ok := strings.Cut(text, "=")
+	field = strings.TrimSpace(field)
+	if !ok || field == "" {
+		return nil, fmt.Errorf("expected field=value, got %q", text)
+	}
+	return &filterExpr{Field: field, Value: unquoteFilterValue(strings.TrimSpace(value))}, nil
+}
+
+func unquoteFilterValue(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// parseFilterExpr parses conditions joined by AND and OR (case-insensitive)
+// with parentheses for grouping. AND binds tighter than OR. Values containing
+// spaces or parentheses can be quoted: name="Ann Lee".
+func parseFilterExpr(input string) (*filterExpr, error) {
+	tokens, err := tokenizeFilterExpr(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterExprParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	return expr, nil
+}
+
+func tokenizeFilterExpr(input string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(input); {
+		switch c := input[i]; {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, string(c))
+			i++
+		default:
+			start := i
+			for i < len(input) && !strings.ContainsRune(" \t\n()", rune(input[i])) {
+				if quote := input[i]; quote == '"' || quote == '\'' {
+					end := strings.IndexByte(input[i+1:], quote)
+					if end == -1 {
+						return nil, fmt.Errorf("unterminated quote in %q", input[start:])
+					}
+					i += end + 1
+				}
+				i++
+			}
+			tokens = append(tokens, input[start:i])
+		}
+	}
+	return tokens, nil
+}
+
+type filterExprParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *filterExprParser) keyword(word string) bool {
+	if p.pos < len(p.tokens) && strings.EqualFold(p.tokens[p.pos], word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterExprParser) parseOr() (*filterExpr, error) {
+	return p.parseJoined("or", p.parseAnd)
+}
+
+func (p *filterExprParser) parseAnd() (*filterExpr, error) {
+	return p.parseJoined("and", p.parseTerm)
+}
+
+func (p *filterExprParser) parseJoined(op string, next func() (*filterExpr, error)) (*filterExpr, error) {
+	first, err := next()
+	if err != nil {
+		return nil, err
+	}
+	parts := []*filterExpr{first}
+	for p.keyword(op) {
+		part, err := next()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+	return combineFilterExpr(op, parts), nil
+}
+
+func (p *filterExprParser) parseTerm() (*filterExpr, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("expected a condition at the end of the expression")
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+	switch {
+	case token == "(":
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.keyword(")") {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		return expr, nil
+	case token == ")" || strings.EqualFold(token, "and") || strings.EqualFold(token, "or"):
+		return nil, fmt.Errorf("expected a condition, got %q", token)
+	}
+	return parseFilterCondition(token)
+}
+
+func (e *filterExpr) coerce(types map[string]string) error {
+	if e.Op != "" {
+		for _, child := range e.Children {
+			if err := child.coerce(types); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	coerced, err := coerceFilterValue(e.Value.(string), types[e.Field])
+	if err != nil {
+		return fmt.Errorf("filter %s: %w", e.Field, err)
+	}
+	e.Value = coerced
+	return nil
+}
+
+// where renders the expression as a report where clause.
+func (e *filterExpr) where() map[string]any {
+	if e.Op == "" {
+		return map[string]any{e.Field: map[string]any{"eq": e.Value}}
+	}
+	conditions := make([]any, 0, len(e.Children))
+	for _, child := range e.Children {
+		conditions = append(conditions, child.where())
+	}
+	return map[string]any{e.Op: conditions}
+}
+
+// matches evaluates the expression against decoded document data. Fields are
+// dotted paths; a missing field matches nothing.
+func (e *filterExpr) matches(data map[string]any) bool {
+	switch e.Op {
+	case "and":
+		for _, child := range e.Children {
+			if !child.matches(data) {
+				return false
+			}
+		}
+		return true
+	case "or":
+		for _, child := range e.Children {
+			if child.matches(data) {
+				return true
+			}
+		}
+		return false
+	}
+	value := lookupExpandPath(data, e.Field)
+	return value != nil && formatFilterValue(value) == formatFilterValue(e.Value)
+}
+
+// matchesDocument decodes a document's data and evaluates the expression.
+func (e *filterExpr) matchesDocument(doc clientpkg.Document) bool {
+	var data map[string]any
+	if err := json.Unmarshal([]byte(doc.Data), &data); err != nil {
+		return false
+	}
+	return e.matches(data)
+}
+
+// fields lists the distinct fields the expression reads, sorted.
+func (e *filterExpr) fields() []string {
+	seen := map[string]bool{}
+	var walk func(*filterExpr)
+	walk = func(node *filterExpr) {
+		if node.Op == "" {
+			seen[node.Field] = true
+		}
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(e)
+	out := make([]string, 0, len(seen))
+	for field := range seen {
+		out = append(out, field)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// pushDown moves the conditions the list API can apply itself (top-level
+// ANDed equality conditions on fields without a --filter) into query, and
+// returns what is left to evaluate client-side, or nil.
+func (e *filterExpr) pushDown(query map[string]string) *filterExpr {
+	if e == nil {
+		return nil
+	}
+	conjuncts := []*filterExpr{e}
+	if e.Op == "and" {
+		conjuncts = e.Children
+	}
+	var rest []*filterExpr
+	for _, part := range conjuncts {
+		if _, taken := query[part.Field]; part.Op != "" || taken {
+			rest = append(rest, part)
+			continue
+		}
+		query[part.Field] = formatFilterValue(part.Value)
+	}
+	if len(rest) == 0 {
+		return nil
+	}
+	return combineFilterExpr("and", rest)
+}
+
+// requireSelected fails when --select would drop a field the expression
+// needs to be evaluated client-side.
+func (e *filterExpr) requireSelected(selected []string) error {
+	if e == nil || len(selected) == 0 {
+		return nil
+	}
+	have := make(map[string]bool, len(selected))
+	for _, field := range selected {
+		have[field] = true
+	}
+	var missing []string
+	for _, field := range e.fields() {
+		top, _, _ := strings.Cut(field, ".")
+		if !have[field] && !have[top] {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("--select must include the fields used by --filter-or/--filter-expr (missing %s)", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// listMatchingDocuments pages through a listing until params.Limit documents
+// match expr (every document when expr is nil) or the listing ends. Documents
+// before the incremental watermark are passed over, and only those scanned up
+// to the last match are passed to incremental, so a state file never skips
+// documents that were fetched but not returned. It returns the matches, with pagination
+// describing them rather than the server's totals, and the number scanned.
+func listMatchingDocuments(ctx context.Context, tenantClient *clientpkg.TenantClient, collection string, params clientpkg.ListDocumentsParams, expr *filterExpr, incremental *incrementalExtract) (*clientpkg.DocumentListResponse, int, error) {
+	limit := params.Limit
+	resp := &clientpkg.DocumentListResponse{Items: []clientpkg.Document{}}
+	scanned := 0
+	docs := tenantClient.DocumentsIterator(ctx, collection, params, clientpkg.WithoutStreaming())
+	for len(resp.Items) < limit && docs.Next() {
+		doc := docs.Document()
+		if incremental.skip(doc) {
+			continue
+		}
+		scanned++
+		incremental.observe(doc)
+		if expr == nil || expr.matchesDocument(doc) {
+			resp.Items = append(resp.Items, doc)
+		}
+	}
+	if err := docs.Err(); err != nil {
+		return nil, scanned, err
+	}
+	resp.Pagination = clientpkg.DocumentPagination{Limit: limit, Offset: params.Offset, Count: int64(len(resp.Items))}
+	return resp, scanned, nil
+}
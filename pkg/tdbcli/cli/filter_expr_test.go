@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestParseFilterExpr(t *testing.T) {
+	cases := map[string]string{
+		"status=active": `{"status":{"eq":"active"}}`,
+		"(status=active OR status=pending) AND region=KH":     `{"and":[{"or":[{"status":{"eq":"active"}},{"status":{"eq":"pending"}}]},{"region":{"eq":"KH"}}]}`,
+		"a=1 or b=2 and c=3":                                  `{"or":[{"a":{"eq":"1"}},{"and":[{"b":{"eq":"2"}},{"c":{"eq":"3"}}]}]}`,
+		`name="Ann (Lee)" OR name='Bo Li'`:                    `{"or":[{"name":{"eq":"Ann (Lee)"}},{"name":{"eq":"Bo Li"}}]}`,
+		"((a=1 OR a=2) OR a=3) AND (b=1 AND address.city=PP)": `{"and":[{"or":[{"a":{"eq":"1"}},{"a":{"eq":"2"}},{"a":{"eq":"3"}}]},{"b":{"eq":"1"}},{"address.city":{"eq":"PP"}}]}`,
+	}
+	for input, want := range cases {
+		expr, err := parseFilterExpr(input)
+		if err != nil {
+			t.Fatalf("parseFilterExpr(%q): %v", input, err)
+		}
+		encoded, _ := json.Marshal(expr.where())
+		if string(encoded) != want {
+			t.Fatalf("parseFilterExpr(%q) = %s, want %s", input, encoded, want)
+		}
+	}
+	for _, input := range []string{"", "(a=1", "a=1 AND", "a=1 b=2", "OR a=1", "status", `name="open`} {
+		if _, err := parseFilterExpr(input); err == nil {
+			t.Fatalf("expected %q to be rejected", input)
+		}
+	}
+}
+
+func TestFilterExprPushDownAndMatch(t *testing.T) {
+	expr, err := parseFilterExpr("(status=active OR status=pending) AND region=KH AND tier=gold")
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := map[string]string{"tier": "silver"}
+	rest := expr.pushDown(query)
+	if query["region"] != "KH" || query["tier"] != "silver" {
+		t.Fatalf("unexpected pushed query %v", query)
+	}
+	encoded, _ := json.Marshal(rest.where())
+	if want := `{"and":[{"or":[{"status":{"eq":"active"}},{"status":{"eq":"pending"}}]},{"tier":{"eq":"gold"}}]}`; string(encoded) != want {
+		t.Fatalf("unexpected remainder %s", encoded)
+	}
+	if !rest.matches(map[string]any{"status": "pending", "tier": "gold"}) || rest.matches(map[string]any{"status": "closed", "tier": "gold"}) {
+		t.Fatal("unexpected match results")
+	}
+	if err := rest.requireSelected([]string{"status"}); err == nil || !strings.Contains(err.Error(), "missing tier") {
+		t.Fatalf("expected missing select field error, got %v", err)
+	}
+}
+
+func TestDocumentsFilterExprListExportReport(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddDocument("orders", map[string]any{"ref": "o1", "status": "active", "region": "KH", "total": 10})
+	srv.AddDocument("orders", map[string]any{"ref": "o2", "status": "pending", "region": "KH", "total": 20})
+	srv.AddDocument("orders", map[string]any{"ref": "o3", "status": "closed", "region": "KH", "total": 30})
+	srv.AddDocument("orders", map[string]any{"ref": "o4", "status": "active", "region": "TH", "total": 40})
+	var reportBody map[string]any
+	routes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/query" {
+			_ = json.NewDecoder(r.Body).Decode(&reportBody)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"data":[],"pagination":{}}`))
+			return
+		}
+		routes.ServeHTTP(w, r)
+	})
+
+	out, _, err := runCommand(t, srv, newTenantDocumentsListCommand, "orders", "--raw", "--filter-expr", "(status=active OR status=pending) AND region=KH")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if !strings.Contains(out, "o1") || !strings.Contains(out, "o2") || strings.Contains(out, "o3") || strings.Contains(out, "o4") {
+		t.Fatalf("expected o1 and o2 only:\n%s", out)
+	}
+
+	// Matches spread over several pages are collected up to --limit.
+	out, _, err = runCommand(t, srv, newTenantDocumentsListCommand, "orders", "--limit", "2", "--filter-expr", "status=closed OR status=pending")
+	if err != nil {
+		t.Fatalf("paged list failed: %v", err)
+	}
+	if !strings.Contains(out, "MATCHED: 2  SCANNED: 3  LIMIT: 2") {
+		t.Fatalf("expected two matches collected over pages:\n%s", out)
+	}
+
+	out, _, err = runCommand(t, srv, newTenantDocumentsExportCommand, "orders", "--filter-or", "status=closed,region=TH", "--filter-or", "total=30,total=40")
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if lines := strings.Count(out, "\n"); lines != 2 || !strings.Contains(out, "o3") || !strings.Contains(out, "o4") {
+		t.Fatalf("expected o3 and o4 exported:\n%s", out)
+	}
+
+	if _, _, err := runCommand(t, srv, newTenantDocumentsReportCommand, "orders", "--raw", "--filter", "region=KH", "--filter-or", "status=active,status=pending"); err != nil {
+		t.Fatalf("report failed: %v", err)
+	}
+	encoded, _ := json.Marshal(reportBody["where"])
+	if want := `{"and":[{"region":{"eq":"KH"}},{"or":[{"status":{"eq":"active"}},{"status":{"eq":"pending"}}]}]}`; string(encoded) != want {
+		t.Fatalf("unexpected report where %s", encoded)
+	}
+}
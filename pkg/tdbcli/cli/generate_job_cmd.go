@@ -0,0 +1,483 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	versionpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/version"
+)
+
+// jobTypes lists the job types 'generate job' knows, in help order.
+var jobTypes = []string{"backup", "incremental", "verify", "audit-ship"}
+
+// jobNamePattern restricts job and collection names to characters that are
+// safe unquoted in file names, unit names and shell words.
+var jobNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// cronShortcuts expands the @-schedules cron accepts; GitHub Actions and the
+// systemd conversion only understand the five-field form.
+var cronShortcuts = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+}
+
+// jobSpec is a routine job reduced to shell: variables the user may override
+// from the environment, the commands that run the job, and the files under
+// JOB_DIR that carry state from one run to the next.
+type jobSpec struct {
+	Name    string
+	Summary string
+	Vars    [][2]string
+	Lines   []string
+	State   []string
+}
+
+func newGenerateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate files that operationalize CLI commands",
+	}
+	cmd.AddCommand(newGenerateJobCommand())
+	return cmd
+}
+
+func newGenerateJobCommand() *cobra.Command {
+	var jobType string
+	var collection string
+	var schedule string
+	var format string
+	var name string
+	var dir string
+	var keepDays int
+	var outPath string
+
+	cmd := &cobra.Command{
+		Use:   "job",
+		Short: "Generate a scheduled job wrapping a routine CLI command",
+		Long: `Generate a ready-to-schedule job for a routine task:
+
+  backup       stream a collection to a timestamped JSONL file with checksums and
+               a lineage manifest, deleting backups older than --keep-days
+  incremental  export what changed since the last run, resuming from a cursor file
+  verify       compare a collection with the checksum manifest written on the
+               first run ('documents verify')
+  audit-ship   append new audit entries to a JSONL file, resuming from a checkpoint
+
+Formats:
+  shell    a self-contained bash script, with the crontab line in its header
+  systemd  a .service and .timer unit plus the script they run (--out is a directory)
+  github   a GitHub Actions workflow running on the schedule
+
+Credentials are never written to the output. Jobs read the tenant and API key
+from TDB_TENANT and TDB_API_KEY (and the endpoint from TDB_ENDPOINT when set),
+and run the CLI with --read-only. Output locations default to a per-job
+directory and can be moved with --dir or the JOB_DIR variable at run time.
+
+GitHub Actions runners start empty, so the github format restores the state
+files of incremental, verify and audit-ship jobs (cursor, manifest,
+checkpoint) from the Actions cache before each run and saves them afterwards.`,
+		Example: `  # Nightly backup script for cron
+  tdb generate job --type backup --collection users --schedule "0 2 * * *" --out backup-users.sh
+
+  # The same job as a systemd timer
+  tdb generate job --type backup --collection users --schedule "0 2 * * *" --format systemd --out /etc/systemd/system
+
+  # Hourly incremental export as a GitHub Actions workflow
+  tdb generate job --type incremental --collection events --schedule @hourly --format github --out .github/workflows/export-events.yml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kind := strings.ToLower(strings.TrimSpace(jobType))
+			collection = strings.TrimSpace(collection)
+			if kind == "audit-ship" {
+				if collection != "" {
+					return errors.New("--collection is not used by audit-ship jobs")
+				}
+			} else if collection == "" {
+				return errors.New("--collection is required")
+			} else if !jobNamePattern.MatchString(collection) {
+				return fmt.Errorf("collection %q cannot be used in a generated job (letters, digits, '.', '_' and '-' only)", collection)
+			}
+			if keepDays < 0 {
+				return errors.New("--keep-days cannot be negative")
+			}
+			cron, err := normalizeCronSchedule(schedule)
+			if err != nil {
+				return err
+			}
+			spec, err := buildJobSpec(kind, collection, keepDays)
+			if err != nil {
+				return err
+			}
+			if trimmed := strings.TrimSpace(name); trimmed != "" {
+				if !jobNamePattern.MatchString(trimmed) {
+					return fmt.Errorf("invalid --name %q (letters, digits, '.', '_' and '-' only)", trimmed)
+				}
+				spec.Name = trimmed
+			}
+
+			files := map[string]string{}
+			var order []string
+			switch strings.ToLower(strings.TrimSpace(format)) {
+			case "shell", "sh", "":
+				order = []string{spec.Name + ".sh"}
+				files[order[0]] = renderJobShell(spec, cron, jobDir(dir, "$HOME/tdb-jobs/"+spec.Name), "")
+			case "systemd":
+				calendar, err := cronToOnCalendar(cron)
+				if err != nil {
+					return err
+				}
+				script := "/usr/local/lib/tdb/" + spec.Name + ".sh"
+				order = []string{spec.Name + ".service", spec.Name + ".timer", spec.Name + ".sh"}
+				files[order[0]], files[order[1]] = renderJobSystemd(spec, cron, calendar, script)
+				files[order[2]] = renderJobShell(spec, cron, jobDir(dir, "/var/lib/tdb/"+spec.Name), script)
+			case "github", "github-actions":
+				order = []string{spec.Name + ".yml"}
+				files[order[0]] = renderJobGitHub(spec, cron, jobDir(dir, "tdb-jobs/"+spec.Name))
+			default:
+				return fmt.Errorf("unsupported format %q (choose shell, systemd, or github)", format)
+			}
+			return writeJobFiles(cmd, strings.TrimSpace(outPath), strings.EqualFold(strings.TrimSpace(format), "systemd"), order, files)
+		},
+	}
+
+	cmd.Flags().StringVar(&jobType, "type", "", "Job to generate: "+strings.Join(jobTypes, ", "))
+	cmd.Flags().StringVar(&collection, "collection", "", "Collection the job works on (not used by audit-ship)")
+	cmd.Flags().StringVar(&schedule, "schedule", "0 2 * * *", "Cron schedule (five fields, or @hourly, @daily, @weekly, @monthly)")
+	cmd.Flags().StringVar(&format, "format", "shell", "Output format: shell, systemd, or github")
+	cmd.Flags().StringVar(&name, "name", "", "Job name used for files and units (default e.g. backup-<collection>)")
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory for the job's output and state (default depends on --format)")
+	cmd.Flags().IntVar(&keepDays, "keep-days", 14, "Days to keep backup files (backup jobs; 0 keeps everything)")
+	cmd.Flags().StringVar(&outPath, "out", "", "Write to this file (a directory for --format systemd) instead of stdout")
+	_ = cmd.MarkFlagRequired("type")
+	return cmd
+}
+
+// buildJobSpec returns the commands for one job type. Every command reads
+// credentials from the environment and runs in read-only mode.
+func buildJobSpec(kind, collection string, keepDays int) (jobSpec, error) {
+	auth := `--tenant "$TDB_TENANT" --api-key "$TDB_API_KEY" ${TDB_ENDPOINT:+--endpoint "$TDB_ENDPOINT"} --read-only`
+	switch kind {
+	case "backup":
+		return jobSpec{
+			Name:    "backup-" + collection,
+			Summary: "back up collection " + collection,
+			Vars:    [][2]string{{"KEEP_DAYS", strconv.Itoa(keepDays)}},
+			Lines: []string{
+				`stamp="$(date -u +%Y%m%dT%H%M%SZ)"`,
+				`mkdir -p "$JOB_DIR"`,
+				fmt.Sprintf(`"$TDB_BIN" tenant documents export %s --stream \`, collection),
+				fmt.Sprintf(`  --out "$JOB_DIR/%s-$stamp.jsonl" --checksum-every 10000 \`, collection),
+				fmt.Sprintf(`  --manifest "$JOB_DIR/%s-$stamp.lineage.json" \`, collection),
+				"  " + auth,
+				`if [ "$KEEP_DAYS" -gt 0 ]; then`,
+				fmt.Sprintf(`  find "$JOB_DIR" -maxdepth 1 -type f -name '%s-*' -mtime "+$KEEP_DAYS" -delete`, collection),
+				`fi`,
+			},
+		}, nil
+	case "incremental":
+		return jobSpec{
+			Name:    "export-" + collection,
+			Summary: "export changes to collection " + collection + " since the last run",
+			Lines: []string{
+				`stamp="$(date -u +%Y%m%dT%H%M%SZ)"`,
+				`mkdir -p "$JOB_DIR"`,
+				fmt.Sprintf(`"$TDB_BIN" tenant documents export %s --stream \`, collection),
+				fmt.Sprintf(`  --cursor-file "$JOB_DIR/%s.cursor" --out "$JOB_DIR/%s-$stamp.jsonl" \`, collection, collection),
+				"  " + auth,
+			},
+			State: []string{collection + ".cursor"},
+		}, nil
+	case "verify":
+		return jobSpec{
+			Name:    "verify-" + collection,
+			Summary: "verify collection " + collection + " against its checksum manifest",
+			Lines: []string{
+				`mkdir -p "$JOB_DIR"`,
+				fmt.Sprintf(`manifest="$JOB_DIR/%s.checksums.json"`, collection),
+				`if [ ! -f "$manifest" ]; then`,
+				`  # First run: record the current state to verify against later.`,
+				`  set -- --write`,
+				`fi`,
+				fmt.Sprintf(`"$TDB_BIN" tenant documents verify %s --manifest "$manifest" "$@" \`, collection),
+				"  " + auth,
+			},
+			State: []string{collection + ".checksums.json"},
+		}, nil
+	case "audit-ship":
+		return jobSpec{
+			Name:    "audit-ship",
+			Summary: "ship new audit log entries to a JSONL file",
+			Lines: []string{
+				`mkdir -p "$JOB_DIR"`,
+				`"$TDB_BIN" tenant audit ship --sink file --out "$JOB_DIR/audit.jsonl" --state "$JOB_DIR/audit-state.json" \`,
+				"  " + auth,
+			},
+			State: []string{"audit-state.json"},
+		}, nil
+	case "":
+		return jobSpec{}, errors.New("--type is required")
+	}
+	return jobSpec{}, fmt.Errorf("unsupported job type %q (choose %s)", kind, strings.Join(jobTypes, ", "))
+}
+
+func jobDir(flag, fallback string) string {
+	if trimmed := strings.TrimSpace(flag); trimmed != "" {
+		return trimmed
+	}
+	return fallback
+}
+
+// renderJobShell renders the job as a bash script. installedAt is where the
+// systemd unit expects the script; empty for a cron script.
+func renderJobShell(spec jobSpec, cron, dir, installedAt string) string {
+	var b strings.Builder
+	b.WriteString("#!/usr/bin/env bash\n")
+	fmt.Fprintf(&b, "# %s: %s\n", spec.Name, spec.Summary)
+	fmt.Fprintf(&b, "# Generated by %s ('tdb generate job'); edit freely.\n#\n", versionpkg.Display())
+	if installedAt == "" {
+		b.WriteString("# Schedule with crontab -e:\n")
+		fmt.Fprintf(&b, "#   %s /path/to/%s.sh >> /var/log/%s.log 2>&1\n#\n", cron, spec.Name, spec.Name)
+	} else {
+		fmt.Fprintf(&b, "# Run by %s.service; install as %s.\n#\n", spec.Name, installedAt)
+	}
+	b.WriteString("# Credentials come from the environment:\n")
+	b.WriteString("#   TDB_TENANT    tenant ID\n")
+	b.WriteString("#   TDB_API_KEY   API key (a read-only key is enough)\n")
+	b.WriteString("#   TDB_ENDPOINT  server URL (optional; defaults to the CLI config)\n")
+	b.WriteString("set -euo pipefail\n\n")
+	b.WriteString(": \"${TDB_TENANT:?set TDB_TENANT to the tenant ID}\"\n")
+	b.WriteString(": \"${TDB_API_KEY:?set TDB_API_KEY to an API key}\"\n")
+	b.WriteString("TDB_BIN=\"${TDB_BIN:-tdb}\"\n")
+	fmt.Fprintf(&b, "JOB_DIR=\"${JOB_DIR:-%s}\"\n", dir)
+	for _, v := range spec.Vars {
+		fmt.Fprintf(&b, "%s=\"${%s:-%s}\"\n", v[0], v[0], v[1])
+	}
+	b.WriteString("\n")
+	for _, line := range spec.Lines {
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// renderJobSystemd renders the service and timer units. Credentials are read
+// from /etc/tdb/<name>.env, which should be readable by root only.
+func renderJobSystemd(spec jobSpec, cron, calendar, script string) (string, string) {
+	var service strings.Builder
+	fmt.Fprintf(&service, "# Generated by %s ('tdb generate job').\n", versionpkg.Display())
+	fmt.Fprintf(&service, "# Put TDB_TENANT, TDB_API_KEY and optionally TDB_ENDPOINT in /etc/tdb/%s.env (chmod 600).\n", spec.Name)
+	service.WriteString("[Unit]\n")
+	fmt.Fprintf(&service, "Description=tdb: %s\n", spec.Summary)
+	service.WriteString("Wants=network-online.target\nAfter=network-online.target\n\n")
+	service.WriteString("[Service]\nType=oneshot\n")
+	fmt.Fprintf(&service, "EnvironmentFile=/etc/tdb/%s.env\n", spec.Name)
+	fmt.Fprintf(&service, "StateDirectory=tdb/%s\n", spec.Name)
+	fmt.Fprintf(&service, "ExecStart=%s\n", script)
+
+	var timer strings.Builder
+	fmt.Fprintf(&timer, "# Generated by %s ('tdb generate job') from the cron schedule %q.\n", versionpkg.Display(), cron)
+	timer.WriteString("[Unit]\n")
+	fmt.Fprintf(&timer, "Description=Schedule for %s\n\n", spec.Name)
+	fmt.Fprintf(&timer, "[Timer]\nOnCalendar=%s\nPersistent=true\n\n", calendar)
+	timer.WriteString("[Install]\nWantedBy=timers.target\n")
+	return service.String(), timer.String()
+}
+
+// renderJobGitHub renders a workflow that installs the CLI and runs the job,
+// reading credentials from repository secrets and keeping the job directory
+// as an artifact. State files are carried between runs in the Actions cache;
+// each run saves under a new key and restores the newest one by prefix.
+func renderJobGitHub(spec jobSpec, cron, dir string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Generated by %s ('tdb generate job').\n", versionpkg.Display())
+	b.WriteString("# Add TDB_TENANT, TDB_API_KEY and TDB_ENDPOINT as repository secrets.\n")
+	fmt.Fprintf(&b, "name: %s\n\n", spec.Name)
+	fmt.Fprintf(&b, "on:\n  schedule:\n    - cron: %q\n  workflow_dispatch: {}\n\n", cron)
+	fmt.Fprintf(&b, "jobs:\n  %s:\n    runs-on: ubuntu-latest\n", spec.Name)
+	b.WriteString("    env:\n")
+	for _, name := range []string{"TDB_TENANT", "TDB_API_KEY", "TDB_ENDPOINT"} {
+		fmt.Fprintf(&b, "      %s: ${{ secrets.%s }}\n", name, name)
+	}
+	fmt.Fprintf(&b, "      JOB_DIR: %s\n", dir)
+	for _, v := range spec.Vars {
+		fmt.Fprintf(&b, "      %s: %q\n", v[0], v[1])
+	}
+	b.WriteString("    steps:\n")
+	b.WriteString("      - name: Install tdb\n        run: |\n")
+	b.WriteString("          curl -fsSL https://raw.githubusercontent.com/cubetiqlabs/tdb-cli/main/scripts/install.sh | TDB_INSTALL_DIR=\"$HOME/.local/bin\" bash\n")
+	b.WriteString("          echo \"$HOME/.local/bin\" >> \"$GITHUB_PATH\"\n")
+	if len(spec.State) > 0 {
+		b.WriteString("      - name: Restore job state\n        uses: actions/cache/restore@v4\n        with:\n")
+		writeJobGitHubCachePaths(&b, spec.State)
+		fmt.Fprintf(&b, "          key: %s-state-${{ github.run_id }}\n", spec.Name)
+		fmt.Fprintf(&b, "          restore-keys: %s-state-\n", spec.Name)
+	}
+	fmt.Fprintf(&b, "      - name: Run %s\n        shell: bash\n        run: |\n", spec.Name)
+	b.WriteString("          set -euo pipefail\n          TDB_BIN=tdb\n")
+	for _, line := range spec.Lines {
+		b.WriteString("          " + line + "\n")
+	}
+	if len(spec.State) > 0 {
+		b.WriteString("      - name: Save job state\n        uses: actions/cache/save@v4\n        with:\n")
+		writeJobGitHubCachePaths(&b, spec.State)
+		fmt.Fprintf(&b, "          key: %s-state-${{ github.run_id }}\n", spec.Name)
+	}
+	b.WriteString("      - uses: actions/upload-artifact@v4\n        with:\n")
+	fmt.Fprintf(&b, "          name: %s\n          path: ${{ env.JOB_DIR }}\n", spec.Name)
+	return b.String()
+}
+
+func writeJobGitHubCachePaths(b *strings.Builder, state []string) {
+	b.WriteString("          path: |\n")
+	for _, name := range state {
+		fmt.Fprintf(b, "            ${{ env.JOB_DIR }}/%s\n", name)
+	}
+}
+
+// writeJobFiles prints the files, or writes them to out: a file for a single
+// output, a directory for systemd units. Scripts are made executable.
+func writeJobFiles(cmd *cobra.Command, out string, directory bool, order []string, files map[string]string) error {
+	if out == "" {
+		for i, name := range order {
+			if len(order) > 1 {
+				if i > 0 {
+					fmt.Fprintln(cmd.OutOrStdout())
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "# ==> %s <==\n", name)
+			}
+			fmt.Fprint(cmd.OutOrStdout(), files[name])
+		}
+		return nil
+	}
+	targets := map[string]string{order[0]: out}
+	if directory {
+		for _, name := range order {
+			targets[name] = filepath.Join(out, name)
+		}
+	}
+	for _, name := range order {
+		path, ok := targets[name]
+		if !ok {
+			continue
+		}
+		if dir := filepath.Dir(path); dir != "." {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return err
+			}
+		}
+		mode := os.FileMode(0o644)
+		if strings.HasSuffix(name, ".sh") {
+			mode = 0o755
+		}
+		if err := os.WriteFile(path, []byte(files[name]), mode); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Wrote %s\n", path)
+	}
+	return nil
+}
+
+// normalizeCronSchedule checks a five-field cron expression, expanding
+// @-shortcuts.
+func normalizeCronSchedule(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	if expanded, ok := cronShortcuts[strings.ToLower(trimmed)]; ok {
+		return expanded, nil
+	}
+	fields := strings.Fields(trimmed)
+	if len(fields) != 5 {
+		return "", fmt.Errorf("invalid --schedule %q: expected five cron fields (minute hour day month weekday) or @hourly, @daily, @weekly, @monthly", raw)
+	}
+	for _, field := range fields {
+		if strings.Trim(field, "0123456789*,/-") != "" {
+			return "", fmt.Errorf("invalid --schedule %q: field %q may only use digits, '*', ',', '-' and '/'", raw, field)
+		}
+	}
+	return strings.Join(fields, " "), nil
+}
+
+// cronToOnCalendar converts a normalized cron expression into a systemd
+// OnCalendar value, e.g. "30 2 * * 1-5" becomes "Mon..Fri *-*-* 02:30:00".
+func cronToOnCalendar(cron string) (string, error) {
+	fields := strings.Fields(cron)
+	if len(fields) != 5 {
+		return "", fmt.Errorf("invalid cron schedule %q", cron)
+	}
+	minute, hour, dom, month, dow := fields[0], fields[1], fields[2], fields[3], fields[4]
+	if dom != "*" && dow != "*" {
+		return "", fmt.Errorf("cron schedule %q restricts both day of month and weekday, which systemd timers cannot express; use --format shell with cron", cron)
+	}
+	calendar := fmt.Sprintf("*-%s-%s %s:%s:00", calendarField(month, 1), calendarField(dom, 1), calendarField(hour, 0), calendarField(minute, 0))
+	if dow == "*" {
+		return calendar, nil
+	}
+	days, err := calendarWeekdays(dow)
+	if err != nil {
+		return "", fmt.Errorf("cron schedule %q: %w", cron, err)
+	}
+	return days + " " + calendar, nil
+}
+
+// calendarField rewrites one cron field for OnCalendar: ranges use "..",
+// steps start at the field's first value, and single numbers are padded.
+func calendarField(field string, first int) string {
+	if field == "*" {
+		return "*"
+	}
+	parts := strings.Split(field, ",")
+	for i, part := range parts {
+		if rest, ok := strings.CutPrefix(part, "*/"); ok {
+			parts[i] = fmt.Sprintf("%02d/%s", first, rest)
+			continue
+		}
+		if n, err := strconv.Atoi(part); err == nil {
+			parts[i] = fmt.Sprintf("%02d", n)
+			continue
+		}
+		parts[i] = strings.Replace(part, "-", "..", 1)
+	}
+	return strings.Join(parts, ",")
+}
+
+func calendarWeekdays(field string) (string, error) {
+	names := []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+	day := func(raw string) (string, error) {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 || n > 7 {
+			return "", fmt.Errorf("unsupported weekday %q", raw)
+		}
+		return names[n], nil
+	}
+	parts := strings.Split(field, ",")
+	for i, part := range parts {
+		if strings.Contains(part, "/") {
+			return "", fmt.Errorf("weekday steps like %q are not supported", part)
+		}
+		from, to, isRange := strings.Cut(part, "-")
+		start, err := day(from)
+		if err != nil {
+			return "", err
+		}
+		if !isRange {
+			parts[i] = start
+			continue
+		}
+		end, err := day(to)
+		if err != nil {
+			return "", err
+		}
+		parts[i] = start + ".." + end
+	}
+	return strings.Join(parts, ","), nil
+}
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runGenerateJob(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := newGenerateJobCommand()
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	cmd.SetArgs(args)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestGenerateJobShellScripts(t *testing.T) {
+	bash, _ := exec.LookPath("bash")
+	for _, kind := range jobTypes {
+		args := []string{"--type", kind, "--schedule", "15 3 * * *"}
+		if kind != "audit-ship" {
+			args = append(args, "--collection", "users")
+		}
+		out, err := runGenerateJob(t, args...)
+		if err != nil {
+			t.Fatalf("%s: %v", kind, err)
+		}
+		for _, want := range []string{"#!/usr/bin/env bash", "#   15 3 * * * /path/to/", `--api-key "$TDB_API_KEY"`, "--read-only"} {
+			if !strings.Contains(out, want) {
+				t.Fatalf("%s: expected %q in script:\n%s", kind, want, out)
+			}
+		}
+		if strings.Contains(out, "tn_") {
+			t.Fatalf("%s: script must not embed credentials:\n%s", kind, out)
+		}
+		if bash != "" {
+			check := exec.Command(bash, "-n")
+			check.Stdin = strings.NewReader(out)
+			if msg, err := check.CombinedOutput(); err != nil {
+				t.Fatalf("%s: script does not parse: %v\n%s\n%s", kind, err, msg, out)
+			}
+		}
+	}
+
+	outPath := filepath.Join(t.TempDir(), "backup-users.sh")
+	if _, err := runGenerateJob(t, "--type", "backup", "--collection", "users", "--keep-days", "7", "--out", outPath); err != nil {
+		t.Fatalf("write script: %v", err)
+	}
+	info, err := os.Stat(outPath)
+	if err != nil || info.Mode().Perm()&0o100 == 0 {
+		t.Fatalf("expected an executable script, got %v %v", info, err)
+	}
+	content, _ := os.ReadFile(outPath)
+	if !strings.Contains(string(content), `KEEP_DAYS="${KEEP_DAYS:-7}"`) || !strings.Contains(string(content), "--manifest") {
+		t.Fatalf("unexpected backup script:\n%s", content)
+	}
+}
+
+func TestGenerateJobSystemdAndGitHub(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := runGenerateJob(t, "--type", "backup", "--collection", "users", "--schedule", "30 2 * * 1-5", "--format", "systemd", "--out", dir); err != nil {
+		t.Fatalf("systemd: %v", err)
+	}
+	timer, err := os.ReadFile(filepath.Join(dir, "backup-users.timer"))
+	if err != nil || !strings.Contains(string(timer), "OnCalendar=Mon..Fri *-*-* 02:30:00") {
+		t.Fatalf("unexpected timer (%v):\n%s", err, timer)
+	}
+	service, err := os.ReadFile(filepath.Join(dir, "backup-users.service"))
+	if err != nil || !strings.Contains(string(service), "ExecStart=/usr/local/lib/tdb/backup-users.sh") || !strings.Contains(string(service), "EnvironmentFile=/etc/tdb/backup-users.env") {
+		t.Fatalf("unexpected service (%v):\n%s", err, service)
+	}
+	if script, err := os.ReadFile(filepath.Join(dir, "backup-users.sh")); err != nil || !strings.Contains(string(script), "/var/lib/tdb/backup-users") {
+		t.Fatalf("unexpected script (%v):\n%s", err, script)
+	}
+
+	out, err := runGenerateJob(t, "--type", "incremental", "--collection", "events", "--schedule", "@hourly", "--format", "github")
+	if err != nil {
+		t.Fatalf("github: %v", err)
+	}
+	for _, want := range []string{
+		`- cron: "0 * * * *"`,
+		"TDB_API_KEY: ${{ secrets.TDB_API_KEY }}",
+		`--cursor-file "$JOB_DIR/events.cursor"`,
+		"uses: actions/cache/restore@v4",
+		"${{ env.JOB_DIR }}/events.cursor",
+		"restore-keys: export-events-state-",
+		"uses: actions/cache/save@v4",
+		"actions/upload-artifact",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in workflow:\n%s", want, out)
+		}
+	}
+	if restore, save := strings.Index(out, "cache/restore"), strings.Index(out, "cache/save"); restore > strings.Index(out, "--cursor-file") || save < strings.Index(out, "--cursor-file") {
+		t.Fatalf("expected state to be restored before and saved after the run:\n%s", out)
+	}
+	out, err = runGenerateJob(t, "--type", "backup", "--collection", "users", "--format", "github")
+	if err != nil || strings.Contains(out, "actions/cache") {
+		t.Fatalf("expected a stateless backup workflow without a cache step (%v):\n%s", err, out)
+	}
+
+	if _, err := runGenerateJob(t, "--type", "backup"); err == nil || !strings.Contains(err.Error(), "--collection is required") {
+		t.Fatalf("expected missing collection error, got %v", err)
+	}
+	if _, err := runGenerateJob(t, "--type", "backup", "--collection", "users; rm -rf /"); err == nil {
+		t.Fatal("expected unsafe collection name to be rejected")
+	}
+}
+
+func TestCronToOnCalendar(t *testing.T) {
+	cases := map[string]string{
+		"0 2 * * *":     "*-*-* 02:00:00",
+		"*/15 * * * *":  "*-*-* *:00/15:00",
+		"0 6,18 * * 0":  "Sun *-*-* 06,18:00:00",
+		"5 4 * 1-3 *":   "*-1..3-* 04:05:00",
+		"0 9 * * 1,3,5": "Mon,Wed,Fri *-*-* 09:00:00",
+		"0 22 * * 5-0":  "Fri..Sun *-*-* 22:00:00",
+		"0 0 * */2 *":   "*-01/2-* 00:00:00",
+		"30 1 15 6 *":   "*-06-15 01:30:00",
+		"0 12 * * 7":    "Sun *-*-* 12:00:00",
+		"10 8-17 * * *": "*-*-* 8..17:10:00",
+	}
+	for cron, want := range cases {
+		got, err := cronToOnCalendar(cron)
+		if err != nil || got != want {
+			t.Errorf("cronToOnCalendar(%q) = %q, %v; want %q", cron, got, err, want)
+		}
+	}
+	for _, cron := range []string{"0 0 1 * 1", "0 0 * * */2", "0 0 * * 9"} {
+		if _, err := cronToOnCalendar(cron); err == nil {
+			t.Errorf("expected %q to be rejected", cron)
+		}
+	}
+	if _, err := normalizeCronSchedule("every day"); err == nil {
+		t.Error("expected an invalid schedule to be rejected")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,6 +11,7 @@ import (
 	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 
+	canonicalpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/canonical"
 	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
 	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
 )
@@ -33,7 +33,7 @@ func ensureEndpoint(env *Environment) (string, error) {
 	}
 	endpoint := strings.TrimSpace(env.Config.Endpoint)
 	if endpoint == "" {
-		return "", errors.New("endpoint not configured; run `tdb config set endpoint <url>`")
+		return "", errors.New(tr("endpoint not configured; run `tdb config set endpoint <url>`"))
 	}
 	return endpoint, nil
 }
@@ -48,12 +48,23 @@ func resolveTenantID(env *Environment, tenantID string) (string, error) {
 		resolved = strings.TrimSpace(envCtx.Config.DefaultTenant)
 	}
 	if resolved == "" {
-		return "", errors.New("--tenant is required (set a default via `tdb config set default-tenant <tenant_id>`)")
+		return "", errors.New(tr("--tenant is required (set a default via `tdb config set default-tenant <tenant_id>`)"))
 	}
 	return resolved, nil
 }
 
 func adminClientFromEnv(env *Environment) (*clientpkg.AdminClient, error) {
+	return newAdminClientFromEnv(env, clientOptions())
+}
+
+// adminClientForTenant returns an admin client for a command acting on one
+// tenant. Like the tenant's own clients, it refuses mutating requests when the
+// tenant is read_only in the config.
+func adminClientForTenant(env *Environment, tenantID string) (*clientpkg.AdminClient, error) {
+	return newAdminClientFromEnv(env, tenantClientOptions(env, tenantID))
+}
+
+func newAdminClientFromEnv(env *Environment, opts []clientpkg.Option) (*clientpkg.AdminClient, error) {
 	endpoint, err := ensureEndpoint(env)
 	if err != nil {
 		return nil, err
@@ -62,7 +73,42 @@ func adminClientFromEnv(env *Environment) (*clientpkg.AdminClient, error) {
 	if secret == "" {
 		return nil, errors.New("admin secret not configured; run `tdb config set admin-secret <secret>`")
 	}
-	return clientpkg.NewAdminClient(endpoint, secret)
+	return clientpkg.NewAdminClient(endpoint, secret, opts...)
+}
+
+// tenantForKeyPrefix returns the tenant whose stored key has prefix, or ""
+// when no stored key matches.
+func tenantForKeyPrefix(env *Environment, prefix string) string {
+	prefix = strings.TrimSpace(prefix)
+	if env == nil || env.Config == nil || prefix == "" {
+		return ""
+	}
+	for tenantID, tenant := range env.Config.Tenants {
+		for _, entry := range tenant.Keys {
+			if strings.EqualFold(entry.Prefix, prefix) {
+				return tenantID
+			}
+		}
+	}
+	return ""
+}
+
+// tenantClientOptions adds the tenant's read_only setting to clientOptions.
+func tenantClientOptions(env *Environment, tenantID string) []clientpkg.Option {
+	opts := clientOptions()
+	if !activeReadOnly && tenantReadOnly(env, tenantID) {
+		opts = append(opts, clientpkg.WithReadOnly())
+	}
+	return opts
+}
+
+// tenantReadOnly reports whether writes are refused for tenantID, by
+// --read-only, the top-level read_only setting or the tenant's own.
+func tenantReadOnly(env *Environment, tenantID string) bool {
+	if activeReadOnly {
+		return true
+	}
+	return env != nil && env.Config != nil && env.Config.Tenants[strings.TrimSpace(tenantID)].ReadOnly
 }
 
 func tenantClientFromEnv(env *Environment, tenantID, keyName, apiKeyOverride string) (*clientpkg.TenantClient, configpkg.APIKeyEntry, error) {
@@ -92,7 +138,7 @@ func tenantClientFromEnv(env *Environment, tenantID, keyName, apiKeyOverride str
 	if strings.TrimSpace(entry.Key) == "" {
 		return nil, configpkg.APIKeyEntry{}, errors.New("api key is empty")
 	}
-	tenantClient, err := clientpkg.NewTenantClient(endpoint, entry.Key)
+	tenantClient, err := clientpkg.NewTenantClient(endpoint, entry.Key, tenantClientOptions(env, tenantID)...)
 	if err != nil {
 		return nil, configpkg.APIKeyEntry{}, err
 	}
@@ -217,13 +263,27 @@ func setDefaultTenant(env *Environment, tenantID string) error {
 }
 
 func printJSON(cmd *cobra.Command, value interface{}) error {
+	if sortKeysFor(cmd) {
+		data, err := canonicalpkg.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return err
+	}
 	enc := json.NewEncoder(cmd.OutOrStdout())
 	enc.SetIndent("", "  ")
 	return enc.Encode(value)
 }
 
 func printCompactJSON(cmd *cobra.Command, value interface{}) error {
-	data, err := json.Marshal(value)
+	var data []byte
+	var err error
+	if sortKeysFor(cmd) {
+		data, err = canonicalpkg.Marshal(value)
+	} else {
+		data, err = json.Marshal(value)
+	}
 	if err != nil {
 		return err
 	}
@@ -267,41 +327,44 @@ func makeAuditLogsPretty(items []clientpkg.AuditLog) []map[string]any {
 	return result
 }
 
-func makeDocumentPretty(doc clientpkg.Document) map[string]any {
-	row := map[string]any{
-		"id":              doc.ID,
-		"tenant_id":       doc.TenantID,
-		"collection_id":   doc.CollectionID,
-		"key":             doc.Key,
-		"key_numeric":     doc.KeyNumeric,
-		"version":         doc.Version,
-		"created_at":      doc.CreatedAt,
-		"updated_at":      doc.UpdatedAt,
-		"deleted_at":      doc.DeletedAt,
-		"data":            coerceJSONValue(doc.Data),
-		"data_size":       doc.DataSize,
-		"data_size_human": formatBytes(doc.DataSize),
-	}
-	return row
+// makeDocumentPretty lays out a document for --raw-pretty output in a fixed
+// order: id, key, key_numeric, collection_id, tenant_id, version, created_at,
+// updated_at, deleted_at, data_size, data_size_human, data. Keep
+// docs/COMMAND_REFERENCE.md in sync when changing it.
+func makeDocumentPretty(doc clientpkg.Document) orderedObject {
+	return orderedObject{
+		{"id", doc.ID},
+		{"key", doc.Key},
+		{"key_numeric", doc.KeyNumeric},
+		{"collection_id", doc.CollectionID},
+		{"tenant_id", doc.TenantID},
+		{"version", doc.Version},
+		{"created_at", doc.CreatedAt},
+		{"updated_at", doc.UpdatedAt},
+		{"deleted_at", doc.DeletedAt},
+		{"data_size", doc.DataSize},
+		{"data_size_human", formatBytes(doc.DataSize)},
+		{"data", coerceJSONValue(doc.Data)},
+	}
 }
 
-func makeDocumentListPretty(resp *clientpkg.DocumentListResponse) map[string]any {
-	items := make([]map[string]any, 0, len(resp.Items))
+func makeDocumentListPretty(resp *clientpkg.DocumentListResponse) orderedObject {
+	items := make([]orderedObject, 0, len(resp.Items))
 	for _, item := range resp.Items {
 		items = append(items, makeDocumentPretty(item))
 	}
-	return map[string]any{
-		"items":      items,
-		"pagination": resp.Pagination,
+	return orderedObject{
+		{"items", items},
+		{"pagination", resp.Pagination},
 	}
 }
 
-func makeDocumentBulkPretty(resp *clientpkg.DocumentBulkResponse) map[string]any {
-	items := make([]map[string]any, 0, len(resp.Items))
+func makeDocumentBulkPretty(resp *clientpkg.DocumentBulkResponse) orderedObject {
+	items := make([]orderedObject, 0, len(resp.Items))
 	for _, item := range resp.Items {
 		items = append(items, makeDocumentPretty(item))
 	}
-	return map[string]any{"items": items}
+	return orderedObject{{"items", items}}
 }
 
 func readFileContent(path string) (string, error) {
@@ -328,10 +391,10 @@ func readJSONPayload(cmd *cobra.Command, inline, filePath string, useStdin, expe
 		sources++
 	}
 	if sources == 0 {
-		return nil, errors.New("provide --data, --file, or --stdin")
+		return nil, errors.New(tr("provide --data, --file, or --stdin"))
 	}
 	if sources > 1 {
-		return nil, errors.New("use only one of --data, --file, or --stdin")
+		return nil, errors.New(tr("use only one of --data, --file, or --stdin"))
 	}
 
 	var payload []byte
@@ -339,13 +402,19 @@ func readJSONPayload(cmd *cobra.Command, inline, filePath string, useStdin, expe
 	case strings.TrimSpace(inline) != "":
 		payload = []byte(inline)
 	case strings.TrimSpace(filePath) != "":
-		content, err := os.ReadFile(filepath.Clean(filePath))
+		clean := filepath.Clean(filePath)
+		file, err := os.Open(clean)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		content, err := readPayloadSource(clean, file)
 		if err != nil {
 			return nil, err
 		}
 		payload = content
 	case useStdin:
-		data, err := io.ReadAll(cmd.InOrStdin())
+		data, err := readPayloadSource("", cmd.InOrStdin())
 		if err != nil {
 			return nil, err
 		}
@@ -354,13 +423,13 @@ func readJSONPayload(cmd *cobra.Command, inline, filePath string, useStdin, expe
 
 	trimmed := strings.TrimSpace(string(payload))
 	if trimmed == "" {
-		return nil, errors.New("payload cannot be empty")
+		return nil, errors.New(tr("payload cannot be empty"))
 	}
 	if expectArray && !strings.HasPrefix(trimmed, "[") {
-		return nil, errors.New("expected JSON array payload")
+		return nil, errors.New(tr("expected JSON array payload"))
 	}
 	if !json.Valid([]byte(trimmed)) {
-		return nil, errors.New("invalid JSON payload")
+		return nil, errors.New(tr("invalid JSON payload"))
 	}
 	return []byte(trimmed), nil
 }
@@ -400,3 +469,12 @@ func formatBytes(value int64) string {
 	}
 	return humanize.Bytes(uint64(value))
 }
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			return trimmed
+		}
+	}
+	return ""
+}
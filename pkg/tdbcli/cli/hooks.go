@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// hookEvent is the JSON document written to a hook's stdin.
+type hookEvent struct {
+	Hook    string            `json:"hook"`
+	Command string            `json:"command"`
+	Args    []string          `json:"args"`
+	Flags   map[string]string `json:"flags"`
+	Tenant  string            `json:"tenant,omitempty"`
+	Status  string            `json:"status,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// commandHooks carries the configured hooks from the root PersistentPreRunE to
+// Execute, which runs the post hook once the command has finished.
+type commandHooks struct {
+	hooks map[string]string
+	// pending is set once the pre stage passed, so a post hook is due.
+	pending bool
+}
+
+// hookName returns the config key for a hook around cmd, such as pre-delete.
+// Commands are grouped by their verb, so pre-delete runs before every delete
+// command (documents, collections, snapshots, tenants, ...).
+func hookName(phase string, cmd *cobra.Command) string {
+	return phase + "-" + cmd.Name()
+}
+
+// runPreHook runs the configured pre-<verb> hook, returning an error that
+// aborts the command when the hook exits non-zero.
+func runPreHook(cmd *cobra.Command, args []string, hooks map[string]string) error {
+	name := hookName("pre", cmd)
+	script := strings.TrimSpace(hooks[name])
+	if script == "" {
+		return nil
+	}
+	event := newHookEvent(name, cmd, args)
+	if err := runHook(cmd, script, event); err != nil {
+		return fmt.Errorf("%s hook aborted the command: %w", name, err)
+	}
+	return nil
+}
+
+// runPostHook runs the configured post-<verb> hook after the command finished,
+// whether it succeeded or not. A failing post hook only prints a warning.
+func runPostHook(cmd *cobra.Command, args []string, hooks map[string]string, cmdErr error) {
+	name := hookName("post", cmd)
+	script := strings.TrimSpace(hooks[name])
+	if script == "" {
+		return
+	}
+	event := newHookEvent(name, cmd, args)
+	event.Status = "success"
+	if cmdErr != nil {
+		event.Status = "failure"
+		event.Error = cmdErr.Error()
+	}
+	if err := runHook(cmd, script, event); err != nil {
+		logWarn(cmd.ErrOrStderr(), fmt.Sprintf("%s hook failed: %v", name, err))
+	}
+}
+
+func newHookEvent(name string, cmd *cobra.Command, args []string) hookEvent {
+	event := hookEvent{
+		Hook:    name,
+		Command: cmd.CommandPath(),
+		Args:    append([]string{}, args...),
+		Flags:   map[string]string{},
+	}
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		value := flag.Value.String()
+		if isSecretFlag(flag.Name) {
+			value = "***"
+		}
+		event.Flags[flag.Name] = value
+	})
+	if tenant, ok := event.Flags["tenant"]; ok {
+		event.Tenant = tenant
+	}
+	return event
+}
+
+// isSecretFlag reports whether a flag value must not be passed to hooks.
+func isSecretFlag(name string) bool {
+	switch name {
+	case "api-key", "admin-secret", "header":
+		return true
+	}
+	return strings.Contains(name, "secret") || strings.Contains(name, "password") || strings.Contains(name, "token")
+}
+
+// runHook executes script through the platform shell with the event as JSON
+// on stdin and as TDB_HOOK_* environment variables. The hook's output goes to
+// stderr so it never mixes with the command's own output.
+func runHook(cmd *cobra.Command, script string, event hookEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	} else if stopCause(ctx) != nil {
+		// Let the post hook still see a run stopped by Ctrl-C or --timeout.
+		ctx = context.WithoutCancel(ctx)
+	}
+	var proc *exec.Cmd
+	if runtime.GOOS == "windows" {
+		proc = exec.CommandContext(ctx, "cmd", "/C", script)
+	} else {
+		proc = exec.CommandContext(ctx, "sh", "-c", script)
+	}
+	proc.Stdin = bytes.NewReader(payload)
+	proc.Stdout = cmd.ErrOrStderr()
+	proc.Stderr = cmd.ErrOrStderr()
+	proc.Env = append(os.Environ(), hookEnv(event)...)
+	return proc.Run()
+}
+
+func hookEnv(event hookEvent) []string {
+	env := []string{
+		"TDB_HOOK=" + event.Hook,
+		"TDB_HOOK_COMMAND=" + event.Command,
+		"TDB_HOOK_ARGS=" + strings.Join(event.Args, " "),
+		"TDB_HOOK_TENANT=" + event.Tenant,
+	}
+	if event.Status != "" {
+		env = append(env, "TDB_HOOK_STATUS="+event.Status, "TDB_HOOK_ERROR="+event.Error)
+	}
+	names := make([]string, 0, len(event.Flags))
+	for name := range event.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		key := "TDB_HOOK_FLAG_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		env = append(env, key+"="+event.Flags[name])
+	}
+	return env
+}
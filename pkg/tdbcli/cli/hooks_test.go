@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestCommandHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook scripts use sh")
+	}
+	dir := t.TempDir()
+	eventFile := filepath.Join(dir, "event.json")
+	envFile := filepath.Join(dir, "env.txt")
+
+	newDelete := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "delete <name>", RunE: func(*cobra.Command, []string) error { return nil }}
+		cmd.Flags().String("api-key", "", "")
+		cmd.Flags().String("tenant", "", "")
+		cmd.SetErr(&bytes.Buffer{})
+		if err := cmd.ParseFlags([]string{"--api-key", "tdb_secret", "--tenant", "tn_1"}); err != nil {
+			t.Fatalf("parse flags: %v", err)
+		}
+		return cmd
+	}
+
+	hooks := map[string]string{
+		"pre-delete":  "cat > " + eventFile + "; [ \"$TDB_HOOK_ARGS\" != protected ]",
+		"post-delete": "echo \"$TDB_HOOK $TDB_HOOK_STATUS $TDB_HOOK_TENANT $TDB_HOOK_FLAG_API_KEY\" > " + envFile,
+	}
+
+	if err := runPreHook(newDelete(), []string{"logs"}, hooks); err != nil {
+		t.Fatalf("pre hook failed: %v", err)
+	}
+	raw, err := os.ReadFile(eventFile)
+	if err != nil {
+		t.Fatalf("read event: %v", err)
+	}
+	var event hookEvent
+	if err := json.Unmarshal(raw, &event); err != nil {
+		t.Fatalf("decode event %s: %v", raw, err)
+	}
+	if event.Hook != "pre-delete" || event.Tenant != "tn_1" || len(event.Args) != 1 || event.Args[0] != "logs" || event.Flags["api-key"] != "***" {
+		t.Fatalf("unexpected event %+v", event)
+	}
+
+	if err := runPreHook(newDelete(), []string{"protected"}, hooks); err == nil || !strings.Contains(err.Error(), "pre-delete hook aborted") {
+		t.Fatalf("expected a non-zero pre hook to abort, got %v", err)
+	}
+
+	runPostHook(newDelete(), []string{"logs"}, hooks, errors.New("boom"))
+	raw, err = os.ReadFile(envFile)
+	if err != nil {
+		t.Fatalf("read env: %v", err)
+	}
+	if got := strings.TrimSpace(string(raw)); got != "post-delete failure tn_1 ***" {
+		t.Fatalf("unexpected post hook environment %q", got)
+	}
+
+	if err := runPreHook(&cobra.Command{Use: "list"}, nil, hooks); err != nil {
+		t.Fatalf("expected commands without hooks to run untouched, got %v", err)
+	}
+}
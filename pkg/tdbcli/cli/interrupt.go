@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	errInterrupted = errors.New("interrupted")
+	errTimedOut    = errors.New("timed out")
+)
+
+// notifyInterrupt returns a context that is cancelled with errInterrupted on
+// the first SIGINT or SIGTERM. Later signals get the default behaviour again,
+// so a second Ctrl-C still kills a command that does not stop on its own.
+func notifyInterrupt(parent context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(parent)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-signals:
+			signal.Stop(signals)
+			cancel(errInterrupted)
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, func() {
+		signal.Stop(signals)
+		cancel(nil)
+	}
+}
+
+// stopCause reports whether ctx was cancelled by Ctrl-C or --timeout, returning
+// errInterrupted or errTimedOut, or nil for any other reason.
+func stopCause(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	cause := context.Cause(ctx)
+	if errors.Is(cause, errInterrupted) || errors.Is(cause, errTimedOut) {
+		return cause
+	}
+	return nil
+}
+
+// isStopped reports whether err is the fallout of a Ctrl-C or --timeout
+// cancellation rather than a genuine failure.
+func isStopped(ctx context.Context, err error) bool {
+	cause := stopCause(ctx)
+	if err == nil || cause == nil {
+		return false
+	}
+	return errors.Is(err, cause) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// reportStopped prints the partial run summary of a batch command stopped by
+// Ctrl-C or --timeout and returns the error that sets the exit status.
+func reportStopped(cmd *cobra.Command, stats *operationStats, cause error) error {
+	if stats.onStop != nil {
+		stats.Checkpoint, stats.Resume = stats.onStop()
+	}
+	out := cmd.ErrOrStderr()
+	elapsed := time.Since(stats.StartedAt).Round(100 * time.Millisecond)
+	code := ExitCodeInterrupted
+	if errors.Is(cause, errTimedOut) {
+		code = ExitCodeTimedOut
+		fmt.Fprintf(out, "\nTimed out: %s stopped after %s\n", stats.Command, elapsed)
+	} else {
+		fmt.Fprintf(out, "\nInterrupted: %s stopped after %s\n", stats.Command, elapsed)
+	}
+	names := make([]string, 0, len(stats.Counts))
+	for name := range stats.Counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	counts := make([]string, len(names))
+	for i, name := range names {
+		counts[i] = fmt.Sprintf("%s %d", name, stats.Counts[name])
+	}
+	if len(counts) > 0 {
+		fmt.Fprintf(out, "  Processed:  %s\n", strings.Join(counts, ", "))
+	}
+	if stats.Checkpoint != "" {
+		fmt.Fprintf(out, "  Checkpoint: %s\n", stats.Checkpoint)
+	}
+	if stats.Resume != "" {
+		fmt.Fprintf(out, "  Resume:     %s\n", stats.Resume)
+	}
+	return &ExitError{Code: code, Err: cause}
+}
+
+// resumeCommand rebuilds the command line of cmd with the flags the user set,
+// replacing or adding the name/value pairs in overrides. Secret flags are
+// written as environment variable references rather than their values.
+func resumeCommand(cmd *cobra.Command, args []string, overrides ...string) string {
+	parts := []string{cmd.CommandPath()}
+	for _, arg := range args {
+		parts = append(parts, shellQuoteIfNeeded(arg))
+	}
+	replaced := map[string]string{}
+	var order []string
+	for i := 0; i+1 < len(overrides); i += 2 {
+		replaced[overrides[i]] = overrides[i+1]
+		order = append(order, overrides[i])
+	}
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if _, ok := replaced[flag.Name]; ok {
+			return
+		}
+		if isSecretFlag(flag.Name) {
+			parts = append(parts, "--"+flag.Name, "$"+strings.ToUpper(strings.ReplaceAll(flag.Name, "-", "_")))
+			return
+		}
+		if flag.Value.Type() == "bool" {
+			if flag.Value.String() == "true" {
+				parts = append(parts, "--"+flag.Name)
+			} else {
+				parts = append(parts, "--"+flag.Name+"=false")
+			}
+			return
+		}
+		if values, ok := flag.Value.(pflag.SliceValue); ok {
+			for _, value := range values.GetSlice() {
+				parts = append(parts, "--"+flag.Name, shellQuoteIfNeeded(value))
+			}
+			return
+		}
+		parts = append(parts, "--"+flag.Name, shellQuoteIfNeeded(flag.Value.String()))
+	})
+	for _, name := range order {
+		parts = append(parts, "--"+name, shellQuoteIfNeeded(replaced[name]))
+	}
+	return strings.Join(parts, " ")
+}
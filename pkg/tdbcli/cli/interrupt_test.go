@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestResumeCommand(t *testing.T) {
+	cmd := &cobra.Command{Use: "sync", RunE: func(*cobra.Command, []string) error { return nil }}
+	cmd.Flags().String("api-key", "", "")
+	cmd.Flags().String("file", "", "")
+	cmd.Flags().StringArray("filter", nil, "")
+	cmd.Flags().Bool("verify", false, "")
+	cmd.Flags().Int("start-at", 0, "")
+	cmd.Flags().String("app", "", "")
+	cmd.SetArgs([]string{"my users", "--api-key", "secret", "--file", "users.jsonl", "--filter", "role=admin", "--filter", "name=Ann Lee", "--verify", "--start-at", "5"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	got := resumeCommand(cmd, []string{"my users"}, "start-at", "12")
+	want := `sync 'my users' --api-key $API_KEY --file users.jsonl --filter role=admin --filter 'name=Ann Lee' --verify --start-at 12`
+	if got != want {
+		t.Fatalf("unexpected resume command:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestDocumentsSyncInterruptedPrintsResumeCommand(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "products", PrimaryKeyField: "sku"})
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+
+	var lines []string
+	for i := 0; i < 10; i++ {
+		lines = append(lines, fmt.Sprintf(`{"sku":"sku-%d","price":%d}`, i, i))
+	}
+	path := filepath.Join(t.TempDir(), "products.json")
+	if err := os.WriteFile(path, []byte("["+strings.Join(lines, ",")+"]"), 0o644); err != nil {
+		t.Fatalf("write payload: %v", err)
+	}
+
+	// Simulate Ctrl-C while the fourth document is being created.
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+	var creates atomic.Int32
+	handler := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && creates.Add(1) == 4 {
+			cancel(errInterrupted)
+			select {
+			case <-r.Context().Done():
+			case <-time.After(200 * time.Millisecond):
+			}
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+
+	statsPath := filepath.Join(t.TempDir(), "stats.json")
+	cmd := newTenantDocumentsSyncCommand(env)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	cmd.SetArgs([]string{"products", "--tenant", "tn_test", "--api-key", clienttest.APIKey, "--file", path, "--stats-json", statsPath})
+	var errOut bytes.Buffer
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&errOut)
+	err := cmd.ExecuteContext(ctx)
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != ExitCodeInterrupted {
+		t.Fatalf("expected interrupted exit error, got %v\n%s", err, errOut.String())
+	}
+	stderr := errOut.String()
+	for _, want := range []string{
+		"created 3, updated 0, unchanged 0, skipped 0, missing 0, failed 0, pending 7",
+		"Interrupted: sync stopped after",
+		"Processed:  created 3,",
+		"Checkpoint: record [3] of 10 was not synced",
+		"Resume:     sync products --api-key $API_KEY --file " + path + " --stats-json " + statsPath + " --tenant tn_test --start-at 3",
+	} {
+		if !strings.Contains(stderr, want) {
+			t.Fatalf("expected %q in output:\n%s", want, stderr)
+		}
+	}
+	if strings.Contains(stderr, "context canceled") {
+		t.Fatalf("pending documents should not be reported as failures:\n%s", stderr)
+	}
+	raw, err := os.ReadFile(statsPath)
+	if err != nil {
+		t.Fatalf("read stats: %v", err)
+	}
+	var stats map[string]any
+	if err := json.Unmarshal(raw, &stats); err != nil {
+		t.Fatalf("decode stats: %v", err)
+	}
+	if stats["status"] != "interrupted" || !strings.Contains(fmt.Sprint(stats["resume"]), "--start-at 3") {
+		t.Fatalf("unexpected stats: %v", stats)
+	}
+
+	_, resumed, err := runCommandWithEnv(t, env, newTenantDocumentsSyncCommand, "products", "--file", path, "--start-at", "3")
+	if err != nil {
+		t.Fatalf("resume failed: %v\n%s", err, resumed)
+	}
+	if !strings.Contains(resumed, "created 7, updated 0") {
+		t.Fatalf("unexpected resume summary: %s", resumed)
+	}
+	if n := len(srv.Documents("products")); n != 10 {
+		t.Fatalf("expected 10 documents after resuming, got %d", n)
+	}
+}
+
+func TestDocumentsExportTimeout(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users"})
+	srv.AddDocument("users", map[string]any{"name": "Ann"})
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+	handler := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/documents") {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(200 * time.Millisecond):
+			}
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+
+	statsPath := filepath.Join(t.TempDir(), "stats.json")
+	_, errOut, err := runCommandWithEnv(t, env, newTenantDocumentsExportCommand, "users", "--timeout", "50ms", "--stats-json", statsPath)
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != ExitCodeTimedOut {
+		t.Fatalf("expected timeout exit error, got %v\n%s", err, errOut)
+	}
+	if !strings.Contains(errOut, "Timed out: export stopped after") || !strings.Contains(errOut, "Resume:     export users") {
+		t.Fatalf("unexpected output:\n%s", errOut)
+	}
+	raw, err := os.ReadFile(statsPath)
+	if err != nil {
+		t.Fatalf("read stats: %v", err)
+	}
+	if !strings.Contains(string(raw), `"status": "timed_out"`) {
+		t.Fatalf("unexpected stats: %s", raw)
+	}
+}
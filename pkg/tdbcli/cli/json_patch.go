@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/diff"
+)
+
+const (
+	patchTypeMerge     = "merge"
+	patchTypeJSONPatch = "json-patch"
+)
+
+// jsonPatchOperation is one RFC 6902 operation.
+type jsonPatchOperation struct {
+	Op    string           `json:"op"`
+	Path  *string          `json:"path"`
+	From  *string          `json:"from"`
+	Value *json.RawMessage `json:"value"`
+}
+
+func normalizePatchType(raw string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", patchTypeMerge, "merge-patch":
+		return patchTypeMerge, nil
+	case patchTypeJSONPatch, "jsonpatch", "rfc6902":
+		return patchTypeJSONPatch, nil
+	}
+	return "", fmt.Errorf("unsupported --patch-type %q (choose merge or json-patch)", raw)
+}
+
+// validateJSONPatch checks that payload is an RFC 6902 operation array before
+// it is sent, so malformed operations fail with the offending index.
+func validateJSONPatch(payload []byte) error {
+	var ops []json.RawMessage
+	if err := json.Unmarshal(payload, &ops); err != nil {
+		return fmt.Errorf("json-patch payload must be an array of operations: %w", err)
+	}
+	if len(ops) == 0 {
+		return fmt.Errorf("json-patch payload has no operations")
+	}
+	for i, raw := range ops {
+		var op jsonPatchOperation
+		if err := json.Unmarshal(raw, &op); err != nil {
+			return fmt.Errorf("operation %d: %w", i, err)
+		}
+		if op.Path == nil {
+			return fmt.Errorf("operation %d: missing path", i)
+		}
+		if err := validateJSONPointer(*op.Path); err != nil {
+			return fmt.Errorf("operation %d: path: %w", i, err)
+		}
+		switch op.Op {
+		case "add", "replace", "test":
+			if op.Value == nil {
+				return fmt.Errorf("operation %d (%s): missing value", i, op.Op)
+			}
+		case "remove":
+		case "move", "copy":
+			if op.From == nil {
+				return fmt.Errorf("operation %d (%s): missing from", i, op.Op)
+			}
+			if err := validateJSONPointer(*op.From); err != nil {
+				return fmt.Errorf("operation %d: from: %w", i, err)
+			}
+		default:
+			return fmt.Errorf("operation %d: unsupported op %q (use add, remove, replace, move, copy, or test)", i, op.Op)
+		}
+	}
+	return nil
+}
+
+// validateJSONPointer checks RFC 6901 syntax: "" or "/"-separated tokens in
+// which "~" is only used as "~0" or "~1".
+func validateJSONPointer(pointer string) error {
+	if pointer == "" {
+		return nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return fmt.Errorf("%q must start with /", pointer)
+	}
+	for i := 0; i < len(pointer); i++ {
+		if pointer[i] == '~' && (i+1 >= len(pointer) || (pointer[i+1] != '0' && pointer[i+1] != '1')) {
+			return fmt.Errorf("%q has an invalid ~ escape (use ~0 for ~ and ~1 for /)", pointer)
+		}
+	}
+	return nil
+}
+
+// applyMergePatch applies an RFC 7386 merge patch to target and returns the
+// result. target is not modified.
+func applyMergePatch(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	merged := map[string]any{}
+	if targetObj, ok := target.(map[string]any); ok {
+		for key, value := range targetObj {
+			merged[key] = value
+		}
+	}
+	for key, value := range patchObj {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = applyMergePatch(merged[key], value)
+	}
+	return merged
+}
+
+// printPatchDiff prints the field changes of a patch, or of a previewed one
+// with dryRun, as a colored diff or as JSON.
+func printPatchDiff(cmd *cobra.Command, id string, changes []diff.Change, asJSON, dryRun bool) error {
+	if changes == nil {
+		changes = []diff.Change{}
+	}
+	summary := diff.Summarize(changes)
+	if asJSON {
+		return printJSON(cmd, map[string]any{"id": id, "dry_run": dryRun, "summary": summary, "changes": changes})
+	}
+	verb := "Patched"
+	if dryRun {
+		verb = "Would patch"
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s document %s (%s)\n", verb, id, summary)
+	return writeDiff(cmd, changes, "  ")
+}
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestValidateJSONPatch(t *testing.T) {
+	valid := `[{"op":"test","path":"/status","value":"pending"},{"op":"move","from":"/a~1b","path":"/c"},{"op":"remove","path":"/tags/0"}]`
+	if err := validateJSONPatch([]byte(valid)); err != nil {
+		t.Fatalf("expected valid patch, got %v", err)
+	}
+	cases := map[string]string{
+		`{"op":"add"}`:                          "must be an array",
+		`[]`:                                    "no operations",
+		`[{"op":"add","path":"/x"}]`:            "operation 0 (add): missing value",
+		`[{"op":"remove","path":"x"}]`:          "must start with /",
+		`[{"op":"copy","path":"/x"}]`:           "missing from",
+		`[{"op":"merge","path":"/x"}]`:          `unsupported op "merge"`,
+		`[{"op":"remove","path":"/a~2"}]`:       "invalid ~ escape",
+		`[{"op":"replace","value":1}]`:          "missing path",
+		`[{"op":"remove","path":"/ok"}, "bad"]`: "operation 1",
+	}
+	for payload, want := range cases {
+		if err := validateJSONPatch([]byte(payload)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("%s: expected error containing %q, got %v", payload, want, err)
+		}
+	}
+}
+
+func TestDocumentsPatchJSONPatch(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "orders"})
+	doc := srv.AddDocument("orders", map[string]any{"status": "pending"})
+	var contentType, body string
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch && r.Header.Get("Content-Type") == clientpkg.JSONPatchContentType {
+			contentType = r.Header.Get("Content-Type")
+			raw, _ := io.ReadAll(r.Body)
+			body = strings.TrimSpace(string(raw))
+			_ = json.NewEncoder(w).Encode(clientpkg.Document{ID: doc.ID})
+			return
+		}
+		tenantRoutes.ServeHTTP(w, r)
+	})
+
+	run := func(args ...string) (string, error) {
+		out, _, err := runCommand(t, srv, newTenantDocumentsPatchCommand, append([]string{"orders", doc.ID}, args...)...)
+		return out, err
+	}
+
+	ops := `[{"op":"test","path":"/status","value":"pending"},{"op":"replace","path":"/status","value":"approved"}]`
+	out, err := run("--patch-type", "json-patch", "--data", ops)
+	if err != nil {
+		t.Fatalf("json-patch failed: %v", err)
+	}
+	if contentType != clientpkg.JSONPatchContentType || body != ops || !strings.Contains(out, "Patched document "+doc.ID) {
+		t.Fatalf("unexpected request %q %q / output %q", contentType, body, out)
+	}
+
+	if _, err := run("--data", ops); err == nil || !strings.Contains(err.Error(), "use --patch-type json-patch") {
+		t.Fatalf("expected merge patch array hint, got %v", err)
+	}
+	if _, err := run("--patch-type", "json-patch", "--data", `[{"op":"add","path":"/x"}]`); err == nil || !strings.Contains(err.Error(), "missing value") {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	contentType = ""
+	if _, err := run("--data", `{"status":"shipped"}`); err != nil || contentType != "" {
+		t.Fatalf("merge patch should use the default route, got %v (%q)", err, contentType)
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	target := map[string]any{"name": "Ann", "address": map[string]any{"city": "Phnom Penh", "zip": "12000"}, "tags": []any{"a"}}
+	patch := map[string]any{"address": map[string]any{"zip": nil, "street": "Norodom"}, "tags": []any{"b"}, "note": nil}
+	got := compactJSON(applyMergePatch(target, patch))
+	want := `{"address":{"city":"Phnom Penh","street":"Norodom"},"name":"Ann","tags":["b"]}`
+	if got != want {
+		t.Fatalf("unexpected merge result %s", got)
+	}
+	if _, ok := target["address"].(map[string]any)["zip"]; !ok {
+		t.Fatalf("target was modified")
+	}
+}
+
+func TestDocumentsPatchDiffAndDryRun(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "orders"})
+	doc := srv.AddDocument("orders", map[string]any{"status": "pending", "note": "call first", "total": 10})
+	run := func(args ...string) (string, error) {
+		out, _, err := runCommand(t, srv, newTenantDocumentsPatchCommand, append([]string{"orders", doc.ID}, args...)...)
+		return out, err
+	}
+
+	patch := `{"status":"shipped","note":null,"carrier":"DHL"}`
+	out, err := run("--data", patch, "--dry-run")
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	want := "Would patch document " + doc.ID + " (1 added, 1 removed, 1 changed)\n" +
+		"  + carrier  \"DHL\"\n" +
+		"  - note     \"call first\"\n" +
+		"  ~ status   \"pending\" → \"shipped\"\n"
+	if out != want {
+		t.Fatalf("unexpected dry-run output:\n%s", out)
+	}
+	if stored := srv.Documents("orders")[0]; stored.Version != doc.Version {
+		t.Fatalf("dry run modified the document: %+v", stored)
+	}
+
+	out, err = run("--data", `{"status":"shipped","carrier":"DHL"}`, "--diff", "--raw")
+	if err != nil {
+		t.Fatalf("patch --diff failed: %v", err)
+	}
+	var result struct {
+		DryRun  bool `json:"dry_run"`
+		Summary struct {
+			Added, Removed, Changed int
+		} `json:"summary"`
+		Changes []struct {
+			Path string `json:"path"`
+			Op   string `json:"op"`
+		} `json:"changes"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, out)
+	}
+	if result.DryRun || result.Summary.Added != 1 || result.Summary.Changed != 1 || len(result.Changes) != 2 || result.Changes[1].Path != "status" {
+		t.Fatalf("unexpected diff %+v", result)
+	}
+	if stored := srv.Documents("orders")[0]; !strings.Contains(stored.Data, "shipped") {
+		t.Fatalf("patch was not applied: %s", stored.Data)
+	}
+
+	if _, err := run("--patch-type", "json-patch", "--data", `[{"op":"remove","path":"/x"}]`, "--dry-run"); err == nil || !strings.Contains(err.Error(), "merge patches only") {
+		t.Fatalf("expected json-patch dry runs to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	langEnglish = "en"
+	langKhmer   = "km"
+	langChinese = "zh"
+)
+
+// activeLanguage selects the message catalog. The root command resolves it
+// once per invocation from --lang, falling back to the language config field.
+var activeLanguage = langEnglish
+
+// messageCatalog translates user-facing messages, keyed by the English text
+// (including format verbs and trailing newlines) as written at the call site.
+// Messages without a translation are printed in English. Translations must
+// keep the verbs of the English text in the same order; a test enforces it.
+var messageCatalog = map[string]map[string]string{
+	langKhmer: {
+		"collection name cannot be empty":                                                      "ឈ្មោះ collection មិនអាចទទេបានទេ",
+		"collection and document ID are required":                                              "ត្រូវការឈ្មោះ collection និង ID ឯកសារ",
+		"--tenant is required (set a default via `tdb config set default-tenant <tenant_id>`)": "ត្រូវការ --tenant (កំណត់លំនាំដើមដោយ `tdb config set default-tenant <tenant_id>`)",
+		"endpoint not configured; run `tdb config set endpoint <url>`":                         "មិនទាន់កំណត់ endpoint ទេ សូមដំណើរការ `tdb config set endpoint <url>`",
+		"provide --data, --file, or --stdin":                                                   "សូមផ្តល់ --data, --file ឬ --stdin",
+		"use only one of --data, --file, or --stdin":                                           "សូមប្រើតែមួយក្នុងចំណោម --data, --file ឬ --stdin",
+		"payload cannot be empty":                                                              "ទិន្នន័យមិនអាចទទេបានទេ",
+		"invalid JSON payload":                                                                 "ទិន្នន័យ JSON មិនត្រឹមត្រូវ",
+		"expected JSON array payload":                                                          "ត្រូវការទិន្នន័យជា JSON array",
+		"use --confirm to acknowledge irreversible purge":                                      "សូមប្រើ --confirm ដើម្បីបញ្ជាក់ការលុបជាស្ថាពរ ដែលមិនអាចត្រឡប់វិញបាន",
+		"No documents found":                                                                   "រកមិនឃើញឯកសារទេ",
+		"Using default tenant %s\n":                                                            "កំពុងប្រើ tenant លំនាំដើម %s\n",
+		"Created document %s\n":                                                                "បានបង្កើតឯកសារ %s\n",
+		"Updated document %s\n":                                                                "បានធ្វើបច្ចុប្បន្នភាពឯកសារ %s\n",
+		"Deleted document %s\n":                                                                "បានលុបឯកសារ %s\n",
+		"Created collection %s (%s)\n":                                                         "បានបង្កើត collection %s (%s)\n",
+		"Updated collection %s\n":                                                              "បានធ្វើបច្ចុប្បន្នភាព collection %s\n",
+		"Deleted collection %s\n":                                                              "បានលុប collection %s\n",
+		"Created application %s (%s)\n":                                                        "បានបង្កើតកម្មវិធី %s (%s)\n",
+		"Created tenant %s (%s)\n":                                                             "បានបង្កើត tenant %s (%s)\n",
+		"Generated key: %s (prefix %s)\n":                                                      "សោដែលបានបង្កើត៖ %s (បុព្វបទ %s)\n",
+		"Stored generated key as %s\n":                                                         "បានរក្សាទុកសោដែលបានបង្កើតជា %s\n",
+		"Exported %d documents to %s\n":                                                        "បាននាំចេញឯកសារ %d ទៅ %s\n",
+		"Exported %d documents\n":                                                              "បាននាំចេញឯកសារ %d\n",
+		"Streamed %d documents\n":                                                              "បានបញ្ជូនឯកសារ %d តាម stream\n",
+		"Documents synced: created %d, updated %d, unchanged %d, skipped %d, missing %d, failed %d": "បានធ្វើសមកាលកម្មឯកសារ៖ បង្កើត %d, ធ្វើបច្ចុប្បន្នភាព %d, មិនផ្លាស់ប្តូរ %d, រំលង %d, បាត់ %d, បរាជ័យ %d",
+	},
+	langChinese: {
+		"collection name cannot be empty":                                                      "集合名称不能为空",
+		"collection and document ID are required":                                              "必须提供集合名称和文档 ID",
+		"--tenant is required (set a default via `tdb config set default-tenant <tenant_id>`)": "必须提供 --tenant（可通过 `tdb config set default-tenant <tenant_id>` 设置默认值）",
+		"endpoint not configured; run `tdb config set endpoint <url>`":                         "尚未配置 endpoint；请运行 `tdb config set endpoint <url>`",
+		"provide --data, --file, or --stdin":                                                   "请提供 --data、--file 或 --stdin",
+		"use only one of --data, --file, or --stdin":                                           "--data、--file 和 --stdin 只能使用其中一个",
+		"payload cannot be empty":                                                              "请求数据不能为空",
+		"invalid JSON payload":                                                                 "JSON 数据无效",
+		"expected JSON array payload":                                                          "需要 JSON 数组格式的数据",
+		"use --confirm to acknowledge irreversible purge":                                      "请使用 --confirm 确认不可恢复的彻底删除",
+		"No documents found":                                                                   "未找到文档",
+		"Using default tenant %s\n":                                                            "使用默认租户 %s\n",
+		"Created document %s\n":                                                                "已创建文档 %s\n",
+		"Updated document %s\n":                                                                "已更新文档 %s\n",
+		"Deleted document %s\n":                                                                "已删除文档 %s\n",
+		"Created collection %s (%s)\n":                                                         "已创建集合 %s (%s)\n",
+		"Updated collection %s\n":                                                              "已更新集合 %s\n",
+		"Deleted collection %s\n":                                                              "已删除集合 %s\n",
+		"Created application %s (%s)\n":                                                        "已创建应用 %s (%s)\n",
+		"Created tenant %s (%s)\n":                                                             "已创建租户 %s (%s)\n",
+		"Generated key: %s (prefix %s)\n":                                                      "已生成密钥：%s（前缀 %s）\n",
+		"Stored generated key as %s\n":                                                         "已将生成的密钥保存为 %s\n",
+		"Exported %d documents to %s\n":                                                        "已导出 %d 个文档到 %s\n",
+		"Exported %d documents\n":                                                              "已导出 %d 个文档\n",
+		"Streamed %d documents\n":                                                              "已流式导出 %d 个文档\n",
+		"Documents synced: created %d, updated %d, unchanged %d, skipped %d, missing %d, failed %d": "文档同步完成：创建 %d，更新 %d，未变 %d，跳过 %d，缺失 %d，失败 %d",
+	},
+}
+
+func parseLanguage(value string) (string, error) {
+	lang := strings.ToLower(strings.TrimSpace(value))
+	// Accept locale names such as km_KH.UTF-8 or zh-CN.
+	if idx := strings.IndexAny(lang, "_-."); idx > 0 {
+		lang = lang[:idx]
+	}
+	switch lang {
+	case "", langEnglish:
+		return langEnglish, nil
+	case langKhmer, langChinese:
+		return lang, nil
+	default:
+		return "", fmt.Errorf("invalid language %q: expected %s", value, strings.Join(supportedLanguages(), ", "))
+	}
+}
+
+func supportedLanguages() []string {
+	langs := []string{langEnglish}
+	for lang := range messageCatalog {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs[1:])
+	return langs
+}
+
+// resolveLanguage prefers the flag value and falls back to the config default.
+// An invalid --lang is an error; an invalid configured language only warns and
+// falls back to English, so a bad config does not break every command.
+func resolveLanguage(flagLang, cfgLang string, warn io.Writer) (string, error) {
+	if strings.TrimSpace(flagLang) != "" {
+		return parseLanguage(flagLang)
+	}
+	lang, err := parseLanguage(cfgLang)
+	if err != nil {
+		logWarn(warn, fmt.Sprintf("config: %v; using English", err))
+		return langEnglish, nil
+	}
+	return lang, nil
+}
+
+// tr returns message in the active language. Pass it the literal English
+// text, e.g. fmt.Fprintf(out, tr("Deleted document %s\n"), id).
+func tr(message string) string {
+	if translated, ok := messageCatalog[activeLanguage][message]; ok {
+		return translated
+	}
+	return message
+}
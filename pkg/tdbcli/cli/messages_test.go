@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+var formatVerbPattern = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+func TestMessageCatalogKeepsFormatVerbs(t *testing.T) {
+	reference := messageCatalog[langKhmer]
+	for lang, messages := range messageCatalog {
+		if len(messages) != len(reference) {
+			t.Errorf("%s has %d messages, %s has %d", lang, len(messages), langKhmer, len(reference))
+		}
+		for source, translated := range messages {
+			if _, ok := reference[source]; !ok {
+				t.Errorf("%s translates %q, which %s does not", lang, source, langKhmer)
+			}
+			if !slices.Equal(formatVerbPattern.FindAllString(source, -1), formatVerbPattern.FindAllString(translated, -1)) {
+				t.Errorf("%s: %q changes the format verbs of %q", lang, translated, source)
+			}
+			if strings.HasSuffix(source, "\n") != strings.HasSuffix(translated, "\n") {
+				t.Errorf("%s: %q changes the trailing newline of %q", lang, translated, source)
+			}
+		}
+	}
+}
+
+func TestParseLanguage(t *testing.T) {
+	cases := map[string]string{"": langEnglish, "EN": langEnglish, "km": langKhmer, "km_KH.UTF-8": langKhmer, "zh-CN": langChinese}
+	for input, want := range cases {
+		if got, err := parseLanguage(input); err != nil || got != want {
+			t.Fatalf("parseLanguage(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := parseLanguage("fr"); err == nil || !strings.Contains(err.Error(), "expected en, km, zh") {
+		t.Fatalf("expected invalid language error, got %v", err)
+	}
+}
+
+func TestLanguageFlagAndConfigDefault(t *testing.T) {
+	defer func() { activeLanguage = langEnglish }()
+	srv := clienttest.NewServer(t)
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("endpoint: "+srv.URL+"\nlanguage: km\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	run := func(extra ...string) error {
+		root := NewRootCommand()
+		root.SetArgs(append([]string{"--config", configPath, "tenant", "documents", "export", " ", "--tenant", "tn_test", "--api-key", clienttest.APIKey}, extra...))
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&bytes.Buffer{})
+		return root.Execute()
+	}
+	if err := run(); err == nil || err.Error() != "ឈ្មោះ collection មិនអាចទទេបានទេ" {
+		t.Fatalf("expected Khmer error from the config default, got %v", err)
+	}
+	if err := run("--lang", "zh"); err == nil || err.Error() != "集合名称不能为空" {
+		t.Fatalf("expected Chinese error from --lang, got %v", err)
+	}
+	if err := run("--lang", "en"); err == nil || err.Error() != "collection name cannot be empty" {
+		t.Fatalf("expected English error, got %v", err)
+	}
+}
+
+func TestResolveLanguageInvalidConfigFallsBack(t *testing.T) {
+	var warn bytes.Buffer
+	if got, err := resolveLanguage("", "fr", &warn); err != nil || got != langEnglish {
+		t.Fatalf("resolveLanguage with a bad config = %q, %v; want English", got, err)
+	}
+	if !strings.Contains(warn.String(), `invalid language "fr"`) {
+		t.Fatalf("expected a warning, got %q", warn.String())
+	}
+	if _, err := resolveLanguage("fr", "km", &warn); err == nil {
+		t.Fatalf("expected an invalid --lang to fail")
+	}
+}
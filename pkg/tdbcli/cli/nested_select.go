@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// selectTree is a nested projection such as user{name,email},items{sku,qty}.
+// A nil child keeps the whole value of that field.
+type selectTree map[string]selectTree
+
+// isNestedSelect reports whether a --select value uses the nested syntax.
+// Plain comma lists keep going to the server unchanged.
+func isNestedSelect(expr string) bool {
+	return strings.ContainsAny(expr, "{}")
+}
+
+// parseNestedSelect parses a nested projection. Fields are separated by
+// commas, braces select sub-fields, and a dotted path (user.name) is shorthand
+// for user{name}.
+func parseNestedSelect(expr string) (selectTree, error) {
+	p := &selectParser{input: expr}
+	tree, err := p.parseFields()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("invalid --select %q: unexpected %q at position %d", expr, p.input[p.pos], p.pos+1)
+	}
+	if len(tree) == 0 {
+		return nil, fmt.Errorf("invalid --select %q: no fields", expr)
+	}
+	return tree, nil
+}
+
+type selectParser struct {
+	input string
+	pos   int
+}
+
+func (p *selectParser) skipSpace() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *selectParser) parseFields() (selectTree, error) {
+	tree := selectTree{}
+	for {
+		p.skipSpace()
+		start := p.pos
+		for p.pos < len(p.input) && !strings.ContainsRune(",{} \t", rune(p.input[p.pos])) {
+			p.pos++
+		}
+		name := p.input[start:p.pos]
+		if name == "" {
+			return nil, fmt.Errorf("invalid --select %q: expected a field name at position %d", p.input, start+1)
+		}
+		var children selectTree
+		p.skipSpace()
+		if p.pos < len(p.input) && p.input[p.pos] == '{' {
+			p.pos++
+			sub, err := p.parseFields()
+			if err != nil {
+				return nil, err
+			}
+			p.skipSpace()
+			if p.pos >= len(p.input) || p.input[p.pos] != '}' {
+				return nil, fmt.Errorf("invalid --select %q: missing closing brace for %s", p.input, name)
+			}
+			p.pos++
+			children = sub
+		}
+		tree.add(strings.Split(name, "."), children)
+		p.skipSpace()
+		if p.pos < len(p.input) && p.input[p.pos] == ',' {
+			p.pos++
+			continue
+		}
+		return tree, nil
+	}
+}
+
+// add merges path (with children at its end) into the tree. Selecting a
+// field whole wins over selecting some of its sub-fields.
+func (t selectTree) add(path []string, children selectTree) {
+	head := path[0]
+	existing, seen := t[head]
+	if len(path) > 1 {
+		children = selectTree{}.with(path[1:], children)
+	}
+	switch {
+	case seen && existing == nil:
+	case !seen || children == nil:
+		t[head] = children
+	default:
+		for key, child := range children {
+			existing.add([]string{key}, child)
+		}
+	}
+}
+
+func (t selectTree) with(path []string, children selectTree) selectTree {
+	t.add(path, children)
+	return t
+}
+
+// topLevel returns the top-level fields to request from the server.
+func (t selectTree) topLevel() []string {
+	fields := make([]string, 0, len(t))
+	for field := range t {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// project trims value to the selected fields. Arrays are projected element by
+// element, and sub-selections on scalar values leave them unchanged.
+func (t selectTree) project(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for field, child := range t {
+			v, ok := typed[field]
+			if !ok {
+				continue
+			}
+			if child == nil {
+				out[field] = v
+			} else {
+				out[field] = child.project(v)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(typed))
+		for i, item := range typed {
+			out[i] = t.project(item)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// projectDocuments applies the projection to each document's data payload
+// after it was fetched.
+func (t selectTree) projectDocuments(docs []clientpkg.Document) error {
+	for i := range docs {
+		data := strings.TrimSpace(docs[i].Data)
+		if data == "" {
+			continue
+		}
+		var value any
+		if err := json.Unmarshal([]byte(data), &value); err != nil {
+			return fmt.Errorf("document %s: decode data: %w", docs[i].ID, err)
+		}
+		projected, err := json.Marshal(t.project(value))
+		if err != nil {
+			return err
+		}
+		docs[i].Data = string(projected)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestParseNestedSelect(t *testing.T) {
+	tree, err := parseNestedSelect("id, user{name,email}, items{sku,qty}, user.address.city")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got := strings.Join(tree.topLevel(), ","); got != "id,items,user" {
+		t.Fatalf("unexpected top-level fields %s", got)
+	}
+	encoded, _ := json.Marshal(tree)
+	if string(encoded) != `{"id":null,"items":{"qty":null,"sku":null},"user":{"address":{"city":null},"email":null,"name":null}}` {
+		t.Fatalf("unexpected tree %s", encoded)
+	}
+	whole, err := parseNestedSelect("user{name},user")
+	if err != nil || whole["user"] != nil {
+		t.Fatalf("expected selecting the whole field to win, got %v (%v)", whole, err)
+	}
+	for _, bad := range []string{"user{name", "user{}", "a,,b", "a}"} {
+		if _, err := parseNestedSelect(bad); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestDocumentsListNestedSelect(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddDocument("orders", map[string]any{
+		"user":  map[string]any{"name": "Ann", "email": "ann@example.com", "password": "x"},
+		"items": []any{map[string]any{"sku": "A1", "qty": 2, "price": 10}, map[string]any{"sku": "B2", "qty": 1, "price": 5}},
+		"notes": "drop me",
+	})
+	out, _, err := runCommand(t, srv, newTenantDocumentsListCommand, "orders", "--select", "user{name,email},items{sku,qty}", "--raw")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	var resp clientpkg.DocumentListResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil || len(resp.Items) != 1 {
+		t.Fatalf("decode %s: %v", out, err)
+	}
+	want := `{"items":[{"qty":2,"sku":"A1"},{"qty":1,"sku":"B2"}],"user":{"email":"ann@example.com","name":"Ann"}}`
+	if resp.Items[0].Data != want {
+		t.Fatalf("unexpected projection:\n got %s\nwant %s", resp.Items[0].Data, want)
+	}
+}
@@ -0,0 +1,404 @@
+package cli
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// openAPISpec is the subset of an OpenAPI 3 document needed to check the
+// client: paths, parameters, JSON bodies, and component references. JSON specs
+// decode too, since JSON is valid YAML.
+type openAPISpec struct {
+	Paths      map[string]openAPIPathItem `yaml:"paths"`
+	Components struct {
+		Schemas       map[string]*openAPISchema     `yaml:"schemas"`
+		Parameters    map[string]openAPIParameter   `yaml:"parameters"`
+		RequestBodies map[string]openAPIRequestBody `yaml:"requestBodies"`
+		Responses     map[string]openAPIResponse    `yaml:"responses"`
+	} `yaml:"components"`
+}
+
+type openAPIPathItem struct {
+	Parameters []openAPIParameter `yaml:"parameters"`
+	Get        *openAPIOperation  `yaml:"get"`
+	Put        *openAPIOperation  `yaml:"put"`
+	Post       *openAPIOperation  `yaml:"post"`
+	Patch      *openAPIOperation  `yaml:"patch"`
+	Delete     *openAPIOperation  `yaml:"delete"`
+}
+
+type openAPIOperation struct {
+	Parameters  []openAPIParameter         `yaml:"parameters"`
+	RequestBody *openAPIRequestBody        `yaml:"requestBody"`
+	Responses   map[string]openAPIResponse `yaml:"responses"`
+}
+
+type openAPIParameter struct {
+	Ref  string `yaml:"$ref"`
+	Name string `yaml:"name"`
+	In   string `yaml:"in"`
+}
+
+type openAPIRequestBody struct {
+	Ref     string                  `yaml:"$ref"`
+	Content map[string]openAPIMedia `yaml:"content"`
+}
+
+type openAPIResponse struct {
+	Ref     string                  `yaml:"$ref"`
+	Content map[string]openAPIMedia `yaml:"content"`
+}
+
+type openAPIMedia struct {
+	Schema *openAPISchema `yaml:"schema"`
+}
+
+type openAPISchema struct {
+	Ref        string                    `yaml:"$ref"`
+	Type       string                    `yaml:"type"`
+	Properties map[string]*openAPISchema `yaml:"properties"`
+	Required   []string                  `yaml:"required"`
+	Items      *openAPISchema            `yaml:"items"`
+	AllOf      []*openAPISchema          `yaml:"allOf"`
+	OneOf      []*openAPISchema          `yaml:"oneOf"`
+	AnyOf      []*openAPISchema          `yaml:"anyOf"`
+}
+
+// apiMismatch is one difference between the client and the spec.
+type apiMismatch struct {
+	Operation string `json:"operation"`
+	Endpoint  string `json:"endpoint"`
+	Problem   string `json:"problem"`
+}
+
+func parseOpenAPISpec(raw []byte) (*openAPISpec, error) {
+	var spec openAPISpec
+	if err := yaml.Unmarshal(raw, &spec); err != nil {
+		return nil, fmt.Errorf("decode OpenAPI spec: %w", err)
+	}
+	if len(spec.Paths) == 0 {
+		return nil, fmt.Errorf("OpenAPI spec declares no paths")
+	}
+	return &spec, nil
+}
+
+var pathParamPattern = regexp.MustCompile(`\{[^}]*\}`)
+
+// normalizeAPIPath makes templates comparable regardless of parameter names
+// and trailing slashes: /api/collections/{name} matches /api/collections/{collection}.
+func normalizeAPIPath(path string) string {
+	path = strings.TrimSpace(path)
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return pathParamPattern.ReplaceAllString(path, "{}")
+}
+
+// checkClientAgainstSpec compares every client endpoint with the spec and
+// returns the mismatches sorted by endpoint.
+func checkClientAgainstSpec(spec *openAPISpec, endpoints []clientpkg.Endpoint) []apiMismatch {
+	items := make(map[string]openAPIPathItem, len(spec.Paths))
+	for path, item := range spec.Paths {
+		items[normalizeAPIPath(path)] = item
+	}
+	var mismatches []apiMismatch
+	for _, ep := range endpoints {
+		report := func(format string, args ...any) {
+			mismatches = append(mismatches, apiMismatch{Operation: ep.Operation, Endpoint: ep.Method + " " + ep.Path, Problem: fmt.Sprintf(format, args...)})
+		}
+		item, ok := items[normalizeAPIPath(ep.Path)]
+		if !ok {
+			report("path not found in spec")
+			continue
+		}
+		op := item.operation(ep.Method)
+		if op == nil {
+			report("method not declared for this path")
+			continue
+		}
+
+		declared := map[string]bool{}
+		for _, param := range append(append([]openAPIParameter{}, item.Parameters...), op.Parameters...) {
+			param = spec.resolveParameter(param)
+			if param.In == "query" {
+				declared[param.Name] = true
+			}
+		}
+		for _, name := range ep.Query {
+			if !declared[name] {
+				report("query parameter %q not declared", name)
+			}
+		}
+
+		if ep.Request != nil {
+			if schema := spec.requestSchema(op); schema != nil {
+				for _, problem := range compareJSONFields(spec, reflect.TypeOf(ep.Request), schema, "", true) {
+					report("request %s", problem)
+				}
+			}
+		}
+		if ep.Response != nil {
+			if schema := spec.responseSchema(op); schema != nil {
+				for _, problem := range compareJSONFields(spec, reflect.TypeOf(ep.Response), schema, "", false) {
+					report("response %s", problem)
+				}
+			}
+		}
+	}
+	sort.SliceStable(mismatches, func(i, j int) bool { return mismatches[i].Endpoint < mismatches[j].Endpoint })
+	return mismatches
+}
+
+func (p openAPIPathItem) operation(method string) *openAPIOperation {
+	switch strings.ToUpper(method) {
+	case "GET":
+		return p.Get
+	case "PUT":
+		return p.Put
+	case "POST":
+		return p.Post
+	case "PATCH":
+		return p.Patch
+	case "DELETE":
+		return p.Delete
+	}
+	return nil
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+func (s *openAPISpec) resolveParameter(param openAPIParameter) openAPIParameter {
+	if param.Ref != "" {
+		return s.Components.Parameters[refName(param.Ref)]
+	}
+	return param
+}
+
+func jsonMediaSchema(content map[string]openAPIMedia) *openAPISchema {
+	for mediaType, media := range content {
+		if strings.Contains(mediaType, "json") && media.Schema != nil {
+			return media.Schema
+		}
+	}
+	return nil
+}
+
+func (s *openAPISpec) requestSchema(op *openAPIOperation) *openAPISchema {
+	if op.RequestBody == nil {
+		return nil
+	}
+	body := *op.RequestBody
+	if body.Ref != "" {
+		body = s.Components.RequestBodies[refName(body.Ref)]
+	}
+	return jsonMediaSchema(body.Content)
+}
+
+// responseSchema picks the JSON body of the lowest 2xx response.
+func (s *openAPISpec) responseSchema(op *openAPIOperation) *openAPISchema {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		resp := op.Responses[code]
+		if resp.Ref != "" {
+			resp = s.Components.Responses[refName(resp.Ref)]
+		}
+		if schema := jsonMediaSchema(resp.Content); schema != nil {
+			return schema
+		}
+	}
+	return nil
+}
+
+// resolveSchema follows $ref and merges allOf members. oneOf/anyOf schemas are
+// merged too, so a field is accepted when any alternative declares it.
+func (s *openAPISpec) resolveSchema(schema *openAPISchema, depth int) *openAPISchema {
+	if schema == nil || depth > 32 {
+		return nil
+	}
+	if schema.Ref != "" {
+		return s.resolveSchema(s.Components.Schemas[refName(schema.Ref)], depth+1)
+	}
+	parts := append(append(append([]*openAPISchema{}, schema.AllOf...), schema.OneOf...), schema.AnyOf...)
+	if len(parts) == 0 {
+		return schema
+	}
+	merged := &openAPISchema{Type: schema.Type, Items: schema.Items, Required: append([]string{}, schema.Required...), Properties: map[string]*openAPISchema{}}
+	for name, prop := range schema.Properties {
+		merged.Properties[name] = prop
+	}
+	for _, part := range parts {
+		resolved := s.resolveSchema(part, depth+1)
+		if resolved == nil {
+			continue
+		}
+		for name, prop := range resolved.Properties {
+			merged.Properties[name] = prop
+		}
+		if merged.Items == nil {
+			merged.Items = resolved.Items
+		}
+		if merged.Type == "" {
+			merged.Type = resolved.Type
+		}
+	}
+	return merged
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// compareJSONFields walks a Go type and the matching schema side by side.
+// Fields the client uses but the spec lacks are reported with a rename hint;
+// for requests, required spec fields the client never sends are reported too.
+// Free-form schemas (no properties) accept anything.
+func compareJSONFields(spec *openAPISpec, t reflect.Type, schema *openAPISchema, path string, request bool) []string {
+	schema = spec.resolveSchema(schema, 0)
+	if schema == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if schema.Items == nil {
+			return nil
+		}
+		return compareJSONFields(spec, t.Elem(), schema.Items, path+"[]", request)
+	case reflect.Struct:
+	default:
+		return nil
+	}
+	if len(schema.Properties) == 0 {
+		return nil
+	}
+
+	var problems []string
+	used := map[string]bool{}
+	fields := jsonFieldsOf(t)
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		used[name] = true
+		prop, ok := schema.Properties[name]
+		if !ok {
+			problem := fmt.Sprintf("field %q not in spec", path+name)
+			if hint := similarAPIField(name, schema.Properties, fields); hint != "" {
+				problem += fmt.Sprintf(" (renamed to %q?)", path+hint)
+			}
+			problems = append(problems, problem)
+			continue
+		}
+		problems = append(problems, compareJSONFields(spec, fields[name], prop, path+name+".", request)...)
+	}
+	if request {
+		required := append([]string{}, schema.Required...)
+		sort.Strings(required)
+		for _, name := range required {
+			if !used[name] {
+				problems = append(problems, fmt.Sprintf("required field %q not sent by the client", path+name))
+			}
+		}
+	}
+	return problems
+}
+
+// jsonFieldsOf maps the JSON names of a struct's exported fields to their types.
+func jsonFieldsOf(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				for inner, typ := range jsonFieldsOf(field.Type) {
+					fields[inner] = typ
+				}
+				continue
+			}
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// similarAPIField suggests a spec property the client field was probably
+// renamed to: one the client does not use that matches ignoring case and
+// separators, where one name extends the other (count vs count_total), or
+// whose words abbreviate each other (document_count vs doc_count).
+func similarAPIField(name string, props map[string]*openAPISchema, clientFields map[string]reflect.Type) string {
+	squash := func(s string) string {
+		return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(s))
+	}
+	var candidates []string
+	for prop := range props {
+		if _, used := clientFields[prop]; !used {
+			candidates = append(candidates, prop)
+		}
+	}
+	sort.Strings(candidates)
+	for _, prop := range candidates {
+		if squash(prop) == squash(name) {
+			return prop
+		}
+	}
+	for _, prop := range candidates {
+		if strings.HasPrefix(squash(prop), squash(name)) || strings.HasPrefix(squash(name), squash(prop)) {
+			return prop
+		}
+	}
+	for _, prop := range candidates {
+		if abbreviatedAPIField(prop, name) {
+			return prop
+		}
+	}
+	return ""
+}
+
+func abbreviatedAPIField(a, b string) bool {
+	split := func(s string) []string {
+		return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return r == '_' || r == '-' })
+	}
+	wa, wb := split(a), split(b)
+	if len(wa) != len(wb) || len(wa) < 2 {
+		return false
+	}
+	for i := range wa {
+		if !strings.HasPrefix(wa[i], wb[i]) && !strings.HasPrefix(wb[i], wa[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const testOpenAPISpec = `openapi: 3.0.3
+paths:
+  /api/collections/{collection_name}:
+    parameters:
+      - $ref: '#/components/parameters/AppID'
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Collection'
+    put:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [schema, reason]
+              properties:
+                schema: {type: string}
+                reason: {type: string}
+                primary_key:
+                  type: object
+                  properties:
+                    field: {type: string}
+                    kind: {type: string}
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Collection'
+  /api/snapshots:
+    get:
+      responses:
+        "200":
+          description: ok
+components:
+  parameters:
+    AppID:
+      name: app_id
+      in: query
+  schemas:
+    Collection:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          properties:
+            name: {type: string}
+            doc_count: {type: integer}
+    Base:
+      type: object
+      properties:
+        id: {type: string}
+`
+
+func TestCheckClientAgainstSpec(t *testing.T) {
+	spec, err := parseOpenAPISpec([]byte(testOpenAPISpec))
+	if err != nil {
+		t.Fatalf("parse spec: %v", err)
+	}
+	type collection struct {
+		ID            string `json:"id"`
+		Name          string `json:"name"`
+		DocumentCount int64  `json:"document_count"`
+	}
+	endpoints := []clientpkg.Endpoint{
+		{Operation: "GetCollection", Method: "GET", Path: "/api/collections/{name}", Query: []string{"app_id"}, Response: collection{}},
+		{Operation: "UpdateCollection", Method: "PUT", Path: "/api/collections/{name}", Query: []string{"app_id"}, Request: clientpkg.UpdateCollectionRequest{}, Response: &collection{}},
+		{Operation: "DeleteCollection", Method: "DELETE", Path: "/api/collections/{name}"},
+		{Operation: "ListSnapshots", Method: "GET", Path: "/api/snapshots/", Query: []string{"limit"}, Response: clientpkg.SnapshotListResponse{}},
+		{Operation: "GetApplication", Method: "GET", Path: "/api/applications/{id}"},
+	}
+	var problems []string
+	for _, m := range checkClientAgainstSpec(spec, endpoints) {
+		problems = append(problems, m.Endpoint+": "+m.Problem)
+	}
+	want := []string{
+		`DELETE /api/collections/{name}: method not declared for this path`,
+		`GET /api/applications/{id}: path not found in spec`,
+		`GET /api/collections/{name}: response field "document_count" not in spec (renamed to "doc_count"?)`,
+		`GET /api/snapshots/: query parameter "limit" not declared`,
+		`PUT /api/collections/{name}: request field "primary_key.auto" not in spec`,
+		`PUT /api/collections/{name}: request field "primary_key.type" not in spec`,
+		`PUT /api/collections/{name}: request required field "reason" not sent by the client`,
+		`PUT /api/collections/{name}: response field "document_count" not in spec (renamed to "doc_count"?)`,
+	}
+	if strings.Join(problems, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected mismatches:\n%s", strings.Join(problems, "\n"))
+	}
+}
+
+func TestDevVerifyAPICommand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(path, []byte(testOpenAPISpec), 0o644); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	cmd := newDevVerifyAPICommand()
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	cmd.SetArgs([]string{"--openapi", path, "--raw"})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "mismatch(es) between the client") {
+		t.Fatalf("expected mismatch error, got %v", err)
+	}
+	var mismatches []apiMismatch
+	if err := json.Unmarshal(out.Bytes(), &mismatches); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, out.String())
+	}
+	found := false
+	for _, m := range mismatches {
+		if m.Operation == "CreateDocument" && m.Problem == "path not found in spec" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected CreateDocument to be reported, got %+v", mismatches)
+	}
+}
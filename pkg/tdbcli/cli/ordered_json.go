@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+)
+
+// orderedObject is a JSON object whose fields are encoded in the order they
+// were added. encoding/json writes map keys alphabetically, which buries the
+// identifying fields of a document between its metadata; pretty output uses
+// orderedObject so the layout is fixed and readable.
+type orderedObject []orderedField
+
+type orderedField struct {
+	Key   string
+	Value any
+}
+
+// MarshalJSON encodes the fields in order.
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Get returns the value stored under key.
+func (o orderedObject) Get(key string) (any, bool) {
+	for _, field := range o {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
+
+// sortKeysFor reports whether the persistent --sort-keys flag is set, so JSON
+// output is written with every object's keys sorted.
+func sortKeysFor(cmd *cobra.Command) bool {
+	if flag := cmd.Flag("sort-keys"); flag != nil {
+		return flag.Value.String() == "true"
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+func TestMakeDocumentPrettyFieldOrder(t *testing.T) {
+	doc := clientpkg.Document{ID: "doc_1", Key: "k1", CollectionID: "col_1", TenantID: "tn_1", Version: 2, Data: `{"b":1,"a":2}`}
+	encoded, err := json.Marshal(makeDocumentPretty(doc))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	text := string(encoded)
+	last := -1
+	for _, key := range strings.Split("id,key,key_numeric,collection_id,tenant_id,version,created_at,updated_at,deleted_at,data_size,data_size_human,data", ",") {
+		idx := strings.Index(text, `"`+key+`":`)
+		if idx <= last {
+			t.Fatalf("field %s out of order in %s", key, text)
+		}
+		last = idx
+	}
+	if !strings.HasSuffix(text, `"data":{"a":2,"b":1}}`) {
+		t.Fatalf("expected data last, got %s", text)
+	}
+}
+
+func TestPrintJSONSortKeys(t *testing.T) {
+	value := orderedObject{{"zeta", 1}, {"alpha", orderedObject{{"y", true}, {"x", false}}}}
+	run := func(sortKeys bool) string {
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("sort-keys", false, "")
+		if sortKeys {
+			_ = cmd.Flags().Set("sort-keys", "true")
+		}
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		if err := printCompactJSON(cmd, value); err != nil {
+			t.Fatalf("print: %v", err)
+		}
+		return strings.TrimSpace(out.String())
+	}
+	if got := run(false); got != `{"zeta":1,"alpha":{"y":true,"x":false}}` {
+		t.Fatalf("unexpected ordered output %s", got)
+	}
+	if got := run(true); got != `{"alpha":{"x":false,"y":true},"zeta":1}` {
+		t.Fatalf("unexpected sorted output %s", got)
+	}
+}
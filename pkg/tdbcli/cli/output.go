@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"encoding/csv"
 	"fmt"
 	"io"
 	"os"
@@ -18,6 +19,37 @@ const (
 	ansiRowAlt    = "\033[90m"
 )
 
+// Table output styles selectable with --table-style.
+const (
+	tableStyleGrid     = "grid"
+	tableStylePlain    = "plain"
+	tableStyleMarkdown = "markdown"
+	tableStyleCSV      = "csv"
+	tableStyleTSV      = "tsv"
+)
+
+func parseTableStyle(value string) (string, error) {
+	style := strings.ToLower(strings.TrimSpace(value))
+	switch style {
+	case "":
+		return tableStyleGrid, nil
+	case tableStyleGrid, tableStylePlain, tableStyleMarkdown, tableStyleCSV, tableStyleTSV:
+		return style, nil
+	}
+	return "", fmt.Errorf("unsupported --table-style %q (choose grid, plain, markdown, csv, or tsv)", value)
+}
+
+// tableStyleFor resolves the style requested via the persistent --table-style
+// flag, falling back to grid for commands run outside the root command.
+func tableStyleFor(cmd *cobra.Command) string {
+	if flag := cmd.Flag("table-style"); flag != nil {
+		if style, err := parseTableStyle(flag.Value.String()); err == nil {
+			return style
+		}
+	}
+	return tableStyleGrid
+}
+
 type tableStyles struct {
 	enabled bool
 }
@@ -68,7 +100,21 @@ func (s tableStyles) row(text string, odd bool) string {
 }
 
 func renderTable(cmd *cobra.Command, headers []string, rows [][]string) {
-	out := cmd.OutOrStdout()
+	renderTableStyle(cmd.OutOrStdout(), tableStyleFor(cmd), headers, rows)
+}
+
+func renderTableStyle(out io.Writer, style string, headers []string, rows [][]string) {
+	switch style {
+	case tableStyleCSV:
+		renderDelimitedTable(out, headers, rows)
+		return
+	case tableStyleTSV:
+		renderTSVTable(out, headers, rows)
+		return
+	case tableStyleMarkdown:
+		renderMarkdownTable(out, headers, rows)
+		return
+	}
 	styles := newTableStyles(out)
 
 	columnCount := len(headers)
@@ -98,6 +144,14 @@ func renderTable(cmd *cobra.Command, headers []string, rows [][]string) {
 		}
 	}
 
+	if style == tableStylePlain {
+		fmt.Fprintln(out, styles.header(buildPlainLine(headers, widths)))
+		for idx, row := range rows {
+			fmt.Fprintln(out, styles.row(buildPlainLine(row, widths), idx%2 == 1))
+		}
+		return
+	}
+
 	top := buildBorder(widths, "┌", "┬", "┐")
 	fmt.Fprintln(out, styles.separator(top))
 
@@ -157,3 +211,68 @@ func padCell(text string, width int) string {
 	}
 	return text
 }
+
+func buildPlainLine(cells []string, widths []int) string {
+	parts := make([]string, len(widths))
+	for i, w := range widths {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		parts[i] = padCell(cell, w)
+	}
+	return strings.TrimRight(strings.Join(parts, "   "), " ")
+}
+
+func renderDelimitedTable(out io.Writer, headers []string, rows [][]string) {
+	writer := csv.NewWriter(out)
+	_ = writer.Write(headers)
+	for _, row := range rows {
+		_ = writer.Write(row)
+	}
+	writer.Flush()
+}
+
+func renderTSVTable(out io.Writer, headers []string, rows [][]string) {
+	clean := strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+	writeLine := func(cells []string) {
+		escaped := make([]string, len(cells))
+		for i, cell := range cells {
+			escaped[i] = clean.Replace(cell)
+		}
+		fmt.Fprintln(out, strings.Join(escaped, "\t"))
+	}
+	writeLine(headers)
+	for _, row := range rows {
+		writeLine(row)
+	}
+}
+
+func renderMarkdownTable(out io.Writer, headers []string, rows [][]string) {
+	columnCount := len(headers)
+	for _, row := range rows {
+		if len(row) > columnCount {
+			columnCount = len(row)
+		}
+	}
+	escape := strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>")
+	writeLine := func(cells []string) {
+		var b strings.Builder
+		b.WriteString("|")
+		for i := 0; i < columnCount; i++ {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			b.WriteString(" ")
+			b.WriteString(escape.Replace(cell))
+			b.WriteString(" |")
+		}
+		fmt.Fprintln(out, b.String())
+	}
+	writeLine(headers)
+	fmt.Fprintln(out, "|"+strings.Repeat(" --- |", columnCount))
+	for _, row := range rows {
+		writeLine(row)
+	}
+}
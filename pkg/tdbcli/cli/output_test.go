@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRenderTableStyles(t *testing.T) {
+	headers := []string{"NAME", "NOTE"}
+	rows := [][]string{{"users", "a|b"}, {"orders", "x,\"y\"\tz"}}
+
+	cases := map[string]string{
+		tableStyleCSV:      "NAME,NOTE\nusers,a|b\norders,\"x,\"\"y\"\"\tz\"\n",
+		tableStyleTSV:      "NAME\tNOTE\nusers\ta|b\norders\tx,\"y\" z\n",
+		tableStyleMarkdown: "| NAME | NOTE |\n| --- | --- |\n| users | a\\|b |\n| orders | x,\"y\"\tz |\n",
+		tableStylePlain:    "NAME     NOTE\nusers    a|b\norders   x,\"y\"\tz\n",
+	}
+	for style, want := range cases {
+		var buf bytes.Buffer
+		renderTableStyle(&buf, style, headers, rows)
+		if buf.String() != want {
+			t.Fatalf("%s: unexpected output:\n%q\nwant:\n%q", style, buf.String(), want)
+		}
+	}
+}
+
+func TestRenderTableGridDefault(t *testing.T) {
+	var buf bytes.Buffer
+	renderTableStyle(&buf, tableStyleGrid, []string{"A"}, [][]string{{"1"}})
+	want := "┌───┐\n│ A │\n├───┤\n│ 1 │\n└───┘\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected grid output:\n%s", buf.String())
+	}
+}
+
+func TestParseTableStyle(t *testing.T) {
+	if style, err := parseTableStyle(" Markdown "); err != nil || style != tableStyleMarkdown {
+		t.Fatalf("expected markdown, got %q (%v)", style, err)
+	}
+	if style, _ := parseTableStyle(""); style != tableStyleGrid {
+		t.Fatalf("expected grid default, got %q", style)
+	}
+	if _, err := parseTableStyle("html"); err == nil {
+		t.Fatalf("expected error for unsupported style")
+	}
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/progress"
+)
+
+// startProgress begins tracking a long-running operation. Events go to the
+// reporter attached to the command context when an embedder provided one;
+// otherwise a progress bar is drawn on stderr when it is a terminal.
+func startProgress(cmd *cobra.Command, operation string, total int64) *progress.Tracker {
+	if r, ok := progress.FromContext(cmd.Context()); ok {
+		return progress.Start(r, operation, total)
+	}
+	errOut := cmd.ErrOrStderr()
+	if !isTerminalWriter(errOut) {
+		return progress.Start(progress.Nop, operation, total)
+	}
+	return progress.Start(newProgressBar(errOut), operation, total)
+}
+
+func isTerminalWriter(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// progressBar renders progress events as a single redrawn line, mirroring the
+// download bar used by upgrade.
+type progressBar struct {
+	mu         sync.Mutex
+	out        io.Writer
+	barWidth   int
+	lastUpdate time.Time
+	lastLen    int
+}
+
+func newProgressBar(out io.Writer) *progressBar {
+	return &progressBar{out: out, barWidth: 30}
+}
+
+func (b *progressBar) Report(e progress.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch e.Type {
+	case progress.Started:
+		b.render(e)
+	case progress.ItemWritten, progress.PageFetched:
+		if time.Since(b.lastUpdate) >= 100*time.Millisecond || (e.Total > 0 && e.Count == e.Total) {
+			b.render(e)
+		}
+	case progress.Completed:
+		b.render(e)
+		fmt.Fprint(b.out, "\n")
+	}
+}
+
+func (b *progressBar) render(e progress.Event) {
+	b.lastUpdate = time.Now()
+	line := formatProgressLine(e, b.barWidth)
+	if len(line) < b.lastLen {
+		line += strings.Repeat(" ", b.lastLen-len(line))
+	}
+	fmt.Fprintf(b.out, "\r%s", line)
+	b.lastLen = len(line)
+}
+
+func formatProgressLine(e progress.Event, barWidth int) string {
+	if e.Total <= 0 {
+		return fmt.Sprintf("%s %d documents", e.Operation, e.Count)
+	}
+	ratio := float64(e.Count) / float64(e.Total)
+	filled := int(float64(barWidth) * ratio)
+	if filled > barWidth {
+		filled = barWidth
+	}
+	bar := strings.Repeat("#", filled) + strings.Repeat("-", barWidth-filled)
+	return fmt.Sprintf("%s [%s] %6.2f%% (%d/%d)", e.Operation, bar, ratio*100, e.Count, e.Total)
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/progress"
+)
+
+func TestFormatProgressLine(t *testing.T) {
+	cases := []struct {
+		event progress.Event
+		want  string
+	}{
+		{progress.Event{Operation: "sync", Total: 4, Count: 1}, "sync [##--------]  25.00% (1/4)"},
+		{progress.Event{Operation: "export", Count: 7}, "export 7 documents"},
+	}
+	for _, tc := range cases {
+		if got := formatProgressLine(tc.event, 10); got != tc.want {
+			t.Fatalf("formatProgressLine(%+v) = %q, want %q", tc.event, got, tc.want)
+		}
+	}
+}
+
+func TestExportReportsProgressToContextReporter(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	for i := 0; i < 3; i++ {
+		srv.AddDocument("users", map[string]any{"id": fmt.Sprintf("u%d", i)})
+	}
+	var events []progress.Event
+	ctx := progress.WithReporter(context.Background(), progress.Func(func(e progress.Event) {
+		events = append(events, e)
+	}))
+
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+	cmd := newTenantDocumentsExportCommand(env)
+	cmd.SetArgs([]string{"users", "--tenant", "tn_test", "--api-key", clienttest.APIKey, "--page-size", "2"})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	if err := cmd.ExecuteContext(ctx); err != nil {
+		t.Fatalf("export failed: %v\n%s", err, out.String())
+	}
+
+	var types []progress.EventType
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	want := []progress.EventType{
+		progress.Started,
+		progress.PageFetched, progress.ItemWritten, progress.ItemWritten,
+		progress.PageFetched, progress.ItemWritten,
+		progress.Completed,
+	}
+	if fmt.Sprint(types) != fmt.Sprint(want) {
+		t.Fatalf("unexpected event sequence %v", types)
+	}
+	last := events[len(events)-1]
+	if last.Operation != "export" || last.Count != 3 || last.Err != nil {
+		t.Fatalf("unexpected completion event %+v", last)
+	}
+}
+
+func TestExportProgressReportsFailureAfterStream(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddDocument("users", map[string]any{"id": "u1"})
+	var events []progress.Event
+	ctx := progress.WithReporter(context.Background(), progress.Func(func(e progress.Event) {
+		events = append(events, e)
+	}))
+
+	// The manifest's directory is a file, so writing the manifest fails after every document was exported.
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+	cmd := newTenantDocumentsExportCommand(env)
+	cmd.SetArgs([]string{"users", "--tenant", "tn_test", "--api-key", clienttest.APIKey, "--manifest", filepath.Join(blocker, "users.lineage.json")})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SilenceUsage = true
+	err := cmd.ExecuteContext(ctx)
+	if err == nil {
+		t.Fatal("expected the manifest write to fail")
+	}
+	if last := events[len(events)-1]; last.Type != progress.Completed || last.Err == nil {
+		t.Fatalf("expected the completion event to carry the error, got %+v", last)
+	}
+}
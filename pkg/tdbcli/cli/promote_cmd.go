@@ -0,0 +1,354 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+
+	canonicalpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/canonical"
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// promotionAction is a single change planned by tdb promote.
+type promotionAction struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+	Detail string `json:"detail,omitempty"`
+
+	collection *clientpkg.Collection
+	query      *clientpkg.SavedQuery
+}
+
+// promotionProfile is a tenant client resolved from a configured profile reference.
+type promotionProfile struct {
+	label  string
+	appID  string
+	client *clientpkg.TenantClient
+}
+
+func newPromoteCommand(env *Environment) *cobra.Command {
+	var from string
+	var to string
+	var collections string
+	var include string
+	var dryRun bool
+	var yes bool
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "promote --from <profile> --to <profile>",
+		Short: "Promote collections and saved queries between profiles",
+		Long: `Diff collections (schemas and primary keys) and optionally saved queries between
+two configured profiles, show the plan, and apply the differences to the target.
+
+A profile is a tenant stored in the config, referenced by tenant ID or name, with
+an optional key alias: tenant[:key]. The key's app scope, when set, is used for
+both reads and writes. Nothing is ever deleted from the target.`,
+		Example: `  # Review and apply schema changes from staging to production
+  tdb promote --from staging --to production --collections users,orders
+
+  # Include saved queries and skip the confirmation prompt
+  tdb promote --from staging:deploy --to production:deploy --include queries --yes
+
+  # Only show the plan
+  tdb promote --from staging --to production --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(from) == "" || strings.TrimSpace(to) == "" {
+				return errors.New("--from and --to are required")
+			}
+			includeQueries := false
+			for _, item := range splitCommaList(include) {
+				switch strings.ToLower(item) {
+				case "queries":
+					includeQueries = true
+				default:
+					return fmt.Errorf("unsupported --include value %q (supported: queries)", item)
+				}
+			}
+			source, err := resolvePromotionProfile(envCtx, from)
+			if err != nil {
+				return fmt.Errorf("--from: %w", err)
+			}
+			target, err := resolvePromotionProfile(envCtx, to)
+			if err != nil {
+				return fmt.Errorf("--to: %w", err)
+			}
+			if source.label == target.label {
+				return errors.New("--from and --to resolve to the same profile")
+			}
+
+			ctx := cmd.Context()
+			sourceCols, err := source.client.ListCollections(ctx, source.appID)
+			if err != nil {
+				return fmt.Errorf("list %s collections: %w", source.label, err)
+			}
+			targetCols, err := target.client.ListCollections(ctx, target.appID)
+			if err != nil {
+				return fmt.Errorf("list %s collections: %w", target.label, err)
+			}
+			plan, err := planCollectionPromotion(sourceCols, targetCols, splitCommaList(collections))
+			if err != nil {
+				return err
+			}
+			if includeQueries {
+				sourceQueries, err := listPromotionQueries(ctx, source)
+				if err != nil {
+					return err
+				}
+				targetQueries, err := listPromotionQueries(ctx, target)
+				if err != nil {
+					return err
+				}
+				plan = append(plan, planQueryPromotion(sourceQueries, targetQueries)...)
+			}
+
+			pending := 0
+			for _, action := range plan {
+				if action.Action == "create" || action.Action == "update" {
+					pending++
+				}
+			}
+			if raw {
+				if err := printJSON(cmd, plan); err != nil {
+					return err
+				}
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), "Promotion plan: %s → %s\n", source.label, target.label)
+				if len(plan) == 0 {
+					fmt.Fprintln(cmd.OutOrStdout(), "No differences found")
+				} else {
+					rows := make([][]string, 0, len(plan))
+					for _, action := range plan {
+						rows = append(rows, []string{strings.ToUpper(action.Action), action.Kind, action.Name, action.Detail})
+					}
+					renderTable(cmd, []string{"ACTION", "KIND", "NAME", "DETAIL"}, rows)
+				}
+			}
+			if pending == 0 || dryRun {
+				return nil
+			}
+			if !yes {
+				if !promptIsInteractive(cmd) {
+					return errors.New("refusing to apply without confirmation; re-run with --yes")
+				}
+				confirmed := false
+				prompt := &survey.Confirm{Message: fmt.Sprintf("Apply %d change(s) to %s?", pending, target.label)}
+				if err := survey.AskOne(prompt, &confirmed); err != nil {
+					return fmt.Errorf("confirmation cancelled: %w", err)
+				}
+				if !confirmed {
+					fmt.Fprintln(cmd.OutOrStdout(), "Promotion cancelled")
+					return nil
+				}
+			}
+
+			applied, failed := 0, 0
+			for _, action := range plan {
+				if action.Action != "create" && action.Action != "update" {
+					continue
+				}
+				if err := applyPromotionAction(ctx, target, action); err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "✗ %s %s %s: %v\n", action.Action, action.Kind, action.Name, err)
+					failed++
+					continue
+				}
+				applied++
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Applied %d change(s) to %s\n", applied, target.label)
+			if failed > 0 {
+				return fmt.Errorf("%d change(s) failed to apply", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Source profile (tenant ID or name, optionally tenant:key)")
+	cmd.Flags().StringVar(&to, "to", "", "Target profile (tenant ID or name, optionally tenant:key)")
+	cmd.Flags().StringVar(&collections, "collections", "", "Comma-separated collections to promote (defaults to all): "+collectionPatternsUsage)
+	cmd.Flags().StringVar(&include, "include", "", "Additional resources to promote (queries)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the plan without applying it")
+	cmd.Flags().BoolVar(&yes, "yes", false, "Apply without prompting for confirmation")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the plan as JSON")
+	return cmd
+}
+
+// resolvePromotionProfile resolves tenant[:key] against the configured tenants,
+// matching either the tenant ID or its stored name.
+func resolvePromotionProfile(env *Environment, ref string) (promotionProfile, error) {
+	tenantRef, keyAlias, _ := strings.Cut(strings.TrimSpace(ref), ":")
+	tenantRef = strings.TrimSpace(tenantRef)
+	tenantID := ""
+	if _, ok := env.Config.Tenants[tenantRef]; ok {
+		tenantID = tenantRef
+	} else {
+		for id, tc := range env.Config.Tenants {
+			if strings.EqualFold(strings.TrimSpace(tc.Name), tenantRef) {
+				if tenantID != "" {
+					return promotionProfile{}, fmt.Errorf("profile %q matches multiple tenants; use the tenant ID", tenantRef)
+				}
+				tenantID = id
+			}
+		}
+	}
+	if tenantID == "" {
+		return promotionProfile{}, fmt.Errorf("profile %q not found in config", tenantRef)
+	}
+	client, entry, err := tenantClientFromEnv(env, tenantID, strings.TrimSpace(keyAlias), "")
+	if err != nil {
+		return promotionProfile{}, err
+	}
+	label := tenantID
+	if name := strings.TrimSpace(env.Config.Tenants[tenantID].Name); name != "" && name != tenantID {
+		label = fmt.Sprintf("%s (%s)", name, tenantID)
+	}
+	if entry.AppID != "" {
+		label += " app " + entry.AppID
+	}
+	return promotionProfile{label: label, appID: entry.AppID, client: client}, nil
+}
+
+func listPromotionQueries(ctx context.Context, profile promotionProfile) ([]clientpkg.SavedQuery, error) {
+	docs, err := profile.client.ListSavedQueries(ctx, profile.appID)
+	if err != nil {
+		return nil, fmt.Errorf("list %s saved queries: %w", profile.label, err)
+	}
+	queries := make([]clientpkg.SavedQuery, 0, len(docs))
+	for _, doc := range docs {
+		sq, err := parseSavedQueryDocument(doc)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s saved query %s: %w", profile.label, doc.ID, err)
+		}
+		queries = append(queries, sq)
+	}
+	return queries, nil
+}
+
+// planCollectionPromotion compares source and target collections, creating
+// missing ones and updating schemas that differ. Primary key changes cannot be
+// applied to existing collections and are reported as skipped.
+func planCollectionPromotion(source, target []clientpkg.Collection, wanted []string) ([]promotionAction, error) {
+	targetByName := make(map[string]clientpkg.Collection, len(target))
+	for _, col := range target {
+		targetByName[col.Name] = col
+	}
+	selected, err := selectCollections(source, wanted)
+	if err != nil {
+		return nil, fmt.Errorf("source profile: %w", err)
+	}
+	selected = append([]clientpkg.Collection(nil), selected...)
+	sort.SliceStable(selected, func(i, j int) bool { return selected[i].Name < selected[j].Name })
+
+	var plan []promotionAction
+	for i := range selected {
+		col := selected[i]
+		existing, ok := targetByName[col.Name]
+		if !ok {
+			plan = append(plan, promotionAction{Kind: "collection", Name: col.Name, Action: "create", Detail: describePrimaryKey(col), collection: &col})
+			continue
+		}
+		if col.PrimaryKeyField != existing.PrimaryKeyField || col.PrimaryKeyType != existing.PrimaryKeyType {
+			plan = append(plan, promotionAction{Kind: "collection", Name: col.Name, Action: "skip", Detail: fmt.Sprintf("primary key differs (%s vs %s); migrate manually", describePrimaryKey(col), describePrimaryKey(existing))})
+			continue
+		}
+		if !jsonTextEqual(col.SchemaJSON, existing.SchemaJSON) {
+			plan = append(plan, promotionAction{Kind: "collection", Name: col.Name, Action: "update", Detail: "schema changed", collection: &col})
+		}
+	}
+	return plan, nil
+}
+
+// planQueryPromotion creates saved queries missing from the target and replaces
+// those whose definition differs.
+func planQueryPromotion(source, target []clientpkg.SavedQuery) []promotionAction {
+	targetByName := make(map[string]clientpkg.SavedQuery, len(target))
+	for _, sq := range target {
+		targetByName[sq.Name] = sq
+	}
+	sorted := append([]clientpkg.SavedQuery(nil), source...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var plan []promotionAction
+	for i := range sorted {
+		sq := sorted[i]
+		existing, ok := targetByName[sq.Name]
+		if !ok {
+			plan = append(plan, promotionAction{Kind: "query", Name: sq.Name, Action: "create", Detail: sq.Type, query: &sq})
+			continue
+		}
+		left, _ := json.Marshal(sq)
+		right, _ := json.Marshal(existing)
+		if equal, err := canonicalpkg.Equal(left, right); err != nil || !equal {
+			plan = append(plan, promotionAction{Kind: "query", Name: sq.Name, Action: "update", Detail: "definition changed", query: &sq})
+		}
+	}
+	return plan
+}
+
+func applyPromotionAction(ctx context.Context, target promotionProfile, action promotionAction) error {
+	switch {
+	case action.collection != nil && action.Action == "create":
+		col := action.collection
+		req := clientpkg.CreateCollectionRequest{Name: col.Name, Schema: col.SchemaJSON, AppID: target.appID}
+		if strings.TrimSpace(col.PrimaryKeyField) != "" {
+			auto := col.PrimaryKeyAuto
+			req.PrimaryKey = &clientpkg.PrimaryKeySpec{Field: col.PrimaryKeyField, Type: col.PrimaryKeyType, Auto: &auto}
+		}
+		_, err := target.client.CreateCollection(ctx, req)
+		return err
+	case action.collection != nil && action.Action == "update":
+		_, err := target.client.UpdateCollection(ctx, action.collection.Name, target.appID, clientpkg.UpdateCollectionRequest{Schema: action.collection.SchemaJSON})
+		return err
+	case action.query != nil:
+		payload, err := json.Marshal(action.query)
+		if err != nil {
+			return err
+		}
+		_, err = target.client.PutSavedQuery(ctx, action.query.Name, payload, target.appID)
+		return err
+	}
+	return fmt.Errorf("unsupported promotion action %s %s", action.Action, action.Kind)
+}
+
+func describePrimaryKey(col clientpkg.Collection) string {
+	if strings.TrimSpace(col.PrimaryKeyField) == "" {
+		return "pk: default"
+	}
+	return fmt.Sprintf("pk: %s:%s", col.PrimaryKeyField, col.PrimaryKeyType)
+}
+
+// jsonTextEqual compares two JSON documents ignoring formatting and key order,
+// falling back to a trimmed string comparison when either side is not JSON.
+func jsonTextEqual(a, b string) bool {
+	left, right := strings.TrimSpace(a), strings.TrimSpace(b)
+	if left == "" || right == "" {
+		return left == right
+	}
+	equal, err := canonicalpkg.Equal([]byte(left), []byte(right))
+	if err != nil {
+		return left == right
+	}
+	return equal
+}
+
+// promptIsInteractive reports whether stdin is a terminal the user can answer prompts on.
+func promptIsInteractive(cmd *cobra.Command) bool {
+	f, ok := cmd.InOrStdin().(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
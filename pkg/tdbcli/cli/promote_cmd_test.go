@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestPlanCollectionPromotion(t *testing.T) {
+	source := []clientpkg.Collection{
+		{Name: "users", SchemaJSON: `{"type":"object","required":["email"]}`, PrimaryKeyField: "email", PrimaryKeyType: "string"},
+		{Name: "orders", SchemaJSON: `{"type":"object"}`},
+		{Name: "events", SchemaJSON: `{"type":"object"}`, PrimaryKeyField: "id", PrimaryKeyType: "uuid"},
+		{Name: "logs", SchemaJSON: `{"a":1,"b":2}`},
+	}
+	target := []clientpkg.Collection{
+		{Name: "users", SchemaJSON: `{"type":"object"}`, PrimaryKeyField: "email", PrimaryKeyType: "string"},
+		{Name: "events", SchemaJSON: `{"type":"object"}`, PrimaryKeyField: "id", PrimaryKeyType: "int"},
+		{Name: "logs", SchemaJSON: `{ "b": 2, "a": 1 }`},
+	}
+
+	plan, err := planCollectionPromotion(source, target, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := map[string]string{}
+	for _, action := range plan {
+		got[action.Name] = action.Action
+	}
+	want := map[string]string{"users": "update", "orders": "create", "events": "skip"}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+	for name, action := range want {
+		if got[name] != action {
+			t.Fatalf("%s: expected %s, got %s", name, action, got[name])
+		}
+	}
+
+	plan, err = planCollectionPromotion(source, target, []string{"orders"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan) != 1 || plan[0].Name != "orders" || plan[0].collection == nil {
+		t.Fatalf("expected only orders, got %+v", plan)
+	}
+
+	if _, err := planCollectionPromotion(source, target, []string{"missing"}); err == nil {
+		t.Fatalf("expected error for unknown collection")
+	}
+}
+
+func TestPlanQueryPromotion(t *testing.T) {
+	source := []clientpkg.SavedQuery{
+		{Name: "active", Type: "dsl", Collection: "users", DSL: json.RawMessage(`{"where":{"status":"active"}}`)},
+		{Name: "recent", Type: "sql", SQL: "select 1"},
+		{Name: "same", Type: "dsl", Collection: "users", DSL: json.RawMessage(`{"a":1,"b":2}`)},
+	}
+	target := []clientpkg.SavedQuery{
+		{Name: "active", Type: "dsl", Collection: "users", DSL: json.RawMessage(`{"where":{"status":"inactive"}}`)},
+		{Name: "same", Type: "dsl", Collection: "users", DSL: json.RawMessage(`{"b":2,"a":1}`)},
+	}
+
+	plan := planQueryPromotion(source, target)
+	if len(plan) != 2 {
+		t.Fatalf("expected 2 actions, got %+v", plan)
+	}
+	if plan[0].Name != "active" || plan[0].Action != "update" {
+		t.Fatalf("unexpected first action %+v", plan[0])
+	}
+	if plan[1].Name != "recent" || plan[1].Action != "create" {
+		t.Fatalf("unexpected second action %+v", plan[1])
+	}
+}
+
+func TestResolvePromotionProfile(t *testing.T) {
+	env := &Environment{Config: &configpkg.Config{
+		Endpoint: "http://localhost:8080",
+		Tenants: map[string]configpkg.TenantConfig{
+			"tn_stage": {Name: "Staging", DefaultKey: "main", Keys: map[string]configpkg.APIKeyEntry{
+				"main":   {Key: "k1"},
+				"deploy": {Key: "k2", AppID: "app_1"},
+			}},
+			"tn_prod": {Name: "Production", DefaultKey: "main", Keys: map[string]configpkg.APIKeyEntry{"main": {Key: "k3"}}},
+		},
+	}}
+
+	profile, err := resolvePromotionProfile(env, "staging:deploy")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profile.appID != "app_1" || profile.label != "Staging (tn_stage) app app_1" {
+		t.Fatalf("unexpected profile %+v", profile)
+	}
+
+	profile, err = resolvePromotionProfile(env, "tn_prod")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profile.appID != "" || profile.label != "Production (tn_prod)" {
+		t.Fatalf("unexpected profile %+v", profile)
+	}
+
+	if _, err := resolvePromotionProfile(env, "qa"); err == nil {
+		t.Fatalf("expected error for unknown profile")
+	}
+}
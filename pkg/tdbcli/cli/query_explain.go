@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const explainServerFlagUsage = "Ask the server for its execution plan (indexes used, rows scanned, timings) and print it after the results"
+
+// withExplainRequest adds "explain": true to a saved query execution payload,
+// which may be empty.
+func withExplainRequest(payload []byte) ([]byte, error) {
+	body := map[string]any{}
+	if len(strings.TrimSpace(string(payload))) > 0 {
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return nil, fmt.Errorf("--explain-server requires a JSON object payload: %w", err)
+		}
+	}
+	body["explain"] = true
+	return json.Marshal(body)
+}
+
+// renderQueryExplain prints the server's execution plan as an indented tree.
+// Scans that do not use an index are flagged, since they are the usual cause
+// of slow analytics queries.
+func renderQueryExplain(cmd *cobra.Command, explain *clientpkg.QueryExplain) {
+	if explain == nil {
+		fmt.Fprintln(cmd.ErrOrStderr(), "warning: the server did not return an execution plan (explain may not be supported)")
+		return
+	}
+	out := cmd.OutOrStdout()
+	var summary []string
+	if explain.PlanningMS > 0 {
+		summary = append(summary, "planning "+formatPlanDuration(explain.PlanningMS))
+	}
+	if explain.ExecutionMS > 0 {
+		summary = append(summary, "execution "+formatPlanDuration(explain.ExecutionMS))
+	}
+	if len(explain.IndexesUsed) > 0 {
+		summary = append(summary, "indexes "+strings.Join(explain.IndexesUsed, ", "))
+	} else {
+		summary = append(summary, "no indexes used")
+	}
+	fmt.Fprintf(out, "\nQUERY PLAN (%s)\n", strings.Join(summary, "; "))
+	writeQueryPlanNode(out, explain.Plan, "", true)
+}
+
+func writeQueryPlanNode(out io.Writer, node clientpkg.QueryPlanNode, prefix string, last bool) {
+	branch, indent := "├─ ", "│  "
+	if last {
+		branch, indent = "└─ ", "   "
+	}
+	label := node.Operation
+	if label == "" {
+		label = "step"
+	}
+	if node.Index != "" {
+		label += " using " + node.Index
+	} else if strings.Contains(strings.ToLower(node.Operation), "scan") {
+		label += " [no index]"
+	}
+	if node.Detail != "" {
+		label += ": " + node.Detail
+	}
+	fmt.Fprintf(out, "%s%s%s  (scanned %s, returned %s, %s)\n", prefix, branch, label,
+		humanize.Comma(node.RowsScanned), humanize.Comma(node.RowsReturned), formatPlanDuration(node.DurationMS))
+	for i, child := range node.Children {
+		writeQueryPlanNode(out, child, prefix+indent, i == len(node.Children)-1)
+	}
+}
+
+func formatPlanDuration(ms float64) string {
+	if ms >= 1000 {
+		return fmt.Sprintf("%.2fs", ms/1000)
+	}
+	return fmt.Sprintf("%.1fms", ms)
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestDocumentsReportExplainServer(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "orders"})
+	var sent map[string]any
+	handler := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/query" {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&sent)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[{"status":"paid","count":1200}],"pagination":{"limit":10,"total":1},"explain":{
+			"planning_ms":0.4,"execution_ms":1830,"indexes_used":["idx_status"],
+			"plan":{"operation":"group","detail":"status","rows_scanned":48210,"rows_returned":1,"duration_ms":210.5,"children":[
+				{"operation":"index_scan","index":"idx_status","rows_scanned":1200,"rows_returned":1200,"duration_ms":3.2},
+				{"operation":"collection_scan","detail":"amount > 100","rows_scanned":47010,"rows_returned":0,"duration_ms":1616.3}]}}}`))
+	})
+
+	out, _, err := runCommand(t, srv, newTenantDocumentsReportCommand, "orders", "--group-by", "status", "--count", "--explain-server")
+	if err != nil {
+		t.Fatalf("report failed: %v", err)
+	}
+	if sent["explain"] != true {
+		t.Fatalf("expected explain in request body, got %v", sent)
+	}
+	want := `QUERY PLAN (planning 0.4ms; execution 1.83s; indexes idx_status)
+└─ group: status  (scanned 48,210, returned 1, 210.5ms)
+   ├─ index_scan using idx_status  (scanned 1,200, returned 1,200, 3.2ms)
+   └─ collection_scan [no index]: amount > 100  (scanned 47,010, returned 0, 1.62s)
+`
+	if !strings.HasSuffix(out, want) {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+}
+
+func TestQueriesExecuteExplainServer(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users"})
+	srv.AddSavedQuery(clientpkg.SavedQuery{Name: "active", Type: "dsl", Collection: "users"})
+	var sent map[string]any
+	handler := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/execute") {
+			var buf bytes.Buffer
+			_, _ = buf.ReadFrom(r.Body)
+			_ = json.Unmarshal(buf.Bytes(), &sent)
+			r.Body = http.NoBody
+		}
+		handler.ServeHTTP(w, r)
+	})
+
+	_, errOut, err := runCommand(t, srv, newTenantQueriesExecuteCommand, "active", "--by-name", "--params", `{"params":{"role":"admin"}}`, "--explain-server")
+	if err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	if sent["explain"] != true || sent["params"] == nil {
+		t.Fatalf("expected params and explain in request body, got %v", sent)
+	}
+	if !strings.Contains(errOut, "did not return an execution plan") {
+		t.Fatalf("expected a warning when the server sends no plan, got %q", errOut)
+	}
+}
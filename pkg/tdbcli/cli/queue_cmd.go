@@ -0,0 +1,394 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const offlineQueueFile = "queue.jsonl"
+
+// queuedOperation is a mutating document operation recorded while offline.
+type queuedOperation struct {
+	ID         string          `json:"id"`
+	Operation  string          `json:"operation"`
+	TenantID   string          `json:"tenant_id"`
+	KeyAlias   string          `json:"key_alias,omitempty"`
+	AppID      string          `json:"app_id,omitempty"`
+	Collection string          `json:"collection"`
+	DocumentID string          `json:"document_id,omitempty"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+	QueuedAt   time.Time       `json:"queued_at"`
+}
+
+func offlineQueuePath(env *Environment) (string, error) {
+	if env == nil || strings.TrimSpace(env.ConfigPath) == "" {
+		return "", errors.New("config path unknown; cannot locate offline queue")
+	}
+	return filepath.Join(filepath.Dir(env.ConfigPath), offlineQueueFile), nil
+}
+
+func loadOfflineQueue(path string) ([]queuedOperation, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var ops []queuedOperation
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		trimmed := bytes.TrimSpace(scanner.Bytes())
+		if len(trimmed) == 0 {
+			continue
+		}
+		var op queuedOperation
+		if err := json.Unmarshal(trimmed, &op); err != nil {
+			return nil, fmt.Errorf("parse offline queue line %d: %w", line, err)
+		}
+		ops = append(ops, op)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+func writeOfflineQueue(path string, ops []queuedOperation) error {
+	if len(ops) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	var buf bytes.Buffer
+	for _, op := range ops {
+		encoded, err := json.Marshal(op)
+		if err != nil {
+			return err
+		}
+		buf.Write(encoded)
+		buf.WriteByte('\n')
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func appendOfflineQueue(path string, op queuedOperation) error {
+	encoded, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(encoded, '\n'))
+	return err
+}
+
+func newQueueOperationID() string {
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("q%d", time.Now().UnixNano())
+	}
+	return "q_" + hex.EncodeToString(buf)
+}
+
+// enqueueOfflineOperation records a document mutation in the local queue
+// instead of sending it to the API.
+func enqueueOfflineOperation(cmd *cobra.Command, env *Environment, auth *authFlags, operation, collection, documentID string, payload []byte) error {
+	path, err := offlineQueuePath(env)
+	if err != nil {
+		return err
+	}
+	tenantID, err := resolveTenantID(env, auth.tenantID)
+	if err != nil {
+		return err
+	}
+	if tenantReadOnly(env, tenantID) {
+		// The write would only be refused at flush time; refuse it now.
+		return fmt.Errorf("read-only mode: refusing to queue %s on %s: this profile is read-only and cannot modify data (set by --read-only or read_only in the config)", operation, collection)
+	}
+	op := queuedOperation{
+		ID:         newQueueOperationID(),
+		Operation:  operation,
+		TenantID:   tenantID,
+		KeyAlias:   strings.TrimSpace(auth.keyAlias),
+		AppID:      strings.TrimSpace(auth.appID),
+		Collection: collection,
+		DocumentID: documentID,
+		QueuedAt:   time.Now().UTC(),
+	}
+	if len(payload) > 0 {
+		op.Payload = json.RawMessage(payload)
+	}
+	if err := appendOfflineQueue(path, op); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Queued %s on %s (%s); run `tdb queue flush` when back online\n", operation, collection, op.ID)
+	return nil
+}
+
+func newQueueCommand(env *Environment) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "queue",
+		Short: "Inspect and replay operations recorded with --offline",
+	}
+	cmd.AddCommand(newQueueListCommand(env))
+	cmd.AddCommand(newQueueFlushCommand(env))
+	cmd.AddCommand(newQueueClearCommand(env))
+	return cmd
+}
+
+func newQueueListCommand(env *Environment) *cobra.Command {
+	var raw bool
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List queued offline operations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			path, err := offlineQueuePath(envCtx)
+			if err != nil {
+				return err
+			}
+			ops, err := loadOfflineQueue(path)
+			if err != nil {
+				return err
+			}
+			if raw {
+				return printJSON(cmd, ops)
+			}
+			if len(ops) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "Offline queue is empty")
+				return nil
+			}
+			rows := make([][]string, 0, len(ops))
+			for _, op := range ops {
+				rows = append(rows, []string{
+					op.ID,
+					op.Operation,
+					op.TenantID,
+					op.Collection,
+					op.DocumentID,
+					summarizeJSON(string(op.Payload), 40),
+					formatTime(op.QueuedAt),
+				})
+			}
+			renderTable(cmd, []string{"ID", "OPERATION", "TENANT", "COLLECTION", "DOCUMENT", "PAYLOAD", "QUEUED"}, rows)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON output")
+	return cmd
+}
+
+func newQueueClearCommand(env *Environment) *cobra.Command {
+	var confirm bool
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Discard all queued offline operations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			if !confirm {
+				return errors.New("use --confirm to discard queued operations")
+			}
+			path, err := offlineQueuePath(envCtx)
+			if err != nil {
+				return err
+			}
+			if err := writeOfflineQueue(path, nil); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "Offline queue cleared")
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm discarding all queued operations")
+	return cmd
+}
+
+func newQueueFlushCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var force bool
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "flush",
+		Short: "Replay queued offline operations in order",
+		Long: `Replay queued offline operations in the order they were recorded.
+
+Before replaying an update, patch, or delete, the target document is fetched.
+If it was modified on the server after the operation was queued, the flush
+stops with a conflict so the change can be reviewed. Use --force to skip the
+conflict check. Each operation is removed from the queue as soon as it has
+been replayed, so an interrupted flush resumes after it; the failing operation
+and everything after it remain queued. Creates are sent with their queue ID as
+the Idempotency-Key, so a create that is replayed twice is not duplicated.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			path, err := offlineQueuePath(envCtx)
+			if err != nil {
+				return err
+			}
+			ops, err := loadOfflineQueue(path)
+			if err != nil {
+				return err
+			}
+			if len(ops) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "Offline queue is empty")
+				return nil
+			}
+			if !dryRun {
+				var destructive []string
+				for _, op := range ops {
+					if op.Operation == "delete" || op.Operation == "purge" {
+						destructive = append(destructive, fmt.Sprintf("%s: %s %s/%s", op.ID, op.Operation, op.Collection, op.DocumentID))
+					}
+				}
+				if len(destructive) > 0 {
+					if err := confirmDestructive(cmd, envCtx, fmt.Sprintf("replay %d queued delete or purge operations", len(destructive)), destructive...); err != nil {
+						return err
+					}
+				}
+			}
+			clients := map[string]*clientpkg.TenantClient{}
+			replayed := 0
+			for i, op := range ops {
+				cacheKey := op.TenantID + "\x00" + op.KeyAlias
+				tenantClient, ok := clients[cacheKey]
+				if !ok {
+					keyAlias := strings.TrimSpace(auth.keyAlias)
+					if keyAlias == "" {
+						keyAlias = op.KeyAlias
+					}
+					tenantClient, _, err = tenantClientFromEnv(envCtx, op.TenantID, keyAlias, strings.TrimSpace(auth.apiKey))
+					if err != nil {
+						if !dryRun {
+							if saveErr := writeOfflineQueue(path, ops[i:]); saveErr != nil {
+								return saveErr
+							}
+						}
+						return fmt.Errorf("operation %s: %w; %d operations replayed, %d remain queued", op.ID, err, replayed, len(ops)-i)
+					}
+					clients[cacheKey] = tenantClient
+				}
+				if !force {
+					if err := checkQueuedConflict(cmd.Context(), tenantClient, op); err != nil {
+						if saveErr := writeOfflineQueue(path, ops[i:]); saveErr != nil {
+							return saveErr
+						}
+						return fmt.Errorf("operation %s (%s %s/%s): %w; %d operations replayed, %d remain queued", op.ID, op.Operation, op.Collection, op.DocumentID, err, replayed, len(ops)-i)
+					}
+				}
+				if dryRun {
+					fmt.Fprintf(cmd.OutOrStdout(), "Would replay %s %s on %s %s\n", op.ID, op.Operation, op.Collection, op.DocumentID)
+					continue
+				}
+				if err := replayQueuedOperation(clientpkg.WithIdempotencyKey(cmd.Context(), op.ID), tenantClient, op); err != nil {
+					if saveErr := writeOfflineQueue(path, ops[i:]); saveErr != nil {
+						return saveErr
+					}
+					return fmt.Errorf("operation %s (%s %s/%s) failed: %w; %d operations replayed, %d remain queued", op.ID, op.Operation, op.Collection, op.DocumentID, err, replayed, len(ops)-i)
+				}
+				replayed++
+				// Drop the operation right away so a crash or Ctrl-C does not replay it again.
+				if err := writeOfflineQueue(path, ops[i+1:]); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Replayed %s %s on %s %s\n", op.ID, op.Operation, op.Collection, op.DocumentID)
+			}
+			if dryRun {
+				return nil
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Flushed %d queued operations\n", replayed)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&auth.keyAlias, "key", "", "Stored key alias to authenticate with (defaults to the alias recorded with each operation)")
+	cmd.Flags().StringVar(&auth.apiKey, "api-key", "", "Raw API key to authenticate with (overrides stored keys)")
+	cmd.Flags().BoolVar(&force, "force", false, "Replay without checking for conflicting server-side changes")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Check for conflicts and list operations without replaying them")
+	bindSafeModeYes(cmd)
+	return cmd
+}
+
+// checkQueuedConflict reports an error when the target document changed on the
+// server after the operation was queued.
+func checkQueuedConflict(ctx context.Context, tenantClient *clientpkg.TenantClient, op queuedOperation) error {
+	switch op.Operation {
+	case "update", "patch", "delete", "purge":
+	default:
+		return nil
+	}
+	doc, err := tenantClient.GetDocument(ctx, op.Collection, op.DocumentID, op.AppID)
+	if err != nil {
+		if isNotFoundError(err) {
+			if op.Operation == "delete" || op.Operation == "purge" {
+				return nil
+			}
+			return errors.New("conflict: document no longer exists")
+		}
+		return err
+	}
+	if doc.UpdatedAt.After(op.QueuedAt) {
+		return fmt.Errorf("conflict: document modified at %s after operation was queued at %s (use --force to overwrite)", doc.UpdatedAt.Format(time.RFC3339), op.QueuedAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func replayQueuedOperation(ctx context.Context, tenantClient *clientpkg.TenantClient, op queuedOperation) error {
+	var err error
+	switch op.Operation {
+	case "create":
+		_, err = tenantClient.CreateDocument(ctx, op.Collection, op.Payload, op.AppID)
+	case "update":
+		_, err = tenantClient.UpdateDocument(ctx, op.Collection, op.DocumentID, op.Payload, op.AppID)
+	case "patch":
+		_, err = tenantClient.PatchDocument(ctx, op.Collection, op.DocumentID, op.Payload, op.AppID)
+	case "delete":
+		err = tenantClient.DeleteDocument(ctx, op.Collection, op.DocumentID, op.AppID)
+	case "purge":
+		err = tenantClient.PurgeDocument(ctx, op.Collection, op.DocumentID, true, op.AppID)
+	default:
+		err = fmt.Errorf("unsupported queued operation %q", op.Operation)
+	}
+	return err
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestOfflineQueueRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), offlineQueueFile)
+	ops, err := loadOfflineQueue(path)
+	if err != nil {
+		t.Fatalf("loadOfflineQueue on missing file returned error: %v", err)
+	}
+	if len(ops) != 0 {
+		t.Fatalf("expected empty queue, got %d entries", len(ops))
+	}
+	first := queuedOperation{ID: "q_1", Operation: "create", TenantID: "t1", Collection: "users", Payload: json.RawMessage(`{"name":"a"}`), QueuedAt: time.Now().UTC()}
+	second := queuedOperation{ID: "q_2", Operation: "delete", TenantID: "t1", Collection: "users", DocumentID: "doc_1", QueuedAt: time.Now().UTC()}
+	if err := appendOfflineQueue(path, first); err != nil {
+		t.Fatalf("appendOfflineQueue returned error: %v", err)
+	}
+	if err := appendOfflineQueue(path, second); err != nil {
+		t.Fatalf("appendOfflineQueue returned error: %v", err)
+	}
+	ops, err = loadOfflineQueue(path)
+	if err != nil {
+		t.Fatalf("loadOfflineQueue returned error: %v", err)
+	}
+	if len(ops) != 2 || ops[0].ID != "q_1" || ops[1].ID != "q_2" {
+		t.Fatalf("unexpected queue contents: %+v", ops)
+	}
+	if string(ops[0].Payload) != `{"name":"a"}` {
+		t.Fatalf("unexpected payload: %s", ops[0].Payload)
+	}
+	if err := writeOfflineQueue(path, ops[1:]); err != nil {
+		t.Fatalf("writeOfflineQueue returned error: %v", err)
+	}
+	ops, err = loadOfflineQueue(path)
+	if err != nil {
+		t.Fatalf("loadOfflineQueue returned error: %v", err)
+	}
+	if len(ops) != 1 || ops[0].ID != "q_2" {
+		t.Fatalf("expected only q_2 to remain, got %+v", ops)
+	}
+	if err := writeOfflineQueue(path, nil); err != nil {
+		t.Fatalf("writeOfflineQueue(nil) returned error: %v", err)
+	}
+	ops, err = loadOfflineQueue(path)
+	if err != nil || len(ops) != 0 {
+		t.Fatalf("expected queue to be cleared, got %+v (%v)", ops, err)
+	}
+}
+
+func TestQueueFlushSavesProgressAfterEachOperation(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users"})
+	var keys []string
+	routes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			keys = append(keys, r.Header.Get(clientpkg.IdempotencyKeyHeader))
+		}
+		routes.ServeHTTP(w, r)
+	})
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	queuePath := filepath.Join(dir, offlineQueueFile)
+	for _, op := range []queuedOperation{
+		{ID: "q_1", Operation: "create", TenantID: fakeTenantID, Collection: "users", Payload: json.RawMessage(`{"name":"a"}`), QueuedAt: time.Now().UTC()},
+		{ID: "q_2", Operation: "create", TenantID: "tn_nokey", Collection: "users", Payload: json.RawMessage(`{"name":"b"}`), QueuedAt: time.Now().UTC()},
+	} {
+		if err := appendOfflineQueue(queuePath, op); err != nil {
+			t.Fatalf("appendOfflineQueue returned error: %v", err)
+		}
+	}
+
+	cmd := newQueueFlushCommand(&Environment{Config: &configpkg.Config{Endpoint: srv.URL, Tenants: map[string]configpkg.TenantConfig{
+		fakeTenantID: {DefaultKey: "main", Keys: map[string]configpkg.APIKeyEntry{"main": {Key: clienttest.APIKey}}},
+	}}, ConfigPath: configPath})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	cmd.SetArgs(nil)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "operation q_2") || !strings.Contains(err.Error(), "1 operations replayed, 1 remain queued") {
+		t.Fatalf("expected the flush to stop at q_2, got %v", err)
+	}
+	if len(keys) != 1 || keys[0] != "q_1" {
+		t.Fatalf("expected q_1 to be created with its ID as Idempotency-Key, got %v", keys)
+	}
+	ops, err := loadOfflineQueue(queuePath)
+	if err != nil {
+		t.Fatalf("loadOfflineQueue returned error: %v", err)
+	}
+	if len(ops) != 1 || ops[0].ID != "q_2" {
+		t.Fatalf("expected only q_2 to remain queued, got %+v", ops)
+	}
+}
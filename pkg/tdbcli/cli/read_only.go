@@ -0,0 +1,23 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// activeReadOnly is set by the root command from --read-only or the top-level
+// read_only config setting; clients built while it is set refuse mutating
+// requests. A tenant's own read_only setting applies to its clients, to admin
+// commands acting on it and to writes queued with --offline.
+var activeReadOnly bool
+
+// explainReadOnly adds how to lift read-only mode to an error caused by it.
+func explainReadOnly(err error) error {
+	var readOnly *clientpkg.ReadOnlyError
+	if !errors.As(err, &readOnly) {
+		return err
+	}
+	return fmt.Errorf("%w: this profile is read-only and cannot modify data (set by --read-only or read_only in the config)", err)
+}
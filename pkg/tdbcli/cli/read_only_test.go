@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestReadOnlyRefusesWrites(t *testing.T) {
+	defer func() { activeReadOnly = false }()
+	srv := clienttest.NewServer(t)
+	doc := srv.AddDocument("users", map[string]any{"name": "Ann"})
+	dir := t.TempDir()
+	writeConfig := func(extra string) string {
+		path := filepath.Join(dir, "config.yaml")
+		if err := os.WriteFile(path, []byte("endpoint: "+srv.URL+"\n"+extra), 0o600); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+		return path
+	}
+	run := func(configPath string, args ...string) error {
+		root := NewRootCommand()
+		root.SetArgs(append(append([]string{"--config", configPath}, args...), "--tenant", "tn_test", "--api-key", clienttest.APIKey))
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&bytes.Buffer{})
+		return explainReadOnly(root.Execute())
+	}
+
+	readOnlyConfig := writeConfig("read_only: true\n")
+	if err := run(readOnlyConfig, "tenant", "documents", "list", "users"); err != nil {
+		t.Fatalf("expected reads to work in read-only mode, got %v", err)
+	}
+	err := run(readOnlyConfig, "tenant", "documents", "delete", "users", doc.ID)
+	if err == nil || !strings.Contains(err.Error(), "read-only mode: refusing DELETE /api/collections/users/documents/"+doc.ID) || !strings.Contains(err.Error(), "this profile is read-only") {
+		t.Fatalf("expected the delete to be refused, got %v", err)
+	}
+	if len(srv.Documents("users")) != 1 {
+		t.Fatalf("document was deleted despite read-only mode")
+	}
+
+	plainConfig := writeConfig("")
+	if err := run(plainConfig, "tenant", "documents", "create", "users", "--data", `{"name":"Bo"}`, "--read-only"); err == nil || !strings.Contains(err.Error(), "refusing POST") {
+		t.Fatalf("expected --read-only to refuse the create, got %v", err)
+	}
+	if err := run(plainConfig, "tenant", "documents", "create", "users", "--data", `{"name":"Bo"}`); err != nil {
+		t.Fatalf("expected the create to succeed without read-only mode, got %v", err)
+	}
+	if len(srv.Documents("users")) != 2 {
+		t.Fatalf("expected the second document to be created")
+	}
+
+	tenantConfig := writeConfig("tenants:\n  tn_test:\n    read_only: true\n")
+	if err := run(tenantConfig, "tenant", "documents", "create", "users", "--data", `{"name":"Cy"}`); err == nil || !strings.Contains(err.Error(), "refusing POST") {
+		t.Fatalf("expected the tenant's read_only setting to refuse the create, got %v", err)
+	}
+	if err := run(tenantConfig, "tenant", "documents", "create", "users", "--data", `{"name":"Cy"}`, "--offline"); err == nil || !strings.Contains(err.Error(), "refusing to queue create") {
+		t.Fatalf("expected the offline write not to be queued, got %v", err)
+	}
+
+	adminConfig := writeConfig("admin_secret: secret\ntenants:\n  tn_test:\n    read_only: true\n")
+	root := NewRootCommand()
+	root.SetArgs([]string{"--config", adminConfig, "admin", "keys", "create", "--tenant", "tn_test"})
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "refusing POST /admin/tenants/tn_test/keys") {
+		t.Fatalf("expected the tenant's read_only setting to apply to admin commands, got %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const profileSlowestRequests = 5
+
+// requestMonitor watches API calls made during one invocation. It warns on
+// stderr when a call exceeds --warn-latency or --warn-size, and collects the
+// calls for the --profile breakdown.
+type requestMonitor struct {
+	warnLatency time.Duration
+	warnSize    int64
+	profile     bool
+	out         io.Writer
+	started     time.Time
+
+	mu       sync.Mutex
+	requests []clientpkg.RequestMetrics
+}
+
+// activeRequestMonitor is set by the root command; nil means no thresholds and
+// no profiling, so clients are built without an observer.
+var activeRequestMonitor *requestMonitor
+
+func parseWarnLatency(value string) (time.Duration, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return 0, nil
+	}
+	latency, err := time.ParseDuration(trimmed)
+	if err != nil || latency < 0 {
+		return 0, fmt.Errorf("invalid latency %q (use a duration such as 500ms or 2s)", value)
+	}
+	return latency, nil
+}
+
+func parseWarnSize(value string) (int64, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return 0, nil
+	}
+	size, err := humanize.ParseBytes(trimmed)
+	if err != nil || size > math.MaxInt64 {
+		return 0, fmt.Errorf("invalid size %q (use a size such as 512KB or 5MB)", value)
+	}
+	return int64(size), nil
+}
+
+// resolveRequestMonitor combines the root flags with the warn_latency and
+// warn_size config defaults. Flags win; an explicit 0 disables a threshold.
+func resolveRequestMonitor(flagLatency, flagSize string, profile bool, cfgLatency, cfgSize string, out io.Writer) (*requestMonitor, error) {
+	if strings.TrimSpace(flagLatency) == "" {
+		flagLatency = cfgLatency
+	}
+	if strings.TrimSpace(flagSize) == "" {
+		flagSize = cfgSize
+	}
+	latency, err := parseWarnLatency(flagLatency)
+	if err != nil {
+		return nil, fmt.Errorf("--warn-latency: %w", err)
+	}
+	size, err := parseWarnSize(flagSize)
+	if err != nil {
+		return nil, fmt.Errorf("--warn-size: %w", err)
+	}
+	if latency == 0 && size == 0 && !profile {
+		return nil, nil
+	}
+	return &requestMonitor{warnLatency: latency, warnSize: size, profile: profile, out: out, started: time.Now()}, nil
+}
+
+// clientOptions returns the options that attach the active monitor and the
+// read-only guard to a new API client.
+func clientOptions() []clientpkg.Option {
+	var opts []clientpkg.Option
+	if activeReadOnly {
+		opts = append(opts, clientpkg.WithReadOnly())
+	}
+	if len(activeHeaders) > 0 {
+		opts = append(opts, clientpkg.WithHeaders(activeHeaders))
+	}
+	if activeRequestMonitor != nil {
+		opts = append(opts, clientpkg.WithRequestObserver(activeRequestMonitor.observe))
+	}
+	return opts
+}
+
+func (m *requestMonitor) observe(metrics clientpkg.RequestMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.profile {
+		m.requests = append(m.requests, metrics)
+	}
+	if m.warnLatency > 0 && metrics.Duration > m.warnLatency {
+		fmt.Fprintf(m.out, "warning: %s %s took %s (budget %s)\n", metrics.Method, metrics.Path, metrics.Duration.Round(time.Millisecond), m.warnLatency)
+	}
+	if m.warnSize > 0 && metrics.Bytes > m.warnSize {
+		fmt.Fprintf(m.out, "warning: %s %s returned %s (budget %s)\n", metrics.Method, metrics.Path, humanize.Bytes(uint64(metrics.Bytes)), humanize.Bytes(uint64(m.warnSize)))
+	}
+}
+
+// printProfile writes the time breakdown for the finished command. Network
+// time is summed across requests, so with concurrent requests it can exceed
+// the wall-clock total.
+func (m *requestMonitor) printProfile(command string) {
+	if m == nil || !m.profile {
+		return
+	}
+	m.mu.Lock()
+	requests := append([]clientpkg.RequestMetrics(nil), m.requests...)
+	m.mu.Unlock()
+
+	total := time.Since(m.started)
+	var network time.Duration
+	var bytes int64
+	for _, req := range requests {
+		network += req.Duration
+		bytes += req.Bytes
+	}
+	processing := total - network
+	if processing < 0 {
+		processing = 0
+	}
+	fmt.Fprintf(m.out, "\nProfile: %s\n", command)
+	fmt.Fprintf(m.out, "  total       %s\n", total.Round(time.Millisecond))
+	fmt.Fprintf(m.out, "  network     %s (%d requests, %s)\n", network.Round(time.Millisecond), len(requests), humanize.Bytes(uint64(bytes)))
+	fmt.Fprintf(m.out, "  processing  %s\n", processing.Round(time.Millisecond))
+	if len(requests) == 0 {
+		return
+	}
+	sort.SliceStable(requests, func(i, j int) bool { return requests[i].Duration > requests[j].Duration })
+	if len(requests) > profileSlowestRequests {
+		requests = requests[:profileSlowestRequests]
+	}
+	fmt.Fprintln(m.out, "  slowest requests:")
+	for _, req := range requests {
+		status := fmt.Sprint(req.StatusCode)
+		if req.Err != nil {
+			status = "error"
+		}
+		fmt.Fprintf(m.out, "    %-6s %s  %s  %s  %s\n", req.Method, req.Path, status, req.Duration.Round(time.Millisecond), humanize.Bytes(uint64(req.Bytes)))
+	}
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestRequestMonitorWarningsAndProfile(t *testing.T) {
+	if monitor, err := resolveRequestMonitor("", "", false, "", "", &bytes.Buffer{}); err != nil || monitor != nil {
+		t.Fatalf("expected no monitor without thresholds, got %v (%v)", monitor, err)
+	}
+	if _, err := resolveRequestMonitor("fast", "", false, "", "", &bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "--warn-latency") {
+		t.Fatalf("expected invalid latency to be rejected, got %v", err)
+	}
+
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users"})
+	var stderr bytes.Buffer
+	monitor, err := resolveRequestMonitor("", "0", true, "1ns", "8B", &stderr)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if monitor.warnLatency != 1 || monitor.warnSize != 0 {
+		t.Fatalf("expected config latency and flag size override, got %+v", monitor)
+	}
+	activeRequestMonitor = monitor
+	t.Cleanup(func() { activeRequestMonitor = nil })
+
+	tenantClient, err := clientpkg.NewTenantClient(srv.URL, clienttest.APIKey, clientOptions()...)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	if _, err := tenantClient.GetCollection(context.Background(), "users", ""); err != nil {
+		t.Fatalf("get collection: %v", err)
+	}
+	if !strings.Contains(stderr.String(), "warning: GET /api/collections/users took") {
+		t.Fatalf("expected a latency warning, got %q", stderr.String())
+	}
+
+	stderr.Reset()
+	monitor.printProfile("tdb tenant collections get")
+	for _, want := range []string{"Profile: tdb tenant collections get", "network", "(1 requests", "processing", "GET    /api/collections/users  200"} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Fatalf("expected profile to contain %q, got:\n%s", want, stderr.String())
+		}
+	}
+}
@@ -12,10 +12,25 @@ import (
 
 // NewRootCommand constructs the root Cobra command for the TinyDB CLI.
 func NewRootCommand() *cobra.Command {
+	cmd, _ := newRootCommand()
+	return cmd
+}
+
+func newRootCommand() (*cobra.Command, *commandHooks) {
 	env := &Environment{}
+	hooks := &commandHooks{}
 	var configPath string
 	var overrideEndpoint string
 	var overrideAdminSecret string
+	var tableStyle string
+	var timeFormat string
+	var timezone string
+	var lang string
+	var warnLatency string
+	var warnSize string
+	var profile bool
+	var readOnly bool
+	var headers []string
 
 	defaultPath, err := configpkg.DefaultPath()
 	if err == nil {
@@ -29,6 +44,9 @@ func NewRootCommand() *cobra.Command {
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := parseTableStyle(tableStyle); err != nil {
+				return err
+			}
 			path := strings.TrimSpace(configPath)
 			if path == "" {
 				var err error
@@ -52,6 +70,23 @@ func NewRootCommand() *cobra.Command {
 			if secret := strings.TrimSpace(overrideAdminSecret); secret != "" {
 				env.Config.AdminSecret = secret
 			}
+			display, err := resolveTimeDisplay(timeFormat, timezone, cfg.TimeFormat, cfg.Timezone)
+			if err != nil {
+				return err
+			}
+			activeTimeDisplay = display
+			if activeLanguage, err = resolveLanguage(lang, cfg.Language, cmd.ErrOrStderr()); err != nil {
+				return err
+			}
+			monitor, err := resolveRequestMonitor(warnLatency, warnSize, profile, cfg.WarnLatency, cfg.WarnSize, cmd.ErrOrStderr())
+			if err != nil {
+				return err
+			}
+			activeRequestMonitor = monitor
+			activeReadOnly = readOnly || cfg.ReadOnly
+			if activeHeaders, err = resolveExtraHeaders(headers, cfg.ExtraHeaders); err != nil {
+				return err
+			}
 
 			ctx := cmd.Context()
 			if ctx == nil {
@@ -63,6 +98,11 @@ func NewRootCommand() *cobra.Command {
 				root.SetContext(ctx)
 			}
 			scheduleUpgradeNotice(cmd)
+			hooks.hooks = cfg.Hooks
+			if err := runPreHook(cmd, args, hooks.hooks); err != nil {
+				return err
+			}
+			hooks.pending = true
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -76,6 +116,16 @@ func NewRootCommand() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&configPath, "config", configPath, "Path to TinyDB CLI config file")
 	cmd.PersistentFlags().StringVar(&overrideEndpoint, "endpoint", "", "Override TinyDB endpoint for this invocation")
 	cmd.PersistentFlags().StringVar(&overrideAdminSecret, "admin-secret", "", "Override admin secret for this invocation")
+	cmd.PersistentFlags().StringVar(&tableStyle, "table-style", "grid", "Table output style: grid, plain, markdown, csv, or tsv")
+	cmd.PersistentFlags().Bool("sort-keys", false, "Sort object keys alphabetically in JSON output")
+	cmd.PersistentFlags().StringVar(&timeFormat, "time-format", "", "Timestamp display: local, rfc3339, unix, or relative (default from config, else local)")
+	cmd.PersistentFlags().StringVar(&timezone, "timezone", "", "Timezone for timestamps, e.g. UTC or Asia/Phnom_Penh (default from config, else system)")
+	cmd.PersistentFlags().StringVar(&lang, "lang", "", "Language for messages: en, km, or zh (default from config, else en)")
+	cmd.PersistentFlags().StringVar(&warnLatency, "warn-latency", "", "Warn on stderr when an API call takes longer than this, e.g. 2s (default from config)")
+	cmd.PersistentFlags().StringVar(&warnSize, "warn-size", "", "Warn on stderr when an API response is larger than this, e.g. 5MB (default from config)")
+	cmd.PersistentFlags().BoolVar(&profile, "profile", false, "Print a breakdown of time spent in network calls vs. processing after the command")
+	cmd.PersistentFlags().BoolVar(&readOnly, "read-only", false, "Refuse every API call that could modify data (default from config)")
+	cmd.PersistentFlags().StringArrayVar(&headers, "header", nil, "Extra header \"Name: value\" sent with every API request, e.g. a gateway token (repeatable; added to extra_headers from config)")
 
 	cmd.CompletionOptions.DisableDefaultCmd = true
 
@@ -83,17 +133,35 @@ func NewRootCommand() *cobra.Command {
 	registerConfigCommands(cmd, env)
 	registerAdminCommands(cmd, env)
 	registerTenantCommands(cmd, env)
+	registerShortcutCommands(cmd, env)
 	cmd.AddCommand(newCompletionCommand(cmd))
+	cmd.AddCommand(newSpecCommand(cmd))
 	cmd.AddCommand(newUpgradeCommand())
-
-	return cmd
+	cmd.AddCommand(newCapabilitiesCommand(env))
+	cmd.AddCommand(newAuthCommand(env))
+	cmd.AddCommand(newQueueCommand(env))
+	cmd.AddCommand(newPromoteCommand(env))
+	cmd.AddCommand(newRunCommand(env))
+	cmd.AddCommand(newSchemasCommand(env))
+	cmd.AddCommand(newGenerateCommand())
+	cmd.AddCommand(newDevCommand())
+
+	return cmd, hooks
 }
 
 // Execute runs the TinyDB CLI with the provided context.
 func Execute(ctx context.Context) error {
-	root := NewRootCommand()
-	if ctx != nil {
-		return root.ExecuteContext(ctx)
+	root, hooks := newRootCommand()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	executed, err := root.ExecuteContextC(ctx)
+	err = explainReadOnly(err)
+	if executed != nil {
+		activeRequestMonitor.printProfile(executed.CommandPath())
+	}
+	if hooks.pending && executed != nil {
+		runPostHook(executed, executed.Flags().Args(), hooks.hooks, err)
 	}
-	return root.Execute()
+	return err
 }
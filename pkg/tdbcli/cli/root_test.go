@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShortcutCommandsResolve(t *testing.T) {
+	root := NewRootCommand()
+	cases := map[string]string{
+		"docs list":          "tdb docs list",
+		"cols scaffold":      "tdb cols scaffold",
+		"q execute":          "tdb q execute",
+		"tenant docs list":   "tdb tenant documents list",
+		"tenant cols create": "tdb tenant collections create",
+		"tenant q list":      "tdb tenant queries list",
+	}
+	for input, want := range cases {
+		cmd, _, err := root.Find(strings.Fields(input))
+		if err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+		if got := cmd.CommandPath(); got != want {
+			t.Fatalf("%s: expected %q, got %q", input, want, got)
+		}
+	}
+}
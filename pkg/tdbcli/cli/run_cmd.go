@@ -0,0 +1,467 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// runFile is the operations file executed by tdb run.
+type runFile struct {
+	Operations []runOperation `yaml:"operations"`
+}
+
+// runOperation is one step of a run file. Rollback, when set, is executed if a
+// later step fails.
+type runOperation struct {
+	Name       string         `yaml:"name"`
+	Op         string         `yaml:"op"`
+	Collection string         `yaml:"collection"`
+	ID         string         `yaml:"id"`
+	Data       any            `yaml:"data"`
+	Rollback   *runOperation  `yaml:"rollback"`
+	Extra      map[string]any `yaml:",inline"`
+}
+
+// runStepResult records what a step did, for the summary and for ${...}
+// references from later steps.
+type runStepResult struct {
+	Name       string `json:"name"`
+	Op         string `json:"op"`
+	Collection string `json:"collection"`
+	ID         string `json:"id,omitempty"`
+	Status     string `json:"status"`
+	DurationMS int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+	Rollback   string `json:"rollback,omitempty"`
+
+	captured map[string]any
+}
+
+var runReferencePattern = regexp.MustCompile(`\$\{([A-Za-z0-9_-]+)((?:\.[A-Za-z0-9_-]+)+)\}`)
+
+func newRunCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var file string
+	var outputDir string
+	var dryRun bool
+	var asJSON bool
+	stats := newOperationStats()
+
+	cmd := &cobra.Command{
+		Use:   "run -f <ops.yaml>",
+		Short: "Execute a file of document operations in order",
+		Long: `Execute a sequence of document operations from a YAML file, in order, stopping
+at the first failure. Intended for migration scripts that need an imperative
+batch mode.
+
+Each entry under "operations" has a name, an op (create, update, patch, delete,
+or purge), a collection, an id (except for create) and data (create, update,
+patch). Later steps may reference earlier results with ${<name>.<path>}, where
+path is id, key, version, data.<field>..., or before.<field>... for the
+document as it was before an update, patch, delete, or purge. A value that is
+exactly one reference keeps the referenced type, so "data: ${step.before.data}"
+restores a whole payload.
+
+An entry may define a rollback operation. When a step fails, the rollbacks of
+the steps that already succeeded run in reverse order. Rollbacks are best
+effort: the server has no transactions, so a failing rollback is reported and
+the remaining rollbacks still run.
+
+In safe mode, a file with delete or purge operations (rollbacks included)
+lists them and asks for confirmation once before anything runs; pass --yes
+to skip the prompt.`,
+		Example: `  # ops.yaml
+  operations:
+    - name: ada
+      op: create
+      collection: users
+      data: {name: Ada, role: viewer}
+      rollback: {op: purge, collection: users, id: "${ada.id}"}
+    - name: promote
+      op: patch
+      collection: users
+      id: "${ada.id}"
+      data: {role: admin}
+      rollback: {op: update, collection: users, id: "${ada.id}", data: "${promote.before.data}"}
+
+  tdb run -f ops.yaml --output-dir run-output/`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(file) == "" {
+				return errors.New("--file is required")
+			}
+			ops, err := loadRunFile(file)
+			if err != nil {
+				return err
+			}
+			if dryRun {
+				rows := make([][]string, 0, len(ops))
+				for i, op := range ops {
+					rows = append(rows, []string{strconv.Itoa(i + 1), op.Name, op.Op, op.Collection, op.ID, strconv.FormatBool(op.Rollback != nil)})
+				}
+				renderTable(cmd, []string{"#", "NAME", "OP", "COLLECTION", "ID", "ROLLBACK"}, rows)
+				return nil
+			}
+			if destructive := runDestructiveSteps(ops); len(destructive) > 0 {
+				if err := confirmDestructive(cmd, envCtx, fmt.Sprintf("run %d delete or purge operations from %s", len(destructive), file), destructive...); err != nil {
+					return err
+				}
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			if outputDir != "" {
+				if err := os.MkdirAll(outputDir, 0o755); err != nil {
+					return err
+				}
+			}
+
+			results := make([]*runStepResult, len(ops))
+			captured := map[string]map[string]any{}
+			var runErr error
+			for i, op := range ops {
+				result := &runStepResult{Name: op.Name, Op: op.Op, Collection: op.Collection, Status: "skipped"}
+				results[i] = result
+				if runErr != nil {
+					continue
+				}
+				started := time.Now()
+				err := executeRunOperation(cmd.Context(), tenantClient, auth.appID, op, captured, result)
+				result.DurationMS = time.Since(started).Milliseconds()
+				if err != nil {
+					result.Status = "failed"
+					result.Error = err.Error()
+					runErr = fmt.Errorf("operation %d (%s) failed: %w", i+1, op.Name, err)
+					stats.addError(runErr.Error())
+					continue
+				}
+				result.Status = "ok"
+				captured[op.Name] = result.captured
+				if outputDir != "" {
+					if err := writeRunCapture(outputDir, i+1, op.Name, result.captured); err != nil {
+						return err
+					}
+				}
+			}
+
+			rolledBack := 0
+			if runErr != nil {
+				for i := len(ops) - 1; i >= 0; i-- {
+					if results[i].Status != "ok" || ops[i].Rollback == nil {
+						continue
+					}
+					rollback := *ops[i].Rollback
+					rollback.Name = ops[i].Name + ":rollback"
+					scratch := &runStepResult{}
+					if err := executeRunOperation(cmd.Context(), tenantClient, auth.appID, rollback, captured, scratch); err != nil {
+						results[i].Rollback = "failed: " + err.Error()
+						stats.addError(fmt.Sprintf("rollback of %s failed: %v", ops[i].Name, err))
+						continue
+					}
+					results[i].Rollback = "ok"
+					rolledBack++
+				}
+			}
+
+			succeeded := 0
+			for _, result := range results {
+				if result.Status == "ok" {
+					succeeded++
+				}
+			}
+			stats.count("operations", len(ops))
+			stats.count("succeeded", succeeded)
+			stats.count("rolled_back", rolledBack)
+
+			if asJSON {
+				if err := printJSON(cmd, results); err != nil {
+					return err
+				}
+			} else {
+				rows := make([][]string, 0, len(results))
+				for i, result := range results {
+					status := result.Status
+					if result.Error != "" {
+						status += ": " + result.Error
+					}
+					rows = append(rows, []string{strconv.Itoa(i + 1), result.Name, result.Op, result.Collection, result.ID, status, result.Rollback, fmt.Sprintf("%dms", result.DurationMS)})
+				}
+				renderTable(cmd, []string{"#", "NAME", "OP", "COLLECTION", "ID", "STATUS", "ROLLBACK", "DURATION"}, rows)
+				fmt.Fprintf(cmd.OutOrStdout(), "%d of %d operations succeeded", succeeded, len(ops))
+				if runErr != nil {
+					fmt.Fprintf(cmd.OutOrStdout(), "; %d rolled back", rolledBack)
+				}
+				fmt.Fprintln(cmd.OutOrStdout())
+			}
+			return runErr
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().StringVarP(&file, "file", "f", "", "YAML file listing the operations to run")
+	cmd.Flags().StringVar(&outputDir, "output-dir", "", "Write each operation's resulting document to <dir>/<NN>-<name>.json")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate the file and list the operations without running them")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the summary as JSON")
+	bindSafeModeYes(cmd)
+	bindStatsJSON(cmd, stats)
+	return cmd
+}
+
+// loadRunFile reads and validates an operations file.
+func loadRunFile(path string) ([]runOperation, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var parsed runFile
+	if err := yaml.Unmarshal(raw, &parsed); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(parsed.Operations) == 0 {
+		return nil, fmt.Errorf("%s: no operations defined", path)
+	}
+	seen := map[string]bool{}
+	for i := range parsed.Operations {
+		op := &parsed.Operations[i]
+		if strings.TrimSpace(op.Name) == "" {
+			op.Name = fmt.Sprintf("op%d", i+1)
+		}
+		if seen[op.Name] {
+			return nil, fmt.Errorf("%s: duplicate operation name %q", path, op.Name)
+		}
+		seen[op.Name] = true
+		if err := validateRunOperation(*op); err != nil {
+			return nil, fmt.Errorf("%s: operation %d (%s): %w", path, i+1, op.Name, err)
+		}
+		if op.Rollback != nil {
+			if err := validateRunOperation(*op.Rollback); err != nil {
+				return nil, fmt.Errorf("%s: operation %d (%s) rollback: %w", path, i+1, op.Name, err)
+			}
+		}
+	}
+	return parsed.Operations, nil
+}
+
+// runDestructiveSteps describes the delete and purge operations in ops,
+// including rollbacks, for the safe mode confirmation.
+func runDestructiveSteps(ops []runOperation) []string {
+	var steps []string
+	describe := func(label string, op runOperation) {
+		if op.Op == "delete" || op.Op == "purge" {
+			steps = append(steps, fmt.Sprintf("%s: %s %s/%s", label, op.Op, op.Collection, op.ID))
+		}
+	}
+	for i, op := range ops {
+		describe(fmt.Sprintf("%d (%s)", i+1, op.Name), op)
+		if op.Rollback != nil {
+			describe(fmt.Sprintf("%d (%s) rollback", i+1, op.Name), *op.Rollback)
+		}
+	}
+	return steps
+}
+
+func validateRunOperation(op runOperation) error {
+	for key := range op.Extra {
+		return fmt.Errorf("unknown field %q", key)
+	}
+	if strings.TrimSpace(op.Collection) == "" {
+		return errors.New("collection is required")
+	}
+	switch op.Op {
+	case "create":
+		if op.Data == nil {
+			return errors.New("data is required for create")
+		}
+	case "update", "patch":
+		if op.Data == nil {
+			return fmt.Errorf("data is required for %s", op.Op)
+		}
+		fallthrough
+	case "delete", "purge":
+		if strings.TrimSpace(op.ID) == "" {
+			return fmt.Errorf("id is required for %s", op.Op)
+		}
+	default:
+		return fmt.Errorf("unsupported op %q (supported: create, update, patch, delete, purge)", op.Op)
+	}
+	return nil
+}
+
+// executeRunOperation resolves references in op and performs it, recording the
+// resulting document (and the previous one, when there was one) in result.
+func executeRunOperation(ctx context.Context, tenantClient *clientpkg.TenantClient, appID string, op runOperation, captured map[string]map[string]any, result *runStepResult) error {
+	collection, err := resolveRunReferences(op.Collection, captured)
+	if err != nil {
+		return err
+	}
+	idValue, err := resolveRunReferences(op.ID, captured)
+	if err != nil {
+		return err
+	}
+	id := fmt.Sprint(idValue)
+	if op.ID == "" {
+		id = ""
+	}
+	collectionName := fmt.Sprint(collection)
+	result.ID = id
+	result.captured = map[string]any{"id": id}
+
+	var payload []byte
+	if op.Data != nil {
+		data, err := resolveRunReferences(op.Data, captured)
+		if err != nil {
+			return err
+		}
+		if payload, err = json.Marshal(data); err != nil {
+			return fmt.Errorf("encode data: %w", err)
+		}
+	}
+
+	if op.Op != "create" {
+		before, err := tenantClient.GetDocument(ctx, collectionName, id, appID)
+		if err != nil {
+			return err
+		}
+		result.captured["before"] = runDocumentFields(before)
+	}
+
+	var doc *clientpkg.Document
+	switch op.Op {
+	case "create":
+		doc, err = tenantClient.CreateDocument(ctx, collectionName, payload, appID)
+	case "update":
+		doc, err = tenantClient.UpdateDocument(ctx, collectionName, id, payload, appID)
+	case "patch":
+		doc, err = tenantClient.PatchDocument(ctx, collectionName, id, payload, appID)
+	case "delete":
+		err = tenantClient.DeleteDocument(ctx, collectionName, id, appID)
+	case "purge":
+		err = tenantClient.PurgeDocument(ctx, collectionName, id, true, appID)
+	}
+	if err != nil {
+		return err
+	}
+	if doc != nil {
+		before := result.captured["before"]
+		result.captured = runDocumentFields(doc)
+		if before != nil {
+			result.captured["before"] = before
+		}
+		result.ID = doc.ID
+	}
+	return nil
+}
+
+// runDocumentFields exposes a document to ${...} references.
+func runDocumentFields(doc *clientpkg.Document) map[string]any {
+	fields := map[string]any{
+		"id":            doc.ID,
+		"key":           doc.Key,
+		"version":       doc.Version,
+		"collection_id": doc.CollectionID,
+	}
+	var data any
+	if err := json.Unmarshal([]byte(doc.Data), &data); err == nil {
+		fields["data"] = data
+	}
+	return fields
+}
+
+// resolveRunReferences replaces ${name.path} references in strings, walking
+// maps and lists. A string that is exactly one reference takes the referenced
+// value's type.
+func resolveRunReferences(value any, captured map[string]map[string]any) (any, error) {
+	switch typed := value.(type) {
+	case string:
+		if match := runReferencePattern.FindStringSubmatch(typed); match != nil && match[0] == typed {
+			return lookupRunReference(match[1], match[2], captured)
+		}
+		var lookupErr error
+		out := runReferencePattern.ReplaceAllStringFunc(typed, func(ref string) string {
+			match := runReferencePattern.FindStringSubmatch(ref)
+			resolved, err := lookupRunReference(match[1], match[2], captured)
+			if err != nil {
+				lookupErr = err
+				return ref
+			}
+			if s, ok := resolved.(string); ok {
+				return s
+			}
+			encoded, _ := json.Marshal(resolved)
+			return string(encoded)
+		})
+		return out, lookupErr
+	case map[string]any:
+		out := make(map[string]any, len(typed))
+		for key, item := range typed {
+			resolved, err := resolveRunReferences(item, captured)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = resolved
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(typed))
+		for i, item := range typed {
+			resolved, err := resolveRunReferences(item, captured)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = resolved
+		}
+		return out, nil
+	default:
+		return value, nil
+	}
+}
+
+func lookupRunReference(name, path string, captured map[string]map[string]any) (any, error) {
+	fields, ok := captured[name]
+	if !ok {
+		return nil, fmt.Errorf("reference ${%s%s}: no completed operation named %q", name, path, name)
+	}
+	var current any = fields
+	for _, part := range strings.Split(strings.TrimPrefix(path, "."), ".") {
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("reference ${%s%s}: %s is not an object", name, path, part)
+		}
+		if current, ok = object[part]; !ok {
+			return nil, fmt.Errorf("reference ${%s%s}: field %s not found", name, path, part)
+		}
+	}
+	return current, nil
+}
+
+func writeRunCapture(dir string, index int, name string, captured map[string]any) error {
+	encoded, err := json.MarshalIndent(captured, "", "  ")
+	if err != nil {
+		return err
+	}
+	safe := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' {
+			return '_'
+		}
+		return r
+	}, name)
+	return os.WriteFile(filepath.Join(dir, fmt.Sprintf("%02d-%s.json", index, safe)), append(encoded, '\n'), 0o644)
+}
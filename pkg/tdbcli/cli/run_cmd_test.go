@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestRunOperationsFile(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	existing := srv.AddDocument("users", map[string]any{"name": "Grace", "role": "viewer"})
+	dir := t.TempDir()
+
+	run := func(ops string, extra ...string) (string, error) {
+		t.Helper()
+		path := filepath.Join(dir, "ops.yaml")
+		if err := os.WriteFile(path, []byte(ops), 0o600); err != nil {
+			t.Fatalf("write ops: %v", err)
+		}
+		out, _, err := runCommand(t, srv, newRunCommand, append([]string{"-f", path}, extra...)...)
+		return out, err
+	}
+
+	outputDir := filepath.Join(dir, "out")
+	out, err := run(`
+operations:
+  - name: ada
+    op: create
+    collection: users
+    data: {name: Ada, role: viewer}
+  - name: promote
+    op: patch
+    collection: users
+    id: "${ada.id}"
+    data: {role: admin, promoted_from: "${ada.data.role}"}
+`, "--output-dir", outputDir)
+	if err != nil {
+		t.Fatalf("run failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "2 of 2 operations succeeded") {
+		t.Fatalf("unexpected summary:\n%s", out)
+	}
+	captured, err := os.ReadFile(filepath.Join(outputDir, "02-promote.json"))
+	if err != nil {
+		t.Fatalf("read capture: %v", err)
+	}
+	if !strings.Contains(string(captured), `"promoted_from": "viewer"`) || !strings.Contains(string(captured), `"before"`) {
+		t.Fatalf("unexpected capture %s", captured)
+	}
+
+	out, err = run(`
+operations:
+  - name: bob
+    op: create
+    collection: users
+    data: {name: Bob}
+    rollback: {op: purge, collection: users, id: "${bob.id}"}
+  - name: grace
+    op: patch
+    collection: users
+    id: ` + existing.ID + `
+    data: {role: admin}
+    rollback: {op: update, collection: users, id: "${grace.id}", data: "${grace.before.data}"}
+  - name: missing
+    op: delete
+    collection: users
+    id: does-not-exist
+  - name: never
+    op: create
+    collection: users
+    data: {name: Never}
+`)
+	if err == nil || !strings.Contains(err.Error(), "operation 3 (missing) failed") {
+		t.Fatalf("expected the third operation to fail, got %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "2 of 4 operations succeeded; 2 rolled back") || !strings.Contains(out, "skipped") {
+		t.Fatalf("unexpected summary:\n%s", out)
+	}
+	for _, doc := range srv.Documents("users") {
+		if strings.Contains(doc.Data, "Bob") || strings.Contains(doc.Data, "Never") {
+			t.Fatalf("expected rolled back and skipped documents to be absent, found %s", doc.Data)
+		}
+		if doc.ID == existing.ID && doc.Data != `{"name":"Grace","role":"viewer"}` {
+			t.Fatalf("expected grace to be restored, got %s", doc.Data)
+		}
+	}
+
+	if _, err := run("operations:\n  - op: patch\n    collection: users\n    data: {a: 1}\n"); err == nil || !strings.Contains(err.Error(), "id is required for patch") {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+const safeModeYesFlagUsage = "Skip the safe mode confirmation prompt"
+
+// bindSafeModeYes adds the --yes flag read by confirmDestructive.
+func bindSafeModeYes(cmd *cobra.Command) {
+	cmd.Flags().Bool("yes", false, safeModeYesFlagUsage)
+}
+
+// confirmDestructive guards destructive commands when safe_mode is enabled in
+// the config. It prints what is about to be affected and asks the user to
+// confirm; without a terminal to prompt on, the command is refused unless
+// --yes was passed. When safe mode is off it does nothing, so the command's
+// own --confirm/--force checks remain the only gate.
+func confirmDestructive(cmd *cobra.Command, envCtx *Environment, action string, details ...string) error {
+	if envCtx == nil || envCtx.Config == nil || !envCtx.Config.SafeMode {
+		return nil
+	}
+	if yes, _ := cmd.Flags().GetBool("yes"); yes {
+		return nil
+	}
+	errOut := cmd.ErrOrStderr()
+	fmt.Fprintf(errOut, "Safe mode: about to %s\n", action)
+	for _, detail := range details {
+		fmt.Fprintf(errOut, "  %s\n", detail)
+	}
+	if !promptIsInteractive(cmd) {
+		return errors.New("safe mode is enabled; re-run with --yes to confirm")
+	}
+	confirmed := false
+	prompt := &survey.Confirm{Message: "Continue?"}
+	if err := survey.AskOne(prompt, &confirmed); err != nil {
+		return fmt.Errorf("confirmation cancelled: %w", err)
+	}
+	if !confirmed {
+		return errors.New("cancelled")
+	}
+	return nil
+}
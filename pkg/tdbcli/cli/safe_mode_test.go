@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestSafeModeGuardsCollectionDelete(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddDocument("logs", map[string]any{"n": 1})
+	srv.AddDocument("logs", map[string]any{"n": 2})
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL, SafeMode: true}}
+
+	run := func(args ...string) (string, error) {
+		_, errOut, err := runCommandWithEnv(t, env, newTenantCollectionsDeleteCommand, append([]string{"logs"}, args...)...)
+		return errOut, err
+	}
+
+	errOut, err := run()
+	if err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("expected safe mode to refuse without a prompt, got %v", err)
+	}
+	if !strings.Contains(errOut, "delete collection logs") || !strings.Contains(errOut, "Documents: 2") {
+		t.Fatalf("expected a summary of what would be deleted, got %q", errOut)
+	}
+	if _, err := srv.TenantClient(t).GetCollection(context.Background(), "logs", ""); err != nil {
+		t.Fatalf("collection was deleted despite safe mode: %v", err)
+	}
+	if _, err := run("--yes"); err != nil {
+		t.Fatalf("expected --yes to bypass the prompt, got %v", err)
+	}
+
+	env.Config.SafeMode = false
+	srv.AddDocument("other", map[string]any{"n": 1})
+	if _, _, err := runCommandWithEnv(t, env, newTenantCollectionsDeleteCommand, "other"); err != nil {
+		t.Fatalf("expected no prompt with safe mode off, got %v", err)
+	}
+}
+
+func TestSafeModeGuardsRunDeletes(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	doc := srv.AddDocument("users", map[string]any{"name": "Grace"})
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL, SafeMode: true}}
+	path := filepath.Join(t.TempDir(), "ops.yaml")
+	ops := "operations:\n  - name: drop\n    op: delete\n    collection: users\n    id: " + doc.ID + "\n"
+	if err := os.WriteFile(path, []byte(ops), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, errOut, err := runCommandWithEnv(t, env, newRunCommand, "-f", path)
+	if err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("expected safe mode to refuse without a prompt, got %v", err)
+	}
+	if !strings.Contains(errOut, "run 1 delete or purge operations") || !strings.Contains(errOut, "1 (drop): delete users/"+doc.ID) {
+		t.Fatalf("expected the delete step to be listed, got %q", errOut)
+	}
+	if docs := srv.Documents("users"); len(docs) != 1 || docs[0].DeletedAt != nil {
+		t.Fatalf("document was deleted despite safe mode: %+v", docs)
+	}
+	if _, _, err := runCommandWithEnv(t, env, newRunCommand, "-f", path, "--yes"); err != nil {
+		t.Fatalf("expected --yes to bypass the prompt, got %v", err)
+	}
+}
+
+func TestSafeModeGuardsSavedQueryDelete(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	query := srv.AddSavedQuery(clientpkg.SavedQuery{Name: "active", Collection: "users"})
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL, SafeMode: true}}
+
+	_, errOut, err := runCommandWithEnv(t, env, newTenantQueriesDeleteCommand, query.ID, "--purge", "--confirm")
+	if err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("expected safe mode to refuse the purge without a prompt, got %v", err)
+	}
+	if !strings.Contains(errOut, "permanently purge saved query "+query.ID) {
+		t.Fatalf("expected the purge to be described, got %q", errOut)
+	}
+	if _, _, err := runCommandWithEnv(t, env, newTenantQueriesDeleteCommand, query.ID); err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("expected safe mode to refuse the delete without a prompt, got %v", err)
+	}
+	if docs := srv.Documents(clienttest.SavedQueriesCollection); len(docs) != 1 || docs[0].DeletedAt != nil {
+		t.Fatalf("saved query was deleted despite safe mode: %+v", docs)
+	}
+	if _, _, err := runCommandWithEnv(t, env, newTenantQueriesDeleteCommand, query.ID, "--yes"); err != nil {
+		t.Fatalf("expected --yes to bypass the prompt, got %v", err)
+	}
+	if docs := srv.Documents(clienttest.SavedQueriesCollection); len(docs) != 1 || docs[0].DeletedAt == nil {
+		t.Fatalf("expected the saved query to be deleted, got %+v", docs)
+	}
+}
+
+func TestSafeModeGuardsKeyRevocation(t *testing.T) {
+	keys := []clientpkg.APIKey{{Prefix: "tdb_old", TenantID: "tn_1", Scope: "tenant", CreatedAt: time.Now().UTC().AddDate(-1, 0, 0)}}
+	var revoked []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/tenants/tn_1/keys":
+			_ = json.NewEncoder(w).Encode(keys)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/admin/keys/"):
+			revoked = append(revoked, strings.TrimPrefix(r.URL.Path, "/admin/keys/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL, AdminSecret: "secret", SafeMode: true}}
+	run := func(newCmd func(*Environment) *cobra.Command, args ...string) (string, error) {
+		cmd := newCmd(env)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetArgs(args)
+		cmd.SetIn(&bytes.Buffer{})
+		var errOut bytes.Buffer
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&errOut)
+		err := cmd.Execute()
+		return errOut.String(), err
+	}
+
+	errOut, err := run(newAdminKeyRevokeCommand, "tdb_old")
+	if err == nil || !strings.Contains(err.Error(), "--yes") || !strings.Contains(errOut, "revoke API key tdb_old") {
+		t.Fatalf("expected safe mode to refuse the revoke, got %v (%q)", err, errOut)
+	}
+	errOut, err = run(newAdminKeyRevokeManyCommand, "--tenant", "tn_1", "--older-than", "180d", "--confirm")
+	if err == nil || !strings.Contains(err.Error(), "--yes") || !strings.Contains(errOut, "revoke 1 API keys of tenant tn_1") || !strings.Contains(errOut, "tdb_old") {
+		t.Fatalf("expected safe mode to refuse revoke-many, got %v (%q)", err, errOut)
+	}
+	if len(revoked) != 0 {
+		t.Fatalf("keys were revoked despite safe mode: %v", revoked)
+	}
+
+	if _, err := run(newAdminKeyRevokeCommand, "tdb_old", "--yes"); err != nil {
+		t.Fatalf("expected --yes to bypass the prompt, got %v", err)
+	}
+	if _, err := run(newAdminKeyRevokeManyCommand, "--tenant", "tn_1", "--older-than", "180d", "--confirm", "--yes"); err != nil {
+		t.Fatalf("expected --yes to bypass the prompt, got %v", err)
+	}
+	if len(revoked) != 2 {
+		t.Fatalf("expected both commands to revoke the key, got %v", revoked)
+	}
+}
+
+func TestSafeModeGuardsQueuedDeletes(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	doc := srv.AddDocument("users", map[string]any{"name": "Grace"})
+	dir := t.TempDir()
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL, SafeMode: true}, ConfigPath: filepath.Join(dir, "config.yaml")}
+	queuePath := filepath.Join(dir, offlineQueueFile)
+	op := queuedOperation{ID: "q_1", Operation: "delete", TenantID: fakeTenantID, Collection: "users", DocumentID: doc.ID, QueuedAt: time.Now().UTC()}
+	if err := appendOfflineQueue(queuePath, op); err != nil {
+		t.Fatalf("appendOfflineQueue returned error: %v", err)
+	}
+	run := func(args ...string) (string, error) {
+		cmd := newQueueFlushCommand(env)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetArgs(append([]string{"--api-key", clienttest.APIKey}, args...))
+		cmd.SetIn(&bytes.Buffer{})
+		var errOut bytes.Buffer
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&errOut)
+		err := cmd.Execute()
+		return errOut.String(), err
+	}
+
+	errOut, err := run()
+	if err == nil || !strings.Contains(err.Error(), "--yes") || !strings.Contains(errOut, "q_1: delete users/"+doc.ID) {
+		t.Fatalf("expected safe mode to refuse the queued delete, got %v (%q)", err, errOut)
+	}
+	if docs := srv.Documents("users"); len(docs) != 1 || docs[0].DeletedAt != nil {
+		t.Fatalf("document was deleted despite safe mode: %+v", docs)
+	}
+	if ops, err := loadOfflineQueue(queuePath); err != nil || len(ops) != 1 {
+		t.Fatalf("expected the operation to stay queued, got %+v (%v)", ops, err)
+	}
+	if _, err := run("--yes"); err != nil {
+		t.Fatalf("expected --yes to bypass the prompt, got %v", err)
+	}
+	if docs := srv.Documents("users"); len(docs) != 1 || docs[0].DeletedAt == nil {
+		t.Fatalf("expected the queued delete to be replayed, got %+v", docs)
+	}
+}
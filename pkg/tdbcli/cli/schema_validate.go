@@ -0,0 +1,297 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// schemaViolation describes a single JSON Schema validation failure.
+type schemaViolation struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (v schemaViolation) String() string {
+	return fmt.Sprintf("%s: %s", v.Path, v.Message)
+}
+
+// jsonSchemaValidator validates decoded JSON values against the subset of JSON
+// Schema used by TinyDB collections: type, enum, const, required, properties,
+// additionalProperties, items, min/max (length, items, value), pattern and the
+// anyOf/oneOf/allOf combinators.
+type jsonSchemaValidator struct {
+	root     map[string]any
+	patterns map[string]*regexp.Regexp
+}
+
+func newJSONSchemaValidator(schemaJSON string) (*jsonSchemaValidator, error) {
+	trimmed := strings.TrimSpace(schemaJSON)
+	if trimmed == "" {
+		return nil, fmt.Errorf("collection has no schema")
+	}
+	var root map[string]any
+	if err := json.Unmarshal([]byte(trimmed), &root); err != nil {
+		return nil, fmt.Errorf("parse schema: %w", err)
+	}
+	return &jsonSchemaValidator{root: root, patterns: map[string]*regexp.Regexp{}}, nil
+}
+
+// Validate returns every violation found in value, sorted by path.
+func (v *jsonSchemaValidator) Validate(value any) []schemaViolation {
+	var out []schemaViolation
+	v.validate(v.root, value, "$", &out)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out
+}
+
+func (v *jsonSchemaValidator) validate(schema map[string]any, value any, path string, out *[]schemaViolation) {
+	if schema == nil {
+		return
+	}
+	add := func(format string, args ...any) {
+		*out = append(*out, schemaViolation{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if rawType, ok := schema["type"]; ok {
+		if !matchesSchemaType(rawType, value) {
+			add("expected type %s, got %s", describeSchemaType(rawType), jsonTypeName(value))
+			return
+		}
+	}
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, candidate := range enum {
+			if jsonValuesEqual(candidate, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			add("value %s is not one of the allowed enum values", compactJSON(value))
+		}
+	}
+	if constant, ok := schema["const"]; ok && !jsonValuesEqual(constant, value) {
+		add("value must equal %s", compactJSON(constant))
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		v.validateObject(schema, typed, path, out)
+	case []any:
+		if minItems, ok := schemaNumber(schema, "minItems"); ok && float64(len(typed)) < minItems {
+			add("expected at least %v items, got %d", minItems, len(typed))
+		}
+		if maxItems, ok := schemaNumber(schema, "maxItems"); ok && float64(len(typed)) > maxItems {
+			add("expected at most %v items, got %d", maxItems, len(typed))
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range typed {
+				v.validate(items, item, fmt.Sprintf("%s[%d]", path, i), out)
+			}
+		}
+	case string:
+		length := float64(len([]rune(typed)))
+		if minLength, ok := schemaNumber(schema, "minLength"); ok && length < minLength {
+			add("expected length >= %v, got %v", minLength, length)
+		}
+		if maxLength, ok := schemaNumber(schema, "maxLength"); ok && length > maxLength {
+			add("expected length <= %v, got %v", maxLength, length)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			re, err := v.compile(pattern)
+			if err != nil {
+				add("invalid schema pattern %q: %v", pattern, err)
+			} else if !re.MatchString(typed) {
+				add("value %q does not match pattern %q", typed, pattern)
+			}
+		}
+	case float64:
+		if minimum, ok := schemaNumber(schema, "minimum"); ok && typed < minimum {
+			add("value %v is less than minimum %v", typed, minimum)
+		}
+		if maximum, ok := schemaNumber(schema, "maximum"); ok && typed > maximum {
+			add("value %v is greater than maximum %v", typed, maximum)
+		}
+		if exclusive, ok := schemaNumber(schema, "exclusiveMinimum"); ok && typed <= exclusive {
+			add("value %v must be greater than %v", typed, exclusive)
+		}
+		if exclusive, ok := schemaNumber(schema, "exclusiveMaximum"); ok && typed >= exclusive {
+			add("value %v must be less than %v", typed, exclusive)
+		}
+	}
+
+	if all, ok := schema["allOf"].([]any); ok {
+		for _, sub := range all {
+			if subSchema, ok := sub.(map[string]any); ok {
+				v.validate(subSchema, value, path, out)
+			}
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]any); ok && v.countMatches(anyOf, value, path) == 0 {
+		add("value does not match any schema in anyOf")
+	}
+	if oneOf, ok := schema["oneOf"].([]any); ok {
+		if matches := v.countMatches(oneOf, value, path); matches != 1 {
+			add("value must match exactly one schema in oneOf, matched %d", matches)
+		}
+	}
+}
+
+func (v *jsonSchemaValidator) validateObject(schema map[string]any, obj map[string]any, path string, out *[]schemaViolation) {
+	if required, ok := schema["required"].([]any); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, present := obj[name]; name != "" && !present {
+				*out = append(*out, schemaViolation{Path: joinSchemaPath(path, name), Message: "required field missing"})
+			}
+		}
+	}
+	props, _ := schema["properties"].(map[string]any)
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if propSchema, ok := props[key].(map[string]any); ok {
+			v.validate(propSchema, obj[key], joinSchemaPath(path, key), out)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				*out = append(*out, schemaViolation{Path: joinSchemaPath(path, key), Message: "additional property not allowed"})
+			}
+		case map[string]any:
+			v.validate(additional, obj[key], joinSchemaPath(path, key), out)
+		}
+	}
+}
+
+func (v *jsonSchemaValidator) countMatches(schemas []any, value any, path string) int {
+	matches := 0
+	for _, sub := range schemas {
+		subSchema, ok := sub.(map[string]any)
+		if !ok {
+			continue
+		}
+		var scratch []schemaViolation
+		v.validate(subSchema, value, path, &scratch)
+		if len(scratch) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+func (v *jsonSchemaValidator) compile(pattern string) (*regexp.Regexp, error) {
+	if re, ok := v.patterns[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	v.patterns[pattern] = re
+	return re, nil
+}
+
+func joinSchemaPath(parent, key string) string {
+	return parent + "." + key
+}
+
+func schemaNumber(schema map[string]any, key string) (float64, bool) {
+	n, ok := schema[key].(float64)
+	return n, ok
+}
+
+func matchesSchemaType(rawType any, value any) bool {
+	switch typed := rawType.(type) {
+	case string:
+		return matchesSingleSchemaType(typed, value)
+	case []any:
+		for _, t := range typed {
+			if name, ok := t.(string); ok && matchesSingleSchemaType(name, value) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func matchesSingleSchemaType(name string, value any) bool {
+	switch name {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	}
+	return true
+}
+
+func describeSchemaType(rawType any) string {
+	if list, ok := rawType.([]any); ok {
+		names := make([]string, 0, len(list))
+		for _, item := range list {
+			names = append(names, fmt.Sprint(item))
+		}
+		return strings.Join(names, "|")
+	}
+	return fmt.Sprint(rawType)
+}
+
+func jsonTypeName(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if typed == math.Trunc(typed) {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func jsonValuesEqual(a, b any) bool {
+	left, errA := json.Marshal(a)
+	right, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(left) == string(right)
+}
+
+func compactJSON(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
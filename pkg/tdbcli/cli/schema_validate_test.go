@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONSchemaValidator(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"required": ["email", "age"],
+		"additionalProperties": false,
+		"properties": {
+			"email": {"type": "string", "pattern": "^[^@]+@[^@]+$"},
+			"age": {"type": "integer", "minimum": 0},
+			"role": {"enum": ["admin", "user"]},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		}
+	}`
+	validator, err := newJSONSchemaValidator(schema)
+	if err != nil {
+		t.Fatalf("newJSONSchemaValidator returned error: %v", err)
+	}
+
+	var valid any
+	_ = json.Unmarshal([]byte(`{"email":"a@b.c","age":30,"role":"admin","tags":["x"]}`), &valid)
+	if violations := validator.Validate(valid); len(violations) != 0 {
+		t.Fatalf("expected no violations, got %v", violations)
+	}
+
+	var invalid any
+	_ = json.Unmarshal([]byte(`{"email":"nope","age":1.5,"role":"owner","tags":[1],"extra":true}`), &invalid)
+	violations := validator.Validate(invalid)
+	got := map[string]bool{}
+	for _, v := range violations {
+		got[v.Path] = true
+	}
+	for _, path := range []string{"$.email", "$.age", "$.role", "$.tags[0]", "$.extra"} {
+		if !got[path] {
+			t.Fatalf("expected violation at %s, got %v", path, violations)
+		}
+	}
+
+	var missing any
+	_ = json.Unmarshal([]byte(`{"email":"a@b.c"}`), &missing)
+	violations = validator.Validate(missing)
+	if len(violations) != 1 || violations[0].Path != "$.age" || violations[0].Message != "required field missing" {
+		t.Fatalf("expected missing age violation, got %v", violations)
+	}
+}
+
+func TestJSONSchemaValidator_NoSchema(t *testing.T) {
+	if _, err := newJSONSchemaValidator("  "); err == nil {
+		t.Fatalf("expected error for empty schema")
+	}
+}
@@ -0,0 +1,258 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultSchemaDir    = "schemas"
+	schemaFileExtension = ".schema.json"
+)
+
+// schemaRecordOffender is a payload record that failed validation.
+type schemaRecordOffender struct {
+	Record     int               `json:"record"`
+	Violations []schemaViolation `json:"violations"`
+}
+
+// schemaFileReport summarizes validating a payload file.
+type schemaFileReport struct {
+	File       string                 `json:"file"`
+	Collection string                 `json:"collection"`
+	Source     string                 `json:"schema_source"`
+	Checked    int                    `json:"checked"`
+	Valid      int                    `json:"valid"`
+	Invalid    int                    `json:"invalid"`
+	Offenders  []schemaRecordOffender `json:"offenders"`
+}
+
+func newSchemasCommand(env *Environment) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schemas",
+		Short: "Keep local copies of collection schemas and validate payloads",
+		Long: `Pull collection schemas into a local directory (one <collection>.schema.json
+file each) and validate JSON payloads against them, online or offline. Commit
+the directory so CI can lint seed data without credentials.`,
+	}
+	cmd.AddCommand(newSchemasPullCommand(env))
+	cmd.AddCommand(newSchemasValidateCommand(env))
+	return cmd
+}
+
+func newSchemasPullCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var dir string
+	var collections string
+
+	cmd := &cobra.Command{
+		Use:   "pull",
+		Short: "Download collection schemas into a local directory",
+		Example: `  tdb schemas pull --dir schemas/
+  tdb schemas pull --dir schemas/ --collections 'users,order*'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			all, err := tenantClient.ListCollections(cmd.Context(), auth.appID)
+			if err != nil {
+				return err
+			}
+			selected, err := selectCollections(all, splitCommaList(collections))
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return err
+			}
+			written := 0
+			for _, col := range selected {
+				schema := strings.TrimSpace(col.SchemaJSON)
+				if schema == "" {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Skipping %s: collection has no schema\n", col.Name)
+					continue
+				}
+				var pretty bytes.Buffer
+				if err := json.Indent(&pretty, []byte(schema), "", "  "); err != nil {
+					return fmt.Errorf("collection %s: invalid schema: %w", col.Name, err)
+				}
+				pretty.WriteByte('\n')
+				path := schemaFilePath(dir, col.Name)
+				if err := os.WriteFile(path, pretty.Bytes(), 0o644); err != nil {
+					return err
+				}
+				written++
+				fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s\n", path)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Pulled %d schemas into %s\n", written, dir)
+			return nil
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().StringVar(&dir, "dir", defaultSchemaDir, "Directory to write <collection>.schema.json files to")
+	cmd.Flags().StringVar(&collections, "collections", "", "Comma-separated collection names, globs, or re: patterns (default: all)")
+	return cmd
+}
+
+func newSchemasValidateCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var collection string
+	var dir string
+	var offline bool
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "validate <file|->",
+		Short: "Validate a JSON payload file against a collection schema",
+		Long: `Validate a JSON object, a JSON array of objects, or JSONL records against a
+collection schema. With --offline the schema is read from --dir (see
+"tdb schemas pull") and no credentials or network access are needed; otherwise
+it is fetched from the API. Exits non-zero when any record is invalid.`,
+		Example: `  # In CI, without credentials
+  tdb schemas validate seed/users.json --collection users --offline
+
+  # Against the live schema
+  tdb schemas validate seed/users.jsonl --collection users --api-key $API_KEY`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.TrimSpace(collection)
+			if name == "" {
+				return errors.New("--collection is required")
+			}
+			report := schemaFileReport{File: args[0], Collection: name}
+			var schemaJSON string
+			if offline {
+				path := schemaFilePath(dir, name)
+				content, err := os.ReadFile(path)
+				if err != nil {
+					if errors.Is(err, os.ErrNotExist) {
+						return fmt.Errorf("no local schema for %s at %s (run `tdb schemas pull --dir %s`)", name, path, dir)
+					}
+					return err
+				}
+				schemaJSON = string(content)
+				report.Source = path
+			} else {
+				envCtx, err := requireEnvironment(env)
+				if err != nil {
+					return err
+				}
+				tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+				if err != nil {
+					return err
+				}
+				col, err := tenantClient.GetCollection(cmd.Context(), name, auth.appID)
+				if err != nil {
+					return err
+				}
+				schemaJSON = col.SchemaJSON
+				report.Source = "server"
+			}
+			validator, err := newJSONSchemaValidator(schemaJSON)
+			if err != nil {
+				return fmt.Errorf("collection %s: %w", name, err)
+			}
+
+			var input io.Reader
+			if args[0] == "-" {
+				input = cmd.InOrStdin()
+			} else {
+				file, err := os.Open(filepath.Clean(args[0]))
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				input = file
+			}
+			records, err := decodeSchemaRecords(input)
+			if err != nil {
+				return fmt.Errorf("%s: %w", args[0], err)
+			}
+			report.Offenders = []schemaRecordOffender{}
+			for i, record := range records {
+				report.Checked++
+				violations := validator.Validate(record)
+				if len(violations) == 0 {
+					report.Valid++
+					continue
+				}
+				report.Invalid++
+				report.Offenders = append(report.Offenders, schemaRecordOffender{Record: i + 1, Violations: violations})
+			}
+
+			if raw {
+				if err := printJSON(cmd, report); err != nil {
+					return err
+				}
+			} else {
+				out := cmd.OutOrStdout()
+				for _, offender := range report.Offenders {
+					for _, violation := range offender.Violations {
+						fmt.Fprintf(out, "record %d: %s\n", offender.Record, violation)
+					}
+				}
+				fmt.Fprintf(out, "Checked %d records against %s: %d valid, %d invalid\n", report.Checked, name, report.Valid, report.Invalid)
+			}
+			if report.Invalid > 0 {
+				return fmt.Errorf("%d of %d records failed validation", report.Invalid, report.Checked)
+			}
+			return nil
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().StringVar(&collection, "collection", "", "Collection whose schema to validate against")
+	cmd.Flags().StringVar(&dir, "dir", defaultSchemaDir, "Directory holding pulled schemas (used with --offline)")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Use the local schema from --dir instead of calling the API")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the validation report as JSON")
+	return cmd
+}
+
+func schemaFilePath(dir, collection string) string {
+	return filepath.Join(dir, collection+schemaFileExtension)
+}
+
+// decodeSchemaRecords reads a JSON array of records, or one or more
+// whitespace-separated JSON values (a single object or JSONL).
+func decodeSchemaRecords(r io.Reader) ([]any, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) == 0 {
+		return nil, errors.New("no records found")
+	}
+	if trimmed[0] == '[' {
+		var records []any
+		if err := json.Unmarshal(trimmed, &records); err != nil {
+			return nil, fmt.Errorf("decode JSON array: %w", err)
+		}
+		return records, nil
+	}
+	var records []any
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	for decoder.More() {
+		var record any
+		if err := decoder.Decode(&record); err != nil {
+			return nil, fmt.Errorf("decode record %d: %w", len(records)+1, err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
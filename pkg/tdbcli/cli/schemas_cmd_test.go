@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestSchemasPullAndOfflineValidate(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users", SchemaJSON: `{"type":"object","required":["email"],"properties":{"email":{"type":"string"},"age":{"type":"integer","minimum":0}}}`})
+	srv.AddCollection(clientpkg.Collection{Name: "logs"})
+	dir := t.TempDir()
+	schemaDir := filepath.Join(dir, "schemas")
+
+	run := func(newCmd func(*Environment) *cobra.Command, env *Environment, args ...string) (string, error) {
+		t.Helper()
+		cmd := newCmd(env)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetArgs(args)
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&bytes.Buffer{})
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+	out, _, err := runCommandWithEnv(t, env, newSchemasPullCommand, "--dir", schemaDir)
+	if err != nil {
+		t.Fatalf("pull failed: %v", err)
+	}
+	if !strings.Contains(out, "Pulled 1 schemas") {
+		t.Fatalf("unexpected pull output %s", out)
+	}
+	if _, err := os.Stat(filepath.Join(schemaDir, "users.schema.json")); err != nil {
+		t.Fatalf("expected users schema file: %v", err)
+	}
+
+	seed := filepath.Join(dir, "users.jsonl")
+	if err := os.WriteFile(seed, []byte("{\"email\":\"ada@example.com\",\"age\":36}\n{\"age\":-1}\n"), 0o600); err != nil {
+		t.Fatalf("write seed: %v", err)
+	}
+	// No endpoint or credentials: offline validation must not need them.
+	offlineEnv := &Environment{Config: &configpkg.Config{}}
+	out, err = run(newSchemasValidateCommand, offlineEnv, seed, "--collection", "users", "--offline", "--dir", schemaDir)
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 records failed validation") {
+		t.Fatalf("expected one invalid record, got %v", err)
+	}
+	if !strings.Contains(out, "record 2: $.age:") || !strings.Contains(out, "record 2: $.email: required field missing") {
+		t.Fatalf("unexpected validation output:\n%s", out)
+	}
+
+	valid := filepath.Join(dir, "valid.json")
+	if err := os.WriteFile(valid, []byte(`[{"email":"grace@example.com"}]`), 0o600); err != nil {
+		t.Fatalf("write valid: %v", err)
+	}
+	if _, err := run(newSchemasValidateCommand, offlineEnv, valid, "--collection", "users", "--offline", "--dir", schemaDir); err != nil {
+		t.Fatalf("expected valid payload to pass, got %v", err)
+	}
+	if _, err := run(newSchemasValidateCommand, offlineEnv, valid, "--collection", "orders", "--offline", "--dir", schemaDir); err == nil || !strings.Contains(err.Error(), "tdb schemas pull") {
+		t.Fatalf("expected missing schema hint, got %v", err)
+	}
+}
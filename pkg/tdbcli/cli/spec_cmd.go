@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	versionpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/version"
+)
+
+// cliSpec is the machine-readable description of the command tree printed by
+// `tdb spec`.
+type cliSpec struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version"`
+	Command commandSpec `json:"command"`
+}
+
+type commandSpec struct {
+	Name        string        `json:"name"`
+	Path        string        `json:"path"`
+	Use         string        `json:"use"`
+	Aliases     []string      `json:"aliases,omitempty"`
+	Short       string        `json:"short,omitempty"`
+	Long        string        `json:"long,omitempty"`
+	Example     string        `json:"example,omitempty"`
+	Deprecated  string        `json:"deprecated,omitempty"`
+	Hidden      bool          `json:"hidden,omitempty"`
+	Runnable    bool          `json:"runnable"`
+	Flags       []flagSpec    `json:"flags,omitempty"`
+	Subcommands []commandSpec `json:"subcommands,omitempty"`
+}
+
+type flagSpec struct {
+	Name       string `json:"name"`
+	Shorthand  string `json:"shorthand,omitempty"`
+	Type       string `json:"type"`
+	Default    string `json:"default,omitempty"`
+	Usage      string `json:"usage"`
+	Required   bool   `json:"required,omitempty"`
+	Persistent bool   `json:"persistent,omitempty"`
+	Deprecated string `json:"deprecated,omitempty"`
+	Hidden     bool   `json:"hidden,omitempty"`
+}
+
+func newSpecCommand(root *cobra.Command) *cobra.Command {
+	var format string
+	var includeHidden bool
+
+	cmd := &cobra.Command{
+		Use:   "spec",
+		Short: "Print the full command tree, flags, and descriptions as JSON",
+		Long: `Dump the CLI surface (every command with its usage, descriptions, examples,
+and flags with their types and defaults) for docs generators, portals, and
+other tooling. The spec is built from the command registry at runtime, so it
+always matches the binary that prints it.
+
+Persistent flags are listed on the command that defines them and apply to all
+of its subcommands. Hidden commands and flags are omitted unless
+--include-hidden is given.`,
+		Example: `  # Dump the spec
+  tdb spec --format json > tdb-spec.json
+
+  # List every command path
+  tdb spec | jq -r '.. | objects | select(has("path")) | .path'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.ToLower(strings.TrimSpace(format)) != "json" {
+				return fmt.Errorf("unsupported format %q (supported: json)", format)
+			}
+			spec := cliSpec{
+				Name:    root.Name(),
+				Version: versionpkg.Number(),
+				Command: buildCommandSpec(root, includeHidden),
+			}
+			return printJSON(cmd, spec)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "json", "Output format (json)")
+	cmd.Flags().BoolVar(&includeHidden, "include-hidden", false, "Include hidden commands and flags")
+
+	return cmd
+}
+
+func buildCommandSpec(cmd *cobra.Command, includeHidden bool) commandSpec {
+	spec := commandSpec{
+		Name:       cmd.Name(),
+		Path:       cmd.CommandPath(),
+		Use:        cmd.UseLine(),
+		Aliases:    cmd.Aliases,
+		Short:      cmd.Short,
+		Long:       cmd.Long,
+		Example:    cmd.Example,
+		Deprecated: cmd.Deprecated,
+		Hidden:     cmd.Hidden,
+		Runnable:   cmd.Runnable(),
+	}
+	// cobra only adds --help to the command being executed; leave it out so
+	// every command is described the same way.
+	addFlags := func(flags *pflag.FlagSet, persistent bool) {
+		flags.VisitAll(func(f *pflag.Flag) {
+			if f.Name != "help" && (includeHidden || !f.Hidden) {
+				spec.Flags = append(spec.Flags, buildFlagSpec(f, persistent))
+			}
+		})
+	}
+	addFlags(cmd.LocalNonPersistentFlags(), false)
+	addFlags(cmd.PersistentFlags(), true)
+	sort.Slice(spec.Flags, func(i, j int) bool { return spec.Flags[i].Name < spec.Flags[j].Name })
+
+	for _, child := range cmd.Commands() {
+		if child.Name() == "help" || (child.Hidden && !includeHidden) {
+			continue
+		}
+		spec.Subcommands = append(spec.Subcommands, buildCommandSpec(child, includeHidden))
+	}
+	return spec
+}
+
+func buildFlagSpec(f *pflag.Flag, persistent bool) flagSpec {
+	_, required := f.Annotations[cobra.BashCompOneRequiredFlag]
+	return flagSpec{
+		Name:       f.Name,
+		Shorthand:  f.Shorthand,
+		Type:       f.Value.Type(),
+		Default:    f.DefValue,
+		Usage:      f.Usage,
+		Required:   required,
+		Persistent: persistent,
+		Deprecated: f.Deprecated,
+		Hidden:     f.Hidden,
+	}
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestSpecDumpsCommandTree(t *testing.T) {
+	root := NewRootCommand()
+	specCmd, _, err := root.Find([]string{"spec"})
+	if err != nil {
+		t.Fatalf("find spec: %v", err)
+	}
+	var out bytes.Buffer
+	specCmd.SetOut(&out)
+	if err := specCmd.RunE(specCmd, nil); err != nil {
+		t.Fatalf("spec failed: %v", err)
+	}
+	var spec cliSpec
+	if err := json.Unmarshal(out.Bytes(), &spec); err != nil {
+		t.Fatalf("decode spec: %v", err)
+	}
+	if spec.Name != "tdb" || spec.Command.Path != "tdb" {
+		t.Fatalf("unexpected root: %+v", spec)
+	}
+
+	find := func(cmd commandSpec, path ...string) *commandSpec {
+		for _, name := range path {
+			var next *commandSpec
+			for i := range cmd.Subcommands {
+				if cmd.Subcommands[i].Name == name {
+					next = &cmd.Subcommands[i]
+				}
+			}
+			if next == nil {
+				return nil
+			}
+			cmd = *next
+		}
+		return &cmd
+	}
+	list := find(spec.Command, "tenant", "documents", "list")
+	if list == nil || list.Path != "tdb tenant documents list" || !list.Runnable {
+		t.Fatalf("expected tenant documents list in spec, got %+v", list)
+	}
+	var limit *flagSpec
+	for i := range list.Flags {
+		if list.Flags[i].Name == "limit" {
+			limit = &list.Flags[i]
+		}
+		if list.Flags[i].Name == "help" {
+			t.Fatalf("help flag should be omitted")
+		}
+	}
+	if limit == nil || limit.Type != "int" || limit.Usage == "" {
+		t.Fatalf("expected typed --limit flag, got %+v", limit)
+	}
+	var endpoint bool
+	for _, flag := range spec.Command.Flags {
+		if flag.Name == "endpoint" && flag.Persistent {
+			endpoint = true
+		}
+	}
+	if !endpoint {
+		t.Fatalf("expected persistent --endpoint on root, got %+v", spec.Command.Flags)
+	}
+}
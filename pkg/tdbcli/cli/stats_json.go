@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const maxStatsErrors = 100
+
+// operationStats is the machine-readable run summary written by --stats-json so
+// orchestrators can inspect batch command outcomes without scraping stderr.
+type operationStats struct {
+	Command       string         `json:"command"`
+	Args          []string       `json:"args,omitempty"`
+	Status        string         `json:"status"`
+	StartedAt     time.Time      `json:"started_at"`
+	FinishedAt    time.Time      `json:"finished_at"`
+	DurationMS    int64          `json:"duration_ms"`
+	Counts        map[string]int `json:"counts"`
+	Bytes         int64          `json:"bytes"`
+	Errors        []string       `json:"errors"`
+	ErrorsOmitted int            `json:"errors_omitted,omitempty"`
+	Checkpoint    string         `json:"checkpoint,omitempty"`
+	Resume        string         `json:"resume,omitempty"`
+
+	onStop func() (checkpoint, resume string)
+	mu     sync.Mutex
+}
+
+func newOperationStats() *operationStats {
+	return &operationStats{Counts: map[string]int{}, Errors: []string{}}
+}
+
+// count sets a named counter.
+func (s *operationStats) count(name string, value int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Counts[name] = value
+}
+
+// stopped registers fn to describe how far the run got, and how to resume it,
+// when Ctrl-C or --timeout stops the command.
+func (s *operationStats) stopped(fn func() (checkpoint, resume string)) {
+	s.onStop = fn
+}
+
+func (s *operationStats) addBytes(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Bytes += n
+}
+
+func (s *operationStats) addError(msg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.Errors) >= maxStatsErrors {
+		s.ErrorsOmitted++
+		return
+	}
+	s.Errors = append(s.Errors, msg)
+}
+
+// errorf prints a per-item failure to w and records it in the error list.
+func (s *operationStats) errorf(w io.Writer, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	fmt.Fprint(w, msg)
+	s.addError(strings.TrimSpace(msg))
+}
+
+// countBytes wraps w so every byte written is added to the stats.
+func (s *operationStats) countBytes(w io.Writer) io.Writer {
+	return statsCountingWriter{w: w, stats: s}
+}
+
+type statsCountingWriter struct {
+	w     io.Writer
+	stats *operationStats
+}
+
+func (c statsCountingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.stats.addBytes(int64(n))
+	return n, err
+}
+
+// bindStatsJSON registers --stats-json and --timeout on cmd and wraps its RunE
+// so the summary is written after the command finishes, whether it succeeded,
+// failed, or was stopped by Ctrl-C or the timeout. A stopped run also prints
+// its partial counts, checkpoint, and resume command on stderr. The first
+// Ctrl-C is caught only while such a command runs; other commands keep the
+// default signal behaviour.
+func bindStatsJSON(cmd *cobra.Command, stats *operationStats) {
+	var path string
+	var timeout time.Duration
+	cmd.Flags().StringVar(&path, "stats-json", "", "Write a machine-readable run summary (counts, duration, bytes, errors) to a file, or - for stdout")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Stop after this long, e.g. 30m, printing a partial summary with a resume command (default: no limit)")
+	run := cmd.RunE
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		stats.Command = c.CommandPath()
+		stats.Args = args
+		stats.StartedAt = time.Now().UTC()
+		if timeout < 0 {
+			return errors.New("--timeout must be positive")
+		}
+		parent := c.Context()
+		if parent == nil {
+			parent = context.Background()
+		}
+		// Only batch commands trade the first Ctrl-C for a partial summary;
+		// everything else keeps the default signal behaviour.
+		ctx, stop := notifyInterrupt(parent)
+		defer stop()
+		c.SetContext(ctx)
+		if timeout > 0 {
+			ctx, cancel := context.WithTimeoutCause(ctx, timeout, fmt.Errorf("%w after %s", errTimedOut, timeout))
+			defer cancel()
+			c.SetContext(ctx)
+		}
+		runErr := run(c, args)
+		if runErr != nil {
+			if cause := stopCause(c.Context()); cause != nil {
+				runErr = reportStopped(c, stats, cause)
+			}
+		}
+		stats.finish(runErr)
+		if strings.TrimSpace(path) == "" {
+			return runErr
+		}
+		if err := writeOperationStats(c, path, stats); err != nil {
+			if runErr != nil {
+				return runErr
+			}
+			return fmt.Errorf("write stats: %w", err)
+		}
+		return runErr
+	}
+}
+
+func (s *operationStats) finish(runErr error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.FinishedAt = time.Now().UTC()
+	s.DurationMS = s.FinishedAt.Sub(s.StartedAt).Milliseconds()
+	s.Status = "success"
+	if runErr != nil {
+		s.Status = "failed"
+		if errors.Is(runErr, errInterrupted) {
+			s.Status = "interrupted"
+		} else if errors.Is(runErr, errTimedOut) {
+			s.Status = "timed_out"
+		}
+		msg := runErr.Error()
+		if len(s.Errors) == 0 || s.Errors[len(s.Errors)-1] != msg {
+			s.Errors = append(s.Errors, msg)
+		}
+	}
+}
+
+func writeOperationStats(cmd *cobra.Command, path string, stats *operationStats) error {
+	stats.mu.Lock()
+	encoded, err := json.MarshalIndent(stats, "", "  ")
+	stats.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	encoded = append(encoded, '\n')
+	trimmed := strings.TrimSpace(path)
+	if trimmed == "-" {
+		_, err := cmd.OutOrStdout().Write(encoded)
+		return err
+	}
+	clean := filepath.Clean(trimmed)
+	if dir := filepath.Dir(clean); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(clean, encoded, 0o644)
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestBindStatsJSONWritesSummary(t *testing.T) {
+	stats := newOperationStats()
+	cmd := &cobra.Command{
+		Use: "export",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var buf bytes.Buffer
+			_, _ = stats.countBytes(&buf).Write([]byte("hello"))
+			stats.count("exported", 2)
+			stats.errorf(&buf, "[%d] create %s failed: %v\n", 1, "k1", errors.New("boom"))
+			return errors.New("1 document failed")
+		},
+	}
+	bindStatsJSON(cmd, stats)
+
+	path := filepath.Join(t.TempDir(), "stats", "run.json")
+	cmd.SetArgs([]string{"users", "--stats-json", path})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	if err := cmd.Execute(); err == nil {
+		t.Fatalf("expected the command error to be returned")
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read stats: %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("decode stats: %v", err)
+	}
+	if got["status"] != "failed" || got["command"] != "export" {
+		t.Fatalf("unexpected status/command: %v", got)
+	}
+	if got["bytes"] != float64(5) {
+		t.Fatalf("expected 5 bytes, got %v", got["bytes"])
+	}
+	if counts := got["counts"].(map[string]any); counts["exported"] != float64(2) {
+		t.Fatalf("unexpected counts: %v", counts)
+	}
+	errs := got["errors"].([]any)
+	if len(errs) != 2 || errs[0] != "[1] create k1 failed: boom" || errs[1] != "1 document failed" {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestBindStatsJSONStdout(t *testing.T) {
+	stats := newOperationStats()
+	cmd := &cobra.Command{Use: "sync", RunE: func(cmd *cobra.Command, args []string) error { return nil }}
+	bindStatsJSON(cmd, stats)
+
+	var out bytes.Buffer
+	cmd.SetArgs([]string{"--stats-json", "-"})
+	cmd.SetOut(&out)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got operationStats
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("decode stats: %v (%s)", err, out.String())
+	}
+	if got.Status != "success" || len(got.Errors) != 0 {
+		t.Fatalf("unexpected stats: status=%s errors=%v", got.Status, got.Errors)
+	}
+}
+
+func TestOperationStatsCapsErrors(t *testing.T) {
+	stats := newOperationStats()
+	for i := 0; i < maxStatsErrors+5; i++ {
+		stats.addError("failure")
+	}
+	if len(stats.Errors) != maxStatsErrors || stats.ErrorsOmitted != 5 {
+		t.Fatalf("expected %d errors and 5 omitted, got %d and %d", maxStatsErrors, len(stats.Errors), stats.ErrorsOmitted)
+	}
+}
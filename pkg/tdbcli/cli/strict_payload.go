@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const strictFlagUsage = "Fail before sending when the payload has fields the collection schema does not define"
+
+// checkStrictPayload fetches the collection and runs checkStrictFields on a
+// single JSON document payload.
+func checkStrictPayload(ctx context.Context, tenantClient *clientpkg.TenantClient, collection, appID string, payload []byte) error {
+	var value any
+	if err := json.Unmarshal(payload, &value); err != nil {
+		return fmt.Errorf("decode payload: %w", err)
+	}
+	col, err := tenantClient.GetCollection(ctx, collection, appID)
+	if err != nil {
+		return err
+	}
+	return checkStrictFields(col, value)
+}
+
+// checkStrictFields fails when any value has a field that the collection
+// schema does not define, catching typos such as "stauts" before they are
+// stored. Objects whose schema sets additionalProperties to true or to a
+// schema are open and not checked; the primary key field is always allowed
+// at the top level.
+func checkStrictFields(col *clientpkg.Collection, values ...any) error {
+	if strings.TrimSpace(col.SchemaJSON) == "" {
+		return fmt.Errorf("collection %s has no schema to check --strict against", col.Name)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(col.SchemaJSON), &schema); err != nil {
+		return fmt.Errorf("parse %s schema: %w", col.Name, err)
+	}
+	checker := strictFieldChecker{primaryKey: strings.TrimSpace(col.PrimaryKeyField)}
+	var unknown []schemaViolation
+	for idx, value := range values {
+		root := "$"
+		if len(values) > 1 {
+			root = fmt.Sprintf("[%d]", idx)
+		}
+		checker.check(schema, value, root, true, &unknown)
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(unknown))
+	for _, v := range unknown {
+		lines = append(lines, "  "+v.String())
+	}
+	return fmt.Errorf("payload has %d field(s) not defined in the %s schema (--strict):\n%s", len(unknown), col.Name, strings.Join(lines, "\n"))
+}
+
+type strictFieldChecker struct {
+	primaryKey string
+}
+
+func (c strictFieldChecker) check(schema map[string]any, value any, path string, top bool, out *[]schemaViolation) {
+	switch typed := value.(type) {
+	case map[string]any:
+		props := strictSchemaProperties(schema)
+		if props == nil {
+			return
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case map[string]any:
+			return
+		case bool:
+			if additional {
+				return
+			}
+		}
+		patterns, _ := schema["patternProperties"].(map[string]any)
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if sub, ok := props[key]; ok {
+				subSchema, _ := sub.(map[string]any)
+				c.check(subSchema, typed[key], joinSchemaPath(path, key), false, out)
+				continue
+			}
+			if (top && key == c.primaryKey) || matchesPatternProperty(patterns, key) {
+				continue
+			}
+			message := "unknown field"
+			if suggestion := closestFieldName(key, props); suggestion != "" {
+				message += fmt.Sprintf(" (did you mean %q?)", suggestion)
+			}
+			*out = append(*out, schemaViolation{Path: joinSchemaPath(path, key), Message: message})
+		}
+	case []any:
+		items, _ := schema["items"].(map[string]any)
+		if items == nil {
+			return
+		}
+		for i, item := range typed {
+			c.check(items, item, fmt.Sprintf("%s[%d]", path, i), false, out)
+		}
+	}
+}
+
+// strictSchemaProperties returns the properties an object schema defines,
+// including those contributed by allOf branches, or nil when it defines none.
+func strictSchemaProperties(schema map[string]any) map[string]any {
+	if schema == nil {
+		return nil
+	}
+	var props map[string]any
+	merge := func(s map[string]any) {
+		if p, ok := s["properties"].(map[string]any); ok {
+			if props == nil {
+				props = map[string]any{}
+			}
+			for k, v := range p {
+				props[k] = v
+			}
+		}
+	}
+	merge(schema)
+	if all, ok := schema["allOf"].([]any); ok {
+		for _, sub := range all {
+			if subSchema, ok := sub.(map[string]any); ok {
+				merge(subSchema)
+			}
+		}
+	}
+	return props
+}
+
+func matchesPatternProperty(patterns map[string]any, key string) bool {
+	for pattern := range patterns {
+		if re, err := regexp.Compile(pattern); err == nil && re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// closestFieldName suggests the defined field nearest to name, if one is
+// within a couple of edits.
+func closestFieldName(name string, props map[string]any) string {
+	best, bestDistance := "", 3
+	candidates := make([]string, 0, len(props))
+	for candidate := range props {
+		candidates = append(candidates, candidate)
+	}
+	sort.Strings(candidates)
+	for _, candidate := range candidates {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(candidate)); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Damerau-Levenshtein (optimal string alignment) distance
+// between a and b, so a swapped pair of letters counts as one edit.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(ra)][len(rb)]
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+const strictTestSchema = `{
+  "type": "object",
+  "properties": {
+    "name": {"type": "string"},
+    "status": {"type": "string"},
+    "address": {"type": "object", "properties": {"city": {"type": "string"}}},
+    "tags": {"type": "array", "items": {"type": "object", "properties": {"label": {"type": "string"}}}},
+    "meta": {"type": "object", "properties": {}, "additionalProperties": true}
+  }
+}`
+
+func TestCheckStrictFieldsReportsUnknownFields(t *testing.T) {
+	col := &clientpkg.Collection{Name: "users", SchemaJSON: strictTestSchema, PrimaryKeyField: "email"}
+	doc := map[string]any{
+		"email":   "a@example.com",
+		"name":    "Ada",
+		"stauts":  "active",
+		"address": map[string]any{"city": "Phnom Penh", "zip": "12000"},
+		"tags":    []any{map[string]any{"label": "vip", "colour": "red"}},
+		"meta":    map[string]any{"anything": true},
+	}
+	err := checkStrictFields(col, doc)
+	if err == nil {
+		t.Fatalf("expected unknown fields to be reported")
+	}
+	for _, want := range []string{`$.stauts: unknown field (did you mean "status"?)`, "$.address.zip: unknown field", "$.tags[0].colour: unknown field"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in error, got:\n%v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "email") || strings.Contains(err.Error(), "meta") {
+		t.Fatalf("primary key and open objects should be allowed, got:\n%v", err)
+	}
+
+	if err := checkStrictFields(col, map[string]any{"name": "Ada"}, map[string]any{"nmae": "Bob"}); err == nil || !strings.Contains(err.Error(), "[1].nmae") {
+		t.Fatalf("expected per-document path, got %v", err)
+	}
+}
+
+func TestDocumentsCreateStrictRejectsBeforeSending(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users", SchemaJSON: strictTestSchema})
+
+	run := func(data string) error {
+		_, _, err := runCommand(t, srv, newTenantDocumentsCreateCommand, "users", "--data", data, "--strict")
+		return err
+	}
+
+	if err := run(`{"name":"Ada","stauts":"active"}`); err == nil || !strings.Contains(err.Error(), "stauts") {
+		t.Fatalf("expected strict failure, got %v", err)
+	}
+	if docs := srv.Documents("users"); len(docs) != 0 {
+		t.Fatalf("expected nothing to be created, got %d document(s)", len(docs))
+	}
+	if err := run(`{"name":"Ada","status":"active"}`); err != nil {
+		t.Fatalf("expected valid payload to be created, got %v", err)
+	}
+	if docs := srv.Documents("users"); len(docs) != 1 {
+		t.Fatalf("expected 1 document, got %d", len(docs))
+	}
+}
+
+func TestDocumentsCreateStrictAllowsExpiryField(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users", SchemaJSON: strictTestSchema})
+
+	if _, _, err := runCommand(t, srv, newTenantDocumentsCreateCommand, "users", "--data", `{"name":"Ada"}`, "--strict", "--expires-in", "1d"); err != nil {
+		t.Fatalf("expected the injected expiry field to pass --strict, got %v", err)
+	}
+	if docs := srv.Documents("users"); len(docs) != 1 || !strings.Contains(docs[0].Data, `"expires_at"`) {
+		t.Fatalf("expected the document to carry an expiry, got %+v", docs)
+	}
+}
@@ -241,3 +241,27 @@ func TestPrepareDocumentCreatePayload(t *testing.T) {
 		t.Fatalf("expected case-insensitive primary key added back")
 	}
 }
+
+func TestDocumentSyncMismatches(t *testing.T) {
+	payload := map[string]any{"email": "a@example.com", "name": "Ada", "age": float64(36)}
+
+	mismatched, err := documentSyncMismatches(`{"email":"a@example.com","name":"Ada","age":36,"extra":true}`, payload, "email", false, "patch")
+	if err != nil {
+		t.Fatalf("documentSyncMismatches returned error: %v", err)
+	}
+	if len(mismatched) != 0 {
+		t.Fatalf("expected no mismatches in patch mode, got %v", mismatched)
+	}
+
+	mismatched, err = documentSyncMismatches(`{"email":"a@example.com","name":"ada","age":"36","extra":true}`, payload, "email", false, "update")
+	if err != nil {
+		t.Fatalf("documentSyncMismatches returned error: %v", err)
+	}
+	if !reflect.DeepEqual(mismatched, []string{"age", "extra", "name"}) {
+		t.Fatalf("unexpected mismatches: %v", mismatched)
+	}
+
+	if _, err := documentSyncMismatches(`not json`, payload, "email", false, "patch"); err == nil {
+		t.Fatalf("expected decode error")
+	}
+}
@@ -0,0 +1,241 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+	versionpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/version"
+)
+
+// appsManifest is the declarative application list read by `apps sync`.
+type appsManifest struct {
+	Applications []appSpec `yaml:"applications"`
+}
+
+// appSpec describes one application. Applications are matched by ID when one
+// is given (which allows renaming) and by name otherwise.
+type appSpec struct {
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	WithKey     bool   `yaml:"with_key"`
+	KeyAlias    string `yaml:"key_alias"`
+	SetDefault  bool   `yaml:"set_default"`
+}
+
+func newTenantAppsSyncCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var file string
+	var keyAliasPrefix string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "sync -f FILE",
+		Short: "Create or update applications from a YAML manifest",
+		Long: `Declaratively provision applications: entries that do not exist are created,
+existing ones get their name and description updated when they differ.
+
+Entries are matched by id when given (so an application can be renamed) and by
+name otherwise. An empty description is left unchanged on existing applications.
+with_key generates an API key when the application is created and stores it in
+the local config under key_alias (default: --key-alias-prefix followed by the
+application name). Keys cannot be retrieved later, so with_key has no effect on
+applications that already exist.
+
+Manifest format:
+  applications:
+    - name: billing
+      description: Billing service
+      with_key: true
+      key_alias: billing-prod   # optional
+      set_default: false        # make the stored key the tenant default
+    - id: app_42
+      name: reporting           # renames app_42`,
+		Example: `  # Preview what would change
+  tdb tenant apps sync -f apps.yaml --dry-run
+
+  # Provision, storing generated keys as prod-<name>
+  tdb tenant apps sync -f apps.yaml --key-alias-prefix prod-`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(file) == "" {
+				return errors.New("--file is required")
+			}
+			specs, err := loadAppsManifest(file)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, tenantID, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			existing, err := tenantClient.ListApplications(cmd.Context())
+			if err != nil {
+				return err
+			}
+			byID := make(map[string]clientpkg.Application, len(existing))
+			byName := make(map[string]clientpkg.Application, len(existing))
+			for _, app := range existing {
+				byID[app.ID] = app
+				byName[app.Name] = app
+			}
+
+			out := cmd.OutOrStdout()
+			verb := "Synced"
+			if dryRun {
+				verb = "Would sync"
+			}
+			var created, updated, unchanged, failed int
+			for _, spec := range specs {
+				var current *clientpkg.Application
+				if spec.ID != "" {
+					app, ok := byID[spec.ID]
+					if !ok {
+						fmt.Fprintf(cmd.ErrOrStderr(), "Failed to sync %s: application %s not found\n", spec.Name, spec.ID)
+						failed++
+						continue
+					}
+					current = &app
+				} else if app, ok := byName[spec.Name]; ok {
+					current = &app
+				}
+
+				if current == nil {
+					if dryRun {
+						fmt.Fprintf(out, "%s application %s (create)\n", verb, spec.Name)
+						created++
+						continue
+					}
+					desc := spec.Description
+					if desc == "" {
+						desc = versionpkg.DefaultApplicationDescription()
+					}
+					app, key, err := tenantClient.CreateApplication(cmd.Context(), clientpkg.CreateApplicationRequest{Name: spec.Name, Description: desc, WithAPIKey: spec.WithKey})
+					if err != nil && app == nil {
+						fmt.Fprintf(cmd.ErrOrStderr(), "Failed to create %s: %v\n", spec.Name, err)
+						failed++
+						continue
+					}
+					fmt.Fprintf(out, "%s application %s (created %s)\n", verb, app.Name, app.ID)
+					created++
+					if err != nil {
+						fmt.Fprintf(cmd.ErrOrStderr(), "Failed to generate key for %s: %v\n", spec.Name, err)
+						failed++
+						continue
+					}
+					if key != nil {
+						alias := spec.KeyAlias
+						if alias == "" {
+							alias = keyAliasPrefix + app.Name
+						}
+						entry := configpkg.APIKeyEntry{Key: key.APIKey, Prefix: key.Prefix, AppID: app.ID}
+						if err := storeAPIKey(envCtx, tenantID, alias, entry, spec.SetDefault, ""); err != nil {
+							fmt.Fprintf(cmd.ErrOrStderr(), "Application %s created but failed to store key (%s): %v\n", app.Name, key.APIKey, err)
+							failed++
+							continue
+						}
+						fmt.Fprintf(out, "  stored generated key as %s (prefix %s)\n", alias, key.Prefix)
+					}
+					continue
+				}
+
+				req := clientpkg.UpdateApplicationRequest{}
+				var changes []string
+				if spec.Name != current.Name {
+					name := spec.Name
+					req.Name = &name
+					changes = append(changes, fmt.Sprintf("name %q -> %q", current.Name, spec.Name))
+				}
+				if spec.Description != "" && spec.Description != current.Description {
+					desc := spec.Description
+					req.Description = &desc
+					changes = append(changes, "description")
+				}
+				if spec.WithKey {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Note: %s already exists; with_key only applies when an application is created\n", spec.Name)
+				}
+				if len(changes) == 0 {
+					fmt.Fprintf(out, "%s application %s (unchanged)\n", verb, current.Name)
+					unchanged++
+					continue
+				}
+				if !dryRun {
+					if _, err := tenantClient.UpdateApplication(cmd.Context(), current.ID, req); err != nil {
+						fmt.Fprintf(cmd.ErrOrStderr(), "Failed to update %s: %v\n", spec.Name, err)
+						failed++
+						continue
+					}
+				}
+				fmt.Fprintf(out, "%s application %s (update: %s)\n", verb, spec.Name, strings.Join(changes, ", "))
+				updated++
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Applications synced: created %d, updated %d, unchanged %d, failed %d\n", created, updated, unchanged, failed)
+			if failed > 0 {
+				return fmt.Errorf("failed to sync %d application(s)", failed)
+			}
+			return nil
+		},
+	}
+
+	auth.bind(cmd)
+	cmd.Flags().StringVarP(&file, "file", "f", "", "YAML manifest listing the applications")
+	cmd.Flags().StringVar(&keyAliasPrefix, "key-alias-prefix", "", "Prefix for the config alias of generated keys when key_alias is not set")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without creating or updating anything")
+	return cmd
+}
+
+// loadAppsManifest reads and validates an applications manifest. Unknown
+// fields are rejected so a typo such as "with_keys" is not silently ignored.
+func loadAppsManifest(path string) ([]appSpec, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var manifest appsManifest
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(manifest.Applications) == 0 {
+		return nil, fmt.Errorf("%s: no applications defined", path)
+	}
+	names := map[string]bool{}
+	aliases := map[string]bool{}
+	for i := range manifest.Applications {
+		spec := &manifest.Applications[i]
+		spec.ID = strings.TrimSpace(spec.ID)
+		spec.Name = strings.TrimSpace(spec.Name)
+		spec.Description = strings.TrimSpace(spec.Description)
+		spec.KeyAlias = strings.TrimSpace(spec.KeyAlias)
+		if spec.Name == "" {
+			return nil, fmt.Errorf("%s: application %d has no name", path, i+1)
+		}
+		if names[spec.Name] {
+			return nil, fmt.Errorf("%s: duplicate application name %q", path, spec.Name)
+		}
+		names[spec.Name] = true
+		if spec.KeyAlias != "" {
+			if !spec.WithKey {
+				return nil, fmt.Errorf("%s: %s sets key_alias without with_key", path, spec.Name)
+			}
+			if aliases[spec.KeyAlias] {
+				return nil, fmt.Errorf("%s: duplicate key_alias %q", path, spec.KeyAlias)
+			}
+			aliases[spec.KeyAlias] = true
+		}
+	}
+	return manifest.Applications, nil
+}
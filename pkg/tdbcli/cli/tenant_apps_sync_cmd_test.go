@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestTenantAppsSyncCreatesUpdatesAndStoresKeys(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddApplication(clientpkg.Application{ID: "app_old", Name: "reports", Description: "Reports"})
+	srv.AddApplication(clientpkg.Application{ID: "app_same", Name: "audit", Description: "Audit"})
+
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "apps.yaml")
+	if err := os.WriteFile(manifest, []byte(`applications:
+  - name: billing
+    description: Billing service
+    with_key: true
+  - id: app_old
+    name: reporting
+  - name: audit
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfgPath := filepath.Join(dir, "config.yaml")
+	env := &Environment{ConfigPath: cfgPath, Config: &configpkg.Config{Endpoint: srv.URL}}
+
+	run := func(extra ...string) (string, error) {
+		out, _, err := runCommandWithEnv(t, env, newTenantAppsSyncCommand, append([]string{"-f", manifest}, extra...)...)
+		return out, err
+	}
+
+	out, err := run("--dry-run")
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if !strings.Contains(out, "Would sync application billing (create)") || !strings.Contains(out, `name "reports" -> "reporting"`) {
+		t.Fatalf("unexpected dry run output: %s", out)
+	}
+	apps, _ := srv.TenantClient(t).ListApplications(t.Context())
+	if len(apps) != 2 {
+		t.Fatalf("dry run must not create applications, got %d", len(apps))
+	}
+
+	out, err = run("--key-alias-prefix", "prod-")
+	if err != nil {
+		t.Fatalf("sync failed: %v\n%s", err, out)
+	}
+	for _, want := range []string{"billing (created", "stored generated key as prod-billing", "reporting (update", "audit (unchanged)"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output: %s", want, out)
+		}
+	}
+	apps, _ = srv.TenantClient(t).ListApplications(t.Context())
+	names := map[string]string{}
+	for _, app := range apps {
+		names[app.ID] = app.Name
+	}
+	if names["app_old"] != "reporting" || len(apps) != 3 {
+		t.Fatalf("unexpected applications after sync: %+v", apps)
+	}
+	saved, err := configpkg.Load(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, ok := saved.Tenants["tn_test"].Keys["prod-billing"]
+	if !ok || entry.Key == "" || entry.AppID == "" {
+		t.Fatalf("expected stored key for billing, got %+v", saved.Tenants["tn_test"])
+	}
+}
+
+func TestLoadAppsManifestRejectsUnknownFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "apps.yaml")
+	if err := os.WriteFile(path, []byte("applications:\n  - name: billing\n    with_keys: true\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadAppsManifest(path); err == nil || !strings.Contains(err.Error(), "with_keys") {
+		t.Fatalf("expected unknown field error, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 
 	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/diff"
 )
 
 var supportedAuditOperations = map[string]struct{}{
@@ -32,6 +34,10 @@ func newTenantAuditCommand(env *Environment) *cobra.Command {
 	var raw bool
 	var rawPretty bool
 	var sortFields []string
+	var all bool
+	var pageSize int
+	var fields []string
+	var showDiff bool
 
 	cmd := &cobra.Command{
 		Use:   "audit",
@@ -40,7 +46,17 @@ func newTenantAuditCommand(env *Environment) *cobra.Command {
 
 View a detailed history of document creates, updates, patches, deletes, and purges with support for filtering by collection, document, actor, operation type, and time ranges.
 
-Time filters support relative durations (e.g., "48h", "7d") or absolute RFC3339 timestamps.`,
+Time filters support relative durations (e.g., "48h", "7d") or absolute RFC3339 timestamps.
+
+By default a single page of up to --limit entries (max 500) is returned. With
+--all the command follows the server's pages until the history is exhausted or
+--limit entries were collected; --limit 0 --all retrieves the complete history.
+--fields asks the server for selected attributes only, which keeps large
+retrievals small by skipping the old and new document payloads.
+
+--diff prints each entry with the fields it added (+), removed (-), or changed
+(~) instead of the table. With --raw or --raw-pretty every entry gains a
+"changes" array in the same form.`,
 		Example: `  # List recent audit logs
   tdb tenant audit --api-key $API_KEY
 
@@ -72,8 +88,21 @@ Time filters support relative durations (e.g., "48h", "7d") or absolute RFC3339
   tdb tenant audit --sort created_at --api-key $API_KEY
 
   # Pretty-print JSON output
-  tdb tenant audit --raw-pretty --limit 20`,
+  tdb tenant audit --raw-pretty --limit 20
+
+  # Show what each change to a document did, field by field
+  tdb tenant audit --document doc_123 --diff
+
+  # Complete delete history of a collection, without document payloads
+  tdb tenant audit --collection orders --operation delete --limit 0 --all \
+    --fields id,document_id,actor,created_at --raw`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit < 0 {
+				return fmt.Errorf("--limit must be zero or positive")
+			}
+			if all && (pageSize <= 0 || pageSize > auditMaxPageSize) {
+				return fmt.Errorf("--page-size must be between 1 and %d", auditMaxPageSize)
+			}
 			envCtx, err := requireEnvironment(env)
 			if err != nil {
 				return err
@@ -87,6 +116,10 @@ Time filters support relative durations (e.g., "48h", "7d") or absolute RFC3339
 				AppID:      auth.appID,
 				Limit:      limit,
 				DocumentID: strings.TrimSpace(documentFilter),
+				Fields:     splitCommaList(strings.Join(fields, ",")),
+			}
+			if showDiff && len(params.Fields) > 0 && (!slices.Contains(params.Fields, "old_data") || !slices.Contains(params.Fields, "new_data")) {
+				return fmt.Errorf("--diff needs old_data and new_data in --fields")
 			}
 
 			normalizedSort, err := normalizeSortTokens(sortFields)
@@ -152,16 +185,42 @@ Time filters support relative durations (e.g., "48h", "7d") or absolute RFC3339
 				params.CollectionID = resolvedID
 			}
 
-			logs, err := tenantClient.ListAuditLogs(cmd.Context(), params)
+			var logs []clientpkg.AuditLog
+			if all {
+				logs, err = collectAuditLogPages(tenantClient.AuditLogPages(cmd.Context(), params), limit, pageSize)
+			} else {
+				logs, err = tenantClient.ListAuditLogs(cmd.Context(), params)
+			}
 			if err != nil {
 				return err
 			}
 
+			var changes [][]diff.Change
+			if showDiff {
+				changes = make([][]diff.Change, len(logs))
+				for i, entry := range logs {
+					if changes[i], err = diff.JSON([]byte(entry.OldData), []byte(entry.NewData)); err != nil {
+						return fmt.Errorf("audit entry %d: %w", entry.ID, err)
+					}
+				}
+			}
+
 			if raw || rawPretty {
 				if rawPretty {
-					payload := map[string]any{"items": makeAuditLogsPretty(logs)}
+					items := makeAuditLogsPretty(logs)
+					for i := range changes {
+						items[i]["changes"] = nonNilChanges(changes[i])
+					}
+					payload := map[string]any{"items": items}
 					return printJSON(cmd, payload)
 				}
+				if showDiff {
+					items := make([]auditLogDiff, len(logs))
+					for i, entry := range logs {
+						items[i] = auditLogDiff{AuditLog: entry, Changes: nonNilChanges(changes[i])}
+					}
+					return printCompactJSON(cmd, map[string]any{"items": items})
+				}
 				payload := clientpkg.AuditLogListResponse{Items: logs}
 				return printCompactJSON(cmd, payload)
 			}
@@ -171,6 +230,10 @@ Time filters support relative durations (e.g., "48h", "7d") or absolute RFC3339
 				return nil
 			}
 
+			if showDiff {
+				return printAuditDiffs(cmd, logs, changes, collectionNameMap)
+			}
+
 			rows := make([][]string, 0, len(logs))
 			for _, entry := range logs {
 				collectionLabel := entry.CollectionID
@@ -201,7 +264,10 @@ Time filters support relative durations (e.g., "48h", "7d") or absolute RFC3339
 	}
 
 	auth.bindWithApp(cmd)
-	cmd.Flags().IntVar(&limit, "limit", 100, "Maximum number of audit entries to return (max 500)")
+	cmd.Flags().IntVar(&limit, "limit", 100, "Maximum number of audit entries to return (max 500 without --all; 0 with --all for no limit)")
+	cmd.Flags().BoolVar(&all, "all", false, "Follow pagination until the history is exhausted or --limit entries were collected")
+	cmd.Flags().IntVar(&pageSize, "page-size", auditMaxPageSize, "Entries requested per page with --all")
+	cmd.Flags().StringSliceVar(&fields, "fields", nil, "Only return these attributes (comma separated), e.g. id,operation,actor,created_at")
 	cmd.Flags().StringVar(&collectionFilter, "collection", "", "Filter by collection name or ID")
 	cmd.Flags().StringVar(&documentFilter, "document", "", "Filter by document ID")
 	cmd.Flags().StringVar(&operationFilter, "operation", "", "Filter by operation (create, update, patch, delete, purge)")
@@ -211,10 +277,74 @@ Time filters support relative durations (e.g., "48h", "7d") or absolute RFC3339
 	cmd.Flags().StringSliceVar(&sortFields, "sort", []string{"-created_at"}, "Sort order (comma separated). Prefix with - for descending. Fields: created_at, operation, actor, collection, document_id, id")
 	cmd.Flags().BoolVar(&raw, "raw", false, "Print compact JSON response")
 	cmd.Flags().BoolVar(&rawPretty, "raw-pretty", false, "Print pretty JSON response")
+	cmd.Flags().BoolVar(&showDiff, "diff", false, "Print the field-level changes of each entry instead of the table")
 
 	return cmd
 }
 
+// auditLogDiff is an audit entry with its field-level changes, printed by
+// --diff --raw.
+type auditLogDiff struct {
+	clientpkg.AuditLog
+	Changes []diff.Change `json:"changes"`
+}
+
+func nonNilChanges(changes []diff.Change) []diff.Change {
+	if changes == nil {
+		return []diff.Change{}
+	}
+	return changes
+}
+
+// printAuditDiffs prints one header line per entry followed by its diff.
+func printAuditDiffs(cmd *cobra.Command, logs []clientpkg.AuditLog, changes [][]diff.Change, collectionNames map[string]string) error {
+	out := cmd.OutOrStdout()
+	for i, entry := range logs {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		collection := entry.CollectionID
+		if name := strings.TrimSpace(collectionNames[entry.CollectionID]); name != "" {
+			collection = name
+		}
+		actor := strings.TrimSpace(entry.Actor)
+		if actor == "" {
+			actor = "-"
+		}
+		fmt.Fprintf(out, "%s  %s %s/%s v%d by %s (%s)\n", formatRelativeTime(entry.CreatedAt, "-"), strings.ToUpper(entry.Operation), collection, entry.DocumentID, entry.DocumentVersion, actor, diff.Summarize(changes[i]))
+		if err := writeDiff(cmd, changes[i], "  "); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectAuditLogPages gathers the entries of every page, stopping once limit
+// entries were collected when limit is positive.
+func collectAuditLogPages(pages *clientpkg.AuditLogPager, limit, pageSize int) ([]clientpkg.AuditLog, error) {
+	var logs []clientpkg.AuditLog
+	for {
+		if limit > 0 {
+			remaining := limit - len(logs)
+			if remaining <= 0 {
+				break
+			}
+			pages.SetPageSize(min(pageSize, remaining))
+		}
+		if !pages.Next() {
+			break
+		}
+		logs = append(logs, pages.Page()...)
+	}
+	if err := pages.Err(); err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(logs) > limit {
+		logs = logs[:limit]
+	}
+	return logs, nil
+}
+
 func normalizeSortTokens(tokens []string) ([]string, error) {
 	if len(tokens) == 0 {
 		tokens = []string{"-created_at"}
@@ -284,6 +414,9 @@ func parseAuditTimeArg(raw string, now time.Time) (time.Time, error) {
 	if ts, err := time.Parse(time.RFC3339, trimmed); err == nil {
 		return ts, nil
 	}
+	if ts, err := time.Parse(time.DateOnly, trimmed); err == nil {
+		return ts, nil
+	}
 	dur, err := parseFlexibleDurationArg(trimmed)
 	if err != nil {
 		return time.Time{}, err
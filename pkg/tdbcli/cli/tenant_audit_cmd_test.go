@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestAuditAllFollowsPages(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	const total = 1200
+	var limits []string
+	var fields string
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/audit" {
+			tenantRoutes.ServeHTTP(w, r)
+			return
+		}
+		query := r.URL.Query()
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limits = append(limits, query.Get("limit"))
+		fields = query.Get("fields")
+		resp := clientpkg.AuditLogListResponse{Items: []clientpkg.AuditLog{}}
+		for id := offset + 1; id <= min(offset+limit, total); id++ {
+			resp.Items = append(resp.Items, clientpkg.AuditLog{ID: uint(id), Operation: "create"})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+
+	run := func(args ...string) []clientpkg.AuditLog {
+		t.Helper()
+		limits = nil
+		out, _, err := runCommand(t, srv, newTenantAuditCommand, append([]string{"--raw"}, args...)...)
+		if err != nil {
+			t.Fatalf("audit failed: %v", err)
+		}
+		var resp clientpkg.AuditLogListResponse
+		if err := json.Unmarshal([]byte(out), &resp); err != nil {
+			t.Fatalf("decode output: %v\n%s", err, out)
+		}
+		return resp.Items
+	}
+
+	if logs := run("--limit", "0", "--all", "--fields", "id,operation"); len(logs) != total || logs[total-1].ID != total {
+		t.Fatalf("expected the complete history of %d entries, got %d", total, len(logs))
+	}
+	if fields != "id,operation" {
+		t.Fatalf("expected fields to be sent, got %q", fields)
+	}
+	logs := run("--limit", "700", "--all")
+	if len(logs) != 700 {
+		t.Fatalf("expected 700 entries, got %d", len(logs))
+	}
+	if want := []string{"500", "200"}; len(limits) != 2 || limits[0] != want[0] || limits[1] != want[1] {
+		t.Fatalf("expected the last page to request only the remainder, got limits %v", limits)
+	}
+	if logs := run("--limit", "50"); len(logs) != 50 || len(limits) != 1 {
+		t.Fatalf("expected a single page without --all, got %d entries in %d requests", len(logs), len(limits))
+	}
+}
+
+func TestAuditDiff(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{ID: "col_1", Name: "orders"})
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/audit" {
+			tenantRoutes.ServeHTTP(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(clientpkg.AuditLogListResponse{Items: []clientpkg.AuditLog{
+			{ID: 2, CollectionID: "col_1", DocumentID: "ord_1", DocumentVersion: 2, Operation: "patch", Actor: "ops", OldData: `{"status":"pending","note":"x"}`, NewData: `{"status":"shipped"}`},
+			{ID: 1, CollectionID: "col_1", DocumentID: "ord_1", DocumentVersion: 1, Operation: "create", NewData: `{"status":"pending","note":"x"}`},
+		}})
+	})
+	run := func(args ...string) (string, error) {
+		out, _, err := runCommand(t, srv, newTenantAuditCommand, append([]string{"--diff"}, args...)...)
+		return out, err
+	}
+
+	out, err := run()
+	if err != nil {
+		t.Fatalf("audit --diff failed: %v", err)
+	}
+	for _, want := range []string{
+		"PATCH orders/ord_1 v2 by ops (1 removed, 1 changed)\n  - note    \"x\"\n  ~ status  \"pending\" → \"shipped\"\n",
+		"CREATE orders/ord_1 v1 by - (2 added)\n  + note    \"x\"\n  + status  \"pending\"\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+
+	out, err = run("--raw")
+	if err != nil {
+		t.Fatalf("audit --diff --raw failed: %v", err)
+	}
+	var resp struct {
+		Items []struct {
+			ID      uint `json:"id"`
+			Changes []struct {
+				Path string `json:"path"`
+				Op   string `json:"op"`
+			} `json:"changes"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, out)
+	}
+	if len(resp.Items) != 2 || resp.Items[0].ID != 2 || len(resp.Items[0].Changes) != 2 || resp.Items[0].Changes[0].Op != "removed" {
+		t.Fatalf("unexpected raw diff %+v", resp)
+	}
+
+	if _, err := run("--fields", "id,operation"); err == nil || !strings.Contains(err.Error(), "old_data and new_data") {
+		t.Fatalf("expected --fields without payloads to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,316 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+// fakeAuditLog serves /api/audit with since/until/limit and ascending order.
+type fakeAuditLog struct {
+	mu      sync.Mutex
+	entries []clientpkg.AuditLog
+}
+
+func (f *fakeAuditLog) add(id uint, at time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entries = append(f.entries, clientpkg.AuditLog{ID: id, Operation: "create", DocumentID: "doc_" + strconv.Itoa(int(id)), CreatedAt: at})
+}
+
+func (f *fakeAuditLog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	query := r.URL.Query()
+	since, _ := time.Parse(time.RFC3339, query.Get("since"))
+	until, _ := time.Parse(time.RFC3339, query.Get("until"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	var items []clientpkg.AuditLog
+	for _, entry := range f.entries {
+		if (!since.IsZero() && entry.CreatedAt.Before(since)) || (!until.IsZero() && entry.CreatedAt.After(until)) {
+			continue
+		}
+		items = append(items, entry)
+	}
+	sortAuditEntries(items)
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	_ = json.NewEncoder(w).Encode(clientpkg.AuditLogListResponse{Items: items})
+}
+
+func newAuditShipTestServer(t *testing.T, audit *fakeAuditLog) *Environment {
+	srv := clienttest.NewServer(t)
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/audit" {
+			audit.ServeHTTP(w, r)
+			return
+		}
+		tenantRoutes.ServeHTTP(w, r)
+	})
+	return &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+}
+
+func runAuditShip(t *testing.T, env *Environment, args ...string) (string, error) {
+	t.Helper()
+	out, _, err := runCommandWithEnv(t, env, newTenantAuditShipCommand, args...)
+	return out, err
+}
+
+func TestAuditShipFileSinkResumesFromCheckpoint(t *testing.T) {
+	audit := &fakeAuditLog{}
+	base := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	// Several entries share a second so paging has to skip repeats by ID.
+	for i := 1; i <= 7; i++ {
+		audit.add(uint(i), base.Add(time.Duration(i/3)*time.Minute))
+	}
+	env := newAuditShipTestServer(t, audit)
+	dir := t.TempDir()
+	out := filepath.Join(dir, "audit.jsonl")
+	state := filepath.Join(dir, "state.json")
+	args := []string{"--sink", "file", "--out", out, "--state", state, "--since", "2h", "--page-size", "4", "--batch-size", "2"}
+
+	stdout, err := runAuditShip(t, env, args...)
+	if err != nil {
+		t.Fatalf("ship failed: %v", err)
+	}
+	if !strings.Contains(stdout, "Shipped 7 audit entries") {
+		t.Fatalf("unexpected output: %s", stdout)
+	}
+	if ids := shippedAuditIDs(t, out); ids != "1,2,3,4,5,6,7" {
+		t.Fatalf("unexpected shipped entries %s", ids)
+	}
+
+	stdout, err = runAuditShip(t, env, args...)
+	if err != nil || !strings.Contains(stdout, "No new audit entries (checkpoint id 7)") {
+		t.Fatalf("expected idempotent rerun, got %v: %s", err, stdout)
+	}
+
+	audit.add(8, time.Now().UTC().Add(-time.Minute))
+	if _, err := runAuditShip(t, env, args...); err != nil {
+		t.Fatalf("ship failed: %v", err)
+	}
+	if ids := shippedAuditIDs(t, out); ids != "1,2,3,4,5,6,7,8" {
+		t.Fatalf("unexpected shipped entries after resume %s", ids)
+	}
+	saved, err := loadAuditShipState(state)
+	if err != nil || saved.LastID != 8 || saved.Shipped != 8 {
+		t.Fatalf("unexpected state %+v (%v)", saved, err)
+	}
+}
+
+func TestAuditShipWebhookRetries(t *testing.T) {
+	previous := auditShipRetryBackoff
+	auditShipRetryBackoff = time.Millisecond
+	t.Cleanup(func() { auditShipRetryBackoff = previous })
+
+	audit := &fakeAuditLog{}
+	audit.add(1, time.Now().UTC().Add(-time.Minute))
+	audit.add(2, time.Now().UTC().Add(-time.Second))
+	env := newAuditShipTestServer(t, audit)
+
+	var attempts atomic.Int32
+	var received atomic.Int32
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var payload struct {
+			Entries []clientpkg.AuditLog `json:"entries"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		received.Add(int32(len(payload.Entries)))
+	}))
+	defer hook.Close()
+
+	state := filepath.Join(t.TempDir(), "state.json")
+	args := []string{"--sink", "webhook", "--url", hook.URL, "--sink-header", "Authorization: Bearer token", "--state", state}
+	if _, err := runAuditShip(t, env, append(args, "--retries", "0")...); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Fatalf("expected delivery failure without retries, got %v", err)
+	}
+	if saved, _ := loadAuditShipState(state); saved.LastID != 0 {
+		t.Fatalf("checkpoint advanced after a failed delivery: %+v", saved)
+	}
+	attempts.Store(0)
+	if _, err := runAuditShip(t, env, args...); err != nil {
+		t.Fatalf("ship failed: %v", err)
+	}
+	if attempts.Load() != 2 || received.Load() != 2 {
+		t.Fatalf("expected one retry delivering 2 entries, got attempts=%d received=%d", attempts.Load(), received.Load())
+	}
+}
+
+func shippedAuditIDs(t *testing.T, path string) string {
+	t.Helper()
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read sink: %v", err)
+	}
+	var ids []string
+	for _, line := range strings.Split(strings.TrimSpace(string(raw)), "\n") {
+		var entry clientpkg.AuditLog
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("decode %q: %v", line, err)
+		}
+		ids = append(ids, strconv.Itoa(int(entry.ID)))
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestAuditShipFollowReloadsRotatedKey(t *testing.T) {
+	audit := &fakeAuditLog{}
+	audit.add(1, time.Now().UTC().Add(-time.Minute))
+	srv := clienttest.NewServer(t)
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "rotated-key" {
+			http.Error(w, "invalid api key", http.StatusUnauthorized)
+			return
+		}
+		audit.ServeHTTP(w, r)
+	})
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	writeConfig := func(key string) {
+		cfg := &configpkg.Config{Endpoint: srv.URL, Tenants: map[string]configpkg.TenantConfig{
+			"tn_test": {DefaultKey: "ci", Keys: map[string]configpkg.APIKeyEntry{"ci": {Key: key}}},
+		}}
+		if err := cfg.Save(configPath); err != nil {
+			t.Fatalf("save config: %v", err)
+		}
+	}
+	writeConfig("old-key")
+	cfg, err := configpkg.Load(configPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	env := &Environment{ConfigPath: configPath, Config: cfg}
+
+	out := filepath.Join(dir, "audit.jsonl")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cmd := newTenantAuditShipCommand(env)
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"--tenant", "tn_test", "--sink", "file", "--out", out, "--state", filepath.Join(dir, "state.json"), "--follow", "--interval", "10ms"})
+	cmd.SetOut(&bytes.Buffer{})
+	var stderr syncBuffer
+	cmd.SetErr(&stderr)
+	done := make(chan error, 1)
+	go func() { done <- cmd.ExecuteContext(ctx) }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(stderr.String(), "invalid api key") {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected a failed cycle with the old key, stderr: %s", stderr.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	writeConfig("rotated-key")
+	for {
+		if data, _ := os.ReadFile(out); strings.Contains(string(data), "doc_1") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("entries were not shipped after key rotation, stderr: %s", stderr.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("follow mode returned error: %v", err)
+	}
+	if !strings.Contains(stderr.String(), "reloaded credentials from "+configPath) {
+		t.Fatalf("expected reload to be logged, stderr: %s", stderr.String())
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent writers and readers.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestAuditShipKeepsAPIAndSinkHeadersApart(t *testing.T) {
+	defer func() { activeHeaders = nil }()
+	audit := &fakeAuditLog{}
+	audit.add(1, time.Now().UTC().Add(-time.Minute))
+	srv := clienttest.NewServer(t)
+	var mu sync.Mutex
+	var apiHeaders, hookHeaders []http.Header
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		apiHeaders = append(apiHeaders, r.Header.Clone())
+		mu.Unlock()
+		if r.URL.Path == "/api/audit" {
+			audit.ServeHTTP(w, r)
+			return
+		}
+		tenantRoutes.ServeHTTP(w, r)
+	})
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hookHeaders = append(hookHeaders, r.Header.Clone())
+		mu.Unlock()
+	}))
+	defer hook.Close()
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("endpoint: "+srv.URL+"\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	root := NewRootCommand()
+	root.SetArgs([]string{"--config", configPath, "tenant", "audit", "ship",
+		"--sink", "webhook", "--url", hook.URL, "--state", filepath.Join(dir, "state.json"),
+		"--header", "X-Org-Token: gateway", "--sink-header", "Authorization: Bearer siem",
+		"--tenant", "tn_test", "--api-key", clienttest.APIKey})
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("ship with --header and --sink-header failed: %v", err)
+	}
+	if len(apiHeaders) == 0 || len(hookHeaders) != 1 {
+		t.Fatalf("expected API requests and one webhook delivery, got %d and %d", len(apiHeaders), len(hookHeaders))
+	}
+	for _, h := range apiHeaders {
+		if h.Get("X-Org-Token") != "gateway" || h.Get("Authorization") != "" {
+			t.Fatalf("unexpected API request headers %v", h)
+		}
+	}
+	if h := hookHeaders[0]; h.Get("Authorization") != "Bearer siem" || h.Get("X-Org-Token") != "" {
+		t.Fatalf("unexpected webhook headers %v", h)
+	}
+}
@@ -2,29 +2,55 @@ package cli
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const (
+	keyExpiryWarningWindow = 7 * 24 * time.Hour
+	keyIdleWarningAge      = 90 * 24 * time.Hour
 )
 
 func newTenantAuthCommand(env *Environment) *cobra.Command {
 	var auth authFlags
 	var raw bool
+	var allKeys bool
+	var refresh bool
 
 	cmd := &cobra.Command{
 		Use:   "auth",
 		Short: "Verify the configured API key by calling /api/me",
+		Long: `Verify the configured API key by calling /api/me and show its scope, usage, and
+warnings such as upcoming expiry, revocation, or long inactivity.
+
+Use --all-keys to check every stored key alias for the tenant side by side, which
+helps pick the right key when several are configured.
+
+Answers are cached per key for a minute; --refresh calls /api/me regardless.`,
+		Example: `  # Check the default key
+  tdb tenant auth
+
+  # Compare all stored keys for the tenant
+  tdb tenant auth --all-keys`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			envCtx, err := requireEnvironment(env)
 			if err != nil {
 				return err
 			}
+			if allKeys {
+				return runTenantAuthAllKeys(cmd, envCtx, &auth, raw, refresh)
+			}
 			tenantClient, keyEntry, tenantID, err := auth.resolveTenantClient(envCtx, cmd)
 			if err != nil {
 				return err
 			}
-			status, err := tenantClient.AuthStatus(cmd.Context(), auth.appID)
+			status, err := cachedAuthStatus(cmd.Context(), envCtx, tenantClient, keyEntry.Key, auth.appID, refresh)
 			if err != nil {
 				return err
 			}
@@ -57,6 +83,7 @@ func newTenantAuthCommand(env *Environment) *cobra.Command {
 			if statusText == "" {
 				statusText = "unknown"
 			}
+			fmt.Fprintf(out, "Status: %s\n", statusText)
 
 			if scope := strings.TrimSpace(status.Scope); scope != "" {
 				fmt.Fprintf(out, "Scope: %s\n", scope)
@@ -70,11 +97,172 @@ func newTenantAuthCommand(env *Environment) *cobra.Command {
 				fmt.Fprintf(out, "Last Used: %s\n", humanize.Time(*status.LastUsed))
 			}
 
+			if status.ExpiresAt != nil {
+				fmt.Fprintf(out, "Expires: %s (%s)\n", formatTime(*status.ExpiresAt), humanize.Time(*status.ExpiresAt))
+			}
+
+			if status.RequestCount != nil {
+				fmt.Fprintf(out, "Requests: %s\n", humanize.Comma(*status.RequestCount))
+			}
+
+			if len(status.RequestsByEndpoint) > 0 {
+				fmt.Fprintln(out)
+				renderTable(cmd, []string{"ENDPOINT", "REQUESTS"}, endpointUsageRows(status.RequestsByEndpoint))
+			}
+
+			if warnings := keyWarnings(status, time.Now()); len(warnings) > 0 {
+				fmt.Fprintln(out)
+				for _, warning := range warnings {
+					fmt.Fprintf(out, "⚠ %s\n", warning)
+				}
+			}
+
 			return nil
 		},
 	}
 
 	auth.bindWithApp(cmd)
 	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON response")
+	cmd.Flags().BoolVar(&allKeys, "all-keys", false, "Check every stored key alias for the tenant")
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "Call /api/me even when a cached status is fresh")
 	return cmd
 }
+
+// keyAuthResult captures the status of one stored key alias.
+type keyAuthResult struct {
+	Alias    string                `json:"alias"`
+	Default  bool                  `json:"default"`
+	Status   *clientpkg.AuthStatus `json:"status,omitempty"`
+	Error    string                `json:"error,omitempty"`
+	Warnings []string              `json:"warnings,omitempty"`
+}
+
+func runTenantAuthAllKeys(cmd *cobra.Command, envCtx *Environment, auth *authFlags, raw, refresh bool) error {
+	tenantID := strings.TrimSpace(auth.tenantID)
+	if tenantID == "" {
+		tenantID = strings.TrimSpace(envCtx.Config.DefaultTenant)
+	}
+	tc, ok := envCtx.Config.Tenants[tenantID]
+	if tenantID == "" || !ok || len(tc.Keys) == 0 {
+		return fmt.Errorf("no stored keys found for tenant %q", tenantID)
+	}
+	aliases := make([]string, 0, len(tc.Keys))
+	for alias := range tc.Keys {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	now := time.Now()
+	results := make([]keyAuthResult, 0, len(aliases))
+	for _, alias := range aliases {
+		result := keyAuthResult{Alias: alias, Default: alias == tc.DefaultKey}
+		tenantClient, entry, err := tenantClientFromEnv(envCtx, tenantID, alias, "")
+		if err == nil {
+			appID := strings.TrimSpace(auth.appID)
+			if appID == "" {
+				appID = entry.AppID
+			}
+			result.Status, err = cachedAuthStatus(cmd.Context(), envCtx, tenantClient, entry.Key, appID, refresh)
+		}
+		if err != nil {
+			result.Error = err.Error()
+			result.Warnings = []string{"key failed authentication"}
+		} else {
+			if result.Status.KeyPrefix == "" {
+				result.Status.KeyPrefix = entry.Prefix
+			}
+			result.Warnings = keyWarnings(result.Status, now)
+		}
+		results = append(results, result)
+	}
+	if raw {
+		return printJSON(cmd, results)
+	}
+
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		alias := result.Alias
+		if result.Default {
+			alias += " *"
+		}
+		if result.Status == nil {
+			rows = append(rows, []string{alias, "-", "-", "error", "-", "-", "-", result.Error})
+			continue
+		}
+		st := result.Status
+		scope := strings.TrimSpace(st.Scope)
+		if scope == "" && st.AppID != "" {
+			scope = "app:" + st.AppID
+		}
+		requests := "-"
+		if st.RequestCount != nil {
+			requests = humanize.Comma(*st.RequestCount)
+		}
+		expires := "-"
+		if st.ExpiresAt != nil {
+			expires = formatRelativeTimePtr(st.ExpiresAt, "-")
+		}
+		rows = append(rows, []string{
+			alias,
+			firstNonEmpty(st.KeyPrefix, "-"),
+			firstNonEmpty(scope, "tenant"),
+			firstNonEmpty(st.Status, "unknown"),
+			formatRelativeTimePtr(st.LastUsed, "never"),
+			expires,
+			requests,
+			strings.Join(result.Warnings, "; "),
+		})
+	}
+	renderTable(cmd, []string{"ALIAS", "PREFIX", "SCOPE", "STATUS", "LAST USED", "EXPIRES", "REQUESTS", "WARNINGS"}, rows)
+	fmt.Fprintln(cmd.OutOrStdout(), "* default key")
+	return nil
+}
+
+// keyWarnings flags keys that are revoked, expired or expiring soon, or idle
+// long enough that they may be cleaned up.
+func keyWarnings(status *clientpkg.AuthStatus, now time.Time) []string {
+	if status == nil {
+		return nil
+	}
+	var warnings []string
+	state := strings.ToLower(strings.TrimSpace(status.Status))
+	switch {
+	case status.RevokedAt != nil || state == "revoked":
+		warnings = append(warnings, "key is revoked")
+	case state != "" && state != "active":
+		warnings = append(warnings, fmt.Sprintf("key status is %s", state))
+	}
+	if status.ExpiresAt != nil {
+		remaining := status.ExpiresAt.Sub(now)
+		switch {
+		case remaining <= 0:
+			warnings = append(warnings, "key has expired")
+		case remaining <= keyExpiryWarningWindow:
+			warnings = append(warnings, fmt.Sprintf("key expires %s", humanize.RelTime(*status.ExpiresAt, now, "ago", "from now")))
+		}
+	}
+	if status.LastUsed == nil {
+		warnings = append(warnings, "key has never been used")
+	} else if idle := now.Sub(*status.LastUsed); idle >= keyIdleWarningAge {
+		warnings = append(warnings, fmt.Sprintf("key unused for %d days", int(idle.Hours()/24)))
+	}
+	return warnings
+}
+
+func endpointUsageRows(usage map[string]int64) [][]string {
+	endpoints := make([]string, 0, len(usage))
+	for endpoint := range usage {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		if usage[endpoints[i]] != usage[endpoints[j]] {
+			return usage[endpoints[i]] > usage[endpoints[j]]
+		}
+		return endpoints[i] < endpoints[j]
+	})
+	rows := make([][]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		rows = append(rows, []string{endpoint, humanize.Comma(usage[endpoint])})
+	}
+	return rows
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+func TestKeyWarnings(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	recent := now.Add(-time.Hour)
+	idle := now.Add(-120 * 24 * time.Hour)
+	soon := now.Add(48 * time.Hour)
+	past := now.Add(-time.Hour)
+	later := now.Add(60 * 24 * time.Hour)
+
+	cases := []struct {
+		name   string
+		status clientpkg.AuthStatus
+		want   []string
+	}{
+		{name: "healthy", status: clientpkg.AuthStatus{Status: "active", LastUsed: &recent, ExpiresAt: &later}},
+		{name: "never used", status: clientpkg.AuthStatus{Status: "active"}, want: []string{"never been used"}},
+		{name: "idle", status: clientpkg.AuthStatus{Status: "active", LastUsed: &idle}, want: []string{"unused for 120 days"}},
+		{name: "expiring", status: clientpkg.AuthStatus{Status: "active", LastUsed: &recent, ExpiresAt: &soon}, want: []string{"expires 2 days from now"}},
+		{name: "expired", status: clientpkg.AuthStatus{Status: "active", LastUsed: &recent, ExpiresAt: &past}, want: []string{"has expired"}},
+		{name: "revoked", status: clientpkg.AuthStatus{Status: "revoked", LastUsed: &recent}, want: []string{"revoked"}},
+		{name: "suspended", status: clientpkg.AuthStatus{Status: "suspended", LastUsed: &recent}, want: []string{"status is suspended"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status := tc.status
+			got := keyWarnings(&status, now)
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+			for i, fragment := range tc.want {
+				if !strings.Contains(got[i], fragment) {
+					t.Fatalf("warning %q does not contain %q", got[i], fragment)
+				}
+			}
+		})
+	}
+}
+
+func TestEndpointUsageRowsSortedByCount(t *testing.T) {
+	rows := endpointUsageRows(map[string]int64{"GET /api/me": 3, "POST /api/collections/users/documents": 1200, "GET /api/collections": 3})
+	if rows[0][0] != "POST /api/collections/users/documents" || rows[0][1] != "1,200" {
+		t.Fatalf("unexpected first row %v", rows[0])
+	}
+	if rows[1][0] != "GET /api/collections" || rows[2][0] != "GET /api/me" {
+		t.Fatalf("expected ties sorted by name, got %v", rows)
+	}
+}
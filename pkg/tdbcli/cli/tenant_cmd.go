@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -25,12 +26,32 @@ func registerTenantCommands(root *cobra.Command, env *Environment) {
 	appsCmd.AddCommand(newTenantAppsListCommand(env))
 	appsCmd.AddCommand(newTenantAppsCreateCommand(env))
 	appsCmd.AddCommand(newTenantAppsGetCommand(env))
+	appsCmd.AddCommand(newTenantAppsSyncCommand(env))
 
 	tenantCmd.AddCommand(appsCmd)
 
+	tenantCmd.AddCommand(newTenantCollectionsGroupCommand(env))
+	tenantCmd.AddCommand(newTenantDocumentsGroupCommand(env))
+	tenantCmd.AddCommand(newTenantQueriesGroupCommand(env))
+
+	auditCmd := newTenantAuditCommand(env)
+	auditCmd.AddCommand(newTenantAuditShipCommand(env))
+	tenantCmd.AddCommand(auditCmd)
+
+	authCmd := newTenantAuthCommand(env)
+	tenantCmd.AddCommand(authCmd)
+
+	snapshotsCmd := newTenantSnapshotsCommand(env)
+	tenantCmd.AddCommand(snapshotsCmd)
+
+	root.AddCommand(tenantCmd)
+}
+
+func newTenantCollectionsGroupCommand(env *Environment) *cobra.Command {
 	collectionsCmd := &cobra.Command{
-		Use:   "collections",
-		Short: "Manage collections for a tenant",
+		Use:     "collections",
+		Aliases: []string{"cols"},
+		Short:   "Manage collections for a tenant",
 	}
 	collectionsCmd.AddCommand(newTenantCollectionsListCommand(env))
 	collectionsCmd.AddCommand(newTenantCollectionsGetCommand(env))
@@ -38,15 +59,26 @@ func registerTenantCommands(root *cobra.Command, env *Environment) {
 	collectionsCmd.AddCommand(newTenantCollectionsUpdateCommand(env))
 	collectionsCmd.AddCommand(newTenantCollectionsSyncCommand(env))
 	collectionsCmd.AddCommand(newTenantCollectionsDeleteCommand(env))
+	collectionsCmd.AddCommand(newTenantCollectionsRestoreCommand(env))
+	collectionsCmd.AddCommand(newTenantCollectionsArchiveCommand(env))
+	collectionsCmd.AddCommand(newTenantCollectionsUnarchiveCommand(env))
 	collectionsCmd.AddCommand(newTenantCollectionsCountCommand(env))
-	tenantCmd.AddCommand(collectionsCmd)
+	collectionsCmd.AddCommand(newTenantCollectionsSetLimitsCommand(env))
+	collectionsCmd.AddCommand(newTenantCollectionsHooksCommand(env))
+	collectionsCmd.AddCommand(newTenantCollectionsValidateCommand(env))
+	collectionsCmd.AddCommand(newTenantCollectionsScaffoldCommand(env))
+	return collectionsCmd
+}
 
+func newTenantDocumentsGroupCommand(env *Environment) *cobra.Command {
 	documentsCmd := &cobra.Command{
-		Use:   "documents",
-		Short: "Manage collection documents",
+		Use:     "documents",
+		Aliases: []string{"docs"},
+		Short:   "Manage collection documents",
 	}
 	documentsCmd.AddCommand(newTenantDocumentsListCommand(env))
 	documentsCmd.AddCommand(newTenantDocumentsGetCommand(env))
+	documentsCmd.AddCommand(newTenantDocumentsExistsCommand(env))
 	documentsCmd.AddCommand(newTenantDocumentsCreateCommand(env))
 	documentsCmd.AddCommand(newTenantDocumentsUpdateCommand(env))
 	documentsCmd.AddCommand(newTenantDocumentsPatchCommand(env))
@@ -56,11 +88,27 @@ func registerTenantCommands(root *cobra.Command, env *Environment) {
 	documentsCmd.AddCommand(newTenantDocumentsReportCommand(env))
 	documentsCmd.AddCommand(newTenantDocumentsExportCommand(env))
 	documentsCmd.AddCommand(newTenantDocumentsSyncCommand(env))
-	tenantCmd.AddCommand(documentsCmd)
+	documentsCmd.AddCommand(newTenantDocumentsAnonymizeCommand(env))
+	documentsCmd.AddCommand(newTenantDocumentsSampleCommand(env))
+	documentsCmd.AddCommand(newTenantDocumentsHeadCommand(env))
+	documentsCmd.AddCommand(newTenantDocumentsTailCommand(env))
+	documentsCmd.AddCommand(newTenantDocumentsCopyCommand(env))
+	documentsCmd.AddCommand(newTenantDocumentsGCCommand(env))
+	documentsCmd.AddCommand(newTenantDocumentsDupesCommand(env))
+	documentsCmd.AddCommand(newTenantDocumentsFieldStatsCommand(env))
+	documentsCmd.AddCommand(newTenantDocumentsVerifyCommand(env))
+	documentsCmd.AddCommand(newTenantDocumentsLockCommand(env))
+	documentsCmd.AddCommand(newTenantDocumentsUnlockCommand(env))
+	documentsCmd.AddCommand(newTenantDocumentsWatchCommand(env))
+	documentsCmd.AddCommand(newTenantDocumentsPreviewCommand(env))
+	return documentsCmd
+}
 
+func newTenantQueriesGroupCommand(env *Environment) *cobra.Command {
 	queriesCmd := &cobra.Command{
-		Use:   "queries",
-		Short: "Manage saved queries",
+		Use:     "queries",
+		Aliases: []string{"q"},
+		Short:   "Manage saved queries",
 	}
 	queriesCmd.AddCommand(newTenantQueriesListCommand(env))
 	queriesCmd.AddCommand(newTenantQueriesGetCommand(env))
@@ -68,20 +116,32 @@ func registerTenantCommands(root *cobra.Command, env *Environment) {
 	queriesCmd.AddCommand(newTenantQueriesPutCommand(env))
 	queriesCmd.AddCommand(newTenantQueriesPatchCommand(env))
 	queriesCmd.AddCommand(newTenantQueriesExecuteCommand(env))
+	queriesCmd.AddCommand(newTenantQueriesPipelineCommand(env))
 	queriesCmd.AddCommand(newTenantQueriesDeleteCommand(env))
 	queriesCmd.AddCommand(newTenantQueriesParamsTemplateCommand(env))
-	tenantCmd.AddCommand(queriesCmd)
-
-	auditCmd := newTenantAuditCommand(env)
-	tenantCmd.AddCommand(auditCmd)
-
-	authCmd := newTenantAuthCommand(env)
-	tenantCmd.AddCommand(authCmd)
-
-	snapshotsCmd := newTenantSnapshotsCommand(env)
-	tenantCmd.AddCommand(snapshotsCmd)
+	queriesCmd.AddCommand(newTenantQueriesExportCurlCommand(env))
+	queriesCmd.AddCommand(newTenantQueriesRenderCommand(env))
+	queriesCmd.AddCommand(newTenantQueriesSearchCommand(env))
+	queriesCmd.AddCommand(newTenantQueriesFavoritesCommand(env))
+	return queriesCmd
+}
 
-	root.AddCommand(tenantCmd)
+// registerShortcutCommands exposes frequently used tenant command groups at the
+// top level (tdb docs, tdb cols, tdb q) so daily operations need less typing.
+func registerShortcutCommands(root *cobra.Command, env *Environment) {
+	shortcuts := []func(*Environment) *cobra.Command{
+		newTenantCollectionsGroupCommand,
+		newTenantDocumentsGroupCommand,
+		newTenantQueriesGroupCommand,
+	}
+	for _, build := range shortcuts {
+		group := build(env)
+		full := group.Name()
+		group.Use = group.Aliases[0]
+		group.Aliases = nil
+		group.Short = fmt.Sprintf("%s (shortcut for tdb tenant %s)", group.Short, full)
+		root.AddCommand(group)
+	}
 }
 
 type authFlags struct {
@@ -89,6 +149,7 @@ type authFlags struct {
 	keyAlias string
 	apiKey   string
 	appID    string
+	appRef   string
 }
 
 func (a *authFlags) bind(cmd *cobra.Command) {
@@ -99,7 +160,8 @@ func (a *authFlags) bind(cmd *cobra.Command) {
 
 func (a *authFlags) bindWithApp(cmd *cobra.Command) {
 	a.bind(cmd)
-	cmd.Flags().StringVar(&a.appID, "app-id", "", "Application ID to scope requests (defaults to stored key scope when available)")
+	cmd.Flags().StringVar(&a.appID, "app-id", "", "Application ID or name to scope requests (defaults to stored key scope when available)")
+	cmd.Flags().StringVar(&a.appRef, "app", "", "Application ID or name to scope requests (alias for --app-id)")
 }
 
 func (a *authFlags) resolveTenantClient(env *Environment, cmd *cobra.Command) (*clientpkg.TenantClient, configpkg.APIKeyEntry, string, error) {
@@ -112,19 +174,34 @@ func (a *authFlags) resolveTenantClient(env *Environment, cmd *cobra.Command) (*
 		tenantID = strings.TrimSpace(envCtx.Config.DefaultTenant)
 	}
 	if tenantID == "" {
-		return nil, configpkg.APIKeyEntry{}, "", errors.New("--tenant is required (set a default via `tdb config set default-tenant <tenant_id>`)")
+		return nil, configpkg.APIKeyEntry{}, "", errors.New(tr("--tenant is required (set a default via `tdb config set default-tenant <tenant_id>`)"))
 	}
 	client, entry, err := tenantClientFromEnv(env, tenantID, strings.TrimSpace(a.keyAlias), strings.TrimSpace(a.apiKey))
 	if err != nil {
 		return nil, configpkg.APIKeyEntry{}, "", err
 	}
-	if strings.TrimSpace(a.appID) == "" {
-		if trimmed := strings.TrimSpace(entry.AppID); trimmed != "" {
-			a.appID = trimmed
-			if cmd != nil {
-				if flag := cmd.Flags().Lookup("app-id"); flag != nil && !flag.Changed {
-					fmt.Fprintf(cmd.OutOrStdout(), "Using stored app scope %s\n", trimmed)
-				}
+	if ref := strings.TrimSpace(a.appRef); ref != "" {
+		if strings.TrimSpace(a.appID) != "" && strings.TrimSpace(a.appID) != ref {
+			return nil, configpkg.APIKeyEntry{}, "", errors.New("--app and --app-id cannot be combined")
+		}
+		a.appID = ref
+	}
+	if ref := strings.TrimSpace(a.appID); ref != "" {
+		ctx := context.Background()
+		if cmd != nil && cmd.Context() != nil {
+			ctx = cmd.Context()
+		}
+		resolved, err := resolveApplicationRef(ctx, env, tenantID, client, ref)
+		if err != nil {
+			return nil, configpkg.APIKeyEntry{}, "", err
+		}
+		a.appID = resolved
+		a.appRef = ""
+	} else if trimmed := strings.TrimSpace(entry.AppID); trimmed != "" {
+		a.appID = trimmed
+		if cmd != nil {
+			if flag := cmd.Flags().Lookup("app-id"); flag != nil && !flag.Changed {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Using stored app scope %s\n", trimmed)
 			}
 		}
 	}
@@ -210,9 +287,9 @@ func newTenantAppsCreateCommand(env *Environment) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "Created application %s (%s)\n", app.Name, app.ID)
+			fmt.Fprintf(cmd.OutOrStdout(), tr("Created application %s (%s)\n"), app.Name, app.ID)
 			if generatedKey != nil {
-				fmt.Fprintf(cmd.OutOrStdout(), "Generated key: %s (prefix %s)\n", generatedKey.APIKey, generatedKey.Prefix)
+				fmt.Fprintf(cmd.OutOrStdout(), tr("Generated key: %s (prefix %s)\n"), generatedKey.APIKey, generatedKey.Prefix)
 				if strings.TrimSpace(storeAlias) != "" {
 					entry := configpkg.APIKeyEntry{Key: generatedKey.APIKey, Prefix: generatedKey.Prefix, AppID: app.ID}
 					if generatedKey.Description != nil {
@@ -221,7 +298,7 @@ func newTenantAppsCreateCommand(env *Environment) *cobra.Command {
 					if err := storeAPIKey(envCtx, resolvedTenantID, storeAlias, entry, setDefault, strings.TrimSpace(tenantLabel)); err != nil {
 						return fmt.Errorf("application created but failed to store key: %w", err)
 					}
-					fmt.Fprintf(cmd.OutOrStdout(), "Stored generated key as %s\n", storeAlias)
+					fmt.Fprintf(cmd.OutOrStdout(), tr("Stored generated key as %s\n"), storeAlias)
 				}
 			}
 			tenantLabelTrim := strings.TrimSpace(tenantLabel)
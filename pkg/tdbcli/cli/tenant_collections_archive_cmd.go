@@ -0,0 +1,279 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const collectionArchiveManifestFile = "archive.json"
+
+// collectionArchiveManifest records what collections archive produced so that
+// unarchive can recreate the collection and restore its snapshot.
+type collectionArchiveManifest struct {
+	Collection    string                    `json:"collection"`
+	CollectionID  string                    `json:"collection_id"`
+	AppID         string                    `json:"app_id,omitempty"`
+	SchemaJSON    string                    `json:"schema_json,omitempty"`
+	PrimaryKey    *clientpkg.PrimaryKeySpec `json:"primary_key,omitempty"`
+	SnapshotID    string                    `json:"snapshot_id"`
+	DocumentCount int64                     `json:"document_count"`
+	ArchivedAt    time.Time                 `json:"archived_at"`
+	Export        tenantExportedTable       `json:"export"`
+}
+
+func newTenantCollectionsArchiveCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var outDir string
+	var confirm bool
+
+	cmd := &cobra.Command{
+		Use:   "archive <name>",
+		Short: "Snapshot, export, and then delete a collection",
+		Long: `Retire a collection without losing the ability to bring it back.
+
+The archive runs in order and stops at the first failure, leaving the live
+collection untouched:
+  1. create a snapshot of the collection
+  2. verify the snapshot holds as many documents as the collection
+  3. export every document (including soft-deleted ones) as NDJSON to --out,
+     read it back, and check it against its SHA-256 checksum
+  4. write archive.json with the snapshot ID and collection definition
+  5. delete the live collection
+
+Use "tdb tenant collections unarchive" to restore it from the snapshot.`,
+		Example: `  # Retire last year's events
+  tdb tenant collections archive events_2024 --out archives/events_2024 --confirm
+
+  # Bring it back later
+  tdb tenant collections unarchive events_2024 --from archives/events_2024`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.TrimSpace(args[0])
+			if name == "" {
+				return errors.New(tr("collection name cannot be empty"))
+			}
+			if strings.TrimSpace(outDir) == "" {
+				return errors.New("--out is required")
+			}
+			if !confirm {
+				return errors.New("use --confirm to acknowledge that the live collection is deleted after archiving")
+			}
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			out := cmd.OutOrStdout()
+
+			col, err := tenantClient.GetCollection(ctx, name, auth.appID)
+			if err != nil {
+				return err
+			}
+			dir := filepath.Clean(strings.TrimSpace(outDir))
+			if err := ensureEmptyDir(dir); err != nil {
+				return err
+			}
+			count, err := tenantClient.CountDocuments(ctx, name, auth.appID)
+			if err != nil {
+				return err
+			}
+
+			snapshot, err := tenantClient.CreateSnapshot(ctx, clientpkg.CreateSnapshotRequest{
+				CollectionID: col.ID,
+				Name:         fmt.Sprintf("archive-%s-%s", name, time.Now().UTC().Format("20060102T150405Z")),
+				Description:  "Archive of collection " + name,
+			})
+			if err != nil {
+				return fmt.Errorf("create snapshot: %w", err)
+			}
+			fmt.Fprintf(out, "Created snapshot %s\n", snapshot.ID)
+			verified, err := tenantClient.GetSnapshot(ctx, snapshot.ID)
+			if err != nil {
+				return fmt.Errorf("verify snapshot %s: %w", snapshot.ID, err)
+			}
+			if int64(verified.DocumentCount) != count {
+				return fmt.Errorf("snapshot %s holds %d documents but the collection has %d; collection not deleted", snapshot.ID, verified.DocumentCount, count)
+			}
+			fmt.Fprintf(out, "Verified snapshot %s (%d documents)\n", snapshot.ID, count)
+
+			manifest := collectionArchiveManifest{
+				Collection:    col.Name,
+				CollectionID:  col.ID,
+				AppID:         strings.TrimSpace(auth.appID),
+				SchemaJSON:    col.SchemaJSON,
+				SnapshotID:    snapshot.ID,
+				DocumentCount: count,
+				ArchivedAt:    time.Now().UTC(),
+				Export:        tenantExportedTable{Collection: col.Name, CollectionID: col.ID, AppID: strings.TrimSpace(auth.appID), File: tenantExportFileName(*col, "")},
+			}
+			if field := strings.TrimSpace(col.PrimaryKeyField); field != "" {
+				auto := col.PrimaryKeyAuto
+				manifest.PrimaryKey = &clientpkg.PrimaryKeySpec{Field: field, Type: col.PrimaryKeyType, Auto: &auto}
+			}
+			if err := exportTenantCollection(ctx, tenantClient, dir, &manifest.Export); err != nil {
+				return fmt.Errorf("export failed; collection not deleted: %w", err)
+			}
+			if err := verifyTenantExport(dir, &tenantExportManifest{Collections: []tenantExportedTable{manifest.Export}}); err != nil {
+				return fmt.Errorf("export verification failed; collection not deleted: %w", err)
+			}
+			fmt.Fprintf(out, "Exported and verified %d documents to %s\n", manifest.Export.Documents, filepath.Join(dir, manifest.Export.File))
+			if err := writeCollectionArchiveManifest(dir, manifest); err != nil {
+				return fmt.Errorf("write archive manifest; collection not deleted: %w", err)
+			}
+			if err := confirmDestructive(cmd, envCtx, "delete collection "+name+" after archiving", fmt.Sprintf("Documents: %d", count), "Snapshot: "+snapshot.ID, "Archive: "+dir); err != nil {
+				return err
+			}
+
+			if err := tenantClient.DeleteCollection(ctx, name, auth.appID); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Deleted collection %s; restore with: tdb tenant collections unarchive %s --from %s\n", name, name, dir)
+			return nil
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().StringVar(&outDir, "out", "", "Empty directory for the NDJSON export and archive.json (required)")
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm deleting the live collection once the archive is verified")
+	bindSafeModeYes(cmd)
+	return cmd
+}
+
+func newTenantCollectionsUnarchiveCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var fromDir string
+	var snapshotID string
+
+	cmd := &cobra.Command{
+		Use:   "unarchive <name>",
+		Short: "Restore an archived collection from its snapshot",
+		Long: `Restore a collection retired with "tdb tenant collections archive".
+
+With --from, the collection is recreated from archive.json (schema and primary
+key) and the archive snapshot is restored into it. With only --snapshot, the
+snapshot is restored to its original collection.`,
+		Example: `  tdb tenant collections unarchive events_2024 --from archives/events_2024
+  tdb tenant collections unarchive events_2024 --snapshot snap_123`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.TrimSpace(args[0])
+			if name == "" {
+				return errors.New(tr("collection name cannot be empty"))
+			}
+			from := strings.TrimSpace(fromDir)
+			snapshotID = strings.TrimSpace(snapshotID)
+			if from == "" && snapshotID == "" {
+				return errors.New("--from or --snapshot is required")
+			}
+			var manifest *collectionArchiveManifest
+			if from != "" {
+				loaded, err := readCollectionArchiveManifest(from)
+				if err != nil {
+					return err
+				}
+				if loaded.Collection != name {
+					return fmt.Errorf("archive in %s is for collection %s, not %s", from, loaded.Collection, name)
+				}
+				if snapshotID != "" && snapshotID != loaded.SnapshotID {
+					return fmt.Errorf("--snapshot %s does not match the archive snapshot %s", snapshotID, loaded.SnapshotID)
+				}
+				snapshotID = loaded.SnapshotID
+				manifest = loaded
+			}
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			out := cmd.OutOrStdout()
+			appID := auth.appID
+			if manifest != nil && appID == "" {
+				appID = manifest.AppID
+			}
+
+			if _, err := tenantClient.GetCollection(ctx, name, appID); err == nil {
+				return fmt.Errorf("collection %s already exists; delete or rename it before unarchiving", name)
+			}
+
+			request := clientpkg.RestoreSnapshotRequest{}
+			if manifest != nil {
+				col, err := tenantClient.CreateCollection(ctx, clientpkg.CreateCollectionRequest{
+					Name:       name,
+					Schema:     manifest.SchemaJSON,
+					AppID:      appID,
+					PrimaryKey: manifest.PrimaryKey,
+				})
+				var conflict *clientpkg.ConflictError
+				switch {
+				case errors.As(err, &conflict):
+					// The deleted collection still holds the name; undelete it
+					// and restore the snapshot over it.
+					col, err = tenantClient.RestoreCollection(ctx, name, appID)
+					if err != nil {
+						return fmt.Errorf("undelete collection: %w", err)
+					}
+					fmt.Fprintf(out, "Undeleted collection %s (%s)\n", col.Name, col.ID)
+				case err != nil:
+					return fmt.Errorf("recreate collection: %w", err)
+				default:
+					fmt.Fprintf(out, "Recreated collection %s (%s)\n", col.Name, col.ID)
+				}
+				request.TargetCollectionID = col.ID
+			}
+			result, err := tenantClient.RestoreSnapshot(ctx, snapshotID, request)
+			if err != nil {
+				return fmt.Errorf("restore snapshot %s: %w", snapshotID, err)
+			}
+			fmt.Fprintf(out, "Restored %d documents from snapshot %s\n", result.DocumentsRestored, snapshotID)
+			if manifest != nil && int64(result.DocumentsRestored) != manifest.DocumentCount {
+				return fmt.Errorf("restored %d documents but the archive recorded %d; the NDJSON copy is in %s", result.DocumentsRestored, manifest.DocumentCount, filepath.Join(from, manifest.Export.File))
+			}
+			return nil
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().StringVar(&fromDir, "from", "", "Archive directory written by collections archive")
+	cmd.Flags().StringVar(&snapshotID, "snapshot", "", "Snapshot ID to restore (defaults to the one recorded in --from)")
+	return cmd
+}
+
+func writeCollectionArchiveManifest(dir string, manifest collectionArchiveManifest) error {
+	encoded, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, collectionArchiveManifestFile), append(encoded, '\n'), 0o600)
+}
+
+func readCollectionArchiveManifest(dir string) (*collectionArchiveManifest, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, collectionArchiveManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("read archive manifest: %w", err)
+	}
+	var manifest collectionArchiveManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, fmt.Errorf("parse archive manifest: %w", err)
+	}
+	if strings.TrimSpace(manifest.SnapshotID) == "" {
+		return nil, errors.New("archive manifest has no snapshot ID")
+	}
+	return &manifest, nil
+}
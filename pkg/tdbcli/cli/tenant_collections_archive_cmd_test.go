@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestCollectionsArchiveAndUnarchive(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "events", PrimaryKeyField: "code", PrimaryKeyType: "string", SchemaJSON: `{"type":"object"}`})
+	srv.AddDocument("events", map[string]any{"code": "a"})
+	srv.AddDocument("events", map[string]any{"code": "b"})
+
+	var mu sync.Mutex
+	snapshotCount := 1
+	var restoreTarget string
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/snapshots":
+			var req clientpkg.CreateSnapshotRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			_ = json.NewEncoder(w).Encode(clientpkg.Snapshot{ID: "snap_1", CollectionID: req.CollectionID, Name: req.Name})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/snapshots/snap_1":
+			_ = json.NewEncoder(w).Encode(clientpkg.Snapshot{ID: "snap_1", DocumentCount: snapshotCount})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/snapshots/snap_1/restore":
+			var req clientpkg.RestoreSnapshotRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			restoreTarget = req.TargetCollectionID
+			_ = json.NewEncoder(w).Encode(clientpkg.RestoreSnapshotResponse{CollectionID: req.TargetCollectionID, DocumentsRestored: 2})
+		default:
+			tenantRoutes.ServeHTTP(w, r)
+		}
+	})
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+	run := func(newCmd func(*Environment) *cobra.Command, args ...string) (string, error) {
+		out, _, err := runCommandWithEnv(t, env, newCmd, args...)
+		return out, err
+	}
+	tenant := srv.TenantClient(t)
+	exists := func() bool {
+		_, err := tenant.GetCollection(context.Background(), "events", "")
+		return err == nil
+	}
+
+	dir := filepath.Join(t.TempDir(), "events")
+	if _, err := run(newTenantCollectionsArchiveCommand, "events", "--out", dir); err == nil || !strings.Contains(err.Error(), "--confirm") {
+		t.Fatalf("expected confirm requirement, got %v", err)
+	}
+	if _, err := run(newTenantCollectionsArchiveCommand, "events", "--out", dir, "--confirm"); err == nil || !strings.Contains(err.Error(), "holds 1 documents") || !exists() {
+		t.Fatalf("expected snapshot verification failure to keep the collection, got %v", err)
+	}
+
+	mu.Lock()
+	snapshotCount = 2
+	mu.Unlock()
+	dir = filepath.Join(t.TempDir(), "events")
+	out, err := run(newTenantCollectionsArchiveCommand, "events", "--out", dir, "--confirm")
+	if err != nil {
+		t.Fatalf("archive failed: %v\n%s", err, out)
+	}
+	if exists() || !strings.Contains(out, "Exported and verified 2 documents") {
+		t.Fatalf("expected the collection to be archived and deleted:\n%s", out)
+	}
+	manifest, err := readCollectionArchiveManifest(dir)
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	if manifest.SnapshotID != "snap_1" || manifest.DocumentCount != 2 || manifest.PrimaryKey == nil || manifest.PrimaryKey.Field != "code" {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+
+	out, err = run(newTenantCollectionsUnarchiveCommand, "events", "--from", dir)
+	if err != nil {
+		t.Fatalf("unarchive failed: %v\n%s", err, out)
+	}
+	col, err := tenant.GetCollection(context.Background(), "events", "")
+	if err != nil || col.PrimaryKeyField != "code" {
+		t.Fatalf("expected recreated collection, got %+v (%v)", col, err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if restoreTarget != col.ID || !strings.Contains(out, "Restored 2 documents from snapshot snap_1") {
+		t.Fatalf("unexpected restore (target %q):\n%s", restoreTarget, out)
+	}
+}
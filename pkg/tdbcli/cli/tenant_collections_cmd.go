@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 
 	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
@@ -23,6 +24,9 @@ func newTenantCollectionsListCommand(env *Environment) *cobra.Command {
 	var inspectDocs bool
 	var describe bool
 	var inspectLimit int
+	var includeDeleted bool
+	var onlyDeleted bool
+	var groupByApp bool
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List collections for a tenant",
@@ -32,8 +36,12 @@ Use the --describe flag to inspect collection schemas and document structure, or
 		Example: `  # List all collections
   tdb tenant collections list --api-key $API_KEY
 
-  # List collections for a specific application
+  # List collections for a specific application (by ID or name)
   tdb tenant collections list --api-key $API_KEY --app app_123
+  tdb tenant collections list --api-key $API_KEY --app billing-service
+
+  # Group collections per application with subtotals
+  tdb tenant collections list --group-by-app
 
   # Show collection schemas and inspect document structure
   tdb tenant collections list --describe
@@ -45,7 +53,10 @@ Use the --describe flag to inspect collection schemas and document structure, or
   tdb tenant collections list --inspect-docs --inspect-limit 20
 
   # Get raw JSON output
-  tdb tenant collections list --raw`,
+  tdb tenant collections list --raw
+
+  # Show only soft-deleted collections
+  tdb tenant collections list --only-deleted`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			envCtx, err := requireEnvironment(env)
 			if err != nil {
@@ -55,34 +66,38 @@ Use the --describe flag to inspect collection schemas and document structure, or
 			if err != nil {
 				return err
 			}
-			collections, err := tenantClient.ListCollections(cmd.Context(), auth.appID)
+			collections, err := tenantClient.ListCollectionsWithParams(cmd.Context(), clientpkg.ListCollectionsParams{
+				AppID:          auth.appID,
+				IncludeDeleted: includeDeleted,
+				OnlyDeleted:    onlyDeleted,
+			})
 			if err != nil {
 				return err
 			}
+			var groups []collectionAppGroup
+			if groupByApp {
+				apps, err := tenantClient.ListApplications(cmd.Context())
+				if err != nil {
+					return err
+				}
+				groups = groupCollectionsByApp(collections, apps)
+			}
 			if raw {
+				if groupByApp {
+					return printJSON(cmd, groups)
+				}
 				return printJSON(cmd, collections)
 			}
 			if len(collections) == 0 {
 				fmt.Fprintln(cmd.OutOrStdout(), "No collections found")
 				return nil
 			}
-			rows := make([][]string, 0, len(collections))
-			for _, col := range collections {
-				app := "-"
-				if col.AppID != nil && strings.TrimSpace(*col.AppID) != "" {
-					app = *col.AppID
-				}
-				rows = append(rows, []string{
-					col.Name,
-					app,
-					summarizePrimaryKey(col.PrimaryKeyField, col.PrimaryKeyType, col.PrimaryKeyAuto),
-					formatTime(col.CreatedAt),
-					formatTime(col.UpdatedAt),
-					fmt.Sprintf("%d", col.DocumentCount),
-					formatBytes(col.StorageBytes),
-				})
-			}
-			renderTable(cmd, []string{"NAME", "APP", "PRIMARY KEY", "CREATED", "UPDATED", "DOCUMENTS", "STORAGE"}, rows)
+			showDeleted := includeDeleted || onlyDeleted
+			if groupByApp {
+				renderCollectionAppGroups(cmd, groups, showDeleted)
+			} else {
+				renderCollectionRows(cmd, collections, showDeleted)
+			}
 
 			inspect := inspectDocs || describe
 			displaySchema := showSchema || describe
@@ -104,6 +119,9 @@ Use the --describe flag to inspect collection schemas and document structure, or
 	cmd.Flags().BoolVar(&inspectDocs, "inspect-docs", false, "Inspect representative documents to infer field types")
 	cmd.Flags().IntVar(&inspectLimit, "inspect-limit", 10, "Maximum documents to inspect when --inspect-docs is enabled")
 	cmd.Flags().BoolVar(&describe, "describe", false, "Convenience flag enabling both --show-schema and --inspect-docs")
+	cmd.Flags().BoolVar(&includeDeleted, "include-deleted", false, "Include soft-deleted collections")
+	cmd.Flags().BoolVar(&onlyDeleted, "only-deleted", false, "Show only soft-deleted collections")
+	cmd.Flags().BoolVar(&groupByApp, "group-by-app", false, "Group collections per application with subtotals")
 	return cmd
 }
 
@@ -413,7 +431,7 @@ func newTenantCollectionsGetCommand(env *Environment) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "get <name>",
 		Short: "Fetch a collection by name",
-		Long:  `Retrieve detailed information about a specific collection including schema, primary key configuration, and statistics.`,
+		Long:  `Retrieve detailed information about a specific collection including schema, primary key configuration, statistics, and usage against its limits.`,
 		Example: `  # Get collection details
   tdb tenant collections get users --api-key $API_KEY
 
@@ -421,7 +439,10 @@ func newTenantCollectionsGetCommand(env *Environment) *cobra.Command {
   tdb tenant collections get orders --api-key $API_KEY --app app_123
 
   # Get raw JSON output
-  tdb tenant collections get users --raw`,
+  tdb tenant collections get users --raw
+
+  # Cap the collection (shown under DOCUMENTS and STORAGE)
+  tdb tenant collections set-limits users --max-documents 1M --max-storage 5GB`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			envCtx, err := requireEnvironment(env)
@@ -436,6 +457,13 @@ func newTenantCollectionsGetCommand(env *Environment) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if col.Limits == nil {
+				// Servers that do not embed limits may still serve them separately;
+				// older servers have neither, which just means no limits.
+				if limits, err := tenantClient.GetCollectionLimits(cmd.Context(), col.Name, auth.appID); err == nil {
+					col.Limits = &limits.Limits
+				}
+			}
 			if raw {
 				return printJSON(cmd, col)
 			}
@@ -451,12 +479,20 @@ func newTenantCollectionsGetCommand(env *Environment) *cobra.Command {
 				formatTime(col.CreatedAt),
 				formatTime(col.UpdatedAt),
 			)
+			var limits clientpkg.CollectionLimits
+			if col.Limits != nil {
+				limits = *col.Limits
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "DOCUMENTS: %s\nSTORAGE: %s\n",
+				formatLimitUsage(col.DocumentCount, limits.MaxDocuments, humanize.Comma),
+				formatLimitUsage(col.StorageBytes, limits.MaxStorageBytes, formatBytes),
+			)
 			schema := strings.TrimSpace(col.SchemaJSON)
 			if schema != "" {
-				var pretty map[string]any
-				if err := json.Unmarshal([]byte(schema), &pretty); err == nil {
+				// Print the schema as authored rather than re-sorting its keys.
+				if json.Valid([]byte(schema)) {
 					fmt.Fprintln(cmd.OutOrStdout(), "SCHEMA:")
-					if err := printJSON(cmd, pretty); err != nil {
+					if err := printJSON(cmd, json.RawMessage(schema)); err != nil {
 						return err
 					}
 				} else {
@@ -571,7 +607,7 @@ Primary key configuration allows you to define custom document identifiers with
 			if raw {
 				return printJSON(cmd, col)
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "Created collection %s (%s)\n", col.Name, col.ID)
+			fmt.Fprintf(cmd.OutOrStdout(), tr("Created collection %s (%s)\n"), col.Name, col.ID)
 			return nil
 		},
 	}
@@ -642,7 +678,7 @@ You can update the schema validation rules, modify primary key settings, or both
 			}
 			name := strings.TrimSpace(args[0])
 			if name == "" {
-				return errors.New("collection name cannot be empty")
+				return errors.New(tr("collection name cannot be empty"))
 			}
 			var schemaProvided bool
 			if cmd.Flags().Lookup("schema").Changed || cmd.Flags().Lookup("schema-file").Changed {
@@ -679,7 +715,7 @@ You can update the schema validation rules, modify primary key settings, or both
 			if raw {
 				return printJSON(cmd, col)
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "Updated collection %s\n", col.Name)
+			fmt.Fprintf(cmd.OutOrStdout(), tr("Updated collection %s\n"), col.Name)
 			return nil
 		},
 	}
@@ -724,16 +760,71 @@ WARNING: This operation is irreversible and will delete all documents in the col
 			}
 			name := strings.TrimSpace(args[0])
 			if name == "" {
-				return errors.New("collection name cannot be empty")
+				return errors.New(tr("collection name cannot be empty"))
+			}
+			if envCtx.Config.SafeMode {
+				count, err := tenantClient.CountDocuments(cmd.Context(), name, auth.appID)
+				if err != nil {
+					return err
+				}
+				if err := confirmDestructive(cmd, envCtx, "delete collection "+name, fmt.Sprintf("Documents: %d", count)); err != nil {
+					return err
+				}
 			}
 			if err := tenantClient.DeleteCollection(cmd.Context(), name, auth.appID); err != nil {
 				return err
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "Deleted collection %s\n", name)
+			fmt.Fprintf(cmd.OutOrStdout(), tr("Deleted collection %s\n"), name)
+			return nil
+		},
+	}
+	auth.bindWithApp(cmd)
+	bindSafeModeYes(cmd)
+	return cmd
+}
+
+func newTenantCollectionsRestoreCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var raw bool
+	cmd := &cobra.Command{
+		Use:   "restore <name>",
+		Short: "Restore a soft-deleted collection",
+		Long: `Undelete a soft-deleted collection so it and its documents become visible again.
+
+Only collections that were soft-deleted can be restored; use "tdb tenant collections list --only-deleted" to find them.`,
+		Example: `  # Find and restore a deleted collection
+  tdb tenant collections list --only-deleted
+  tdb tenant collections restore old-logs`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			name := strings.TrimSpace(args[0])
+			if name == "" {
+				return errors.New(tr("collection name cannot be empty"))
+			}
+			col, err := tenantClient.RestoreCollection(cmd.Context(), name, auth.appID)
+			if err != nil {
+				if isNotFoundError(err) {
+					return fmt.Errorf("collection %s could not be restored (not soft-deleted or restore unsupported by server): %w", name, err)
+				}
+				return err
+			}
+			if raw {
+				return printJSON(cmd, col)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Restored collection %s\n", name)
 			return nil
 		},
 	}
 	auth.bindWithApp(cmd)
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON response")
 	return cmd
 }
 
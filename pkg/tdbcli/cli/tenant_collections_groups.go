@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// collectionAppGroup is one application's share of a collections listing.
+// Collections without an application are grouped under an empty AppID.
+type collectionAppGroup struct {
+	AppID         string                 `json:"app_id"`
+	AppName       string                 `json:"app_name,omitempty"`
+	Collections   []clientpkg.Collection `json:"collections"`
+	DocumentCount int64                  `json:"document_count"`
+	StorageBytes  int64                  `json:"storage_bytes"`
+}
+
+func (g collectionAppGroup) label() string {
+	switch {
+	case g.AppID == "":
+		return "(no app)"
+	case g.AppName == "":
+		return g.AppID
+	default:
+		return fmt.Sprintf("%s (%s)", g.AppName, g.AppID)
+	}
+}
+
+// groupCollectionsByApp buckets collections per application. Tenant-level
+// collections come first, then applications ordered by name.
+func groupCollectionsByApp(collections []clientpkg.Collection, apps []clientpkg.Application) []collectionAppGroup {
+	names := make(map[string]string, len(apps))
+	for _, app := range apps {
+		names[app.ID] = app.Name
+	}
+	index := map[string]int{}
+	var groups []collectionAppGroup
+	for _, col := range collections {
+		appID := ""
+		if col.AppID != nil {
+			appID = strings.TrimSpace(*col.AppID)
+		}
+		i, ok := index[appID]
+		if !ok {
+			i = len(groups)
+			index[appID] = i
+			groups = append(groups, collectionAppGroup{AppID: appID, AppName: names[appID]})
+		}
+		groups[i].Collections = append(groups[i].Collections, col)
+		groups[i].DocumentCount += col.DocumentCount
+		groups[i].StorageBytes += col.StorageBytes
+	}
+	sort.SliceStable(groups, func(a, b int) bool {
+		if (groups[a].AppID == "") != (groups[b].AppID == "") {
+			return groups[a].AppID == ""
+		}
+		return strings.ToLower(groups[a].label()) < strings.ToLower(groups[b].label())
+	})
+	return groups
+}
+
+func renderCollectionAppGroups(cmd *cobra.Command, groups []collectionAppGroup, showDeleted bool) {
+	out := cmd.OutOrStdout()
+	var total collectionAppGroup
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintf(out, "App: %s\n", group.label())
+		renderCollectionRows(cmd, group.Collections, showDeleted)
+		fmt.Fprintf(out, "Subtotal: %s\n", formatCollectionTotals(len(group.Collections), group.DocumentCount, group.StorageBytes))
+		total.Collections = append(total.Collections, group.Collections...)
+		total.DocumentCount += group.DocumentCount
+		total.StorageBytes += group.StorageBytes
+	}
+	fmt.Fprintf(out, "\nTotal: %s across %d group(s)\n", formatCollectionTotals(len(total.Collections), total.DocumentCount, total.StorageBytes), len(groups))
+}
+
+func formatCollectionTotals(collections int, documents, storage int64) string {
+	return fmt.Sprintf("%d collection(s), %s documents, %s", collections, humanize.Comma(documents), formatBytes(storage))
+}
+
+func renderCollectionRows(cmd *cobra.Command, collections []clientpkg.Collection, showDeleted bool) {
+	rows := make([][]string, 0, len(collections))
+	for _, col := range collections {
+		app := "-"
+		if col.AppID != nil && strings.TrimSpace(*col.AppID) != "" {
+			app = *col.AppID
+		}
+		row := []string{
+			col.Name,
+			app,
+			summarizePrimaryKey(col.PrimaryKeyField, col.PrimaryKeyType, col.PrimaryKeyAuto),
+			formatTime(col.CreatedAt),
+			formatTime(col.UpdatedAt),
+			fmt.Sprintf("%d", col.DocumentCount),
+			formatBytes(col.StorageBytes),
+		}
+		if showDeleted {
+			deleted := "-"
+			if col.DeletedAt != nil {
+				deleted = formatTime(*col.DeletedAt)
+			}
+			row = append(row, deleted)
+		}
+		rows = append(rows, row)
+	}
+	headers := []string{"NAME", "APP", "PRIMARY KEY", "CREATED", "UPDATED", "DOCUMENTS", "STORAGE"}
+	if showDeleted {
+		headers = append(headers, "DELETED")
+	}
+	renderTable(cmd, headers, rows)
+}
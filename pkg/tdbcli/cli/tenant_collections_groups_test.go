@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestMatchApplicationRef(t *testing.T) {
+	apps := []clientpkg.Application{
+		{ID: "app_1", Name: "billing"},
+		{ID: "app_2", Name: "Search"},
+		{ID: "app_3", Name: "search"},
+	}
+	cases := []struct {
+		ref     string
+		want    string
+		wantErr string
+	}{
+		{ref: "app_2", want: "app_2"},
+		{ref: "Billing", want: "app_1"},
+		{ref: "search", wantErr: "ambiguous; matches app_2, app_3"},
+		{ref: "missing", wantErr: "not found"},
+		{ref: " ", want: ""},
+	}
+	for _, tc := range cases {
+		got, err := matchApplicationRef(apps, tc.ref)
+		if tc.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("matchApplicationRef(%q): expected error containing %q, got %v", tc.ref, tc.wantErr, err)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Fatalf("matchApplicationRef(%q) = %q, %v; want %q", tc.ref, got, err, tc.want)
+		}
+	}
+}
+
+func TestCollectionsListGroupByApp(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	billing := srv.AddApplication(clientpkg.Application{Name: "billing"})
+	analytics := srv.AddApplication(clientpkg.Application{Name: "analytics"})
+	srv.AddCollection(clientpkg.Collection{Name: "invoices", AppID: &billing.ID, DocumentCount: 1200, StorageBytes: 2048})
+	srv.AddCollection(clientpkg.Collection{Name: "payments", AppID: &billing.ID, DocumentCount: 300, StorageBytes: 1024})
+	srv.AddCollection(clientpkg.Collection{Name: "events", AppID: &analytics.ID, DocumentCount: 5})
+	srv.AddCollection(clientpkg.Collection{Name: "settings", DocumentCount: 1})
+
+	run := func(args ...string) string {
+		t.Helper()
+		out, errOut, err := runCommand(t, srv, newTenantCollectionsListCommand, args...)
+		if err != nil {
+			t.Fatalf("collections list failed: %v\n%s%s", err, out, errOut)
+		}
+		return out
+	}
+
+	out := run("--group-by-app")
+	noApp := strings.Index(out, "App: (no app)")
+	analyticsAt := strings.Index(out, "App: analytics ("+analytics.ID+")")
+	billingAt := strings.Index(out, "App: billing ("+billing.ID+")")
+	if noApp < 0 || analyticsAt < noApp || billingAt < analyticsAt {
+		t.Fatalf("unexpected group order:\n%s", out)
+	}
+	if !strings.Contains(out, "Subtotal: 2 collection(s), 1,500 documents, 3.1 kB") {
+		t.Fatalf("missing billing subtotal:\n%s", out)
+	}
+	if !strings.Contains(out, "Total: 4 collection(s), 1,506 documents") {
+		t.Fatalf("missing total:\n%s", out)
+	}
+
+	out = run("--app", "Billing")
+	if !strings.Contains(out, "invoices") || strings.Contains(out, "events") || strings.Contains(out, "settings") {
+		t.Fatalf("expected only billing collections:\n%s", out)
+	}
+}
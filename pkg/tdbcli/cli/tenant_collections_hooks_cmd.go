@@ -0,0 +1,381 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// collectionHookEvents are the document events a hook can subscribe to.
+var collectionHookEvents = []string{"document.created", "document.updated", "document.deleted", "document.*"}
+
+// collectionHookActions are the actions a hook can run.
+var collectionHookActions = []string{"webhook", "function"}
+
+func newTenantCollectionsHooksCommand(env *Environment) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hooks",
+		Short: "Manage server-side event hooks on a collection",
+		Long: `Hooks run an action on the server when documents in a collection change:
+call a webhook or a server function on document.created, document.updated,
+document.deleted, or document.* for all three. They need a server with
+collection hook support.`,
+	}
+	cmd.AddCommand(newTenantCollectionsHooksListCommand(env))
+	cmd.AddCommand(newTenantCollectionsHooksSetCommand(env))
+	cmd.AddCommand(newTenantCollectionsHooksDeleteCommand(env))
+	return cmd
+}
+
+func newTenantCollectionsHooksListCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "list <collection>",
+		Short: "List the hooks of a collection",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			collection := strings.TrimSpace(args[0])
+			if collection == "" {
+				return errors.New(tr("collection name cannot be empty"))
+			}
+			hooks, err := tenantClient.ListCollectionHooks(cmd.Context(), collection, auth.appID)
+			if err != nil {
+				return explainHooksError(err)
+			}
+			sort.Slice(hooks, func(i, j int) bool { return hooks[i].Name < hooks[j].Name })
+			if raw {
+				return printJSON(cmd, hooks)
+			}
+			if len(hooks) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "No hooks on collection %s\n", collection)
+				return nil
+			}
+			rows := make([][]string, 0, len(hooks))
+			for _, hook := range hooks {
+				updated := ""
+				if hook.UpdatedAt != nil {
+					updated = formatTime(*hook.UpdatedAt)
+				}
+				rows = append(rows, []string{hook.Name, hook.Event, hook.Action, hook.Target, hookEnabledLabel(hook), updated})
+			}
+			renderTable(cmd, []string{"NAME", "EVENT", "ACTION", "TARGET", "ENABLED", "UPDATED"}, rows)
+			return nil
+		},
+	}
+	auth.bindWithApp(cmd)
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the hooks as JSON")
+	return cmd
+}
+
+func newTenantCollectionsHooksSetCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var data string
+	var file string
+	var test bool
+	var sampleFile string
+	var yes bool
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "set <collection>",
+		Short: "Create or replace a hook from a JSON or YAML definition",
+		Long: `Create a hook, or replace the hook with the same name, from a definition with
+name, event, action, and target, plus optional enabled and action-specific
+config:
+
+  name: notify-crm
+  event: document.created
+  action: webhook
+  target: https://crm.example.com/hooks/tdb
+  config:
+    secret_header: X-Hook-Secret
+
+With --test the hook is saved disabled, invoked once with a sample document,
+and only then saved as defined; if the test fails it stays disabled. The
+sample is the file given by --sample-file, or else the newest document in the
+collection, which is only sent after you confirm (or pass --yes) because the
+action really runs and receives real data. The sample is not written to the
+collection.`,
+		Example: `  tdb tenant collections hooks set users --file notify-crm.yaml
+  tdb tenant collections hooks set users --file notify-crm.yaml --test --sample-file user.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			collection := strings.TrimSpace(args[0])
+			if collection == "" {
+				return errors.New(tr("collection name cannot be empty"))
+			}
+			payload, err := readHookDefinition(data, file)
+			if err != nil {
+				return err
+			}
+			hook, err := parseCollectionHook(payload)
+			if err != nil {
+				return err
+			}
+			var sample json.RawMessage
+			if trimmed := strings.TrimSpace(sampleFile); trimmed != "" {
+				if !test {
+					return errors.New("--sample-file requires --test")
+				}
+				if sample, err = readHookSample(trimmed); err != nil {
+					return err
+				}
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			if test && sample == nil {
+				if sample, err = newestDocumentSample(cmd, tenantClient, collection, auth.appID, hook.Target, yes); err != nil {
+					return err
+				}
+			}
+			var saved *clientpkg.CollectionHook
+			var result *clientpkg.CollectionHookTestResult
+			if test {
+				disabled := hook
+				disabled.Enabled = new(bool)
+				if saved, err = tenantClient.PutCollectionHook(cmd.Context(), collection, auth.appID, disabled); err != nil {
+					return explainHooksError(err)
+				}
+				result, err = tenantClient.TestCollectionHook(cmd.Context(), collection, saved.Name, auth.appID, clientpkg.CollectionHookTestRequest{Event: saved.Event, Document: sample})
+				if err != nil {
+					return fmt.Errorf("hook %s was saved disabled because the test invocation failed: %w", saved.Name, err)
+				}
+			}
+			if result == nil || result.Success {
+				if saved, err = tenantClient.PutCollectionHook(cmd.Context(), collection, auth.appID, hook); err != nil {
+					return explainHooksError(err)
+				}
+			}
+			if raw {
+				if err := printJSON(cmd, struct {
+					Hook *clientpkg.CollectionHook           `json:"hook"`
+					Test *clientpkg.CollectionHookTestResult `json:"test,omitempty"`
+				}{saved, result}); err != nil {
+					return err
+				}
+			} else {
+				out := cmd.OutOrStdout()
+				fmt.Fprintf(out, "Saved hook %s on collection %s: %s -> %s %s\n", saved.Name, collection, saved.Event, saved.Action, saved.Target)
+				if result != nil {
+					renderHookTestResult(cmd, result)
+				}
+			}
+			if result != nil && !result.Success {
+				return fmt.Errorf("test invocation of hook %s failed; the hook was saved disabled", saved.Name)
+			}
+			return nil
+		},
+	}
+	auth.bindWithApp(cmd)
+	cmd.Flags().StringVar(&data, "data", "", "Hook definition as inline JSON")
+	cmd.Flags().StringVar(&file, "file", "", "Path to a JSON or YAML hook definition")
+	cmd.Flags().BoolVar(&test, "test", false, "Invoke the hook once with a sample document before enabling it")
+	cmd.Flags().StringVar(&sampleFile, "sample-file", "", "JSON document to test the hook with (default: the newest document in the collection)")
+	cmd.Flags().BoolVar(&yes, "yes", false, "Test with the newest document without asking when --sample-file is not given")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the saved hook and test result as JSON")
+	return cmd
+}
+
+func newTenantCollectionsHooksDeleteCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+
+	cmd := &cobra.Command{
+		Use:   "delete <collection> <hook>",
+		Short: "Delete a hook from a collection",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			collection, name := strings.TrimSpace(args[0]), strings.TrimSpace(args[1])
+			if collection == "" || name == "" {
+				return errors.New("collection and hook names cannot be empty")
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			if err := confirmDestructive(cmd, envCtx, "delete hook "+name, "Collection: "+collection); err != nil {
+				return err
+			}
+			if err := tenantClient.DeleteCollectionHook(cmd.Context(), collection, name, auth.appID); err != nil {
+				return explainHooksError(err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Deleted hook %s from collection %s\n", name, collection)
+			return nil
+		},
+	}
+	auth.bindWithApp(cmd)
+	bindSafeModeYes(cmd)
+	return cmd
+}
+
+// readHookDefinition reads the --data or --file hook definition. It is left
+// undecoded because a file may hold YAML.
+func readHookDefinition(data, file string) ([]byte, error) {
+	inline, path := strings.TrimSpace(data), strings.TrimSpace(file)
+	switch {
+	case inline != "" && path != "":
+		return nil, errors.New("use only one of --data or --file")
+	case inline != "":
+		return []byte(inline), nil
+	case path != "":
+		content, err := readFileContent(path)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(content) == "" {
+			return nil, errors.New("hook definition cannot be empty")
+		}
+		return []byte(content), nil
+	}
+	return nil, errors.New("provide --data or --file")
+}
+
+// parseCollectionHook decodes a JSON or YAML hook definition and checks it
+// before anything is sent.
+func parseCollectionHook(payload []byte) (clientpkg.CollectionHook, error) {
+	var hook clientpkg.CollectionHook
+	var decoded any
+	if err := yaml.Unmarshal(payload, &decoded); err != nil {
+		return hook, fmt.Errorf("invalid hook definition: %w", err)
+	}
+	if _, ok := decoded.(map[string]any); !ok {
+		return hook, errors.New("invalid hook definition: expected an object with name, event, action, and target")
+	}
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		return hook, fmt.Errorf("invalid hook definition: %w", err)
+	}
+	if err := json.Unmarshal(encoded, &hook); err != nil {
+		return hook, fmt.Errorf("invalid hook definition: %w", err)
+	}
+	hook.Name, hook.Event = strings.TrimSpace(hook.Name), strings.TrimSpace(hook.Event)
+	hook.Action, hook.Target = strings.ToLower(strings.TrimSpace(hook.Action)), strings.TrimSpace(hook.Target)
+	var missing []string
+	for _, field := range []struct{ name, value string }{{"name", hook.Name}, {"event", hook.Event}, {"action", hook.Action}, {"target", hook.Target}} {
+		if field.value == "" {
+			missing = append(missing, field.name)
+		}
+	}
+	if len(missing) > 0 {
+		return hook, fmt.Errorf("hook definition is missing %s", strings.Join(missing, ", "))
+	}
+	if !slices.Contains(collectionHookEvents, hook.Event) {
+		return hook, fmt.Errorf("unknown hook event %q (expected one of %s)", hook.Event, strings.Join(collectionHookEvents, ", "))
+	}
+	if !slices.Contains(collectionHookActions, hook.Action) {
+		return hook, fmt.Errorf("unknown hook action %q (expected one of %s)", hook.Action, strings.Join(collectionHookActions, ", "))
+	}
+	if hook.Action == "webhook" {
+		if target, err := url.Parse(hook.Target); err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			return hook, fmt.Errorf("webhook target %q must be an http(s) URL", hook.Target)
+		}
+	}
+	return hook, nil
+}
+
+func readHookSample(path string) (json.RawMessage, error) {
+	raw, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read sample document: %w", err)
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, fmt.Errorf("sample document %s must be a JSON object: %w", path, err)
+	}
+	return json.RawMessage(raw), nil
+}
+
+// newestDocumentSample returns the data of the newest document in the
+// collection, or an empty object when it has none. A real document is only
+// returned once the user confirms sending it to target, or with --yes.
+func newestDocumentSample(cmd *cobra.Command, tenantClient *clientpkg.TenantClient, collection, appID, target string, yes bool) (json.RawMessage, error) {
+	resp, err := tenantClient.ListDocuments(cmd.Context(), collection, clientpkg.ListDocumentsParams{AppID: appID, Limit: 1, Sort: []string{"-created_at"}})
+	if err != nil {
+		return nil, fmt.Errorf("load a sample document: %w", err)
+	}
+	if len(resp.Items) == 0 || strings.TrimSpace(resp.Items[0].Data) == "" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Collection %s has no documents; testing with an empty document\n", collection)
+		return json.RawMessage(`{}`), nil
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Testing with document %s\n", resp.Items[0].ID)
+	if !yes {
+		if !promptIsInteractive(cmd) {
+			return nil, fmt.Errorf("testing would send document %s to %s; pass --sample-file, or --yes to send it", resp.Items[0].ID, target)
+		}
+		confirmed := false
+		prompt := &survey.Confirm{Message: fmt.Sprintf("Send document %s to %s?", resp.Items[0].ID, target)}
+		if err := survey.AskOne(prompt, &confirmed); err != nil {
+			return nil, fmt.Errorf("confirmation cancelled: %w", err)
+		}
+		if !confirmed {
+			return nil, errors.New("cancelled")
+		}
+	}
+	return json.RawMessage(resp.Items[0].Data), nil
+}
+
+func renderHookTestResult(cmd *cobra.Command, result *clientpkg.CollectionHookTestResult) {
+	out := cmd.OutOrStdout()
+	status := "ok"
+	if !result.Success {
+		status = "FAILED"
+	}
+	detail := fmt.Sprintf("%dms", result.DurationMS)
+	if result.StatusCode != 0 {
+		detail = fmt.Sprintf("HTTP %d in %s", result.StatusCode, detail)
+	}
+	fmt.Fprintf(out, "Test %s (%s): %s, %s\n", result.Hook, result.Event, status, detail)
+	if result.Error != "" {
+		fmt.Fprintf(out, "  error: %s\n", result.Error)
+	}
+	if result.Output != "" {
+		fmt.Fprintf(out, "  output: %s\n", result.Output)
+	}
+}
+
+func hookEnabledLabel(hook clientpkg.CollectionHook) string {
+	if hook.Enabled != nil && !*hook.Enabled {
+		return "no"
+	}
+	return "yes"
+}
+
+// explainHooksError points at missing server support when the hook routes
+// are not found.
+func explainHooksError(err error) error {
+	if isNotFoundError(err) {
+		return fmt.Errorf("%w (collection hooks need a server that supports them)", err)
+	}
+	return err
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestCollectionsHooks(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users"})
+	doc := srv.AddDocument("users", map[string]any{"name": "Ann"})
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+	run := func(command func(*Environment) *cobra.Command, args ...string) (string, string, error) {
+		return runCommandWithEnv(t, env, command, args...)
+	}
+
+	hookFile := filepath.Join(t.TempDir(), "notify.yaml")
+	definition := "name: notify-crm\nevent: document.created\naction: webhook\ntarget: https://crm.example.com/hooks\nconfig:\n  secret_header: X-Secret\n"
+	if err := os.WriteFile(hookFile, []byte(definition), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := run(newTenantCollectionsHooksSetCommand, "users", "--file", hookFile, "--test"); err == nil || !strings.Contains(err.Error(), "pass --sample-file, or --yes") {
+		t.Fatalf("expected the newest document to need confirmation, got %v", err)
+	}
+	if hooks := srv.Hooks("users"); len(hooks) != 0 {
+		t.Fatalf("expected nothing saved without confirmation, got %+v", hooks)
+	}
+	out, errOut, err := run(newTenantCollectionsHooksSetCommand, "users", "--file", hookFile, "--test", "--yes")
+	if err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if !strings.Contains(out, "Saved hook notify-crm on collection users: document.created -> webhook") || !strings.Contains(out, "Test notify-crm (document.created): ok, HTTP 200") {
+		t.Fatalf("unexpected set output:\n%s", out)
+	}
+	if !strings.Contains(errOut, "Testing with document "+doc.ID) {
+		t.Fatalf("expected the newest document as the sample:\n%s", errOut)
+	}
+	hooks := srv.Hooks("users")
+	if len(hooks) != 1 || string(hooks[0].Config) != `{"secret_header":"X-Secret"}` || hooks[0].Enabled != nil {
+		t.Fatalf("unexpected stored hooks %+v", hooks)
+	}
+	var calls []string
+	for _, req := range srv.Requests() {
+		if strings.Contains(req, "/hooks/") {
+			calls = append(calls, req)
+		}
+	}
+	if want := "PUT /api/collections/users/hooks/notify-crm,POST /api/collections/users/hooks/notify-crm/test,PUT /api/collections/users/hooks/notify-crm"; strings.Join(calls, ",") != want {
+		t.Fatalf("expected the hook to be tested disabled before it is saved as defined, got %v", calls)
+	}
+
+	if _, _, err := run(newTenantCollectionsHooksSetCommand, "users", "--data", `{"name":"notify-crm","event":"document.*","action":"function","target":"sync_crm","enabled":false}`); err != nil {
+		t.Fatalf("replace failed: %v", err)
+	}
+	out, _, err = run(newTenantCollectionsHooksListCommand, "users")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if !strings.Contains(out, "notify-crm") || !strings.Contains(out, "sync_crm") || strings.Contains(out, "crm.example.com") {
+		t.Fatalf("expected the replaced hook to be listed:\n%s", out)
+	}
+
+	if hooks := srv.Hooks("users"); len(hooks) != 1 || hooks[0].Enabled == nil || *hooks[0].Enabled {
+		t.Fatalf("expected one disabled hook, got %+v", hooks)
+	}
+
+	for data, want := range map[string]string{
+		`{"name":"x","event":"document.created","action":"webhook"}`:                    "missing target",
+		`{"name":"x","event":"doc.saved","action":"webhook","target":"https://a"}`:      "unknown hook event",
+		`{"name":"x","event":"document.created","action":"email","target":"a@b"}`:       "unknown hook action",
+		`{"name":"x","event":"document.created","action":"webhook","target":"ftp://a"}`: "must be an http(s) URL",
+	} {
+		if _, _, err := run(newTenantCollectionsHooksSetCommand, "users", "--data", data); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q for %s, got %v", want, data, err)
+		}
+	}
+
+	if out, _, err := run(newTenantCollectionsHooksDeleteCommand, "users", "notify-crm"); err != nil || !strings.Contains(out, "Deleted hook notify-crm") {
+		t.Fatalf("delete failed: %v\n%s", err, out)
+	}
+	if len(srv.Hooks("users")) != 0 {
+		t.Fatalf("expected the hook to be removed")
+	}
+	if _, _, err := run(newTenantCollectionsHooksDeleteCommand, "users", "notify-crm"); err == nil || !strings.Contains(err.Error(), "need a server that supports them") {
+		t.Fatalf("expected a not found error with a hint, got %v", err)
+	}
+}
+
+func TestCollectionsHooksFailedTestLeavesHookDisabled(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users"})
+	routes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/test") {
+			_ = json.NewEncoder(w).Encode(clientpkg.CollectionHookTestResult{Hook: "notify-crm", Event: "document.created", StatusCode: http.StatusBadGateway})
+			return
+		}
+		routes.ServeHTTP(w, r)
+	})
+	sampleFile := filepath.Join(t.TempDir(), "user.json")
+	if err := os.WriteFile(sampleFile, []byte(`{"name":"Sample"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err := runCommand(t, srv, newTenantCollectionsHooksSetCommand, "users", "--test", "--sample-file", sampleFile,
+		"--data", `{"name":"notify-crm","event":"document.created","action":"webhook","target":"https://crm.example.com/hooks"}`)
+	if err == nil || !strings.Contains(err.Error(), "the hook was saved disabled") {
+		t.Fatalf("expected the failed test to be reported, got %v", err)
+	}
+	if hooks := srv.Hooks("users"); len(hooks) != 1 || hooks[0].Enabled == nil || *hooks[0].Enabled {
+		t.Fatalf("expected the hook to stay disabled, got %+v", hooks)
+	}
+}
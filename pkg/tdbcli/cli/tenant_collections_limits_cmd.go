@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+func newTenantCollectionsSetLimitsCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var maxDocuments string
+	var maxStorage string
+	var dryRun bool
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "set-limits <name>",
+		Short: "Cap the document count and storage of a collection",
+		Long: `Set per-collection quotas and show the before and after values next to the
+current usage. Writes that would exceed a limit are rejected by the server.
+
+Document counts accept suffixes (10k, 1M) and separators (1,000,000); storage
+accepts sizes such as 500MB or 5GB. Pass "unlimited" to remove a limit. Limits
+that are not specified are left unchanged.`,
+		Example: `  # Cap a runaway events collection
+  tdb tenant collections set-limits events --max-documents 1M --max-storage 5GB
+
+  # Preview a change, then lift the document cap
+  tdb tenant collections set-limits events --max-documents 2M --dry-run
+  tdb tenant collections set-limits events --max-documents unlimited`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := strings.TrimSpace(args[0])
+			if name == "" {
+				return errors.New(tr("collection name cannot be empty"))
+			}
+			req, err := buildCollectionLimitsRequest(maxDocuments, maxStorage)
+			if err != nil {
+				return err
+			}
+			if req.MaxDocuments == nil && req.MaxStorageBytes == nil && len(req.Clear) == 0 {
+				return errors.New("specify at least one of --max-documents or --max-storage")
+			}
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			before, err := tenantClient.GetCollectionLimits(cmd.Context(), name, auth.appID)
+			if err != nil {
+				return err
+			}
+			after := applyCollectionLimits(*before, req)
+			if !dryRun {
+				updated, err := tenantClient.UpdateCollectionLimits(cmd.Context(), name, auth.appID, req)
+				if err != nil {
+					return err
+				}
+				after = *updated
+			}
+			if raw {
+				return printJSON(cmd, after)
+			}
+			if limit := after.Limits.MaxDocuments; limit != nil && after.DocumentCount > *limit {
+				logWarn(cmd.ErrOrStderr(), fmt.Sprintf("collection %s already holds %s documents, above the new limit; new documents will be rejected", name, humanize.Comma(after.DocumentCount)))
+			}
+			if limit := after.Limits.MaxStorageBytes; limit != nil && after.StorageBytes > *limit {
+				logWarn(cmd.ErrOrStderr(), fmt.Sprintf("collection %s already uses %s, above the new limit; writes will be rejected", name, formatBytes(after.StorageBytes)))
+			}
+			if dryRun {
+				fmt.Fprintf(cmd.OutOrStdout(), "Planned limit changes for collection %s:\n", name)
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), "Updated limits for collection %s:\n", name)
+			}
+			renderTable(cmd, []string{"LIMIT", "USAGE", "BEFORE", "AFTER"}, collectionLimitRows(*before, after))
+			return nil
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().StringVar(&maxDocuments, "max-documents", "", "Maximum number of documents (e.g. 500000, 1M, or unlimited)")
+	cmd.Flags().StringVar(&maxStorage, "max-storage", "", "Maximum storage (e.g. 500MB, 5GB, or unlimited)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the planned changes without applying them")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the resulting limits and usage as JSON")
+	return cmd
+}
+
+// buildCollectionLimitsRequest parses limit flags, leaving empty values unset.
+func buildCollectionLimitsRequest(maxDocuments, maxStorage string) (clientpkg.UpdateCollectionLimitsRequest, error) {
+	var req clientpkg.UpdateCollectionLimitsRequest
+	if trimmed := strings.TrimSpace(maxDocuments); trimmed != "" {
+		if isUnlimited(trimmed) {
+			req.Clear = append(req.Clear, "max_documents")
+		} else {
+			count, err := parseDocumentCount(trimmed)
+			if err != nil {
+				return req, fmt.Errorf("invalid --max-documents %q (expected e.g. 500000, 1M, or unlimited)", maxDocuments)
+			}
+			req.MaxDocuments = &count
+		}
+	}
+	if trimmed := strings.TrimSpace(maxStorage); trimmed != "" {
+		if isUnlimited(trimmed) {
+			req.Clear = append(req.Clear, "max_storage_bytes")
+		} else {
+			size, err := humanize.ParseBytes(trimmed)
+			if err != nil || size == 0 {
+				return req, fmt.Errorf("invalid --max-storage %q (expected e.g. 500MB, 5GB, or unlimited)", maxStorage)
+			}
+			limit := int64(size)
+			req.MaxStorageBytes = &limit
+		}
+	}
+	return req, nil
+}
+
+func isUnlimited(value string) bool {
+	switch strings.ToLower(value) {
+	case "unlimited", "none":
+		return true
+	}
+	return false
+}
+
+// parseDocumentCount accepts positive counts such as 250000, 1,000,000, 10k,
+// 1.5M, or 2B.
+func parseDocumentCount(raw string) (int64, error) {
+	cleaned := strings.NewReplacer(",", "", "_", "").Replace(strings.TrimSpace(raw))
+	multiplier := 1.0
+	if cleaned != "" {
+		switch cleaned[len(cleaned)-1] {
+		case 'k', 'K':
+			multiplier = 1e3
+		case 'm', 'M':
+			multiplier = 1e6
+		case 'b', 'B', 'g', 'G':
+			multiplier = 1e9
+		}
+		if multiplier > 1 {
+			cleaned = cleaned[:len(cleaned)-1]
+		}
+	}
+	value, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0, err
+	}
+	count := int64(value * multiplier)
+	if count <= 0 || float64(count) != value*multiplier {
+		return 0, fmt.Errorf("invalid document count %q", raw)
+	}
+	return count, nil
+}
+
+func applyCollectionLimits(current clientpkg.CollectionLimitsResponse, req clientpkg.UpdateCollectionLimitsRequest) clientpkg.CollectionLimitsResponse {
+	for _, name := range req.Clear {
+		switch name {
+		case "max_documents":
+			current.Limits.MaxDocuments = nil
+		case "max_storage_bytes":
+			current.Limits.MaxStorageBytes = nil
+		}
+	}
+	if req.MaxDocuments != nil {
+		current.Limits.MaxDocuments = req.MaxDocuments
+	}
+	if req.MaxStorageBytes != nil {
+		current.Limits.MaxStorageBytes = req.MaxStorageBytes
+	}
+	return current
+}
+
+func collectionLimitRows(before, after clientpkg.CollectionLimitsResponse) [][]string {
+	countLimit := func(v *int64) string {
+		if v == nil {
+			return "unlimited"
+		}
+		return humanize.Comma(*v)
+	}
+	bytesLimit := func(v *int64) string {
+		if v == nil {
+			return "unlimited"
+		}
+		return formatBytes(*v)
+	}
+	return [][]string{
+		{"Documents", humanize.Comma(after.DocumentCount), countLimit(before.Limits.MaxDocuments), countLimit(after.Limits.MaxDocuments)},
+		{"Storage", formatBytes(after.StorageBytes), bytesLimit(before.Limits.MaxStorageBytes), bytesLimit(after.Limits.MaxStorageBytes)},
+	}
+}
+
+// formatLimitUsage renders usage against an optional limit, e.g.
+// "1,200 of 5,000 (24%)" or "1,200 (no limit)".
+func formatLimitUsage(used int64, limit *int64, format func(int64) string) string {
+	if limit == nil {
+		return format(used) + " (no limit)"
+	}
+	percent := 0.0
+	if *limit > 0 {
+		percent = float64(used) / float64(*limit) * 100
+	}
+	return fmt.Sprintf("%s of %s (%.0f%%)", format(used), format(*limit), percent)
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestParseDocumentCount(t *testing.T) {
+	cases := map[string]int64{"250000": 250000, "1,000,000": 1000000, "10k": 10000, "1.5M": 1500000, "2B": 2000000000}
+	for input, want := range cases {
+		if got, err := parseDocumentCount(input); err != nil || got != want {
+			t.Fatalf("parseDocumentCount(%q) = %d, %v; want %d", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "0", "-5", "1.5", "lots"} {
+		if _, err := parseDocumentCount(input); err == nil {
+			t.Fatalf("expected %q to be rejected", input)
+		}
+	}
+}
+
+func TestCollectionsSetLimits(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "events"})
+	for i := 0; i < 3; i++ {
+		srv.AddDocument("events", map[string]any{"n": i})
+	}
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+	run := func(command func(*Environment) *cobra.Command, args ...string) (string, string, error) {
+		return runCommandWithEnv(t, env, command, args...)
+	}
+
+	out, _, err := run(newTenantCollectionsSetLimitsCommand, "events", "--max-documents", "2", "--dry-run")
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if !strings.Contains(out, "Planned limit changes for collection events") {
+		t.Fatalf("unexpected dry-run output:\n%s", out)
+	}
+	for _, req := range srv.Requests() {
+		if strings.HasPrefix(req, "PUT ") {
+			t.Fatalf("dry run should not update limits, got %v", srv.Requests())
+		}
+	}
+
+	out, errOut, err := run(newTenantCollectionsSetLimitsCommand, "events", "--max-documents", "2", "--max-storage", "5GB")
+	if err != nil {
+		t.Fatalf("set-limits failed: %v", err)
+	}
+	if !strings.Contains(errOut, "already holds 3 documents, above the new limit") {
+		t.Fatalf("expected over-limit warning, got %q", errOut)
+	}
+	for _, want := range []string{"Updated limits for collection events", "Documents", "unlimited", "5.0 GB"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if _, _, err := run(newTenantDocumentsCreateCommand, "events", "--data", `{"n":4}`); err == nil || !strings.Contains(err.Error(), "limit of 2 documents") {
+		t.Fatalf("expected the server to reject a write over the limit, got %v", err)
+	}
+
+	out, _, err = run(newTenantCollectionsGetCommand, "events")
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if !strings.Contains(out, "DOCUMENTS: 3 of 2 (150%)") || !strings.Contains(out, "of 5.0 GB (0%)") {
+		t.Fatalf("expected usage against limits in get output:\n%s", out)
+	}
+
+	if _, _, err := run(newTenantCollectionsSetLimitsCommand, "events", "--max-documents", "unlimited"); err != nil {
+		t.Fatalf("clear limit failed: %v", err)
+	}
+	out, _, _ = run(newTenantCollectionsGetCommand, "events")
+	if !strings.Contains(out, "DOCUMENTS: 3 (no limit)") {
+		t.Fatalf("expected the document limit to be cleared:\n%s", out)
+	}
+
+	if _, _, err := run(newTenantCollectionsSetLimitsCommand, "events"); err == nil || !strings.Contains(err.Error(), "specify at least one") {
+		t.Fatalf("expected missing flag error, got %v", err)
+	}
+}
@@ -0,0 +1,262 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// schemaPresets are reusable property blocks merged into scaffolded schemas.
+var schemaPresets = map[string]map[string]any{
+	"timestamps": {
+		"created_at": map[string]any{"type": "string", "format": "date-time"},
+		"updated_at": map[string]any{"type": "string", "format": "date-time"},
+	},
+	"soft-delete": {
+		"deleted_at": map[string]any{"type": []any{"string", "null"}, "format": "date-time"},
+	},
+	"audit": {
+		"created_by": map[string]any{"type": "string"},
+		"updated_by": map[string]any{"type": "string"},
+	},
+}
+
+func newTenantCollectionsScaffoldCommand(env *Environment) *cobra.Command {
+	var name string
+	var samplePath string
+	var presets string
+	var required bool
+	var outPath string
+
+	cmd := &cobra.Command{
+		Use:   "scaffold",
+		Short: "Generate a JSON schema and create command from a sample document",
+		Long: `Infer a JSON schema from one or more example documents and print it together with
+the matching collections create command. Nothing is sent to the server.
+
+The sample file may contain a single object, a JSON array, or JSON Lines. Presets
+merge common property blocks into the schema:
+  timestamps   created_at, updated_at (date-time strings)
+  soft-delete  deleted_at (nullable date-time string)
+  audit        created_by, updated_by (strings)`,
+		Example: `  # Infer a schema from an example document
+  tdb tenant collections scaffold --name users --from-sample user.json
+
+  # Mark fields present in every sample as required and add timestamp fields
+  tdb tenant collections scaffold --name orders --from-sample orders.jsonl --required --preset timestamps,audit
+
+  # Write the schema to a file for collections create --schema-file
+  tdb tenant collections scaffold --name events --preset timestamps,soft-delete --out events.schema.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := requireEnvironment(env); err != nil {
+				return err
+			}
+			presetNames := splitCommaList(presets)
+			if strings.TrimSpace(samplePath) == "" && len(presetNames) == 0 {
+				return errors.New("provide --from-sample and/or --preset")
+			}
+			schema := map[string]any{"type": "object", "properties": map[string]any{}}
+			if trimmed := strings.TrimSpace(samplePath); trimmed != "" {
+				raw, err := os.ReadFile(filepath.Clean(trimmed))
+				if err != nil {
+					return err
+				}
+				samples, err := decodeSchemaSamples(raw)
+				if err != nil {
+					return err
+				}
+				schema = inferSchemaFromSamples(samples, required)
+				if _, ok := schema["properties"]; !ok {
+					return errors.New("sample documents must be JSON objects")
+				}
+			}
+			if err := applySchemaPresets(schema, presetNames); err != nil {
+				return err
+			}
+
+			pretty, err := json.MarshalIndent(schema, "", "  ")
+			if err != nil {
+				return err
+			}
+			collection := strings.TrimSpace(name)
+			if collection == "" {
+				collection = "<name>"
+			}
+			out := cmd.OutOrStdout()
+			if trimmed := strings.TrimSpace(outPath); trimmed != "" {
+				if err := os.WriteFile(filepath.Clean(trimmed), append(pretty, '\n'), 0o644); err != nil {
+					return err
+				}
+				fmt.Fprintf(out, "Wrote schema to %s\n\n", trimmed)
+				fmt.Fprintf(out, "tdb tenant collections create --name %s --schema-file %s\n", shellQuoteIfNeeded(collection), shellQuoteIfNeeded(trimmed))
+				return nil
+			}
+			compact, err := json.Marshal(schema)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(out, string(pretty))
+			fmt.Fprintln(out)
+			fmt.Fprintf(out, "tdb tenant collections create --name %s --schema %s\n", shellQuoteIfNeeded(collection), shellQuote(string(compact)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Collection name used in the generated create command")
+	cmd.Flags().StringVar(&samplePath, "from-sample", "", "Path to example document(s): JSON object, array, or JSON Lines")
+	cmd.Flags().StringVar(&presets, "preset", "", "Comma-separated presets to merge: timestamps, soft-delete, audit")
+	cmd.Flags().BoolVar(&required, "required", false, "Mark fields present (and non-null) in every sample as required")
+	cmd.Flags().StringVar(&outPath, "out", "", "Write the schema to this file instead of printing it")
+	return cmd
+}
+
+// decodeSchemaSamples reads a single JSON value, a JSON array of values, or a
+// stream of values (JSON Lines).
+func decodeSchemaSamples(raw []byte) ([]any, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 {
+		return nil, errors.New("sample file is empty")
+	}
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	var samples []any
+	for {
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("decode sample: %w", err)
+		}
+		if list, ok := value.([]any); ok && len(samples) == 0 && !decoder.More() {
+			return list, nil
+		}
+		samples = append(samples, value)
+	}
+	return samples, nil
+}
+
+// inferSchemaFromSamples builds a JSON schema describing every sample value.
+// When required is set, object fields present and non-null in every sample are
+// listed as required.
+func inferSchemaFromSamples(values []any, required bool) map[string]any {
+	types := map[string]bool{}
+	var objects []map[string]any
+	var elements []any
+	var strs []string
+	for _, value := range values {
+		name := jsonTypeName(value)
+		types[name] = true
+		switch typed := value.(type) {
+		case map[string]any:
+			objects = append(objects, typed)
+		case []any:
+			elements = append(elements, typed...)
+		case string:
+			strs = append(strs, typed)
+		}
+	}
+	if types["integer"] && types["number"] {
+		delete(types, "integer")
+	}
+
+	schema := map[string]any{}
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	switch len(names) {
+	case 0:
+	case 1:
+		schema["type"] = names[0]
+	default:
+		list := make([]any, 0, len(names))
+		for _, name := range names {
+			list = append(list, name)
+		}
+		schema["type"] = list
+	}
+
+	if len(objects) > 0 {
+		fieldValues := map[string][]any{}
+		presence := map[string]int{}
+		for _, obj := range objects {
+			for key, value := range obj {
+				fieldValues[key] = append(fieldValues[key], value)
+				if value != nil {
+					presence[key]++
+				}
+			}
+		}
+		props := make(map[string]any, len(fieldValues))
+		var requiredFields []string
+		for key, vals := range fieldValues {
+			props[key] = inferSchemaFromSamples(vals, required)
+			if required && presence[key] == len(objects) {
+				requiredFields = append(requiredFields, key)
+			}
+		}
+		schema["properties"] = props
+		if len(requiredFields) > 0 {
+			sort.Strings(requiredFields)
+			list := make([]any, 0, len(requiredFields))
+			for _, field := range requiredFields {
+				list = append(list, field)
+			}
+			schema["required"] = list
+		}
+	}
+	if len(elements) > 0 {
+		schema["items"] = inferSchemaFromSamples(elements, required)
+	}
+	if len(strs) > 0 && allDateTimes(strs) {
+		schema["format"] = "date-time"
+	}
+	return schema
+}
+
+func allDateTimes(values []string) bool {
+	for _, value := range values {
+		if _, err := time.Parse(time.RFC3339Nano, value); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// applySchemaPresets merges the named preset property blocks into schema,
+// leaving properties inferred from samples untouched.
+func applySchemaPresets(schema map[string]any, presets []string) error {
+	props, _ := schema["properties"].(map[string]any)
+	if props == nil {
+		props = map[string]any{}
+		schema["properties"] = props
+	}
+	for _, preset := range presets {
+		block, ok := schemaPresets[strings.ToLower(preset)]
+		if !ok {
+			known := make([]string, 0, len(schemaPresets))
+			for name := range schemaPresets {
+				known = append(known, name)
+			}
+			sort.Strings(known)
+			return fmt.Errorf("unknown preset %q (available: %s)", preset, strings.Join(known, ", "))
+		}
+		for field, def := range block {
+			if _, exists := props[field]; !exists {
+				props[field] = def
+			}
+		}
+	}
+	return nil
+}
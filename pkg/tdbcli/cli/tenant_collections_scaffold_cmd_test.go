@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestInferSchemaFromSamples(t *testing.T) {
+	samples, err := decodeSchemaSamples([]byte(`{"name":"Ada","age":36,"tags":["a"],"joined":"2024-01-02T03:04:05Z","address":{"city":"Paris"}}
+{"name":"Bob","age":41.5,"tags":[],"joined":"2024-02-01T00:00:00Z","nickname":null}`))
+	if err != nil {
+		t.Fatalf("decodeSchemaSamples: %v", err)
+	}
+	if len(samples) != 2 {
+		t.Fatalf("expected 2 samples, got %d", len(samples))
+	}
+	schema := inferSchemaFromSamples(samples, true)
+	encoded, _ := json.Marshal(schema)
+	var got map[string]any
+	_ = json.Unmarshal(encoded, &got)
+
+	want := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"name":     map[string]any{"type": "string"},
+			"age":      map[string]any{"type": "number"},
+			"tags":     map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"joined":   map[string]any{"type": "string", "format": "date-time"},
+			"address":  map[string]any{"type": "object", "properties": map[string]any{"city": map[string]any{"type": "string"}}, "required": []any{"city"}},
+			"nickname": map[string]any{"type": "null"},
+		},
+		"required": []any{"age", "joined", "name", "tags"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected schema:\n got: %s", encoded)
+	}
+}
+
+func TestDecodeSchemaSamplesArray(t *testing.T) {
+	samples, err := decodeSchemaSamples([]byte(`[{"a":1},{"a":2}]`))
+	if err != nil {
+		t.Fatalf("decodeSchemaSamples: %v", err)
+	}
+	if len(samples) != 2 {
+		t.Fatalf("expected array to expand into 2 samples, got %d", len(samples))
+	}
+}
+
+func TestApplySchemaPresets(t *testing.T) {
+	schema := map[string]any{"type": "object", "properties": map[string]any{"created_at": map[string]any{"type": "integer"}}}
+	if err := applySchemaPresets(schema, []string{"timestamps", "soft-delete"}); err != nil {
+		t.Fatalf("applySchemaPresets: %v", err)
+	}
+	props := schema["properties"].(map[string]any)
+	if props["created_at"].(map[string]any)["type"] != "integer" {
+		t.Fatalf("expected sample-inferred property to be preserved")
+	}
+	for _, field := range []string{"updated_at", "deleted_at"} {
+		if _, ok := props[field]; !ok {
+			t.Fatalf("expected preset field %s", field)
+		}
+	}
+	if err := applySchemaPresets(schema, []string{"bogus"}); err == nil {
+		t.Fatalf("expected error for unknown preset")
+	}
+}
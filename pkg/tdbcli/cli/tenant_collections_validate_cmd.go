@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// collectionValidationOffender records a document that failed schema validation.
+type collectionValidationOffender struct {
+	ID         string            `json:"id"`
+	Key        string            `json:"key,omitempty"`
+	Violations []schemaViolation `json:"violations"`
+	Data       any               `json:"data,omitempty"`
+}
+
+// collectionValidationReport summarizes a validation run.
+type collectionValidationReport struct {
+	Collection string                         `json:"collection"`
+	Checked    int                            `json:"checked"`
+	Valid      int                            `json:"valid"`
+	Invalid    int                            `json:"invalid"`
+	Offenders  []collectionValidationOffender `json:"offenders"`
+}
+
+func newTenantCollectionsValidateCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var sample int
+	var all bool
+	var outPath string
+	var maxReport int
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "validate <name>",
+		Short: "Validate existing documents against the collection schema",
+		Long: `Load the collection schema and validate existing documents against it, reporting
+violating document IDs with the failing paths and a summary.
+
+By default the newest 5000 documents are checked; use --all to scan the whole
+collection. Offending documents can be written to a JSONL file for remediation.`,
+		Example: `  # Validate a sample of documents
+  tdb tenant collections validate users
+
+  # Validate every document and export offenders
+  tdb tenant collections validate users --all --out invalid-users.jsonl`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			name := strings.TrimSpace(args[0])
+			if name == "" {
+				return errors.New(tr("collection name cannot be empty"))
+			}
+			if !all && sample <= 0 {
+				return errors.New("--sample must be greater than zero (or use --all)")
+			}
+			col, err := tenantClient.GetCollection(cmd.Context(), name, auth.appID)
+			if err != nil {
+				return err
+			}
+			validator, err := newJSONSchemaValidator(col.SchemaJSON)
+			if err != nil {
+				return fmt.Errorf("collection %s: %w", name, err)
+			}
+
+			var out *bufio.Writer
+			if trimmed := strings.TrimSpace(outPath); trimmed != "" {
+				clean := filepath.Clean(trimmed)
+				if dir := filepath.Dir(clean); dir != "." && dir != "" {
+					if err := os.MkdirAll(dir, 0o755); err != nil {
+						return err
+					}
+				}
+				file, err := os.Create(clean)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = file.Close() }()
+				out = bufio.NewWriter(file)
+				defer out.Flush()
+			}
+
+			report := collectionValidationReport{Collection: name}
+			page := 100
+			offset := 0
+			for all || report.Checked < sample {
+				limit := page
+				if !all && sample-report.Checked < limit {
+					limit = sample - report.Checked
+				}
+				resp, err := tenantClient.ListDocuments(cmd.Context(), name, clientpkg.ListDocumentsParams{AppID: auth.appID, Limit: limit, Offset: offset})
+				if err != nil {
+					return err
+				}
+				for _, doc := range resp.Items {
+					report.Checked++
+					var data any
+					if err := json.Unmarshal([]byte(doc.Data), &data); err != nil {
+						data = nil
+					}
+					violations := validator.Validate(data)
+					if len(violations) == 0 {
+						report.Valid++
+						continue
+					}
+					report.Invalid++
+					offender := collectionValidationOffender{ID: doc.ID, Key: doc.Key, Violations: violations}
+					if out != nil {
+						offender.Data = data
+						encoded, err := json.Marshal(offender)
+						if err != nil {
+							return err
+						}
+						if _, err := out.Write(append(encoded, '\n')); err != nil {
+							return err
+						}
+						offender.Data = nil
+					}
+					if maxReport <= 0 || len(report.Offenders) < maxReport {
+						report.Offenders = append(report.Offenders, offender)
+					}
+				}
+				offset += len(resp.Items)
+				if len(resp.Items) < limit || len(resp.Items) == 0 {
+					break
+				}
+			}
+
+			if raw {
+				if err := printJSON(cmd, report); err != nil {
+					return err
+				}
+			} else {
+				if len(report.Offenders) > 0 {
+					rows := make([][]string, 0, len(report.Offenders))
+					for _, offender := range report.Offenders {
+						for _, violation := range offender.Violations {
+							rows = append(rows, []string{offender.ID, violation.Path, violation.Message})
+						}
+					}
+					renderTable(cmd, []string{"DOCUMENT", "PATH", "ERROR"}, rows)
+					if report.Invalid > len(report.Offenders) {
+						fmt.Fprintf(cmd.OutOrStdout(), "... %d more invalid documents not shown (raise --max-report)\n", report.Invalid-len(report.Offenders))
+					}
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "CHECKED: %d  VALID: %d  INVALID: %d\n", report.Checked, report.Valid, report.Invalid)
+				if out != nil && report.Invalid > 0 {
+					fmt.Fprintf(cmd.OutOrStdout(), "Wrote %d offending documents to %s\n", report.Invalid, strings.TrimSpace(outPath))
+				}
+			}
+			if report.Invalid > 0 {
+				return fmt.Errorf("%d of %d documents failed schema validation", report.Invalid, report.Checked)
+			}
+			return nil
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().IntVar(&sample, "sample", 5000, "Number of newest documents to validate")
+	cmd.Flags().BoolVar(&all, "all", false, "Validate every document in the collection")
+	cmd.Flags().StringVar(&outPath, "out", "", "Write offending documents (with violations) to a JSONL file")
+	cmd.Flags().IntVar(&maxReport, "max-report", 50, "Maximum offending documents to display (0 for all)")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the validation report as JSON")
+	return cmd
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+const validateTestSchema = `{"type":"object","required":["email"],"properties":{"email":{"type":"string"}}}`
+
+func TestCollectionsValidateReportsViolations(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users", SchemaJSON: validateTestSchema})
+	srv.AddDocument("users", map[string]any{"email": "ann@example.com"})
+
+	out, _, err := runCommand(t, srv, newTenantCollectionsValidateCommand, "users")
+	if err != nil {
+		t.Fatalf("expected a valid collection to pass, got %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "CHECKED: 1  VALID: 1  INVALID: 0") {
+		t.Fatalf("unexpected summary:\n%s", out)
+	}
+
+	bad := srv.AddDocument("users", map[string]any{"name": "Bo"})
+	offenders := filepath.Join(t.TempDir(), "invalid.jsonl")
+	out, _, err = runCommand(t, srv, newTenantCollectionsValidateCommand, "users", "--out", offenders)
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 documents failed schema validation") {
+		t.Fatalf("expected the schema violation to fail the command, got %v", err)
+	}
+	if !strings.Contains(out, bad.ID) || !strings.Contains(out, "required field missing") || !strings.Contains(out, "CHECKED: 2  VALID: 1  INVALID: 1") {
+		t.Fatalf("expected the offending document in the report:\n%s", out)
+	}
+	raw, err := os.ReadFile(offenders)
+	if err != nil {
+		t.Fatalf("read offenders: %v", err)
+	}
+	var offender collectionValidationOffender
+	if err := json.Unmarshal(raw, &offender); err != nil || offender.ID != bad.ID || len(offender.Violations) != 1 || offender.Data == nil {
+		t.Fatalf("unexpected offenders file %s (%v)", raw, err)
+	}
+
+	out, _, err = runCommand(t, srv, newTenantCollectionsValidateCommand, "users", "--raw")
+	if err == nil {
+		t.Fatalf("expected --raw to fail on the schema violation too")
+	}
+	var report collectionValidationReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil || report.Invalid != 1 || report.Offenders[0].ID != bad.ID {
+		t.Fatalf("unexpected raw report %s (%v)", out, err)
+	}
+}
+
+func TestCollectionsValidatePagesThroughSample(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users", SchemaJSON: validateTestSchema})
+	for i := 0; i < 240; i++ {
+		srv.AddDocument("users", map[string]any{"email": "user@example.com"})
+	}
+	srv.AddDocument("users", map[string]any{"name": "no email"})
+	var pages []string
+	routes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/users/documents") {
+			pages = append(pages, r.URL.Query().Get("limit")+"@"+r.URL.Query().Get("offset"))
+		}
+		routes.ServeHTTP(w, r)
+	})
+
+	out, _, err := runCommand(t, srv, newTenantCollectionsValidateCommand, "users", "--sample", "150")
+	if err != nil {
+		t.Fatalf("expected the sample to miss the invalid document, got %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "CHECKED: 150  VALID: 150  INVALID: 0") {
+		t.Fatalf("unexpected summary:\n%s", out)
+	}
+	if got := strings.Join(pages, ","); got != "100@,50@100" {
+		t.Fatalf("expected the sample to be fetched in two pages, got %s", got)
+	}
+
+	pages = nil
+	out, _, err = runCommand(t, srv, newTenantCollectionsValidateCommand, "users", "--all")
+	if err == nil || !strings.Contains(out, "CHECKED: 241  VALID: 240  INVALID: 1") {
+		t.Fatalf("expected --all to reach the last page and fail, got %v\n%s", err, out)
+	}
+	if got := strings.Join(pages, ","); got != "100@,100@100,100@200" {
+		t.Fatalf("unexpected pages with --all: %s", got)
+	}
+
+	if _, _, err := runCommand(t, srv, newTenantCollectionsValidateCommand, "users", "--sample", "0"); err == nil || !strings.Contains(err.Error(), "--sample") {
+		t.Fatalf("expected --sample 0 to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,335 @@
+package cli
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// anonymizationRules maps dotted field paths to transformation actions.
+//
+// Supported actions: null, drop, redact, hash, hash:<length>, keep, and
+// fake:<kind> where kind is one of name, first_name, last_name, email, phone,
+// uuid, word, or number. Fakes are derived from the original value so the same
+// input always maps to the same output.
+type anonymizationRules struct {
+	Salt   string            `yaml:"salt"`
+	Fields map[string]string `yaml:"fields"`
+}
+
+func newTenantDocumentsAnonymizeCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var rulesPath string
+	var inPlace bool
+	var confirm bool
+	var targetCollection string
+	var outPath string
+	var pageSize int
+	var includeDeleted bool
+	stats := newOperationStats()
+
+	cmd := &cobra.Command{
+		Use:   "anonymize <collection>",
+		Short: "Transform documents with anonymization rules (hash, fake, null)",
+		Long: `Stream documents from a collection, apply field-level anonymization rules, and
+write the result to a file, another collection, or back to the same collection.
+
+Rules file (YAML):
+  salt: staging-2025          # optional salt for hash and fake actions
+  fields:
+    email: fake:email
+    name: fake:name
+    ssn: null
+    notes: drop
+    address.street: redact
+    customer_id: hash:12
+
+Actions: null, drop, redact, hash[:length], keep, fake:<name|first_name|last_name|email|phone|uuid|word|number>`,
+		Example: `  # Write an anonymized JSONL file
+  tdb tenant documents anonymize users --rules rules.yaml --out users-staging.jsonl
+
+  # Copy anonymized documents into another collection
+  tdb tenant documents anonymize users --rules rules.yaml --target-collection users_staging
+
+  # Anonymize the collection in place
+  tdb tenant documents anonymize users --rules rules.yaml --in-place --confirm`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			collection := strings.TrimSpace(args[0])
+			if collection == "" {
+				return errors.New(tr("collection name cannot be empty"))
+			}
+			targetCollection = strings.TrimSpace(targetCollection)
+			outPath = strings.TrimSpace(outPath)
+			destinations := 0
+			for _, set := range []bool{inPlace, targetCollection != "", outPath != ""} {
+				if set {
+					destinations++
+				}
+			}
+			if destinations != 1 {
+				return errors.New("choose exactly one destination: --in-place, --target-collection, or --out")
+			}
+			if inPlace && !confirm {
+				return errors.New("use --confirm to acknowledge irreversible in-place anonymization")
+			}
+			if strings.TrimSpace(rulesPath) == "" {
+				return errors.New("--rules is required")
+			}
+			rules, err := loadAnonymizationRules(rulesPath)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+
+			var out *bufio.Writer
+			if outPath != "" {
+				clean := filepath.Clean(outPath)
+				if dir := filepath.Dir(clean); dir != "." && dir != "" {
+					if err := os.MkdirAll(dir, 0o755); err != nil {
+						return err
+					}
+				}
+				file, err := os.Create(clean)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = file.Close() }()
+				out = bufio.NewWriter(stats.countBytes(file))
+				defer out.Flush()
+			}
+
+			page := tunePageSize(cmd, envCtx, "page-size", pageSize)
+			if page <= 0 {
+				page = 100
+			}
+			processed := 0
+			offset := 0
+			for {
+				params := clientpkg.ListDocumentsParams{AppID: auth.appID, Limit: page, Offset: offset, IncludeDeleted: includeDeleted}
+				resp, err := tenantClient.ListDocuments(cmd.Context(), collection, params)
+				if err != nil {
+					return err
+				}
+				if len(resp.Items) == 0 {
+					break
+				}
+				for _, doc := range resp.Items {
+					var data map[string]any
+					if err := json.Unmarshal([]byte(doc.Data), &data); err != nil {
+						return fmt.Errorf("decode document %s: %w", doc.ID, err)
+					}
+					if err := rules.apply(data); err != nil {
+						return fmt.Errorf("anonymize document %s: %w", doc.ID, err)
+					}
+					payload, err := json.Marshal(data)
+					if err != nil {
+						return err
+					}
+					switch {
+					case out != nil:
+						if _, err := out.Write(append(payload, '\n')); err != nil {
+							return err
+						}
+					case targetCollection != "":
+						if _, err := tenantClient.CreateDocument(cmd.Context(), targetCollection, payload, auth.appID); err != nil {
+							return fmt.Errorf("write document %s to %s: %w", doc.ID, targetCollection, err)
+						}
+					default:
+						if _, err := tenantClient.UpdateDocument(cmd.Context(), collection, doc.ID, payload, auth.appID); err != nil {
+							return fmt.Errorf("update document %s: %w", doc.ID, err)
+						}
+					}
+					processed++
+					stats.count("processed", processed)
+				}
+				offset += len(resp.Items)
+				if len(resp.Items) < page {
+					break
+				}
+			}
+
+			switch {
+			case out != nil:
+				fmt.Fprintf(cmd.ErrOrStderr(), "Anonymized %d documents to %s\n", processed, outPath)
+			case targetCollection != "":
+				fmt.Fprintf(cmd.OutOrStdout(), "Anonymized %d documents into %s\n", processed, targetCollection)
+			default:
+				fmt.Fprintf(cmd.OutOrStdout(), "Anonymized %d documents in place\n", processed)
+			}
+			return nil
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().StringVar(&rulesPath, "rules", "", "Path to YAML anonymization rules")
+	cmd.Flags().BoolVar(&inPlace, "in-place", false, "Overwrite documents in the source collection")
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm irreversible in-place anonymization")
+	cmd.Flags().StringVar(&targetCollection, "target-collection", "", "Create anonymized documents in this collection")
+	cmd.Flags().StringVar(&outPath, "out", "", "Write anonymized documents as JSONL to this file")
+	cmd.Flags().IntVar(&pageSize, "page-size", 100, "Number of documents fetched per page")
+	cmd.Flags().BoolVar(&includeDeleted, "include-deleted", false, "Include soft-deleted documents")
+	bindStatsJSON(cmd, stats)
+	return cmd
+}
+
+func loadAnonymizationRules(path string) (*anonymizationRules, error) {
+	raw, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	var rules anonymizationRules
+	if err := yaml.Unmarshal(raw, &rules); err != nil {
+		return nil, fmt.Errorf("parse rules: %w", err)
+	}
+	if len(rules.Fields) == 0 {
+		return nil, errors.New("rules file defines no fields")
+	}
+	for field, action := range rules.Fields {
+		if err := validateAnonymizationAction(action); err != nil {
+			return nil, fmt.Errorf("field %s: %w", field, err)
+		}
+	}
+	return &rules, nil
+}
+
+func validateAnonymizationAction(action string) error {
+	name, arg, _ := strings.Cut(strings.TrimSpace(action), ":")
+	switch strings.ToLower(name) {
+	case "", "null", "drop", "redact", "keep":
+		return nil
+	case "hash":
+		if arg != "" {
+			var n int
+			if _, err := fmt.Sscanf(arg, "%d", &n); err != nil || n <= 0 || n > 64 {
+				return fmt.Errorf("invalid hash length %q", arg)
+			}
+		}
+		return nil
+	case "fake":
+		switch strings.ToLower(arg) {
+		case "name", "first_name", "last_name", "email", "phone", "uuid", "word", "number":
+			return nil
+		}
+		return fmt.Errorf("unsupported fake kind %q", arg)
+	}
+	return fmt.Errorf("unsupported action %q", action)
+}
+
+// apply transforms data in place according to the rules. Fields are processed
+// in sorted order so results are deterministic.
+func (r *anonymizationRules) apply(data map[string]any) error {
+	fields := make([]string, 0, len(r.Fields))
+	for field := range r.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		parts := strings.Split(field, ".")
+		parent := data
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := parent[part].(map[string]any)
+			if !ok {
+				parent = nil
+				break
+			}
+			parent = next
+		}
+		if parent == nil {
+			continue
+		}
+		leaf := parts[len(parts)-1]
+		current, ok := parent[leaf]
+		if !ok {
+			continue
+		}
+		name, arg, _ := strings.Cut(strings.TrimSpace(r.Fields[field]), ":")
+		switch strings.ToLower(name) {
+		case "keep":
+		case "", "null":
+			parent[leaf] = nil
+		case "drop":
+			delete(parent, leaf)
+		case "redact":
+			parent[leaf] = "***"
+		case "hash":
+			digest := anonymizeDigest(r.Salt, field, current)
+			encoded := hex.EncodeToString(digest[:])
+			if arg != "" {
+				var n int
+				fmt.Sscanf(arg, "%d", &n)
+				if n > 0 && n < len(encoded) {
+					encoded = encoded[:n]
+				}
+			}
+			parent[leaf] = encoded
+		case "fake":
+			if current == nil {
+				continue
+			}
+			parent[leaf] = fakeValue(strings.ToLower(arg), anonymizeDigest(r.Salt, field, current))
+		default:
+			return fmt.Errorf("unsupported action %q for field %s", r.Fields[field], field)
+		}
+	}
+	return nil
+}
+
+func anonymizeDigest(salt, field string, value any) [32]byte {
+	encoded, _ := json.Marshal(value)
+	return sha256.Sum256([]byte(salt + "\x00" + field + "\x00" + string(encoded)))
+}
+
+var (
+	fakeFirstNames = []string{"Alex", "Blake", "Casey", "Dana", "Emery", "Finley", "Harper", "Jordan", "Kai", "Logan", "Morgan", "Parker", "Quinn", "Riley", "Sage", "Taylor"}
+	fakeLastNames  = []string{"Adams", "Brooks", "Carter", "Diaz", "Ellis", "Foster", "Gray", "Hayes", "Irwin", "James", "Kim", "Lopez", "Moore", "Nguyen", "Owens", "Price"}
+	fakeWords      = []string{"amber", "birch", "cobalt", "delta", "ember", "fjord", "granite", "harbor", "indigo", "juniper", "koala", "lumen", "meadow", "nimbus", "orchid", "pebble"}
+)
+
+func fakeValue(kind string, digest [32]byte) any {
+	pick := func(list []string, offset int) string {
+		return list[int(binary.BigEndian.Uint16(digest[offset:offset+2]))%len(list)]
+	}
+	switch kind {
+	case "first_name":
+		return pick(fakeFirstNames, 0)
+	case "last_name":
+		return pick(fakeLastNames, 2)
+	case "name":
+		return pick(fakeFirstNames, 0) + " " + pick(fakeLastNames, 2)
+	case "email":
+		return fmt.Sprintf("%s.%s%d@example.com", strings.ToLower(pick(fakeFirstNames, 0)), strings.ToLower(pick(fakeLastNames, 2)), binary.BigEndian.Uint16(digest[4:6])%1000)
+	case "phone":
+		return fmt.Sprintf("+1-555-%04d", binary.BigEndian.Uint32(digest[6:10])%10000)
+	case "uuid":
+		b := digest[:16]
+		b[6] = (b[6] & 0x0f) | 0x40
+		b[8] = (b[8] & 0x3f) | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+	case "word":
+		return pick(fakeWords, 10)
+	case "number":
+		return float64(binary.BigEndian.Uint32(digest[12:16]) % 1000000)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAnonymizationRulesApply(t *testing.T) {
+	rules := &anonymizationRules{
+		Salt: "s",
+		Fields: map[string]string{
+			"email":          "fake:email",
+			"ssn":            "null",
+			"notes":          "drop",
+			"address.street": "redact",
+			"customer_id":    "hash:12",
+			"missing":        "redact",
+		},
+	}
+	data := map[string]any{
+		"email":       "real@example.org",
+		"ssn":         "123-45-6789",
+		"notes":       "secret",
+		"address":     map[string]any{"street": "1 Main St", "city": "Springfield"},
+		"customer_id": "cust_1",
+		"status":      "active",
+	}
+	if err := rules.apply(data); err != nil {
+		t.Fatalf("apply returned error: %v", err)
+	}
+	if data["ssn"] != nil {
+		t.Fatalf("expected ssn to be null, got %v", data["ssn"])
+	}
+	if _, ok := data["notes"]; ok {
+		t.Fatalf("expected notes to be dropped")
+	}
+	if street := data["address"].(map[string]any)["street"]; street != "***" {
+		t.Fatalf("expected street to be redacted, got %v", street)
+	}
+	if id, _ := data["customer_id"].(string); len(id) != 12 {
+		t.Fatalf("expected 12-char hash, got %v", data["customer_id"])
+	}
+	if email, _ := data["email"].(string); email == "real@example.org" || email == "" {
+		t.Fatalf("expected fake email, got %v", data["email"])
+	}
+	if _, ok := data["missing"]; ok {
+		t.Fatalf("rules must not add missing fields")
+	}
+
+	again := map[string]any{"email": "real@example.org", "customer_id": "cust_1"}
+	if err := rules.apply(again); err != nil {
+		t.Fatalf("apply returned error: %v", err)
+	}
+	if !reflect.DeepEqual(again["email"], data["email"]) || !reflect.DeepEqual(again["customer_id"], data["customer_id"]) {
+		t.Fatalf("expected deterministic output, got %v and %v", again, data)
+	}
+}
+
+func TestLoadAnonymizationRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(path, []byte("fields:\n  ssn: null\n  name: fake:name\n"), 0o600); err != nil {
+		t.Fatalf("write rules: %v", err)
+	}
+	rules, err := loadAnonymizationRules(path)
+	if err != nil {
+		t.Fatalf("loadAnonymizationRules returned error: %v", err)
+	}
+	if len(rules.Fields) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules.Fields))
+	}
+	if err := os.WriteFile(path, []byte("fields:\n  name: fake:planet\n"), 0o600); err != nil {
+		t.Fatalf("write rules: %v", err)
+	}
+	if _, err := loadAnonymizationRules(path); err == nil {
+		t.Fatalf("expected error for unsupported fake kind")
+	}
+}
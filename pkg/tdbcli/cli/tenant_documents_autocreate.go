@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// primaryKeyCandidates are checked, in order, before other *_id fields when
+// inferring a primary key from a payload.
+var primaryKeyCandidates = []string{"id", "key", "_id", "uuid"}
+
+// collectionAutoCreate holds the --create-collection and --infer-schema flags
+// shared by the document loading commands.
+type collectionAutoCreate struct {
+	create bool
+	infer  bool
+}
+
+func (a *collectionAutoCreate) bind(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&a.create, "create-collection", false, "Create the collection first if it does not exist")
+	cmd.Flags().BoolVar(&a.infer, "infer-schema", false, "With --create-collection, infer the schema and primary key from the payload")
+}
+
+func (a *collectionAutoCreate) validate() error {
+	if a.infer && !a.create {
+		return errors.New("--infer-schema requires --create-collection")
+	}
+	return nil
+}
+
+// ensure creates collection when --create-collection is set and it does not
+// exist yet. pkField, when set, is used as the primary key instead of an
+// inferred one.
+func (a *collectionAutoCreate) ensure(cmd *cobra.Command, tenantClient *clientpkg.TenantClient, collection, appID, pkField string, docs []map[string]any) error {
+	if !a.create {
+		return nil
+	}
+	_, err := tenantClient.GetCollection(cmd.Context(), collection, appID)
+	if err == nil {
+		return nil
+	}
+	if !isNotFoundError(err) {
+		return err
+	}
+	req := clientpkg.CreateCollectionRequest{Name: collection, AppID: strings.TrimSpace(appID)}
+	if a.infer {
+		samples := make([]any, len(docs))
+		for i, doc := range docs {
+			samples[i] = doc
+		}
+		schema, err := json.Marshal(inferSchemaFromSamples(samples, false))
+		if err != nil {
+			return err
+		}
+		req.Schema = string(schema)
+		if strings.TrimSpace(pkField) == "" {
+			req.PrimaryKey = inferPrimaryKey(docs)
+		}
+	}
+	if field := strings.TrimSpace(pkField); field != "" {
+		req.PrimaryKey = &clientpkg.PrimaryKeySpec{Field: field, Type: primaryKeyType(docs, field)}
+	}
+	col, err := tenantClient.CreateCollection(cmd.Context(), req)
+	if err != nil {
+		return fmt.Errorf("create collection %s: %w", collection, err)
+	}
+	if req.PrimaryKey != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Created collection %s (%s) with primary key %s (%s)\n", col.Name, col.ID, req.PrimaryKey.Field, req.PrimaryKey.Type)
+	} else {
+		fmt.Fprintf(cmd.ErrOrStderr(), tr("Created collection %s (%s)\n"), col.Name, col.ID)
+	}
+	return nil
+}
+
+// inferPrimaryKey returns the first candidate field that is present in every
+// document with a unique string or integer value, or nil when none qualifies.
+func inferPrimaryKey(docs []map[string]any) *clientpkg.PrimaryKeySpec {
+	if len(docs) == 0 {
+		return nil
+	}
+	candidates := append([]string{}, primaryKeyCandidates...)
+	var idFields []string
+	for field := range docs[0] {
+		if strings.HasSuffix(field, "_id") && field != "_id" {
+			idFields = append(idFields, field)
+		}
+	}
+	sort.Strings(idFields)
+	candidates = append(candidates, idFields...)
+	for _, field := range candidates {
+		if isUniqueKeyField(docs, field) {
+			return &clientpkg.PrimaryKeySpec{Field: field, Type: primaryKeyType(docs, field)}
+		}
+	}
+	return nil
+}
+
+func isUniqueKeyField(docs []map[string]any, field string) bool {
+	seen := make(map[string]struct{}, len(docs))
+	for _, doc := range docs {
+		var key string
+		switch value := doc[field].(type) {
+		case string:
+			if strings.TrimSpace(value) == "" {
+				return false
+			}
+			key = "s:" + value
+		case float64:
+			if value != math.Trunc(value) {
+				return false
+			}
+			key = fmt.Sprintf("n:%v", value)
+		default:
+			return false
+		}
+		if _, dup := seen[key]; dup {
+			return false
+		}
+		seen[key] = struct{}{}
+	}
+	return true
+}
+
+// primaryKeyType reports "number" when every value of field is numeric and
+// "string" otherwise.
+func primaryKeyType(docs []map[string]any, field string) string {
+	numeric := false
+	for _, doc := range docs {
+		switch doc[field].(type) {
+		case float64:
+			numeric = true
+		case nil:
+		default:
+			return "string"
+		}
+	}
+	if numeric {
+		return "number"
+	}
+	return "string"
+}
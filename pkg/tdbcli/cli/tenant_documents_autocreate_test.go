@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestInferPrimaryKey(t *testing.T) {
+	cases := []struct {
+		name  string
+		docs  []map[string]any
+		field string
+		typ   string
+	}{
+		{name: "id wins", docs: []map[string]any{{"id": "a", "sku": "x"}, {"id": "b", "sku": "y"}}, field: "id", typ: "string"},
+		{name: "numeric key", docs: []map[string]any{{"key": 1.0}, {"key": 2.0}}, field: "key", typ: "number"},
+		{name: "duplicate id falls through", docs: []map[string]any{{"id": "a", "order_id": "o1"}, {"id": "a", "order_id": "o2"}}, field: "order_id", typ: "string"},
+		{name: "fractional numbers rejected", docs: []map[string]any{{"id": 1.5}}},
+		{name: "missing in one document", docs: []map[string]any{{"id": "a"}, {"name": "b"}}},
+	}
+	for _, tc := range cases {
+		spec := inferPrimaryKey(tc.docs)
+		if tc.field == "" {
+			if spec != nil {
+				t.Fatalf("%s: expected no primary key, got %+v", tc.name, spec)
+			}
+			continue
+		}
+		if spec == nil || spec.Field != tc.field || spec.Type != tc.typ {
+			t.Fatalf("%s: unexpected primary key %+v", tc.name, spec)
+		}
+	}
+}
+
+func TestBulkCreateCreatesCollection(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+
+	run := func(args ...string) (string, error) {
+		out, errOut, err := runCommandWithEnv(t, env, newTenantDocumentsBulkCreateCommand, append([]string{"products"}, args...)...)
+		return out + errOut, err
+	}
+
+	if _, err := run("--infer-schema", "--data", `[{"sku":"a"}]`); err == nil || !strings.Contains(err.Error(), "requires --create-collection") {
+		t.Fatalf("expected flag validation error, got %v", err)
+	}
+
+	payload := `[{"sku_id":"a","price":1.5},{"sku_id":"b","price":2}]`
+	out, err := run("--create-collection", "--infer-schema", "--data", payload)
+	if err != nil {
+		t.Fatalf("bulk-create failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Created collection products") || !strings.Contains(out, "primary key sku_id (string)") {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+	col, err := srv.TenantClient(t).GetCollection(context.Background(), "products", "")
+	if err != nil {
+		t.Fatalf("GetCollection: %v", err)
+	}
+	if col.PrimaryKeyField != "sku_id" || !strings.Contains(col.SchemaJSON, `"price"`) {
+		t.Fatalf("unexpected collection %+v", col)
+	}
+	if docs := srv.Documents("products"); len(docs) != 2 {
+		t.Fatalf("expected 2 documents, got %d", len(docs))
+	}
+
+	// A second run finds the collection and only loads documents.
+	out, err = run("--create-collection", "--data", `[{"sku_id":"c"}]`)
+	if err != nil || strings.Contains(out, "Created collection") {
+		t.Fatalf("expected existing collection to be reused: %v\n%s", err, out)
+	}
+}
@@ -3,20 +3,27 @@ package cli
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	canonicalpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/canonical"
 	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/diff"
 )
 
 // Reconstructed missing commands (list/get) and cleaned export implementation.
@@ -25,6 +32,8 @@ func newTenantDocumentsGetCommand(env *Environment) *cobra.Command {
 	var auth authFlags
 	var raw bool
 	var rawPretty bool
+	var maxDocSize string
+	var expand string
 
 	cmd := &cobra.Command{
 		Use:   "get <collection> <id>",
@@ -42,12 +51,26 @@ func newTenantDocumentsGetCommand(env *Environment) *cobra.Command {
 			collection := strings.TrimSpace(args[0])
 			id := strings.TrimSpace(args[1])
 			if collection == "" || id == "" {
-				return errors.New("collection and document ID are required")
+				return errors.New(tr("collection and document ID are required"))
 			}
-			doc, err := tenantClient.GetDocument(cmd.Context(), collection, id, auth.appID)
+			limit, limitSet, err := parseMaxDocSize(maxDocSize)
 			if err != nil {
 				return err
 			}
+			expansions, err := parseExpandFlag(expand)
+			if err != nil {
+				return err
+			}
+			ctx := withDocumentSizeLimit(cmd.Context(), limit, limitSet, 1)
+			doc, err := tenantClient.GetDocument(ctx, collection, id, auth.appID)
+			if err != nil {
+				return explainDocumentSizeError(err)
+			}
+			docs := []clientpkg.Document{*doc}
+			if err := expandDocuments(ctx, tenantClient, auth.appID, docs, expansions, cmd.ErrOrStderr()); err != nil {
+				return err
+			}
+			doc = &docs[0]
 			if raw || rawPretty {
 				if rawPretty {
 					return printJSON(cmd, makeDocumentPretty(*doc))
@@ -71,6 +94,8 @@ func newTenantDocumentsGetCommand(env *Environment) *cobra.Command {
 	auth.bindWithApp(cmd)
 	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON response")
 	cmd.Flags().BoolVar(&rawPretty, "raw-pretty", false, "Print pretty JSON response")
+	cmd.Flags().StringVar(&maxDocSize, "max-doc-size", "", maxDocSizeFlagUsage)
+	cmd.Flags().StringVar(&expand, "expand", "", expandFlagUsage)
 	return cmd
 }
 
@@ -81,11 +106,17 @@ func newTenantDocumentsListCommand(env *Environment) *cobra.Command {
 	var cursor string
 	var includeDeleted bool
 	var filters []string
+	var rawFilters []string
+	var exprFilters filterExprFlags
 	var selectFields string
 	var selectOnly bool
 	var sortFields string
+	var timeRange documentTimeRangeFlags
+	var stateFile string
+	var expand string
 	var raw bool
 	var rawPretty bool
+	var interactiveFilter bool
 
 	cmd := &cobra.Command{
 		Use:   "list <collection>",
@@ -94,44 +125,70 @@ func newTenantDocumentsListCommand(env *Environment) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			envCtx, err := requireEnvironment(env)
 			if err != nil { return err }
-			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			tenantClient, _, tenantID, err := auth.resolveTenantClient(envCtx, cmd)
 			if err != nil { return err }
 			collection := strings.TrimSpace(args[0])
-			if collection == "" { return errors.New("collection name cannot be empty") }
-			pageLimit := limit
+			if collection == "" { return errors.New(tr("collection name cannot be empty")) }
+			pageLimit := tunePageSize(cmd, envCtx, "limit", limit)
 			if pageLimit <= 0 { pageLimit = 50 }
-			filterMap := map[string]string{}
-			for _, f := range filters {
-				parts := strings.SplitN(f, "=", 2)
-				if len(parts) != 2 { return fmt.Errorf("invalid filter %q (expected key=value)", f) }
-				k := strings.TrimSpace(parts[0])
-				v := strings.TrimSpace(parts[1])
-				if k == "" { return fmt.Errorf("filter key cannot be empty: %q", f) }
-				filterMap[k] = v
+			if interactiveFilter {
+				selection, err := promptDocumentListSelection(cmd.Context(), tenantClient, collection, auth.appID)
+				if err != nil { return err }
+				filters = append(filters, selection.Filters...)
+				sortFields = selection.Sort
+				if len(selection.Select) > 0 { selectFields = strings.Join(selection.Select, ","); selectOnly = selection.SelectOnly }
+				fmt.Fprintf(cmd.OutOrStdout(), "Equivalent command:\n  %s\n\n", buildDocumentsListCommandLine(collection, pageLimit, documentListSelection{Filters: filters, Sort: sortFields, Select: splitCommaList(selectFields), SelectOnly: selectOnly}))
 			}
+			typed, err := resolveTypedFilters(cmd.Context(), envCtx, tenantClient, tenantID, collection, auth.appID, filters, rawFilters)
+			if err != nil { return err }
+			filterMap := typed.queryParams()
+			matcher, err := exprFilters.resolve(cmd.Context(), envCtx, tenantClient, auth.tenantID, collection, auth.appID)
+			if err != nil { return err }
+			// Conditions the list API cannot express are applied to each page here.
+			matcher = matcher.pushDown(filterMap)
+			bounds, err := timeRange.resolve(time.Now())
+			if err != nil { return err }
+			incremental, err := startIncrementalExtract(stateFile, collection, &bounds)
+			if err != nil { return err }
+			expansions, err := parseExpandFlag(expand)
+			if err != nil { return err }
 			params := clientpkg.ListDocumentsParams{AppID: auth.appID, Limit: pageLimit, Offset: offset, Cursor: strings.TrimSpace(cursor), IncludeDeleted: includeDeleted, Filters: filterMap}
-			if trimmed := strings.TrimSpace(selectFields); trimmed != "" { params.SelectFields = splitCommaList(trimmed) }
+			bounds.apply(&params)
+			var nested selectTree
+			if trimmed := strings.TrimSpace(selectFields); isNestedSelect(trimmed) || (trimmed != "" && len(expansions) > 0) {
+				if nested, err = parseNestedSelect(trimmed); err != nil { return err }
+				// References are fetched for --expand even when unselected; the projection drops them again but keeps _expanded.
+				params.SelectFields = expansionSelectFields(nested.topLevel(), expansions)
+				if len(expansions) > 0 { nested[expandedKey] = nil }
+			} else if trimmed != "" { params.SelectFields = splitCommaList(trimmed) }
 			params.SelectOnly = selectOnly
-			if trimmed := strings.TrimSpace(sortFields); trimmed != "" { sortTokens, err := normalizeDocumentSortTokens(splitCommaList(trimmed)); if err != nil { return err }; params.Sort = sortTokens }
-			resp, err := tenantClient.ListDocuments(cmd.Context(), collection, params)
-			if err != nil { return err }
-			if raw || rawPretty {
-				if rawPretty { return printJSON(cmd, resp) }
-				return printJSON(cmd, resp)
+			if err := matcher.requireSelected(params.SelectFields); err != nil { return err }
+			if trimmed := strings.TrimSpace(sortFields); trimmed != "" { sortTokens, err := resolveDocumentSort(cmd.Context(), tenantClient, collection, auth.appID, splitCommaList(trimmed)); if err != nil { return err }; params.Sort = sortTokens }
+			if incremental != nil {
+				// Oldest changes first, with the ID breaking ties, so the watermark never skips documents beyond --limit.
+				if cmd.Flags().Changed("sort") { return errors.New("--state-file orders by updated_at; drop --sort") }
+				params.Sort = []string{"updated_at", "id"}
+			}
+			var resp *clientpkg.DocumentListResponse
+			scanned := -1
+			if matcher != nil || incremental != nil {
+				// The list API cannot express OR, and documents at the watermark
+				// come back again, so keep paging until --limit documents match;
+				// server totals would count non-matches too.
+				resp, scanned, err = listMatchingDocuments(cmd.Context(), tenantClient, collection, params, matcher, incremental)
+				if err != nil { return err }
+				if matcher == nil { scanned = -1 }
+			} else {
+				resp, err = tenantClient.ListDocuments(cmd.Context(), collection, params)
+				if err != nil { return err }
 			}
-			if len(resp.Items) == 0 { fmt.Fprintln(cmd.OutOrStdout(), "No documents found"); return nil }
-			rows := make([][]string, 0, len(resp.Items))
-			for _, item := range resp.Items {
-				rows = append(rows, []string{
-					item.ID,
-					item.Key,
-					formatTime(item.CreatedAt),
-					formatTime(item.UpdatedAt),
-				})
+			if err := expandDocuments(cmd.Context(), tenantClient, auth.appID, resp.Items, expansions, cmd.ErrOrStderr()); err != nil { return err }
+			if nested != nil {
+				if err := nested.projectDocuments(resp.Items); err != nil { return err }
 			}
-			renderTable(cmd, []string{"ID", "KEY", "CREATED", "UPDATED"}, rows)
-			p := resp.Pagination
-			fmt.Fprintf(cmd.OutOrStdout(), "COUNT: %d  LIMIT: %d  OFFSET: %d\n", p.Count, p.Limit, p.Offset)
+			if err := renderDocumentList(cmd, resp, scanned, raw, rawPretty, len(expansions) > 0); err != nil { return err }
+			// The watermark only moves once the documents have been printed.
+			if err := incremental.finish(time.Now()); err != nil { return fmt.Errorf("write state file: %w", err) }
 			return nil
 		},
 	}
@@ -140,15 +197,54 @@ func newTenantDocumentsListCommand(env *Environment) *cobra.Command {
 	cmd.Flags().IntVar(&offset, "offset", 0, "Offset for pagination")
 	cmd.Flags().StringVar(&cursor, "cursor", "", "Cursor for pagination")
 	cmd.Flags().BoolVar(&includeDeleted, "include-deleted", false, "Include soft-deleted documents")
-	cmd.Flags().StringArrayVar(&filters, "filter", nil, "Filter predicate field=value (repeatable)")
-	cmd.Flags().StringVar(&selectFields, "select", "", "Comma-separated list of fields to project")
+	cmd.Flags().StringArrayVar(&filters, "filter", nil, "Filter predicate field=value, coerced to the schema type of the field (repeatable)")
+	cmd.Flags().StringArrayVar(&rawFilters, "filter-raw", nil, filterRawFlagUsage)
+	exprFilters.bind(cmd)
+	timeRange.bind(cmd)
+	cmd.Flags().StringVar(&selectFields, "select", "", "Fields to project: comma-separated, or nested like 'user{name,email},items{sku,qty}'")
 	cmd.Flags().BoolVar(&selectOnly, "select-only", false, "Restrict output to selected fields only (omit implicit metadata fields)")
-	cmd.Flags().StringVar(&sortFields, "sort", "-created_at", "Comma-separated sort fields (prefix with - for descending)")
+	cmd.Flags().StringVar(&sortFields, "sort", "-created_at", "Comma-separated sort fields (prefix with - for descending); data fields such as data.price when the collection lists them as sortable")
+	cmd.Flags().StringVar(&stateFile, "state-file", "", stateFileFlagUsage)
+	cmd.Flags().StringVar(&expand, "expand", "", expandFlagUsage+"; prints JSON")
 	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON response")
 	cmd.Flags().BoolVar(&rawPretty, "raw-pretty", false, "Print pretty JSON response")
+	cmd.Flags().BoolVar(&interactiveFilter, "interactive-filter", false, "Build filters, sort, and projection interactively from the collection schema")
 	return cmd
 }
 
+// renderDocumentList prints a documents list page. scanned is the number of
+// documents scanned for client-side filters, or -1 when none were applied.
+func renderDocumentList(cmd *cobra.Command, resp *clientpkg.DocumentListResponse, scanned int, raw, rawPretty, expanded bool) error {
+	// The table has no data column, so expanded documents are printed as JSON.
+	if expanded && !raw {
+		return printJSON(cmd, makeDocumentListPretty(resp))
+	}
+	if raw || rawPretty {
+		return printJSON(cmd, resp)
+	}
+	if len(resp.Items) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), tr("No documents found"))
+		return nil
+	}
+	rows := make([][]string, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		rows = append(rows, []string{
+			item.ID,
+			item.Key,
+			formatTime(item.CreatedAt),
+			formatTime(item.UpdatedAt),
+		})
+	}
+	renderTable(cmd, []string{"ID", "KEY", "CREATED", "UPDATED"}, rows)
+	p := resp.Pagination
+	if scanned >= 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "MATCHED: %d  SCANNED: %d  LIMIT: %d (--filter-or/--filter-expr applied client-side)\n", p.Count, scanned, p.Limit)
+		return nil
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "COUNT: %d  LIMIT: %d  OFFSET: %d\n", p.Count, p.Limit, p.Offset)
+	return nil
+}
+
 func newTenantDocumentsCreateCommand(env *Environment) *cobra.Command {
 	var auth authFlags
 	var data string
@@ -156,6 +252,11 @@ func newTenantDocumentsCreateCommand(env *Environment) *cobra.Command {
 	var stdin bool
 	var raw bool
 	var rawPretty bool
+	var offline bool
+	var idempotencyKey string
+	var showExisting bool
+	var strict bool
+	var expiry documentExpiry
 
 	cmd := &cobra.Command{
 		Use:   "create <collection>",
@@ -186,7 +287,13 @@ If the collection has a primary key configured with auto-generation, the ID will
   tdb tenant documents create logs \
     --data '{"level":"info","message":"Server started"}' \
     --app app_123 \
-    --api-key $API_KEY`,
+    --api-key $API_KEY
+
+  # Reject fields the collection schema does not define
+  tdb tenant documents create users --data '{"email":"a@example.com","stauts":"active"}' --strict
+
+  # Expire the document a week from now (sets the collection's TTL field)
+  tdb tenant documents create sessions --data '{"user":"u_1"}' --expires-in 7d`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			envCtx, err := requireEnvironment(env)
@@ -199,23 +306,46 @@ If the collection has a primary key configured with auto-generation, the ID will
 			}
 			collection := strings.TrimSpace(args[0])
 			if collection == "" {
-				return errors.New("collection name cannot be empty")
+				return errors.New(tr("collection name cannot be empty"))
+			}
+			if strict && offline {
+				return errors.New("--strict cannot be combined with --offline")
+			}
+			if err := expiry.validate(); err != nil {
+				return err
 			}
 			payload, err := readJSONPayload(cmd, data, file, stdin, false)
 			if err != nil {
 				return err
 			}
-			doc, err := tenantClient.CreateDocument(cmd.Context(), collection, payload, auth.appID)
+			// Check the caller's fields before --expires-in adds one the schema may not list.
+			if strict {
+				if err := checkStrictPayload(cmd.Context(), tenantClient, collection, auth.appID, payload); err != nil {
+					return err
+				}
+			}
+			payload, err = expiry.apply(cmd, tenantClient, collection, auth.appID, payload, !offline)
 			if err != nil {
 				return err
 			}
+			if offline {
+				return enqueueOfflineOperation(cmd, envCtx, &auth, "create", collection, "", payload)
+			}
+			ctx := cmd.Context()
+			if key := strings.TrimSpace(idempotencyKey); key != "" {
+				ctx = clientpkg.WithIdempotencyKey(ctx, key)
+			}
+			doc, err := tenantClient.CreateDocument(ctx, collection, payload, auth.appID)
+			if err != nil {
+				return explainConflict(cmd, tenantClient, collection, auth.appID, err, showExisting)
+			}
 			if raw || rawPretty {
 				if rawPretty {
 					return printJSON(cmd, makeDocumentPretty(*doc))
 				}
 				return printJSON(cmd, doc)
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "Created document %s\n", doc.ID)
+			fmt.Fprintf(cmd.OutOrStdout(), tr("Created document %s\n"), doc.ID)
 			return nil
 		},
 	}
@@ -226,6 +356,11 @@ If the collection has a primary key configured with auto-generation, the ID will
 	cmd.Flags().BoolVar(&stdin, "stdin", false, "Read JSON payload from stdin")
 	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON response")
 	cmd.Flags().BoolVar(&rawPretty, "raw-pretty", false, "Print pretty JSON response")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Queue the operation locally instead of calling the API (replay with tdb queue flush)")
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "Idempotency-Key to send (defaults to a generated key reused across retries)")
+	cmd.Flags().BoolVar(&showExisting, "show-existing", false, showExistingFlagUsage)
+	cmd.Flags().BoolVar(&strict, "strict", false, strictFlagUsage)
+	expiry.bind(cmd)
 
 	return cmd
 }
@@ -237,6 +372,9 @@ func newTenantDocumentsUpdateCommand(env *Environment) *cobra.Command {
 	var stdin bool
 	var raw bool
 	var rawPretty bool
+	var offline bool
+	var showExisting bool
+	var strict bool
 
 	cmd := &cobra.Command{
 		Use:   "update <collection> <id>",
@@ -273,15 +411,26 @@ This performs a full replacement - all existing fields will be replaced with the
 			collection := strings.TrimSpace(args[0])
 			id := strings.TrimSpace(args[1])
 			if collection == "" || id == "" {
-				return errors.New("collection and document ID are required")
+				return errors.New(tr("collection and document ID are required"))
+			}
+			if strict && offline {
+				return errors.New("--strict cannot be combined with --offline")
 			}
 			payload, err := readJSONPayload(cmd, data, file, stdin, false)
 			if err != nil {
 				return err
 			}
+			if offline {
+				return enqueueOfflineOperation(cmd, envCtx, &auth, "update", collection, id, payload)
+			}
+			if strict {
+				if err := checkStrictPayload(cmd.Context(), tenantClient, collection, auth.appID, payload); err != nil {
+					return err
+				}
+			}
 			doc, err := tenantClient.UpdateDocument(cmd.Context(), collection, id, payload, auth.appID)
 			if err != nil {
-				return err
+				return explainConflict(cmd, tenantClient, collection, auth.appID, err, showExisting)
 			}
 			if raw || rawPretty {
 				if rawPretty {
@@ -289,7 +438,7 @@ This performs a full replacement - all existing fields will be replaced with the
 				}
 				return printJSON(cmd, doc)
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "Updated document %s\n", doc.ID)
+			fmt.Fprintf(cmd.OutOrStdout(), tr("Updated document %s\n"), doc.ID)
 			return nil
 		},
 	}
@@ -300,6 +449,9 @@ This performs a full replacement - all existing fields will be replaced with the
 	cmd.Flags().BoolVar(&stdin, "stdin", false, "Read JSON payload from stdin")
 	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON response")
 	cmd.Flags().BoolVar(&rawPretty, "raw-pretty", false, "Print pretty JSON response")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Queue the operation locally instead of calling the API (replay with tdb queue flush)")
+	cmd.Flags().BoolVar(&showExisting, "show-existing", false, showExistingFlagUsage)
+	cmd.Flags().BoolVar(&strict, "strict", false, strictFlagUsage)
 
 	return cmd
 }
@@ -311,13 +463,22 @@ func newTenantDocumentsPatchCommand(env *Environment) *cobra.Command {
 	var stdin bool
 	var raw bool
 	var rawPretty bool
+	var offline bool
+	var showExisting bool
+	var patchType string
+	var showDiff bool
+	var dryRun bool
 
 	cmd := &cobra.Command{
 		Use:   "patch <collection> <id>",
 		Short: "Patch a document",
 		Long: `Partially update a document by merging the provided changes with the existing document.
 
-This performs a JSON merge patch operation - only the fields you specify will be updated, and existing fields not mentioned in the patch will remain unchanged. To remove a field, set its value to null.`,
+By default this performs a JSON merge patch operation - only the fields you specify will be updated, and existing fields not mentioned in the patch will remain unchanged. To remove a field, set its value to null.
+
+With --patch-type json-patch the payload is an RFC 6902 operation array (add, remove, replace, move, copy, test) sent as application/json-patch+json. Use it for precise array edits, and "test" operations as preconditions: if one fails, nothing is applied.
+
+--diff prints the fields the patch changed, with + for added, - for removed, and ~ for changed fields. --dry-run previews that diff by applying a merge patch locally to the current document, without writing anything. With --raw or --raw-pretty the diff is printed as JSON.`,
 		Example: `  # Patch specific fields
   tdb tenant documents patch users user_123 \
     --data '{"status":"active","last_login":"2025-01-15T10:00:00Z"}' \
@@ -340,6 +501,14 @@ This performs a JSON merge patch operation - only the fields you specify will be
   tdb tenant documents patch settings cfg_001 \
     --data '{"enabled":true}' \
     --app app_123 \
+    --api-key $API_KEY
+
+  # Preview which fields a patch would change
+  tdb tenant documents patch users user_123 --data '{"status":"suspended","note":null}' --dry-run
+
+  # JSON Patch: only if the status is still pending, approve and drop the first tag
+  tdb tenant documents patch orders ord_42 --patch-type json-patch \
+    --data '[{"op":"test","path":"/status","value":"pending"},{"op":"replace","path":"/status","value":"approved"},{"op":"remove","path":"/tags/0"}]' \
     --api-key $API_KEY`,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -354,16 +523,70 @@ This performs a JSON merge patch operation - only the fields you specify will be
 			collection := strings.TrimSpace(args[0])
 			id := strings.TrimSpace(args[1])
 			if collection == "" || id == "" {
-				return errors.New("collection and document ID are required")
+				return errors.New(tr("collection and document ID are required"))
 			}
-			payload, err := readJSONPayload(cmd, data, file, stdin, false)
+			kind, err := normalizePatchType(patchType)
 			if err != nil {
 				return err
 			}
-			doc, err := tenantClient.PatchDocument(cmd.Context(), collection, id, payload, auth.appID)
+			if dryRun && kind == patchTypeJSONPatch {
+				return errors.New("--dry-run supports merge patches only; use --diff to see what a json-patch changed")
+			}
+			if (showDiff || dryRun) && offline {
+				return errors.New("--diff and --dry-run cannot be combined with --offline")
+			}
+			payload, err := readJSONPayload(cmd, data, file, stdin, kind == patchTypeJSONPatch)
 			if err != nil {
 				return err
 			}
+			var before *clientpkg.Document
+			if showDiff || dryRun {
+				if before, err = tenantClient.GetDocument(cmd.Context(), collection, id, auth.appID); err != nil {
+					return err
+				}
+			}
+			if dryRun {
+				if bytes.HasPrefix(payload, []byte("[")) {
+					return errors.New("merge patch payload must be a JSON object; use --patch-type json-patch for an operation array")
+				}
+				current, err := decodeJSONPreservingNumbers([]byte(before.Data))
+				if err != nil {
+					return fmt.Errorf("decode document data: %w", err)
+				}
+				patch, err := decodeJSONPreservingNumbers(payload)
+				if err != nil {
+					return fmt.Errorf("decode patch: %w", err)
+				}
+				return printPatchDiff(cmd, before.ID, diff.Values(current, applyMergePatch(current, patch)), raw || rawPretty, true)
+			}
+			var doc *clientpkg.Document
+			if kind == patchTypeJSONPatch {
+				if err := validateJSONPatch(payload); err != nil {
+					return err
+				}
+				if offline {
+					return errors.New("--offline supports merge patches only")
+				}
+				doc, err = tenantClient.ApplyJSONPatch(cmd.Context(), collection, id, payload, auth.appID)
+			} else {
+				if bytes.HasPrefix(payload, []byte("[")) {
+					return errors.New("merge patch payload must be a JSON object; use --patch-type json-patch for an operation array")
+				}
+				if offline {
+					return enqueueOfflineOperation(cmd, envCtx, &auth, "patch", collection, id, payload)
+				}
+				doc, err = tenantClient.PatchDocument(cmd.Context(), collection, id, payload, auth.appID)
+			}
+			if err != nil {
+				return explainConflict(cmd, tenantClient, collection, auth.appID, err, showExisting)
+			}
+			if showDiff {
+				changes, err := diff.JSON([]byte(before.Data), []byte(doc.Data))
+				if err != nil {
+					return err
+				}
+				return printPatchDiff(cmd, doc.ID, changes, raw || rawPretty, false)
+			}
 			if raw || rawPretty {
 				if rawPretty {
 					return printJSON(cmd, makeDocumentPretty(*doc))
@@ -381,6 +604,11 @@ This performs a JSON merge patch operation - only the fields you specify will be
 	cmd.Flags().BoolVar(&stdin, "stdin", false, "Read JSON payload from stdin")
 	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON response")
 	cmd.Flags().BoolVar(&rawPretty, "raw-pretty", false, "Print pretty JSON response")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Queue the operation locally instead of calling the API (replay with tdb queue flush)")
+	cmd.Flags().BoolVar(&showExisting, "show-existing", false, showExistingFlagUsage)
+	cmd.Flags().StringVar(&patchType, "patch-type", patchTypeMerge, "Patch format: merge (JSON Merge Patch) or json-patch (RFC 6902 operation array)")
+	cmd.Flags().BoolVar(&showDiff, "diff", false, "Print the fields the patch changed")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview the changes of a merge patch without applying it")
 
 	return cmd
 }
@@ -389,6 +617,7 @@ func newTenantDocumentsDeleteCommand(env *Environment) *cobra.Command {
 	var auth authFlags
 	var purge bool
 	var confirm bool
+	var offline bool
 
 	cmd := &cobra.Command{
 		Use:   "delete <collection> <id>",
@@ -422,11 +651,17 @@ Soft-deleted documents can still be queried with the --include-deleted flag.`,
 			collection := strings.TrimSpace(args[0])
 			id := strings.TrimSpace(args[1])
 			if collection == "" || id == "" {
-				return errors.New("collection and document ID are required")
+				return errors.New(tr("collection and document ID are required"))
 			}
 			if purge {
 				if !confirm {
-					return errors.New("use --confirm to acknowledge irreversible purge")
+					return errors.New(tr("use --confirm to acknowledge irreversible purge"))
+				}
+				if err := confirmDestructive(cmd, envCtx, "permanently purge 1 document", "Collection: "+collection, "Document: "+id); err != nil {
+					return err
+				}
+				if offline {
+					return enqueueOfflineOperation(cmd, envCtx, &auth, "purge", collection, id, nil)
 				}
 				if err := tenantClient.PurgeDocument(cmd.Context(), collection, id, true, auth.appID); err != nil {
 					return err
@@ -434,10 +669,16 @@ Soft-deleted documents can still be queried with the --include-deleted flag.`,
 				fmt.Fprintf(cmd.OutOrStdout(), "Purged document %s\n", id)
 				return nil
 			}
+			if err := confirmDestructive(cmd, envCtx, "delete 1 document", "Collection: "+collection, "Document: "+id); err != nil {
+				return err
+			}
+			if offline {
+				return enqueueOfflineOperation(cmd, envCtx, &auth, "delete", collection, id, nil)
+			}
 			if err := tenantClient.DeleteDocument(cmd.Context(), collection, id, auth.appID); err != nil {
 				return err
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "Deleted document %s\n", id)
+			fmt.Fprintf(cmd.OutOrStdout(), tr("Deleted document %s\n"), id)
 			return nil
 		},
 	}
@@ -445,6 +686,8 @@ Soft-deleted documents can still be queried with the --include-deleted flag.`,
 	auth.bindWithApp(cmd)
 	cmd.Flags().BoolVar(&purge, "purge", false, "Permanently purge the document")
 	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm irreversible purge")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Queue the operation locally instead of calling the API (replay with tdb queue flush)")
+	bindSafeModeYes(cmd)
 
 	return cmd
 }
@@ -456,6 +699,12 @@ func newTenantDocumentsBulkCreateCommand(env *Environment) *cobra.Command {
 	var stdin bool
 	var raw bool
 	var rawPretty bool
+	var idempotencyKey string
+	var autoCreate collectionAutoCreate
+	var expiry documentExpiry
+	var batching batchOptions
+	var noAutoSplit bool
+	stats := newOperationStats()
 
 	cmd := &cobra.Command{
 		Use:   "bulk-create <collection>",
@@ -472,16 +721,107 @@ func newTenantDocumentsBulkCreateCommand(env *Environment) *cobra.Command {
 			}
 			collection := strings.TrimSpace(args[0])
 			if collection == "" {
-				return errors.New("collection name cannot be empty")
+				return errors.New(tr("collection name cannot be empty"))
+			}
+			if err := autoCreate.validate(); err != nil {
+				return err
+			}
+			if err := expiry.validate(); err != nil {
+				return err
+			}
+			if err := batching.validate(); err != nil {
+				return err
 			}
 			payload, err := readJSONPayload(cmd, data, file, stdin, true)
 			if err != nil {
 				return err
 			}
-			resp, err := tenantClient.BulkCreateDocuments(cmd.Context(), collection, payload, auth.appID)
+			if autoCreate.create {
+				var docs []map[string]any
+				if err := json.Unmarshal(payload, &docs); err != nil {
+					return fmt.Errorf("decode payload: %w", err)
+				}
+				if err := autoCreate.ensure(cmd, tenantClient, collection, auth.appID, "", docs); err != nil {
+					return err
+				}
+			}
+			payload, err = expiry.apply(cmd, tenantClient, collection, auth.appID, payload, true)
+			if err != nil {
+				return err
+			}
+			batchSize := 0
+			if caps := cachedCapabilities(envCtx); caps != nil && caps.Detected {
+				batchSize = caps.Capabilities.MaxBulkSize
+			}
+			batches, err := chunkJSONArray(payload, batchSize)
 			if err != nil {
 				return err
 			}
+			stats.addBytes(int64(len(payload)))
+			stats.count("batches", len(batches))
+			stats.count("inserted", 0)
+			tracker := startProgress(cmd, "bulk-create", 0)
+			parts := make([][]clientpkg.Document, len(batches))
+			ops := make([]clientpkg.BatchOperation, len(batches))
+			// One base key for the run lets an interrupted load be resumed: the
+			// server skips the batches whose keys it has already seen.
+			baseKey := strings.TrimSpace(idempotencyKey)
+			if baseKey == "" {
+				baseKey = clientpkg.NewIdempotencyKey()
+			}
+			autoSplit := &bulkAutoSplit{disabled: noAutoSplit}
+			send := func(ctx context.Context, key string, chunk []byte) ([]clientpkg.Document, error) {
+				part, err := tenantClient.BulkCreateDocuments(clientpkg.WithIdempotencyKey(ctx, key), collection, chunk, auth.appID)
+				if err != nil {
+					return nil, err
+				}
+				tracker.Page(len(part.Items))
+				tracker.Add(len(part.Items))
+				return part.Items, nil
+			}
+			for i, chunk := range batches {
+				// Fix the key up front so executor retries of a batch are deduplicated too.
+				key := baseKey
+				if len(batches) > 1 {
+					key = fmt.Sprintf("%s-%d", baseKey, i+1)
+				}
+				ops[i] = func(ctx context.Context) error {
+					var err error
+					parts[i], err = autoSplit.send(ctx, key, chunk, send)
+					return err
+				}
+			}
+			inserted, completed := 0, 0
+			stats.stopped(func() (string, string) {
+				return fmt.Sprintf("%d of %d batches inserted (%d documents)", completed, len(batches), inserted), resumeCommand(cmd, args, "idempotency-key", baseKey)
+			})
+			executor := batching.executor()
+			executor.StopOnError = true
+			executor.OnResult = func(result clientpkg.BatchResult) {
+				if result.Err == nil {
+					completed++
+				}
+				inserted += len(parts[result.Index])
+				stats.count("inserted", inserted)
+			}
+			results := executor.Execute(cmd.Context(), ops)
+			autoSplit.report(cmd.ErrOrStderr())
+			resp := &clientpkg.DocumentBulkResponse{}
+			for _, part := range parts {
+				resp.Items = append(resp.Items, part...)
+			}
+			for i, result := range results {
+				if result.Err == nil || errors.Is(result.Err, clientpkg.ErrBatchAborted) {
+					continue
+				}
+				err := result.Err
+				if len(batches) > 1 {
+					err = fmt.Errorf("batch %d/%d failed after inserting %d documents: %w", i+1, len(batches), len(resp.Items), err)
+				}
+				tracker.Done(err)
+				return err
+			}
+			tracker.Done(nil)
 			if raw || rawPretty {
 				if rawPretty {
 					payload := makeDocumentBulkPretty(resp)
@@ -500,11 +840,47 @@ func newTenantDocumentsBulkCreateCommand(env *Environment) *cobra.Command {
 	cmd.Flags().BoolVar(&stdin, "stdin", false, "Read JSON array payload from stdin")
 	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON response")
 	cmd.Flags().BoolVar(&rawPretty, "raw-pretty", false, "Print pretty JSON response")
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "Idempotency-Key to send (suffixed per batch; defaults to generated keys)")
+	cmd.Flags().BoolVar(&noAutoSplit, "no-auto-split", false, "Fail on 413 Payload Too Large instead of splitting the batch in half and retrying")
+	autoCreate.bind(cmd)
+	expiry.bind(cmd)
+	batching.bind(cmd)
+	bindStatsJSON(cmd, stats)
 
 	return cmd
 }
 
-func normalizeDocumentSortTokens(tokens []string) ([]string, error) {
+// documentSortMetaFields are the document metadata columns every server can
+// sort by. Data fields are sortable only when the collection advertises them
+// in sortable_fields (typically indexed fields).
+var documentSortMetaFields = []string{"created_at", "updated_at", "version", "id", "key", "key_numeric", "deleted_at"}
+
+// resolveDocumentSort normalizes --sort tokens, fetching the collection's
+// sortable data fields only when a token is not a metadata column.
+func resolveDocumentSort(ctx context.Context, tenantClient *clientpkg.TenantClient, collection, appID string, tokens []string) ([]string, error) {
+	needsMetadata := false
+	for _, token := range tokens {
+		field := strings.ToLower(strings.TrimLeft(strings.TrimSpace(token), "+-"))
+		if field != "" && !slices.Contains(documentSortMetaFields, field) {
+			needsMetadata = true
+			break
+		}
+	}
+	var sortable []string
+	if needsMetadata {
+		col, err := tenantClient.GetCollection(ctx, collection, appID)
+		if err != nil {
+			return nil, fmt.Errorf("load sortable fields for %s: %w", collection, err)
+		}
+		sortable = col.SortableFields
+	}
+	return normalizeDocumentSortTokens(tokens, sortable...)
+}
+
+// normalizeDocumentSortTokens validates sort tokens against the metadata
+// columns plus any sortable data fields reported by the server. Data fields
+// may be given with or without the data. prefix and are sent prefixed.
+func normalizeDocumentSortTokens(tokens []string, sortable ...string) ([]string, error) {
 	if len(tokens) == 0 {
 		tokens = []string{"-created_at"}
 	}
@@ -520,14 +896,17 @@ func normalizeDocumentSortTokens(tokens []string) ([]string, error) {
 	if len(expanded) == 0 {
 		expanded = append(expanded, "-created_at")
 	}
-	allowed := map[string]struct{}{
-		"created_at":  {},
-		"updated_at":  {},
-		"version":     {},
-		"id":          {},
-		"key":         {},
-		"key_numeric": {},
-		"deleted_at":  {},
+	allowed := append([]string{}, documentSortMetaFields...)
+	dataFields := make(map[string]struct{}, len(sortable))
+	for _, field := range sortable {
+		field = strings.TrimPrefix(strings.TrimSpace(field), "data.")
+		if field == "" {
+			continue
+		}
+		if _, seen := dataFields[field]; !seen {
+			dataFields[field] = struct{}{}
+			allowed = append(allowed, "data."+field)
+		}
 	}
 	result := make([]string, 0, len(expanded))
 	for _, token := range expanded {
@@ -539,9 +918,13 @@ func normalizeDocumentSortTokens(tokens []string) ([]string, error) {
 		} else if strings.HasPrefix(field, "+") {
 			field = field[1:]
 		}
-		field = strings.ToLower(strings.TrimSpace(field))
-		if _, ok := allowed[field]; !ok || field == "" {
-			return nil, fmt.Errorf("unsupported sort field %q", token)
+		field = strings.TrimSpace(field)
+		if lower := strings.ToLower(field); slices.Contains(documentSortMetaFields, lower) {
+			field = lower
+		} else if _, ok := dataFields[strings.TrimPrefix(field, "data.")]; ok && field != "" {
+			field = "data." + strings.TrimPrefix(field, "data.")
+		} else {
+			return nil, fmt.Errorf("unsupported sort field %q (allowed: %s)", token, strings.Join(allowed, ", "))
 		}
 		if desc {
 			result = append(result, "-"+field)
@@ -557,6 +940,7 @@ func normalizeDocumentSortTokens(tokens []string) ([]string, error) {
 
 func newTenantDocumentsCountCommand(env *Environment) *cobra.Command {
 	var auth authFlags
+	var timeRange documentTimeRangeFlags
 	cmd := &cobra.Command{
 		Use:   "count <collection>",
 		Short: "Count documents in a collection",
@@ -572,9 +956,18 @@ func newTenantDocumentsCountCommand(env *Environment) *cobra.Command {
 			}
 			collection := strings.TrimSpace(args[0])
 			if collection == "" {
-				return errors.New("collection name cannot be empty")
+				return errors.New(tr("collection name cannot be empty"))
+			}
+			bounds, err := timeRange.resolve(time.Now())
+			if err != nil {
+				return err
+			}
+			var count int64
+			if bounds.active() {
+				count, err = countDocumentsInRange(cmd, tenantClient, collection, auth.appID, bounds)
+			} else {
+				count, err = tenantClient.CountDocuments(cmd.Context(), collection, auth.appID)
 			}
-			count, err := tenantClient.CountDocuments(cmd.Context(), collection, auth.appID)
 			if err != nil {
 				return err
 			}
@@ -583,6 +976,7 @@ func newTenantDocumentsCountCommand(env *Environment) *cobra.Command {
 		},
 	}
 	auth.bindWithApp(cmd)
+	timeRange.bind(cmd)
 	return cmd
 }
 
@@ -706,6 +1100,9 @@ func newTenantDocumentsReportCommand(env *Environment) *cobra.Command {
 	var selectOnly bool
 	var groupBy string
 	var aggregates []string
+	var filters []string
+	var rawFilters []string
+	var exprFilters filterExprFlags
 	// sugar flags
 	var aggCount bool
 	var aggCountDistinct string
@@ -715,6 +1112,7 @@ func newTenantDocumentsReportCommand(env *Environment) *cobra.Command {
 	var aggAvgs []string
 	var raw bool
 	var rawPretty bool
+	var explainServer bool
 
 	cmd := &cobra.Command{
 		Use:   "report <collection>",
@@ -725,13 +1123,13 @@ func newTenantDocumentsReportCommand(env *Environment) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			tenantClient, _, tenantID, err := auth.resolveTenantClient(envCtx, cmd)
 			if err != nil {
 				return err
 			}
 			collection := strings.TrimSpace(args[0])
 			if collection == "" {
-				return errors.New("collection name cannot be empty")
+				return errors.New(tr("collection name cannot be empty"))
 			}
 
 			var body map[string]any
@@ -749,6 +1147,27 @@ func newTenantDocumentsReportCommand(env *Environment) *cobra.Command {
 				body = make(map[string]any)
 			}
 			body["collection"] = collection
+			typed, err := resolveTypedFilters(cmd.Context(), envCtx, tenantClient, tenantID, collection, auth.appID, filters, rawFilters)
+			if err != nil {
+				return err
+			}
+			matcher, err := exprFilters.resolve(cmd.Context(), envCtx, tenantClient, auth.tenantID, collection, auth.appID)
+			if err != nil {
+				return err
+			}
+			where := typed.whereClause()
+			if matcher != nil {
+				if where == nil {
+					where = map[string]any{"and": []any{}}
+				}
+				where["and"] = append(where["and"].([]any), matcher.where())
+			}
+			if where != nil {
+				if existing, ok := body["where"]; ok && existing != nil {
+					where["and"] = append([]any{existing}, where["and"].([]any)...)
+				}
+				body["where"] = where
+			}
 
 			params := clientpkg.ReportQueryParams{
 				AppID:      auth.appID,
@@ -762,6 +1181,7 @@ func newTenantDocumentsReportCommand(env *Environment) *cobra.Command {
 				params.SelectFields = splitCommaList(trimmed)
 			}
 			params.SelectOnly = selectOnly
+			params.Explain = explainServer
 			if gb := strings.TrimSpace(groupBy); gb != "" {
 				fields := splitCommaList(gb)
 				if len(fields) > 0 {
@@ -821,7 +1241,9 @@ func newTenantDocumentsReportCommand(env *Environment) *cobra.Command {
 			}
 			if raw || rawPretty {
 				if rawPretty {
-					return printJSON(cmd, map[string]any{"data": resp.Data, "pagination": resp.Pagination})
+					pretty := map[string]any{"data": resp.Data, "pagination": resp.Pagination}
+					if resp.Explain != nil { pretty["explain"] = resp.Explain }
+					return printJSON(cmd, pretty)
 				}
 				return printJSON(cmd, resp)
 			}
@@ -834,6 +1256,9 @@ func newTenantDocumentsReportCommand(env *Environment) *cobra.Command {
 			if trimmed := strings.TrimSpace(pagination.NextCursor); trimmed != "" {
 				fmt.Fprintf(cmd.OutOrStdout(), "NEXT_CURSOR: %s\n", trimmed)
 			}
+			if explainServer {
+				renderQueryExplain(cmd, resp.Explain)
+			}
 			return nil
 		},
 	}
@@ -847,9 +1272,13 @@ func newTenantDocumentsReportCommand(env *Environment) *cobra.Command {
 	cmd.Flags().StringVar(&cursor, "cursor", "", "Cursor token for paginated reports")
 	cmd.Flags().StringVar(&selectFields, "select", "", "Comma-separated list of fields to project")
 	cmd.Flags().StringVar(&groupBy, "group-by", "", "Comma-separated list of fields to group by (report mode)")
+	cmd.Flags().StringArrayVar(&filters, "filter", nil, "Equality condition field=value added to the where clause, coerced to the schema type of the field (repeatable)")
+	cmd.Flags().StringArrayVar(&rawFilters, "filter-raw", nil, filterRawFlagUsage)
+	exprFilters.bind(cmd)
 	cmd.Flags().StringArrayVar(&aggregates, "aggregate", nil, "Aggregate spec op[:field][:alias][!distinct] (repeatable, e.g. --aggregate count --aggregate sum:price:total_sales)")
 	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON response")
 	cmd.Flags().BoolVar(&rawPretty, "raw-pretty", false, "Print pretty JSON response")
+	cmd.Flags().BoolVar(&explainServer, "explain-server", false, explainServerFlagUsage)
 	// Sugar aggregate flags
 	cmd.Flags().BoolVar(&aggCount, "count", false, "Add COUNT(*) aggregate")
 	cmd.Flags().StringVar(&aggCountDistinct, "count-distinct", "", "Add COUNT(DISTINCT <field>) aggregate")
@@ -864,6 +1293,9 @@ func newTenantDocumentsReportCommand(env *Environment) *cobra.Command {
 func newTenantDocumentsExportCommand(env *Environment) *cobra.Command {
 	var auth authFlags
 	var filters []string
+	var rawFilters []string
+	var exprFilters filterExprFlags
+	var timeRange documentTimeRangeFlags
 	var selectFields string
 	var selectOnly bool
 	var includeDeleted bool
@@ -874,6 +1306,20 @@ func newTenantDocumentsExportCommand(env *Environment) *cobra.Command {
 	var pageSize int
 	var stream bool
 	var cursor string
+	var cursorFile string
+	var stateFile string
+	var stable bool
+	var flatten bool
+	var separator string
+	var exclude string
+	var maxDocSize string
+	var splitSize string
+	var splitCount int
+	var withAudit bool
+	var auditWorkers int
+	var checksumEvery int
+	var manifestPath string
+	stats := newOperationStats()
 
 	cmd := &cobra.Command{
 		Use:   "export <collection>",
@@ -892,22 +1338,113 @@ Examples:
   tdb tenant documents export events --filter type=click --out events.jsonl --api-key $API_KEY
 
   # JSON array pretty output (paginated mode)
-  tdb tenant documents export products --format json --pretty --api-key $API_KEY`,
+  tdb tenant documents export products --format json --pretty --api-key $API_KEY
+
+  # Deterministic export for diffing (sorted by key, canonical JSON)
+  tdb tenant documents export products --stable --out products.jsonl
+
+  # Flat rows for a warehouse load, without sensitive fields
+  tdb tenant documents export users --flatten --separator _ --exclude password,profile.ssn
+
+  # Roll output into 100MB part files (events-00001.jsonl, ...) plus events.manifest.json
+  tdb tenant documents export events --stream --out exports/events.jsonl --split-size 100MB
+
+  # Incremental cron job: resume from the last run's cursor and save the next one
+  tdb tenant documents export events --stream --cursor-file events.cursor --out events-$(date +%F).jsonl
+
+  # Give up after 30 minutes; the partial summary prints the command that resumes from the cursor file
+  tdb tenant documents export events --stream --cursor-file events.cursor --out events.jsonl --timeout 30m
+
+  # Compliance extract with each document's audit history under _audit
+  tdb tenant documents export contracts --with-audit --audit-workers 2 --out contracts-audit.jsonl
+
+  # Backup with a checkpoint hash every 10000 records (users.jsonl.sha256.json),
+  # checked with 'documents verify users --manifest users.jsonl.sha256.json'
+  tdb tenant documents export users --stream --out users.jsonl --checksum-every 10000
+
+  # Record a lineage manifest (source, filters, cursors, checksum, CLI version) for auditors;
+  # 'documents sync --file users.json --manifest users.lineage.json' verifies and records the import
+  tdb tenant documents export users --format json --out users.json --manifest users.lineage.json`,
 		Args: cobra.ExactArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			envCtx, err := requireEnvironment(env)
 			if err != nil { return err }
-			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			tenantClient, _, tenantID, err := auth.resolveTenantClient(envCtx, cmd)
 			if err != nil { return err }
 			collection := strings.TrimSpace(args[0])
-			if collection == "" { return errors.New("collection name cannot be empty") }
+			if collection == "" { return errors.New(tr("collection name cannot be empty")) }
 
 			mode := strings.ToLower(strings.TrimSpace(format))
 			if mode == "" { mode = "jsonl" }
 			if mode != "jsonl" && mode != "json" { return fmt.Errorf("unsupported format %q (choose json or jsonl)", mode) }
+			shaping, err := newExportShaping(exclude, flatten, separator)
+			if err != nil { return err }
+			docLimit, docLimitSet, err := parseMaxDocSize(maxDocSize)
+			if err != nil { return err }
+			bounds, err := timeRange.resolve(time.Now())
+			if err != nil { return err }
+			incremental, err := startIncrementalExtract(stateFile, collection, &bounds)
+			if err != nil { return err }
+			splitBytes, err := parseExportSplit(splitSize, splitCount)
+			if err != nil { return err }
+			var splitter *exportSplitter
+			if splitBytes > 0 || splitCount > 0 {
+				if strings.TrimSpace(outPath) == "" { return errors.New("--split-size and --split-count require --out to name the part files") }
+				if mode != "jsonl" { return errors.New("--split-size and --split-count require --format jsonl") }
+				splitter, err = newExportSplitter(outPath, collection, splitBytes, splitCount)
+				if err != nil { return err }
+				defer splitter.abort()
+			}
+			if checksumEvery < 0 { return errors.New("--checksum-every must be positive") }
+			if checksumEvery > 0 {
+				if strings.TrimSpace(outPath) == "" { return errors.New("--checksum-every requires --out to name the checksum file") }
+				if mode != "jsonl" { return errors.New("--checksum-every requires --format jsonl") }
+				if splitter != nil { return errors.New("--checksum-every cannot be combined with --split-size or --split-count (the manifest already records a checksum per part)") }
+			}
+			var checksums *exportChecksumWriter
+			withChecksums := func(w io.Writer) io.Writer {
+				if checksumEvery <= 0 { return w }
+				checksums = newExportChecksumWriter(w, collection, checksumEvery)
+				return checksums
+			}
+			var lineage *exportLineage
+			if trimmed := strings.TrimSpace(manifestPath); trimmed != "" {
+				lineage = newExportLineage(trimmed, lineageLocation{Endpoint: strings.TrimSpace(envCtx.Config.Endpoint), TenantID: tenantID, AppID: auth.appID, Collection: collection})
+				lineage.manifest.Output = strings.TrimSpace(outPath)
+				lineage.manifest.Format = mode
+				lineage.manifest.Filters = append(append(append([]string{}, filters...), rawFilters...), exprFilters.describe()...)
+				lineage.manifest.IncludeDeleted = includeDeleted
+				lineage.manifest.CreatedSince, lineage.manifest.CreatedBefore, lineage.manifest.UpdatedSince = bounds.CreatedSince, bounds.CreatedBefore, bounds.UpdatedSince
+			}
+
+			caps := cachedCapabilities(envCtx)
+			if stream && caps != nil && caps.Detected && !caps.Capabilities.StreamingExport {
+				fmt.Fprintln(cmd.ErrOrStderr(), "Streaming disabled: server does not support streaming export; falling back to paginated export")
+				stream = false
+			}
+			pageSize = tunePageSize(cmd, envCtx, "page-size", pageSize)
+			if stable && stream {
+				fmt.Fprintln(cmd.ErrOrStderr(), "Streaming disabled: --stable requires ordered paginated export")
+				stream = false
+			}
+
+			if stream && incremental != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), "Streaming disabled: --state-file tracks updated_at through paginated export")
+				stream = false
+			}
+			if withAudit && auditWorkers < 1 { return errors.New("--audit-workers must be at least 1") }
+			if stream && withAudit {
+				fmt.Fprintln(cmd.ErrOrStderr(), "Streaming disabled: --with-audit embeds audit history through paginated export")
+				stream = false
+			}
+
+			if stream && bounds.active() {
+				fmt.Fprintln(cmd.ErrOrStderr(), "Streaming disabled: time-range filters not supported in streaming; falling back to paginated export")
+				stream = false
+			}
 
 			// Decide streaming usage via helper
-			if ok, reason := decideStreamingExport(stream, filters, includeDeleted, mode); stream && !ok {
+			if ok, reason := decideStreamingExport(stream, append(append(filters, rawFilters...), exprFilters.describe()...), includeDeleted, mode); stream && !ok {
 				fmt.Fprintf(cmd.ErrOrStderr(), "Streaming disabled: %s; falling back to paginated export\n", reason)
 				stream = false
 			} else if stream && mode != "jsonl" { // defensive (helper already checks json format keyword only)
@@ -917,88 +1454,135 @@ Examples:
 
 			selector := []string{}
 			if trimmed := strings.TrimSpace(selectFields); trimmed != "" { selector = splitCommaList(trimmed) }
+			if lineage != nil { lineage.manifest.Streamed = stream; lineage.manifest.Select = selector }
+
+			cursorPath := strings.TrimSpace(cursorFile)
+			if cursorPath != "" {
+				if !stream { return errors.New("--cursor-file requires streaming export (--stream without filters, --include-deleted, time ranges or --stable)") }
+				if strings.TrimSpace(cursor) != "" { return errors.New("use only one of --cursor or --cursor-file") }
+				if cursor, err = readCursorFile(cursorPath); err != nil { return fmt.Errorf("read cursor file: %w", err) }
+			}
+			// On Ctrl-C or --timeout the cursor and state files are left untouched,
+			// so re-running the same command resumes where this run started.
+			var lastID string
+			stats.stopped(func() (string, string) {
+				switch {
+				case cursorPath != "":
+					return "cursor file " + cursorPath + " still holds this run's starting cursor", resumeCommand(cmd, args)
+				case incremental != nil:
+					return "state file " + strings.TrimSpace(stateFile) + " still holds the previous watermark", resumeCommand(cmd, args)
+				case lastID != "":
+					return "last exported document " + lastID + " (without --cursor-file or --state-file the export starts over)", resumeCommand(cmd, args)
+				}
+				return "", resumeCommand(cmd, args)
+			})
 
 			// Streaming path
 			if stream {
 				body, headers, err := tenantClient.StreamExport(cmd.Context(), collection, selector, selectOnly, strings.TrimSpace(cursor), pageSize, auth.appID)
 				if err != nil { return err }
 				defer body.Close()
+				// Servers that hash the export body send it up front; verify what actually arrived.
+				var src io.Reader = body
+				var received hash.Hash
+				expectedSum := strings.TrimSpace(headers.Get(exportChecksumHeader))
+				if expectedSum != "" { received = sha256.New(); src = io.TeeReader(body, received) }
 				var out *bufio.Writer
 				var file *os.File
-				if trimmed := strings.TrimSpace(outPath); trimmed != "" {
+				if splitter != nil {
+					out = bufio.NewWriter(stats.countBytes(lineage.track(splitter)))
+					defer out.Flush()
+				} else if trimmed := strings.TrimSpace(outPath); trimmed != "" {
 					clean := filepath.Clean(trimmed)
 					if dir := filepath.Dir(clean); dir != "." && dir != "" { if err := os.MkdirAll(dir, 0o755); err != nil { return err } }
 					file, err = os.Create(clean)
 					if err != nil { return err }
 					defer func(){ _ = file.Close() }()
-					out = bufio.NewWriter(file)
+					out = bufio.NewWriter(stats.countBytes(lineage.track(withChecksums(file))))
 					defer out.Flush()
 				} else {
-					out = bufio.NewWriter(cmd.OutOrStdout())
+					out = bufio.NewWriter(stats.countBytes(lineage.track(cmd.OutOrStdout())))
 					defer out.Flush()
 				}
 				// Stream line by line to output; optionally transform if includeMeta false and line has 'data'.
-				reader := bufio.NewReader(body)
 				lines := 0
-				for {
-					line, readErr := reader.ReadBytes('\n')
-					if len(line) > 0 {
-						trim := bytes.TrimSpace(line)
-						if len(trim) > 0 {
-							if !includeMeta {
-								var parsed map[string]any
-								if json.Unmarshal(trim, &parsed) == nil {
-									if dataVal, ok := parsed["data"]; ok {
-										if pretty {
-											b, _ := json.MarshalIndent(dataVal, "", "  ")
-											trim = b
-										} else {
-											b, _ := json.Marshal(dataVal)
-											trim = b
-										}
-									}
-								}
+				tracker := startProgress(cmd, "export", 0)
+				defer func() { tracker.Done(err) }()
+				if includeMeta && !shaping.active() {
+					// Records pass through untouched, so copy them in bounded chunks.
+					lines, err = copyNDJSONLines(out, src, docLimit, func() { tracker.Item() })
+					stats.count("exported", lines)
+					if err != nil { return err }
+				} else {
+					reader := bufio.NewReader(src)
+					for {
+						line, readErr := readNDJSONLine(reader, docLimit)
+						if len(line) > 0 {
+							trim := bytes.TrimSpace(line)
+							if len(trim) > 0 {
+								trim = shapeStreamedExportLine(trim, includeMeta, pretty, shaping)
+								if _, err := out.Write(trim); err != nil { return err }
+								if _, err := out.WriteString("\n"); err != nil { return err }
+								lines++
+								stats.count("exported", lines)
+								tracker.Item()
 							}
-							if _, err := out.Write(trim); err != nil { return err }
-							if _, err := out.WriteString("\n"); err != nil { return err }
-							lines++
+						}
+						if readErr != nil {
+							if readErr == io.EOF { break }
+							return readErr
 						}
 					}
-					if readErr != nil {
-						if readErr == io.EOF { break }
-						return readErr
+				}
+				if err := verifyStreamChecksum(expectedSum, received); err != nil { return err }
+				next := strings.TrimSpace(headers.Get("X-Next-Cursor"))
+				if next != "" { fmt.Fprintf(cmd.ErrOrStderr(), "NEXT_CURSOR: %s\n", next) }
+				if err := finishExportSplit(cmd, out, splitter); err != nil { return err }
+				if err := finishExportChecksums(cmd, out, checksums, outPath); err != nil { return err }
+				if lineage != nil { lineage.manifest.CursorStart, lineage.manifest.CursorNext = strings.TrimSpace(cursor), next }
+				if err := finishExportLineage(cmd, out, lineage, lines, splitter); err != nil { return err }
+				// Save the cursor only after the output is complete, so a failed run is retried from the same point.
+				if cursorPath != "" && next != "" {
+					if err := out.Flush(); err != nil { return err }
+					if err := writeCursorFile(cursorPath, next); err != nil { return fmt.Errorf("write cursor file: %w", err) }
+				} else if cursorPath != "" {
+					// Without X-Next-Cursor the stream has nothing to resume from; say so rather than silently re-exporting.
+					if lines == 0 {
+						fmt.Fprintf(cmd.ErrOrStderr(), "Caught up: no new documents since the cursor in %s\n", cursorPath)
+					} else {
+						logWarn(cmd.ErrOrStderr(), fmt.Sprintf("the server sent no X-Next-Cursor after %d documents; %s was not advanced, so the next run exports them again", lines, cursorPath))
 					}
 				}
-				if next := headers.Get("X-Next-Cursor"); next != "" { fmt.Fprintf(cmd.ErrOrStderr(), "NEXT_CURSOR: %s\n", strings.TrimSpace(next)) }
-				fmt.Fprintf(cmd.ErrOrStderr(), "Streamed %d documents\n", lines)
+				fmt.Fprintf(cmd.ErrOrStderr(), tr("Streamed %d documents\n"), lines)
 				return nil
 			}
 
 			// Paginated path
 			page := pageSize
 			if page <= 0 { page = 100 }
-			filterMap := map[string]string{}
-			for _, f := range filters {
-				parts := strings.SplitN(f, "=", 2)
-				if len(parts) != 2 { return fmt.Errorf("invalid filter %q (expected key=value)", f) }
-				k := strings.TrimSpace(parts[0])
-				v := strings.TrimSpace(parts[1])
-				if k == "" { return fmt.Errorf("filter key cannot be empty: %q", f) }
-				filterMap[k] = v
-			}
+			typed, err := resolveTypedFilters(cmd.Context(), envCtx, tenantClient, tenantID, collection, auth.appID, filters, rawFilters)
+			if err != nil { return err }
+			filterMap := typed.queryParams()
+			matcher, err := exprFilters.resolve(cmd.Context(), envCtx, tenantClient, auth.tenantID, collection, auth.appID)
+			if err != nil { return err }
+			matcher = matcher.pushDown(filterMap)
+			if err := matcher.requireSelected(selector); err != nil { return err }
 
 			var out *bufio.Writer
 			var file *os.File
-			if trimmed := strings.TrimSpace(outPath); trimmed != "" {
+			if splitter != nil {
+				out = bufio.NewWriter(stats.countBytes(lineage.track(splitter)))
+				defer out.Flush()
+			} else if trimmed := strings.TrimSpace(outPath); trimmed != "" {
 				clean := filepath.Clean(trimmed)
 				if dir := filepath.Dir(clean); dir != "." && dir != "" { if err := os.MkdirAll(dir, 0o755); err != nil { return err } }
 				file, err = os.Create(clean)
 				if err != nil { return err }
 				defer func(){ _ = file.Close() }()
-				out = bufio.NewWriter(file)
+				out = bufio.NewWriter(stats.countBytes(lineage.track(withChecksums(file))))
 				defer out.Flush()
 			} else {
-				out = bufio.NewWriter(cmd.OutOrStdout())
+				out = bufio.NewWriter(stats.countBytes(lineage.track(cmd.OutOrStdout())))
 				defer out.Flush()
 			}
 
@@ -1008,44 +1592,85 @@ Examples:
 				if pretty { if _, err := out.WriteString("\n"); err != nil { return err } }
 			}
 			written := 0
-			offset := 0
 			first := true
-			for {
-				params := clientpkg.ListDocumentsParams{AppID: auth.appID, Limit: page, Offset: offset, IncludeDeleted: includeDeleted, Filters: map[string]string{}}
-				for k,v := range filterMap { params.Filters[k] = v }
-				if len(selector) > 0 { params.SelectFields = selector }
-				params.SelectOnly = selectOnly
-				resp, err := tenantClient.ListDocuments(cmd.Context(), collection, params)
-				if err != nil { return err }
-				if len(resp.Items) == 0 { break }
-				for _, doc := range resp.Items {
-					payload, err := buildExportPayload(doc, includeMeta, pretty)
-					if err != nil { return fmt.Errorf("prepare document %s: %w", doc.ID, err) }
-					if jsonArray {
-						if !first {
-							if pretty { if _, err := out.WriteString(",\n"); err != nil { return err } } else { if _, err := out.WriteString(","); err != nil { return err } }
-						} else { first = false }
-						if _, err := out.Write(payload); err != nil { return err }
-						if pretty { if _, err := out.WriteString("\n"); err != nil { return err } }
-					} else {
-						if _, err := out.Write(payload); err != nil { return err }
-						if _, err := out.WriteString("\n"); err != nil { return err }
-					}
-					written++
+			params := clientpkg.ListDocumentsParams{AppID: auth.appID, Limit: page, IncludeDeleted: includeDeleted, Filters: filterMap, SelectOnly: selectOnly}
+			if len(selector) > 0 { params.SelectFields = selector }
+			if stable { params.Sort = []string{"key", "id"} }
+			bounds.apply(&params)
+			tracker := startProgress(cmd, "export", 0)
+			defer func() { tracker.Done(err) }()
+			listCtx := withDocumentSizeLimit(cmd.Context(), docLimit, docLimitSet, page)
+			docs := tenantClient.DocumentsIterator(listCtx, collection, params, clientpkg.WithoutStreaming(), clientpkg.WithPageHook(tracker.Page))
+			emit := func(doc clientpkg.Document) error {
+				payload, err := buildExportPayload(doc, includeMeta, pretty)
+				if err != nil { return fmt.Errorf("prepare document %s: %w", doc.ID, err) }
+				if stable {
+					if pretty { payload, err = canonicalpkg.CanonicalizeIndent(payload, "", "  ") } else { payload, err = canonicalpkg.Canonicalize(payload) }
+					if err != nil { return fmt.Errorf("canonicalize document %s: %w", doc.ID, err) }
 				}
-				offset += len(resp.Items)
-				if len(resp.Items) < page { break }
+				if jsonArray {
+					if !first {
+						if pretty { if _, err := out.WriteString(",\n"); err != nil { return err } } else { if _, err := out.WriteString(","); err != nil { return err } }
+					} else { first = false }
+					if _, err := out.Write(payload); err != nil { return err }
+					if pretty { if _, err := out.WriteString("\n"); err != nil { return err } }
+				} else {
+					if _, err := out.Write(payload); err != nil { return err }
+					if _, err := out.WriteString("\n"); err != nil { return err }
+				}
+				written++
+				lastID = doc.ID
+				stats.count("exported", written)
+				tracker.Item()
+				incremental.observe(doc)
+				return nil
+			}
+			// With --with-audit, documents are buffered a page at a time so their
+			// audit histories can be fetched concurrently.
+			var pending []clientpkg.Document
+			flushPending := func() error {
+				if len(pending) == 0 { return nil }
+				if err := attachAuditTrails(cmd.Context(), tenantClient, auth.appID, pending, auditWorkers, shaping.applyJSON); err != nil { return err }
+				for _, doc := range pending { if err := emit(doc); err != nil { return err } }
+				pending = pending[:0]
+				return nil
 			}
+			for docs.Next() {
+				doc := docs.Document()
+				if err := checkDocumentSize(doc.ID, len(doc.Data), docLimit); err != nil { return err }
+				if incremental.skip(doc) { continue }
+				if matcher != nil && !matcher.matchesDocument(doc) { continue }
+				if doc.Data, err = shaping.applyJSON(doc.Data); err != nil { return fmt.Errorf("reshape document %s: %w", doc.ID, err) }
+				if !withAudit {
+					if err := emit(doc); err != nil { return err }
+					continue
+				}
+				pending = append(pending, doc)
+				if len(pending) >= page { if err := flushPending(); err != nil { return err } }
+			}
+			if err := docs.Err(); err != nil { return explainDocumentSizeError(err) }
+			if err := flushPending(); err != nil { return err }
 			if jsonArray {
 				if _, err := out.WriteString("]"); err != nil { return err }
 				if pretty { if _, err := out.WriteString("\n"); err != nil { return err } }
 			}
-			if trimmed := strings.TrimSpace(outPath); trimmed != "" { fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d documents to %s\n", written, trimmed) } else { fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d documents\n", written) }
+			if err := finishExportSplit(cmd, out, splitter); err != nil { return err }
+			if err := finishExportChecksums(cmd, out, checksums, outPath); err != nil { return err }
+			if lineage != nil { lineage.manifest.LastDocument = lastID }
+			if err := finishExportLineage(cmd, out, lineage, written, splitter); err != nil { return err }
+			if incremental != nil {
+				if err := out.Flush(); err != nil { return err }
+				if err := incremental.finish(time.Now()); err != nil { return fmt.Errorf("write state file: %w", err) }
+			}
+			if trimmed := strings.TrimSpace(outPath); trimmed != "" { fmt.Fprintf(cmd.ErrOrStderr(), tr("Exported %d documents to %s\n"), written, trimmed) } else { fmt.Fprintf(cmd.ErrOrStderr(), tr("Exported %d documents\n"), written) }
 			return nil
 		},
 	}
 	auth.bindWithApp(cmd)
-	cmd.Flags().StringArrayVar(&filters, "filter", nil, "Filter predicate field=value (repeatable; disables streaming)")
+	cmd.Flags().StringArrayVar(&filters, "filter", nil, "Filter predicate field=value, coerced to the schema type of the field (repeatable; disables streaming)")
+	cmd.Flags().StringArrayVar(&rawFilters, "filter-raw", nil, filterRawFlagUsage+"; disables streaming")
+	exprFilters.bind(cmd)
+	timeRange.bind(cmd)
 	cmd.Flags().StringVar(&selectFields, "select", "", "Comma-separated list of fields to project")
 	cmd.Flags().BoolVar(&selectOnly, "select-only", false, "Restrict output to only selected fields (omit implicit metadata)")
 	cmd.Flags().BoolVar(&includeDeleted, "include-deleted", false, "Include soft-deleted documents (disables streaming)")
@@ -1055,7 +1680,21 @@ Examples:
 	cmd.Flags().BoolVar(&includeMeta, "include-meta", false, "Include document metadata alongside payload data (paginated mode)")
 	cmd.Flags().IntVar(&pageSize, "page-size", 100, "Page size for paginated mode or limit hint for streaming")
 	cmd.Flags().BoolVar(&stream, "stream", false, "Use streaming NDJSON export (no filters, no include-deleted, jsonl only)")
+	cmd.Flags().StringVar(&maxDocSize, "max-doc-size", "", maxDocSizeFlagUsage)
 	cmd.Flags().StringVar(&cursor, "cursor", "", "Cursor for streaming continuation (X-Next-Cursor emitted to stderr)")
+	cmd.Flags().StringVar(&stateFile, "state-file", "", stateFileFlagUsage)
+	cmd.Flags().StringVar(&cursorFile, "cursor-file", "", "Read the starting cursor from this file and write X-Next-Cursor back on success (streaming only)")
+	cmd.Flags().BoolVar(&stable, "stable", false, "Order by primary key and write canonical JSON (sorted keys) so exports can be diffed")
+	cmd.Flags().BoolVar(&flatten, "flatten", false, "Flatten nested data objects into joined keys (e.g. address.city)")
+	cmd.Flags().StringVar(&separator, "separator", ".", "Key separator used with --flatten")
+	cmd.Flags().StringVar(&exclude, "exclude", "", "Comma-separated fields to drop from exported data (dotted paths for nested fields)")
+	cmd.Flags().StringVar(&splitSize, "split-size", "", "Roll --out into numbered part files of at most this size, e.g. 100MB (jsonl only; writes a manifest)")
+	cmd.Flags().IntVar(&splitCount, "split-count", 0, "Roll --out into numbered part files of at most this many documents (jsonl only; writes a manifest)")
+	cmd.Flags().BoolVar(&withAudit, "with-audit", false, "Embed each document's audit history under _audit (paginated mode)")
+	cmd.Flags().IntVar(&auditWorkers, "audit-workers", 4, "Concurrent audit history requests with --with-audit")
+	cmd.Flags().IntVar(&checksumEvery, "checksum-every", 0, "Write <out>.sha256.json with a running SHA-256 checkpoint every N records (jsonl only)")
+	cmd.Flags().StringVar(&manifestPath, "manifest", "", "Write a lineage manifest (source, filters, cursor range, document count, SHA-256, CLI version, timestamps) to this file")
+	bindStatsJSON(cmd, stats)
 	return cmd
 }
 
@@ -1067,6 +1706,13 @@ func newTenantDocumentsSyncCommand(env *Environment) *cobra.Command {
 	var mode string
 	var keyField string
 	var skipMissing bool
+	var verify bool
+	var strict bool
+	var autoCreate collectionAutoCreate
+	var batching batchOptions
+	var startAt int
+	var manifestPath string
+	stats := newOperationStats()
 
 	cmd := &cobra.Command{
 		Use:   "sync <collection>",
@@ -1080,7 +1726,25 @@ Modes:
   - update: Completely replace existing documents
   - create: Only create new documents, skip existing ones
 
-Use --skip-missing to only update existing documents without creating new ones.`,
+Use --skip-missing to only update existing documents without creating new ones.
+
+Use --verify to re-fetch every created or updated document and compare it with the
+payload that was sent. Mismatches are reported and counted separately from write
+failures, surfacing eventual-consistency or schema-coercion surprises.
+
+Use --strict to check every document against the collection schema first; the
+sync fails without writing anything if any document has a field the schema
+does not define (for example a typo like "stauts").
+
+With --manifest, --file is checked against the lineage manifest written by
+'documents export --manifest' (SHA-256, size and document count, or those of
+the matching part of a split export) before anything is written. After the
+sync, an import record with the target, counts and timestamps is appended to
+the manifest, giving a traceable chain from source to copy.
+
+Ctrl-C (or --timeout) stops the sync after the documents in flight: it prints
+the counts so far, the first record that was not synced, and a resume command
+that re-runs the sync from there with --start-at.`,
 		Example: `  # Sync from JSONL file (patch mode)
   tdb tenant documents sync users --file users.jsonl --api-key $API_KEY
 
@@ -1105,6 +1769,15 @@ Use --skip-missing to only update existing documents without creating new ones.`
     --key-field sku \
     --api-key $API_KEY
 
+  # Verify an export against its lineage manifest and record the import in it
+  tdb tenant documents sync users --file users.json --manifest users.lineage.json
+
+  # Verify each write by reading the document back
+  tdb tenant documents sync users --file users.jsonl --verify --api-key $API_KEY
+
+  # Load into a new collection, inferring its schema and primary key
+  tdb tenant documents sync users --file users.jsonl --create-collection --infer-schema
+
   # Example JSONL format (users.jsonl):
   # {"email":"user1@example.com","name":"Alice","role":"admin"}
   # {"email":"user2@example.com","name":"Bob","role":"user"}
@@ -1126,13 +1799,13 @@ Use --skip-missing to only update existing documents without creating new ones.`
 			if err != nil {
 				return err
 			}
-			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			tenantClient, _, tenantID, err := auth.resolveTenantClient(envCtx, cmd)
 			if err != nil {
 				return err
 			}
 			collection := strings.TrimSpace(args[0])
 			if collection == "" {
-				return errors.New("collection name cannot be empty")
+				return errors.New(tr("collection name cannot be empty"))
 			}
 			modeValue := strings.ToLower(strings.TrimSpace(mode))
 			if modeValue == "" {
@@ -1141,10 +1814,20 @@ Use --skip-missing to only update existing documents without creating new ones.`
 			if modeValue != "patch" && modeValue != "update" {
 				return fmt.Errorf("unsupported mode %q (choose patch or update)", mode)
 			}
+			if err := autoCreate.validate(); err != nil {
+				return err
+			}
+			if err := batching.validate(); err != nil {
+				return err
+			}
+			if strings.TrimSpace(manifestPath) != "" && strings.TrimSpace(file) == "" {
+				return errors.New("--manifest requires --file")
+			}
 			payload, err := readJSONPayload(cmd, data, file, stdin, false)
 			if err != nil {
 				return err
 			}
+			stats.addBytes(int64(len(payload)))
 			docs, err := decodeDocumentSyncPayload(payload)
 			if err != nil {
 				return err
@@ -1152,6 +1835,28 @@ Use --skip-missing to only update existing documents without creating new ones.`
 			if len(docs) == 0 {
 				return errors.New("no documents provided in payload")
 			}
+			var lineage *lineageManifest
+			var lineageImportRecord lineageImport
+			if trimmed := strings.TrimSpace(manifestPath); trimmed != "" {
+				var sum string
+				if lineage, sum, err = verifyPayloadLineage(cmd, trimmed, file, len(docs)); err != nil {
+					return err
+				}
+				lineageImportRecord = lineageImport{
+					Target:    lineageLocation{Endpoint: strings.TrimSpace(envCtx.Config.Endpoint), TenantID: tenantID, AppID: auth.appID, Collection: collection},
+					File:      filepath.Clean(file),
+					SHA256:    sum,
+					Mode:      modeValue,
+					Documents: len(docs) - startAt,
+					StartedAt: time.Now().UTC(),
+				}
+			}
+			if startAt < 0 || startAt >= len(docs) {
+				return fmt.Errorf("--start-at must be between 0 and %d", len(docs)-1)
+			}
+			if err := autoCreate.ensure(cmd, tenantClient, collection, auth.appID, keyField, docs); err != nil {
+				return err
+			}
 			col, err := tenantClient.GetCollection(cmd.Context(), collection, auth.appID)
 			if err != nil {
 				return err
@@ -1163,87 +1868,191 @@ Use --skip-missing to only update existing documents without creating new ones.`
 					pkField = "id"
 				}
 			}
+			if strict {
+				values := make([]any, len(docs))
+				for i, doc := range docs {
+					values[i] = doc
+				}
+				strictCol := *col
+				strictCol.PrimaryKeyField = pkField
+				if err := checkStrictFields(&strictCol, values...); err != nil {
+					return err
+				}
+			}
 			pkType := strings.TrimSpace(col.PrimaryKeyType)
 			if pkType == "" {
 				pkType = "string"
 			}
 			keepPrimary := modeValue == "update"
-			var created, updated, unchanged, skipped, missing, failed int
-			for idx, rawDoc := range docs {
+			verifyWrite := func(ctx context.Context, idx int, keyValue, id string, payload map[string]any, keepPrimary bool, mode string) bool {
+				if !verify {
+					return true
+				}
+				stored, err := tenantClient.GetDocument(ctx, collection, id, auth.appID)
+				if err != nil {
+					stats.errorf(cmd.ErrOrStderr(), "[%d] verify %s failed: %v\n", idx, keyValue, err)
+					return false
+				}
+				mismatched, err := documentSyncMismatches(stored.Data, payload, pkField, keepPrimary, mode)
+				if err != nil {
+					stats.errorf(cmd.ErrOrStderr(), "[%d] verify %s failed: %v\n", idx, keyValue, err)
+					return false
+				}
+				if len(mismatched) > 0 {
+					stats.errorf(cmd.ErrOrStderr(), "[%d] verify %s failed: stored value differs for %s\n", idx, keyValue, strings.Join(mismatched, ", "))
+					return false
+				}
+				return true
+			}
+			verifyFailures := make([]bool, len(docs))
+			// syncDocument reports the outcome of one document; a non-nil error
+			// means it failed and may be retried by the executor.
+			syncDocument := func(ctx context.Context, idx int, rawDoc map[string]any) (string, error) {
 				keyValue, err := extractDocumentKey(rawDoc, pkField, pkType)
 				if err != nil || strings.TrimSpace(keyValue) == "" {
-					fmt.Fprintf(cmd.ErrOrStderr(), "[%d] skipping: %v\n", idx, firstNonNil(err, errors.New("missing primary key value")))
-					skipped++
-					continue
+					stats.errorf(cmd.ErrOrStderr(), "[%d] skipping: %v\n", idx, firstNonNil(err, errors.New("missing primary key value")))
+					return "skipped", nil
 				}
-				existing, err := tenantClient.GetDocumentByPrimaryKey(cmd.Context(), collection, keyValue, auth.appID)
+				existing, err := tenantClient.GetDocumentByPrimaryKey(ctx, collection, keyValue, auth.appID)
 				if err != nil {
-					if isNotFoundError(err) {
-						if skipMissing {
-							fmt.Fprintf(cmd.ErrOrStderr(), "[%d] document %s not found; skipping\n", idx, keyValue)
-							missing++
-							continue
-						}
-						createPayload := prepareDocumentCreatePayload(rawDoc, pkField)
-						encoded, err := json.Marshal(createPayload)
-						if err != nil {
-							fmt.Fprintf(cmd.ErrOrStderr(), "[%d] encode %s failed: %v\n", idx, keyValue, err)
-							failed++
-							continue
-						}
-						result, err := tenantClient.CreateDocument(cmd.Context(), collection, encoded, auth.appID)
-						if err != nil {
-							fmt.Fprintf(cmd.ErrOrStderr(), "[%d] create %s failed: %v\n", idx, keyValue, err)
-							failed++
-							continue
-						}
-						fmt.Fprintf(cmd.OutOrStdout(), "Synced document %s (created %s)\n", keyValue, formatRelativeTime(result.CreatedAt, "just now"))
-						created++
-						continue
+					if !isNotFoundError(err) {
+						return "failed", fmt.Errorf("lookup %s failed: %w", keyValue, err)
 					}
-					fmt.Fprintf(cmd.ErrOrStderr(), "[%d] lookup %s failed: %v\n", idx, keyValue, err)
-					failed++
-					continue
+					if skipMissing {
+						fmt.Fprintf(cmd.ErrOrStderr(), "[%d] document %s not found; skipping\n", idx, keyValue)
+						return "missing", nil
+					}
+					createPayload := prepareDocumentCreatePayload(rawDoc, pkField)
+					encoded, err := json.Marshal(createPayload)
+					if err != nil {
+						return "failed", fmt.Errorf("encode %s failed: %w", keyValue, err)
+					}
+					result, err := tenantClient.CreateDocument(ctx, collection, encoded, auth.appID)
+					if err != nil {
+						return "failed", fmt.Errorf("create %s failed: %w", keyValue, err)
+					}
+					fmt.Fprintf(cmd.OutOrStdout(), "Synced document %s (created %s)\n", keyValue, formatRelativeTime(result.CreatedAt, "just now"))
+					verifyFailures[idx] = !verifyWrite(ctx, idx, keyValue, result.ID, createPayload, true, "patch")
+					return "created", nil
 				}
 				payloadMap := prepareDocumentSyncPayload(rawDoc, pkField, keepPrimary)
 				if len(payloadMap) == 0 {
 					fmt.Fprintf(cmd.ErrOrStderr(), "[%d] document %s has no mutable fields; skipping\n", idx, keyValue)
-					skipped++
-					continue
+					return "skipped", nil
 				}
 				skipUpdate, cmpErr := shouldSkipDocumentSync(existing.Data, payloadMap, pkField, keepPrimary, modeValue)
 				if cmpErr != nil {
 					fmt.Fprintf(cmd.ErrOrStderr(), "[%d] compare %s failed: %v\n", idx, keyValue, cmpErr)
 				} else if skipUpdate {
 					fmt.Fprintf(cmd.OutOrStdout(), "Synced document %s (unchanged)\n", keyValue)
-					unchanged++
-					continue
+					return "unchanged", nil
 				}
 				encoded, err := json.Marshal(payloadMap)
 				if err != nil {
-					fmt.Fprintf(cmd.ErrOrStderr(), "[%d] encode %s failed: %v\n", idx, keyValue, err)
-					failed++
-					continue
+					return "failed", fmt.Errorf("encode %s failed: %w", keyValue, err)
 				}
 				var result *clientpkg.Document
 				if modeValue == "patch" {
-					result, err = tenantClient.PatchDocument(cmd.Context(), collection, existing.ID, encoded, auth.appID)
+					result, err = tenantClient.PatchDocument(ctx, collection, existing.ID, encoded, auth.appID)
 				} else {
-					result, err = tenantClient.UpdateDocument(cmd.Context(), collection, existing.ID, encoded, auth.appID)
+					result, err = tenantClient.UpdateDocument(ctx, collection, existing.ID, encoded, auth.appID)
 				}
 				if err != nil {
-					fmt.Fprintf(cmd.ErrOrStderr(), "[%d] sync %s failed: %v\n", idx, keyValue, err)
-					failed++
-					continue
+					return "failed", fmt.Errorf("sync %s failed: %w", keyValue, err)
 				}
 				fmt.Fprintf(cmd.OutOrStdout(), "Synced document %s (updated %s)\n", keyValue, formatRelativeTime(result.UpdatedAt, "just now"))
-				updated++
+				verifyFailures[idx] = !verifyWrite(ctx, idx, keyValue, existing.ID, payloadMap, keepPrimary, modeValue)
+				return "updated", nil
+			}
+			// Records stopped by Ctrl-C or --timeout keep an empty outcome.
+			outcomes := make([]string, len(docs))
+			ops := make([]clientpkg.BatchOperation, 0, len(docs)-startAt)
+			for idx := startAt; idx < len(docs); idx++ {
+				rawDoc := docs[idx]
+				ops = append(ops, func(ctx context.Context) error {
+					var err error
+					outcomes[idx], err = syncDocument(ctx, idx, rawDoc)
+					return err
+				})
 			}
-			fmt.Fprintf(cmd.ErrOrStderr(), "Documents synced: created %d, updated %d, unchanged %d, skipped %d, missing %d, failed %d\n", created, updated, unchanged, skipped, missing, failed)
-			if failed > 0 {
-				return fmt.Errorf("failed to sync %d document(s)", failed)
+			stats.stopped(func() (string, string) {
+				for idx := startAt; idx < len(docs); idx++ {
+					if outcomes[idx] == "" || outcomes[idx] == "failed" {
+						return fmt.Sprintf("record [%d] of %d was not synced", idx, len(docs)), resumeCommand(cmd, args, "start-at", strconv.Itoa(idx))
+					}
+				}
+				return "", ""
+			})
+			tracker := startProgress(cmd, "sync", int64(len(ops)))
+			batching.serializeOutput(cmd)
+			executor := batching.executor()
+			executor.OnResult = func(result clientpkg.BatchResult) {
+				idx := startAt + result.Index
+				if isStopped(cmd.Context(), result.Err) {
+					outcomes[idx] = ""
+				} else if result.Err != nil {
+					stats.errorf(cmd.ErrOrStderr(), "[%d] %v\n", idx, result.Err)
+					outcomes[idx] = "failed"
+				}
+				tracker.Item()
+			}
+			executor.Execute(cmd.Context(), ops)
+			var created, updated, unchanged, skipped, missing, failed, pending, verifyFailed int
+			for idx := startAt; idx < len(docs); idx++ {
+				switch outcomes[idx] {
+				case "created":
+					created++
+				case "updated":
+					updated++
+				case "unchanged":
+					unchanged++
+				case "skipped":
+					skipped++
+				case "missing":
+					missing++
+				case "":
+					pending++
+				default:
+					failed++
+				}
+				if verifyFailures[idx] {
+					verifyFailed++
+				}
 			}
-			return nil
+			var syncErr error
+			if pending > 0 {
+				syncErr = fmt.Errorf("sync stopped with %d document(s) pending: %w", pending, cmd.Context().Err())
+			} else if failed > 0 {
+				syncErr = fmt.Errorf("failed to sync %d document(s)", failed)
+			} else if verifyFailed > 0 {
+				syncErr = fmt.Errorf("%d synced document(s) failed read-after-write verification", verifyFailed)
+			}
+			tracker.Done(syncErr)
+			summary := fmt.Sprintf(tr("Documents synced: created %d, updated %d, unchanged %d, skipped %d, missing %d, failed %d"), created, updated, unchanged, skipped, missing, failed)
+			if verify {
+				summary += fmt.Sprintf(", verification failed %d", verifyFailed)
+				stats.count("verify_failed", verifyFailed)
+			}
+			if pending > 0 {
+				summary += fmt.Sprintf(", pending %d", pending)
+				stats.count("pending", pending)
+			}
+			fmt.Fprintln(cmd.ErrOrStderr(), summary)
+			if lineage != nil {
+				lineageImportRecord.Created, lineageImportRecord.Updated, lineageImportRecord.Unchanged, lineageImportRecord.Failed = created, updated, unchanged, failed
+				lineageImportRecord.Complete = syncErr == nil
+				if err := recordLineageImport(cmd, strings.TrimSpace(manifestPath), lineage, lineageImportRecord); err != nil {
+					if syncErr != nil {
+						fmt.Fprintln(cmd.ErrOrStderr(), err)
+					} else {
+						syncErr = err
+					}
+				}
+			}
+			for name, value := range map[string]int{"total": len(docs) - startAt, "created": created, "updated": updated, "unchanged": unchanged, "skipped": skipped, "missing": missing, "failed": failed} {
+				stats.count(name, value)
+			}
+			return syncErr
 		},
 	}
 
@@ -1254,6 +2063,13 @@ Use --skip-missing to only update existing documents without creating new ones.`
 	cmd.Flags().StringVar(&mode, "mode", "patch", "Sync mode: patch (default) or update")
 	cmd.Flags().StringVar(&keyField, "key-field", "", "Override primary key field name used for matching")
 	cmd.Flags().BoolVar(&skipMissing, "skip-missing", false, "Skip documents that are not found instead of creating them")
+	cmd.Flags().BoolVar(&verify, "verify", false, "Re-fetch each created or updated document and compare it with the payload")
+	cmd.Flags().BoolVar(&strict, "strict", false, strictFlagUsage)
+	cmd.Flags().StringVar(&manifestPath, "manifest", "", "Lineage manifest from 'documents export --manifest' to verify --file against and record this import in")
+	cmd.Flags().IntVar(&startAt, "start-at", 0, "Skip the records before this zero-based index, e.g. to resume an interrupted sync")
+	autoCreate.bind(cmd)
+	batching.bind(cmd)
+	bindStatsJSON(cmd, stats)
 	return cmd
 }
 
@@ -1427,6 +2243,35 @@ func shouldSkipDocumentSync(existingJSON string, payload map[string]any, pkField
 	return false, nil
 }
 
+// documentSyncMismatches lists payload fields whose stored value differs after a
+// write. In update mode fields present only in the stored document also count.
+func documentSyncMismatches(storedJSON string, payload map[string]any, pkField string, keepPrimary bool, mode string) ([]string, error) {
+	var stored map[string]any
+	if trimmed := strings.TrimSpace(storedJSON); trimmed != "" {
+		if err := json.Unmarshal([]byte(trimmed), &stored); err != nil {
+			return nil, fmt.Errorf("decode stored document: %w", err)
+		}
+	}
+	storedComparable := sanitizeDocumentComparisonMap(stored, pkField, keepPrimary)
+	payloadComparable := sanitizeDocumentComparisonMap(payload, pkField, keepPrimary)
+	var mismatched []string
+	for key, want := range payloadComparable {
+		got, ok := storedComparable[key]
+		if !ok || !reflect.DeepEqual(got, want) {
+			mismatched = append(mismatched, key)
+		}
+	}
+	if !strings.EqualFold(mode, "patch") {
+		for key := range storedComparable {
+			if _, ok := payloadComparable[key]; !ok {
+				mismatched = append(mismatched, key)
+			}
+		}
+	}
+	sort.Strings(mismatched)
+	return mismatched, nil
+}
+
 func sanitizeDocumentComparisonMap(source map[string]any, pkField string, keepPrimary bool) map[string]any {
 	cleaned := make(map[string]any)
 	if source == nil {
@@ -1533,15 +2378,15 @@ func buildExportPayload(doc clientpkg.Document, includeMeta bool, pretty bool) (
 			"tenant_id":     doc.TenantID,
 			"collection_id": doc.CollectionID,
 			"key":           doc.Key,
-			"created_at":    doc.CreatedAt.Format(time.RFC3339Nano),
-			"updated_at":    doc.UpdatedAt.Format(time.RFC3339Nano),
+			"created_at":    activeTimeDisplay.export(doc.CreatedAt),
+			"updated_at":    activeTimeDisplay.export(doc.UpdatedAt),
 			"data":          jsonStringToInterface(doc.Data),
 		}
 		if doc.KeyNumeric != nil {
 			payload["key_numeric"] = *doc.KeyNumeric
 		}
 		if doc.DeletedAt != nil {
-			payload["deleted_at"] = doc.DeletedAt.Format(time.RFC3339Nano)
+			payload["deleted_at"] = activeTimeDisplay.export(*doc.DeletedAt)
 		}
 		if pretty {
 			return json.MarshalIndent(payload, "", "  ")
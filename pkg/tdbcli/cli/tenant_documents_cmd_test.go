@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestNormalizeDocumentSortTokensWithSortableFields(t *testing.T) {
+	got, err := normalizeDocumentSortTokens([]string{"-Created_At", "+price", "data.name"}, "price", "data.name")
+	if err != nil {
+		t.Fatalf("normalize failed: %v", err)
+	}
+	if strings.Join(got, ",") != "-created_at,data.price,data.name" {
+		t.Fatalf("unexpected sort tokens %v", got)
+	}
+	_, err = normalizeDocumentSortTokens([]string{"-data.price"})
+	if err == nil || !strings.HasSuffix(err.Error(), "(allowed: created_at, updated_at, version, id, key, key_numeric, deleted_at)") {
+		t.Fatalf("expected error listing metadata fields only, got %v", err)
+	}
+}
+
+func TestDocumentsListSortsBySortableDataField(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "products", SortableFields: []string{"name"}})
+	for _, name := range []string{"bolt", "anvil", "crate"} {
+		srv.AddDocument("products", map[string]any{"name": name})
+	}
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+
+	run := func(sort string) (string, error) {
+		out, _, err := runCommandWithEnv(t, env, newTenantDocumentsListCommand, "products", "--sort", sort, "--raw")
+		return out, err
+	}
+
+	out, err := run("-data.name")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	var resp clientpkg.DocumentListResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, out)
+	}
+	var names []string
+	for _, doc := range resp.Items {
+		var data map[string]any
+		_ = json.Unmarshal([]byte(doc.Data), &data)
+		names = append(names, data["name"].(string))
+	}
+	if strings.Join(names, ",") != "crate,bolt,anvil" {
+		t.Fatalf("unexpected order %v", names)
+	}
+
+	if _, err := run("price"); err == nil || !strings.Contains(err.Error(), "allowed: created_at") || !strings.Contains(err.Error(), "data.name") {
+		t.Fatalf("expected error listing allowed fields, got %v", err)
+	}
+}
@@ -0,0 +1,324 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// documentCopyStats summarizes a copy run.
+type documentCopyStats struct {
+	Read    int      `json:"read"`
+	Written int      `json:"written"`
+	Failed  int      `json:"failed"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+func newTenantDocumentsCopyCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var filters []string
+	var transform string
+	var targetAppID string
+	var concurrency int
+	var pageSize int
+	var includeDeleted bool
+	var dryRun bool
+	var raw bool
+	runStats := newOperationStats()
+
+	cmd := &cobra.Command{
+		Use:   "copy <source> <target>",
+		Short: "Copy documents from one collection into another",
+		Long: `Stream documents from a source collection and create them in a target collection,
+optionally filtering the source and transforming each document with a jq
+expression (requires the jq binary on PATH).
+
+The transform receives each document's data and may emit zero, one, or many
+documents; every emitted object is written to the target. Use --target-app-id
+to write into a different application scope.`,
+		Example: `  # Copy all documents
+  tdb tenant documents copy users users_backup
+
+  # Copy active users, dropping a field and renaming another
+  tdb tenant documents copy users users_staging \
+    --filter status=active \
+    --transform 'del(.password) | .full_name = .name | del(.name)'
+
+  # Copy into another app with 8 concurrent writers
+  tdb tenant documents copy events events --target-app-id app_456 --concurrency 8`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			source := strings.TrimSpace(args[0])
+			target := strings.TrimSpace(args[1])
+			if source == "" || target == "" {
+				return errors.New("source and target collections are required")
+			}
+			targetApp := strings.TrimSpace(targetAppID)
+			if targetApp == "" {
+				targetApp = auth.appID
+			}
+			if source == target && targetApp == auth.appID {
+				return errors.New("source and target are identical; use a different target collection or --target-app-id")
+			}
+			filterMap, err := parseFilterFlags(filters)
+			if err != nil {
+				return err
+			}
+			if concurrency <= 0 {
+				concurrency = 1
+			}
+			page := tunePageSize(cmd, envCtx, "page-size", pageSize)
+			if page <= 0 {
+				page = 100
+			}
+
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+
+			stats := &documentCopyStats{}
+			var mu sync.Mutex
+			records := make(chan []byte, concurrency*2)
+			producerErr := make(chan error, 1)
+			go func() {
+				defer close(records)
+				producerErr <- produceCopyRecords(ctx, tenantClient, source, clientpkg.ListDocumentsParams{AppID: auth.appID, Filters: filterMap, IncludeDeleted: includeDeleted, Sort: []string{"created_at", "id"}}, page, transform, records, func() {
+					mu.Lock()
+					stats.Read++
+					mu.Unlock()
+				})
+			}()
+
+			var wg sync.WaitGroup
+			for i := 0; i < concurrency; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for record := range records {
+						if dryRun {
+							mu.Lock()
+							stats.Written++
+							mu.Unlock()
+							continue
+						}
+						_, err := tenantClient.CreateDocument(ctx, target, record, targetApp)
+						mu.Lock()
+						if err != nil {
+							stats.Failed++
+							if len(stats.Errors) < 10 {
+								stats.Errors = append(stats.Errors, fmt.Sprintf("%s: %v", summarizeJSON(string(record), 60), err))
+							}
+						} else {
+							stats.Written++
+						}
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+			runStats.count("read", stats.Read)
+			runStats.count("written", stats.Written)
+			runStats.count("failed", stats.Failed)
+			for _, msg := range stats.Errors {
+				runStats.addError(msg)
+			}
+			if err := <-producerErr; err != nil {
+				return fmt.Errorf("copy aborted after writing %d documents: %w", stats.Written, err)
+			}
+
+			if raw {
+				if err := printJSON(cmd, stats); err != nil {
+					return err
+				}
+			} else {
+				verb := "Copied"
+				if dryRun {
+					verb = "Would copy"
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s %d documents from %s to %s (read %d, failed %d)\n", verb, stats.Written, source, target, stats.Read, stats.Failed)
+				for _, msg := range stats.Errors {
+					fmt.Fprintf(cmd.ErrOrStderr(), "  error: %s\n", msg)
+				}
+			}
+			if stats.Failed > 0 {
+				return fmt.Errorf("%d documents failed to copy", stats.Failed)
+			}
+			return nil
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().StringArrayVar(&filters, "filter", nil, "Filter source documents field=value (repeatable)")
+	cmd.Flags().StringVar(&transform, "transform", "", "jq expression applied to each document's data")
+	cmd.Flags().StringVar(&targetAppID, "target-app-id", "", "Application scope for the target collection (defaults to the source scope)")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 4, "Number of concurrent writers")
+	cmd.Flags().IntVar(&pageSize, "page-size", 100, "Number of source documents fetched per page")
+	cmd.Flags().BoolVar(&includeDeleted, "include-deleted", false, "Include soft-deleted source documents")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Read and transform documents without writing them")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the copy summary as JSON")
+	bindStatsJSON(cmd, runStats)
+	return cmd
+}
+
+// produceCopyRecords pages through the source collection and sends each
+// document payload (optionally transformed through jq) to out.
+func produceCopyRecords(ctx context.Context, tenantClient *clientpkg.TenantClient, collection string, params clientpkg.ListDocumentsParams, page int, transform string, out chan<- []byte, onRead func()) error {
+	sink := func(payload []byte) error {
+		select {
+		case out <- payload:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	var jq *jqTransformer
+	if strings.TrimSpace(transform) != "" {
+		var err error
+		jq, err = startJQTransformer(ctx, transform, sink)
+		if err != nil {
+			return err
+		}
+		sink = jq.Write
+	}
+
+	offset := 0
+	var readErr error
+	for {
+		params.Limit = page
+		params.Offset = offset
+		resp, err := tenantClient.ListDocuments(ctx, collection, params)
+		if err != nil {
+			readErr = err
+			break
+		}
+		for _, doc := range resp.Items {
+			onRead()
+			data := strings.TrimSpace(doc.Data)
+			if data == "" {
+				continue
+			}
+			if err := sink([]byte(data)); err != nil {
+				readErr = err
+				break
+			}
+		}
+		if readErr != nil || len(resp.Items) < page {
+			break
+		}
+		offset += len(resp.Items)
+	}
+	if jq != nil {
+		if err := jq.Close(); err != nil {
+			readErr = err
+		}
+	}
+	return readErr
+}
+
+// jqTransformer pipes NDJSON documents through a long-running jq process and
+// forwards every emitted object to a callback.
+type jqTransformer struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	done   chan error
+	stderr bytes.Buffer
+}
+
+func startJQTransformer(ctx context.Context, expr string, emit func([]byte) error) (*jqTransformer, error) {
+	path, err := exec.LookPath("jq")
+	if err != nil {
+		return nil, errors.New("--transform requires the jq binary on PATH")
+	}
+	t := &jqTransformer{cmd: exec.CommandContext(ctx, path, "-c", expr), done: make(chan error, 1)}
+	t.cmd.Stderr = &t.stderr
+	stdout, err := t.cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	t.stdin, err = t.cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := t.cmd.Start(); err != nil {
+		return nil, err
+	}
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 || bytes.Equal(line, []byte("null")) {
+				continue
+			}
+			if !json.Valid(line) || line[0] != '{' {
+				t.done <- fmt.Errorf("transform produced a non-object value: %s", summarizeJSON(string(line), 60))
+				_, _ = io.Copy(io.Discard, stdout)
+				return
+			}
+			if err := emit(append([]byte(nil), line...)); err != nil {
+				t.done <- err
+				_, _ = io.Copy(io.Discard, stdout)
+				return
+			}
+		}
+		t.done <- scanner.Err()
+	}()
+	return t, nil
+}
+
+func (t *jqTransformer) Write(payload []byte) error {
+	_, err := t.stdin.Write(append(payload, '\n'))
+	return err
+}
+
+func (t *jqTransformer) Close() error {
+	_ = t.stdin.Close()
+	readErr := <-t.done
+	waitErr := t.cmd.Wait()
+	if readErr != nil {
+		return readErr
+	}
+	if waitErr != nil {
+		if msg := strings.TrimSpace(t.stderr.String()); msg != "" {
+			return fmt.Errorf("jq: %s", msg)
+		}
+		return fmt.Errorf("jq: %w", waitErr)
+	}
+	return nil
+}
+
+// parseFilterFlags converts repeated field=value flags into a filter map.
+func parseFilterFlags(filters []string) (map[string]string, error) {
+	filterMap := map[string]string{}
+	for _, f := range filters {
+		parts := strings.SplitN(f, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid filter %q (expected key=value)", f)
+		}
+		k := strings.TrimSpace(parts[0])
+		v := strings.TrimSpace(parts[1])
+		if k == "" {
+			return nil, fmt.Errorf("filter key cannot be empty: %q", f)
+		}
+		filterMap[k] = v
+	}
+	return filterMap, nil
+}
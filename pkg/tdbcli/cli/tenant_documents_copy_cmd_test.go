@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"context"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestParseFilterFlags(t *testing.T) {
+	got, err := parseFilterFlags([]string{"status=active", " name = a=b "})
+	if err != nil {
+		t.Fatalf("parseFilterFlags returned error: %v", err)
+	}
+	want := map[string]string{"status": "active", "name": "a=b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if _, err := parseFilterFlags([]string{"status"}); err == nil {
+		t.Fatalf("expected error for missing '='")
+	}
+	if _, err := parseFilterFlags([]string{"=x"}); err == nil {
+		t.Fatalf("expected error for empty key")
+	}
+}
+
+func TestJQTransformer(t *testing.T) {
+	if _, err := exec.LookPath("jq"); err != nil {
+		t.Skip("jq not installed")
+	}
+	var out []string
+	jq, err := startJQTransformer(context.Background(), `select(.keep) | del(.keep)`, func(b []byte) error {
+		out = append(out, string(b))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("startJQTransformer returned error: %v", err)
+	}
+	for _, doc := range []string{`{"a":1,"keep":true}`, `{"a":2,"keep":false}`, `{"a":3,"keep":true}`} {
+		if err := jq.Write([]byte(doc)); err != nil {
+			t.Fatalf("Write returned error: %v", err)
+		}
+	}
+	if err := jq.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	want := []string{`{"a":1}`, `{"a":3}`}
+	if !reflect.DeepEqual(out, want) {
+		t.Fatalf("expected %v, got %v", want, out)
+	}
+}
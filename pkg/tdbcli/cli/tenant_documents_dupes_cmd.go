@@ -0,0 +1,340 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// dupeGroup is a set of documents sharing the same values for one --by key.
+type dupeGroup struct {
+	By        []string       `json:"by"`
+	Value     map[string]any `json:"value"`
+	Documents []dupeDocument `json:"documents"`
+	Keep      string         `json:"keep,omitempty"`
+}
+
+type dupeDocument struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// dupeIndex groups documents by the values of one set of fields.
+type dupeIndex struct {
+	fields     []string
+	ignoreCase bool
+	groups     map[string]*dupeGroup
+}
+
+func newTenantDocumentsDupesCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var byKeys []string
+	var ignoreCase bool
+	var keep string
+	var deleteRest bool
+	var confirm bool
+	var outPath string
+	var show int
+	var raw bool
+	stats := newOperationStats()
+
+	cmd := &cobra.Command{
+		Use:   "dupes <collection>",
+		Short: "Find documents sharing the same field values",
+		Long: `Stream every document of a collection and report groups of documents that share
+the same value for --by. Each --by is one key; list several fields in one --by
+(--by name,dob) to match on all of them together. Documents missing a field, or
+holding null or an empty string, are not grouped. Fields may be dotted paths.
+
+--out writes the duplicate groups to a JSONL file for review. With --keep newest
+(or oldest) the document to keep is marked in each group, and --delete-rest
+--confirm soft-deletes the others. Deleting requires a single --by, so a
+document kept for one key is never deleted for another.`,
+		Example: `  # Find users sharing an email address, ignoring case
+  tdb tenant documents dupes users --by email --ignore-case
+
+  # Check two keys at once and export the groups
+  tdb tenant documents dupes users --by email --by name,dob --out user-dupes.jsonl
+
+  # Keep the most recently updated document of each group, delete the rest
+  tdb tenant documents dupes users --by email --keep newest --delete-rest --confirm`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			collection := strings.TrimSpace(args[0])
+			if collection == "" {
+				return errors.New(tr("collection name cannot be empty"))
+			}
+			var indexes []*dupeIndex
+			for _, by := range byKeys {
+				fields := splitCommaList(by)
+				if len(fields) == 0 {
+					return fmt.Errorf("--by requires at least one field")
+				}
+				indexes = append(indexes, &dupeIndex{fields: fields, ignoreCase: ignoreCase, groups: map[string]*dupeGroup{}})
+			}
+			if len(indexes) == 0 {
+				return fmt.Errorf("--by is required")
+			}
+			switch keep {
+			case "", "newest", "oldest":
+			default:
+				return fmt.Errorf("unsupported --keep %q (supported: newest, oldest)", keep)
+			}
+			if deleteRest {
+				if keep == "" {
+					return fmt.Errorf("--delete-rest requires --keep newest or --keep oldest")
+				}
+				if len(indexes) > 1 {
+					return fmt.Errorf("--delete-rest requires a single --by")
+				}
+				if !confirm {
+					return fmt.Errorf("use --confirm to delete duplicate documents")
+				}
+			}
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+
+			scanned := 0
+			docs := tenantClient.DocumentsIterator(cmd.Context(), collection, clientpkg.ListDocumentsParams{AppID: auth.appID})
+			for docs.Next() {
+				doc := docs.Document()
+				var data map[string]any
+				if err := json.Unmarshal([]byte(doc.Data), &data); err != nil {
+					stats.errorf(cmd.ErrOrStderr(), "skipping %s: %v\n", doc.ID, err)
+					continue
+				}
+				scanned++
+				for _, index := range indexes {
+					index.add(doc, data)
+				}
+			}
+			if err := docs.Err(); err != nil {
+				return err
+			}
+			stats.count("scanned", scanned)
+
+			var groups []*dupeGroup
+			for _, index := range indexes {
+				groups = append(groups, index.duplicates(keep)...)
+			}
+			redundant := 0
+			for _, group := range groups {
+				redundant += len(group.Documents) - 1
+			}
+			stats.count("groups", len(groups))
+			stats.count("redundant", redundant)
+
+			if strings.TrimSpace(outPath) != "" {
+				if err := writeDupeGroups(outPath, groups); err != nil {
+					return fmt.Errorf("write %s: %w", outPath, err)
+				}
+			}
+
+			deleted := 0
+			if deleteRest && redundant > 0 {
+				if err := confirmDestructive(cmd, envCtx, fmt.Sprintf("delete %d duplicate documents from %s", redundant, collection), fmt.Sprintf("Keeping the %s document of %d groups", keep, len(groups))); err != nil {
+					return err
+				}
+				for _, group := range groups {
+					for _, doc := range group.Documents {
+						if doc.ID == group.Keep {
+							continue
+						}
+						if err := tenantClient.DeleteDocument(cmd.Context(), collection, doc.ID, auth.appID); err != nil {
+							stats.errorf(cmd.ErrOrStderr(), "failed to delete %s: %v\n", doc.ID, err)
+							continue
+						}
+						deleted++
+					}
+				}
+				stats.count("deleted", deleted)
+			}
+
+			if raw {
+				if err := printJSON(cmd, map[string]any{"collection": collection, "scanned": scanned, "groups": groups, "deleted": deleted}); err != nil {
+					return err
+				}
+			} else {
+				renderDupeGroups(cmd, collection, scanned, groups, redundant, show, keep != "")
+				if strings.TrimSpace(outPath) != "" {
+					fmt.Fprintf(cmd.OutOrStdout(), "Wrote %d groups to %s\n", len(groups), outPath)
+				}
+				if deleteRest {
+					fmt.Fprintf(cmd.OutOrStdout(), "Deleted %d of %d duplicate documents, keeping the %s of each group\n", deleted, redundant, keep)
+				}
+			}
+			if failed := redundant - deleted; deleteRest && failed > 0 {
+				return fmt.Errorf("%d duplicate documents failed to delete", failed)
+			}
+			return nil
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().StringArrayVar(&byKeys, "by", nil, "Fields whose values must match (comma separated for a combined key); repeat for separate keys")
+	cmd.Flags().BoolVar(&ignoreCase, "ignore-case", false, "Compare string values case-insensitively, ignoring surrounding whitespace")
+	cmd.Flags().StringVar(&keep, "keep", "", "Document to keep in each group: newest or oldest (by update time)")
+	cmd.Flags().BoolVar(&deleteRest, "delete-rest", false, "Soft-delete every document of a group except the one chosen by --keep")
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm deleting duplicate documents")
+	cmd.Flags().StringVar(&outPath, "out", "", "Write the duplicate groups to this JSONL file")
+	cmd.Flags().IntVar(&show, "show", 20, "Number of groups listed in the table (0 for all)")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the duplicate groups as JSON")
+	bindSafeModeYes(cmd)
+	bindStatsJSON(cmd, stats)
+	return cmd
+}
+
+// add records doc under its key values. Documents missing any of the fields
+// are ignored.
+func (index *dupeIndex) add(doc clientpkg.Document, data map[string]any) {
+	values := make(map[string]any, len(index.fields))
+	key := make([]any, 0, len(index.fields))
+	for _, field := range index.fields {
+		value := lookupExpandPath(data, field)
+		if text, ok := value.(string); ok {
+			if strings.TrimSpace(text) == "" {
+				return
+			}
+			if index.ignoreCase {
+				value = strings.ToLower(strings.TrimSpace(text))
+			}
+		}
+		if value == nil {
+			return
+		}
+		values[field] = value
+		key = append(key, value)
+	}
+	encoded, err := json.Marshal(key)
+	if err != nil {
+		return
+	}
+	group, ok := index.groups[string(encoded)]
+	if !ok {
+		group = &dupeGroup{By: index.fields, Value: values}
+		index.groups[string(encoded)] = group
+	}
+	group.Documents = append(group.Documents, dupeDocument{ID: doc.ID, CreatedAt: doc.CreatedAt, UpdatedAt: doc.UpdatedAt})
+}
+
+// duplicates returns the groups holding more than one document, largest
+// first, with documents ordered newest first and the kept one marked.
+func (index *dupeIndex) duplicates(keep string) []*dupeGroup {
+	var groups []*dupeGroup
+	for _, group := range index.groups {
+		if len(group.Documents) < 2 {
+			continue
+		}
+		sort.SliceStable(group.Documents, func(i, j int) bool {
+			a, b := group.Documents[i], group.Documents[j]
+			if !a.UpdatedAt.Equal(b.UpdatedAt) {
+				return a.UpdatedAt.After(b.UpdatedAt)
+			}
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+			return a.ID > b.ID
+		})
+		switch keep {
+		case "newest":
+			group.Keep = group.Documents[0].ID
+		case "oldest":
+			group.Keep = group.Documents[len(group.Documents)-1].ID
+		}
+		groups = append(groups, group)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if len(groups[i].Documents) != len(groups[j].Documents) {
+			return len(groups[i].Documents) > len(groups[j].Documents)
+		}
+		return formatDupeValue(groups[i]) < formatDupeValue(groups[j])
+	})
+	return groups
+}
+
+// formatDupeValue renders a group's key, e.g. "a@example.com" or
+// "name=Ann, dob=1990-01-01".
+func formatDupeValue(group *dupeGroup) string {
+	parts := make([]string, 0, len(group.By))
+	for _, field := range group.By {
+		value := group.Value[field]
+		text, ok := value.(string)
+		if !ok {
+			encoded, _ := json.Marshal(value)
+			text = string(encoded)
+		}
+		if len(group.By) == 1 {
+			return text
+		}
+		parts = append(parts, field+"="+text)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func renderDupeGroups(cmd *cobra.Command, collection string, scanned int, groups []*dupeGroup, redundant, show int, marked bool) {
+	out := cmd.OutOrStdout()
+	if len(groups) == 0 {
+		fmt.Fprintf(out, "No duplicates found among %d documents in %s\n", scanned, collection)
+		return
+	}
+	fmt.Fprintf(out, "Found %d duplicate groups among %d documents in %s (%d redundant documents)\n", len(groups), scanned, collection, redundant)
+	listed := groups
+	if show > 0 && len(listed) > show {
+		listed = listed[:show]
+	}
+	headers := []string{"BY", "VALUE", "COUNT", "DOCUMENTS"}
+	if marked {
+		headers = append(headers, "KEEP")
+	}
+	rows := make([][]string, 0, len(listed))
+	for _, group := range listed {
+		ids := make([]string, 0, 3)
+		for i, doc := range group.Documents {
+			if i == 3 {
+				ids = append(ids, fmt.Sprintf("+%d more", len(group.Documents)-3))
+				break
+			}
+			ids = append(ids, doc.ID)
+		}
+		row := []string{strings.Join(group.By, ","), formatDupeValue(group), fmt.Sprintf("%d", len(group.Documents)), strings.Join(ids, ", ")}
+		if marked {
+			row = append(row, group.Keep)
+		}
+		rows = append(rows, row)
+	}
+	renderTable(cmd, headers, rows)
+	if len(listed) < len(groups) {
+		fmt.Fprintf(out, "... %d more groups (use --show 0, --out, or --raw to see all)\n", len(groups)-len(listed))
+	}
+}
+
+// writeDupeGroups writes one JSON line per duplicate group.
+func writeDupeGroups(path string, groups []*dupeGroup) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(file)
+	for _, group := range groups {
+		if err := encoder.Encode(group); err != nil {
+			_ = file.Close()
+			return err
+		}
+	}
+	return file.Close()
+}
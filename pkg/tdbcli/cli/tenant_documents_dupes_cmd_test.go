@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestDocumentsDupesReportsAndResolvesGroups(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	add := func(minutes int, data map[string]any) string {
+		srv.SetClock(func() time.Time { return base.Add(time.Duration(minutes) * time.Minute) })
+		return srv.AddDocument("users", data).ID
+	}
+	oldAnn := add(1, map[string]any{"email": "ann@example.com", "name": "Ann", "dob": "1990-01-01"})
+	newAnn := add(2, map[string]any{"email": "ANN@example.com ", "name": "Ann", "dob": "1990-01-01"})
+	add(3, map[string]any{"email": "bob@example.com", "name": "Bob", "dob": "1985-05-05"})
+	add(4, map[string]any{"email": "", "name": "Bob", "dob": "1985-05-05"})
+	add(5, map[string]any{"name": "Cy"})
+
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+	run := func(args ...string) (string, error) {
+		out, _, err := runCommandWithEnv(t, env, newTenantDocumentsDupesCommand, append([]string{"users"}, args...)...)
+		return out, err
+	}
+
+	out, err := run("--by", "email")
+	if err != nil {
+		t.Fatalf("dupes failed: %v", err)
+	}
+	if !strings.Contains(out, "No duplicates found among 5 documents") {
+		t.Fatalf("expected case-sensitive emails to differ:\n%s", out)
+	}
+
+	outPath := filepath.Join(t.TempDir(), "dupes.jsonl")
+	out, err = run("--by", "email", "--by", "name,dob", "--ignore-case", "--keep", "newest", "--out", outPath)
+	if err != nil {
+		t.Fatalf("dupes failed: %v", err)
+	}
+	for _, want := range []string{"Found 3 duplicate groups among 5 documents in users (3 redundant documents)", "ann@example.com", "name=bob, dob=1985-05-05", newAnn + ", " + oldAnn} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+	exported, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(exported)), "\n")
+	var first dupeGroup
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil || len(lines) != 3 || first.Keep != newAnn {
+		t.Fatalf("unexpected export (%v):\n%s", err, exported)
+	}
+
+	if _, err := run("--by", "email", "--by", "name", "--keep", "newest", "--delete-rest", "--confirm"); err == nil || !strings.Contains(err.Error(), "single --by") {
+		t.Fatalf("expected deleting with several keys to be rejected, got %v", err)
+	}
+	if _, err := run("--by", "email", "--keep", "newest", "--delete-rest"); err == nil || !strings.Contains(err.Error(), "--confirm") {
+		t.Fatalf("expected --confirm to be required, got %v", err)
+	}
+	out, err = run("--by", "email", "--ignore-case", "--keep", "oldest", "--delete-rest", "--confirm")
+	if err != nil {
+		t.Fatalf("delete-rest failed: %v", err)
+	}
+	if !strings.Contains(out, "Deleted 1 of 1 duplicate documents, keeping the oldest of each group") {
+		t.Fatalf("unexpected delete output:\n%s", out)
+	}
+	for _, doc := range srv.Documents("users") {
+		if (doc.ID == newAnn) != (doc.DeletedAt != nil) {
+			t.Fatalf("expected only the newer Ann to be deleted, got %s deleted=%v", doc.ID, doc.DeletedAt != nil)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+func newTenantDocumentsExistsCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var byKey bool
+	var verbose bool
+
+	cmd := &cobra.Command{
+		Use:   "exists <collection> <id_or_key>",
+		Short: "Exit 0 if a document exists, 4 if it does not",
+		Long: `Check whether a document exists without printing anything, so shell scripts
+can branch on the exit status:
+
+  0  the document exists
+  4  the document (or its collection) was not found, or it is soft-deleted
+  1  any other failure, such as an invalid API key or an unreachable server
+
+Errors other than "not found" are still printed to stderr.`,
+		Example: `  # Create the document only when it is missing
+  if ! tdb tenant documents exists users usr_123; then
+    tdb tenant documents create users --data '{"id":"usr_123"}'
+  fi
+
+  # Look up by primary key value and say what happened
+  tdb tenant documents exists users alice@example.com --by-key --verbose`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			collection := strings.TrimSpace(args[0])
+			id := strings.TrimSpace(args[1])
+			if collection == "" || id == "" {
+				return errors.New(tr("collection and document ID are required"))
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			var doc *clientpkg.Document
+			if byKey {
+				doc, err = tenantClient.GetDocumentByPrimaryKey(cmd.Context(), collection, id, auth.appID)
+			} else {
+				doc, err = tenantClient.GetDocument(cmd.Context(), collection, id, auth.appID)
+			}
+			if err != nil && !isNotFoundError(err) {
+				return err
+			}
+			if err != nil || doc.DeletedAt != nil {
+				if verbose {
+					fmt.Fprintf(cmd.OutOrStdout(), "Document %s not found in %s\n", id, collection)
+				}
+				return &ExitError{Code: ExitCodeNotFound}
+			}
+			if verbose {
+				fmt.Fprintf(cmd.OutOrStdout(), "Document %s exists in %s (id %s)\n", id, collection, doc.ID)
+			}
+			return nil
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().BoolVar(&byKey, "by-key", false, "Treat the argument as a primary key value instead of a document ID")
+	cmd.Flags().BoolVar(&verbose, "verbose", false, "Print whether the document was found")
+	return cmd
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestDocumentsExistsExitCodes(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users", PrimaryKeyField: "email", PrimaryKeyType: "string"})
+	doc := srv.AddDocument("users", map[string]any{"email": "alice@example.com"})
+
+	run := func(args ...string) (string, error) {
+		t.Helper()
+		out, _, err := runCommand(t, srv, newTenantDocumentsExistsCommand, args...)
+		return out, err
+	}
+	exitCode := func(err error) int {
+		var exitErr *ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.Code
+		}
+		if err != nil {
+			return 1
+		}
+		return 0
+	}
+
+	if out, err := run("users", doc.ID); exitCode(err) != 0 || out != "" {
+		t.Fatalf("expected silent success, got %v %q", err, out)
+	}
+	if out, err := run("users", "alice@example.com", "--by-key"); exitCode(err) != 0 || out != "" {
+		t.Fatalf("expected lookup by key to succeed, got %v %q", err, out)
+	}
+	if out, err := run("users", "missing"); exitCode(err) != ExitCodeNotFound || out != "" {
+		t.Fatalf("expected silent exit 4, got %v %q", err, out)
+	}
+	out, err := run("users", "bob@example.com", "--by-key", "--verbose")
+	if exitCode(err) != ExitCodeNotFound || !strings.Contains(out, "not found") {
+		t.Fatalf("expected verbose not found, got %v %q", err, out)
+	}
+}
+
+func TestDocumentsExistsKeepsScopeNoticeOffStdout(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users"})
+	doc := srv.AddDocument("users", map[string]any{"name": "Ann"})
+	env := &Environment{Config: &configpkg.Config{
+		Endpoint: srv.URL,
+		Tenants: map[string]configpkg.TenantConfig{
+			fakeTenantID: {DefaultKey: "main", Keys: map[string]configpkg.APIKeyEntry{"main": {Key: clienttest.APIKey, AppID: "app_1"}}},
+		},
+	}}
+
+	cmd := newTenantDocumentsExistsCommand(env)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	cmd.SetArgs([]string{"users", doc.ID, "--tenant", fakeTenantID})
+	var out, errOut bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	err := cmd.Execute()
+	var exitErr *ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		t.Fatalf("exists: %v", err)
+	}
+	if out.String() != "" {
+		t.Fatalf("expected nothing on stdout, got %q", out.String())
+	}
+	if !strings.Contains(errOut.String(), "Using stored app scope app_1") {
+		t.Fatalf("expected the scope notice on stderr, got %q", errOut.String())
+	}
+}
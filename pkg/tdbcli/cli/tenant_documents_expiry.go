@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// defaultExpiryField is written when neither --expires-field nor the
+// collection's TTL configuration names the expiry field.
+const defaultExpiryField = "expires_at"
+
+// documentExpiry holds the --expires-in and --expires-field flags shared by
+// document create and bulk-create.
+type documentExpiry struct {
+	in    string
+	field string
+	ttl   time.Duration
+}
+
+func (e *documentExpiry) bind(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&e.in, "expires-in", "", "Set the collection's expiry field to now plus this duration (e.g. 90m, 7d)")
+	cmd.Flags().StringVar(&e.field, "expires-field", "", "Expiry field to set with --expires-in (defaults to the collection's TTL field, then expires_at)")
+}
+
+func (e *documentExpiry) validate() error {
+	if strings.TrimSpace(e.in) == "" {
+		if strings.TrimSpace(e.field) != "" {
+			return fmt.Errorf("--expires-field requires --expires-in")
+		}
+		return nil
+	}
+	ttl, err := parseFlexibleDurationArg(e.in)
+	if err != nil {
+		return fmt.Errorf("invalid --expires-in: %w", err)
+	}
+	if ttl <= 0 {
+		return fmt.Errorf("--expires-in must be positive")
+	}
+	e.ttl = ttl
+	return nil
+}
+
+// apply sets the expiry field on the document (or every document of an
+// array payload) that does not already carry one. With lookup set, the field
+// name comes from the collection's TTL configuration and a warning is printed
+// when the collection has none, since the server would not expire anything.
+func (e *documentExpiry) apply(cmd *cobra.Command, tenantClient *clientpkg.TenantClient, collection, appID string, payload []byte, lookup bool) ([]byte, error) {
+	if e.ttl <= 0 {
+		return payload, nil
+	}
+	field := strings.TrimSpace(e.field)
+	if lookup {
+		col, err := tenantClient.GetCollection(cmd.Context(), collection, appID)
+		if err != nil {
+			return nil, err
+		}
+		ttlField := strings.TrimSpace(col.TTLField)
+		switch {
+		case ttlField == "":
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: collection %s has no TTL configuration; documents will not expire automatically\n", collection)
+		case field != "" && field != ttlField:
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: collection %s expires documents by %s, not %s\n", collection, ttlField, field)
+		case field == "":
+			field = ttlField
+		}
+	}
+	if field == "" {
+		field = defaultExpiryField
+	}
+
+	value, err := decodeJSONPreservingNumbers(payload)
+	if err != nil {
+		return nil, fmt.Errorf("decode payload: %w", err)
+	}
+	expiresAt := time.Now().UTC().Add(e.ttl).Format(time.RFC3339)
+	setExpiry := func(doc any) error {
+		obj, ok := doc.(map[string]any)
+		if !ok {
+			return fmt.Errorf("--expires-in requires JSON object documents")
+		}
+		if _, exists := obj[field]; !exists {
+			obj[field] = expiresAt
+		}
+		return nil
+	}
+	if docs, ok := value.([]any); ok {
+		for _, doc := range docs {
+			if err := setExpiry(doc); err != nil {
+				return nil, err
+			}
+		}
+	} else if err := setExpiry(value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
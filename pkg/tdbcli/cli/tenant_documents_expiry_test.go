@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestDocumentsCreateExpiresIn(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "sessions", TTLField: "expire_on"})
+	srv.AddCollection(clientpkg.Collection{Name: "events"})
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+
+	run := func(build func(*Environment) *cobra.Command, args ...string) (string, error) {
+		_, errOut, err := runCommandWithEnv(t, env, build, args...)
+		return errOut, err
+	}
+
+	before := time.Now().UTC()
+	if _, err := run(newTenantDocumentsCreateCommand, "sessions", "--data", `{"user":"u_1"}`, "--expires-in", "7d"); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	var data map[string]any
+	if err := json.Unmarshal([]byte(srv.Documents("sessions")[0].Data), &data); err != nil {
+		t.Fatal(err)
+	}
+	expiresAt, err := time.Parse(time.RFC3339, data["expire_on"].(string))
+	if err != nil {
+		t.Fatalf("expected RFC3339 expire_on, got %v", data)
+	}
+	if d := expiresAt.Sub(before); d < 7*24*time.Hour-time.Minute || d > 7*24*time.Hour+time.Minute {
+		t.Fatalf("expire_on %s is not 7 days from now", expiresAt)
+	}
+
+	stderr, err := run(newTenantDocumentsBulkCreateCommand, "events", "--data", `[{"n":1},{"n":2,"expires_at":"2030-01-01T00:00:00Z"}]`, "--expires-in", "90m")
+	if err != nil {
+		t.Fatalf("bulk-create failed: %v", err)
+	}
+	if !strings.Contains(stderr, "collection events has no TTL configuration") {
+		t.Fatalf("expected missing TTL warning, got %q", stderr)
+	}
+	docs := srv.Documents("events")
+	if len(docs) != 2 || !strings.Contains(docs[0].Data, `"expires_at"`) || !strings.Contains(docs[1].Data, "2030-01-01T00:00:00Z") {
+		t.Fatalf("unexpected documents: %+v", docs)
+	}
+
+	if _, err := run(newTenantDocumentsCreateCommand, "sessions", "--data", `{}`, "--expires-in", "soon"); err == nil || !strings.Contains(err.Error(), "invalid --expires-in") {
+		t.Fatalf("expected duration error, got %v", err)
+	}
+}
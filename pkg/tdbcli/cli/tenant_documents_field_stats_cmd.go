@@ -0,0 +1,319 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// maxFieldStatsValues caps the distinct values counted per field, so a
+// high-cardinality field such as an ID cannot exhaust memory.
+const maxFieldStatsValues = 10000
+
+// fieldStats profiles the values of one field. Numeric is set when every
+// present value is a number; otherwise Values holds a frequency table. Values
+// past maxFieldStatsValues distinct ones are counted in Other and make
+// Distinct a lower bound, flagged by Capped.
+type fieldStats struct {
+	Field    string           `json:"field"`
+	Kind     string           `json:"kind"`
+	Count    int              `json:"count"`
+	Missing  int              `json:"missing"`
+	Distinct int              `json:"distinct"`
+	Capped   bool             `json:"capped,omitempty"`
+	Other    int              `json:"other,omitempty"`
+	Numeric  *numericStats    `json:"numeric,omitempty"`
+	Values   []fieldValueFreq `json:"values,omitempty"`
+
+	// numbers holds the values while they are all numeric; freq is only
+	// filled once a non-numeric value shows the field is categorical.
+	numbers     []float64
+	freq        map[string]int
+	categorical bool
+}
+
+type numericStats struct {
+	Min         float64            `json:"min"`
+	Max         float64            `json:"max"`
+	Avg         float64            `json:"avg"`
+	Sum         float64            `json:"sum"`
+	Percentiles map[string]float64 `json:"percentiles"`
+}
+
+type fieldValueFreq struct {
+	Value string  `json:"value"`
+	Count int     `json:"count"`
+	Share float64 `json:"share"`
+}
+
+func newTenantDocumentsFieldStatsCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var fieldFlags []string
+	var filters []string
+	var rawFilters []string
+	var percentileList string
+	var top int
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "field-stats <collection>",
+		Short: "Profile the values of document fields",
+		Long: `Stream the documents of a collection and summarize the values of each --field.
+Fields holding only numbers get min, max, average, sum and percentiles; any
+other field gets a frequency table of its most common values (--top of them,
+also with --raw). At most 10000 distinct values are counted per field; the rest
+are reported together. Documents missing a field, or holding null, count as
+missing. Fields may be dotted paths.
+
+--filter narrows the documents on the server, like documents list.`,
+		Example: `  # Price distribution and status breakdown of all orders
+  tdb tenant documents field-stats orders --field price --field status
+
+  # Custom percentiles for paid orders, as JSON
+  tdb tenant documents field-stats orders --field price --filter status=paid --percentiles 50,75,99.9 --raw`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			collection := strings.TrimSpace(args[0])
+			if collection == "" {
+				return errors.New(tr("collection name cannot be empty"))
+			}
+			var fields []string
+			for _, value := range fieldFlags {
+				fields = append(fields, splitCommaList(value)...)
+			}
+			if len(fields) == 0 {
+				return fmt.Errorf("--field is required")
+			}
+			percentiles, err := parsePercentiles(percentileList)
+			if err != nil {
+				return err
+			}
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, tenantID, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			typed, err := resolveTypedFilters(cmd.Context(), envCtx, tenantClient, tenantID, collection, auth.appID, filters, rawFilters)
+			if err != nil {
+				return err
+			}
+
+			profiles := make([]*fieldStats, len(fields))
+			for i, field := range fields {
+				profiles[i] = &fieldStats{Field: field, freq: map[string]int{}}
+			}
+			scanned := 0
+			docs := tenantClient.DocumentsIterator(cmd.Context(), collection, clientpkg.ListDocumentsParams{AppID: auth.appID, Filters: typed.queryParams()})
+			for docs.Next() {
+				doc := docs.Document()
+				var data map[string]any
+				if err := json.Unmarshal([]byte(doc.Data), &data); err != nil {
+					logWarn(cmd.ErrOrStderr(), fmt.Sprintf("skipping %s: %v", doc.ID, err))
+					continue
+				}
+				scanned++
+				for _, profile := range profiles {
+					profile.add(lookupExpandPath(data, profile.Field))
+				}
+			}
+			if err := docs.Err(); err != nil {
+				return err
+			}
+			for _, profile := range profiles {
+				profile.finish(scanned, percentiles)
+			}
+
+			if raw {
+				for _, profile := range profiles {
+					if top > 0 && len(profile.Values) > top {
+						profile.Values = profile.Values[:top]
+					}
+				}
+				return printJSON(cmd, map[string]any{"collection": collection, "scanned": scanned, "fields": profiles})
+			}
+			renderFieldStats(cmd, collection, scanned, profiles, percentiles, top)
+			return nil
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().StringArrayVar(&fieldFlags, "field", nil, "Field to profile (comma separated or repeatable; dotted paths allowed)")
+	cmd.Flags().StringArrayVar(&filters, "filter", nil, "Filter predicate field=value, coerced to the schema type of the field (repeatable)")
+	cmd.Flags().StringArrayVar(&rawFilters, "filter-raw", nil, filterRawFlagUsage)
+	cmd.Flags().StringVar(&percentileList, "percentiles", "50,90,95,99", "Comma-separated percentiles reported for numeric fields")
+	cmd.Flags().IntVar(&top, "top", 10, "Most common values listed for non-numeric fields, also with --raw (0 for all)")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the statistics as JSON")
+	return cmd
+}
+
+func parsePercentiles(list string) ([]float64, error) {
+	var out []float64
+	for _, item := range splitCommaList(list) {
+		value, err := strconv.ParseFloat(item, 64)
+		if err != nil || value < 0 || value > 100 {
+			return nil, fmt.Errorf("invalid --percentiles value %q (expected numbers from 0 to 100)", item)
+		}
+		out = append(out, value)
+	}
+	sort.Float64s(out)
+	return out, nil
+}
+
+func (s *fieldStats) add(value any) {
+	if value == nil {
+		return
+	}
+	s.Count++
+	number, isNumber := value.(float64)
+	if !s.categorical {
+		if isNumber {
+			s.numbers = append(s.numbers, number)
+			return
+		}
+		s.categorical = true
+		for _, seen := range s.numbers {
+			s.count(seen)
+		}
+		s.numbers = nil
+	}
+	s.count(value)
+}
+
+// count adds value to the frequency table, or to Other once the table holds
+// maxFieldStatsValues distinct values.
+func (s *fieldStats) count(value any) {
+	text, ok := value.(string)
+	if !ok {
+		encoded, _ := json.Marshal(value)
+		text = string(encoded)
+	}
+	if _, seen := s.freq[text]; !seen && len(s.freq) >= maxFieldStatsValues {
+		s.Capped = true
+		s.Other++
+		return
+	}
+	s.freq[text]++
+}
+
+// finish derives the summary once every document has been added.
+func (s *fieldStats) finish(scanned int, percentiles []float64) {
+	s.Missing = scanned - s.Count
+	switch {
+	case s.Count == 0:
+		s.Kind = "missing"
+		return
+	case !s.categorical:
+		s.Kind = "numeric"
+		s.Numeric = summarizeNumbers(s.numbers, percentiles)
+		s.Distinct = countDistinct(s.numbers)
+		return
+	}
+	s.Distinct = len(s.freq)
+	s.Kind = "categorical"
+	for value, count := range s.freq {
+		s.Values = append(s.Values, fieldValueFreq{Value: value, Count: count, Share: float64(count) / float64(s.Count)})
+	}
+	sort.Slice(s.Values, func(i, j int) bool {
+		if s.Values[i].Count != s.Values[j].Count {
+			return s.Values[i].Count > s.Values[j].Count
+		}
+		return s.Values[i].Value < s.Values[j].Value
+	})
+}
+
+// countDistinct returns how many different values numbers holds.
+func countDistinct(numbers []float64) int {
+	sorted := append([]float64(nil), numbers...)
+	sort.Float64s(sorted)
+	distinct := 0
+	for i, number := range sorted {
+		if i == 0 || number != sorted[i-1] {
+			distinct++
+		}
+	}
+	return distinct
+}
+
+func summarizeNumbers(numbers []float64, percentiles []float64) *numericStats {
+	sorted := append([]float64(nil), numbers...)
+	sort.Float64s(sorted)
+	stats := &numericStats{Min: sorted[0], Max: sorted[len(sorted)-1], Percentiles: make(map[string]float64, len(percentiles))}
+	for _, number := range sorted {
+		stats.Sum += number
+	}
+	stats.Avg = stats.Sum / float64(len(sorted))
+	for _, p := range percentiles {
+		stats.Percentiles[formatPercentile(p)] = percentileOf(sorted, p)
+	}
+	return stats
+}
+
+// percentileOf interpolates linearly between the closest ranks of sorted.
+func percentileOf(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+func formatPercentile(p float64) string {
+	return "p" + strconv.FormatFloat(p, 'f', -1, 64)
+}
+
+func formatStatNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*1e6)/1e6, 'f', -1, 64)
+}
+
+func renderFieldStats(cmd *cobra.Command, collection string, scanned int, profiles []*fieldStats, percentiles []float64, top int) {
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Profiled %d documents in %s\n", scanned, collection)
+	for _, profile := range profiles {
+		distinct := strconv.Itoa(profile.Distinct)
+		if profile.Capped {
+			distinct += "+"
+		}
+		fmt.Fprintf(out, "\n%s (%s): %d values, %d missing, %s distinct\n", profile.Field, profile.Kind, profile.Count, profile.Missing, distinct)
+		switch profile.Kind {
+		case "numeric":
+			stats := profile.Numeric
+			rows := [][]string{
+				{"min", formatStatNumber(stats.Min)},
+				{"max", formatStatNumber(stats.Max)},
+				{"avg", formatStatNumber(stats.Avg)},
+				{"sum", formatStatNumber(stats.Sum)},
+			}
+			for _, p := range percentiles {
+				name := formatPercentile(p)
+				rows = append(rows, []string{name, formatStatNumber(stats.Percentiles[name])})
+			}
+			renderTable(cmd, []string{"STAT", "VALUE"}, rows)
+		case "categorical":
+			listed := profile.Values
+			if top > 0 && len(listed) > top {
+				listed = listed[:top]
+			}
+			rows := make([][]string, 0, len(listed))
+			for _, value := range listed {
+				rows = append(rows, []string{value.Value, fmt.Sprintf("%d", value.Count), fmt.Sprintf("%.1f%%", value.Share*100)})
+			}
+			renderTable(cmd, []string{"VALUE", "COUNT", "SHARE"}, rows)
+			if len(listed) < len(profile.Values) {
+				fmt.Fprintf(out, "... %d more values (use --top 0 to see all)\n", len(profile.Values)-len(listed))
+			}
+			if profile.Capped {
+				fmt.Fprintf(out, "... %d occurrences of values beyond the first %d distinct were not counted separately\n", profile.Other, maxFieldStatsValues)
+			}
+		}
+	}
+}
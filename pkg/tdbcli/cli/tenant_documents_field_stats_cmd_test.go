@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestDocumentsFieldStatsProfilesNumericAndCategoricalFields(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	for i, status := range []string{"paid", "paid", "open", "paid", "refunded"} {
+		srv.AddDocument("orders", map[string]any{"price": float64((i + 1) * 10), "status": status, "meta": map[string]any{"channel": "web"}})
+	}
+	srv.AddDocument("orders", map[string]any{"status": "open", "price": nil})
+
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+	run := func(args ...string) (string, error) {
+		out, _, err := runCommandWithEnv(t, env, newTenantDocumentsFieldStatsCommand, append([]string{"orders"}, args...)...)
+		return out, err
+	}
+
+	out, err := run("--field", "price,status", "--field", "meta.channel", "--top", "2")
+	if err != nil {
+		t.Fatalf("field-stats failed: %v", err)
+	}
+	for _, want := range []string{
+		"Profiled 6 documents in orders",
+		"price (numeric): 5 values, 1 missing, 5 distinct",
+		"status (categorical): 6 values, 0 missing, 3 distinct",
+		"meta.channel (categorical): 5 values, 1 missing, 1 distinct",
+		"50.0%",
+		"... 1 more values",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+
+	out, err = run("--field", "price", "--field", "status", "--percentiles", "90,50", "--raw")
+	if err != nil {
+		t.Fatalf("field-stats --raw failed: %v", err)
+	}
+	var result struct {
+		Scanned int          `json:"scanned"`
+		Fields  []fieldStats `json:"fields"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, out)
+	}
+	price := result.Fields[0].Numeric
+	if result.Scanned != 6 || price == nil || price.Min != 10 || price.Max != 50 || price.Avg != 30 || price.Percentiles["p50"] != 30 || price.Percentiles["p90"] != 46 {
+		t.Fatalf("unexpected numeric stats: %+v", result)
+	}
+	status := result.Fields[1].Values
+	if len(status) != 3 || status[0].Value != "paid" || status[0].Count != 3 || status[0].Share != 0.5 {
+		t.Fatalf("unexpected frequency table: %+v", status)
+	}
+
+	if _, err := run(); err == nil || !strings.Contains(err.Error(), "--field is required") {
+		t.Fatalf("expected --field to be required, got %v", err)
+	}
+	if _, err := run("--field", "price", "--percentiles", "150"); err == nil || !strings.Contains(err.Error(), "--percentiles") {
+		t.Fatalf("expected an invalid percentile to be rejected, got %v", err)
+	}
+}
+
+func TestFieldStatsKeepsFrequenciesOnlyForCategoricalFields(t *testing.T) {
+	numeric := &fieldStats{Field: "price", freq: map[string]int{}}
+	for _, value := range []any{10.0, 20.0, 10.0} {
+		numeric.add(value)
+	}
+	numeric.finish(3, []float64{50})
+	if numeric.Kind != "numeric" || len(numeric.freq) != 0 || numeric.Distinct != 2 || numeric.Values != nil {
+		t.Fatalf("expected a numeric profile without a frequency table, got %+v", numeric)
+	}
+
+	mixed := &fieldStats{Field: "code", freq: map[string]int{}}
+	for _, value := range []any{1.0, 1.0, "A"} {
+		mixed.add(value)
+	}
+	mixed.finish(3, nil)
+	if mixed.Kind != "categorical" || mixed.numbers != nil || mixed.Distinct != 2 || mixed.Values[0].Value != "1" || mixed.Values[0].Count != 2 {
+		t.Fatalf("expected the numbers seen earlier in the frequency table, got %+v", mixed)
+	}
+
+	ids := &fieldStats{Field: "id", freq: map[string]int{}}
+	for i := 0; i < maxFieldStatsValues+5; i++ {
+		ids.add(fmt.Sprintf("id-%d", i))
+	}
+	ids.add("id-0")
+	ids.finish(maxFieldStatsValues+6, nil)
+	if len(ids.freq) != maxFieldStatsValues || !ids.Capped || ids.Other != 5 || ids.Values[0].Value != "id-0" || ids.Values[0].Count != 2 {
+		t.Fatalf("expected the frequency table to stop at %d values, got %d values, capped=%v other=%d", maxFieldStatsValues, len(ids.freq), ids.Capped, ids.Other)
+	}
+}
+
+func TestDocumentsFieldStatsRawHonorsTop(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	for _, status := range []string{"paid", "paid", "open", "refunded"} {
+		srv.AddDocument("orders", map[string]any{"status": status})
+	}
+	out, _, err := runCommand(t, srv, newTenantDocumentsFieldStatsCommand, "orders", "--field", "status", "--top", "1", "--raw")
+	if err != nil {
+		t.Fatalf("field-stats --raw failed: %v", err)
+	}
+	var result struct {
+		Fields []fieldStats `json:"fields"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, out)
+	}
+	if values := result.Fields[0].Values; len(values) != 1 || values[0].Value != "paid" || result.Fields[0].Distinct != 3 {
+		t.Fatalf("expected only the top value, got %+v", result.Fields[0])
+	}
+}
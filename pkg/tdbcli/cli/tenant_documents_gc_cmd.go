@@ -0,0 +1,241 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// gcLogEntry is one line of the recovery log written before a document is
+// purged. Data holds the original document payload so it can be re-imported.
+type gcLogEntry struct {
+	ID        string          `json:"id"`
+	Key       string          `json:"key,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	DeletedAt *time.Time      `json:"deleted_at"`
+	PurgedAt  time.Time       `json:"purged_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func newTenantDocumentsGCCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var deletedBefore string
+	var purge bool
+	var confirm bool
+	var logPath string
+	var pageSize int
+	stats := newOperationStats()
+
+	cmd := &cobra.Command{
+		Use:   "gc <collection>",
+		Short: "Purge soft-deleted documents older than a cutoff",
+		Long: `Find documents that were soft-deleted before a cutoff and, with --purge, remove
+them permanently in batches to reclaim storage.
+
+Without --purge the command only reports what would be purged. Before each batch
+is purged, the full documents are appended to a JSONL recovery log (--log,
+defaulting to gc-<collection>-<timestamp>.jsonl) so they can be re-imported.`,
+		Example: `  # Report documents soft-deleted more than 90 days ago
+  tdb tenant documents gc users --deleted-before 90d
+
+  # Purge them, keeping a recovery log
+  tdb tenant documents gc users --deleted-before 90d --purge --confirm --log users-gc.jsonl
+
+  # Use an absolute cutoff
+  tdb tenant documents gc orders --deleted-before 2024-01-01T00:00:00Z --purge --confirm`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			collection := strings.TrimSpace(args[0])
+			if collection == "" {
+				return errors.New(tr("collection name cannot be empty"))
+			}
+			now := time.Now().UTC()
+			cutoff, err := parseAuditTimeArg(deletedBefore, now)
+			if err != nil {
+				return fmt.Errorf("invalid --deleted-before: %w", err)
+			}
+			if purge && !confirm {
+				return errors.New(tr("use --confirm to acknowledge irreversible purge"))
+			}
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+
+			page := tunePageSize(cmd, envCtx, "page-size", pageSize)
+			if page <= 0 {
+				page = 100
+			}
+			if purge && envCtx.Config != nil && envCtx.Config.SafeMode {
+				count, size, err := countGCCandidates(cmd.Context(), tenantClient, collection, auth.appID, page, cutoff)
+				if err != nil {
+					return err
+				}
+				action := fmt.Sprintf("permanently purge %d soft-deleted documents from %s", count, collection)
+				if err := confirmDestructive(cmd, envCtx, action, "Deleted before: "+formatTime(cutoff), "Size: "+formatBytes(size)); err != nil {
+					return err
+				}
+			}
+
+			var logWriter *bufio.Writer
+			if purge {
+				if strings.TrimSpace(logPath) == "" {
+					logPath = fmt.Sprintf("gc-%s-%s.jsonl", collection, now.Format("20060102T150405Z"))
+				}
+				clean := filepath.Clean(strings.TrimSpace(logPath))
+				if dir := filepath.Dir(clean); dir != "." && dir != "" {
+					if err := os.MkdirAll(dir, 0o755); err != nil {
+						return err
+					}
+				}
+				file, err := os.OpenFile(clean, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = file.Close() }()
+				logWriter = bufio.NewWriter(file)
+				defer logWriter.Flush()
+			}
+
+			progress := cmd.ErrOrStderr()
+			scanned, matched, purged := 0, 0, 0
+			var reclaimed int64
+			offset := 0
+			for {
+				params := clientpkg.ListDocumentsParams{AppID: auth.appID, Limit: page, Offset: offset, IncludeDeleted: true, Sort: []string{"created_at", "id"}}
+				resp, err := tenantClient.ListDocuments(cmd.Context(), collection, params)
+				if err != nil {
+					return err
+				}
+				scanned += len(resp.Items)
+				batch := gcCandidates(resp.Items, cutoff)
+				matched += len(batch)
+				removed := 0
+				if purge && len(batch) > 0 {
+					if err := writeGCLog(logWriter, batch, time.Now().UTC()); err != nil {
+						return fmt.Errorf("write recovery log: %w", err)
+					}
+					for _, doc := range batch {
+						if err := tenantClient.PurgeDocument(cmd.Context(), collection, doc.ID, true, auth.appID); err != nil {
+							stats.errorf(progress, "failed to purge %s: %v\n", doc.ID, err)
+							continue
+						}
+						removed++
+						reclaimed += doc.DataSize
+					}
+					purged += removed
+					fmt.Fprintf(progress, "Scanned %d, purged %d of %d matching documents\n", scanned, purged, matched)
+				} else {
+					for _, doc := range batch {
+						reclaimed += doc.DataSize
+					}
+				}
+				stats.count("scanned", scanned)
+				stats.count("matched", matched)
+				stats.count("purged", purged)
+				if len(resp.Items) < page {
+					break
+				}
+				// Purged documents disappear from the listing, so only advance
+				// past the ones that remain.
+				offset += len(resp.Items) - removed
+			}
+
+			out := cmd.OutOrStdout()
+			if !purge {
+				fmt.Fprintf(out, "%d of %d documents in %s were soft-deleted before %s (%s)\n", matched, scanned, collection, formatTime(cutoff), formatBytes(reclaimed))
+				if matched > 0 {
+					fmt.Fprintln(out, "Re-run with --purge --confirm to remove them permanently.")
+				}
+				return nil
+			}
+			fmt.Fprintf(out, "Purged %d of %d matching documents from %s, reclaiming %s\n", purged, matched, collection, formatBytes(reclaimed))
+			if matched > 0 {
+				fmt.Fprintf(out, "Recovery log: %s\n", logPath)
+			}
+			if failed := matched - purged; failed > 0 {
+				return fmt.Errorf("%d documents failed to purge", failed)
+			}
+			return nil
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().StringVar(&deletedBefore, "deleted-before", "90d", "Only documents soft-deleted before this age or RFC3339 timestamp")
+	cmd.Flags().BoolVar(&purge, "purge", false, "Permanently purge matching documents (default only reports them)")
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm irreversible purge")
+	cmd.Flags().StringVar(&logPath, "log", "", "Append purged documents to this JSONL recovery log")
+	cmd.Flags().IntVar(&pageSize, "page-size", 100, "Number of documents fetched and purged per batch")
+	bindSafeModeYes(cmd)
+	bindStatsJSON(cmd, stats)
+	return cmd
+}
+
+// countGCCandidates scans the collection once and returns how many documents
+// gc would purge and their total size, for the safe-mode confirmation.
+func countGCCandidates(ctx context.Context, tenantClient *clientpkg.TenantClient, collection, appID string, page int, cutoff time.Time) (int, int64, error) {
+	count := 0
+	var size int64
+	for offset := 0; ; offset += page {
+		params := clientpkg.ListDocumentsParams{AppID: appID, Limit: page, Offset: offset, IncludeDeleted: true, Sort: []string{"created_at", "id"}}
+		resp, err := tenantClient.ListDocuments(ctx, collection, params)
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, doc := range gcCandidates(resp.Items, cutoff) {
+			count++
+			size += doc.DataSize
+		}
+		if len(resp.Items) < page {
+			return count, size, nil
+		}
+	}
+}
+
+// gcCandidates returns the soft-deleted documents deleted before cutoff.
+func gcCandidates(docs []clientpkg.Document, cutoff time.Time) []clientpkg.Document {
+	var candidates []clientpkg.Document
+	for _, doc := range docs {
+		if doc.DeletedAt != nil && doc.DeletedAt.Before(cutoff) {
+			candidates = append(candidates, doc)
+		}
+	}
+	return candidates
+}
+
+// writeGCLog appends the batch to the recovery log and flushes it so the
+// documents are on disk before they are purged.
+func writeGCLog(w *bufio.Writer, docs []clientpkg.Document, purgedAt time.Time) error {
+	for _, doc := range docs {
+		data := json.RawMessage(doc.Data)
+		if !json.Valid(data) {
+			encoded, err := json.Marshal(doc.Data)
+			if err != nil {
+				return err
+			}
+			data = encoded
+		}
+		line, err := json.Marshal(gcLogEntry{ID: doc.ID, Key: doc.Key, CreatedAt: doc.CreatedAt, DeletedAt: doc.DeletedAt, PurgedAt: purgedAt, Data: data})
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
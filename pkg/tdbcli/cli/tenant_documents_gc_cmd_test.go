@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestGCCandidates(t *testing.T) {
+	cutoff := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := cutoff.Add(-time.Hour)
+	recent := cutoff.Add(time.Hour)
+	docs := []clientpkg.Document{
+		{ID: "live"},
+		{ID: "old", DeletedAt: &old},
+		{ID: "recent", DeletedAt: &recent},
+	}
+	got := gcCandidates(docs, cutoff)
+	if len(got) != 1 || got[0].ID != "old" {
+		t.Fatalf("unexpected candidates: %+v", got)
+	}
+}
+
+func TestWriteGCLog(t *testing.T) {
+	deleted := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	purgedAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	docs := []clientpkg.Document{
+		{ID: "d1", Key: "k1", Data: `{"name":"Ada"}`, DeletedAt: &deleted},
+		{ID: "d2", Data: "not json", DeletedAt: &deleted},
+	}
+	var buf bytes.Buffer
+	if err := writeGCLog(bufio.NewWriter(&buf), docs, purgedAt); err != nil {
+		t.Fatalf("writeGCLog: %v", err)
+	}
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d", len(lines))
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(lines[0], &entry); err != nil {
+		t.Fatalf("decode log line: %v", err)
+	}
+	data, ok := entry["data"].(map[string]any)
+	if !ok || data["name"] != "Ada" || entry["key"] != "k1" {
+		t.Fatalf("unexpected log entry: %v", entry)
+	}
+	if err := json.Unmarshal(lines[1], &entry); err != nil || entry["data"] != "not json" {
+		t.Fatalf("expected invalid data kept as string, got %v (%v)", entry, err)
+	}
+}
+
+func TestDocumentsGCCommandPurgesOldDocuments(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	c := srv.TenantClient(t)
+	old := time.Now().UTC().Add(-200 * 24 * time.Hour)
+	srv.SetClock(func() time.Time { return old })
+	stale := srv.AddDocument("users", map[string]any{"id": "stale"})
+	if err := c.DeleteDocument(context.Background(), "users", stale.ID, ""); err != nil {
+		t.Fatalf("DeleteDocument: %v", err)
+	}
+	srv.SetClock(func() time.Time { return time.Now().UTC() })
+	recent := srv.AddDocument("users", map[string]any{"id": "recent"})
+	if err := c.DeleteDocument(context.Background(), "users", recent.ID, ""); err != nil {
+		t.Fatalf("DeleteDocument: %v", err)
+	}
+	srv.AddDocument("users", map[string]any{"id": "live"})
+
+	logPath := filepath.Join(t.TempDir(), "gc.jsonl")
+	if out, errOut, err := runCommand(t, srv, newTenantDocumentsGCCommand, "users", "--deleted-before", "90d", "--purge", "--confirm", "--log", logPath, "--page-size", "1"); err != nil {
+		t.Fatalf("gc failed: %v\n%s%s", err, out, errOut)
+	}
+
+	remaining := srv.Documents("users")
+	if len(remaining) != 2 {
+		t.Fatalf("expected 2 documents to remain, got %+v", remaining)
+	}
+	for _, doc := range remaining {
+		if doc.ID == stale.ID {
+			t.Fatalf("stale document was not purged")
+		}
+	}
+	logged, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(logged)), "\n"); len(lines) != 1 || !strings.Contains(lines[0], `"stale"`) {
+		t.Fatalf("unexpected recovery log: %s", logged)
+	}
+}
+
+func TestDocumentsGCSafeModeConfirmationCountsDocuments(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	c := srv.TenantClient(t)
+	srv.SetClock(func() time.Time { return time.Now().UTC().Add(-200 * 24 * time.Hour) })
+	for _, id := range []string{"a", "b", "c"} {
+		doc := srv.AddDocument("users", map[string]any{"id": id})
+		if err := c.DeleteDocument(context.Background(), "users", doc.ID, ""); err != nil {
+			t.Fatalf("DeleteDocument: %v", err)
+		}
+	}
+	srv.SetClock(func() time.Time { return time.Now().UTC() })
+	srv.AddDocument("users", map[string]any{"id": "live"})
+
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL, SafeMode: true}}
+	logPath := filepath.Join(t.TempDir(), "gc.jsonl")
+	_, errOut, err := runCommandWithEnv(t, env, newTenantDocumentsGCCommand, "users", "--purge", "--confirm", "--log", logPath, "--page-size", "2")
+	if err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("expected safe mode to refuse without --yes, got %v", err)
+	}
+	if !strings.Contains(errOut, "permanently purge 3 soft-deleted documents from users") {
+		t.Fatalf("expected the confirmation to count the documents:\n%s", errOut)
+	}
+	if got := len(srv.Documents("users")); got != 4 {
+		t.Fatalf("expected nothing purged before confirmation, %d documents remain", got)
+	}
+}
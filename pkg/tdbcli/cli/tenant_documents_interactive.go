@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const interactiveDoneOption = "(done)"
+
+// documentListSelection captures the filters, sort order, and projection
+// chosen through the interactive filter builder.
+type documentListSelection struct {
+	Filters    []string
+	Sort       string
+	Select     []string
+	SelectOnly bool
+}
+
+// promptDocumentListSelection walks the user through building filters, sort,
+// and projection for a collection using its schema (or sampled documents when
+// no schema is defined).
+func promptDocumentListSelection(ctx context.Context, tenantClient *clientpkg.TenantClient, collection, appID string) (documentListSelection, error) {
+	var selection documentListSelection
+	fields, err := interactiveCollectionFields(ctx, tenantClient, collection, appID)
+	if err != nil {
+		return selection, err
+	}
+
+	if len(fields) > 0 {
+		options := append([]string{interactiveDoneOption}, fields...)
+		for {
+			var field string
+			if err := survey.AskOne(&survey.Select{Message: "Filter on field:", Options: options}, &field); err != nil {
+				return selection, fmt.Errorf("filter selection cancelled or failed: %w", err)
+			}
+			if field == interactiveDoneOption {
+				break
+			}
+			var value string
+			if err := survey.AskOne(&survey.Input{Message: fmt.Sprintf("Value for %s:", field)}, &value, survey.WithValidator(survey.Required)); err != nil {
+				return selection, fmt.Errorf("filter value cancelled or failed: %w", err)
+			}
+			selection.Filters = append(selection.Filters, field+"="+strings.TrimSpace(value))
+		}
+	}
+
+	sortFields := []string{"created_at", "updated_at", "version", "id", "key", "key_numeric", "deleted_at"}
+	var sortField string
+	if err := survey.AskOne(&survey.Select{Message: "Sort by:", Options: sortFields, Default: "created_at"}, &sortField); err != nil {
+		return selection, fmt.Errorf("sort selection cancelled or failed: %w", err)
+	}
+	descending := true
+	if err := survey.AskOne(&survey.Confirm{Message: "Sort descending?", Default: true}, &descending); err != nil {
+		return selection, fmt.Errorf("sort direction cancelled or failed: %w", err)
+	}
+	selection.Sort = sortField
+	if descending {
+		selection.Sort = "-" + sortField
+	}
+
+	if len(fields) > 0 {
+		var projected []string
+		if err := survey.AskOne(&survey.MultiSelect{Message: "Fields to select (none for all):", Options: fields}, &projected); err != nil {
+			return selection, fmt.Errorf("projection selection cancelled or failed: %w", err)
+		}
+		selection.Select = projected
+		if len(projected) > 0 {
+			if err := survey.AskOne(&survey.Confirm{Message: "Omit metadata fields (--select-only)?", Default: false}, &selection.SelectOnly); err != nil {
+				return selection, fmt.Errorf("projection selection cancelled or failed: %w", err)
+			}
+		}
+	}
+	return selection, nil
+}
+
+func interactiveCollectionFields(ctx context.Context, tenantClient *clientpkg.TenantClient, collection, appID string) ([]string, error) {
+	col, err := tenantClient.GetCollection(ctx, collection, appID)
+	if err != nil {
+		return nil, err
+	}
+	fieldSet := extractSchemaProperties(col.SchemaJSON)
+	if len(fieldSet) == 0 {
+		inferred, err := inferDocumentFieldTypes(ctx, tenantClient, collection, appID, 20)
+		if err != nil {
+			return nil, err
+		}
+		fieldSet = make(map[string]struct{}, len(inferred))
+		for field := range inferred {
+			fieldSet[field] = struct{}{}
+		}
+	}
+	return filterableFields(fieldSet), nil
+}
+
+// filterableFields returns the sorted scalar-addressable fields, skipping array
+// element paths which cannot be used as f.<field> filters.
+func filterableFields(fieldSet map[string]struct{}) []string {
+	fields := make([]string, 0, len(fieldSet))
+	for field := range fieldSet {
+		if field == "" || strings.Contains(field, "[]") {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// buildDocumentsListCommandLine renders the non-interactive equivalent of an
+// interactive selection so users can learn the flag syntax.
+func buildDocumentsListCommandLine(collection string, limit int, selection documentListSelection) string {
+	parts := []string{"tdb", "tenant", "documents", "list", shellQuoteIfNeeded(collection)}
+	for _, filter := range selection.Filters {
+		parts = append(parts, "--filter", shellQuoteIfNeeded(filter))
+	}
+	if selection.Sort != "" && selection.Sort != "-created_at" {
+		parts = append(parts, "--sort", shellQuoteIfNeeded(selection.Sort))
+	}
+	if len(selection.Select) > 0 {
+		parts = append(parts, "--select", shellQuoteIfNeeded(strings.Join(selection.Select, ",")))
+		if selection.SelectOnly {
+			parts = append(parts, "--select-only")
+		}
+	}
+	if limit > 0 && limit != 50 {
+		parts = append(parts, "--limit", fmt.Sprintf("%d", limit))
+	}
+	return strings.Join(parts, " ")
+}
+
+func shellQuoteIfNeeded(value string) string {
+	if value == "" {
+		return "''"
+	}
+	if strings.IndexFunc(value, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_.,=/:@", r))
+	}) < 0 {
+		return value
+	}
+	return shellQuote(value)
+}
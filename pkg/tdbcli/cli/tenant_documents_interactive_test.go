@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildDocumentsListCommandLine(t *testing.T) {
+	selection := documentListSelection{
+		Filters:    []string{"status=active", "name=John Doe"},
+		Sort:       "updated_at",
+		Select:     []string{"name", "email"},
+		SelectOnly: true,
+	}
+	got := buildDocumentsListCommandLine("users", 25, selection)
+	want := "tdb tenant documents list users --filter status=active --filter 'name=John Doe' --sort updated_at --select name,email --select-only --limit 25"
+	if got != want {
+		t.Fatalf("unexpected command line:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestBuildDocumentsListCommandLine_Defaults(t *testing.T) {
+	got := buildDocumentsListCommandLine("users", 50, documentListSelection{Sort: "-created_at"})
+	if got != "tdb tenant documents list users" {
+		t.Fatalf("unexpected command line: %s", got)
+	}
+}
+
+func TestFilterableFields(t *testing.T) {
+	fields := filterableFields(map[string]struct{}{
+		"status":       {},
+		"address.city": {},
+		"tags[]":       {},
+		"":             {},
+	})
+	want := []string{"address.city", "status"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Fatalf("expected %v, got %v", want, fields)
+	}
+}
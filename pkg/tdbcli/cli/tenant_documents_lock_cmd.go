@@ -0,0 +1,293 @@
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// documentLockField is the data field holding a document lease. Locks are a
+// client-side convention: every job that coordinates on a document must take
+// the lock through these commands before writing.
+const documentLockField = "_lock"
+
+const documentLockPollInterval = time.Second
+
+// documentLock is the lease stored under documentLockField.
+type documentLock struct {
+	Owner      string    `json:"owner"`
+	Token      string    `json:"token"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+func (l *documentLock) expired(now time.Time) bool {
+	return l == nil || !now.Before(l.ExpiresAt)
+}
+
+// errDocumentLocked reports a live lease held by another owner.
+type errDocumentLocked struct {
+	lock *documentLock
+}
+
+func (e *errDocumentLocked) Error() string {
+	return fmt.Sprintf("document is locked by %s until %s", e.lock.Owner, e.lock.ExpiresAt.Format(time.RFC3339))
+}
+
+// readDocumentLock returns the lease stored in a document, or nil when the
+// document is unlocked.
+func readDocumentLock(doc *clientpkg.Document) (*documentLock, error) {
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(doc.Data), &data); err != nil {
+		return nil, fmt.Errorf("decode document %s: %w", doc.ID, err)
+	}
+	raw, ok := data[documentLockField]
+	if !ok || string(raw) == "null" {
+		return nil, nil
+	}
+	var lock documentLock
+	if err := json.Unmarshal(raw, &lock); err != nil {
+		return nil, fmt.Errorf("document %s has an unreadable %s field: %w", doc.ID, documentLockField, err)
+	}
+	return &lock, nil
+}
+
+func newDocumentLockToken() string {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("lk%d", time.Now().UnixNano())
+	}
+	return "lk_" + hex.EncodeToString(buf)
+}
+
+// defaultLockOwner names the owner as user@host. It is the same for every run
+// on a machine, so a later unlock or renew without --owner matches the lock.
+func defaultLockOwner() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	name := os.Getenv("USER")
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		name = current.Username
+	}
+	if name == "" {
+		name = "unknown"
+	}
+	return name + "@" + host
+}
+
+// documentLockAttempts bounds how often acquiring is retried when the document
+// changes between reading and writing the lease.
+const documentLockAttempts = 3
+
+// acquireDocumentLock takes or renews the lease on a document. The lease is
+// written with a conditional update on the version that was read, so when two
+// jobs race only the first write succeeds; the other reads the document again
+// and finds the new holder.
+func acquireDocumentLock(ctx context.Context, tenantClient *clientpkg.TenantClient, collection, id, appID, owner string, ttl time.Duration) (*documentLock, error) {
+	var err error
+	for attempt := 0; attempt < documentLockAttempts; attempt++ {
+		var lock *documentLock
+		lock, err = tryDocumentLock(ctx, tenantClient, collection, id, appID, owner, ttl)
+		var conflict *clientpkg.ConflictError
+		if !errors.As(err, &conflict) {
+			return lock, err
+		}
+	}
+	return nil, fmt.Errorf("document %s kept changing while acquiring the lock: %w", id, err)
+}
+
+func tryDocumentLock(ctx context.Context, tenantClient *clientpkg.TenantClient, collection, id, appID, owner string, ttl time.Duration) (*documentLock, error) {
+	doc, err := tenantClient.GetDocument(ctx, collection, id, appID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := readDocumentLock(doc)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if !current.expired(now) && current.Owner != owner {
+		return nil, &errDocumentLocked{lock: current}
+	}
+	lock := &documentLock{Owner: owner, Token: newDocumentLockToken(), AcquiredAt: now, ExpiresAt: now.Add(ttl)}
+	if !current.expired(now) {
+		lock.AcquiredAt = current.AcquiredAt
+	}
+	payload, err := json.Marshal(map[string]any{documentLockField: lock})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tenantClient.PatchDocumentIfVersion(ctx, collection, id, payload, doc.Version, appID); err != nil {
+		return nil, err
+	}
+	return lock, nil
+}
+
+func newTenantDocumentsLockCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var ttl time.Duration
+	var owner string
+	var wait time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "lock <collection> <id>",
+		Short: "Take a time-limited lease on a document",
+		Long: `Take a lease on a document so concurrent batch jobs can coordinate on it.
+
+The lease is stored in the document's "_lock" field as {owner, token,
+acquired_at, expires_at}. A lock held by another owner is respected until it
+expires; locking again with the same --owner renews it. The lease is written
+with a conditional update on the document version (If-Match), so when two jobs
+race only one of them succeeds.
+
+Without --owner the lock is taken as user@host, so a later renew or unlock from
+the same account and machine matches it. Give concurrent jobs on one machine
+their own --owner.
+
+Locks are advisory: they only protect documents from jobs that also use
+"tdb tenant documents lock". The command exits with status 4 when the document
+does not exist.`,
+		Example: `  # Hold a document for a minute while a CI job processes it
+  tdb tenant documents lock orders ord_123 --ttl 60s --owner ci-job
+
+  # Wait up to 5 minutes for another job to release the lock
+  tdb tenant documents lock orders ord_123 --owner nightly --wait 5m`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			collection := strings.TrimSpace(args[0])
+			id := strings.TrimSpace(args[1])
+			if collection == "" || id == "" {
+				return errors.New(tr("collection and document ID are required"))
+			}
+			if ttl <= 0 {
+				return errors.New("--ttl must be positive")
+			}
+			owner = strings.TrimSpace(owner)
+			if owner == "" {
+				owner = defaultLockOwner()
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			deadline := time.Now().Add(wait)
+			for {
+				lock, err := acquireDocumentLock(cmd.Context(), tenantClient, collection, id, auth.appID, owner, ttl)
+				var locked *errDocumentLocked
+				switch {
+				case err == nil:
+					return printJSON(cmd, lock)
+				case isNotFoundError(err):
+					return &ExitError{Code: ExitCodeNotFound, Err: err}
+				case !errors.As(err, &locked) || !time.Now().Add(documentLockPollInterval).Before(deadline):
+					return err
+				}
+				select {
+				case <-cmd.Context().Done():
+					return cmd.Context().Err()
+				case <-time.After(documentLockPollInterval):
+				}
+			}
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().DurationVar(&ttl, "ttl", time.Minute, "How long the lease is held before it expires")
+	cmd.Flags().StringVar(&owner, "owner", "", "Lock owner name (defaults to user@host)")
+	cmd.Flags().DurationVar(&wait, "wait", 0, "Keep retrying for this long while another owner holds the lock")
+	return cmd
+}
+
+func newTenantDocumentsUnlockCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var owner string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "unlock <collection> <id>",
+		Short: "Release a lease taken with documents lock",
+		Long: `Release a lease taken with "tdb tenant documents lock". Only the owner that took
+the lock may release it while it is live; use --force to break someone else's
+lock. Unlocking a document that is not locked, or whose lease already expired,
+succeeds without changes.`,
+		Example: `  tdb tenant documents unlock orders ord_123 --owner ci-job
+
+  # Break a stale lock left behind by a crashed job
+  tdb tenant documents unlock orders ord_123 --force`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			collection := strings.TrimSpace(args[0])
+			id := strings.TrimSpace(args[1])
+			if collection == "" || id == "" {
+				return errors.New(tr("collection and document ID are required"))
+			}
+			owner = strings.TrimSpace(owner)
+			if owner == "" && !force {
+				owner = defaultLockOwner()
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			doc, err := tenantClient.GetDocument(cmd.Context(), collection, id, auth.appID)
+			if err != nil {
+				if isNotFoundError(err) {
+					return &ExitError{Code: ExitCodeNotFound, Err: err}
+				}
+				return err
+			}
+			current, err := readDocumentLock(doc)
+			if err != nil {
+				return err
+			}
+			if current == nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "Document %s is not locked\n", id)
+				return nil
+			}
+			if !force && !current.expired(time.Now()) && current.Owner != owner {
+				return fmt.Errorf("%w (use --force to break it)", &errDocumentLocked{lock: current})
+			}
+			payload, err := json.Marshal(map[string]any{documentLockField: nil})
+			if err != nil {
+				return err
+			}
+			// Release only the lease that was checked above; if the document
+			// changed since, the lock may have passed to another owner.
+			if _, err := tenantClient.PatchDocumentIfVersion(cmd.Context(), collection, id, payload, doc.Version, auth.appID); err != nil {
+				var conflict *clientpkg.ConflictError
+				if errors.As(err, &conflict) {
+					return fmt.Errorf("document %s changed while unlocking; check the lock and retry: %w", id, err)
+				}
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Document %s unlocked (was held by %s)\n", id, current.Owner)
+			return nil
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().StringVar(&owner, "owner", "", "Lock owner name (defaults to user@host)")
+	cmd.Flags().BoolVar(&force, "force", false, "Release the lock even if another owner holds it")
+	return cmd
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestDocumentsLockAndUnlock(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	doc := srv.AddDocument("orders", map[string]any{"status": "pending"})
+
+	run := func(newCmd func(*Environment) *cobra.Command, args ...string) (string, error) {
+		t.Helper()
+		out, _, err := runCommand(t, srv, newCmd, args...)
+		return out, err
+	}
+
+	out, err := run(newTenantDocumentsLockCommand, "orders", doc.ID, "--ttl", "60s", "--owner", "ci-job")
+	if err != nil {
+		t.Fatalf("lock failed: %v", err)
+	}
+	var lock documentLock
+	if err := json.Unmarshal([]byte(out), &lock); err != nil || lock.Owner != "ci-job" || lock.Token == "" {
+		t.Fatalf("unexpected lock output %s (%v)", out, err)
+	}
+
+	if _, err := run(newTenantDocumentsLockCommand, "orders", doc.ID, "--owner", "other-job"); err == nil || !strings.Contains(err.Error(), "locked by ci-job") {
+		t.Fatalf("expected a second owner to be refused, got %v", err)
+	}
+	if _, err := run(newTenantDocumentsLockCommand, "orders", doc.ID, "--owner", "ci-job", "--ttl", "2m"); err != nil {
+		t.Fatalf("expected the owner to renew the lock, got %v", err)
+	}
+	if _, err := run(newTenantDocumentsUnlockCommand, "orders", doc.ID, "--owner", "other-job"); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected unlock by another owner to be refused, got %v", err)
+	}
+	if _, err := run(newTenantDocumentsUnlockCommand, "orders", doc.ID, "--owner", "ci-job"); err != nil {
+		t.Fatalf("unlock failed: %v", err)
+	}
+
+	stored, err := srv.TenantClient(t).GetDocument(context.Background(), "orders", doc.ID, "")
+	if err != nil {
+		t.Fatalf("get document: %v", err)
+	}
+	if current, err := readDocumentLock(stored); err != nil || current != nil {
+		t.Fatalf("expected the lock to be released, got %+v (%v)", current, err)
+	}
+	if !strings.Contains(stored.Data, `"status":"pending"`) {
+		t.Fatalf("expected the document data to be preserved, got %s", stored.Data)
+	}
+
+	_, err = run(newTenantDocumentsLockCommand, "orders", "missing", "--owner", "ci-job")
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != ExitCodeNotFound {
+		t.Fatalf("expected exit code %d for a missing document, got %v", ExitCodeNotFound, err)
+	}
+}
+
+func TestDocumentsLockLosesRaceToConcurrentWriter(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	doc := srv.AddDocument("orders", map[string]any{"status": "pending"})
+	racer := srv.TenantClient(t)
+
+	// Another job takes the lock between our read and our conditional write.
+	race := true
+	routes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if race && r.Method == http.MethodPatch && r.Header.Get("If-Match") != "" {
+			race = false
+			lease := documentLock{Owner: "racer", Token: "lk_racer", AcquiredAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}
+			payload, _ := json.Marshal(map[string]any{documentLockField: lease})
+			if _, err := racer.PatchDocument(r.Context(), "orders", doc.ID, payload, ""); err != nil {
+				t.Errorf("racing patch: %v", err)
+			}
+		}
+		routes.ServeHTTP(w, r)
+	})
+
+	run := func(newCmd func(*Environment) *cobra.Command, args ...string) error {
+		_, _, err := runCommand(t, srv, newCmd, args...)
+		return err
+	}
+	if err := run(newTenantDocumentsLockCommand, "orders", doc.ID, "--owner", "ci-job"); err == nil || !strings.Contains(err.Error(), "locked by racer") {
+		t.Fatalf("expected the lock to go to the first writer, got %v", err)
+	}
+
+	race = true
+	if err := run(newTenantDocumentsUnlockCommand, "orders", doc.ID, "--force"); err == nil || !strings.Contains(err.Error(), "changed while unlocking") {
+		t.Fatalf("expected unlocking a changed document to be refused, got %v", err)
+	}
+	stored, err := racer.GetDocument(context.Background(), "orders", doc.ID, "")
+	if err != nil {
+		t.Fatalf("get document: %v", err)
+	}
+	if current, _ := readDocumentLock(stored); current == nil || current.Owner != "racer" {
+		t.Fatalf("expected the racer to keep the lock, got %+v", current)
+	}
+}
+
+func TestDocumentsUnlockMatchesDefaultOwnerAcrossRuns(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	doc := srv.AddDocument("orders", map[string]any{"status": "pending"})
+
+	out, _, err := runCommand(t, srv, newTenantDocumentsLockCommand, "orders", doc.ID)
+	if err != nil {
+		t.Fatalf("lock failed: %v", err)
+	}
+	var lock documentLock
+	if err := json.Unmarshal([]byte(out), &lock); err != nil || lock.Owner != defaultLockOwner() {
+		t.Fatalf("expected the lock to be owned by %s, got %s (%v)", defaultLockOwner(), out, err)
+	}
+	if _, _, err := runCommand(t, srv, newTenantDocumentsLockCommand, "orders", doc.ID, "--ttl", "2m"); err != nil {
+		t.Fatalf("expected a later run to renew the lock, got %v", err)
+	}
+	if _, _, err := runCommand(t, srv, newTenantDocumentsUnlockCommand, "orders", doc.ID); err != nil {
+		t.Fatalf("expected a later run to release the lock, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+func newTenantDocumentsHeadCommand(env *Environment) *cobra.Command {
+	cmd := newTenantDocumentsPeekCommand(env, false)
+	cmd.Use = "head <collection>"
+	cmd.Short = "Show the oldest documents in a collection"
+	cmd.Long = `Print the first documents of a collection by creation time, oldest first, with
+their data payloads pretty-printed. A shorthand for list with an ascending
+created_at sort.`
+	cmd.Example = `  # Show the 10 oldest documents
+  tdb tenant documents head users
+
+  # Show the first 20 active users
+  tdb tenant documents head users -n 20 --filter status=active`
+	return cmd
+}
+
+func newTenantDocumentsTailCommand(env *Environment) *cobra.Command {
+	cmd := newTenantDocumentsPeekCommand(env, true)
+	cmd.Use = "tail <collection>"
+	cmd.Short = "Show the newest documents in a collection"
+	cmd.Long = `Print the most recently created documents of a collection with their data
+payloads pretty-printed. Like tail(1), the newest document is printed last.`
+	cmd.Example = `  # Show the 10 newest documents
+  tdb tenant documents tail events
+
+  # Show the last 5 documents as a JSON array
+  tdb tenant documents tail events -n 5 --raw`
+	return cmd
+}
+
+// newTenantDocumentsPeekCommand builds head (newest false) and tail (newest
+// true). Both print in ascending creation order.
+func newTenantDocumentsPeekCommand(env *Environment, newest bool) *cobra.Command {
+	var auth authFlags
+	var n int
+	var filters []string
+	var includeDeleted bool
+	var raw bool
+
+	cmd := &cobra.Command{
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			collection := strings.TrimSpace(args[0])
+			if collection == "" {
+				return errors.New(tr("collection name cannot be empty"))
+			}
+			if n <= 0 {
+				return errors.New("-n must be greater than zero")
+			}
+			filterMap, err := parseFilterFlags(filters)
+			if err != nil {
+				return err
+			}
+			params := clientpkg.ListDocumentsParams{AppID: auth.appID, Limit: n, IncludeDeleted: includeDeleted, Filters: filterMap, Sort: []string{"created_at", "id"}}
+			if newest {
+				params.Sort = []string{"-created_at", "-id"}
+			}
+			resp, err := tenantClient.ListDocuments(cmd.Context(), collection, params)
+			if err != nil {
+				return err
+			}
+			docs := resp.Items
+			if newest {
+				slices.Reverse(docs)
+			}
+			return printDocumentPayloads(cmd, docs, raw)
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().IntVarP(&n, "lines", "n", 10, "Number of documents to show")
+	cmd.Flags().StringArrayVar(&filters, "filter", nil, "Filter predicate field=value (repeatable)")
+	cmd.Flags().BoolVar(&includeDeleted, "include-deleted", false, "Include soft-deleted documents")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the payloads as a JSON array")
+	return cmd
+}
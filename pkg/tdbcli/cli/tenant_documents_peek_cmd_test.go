@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestDocumentsHeadAndTail(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= 4; i++ {
+		created := base.Add(time.Duration(i) * time.Hour)
+		srv.SetClock(func() time.Time { return created })
+		srv.AddDocument("events", map[string]any{"seq": i})
+	}
+
+	run := func(newCmd func(*Environment) *cobra.Command, args ...string) string {
+		t.Helper()
+		out, errOut, err := runCommand(t, srv, newCmd, append([]string{"events", "--raw"}, args...)...)
+		if err != nil {
+			t.Fatalf("command failed: %v\n%s%s", err, out, errOut)
+		}
+		return strings.Join(strings.Fields(out), "")
+	}
+
+	if got := run(newTenantDocumentsHeadCommand, "-n", "2"); got != `[{"seq":1},{"seq":2}]` {
+		t.Fatalf("unexpected head output %s", got)
+	}
+	if got := run(newTenantDocumentsTailCommand, "-n", "2"); got != `[{"seq":3},{"seq":4}]` {
+		t.Fatalf("unexpected tail output %s", got)
+	}
+	if got := run(newTenantDocumentsTailCommand, "--filter", "seq=1"); got != fmt.Sprintf(`[{"seq":%d}]`, 1) {
+		t.Fatalf("unexpected filtered tail output %s", got)
+	}
+}
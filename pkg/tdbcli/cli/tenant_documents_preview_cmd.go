@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+func newTenantDocumentsPreviewCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var byKey bool
+	var maxField int
+	var maxItems int
+	var field string
+
+	cmd := &cobra.Command{
+		Use:   "preview <collection> <id_or_key>",
+		Short: "Pretty-print a document with long fields truncated",
+		Long: `Print a document without flooding the terminal: strings longer than
+--max-field characters and arrays with more than --max-items elements are cut
+short and annotated with their full size. The output is still valid JSON.
+
+Use --field to print one field in full instead (strings are written as-is, so
+they can be piped into other tools).`,
+		Example: `  # Skim a blob-heavy document
+  tdb tenant documents preview attachments att_123 --max-field 200
+
+  # Print the full payload field
+  tdb tenant documents preview attachments att_123 --field data.payload > payload.txt`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if maxField < 0 || maxItems < 0 {
+				return errors.New("--max-field and --max-items cannot be negative")
+			}
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			collection := strings.TrimSpace(args[0])
+			id := strings.TrimSpace(args[1])
+			if collection == "" || id == "" {
+				return errors.New(tr("collection and document ID are required"))
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			var doc *clientpkg.Document
+			if byKey {
+				doc, err = tenantClient.GetDocumentByPrimaryKey(cmd.Context(), collection, id, auth.appID)
+			} else {
+				doc, err = tenantClient.GetDocument(cmd.Context(), collection, id, auth.appID)
+			}
+			if err != nil {
+				if isNotFoundError(err) {
+					return &ExitError{Code: ExitCodeNotFound, Err: fmt.Errorf("document %s not found in %s", id, collection)}
+				}
+				return err
+			}
+			data, err := decodeJSONPreservingNumbers([]byte(doc.Data))
+			if err != nil {
+				return fmt.Errorf("decode document data: %w", err)
+			}
+
+			if path := strings.TrimSpace(field); path != "" {
+				path = strings.TrimPrefix(path, "data.")
+				obj, _ := data.(map[string]any)
+				value := lookupExpandPath(obj, path)
+				if value == nil {
+					return fmt.Errorf("field %s not found in document %s", field, doc.ID)
+				}
+				if text, ok := value.(string); ok {
+					fmt.Fprintln(cmd.OutOrStdout(), text)
+					return nil
+				}
+				return printJSON(cmd, value)
+			}
+
+			size := doc.DataSize
+			if size <= 0 {
+				size = int64(len(doc.Data))
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "# %s (key: %s, version: %d, data: %s)\n", doc.ID, optional(&doc.Key), doc.Version, formatBytes(size))
+			return printJSON(cmd, truncatePreviewValue(data, maxField, maxItems))
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().BoolVar(&byKey, "by-key", false, "Treat the argument as a primary key value instead of a document ID")
+	cmd.Flags().IntVar(&maxField, "max-field", 200, "Truncate strings longer than this many characters (0 disables)")
+	cmd.Flags().IntVar(&maxItems, "max-items", 10, "Truncate arrays with more than this many elements (0 disables)")
+	cmd.Flags().StringVar(&field, "field", "", "Print only this field in full, e.g. data.payload (dotted path)")
+	return cmd
+}
+
+// truncatePreviewValue shortens long strings and arrays anywhere in value,
+// appending a marker with the original size so nothing is silently hidden.
+func truncatePreviewValue(value any, maxField, maxItems int) any {
+	switch typed := value.(type) {
+	case string:
+		runes := []rune(typed)
+		if maxField <= 0 || len(runes) <= maxField {
+			return typed
+		}
+		return fmt.Sprintf("%s… [truncated: %d chars, %s]", string(runes[:maxField]), len(runes), formatBytes(int64(len(typed))))
+	case []any:
+		limit := len(typed)
+		if maxItems > 0 && limit > maxItems {
+			limit = maxItems
+		}
+		out := make([]any, 0, limit+1)
+		for _, item := range typed[:limit] {
+			out = append(out, truncatePreviewValue(item, maxField, maxItems))
+		}
+		if limit < len(typed) {
+			encoded, _ := json.Marshal(typed)
+			out = append(out, fmt.Sprintf("… [truncated: %d more items, %d total, %s]", len(typed)-limit, len(typed), formatBytes(int64(len(encoded)))))
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(typed))
+		for key, item := range typed {
+			out[key] = truncatePreviewValue(item, maxField, maxItems)
+		}
+		return out
+	}
+	return value
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestDocumentsPreviewTruncatesLongFields(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "attachments"})
+	payload := strings.Repeat("x", 5000)
+	items := make([]any, 30)
+	for i := range items {
+		items[i] = i
+	}
+	doc := srv.AddDocument("attachments", map[string]any{"name": "report.pdf", "blob": map[string]any{"payload": payload}, "chunks": items})
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+
+	run := func(args ...string) string {
+		t.Helper()
+		out, _, err := runCommandWithEnv(t, env, newTenantDocumentsPreviewCommand, append([]string{"attachments", doc.ID}, args...)...)
+		if err != nil {
+			t.Fatalf("preview failed: %v", err)
+		}
+		return out
+	}
+
+	out := run("--max-field", "20", "--max-items", "3")
+	header, body, _ := strings.Cut(out, "\n")
+	if !strings.HasPrefix(header, "# "+doc.ID) {
+		t.Fatalf("unexpected header %q", header)
+	}
+	var preview map[string]any
+	if err := json.Unmarshal([]byte(body), &preview); err != nil {
+		t.Fatalf("preview should stay valid JSON: %v\n%s", err, body)
+	}
+	blob := preview["blob"].(map[string]any)["payload"].(string)
+	if !strings.HasPrefix(blob, strings.Repeat("x", 20)+"… [truncated: 5000 chars") {
+		t.Fatalf("unexpected truncated payload %q", blob)
+	}
+	chunks := preview["chunks"].([]any)
+	if len(chunks) != 4 || !strings.Contains(chunks[3].(string), "27 more items, 30 total") {
+		t.Fatalf("unexpected truncated array %v", chunks)
+	}
+	if preview["name"] != "report.pdf" {
+		t.Fatalf("short fields should be untouched, got %v", preview["name"])
+	}
+
+	if full := run("--field", "data.blob.payload"); strings.TrimSpace(full) != payload {
+		t.Fatalf("expected full field, got %d bytes", len(full))
+	}
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+func newTenantDocumentsSampleCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var n int
+	var random bool
+	var seed int64
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "sample <collection>",
+		Short: "Print a small sample of document payloads",
+		Long: `Fetch a handful of documents and pretty-print their data payloads to get a quick
+feel for the shape of an unfamiliar collection.
+
+By default the most recently created documents are shown. With --random, documents
+are picked from random offsets across the whole collection.`,
+		Example: `  # Show the 10 newest documents
+  tdb tenant documents sample users
+
+  # Show 5 documents from random positions (reproducible with --seed)
+  tdb tenant documents sample events --n 5 --random --seed 42`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			collection := strings.TrimSpace(args[0])
+			if collection == "" {
+				return errors.New(tr("collection name cannot be empty"))
+			}
+			if n <= 0 {
+				return errors.New("--n must be greater than zero")
+			}
+
+			var docs []clientpkg.Document
+			if random {
+				total, err := tenantClient.CountDocuments(cmd.Context(), collection, auth.appID)
+				if err != nil {
+					return err
+				}
+				if !cmd.Flags().Changed("seed") {
+					seed = time.Now().UnixNano()
+				}
+				for _, offset := range randomSampleOffsets(total, n, rand.New(rand.NewSource(seed))) {
+					resp, err := tenantClient.ListDocuments(cmd.Context(), collection, clientpkg.ListDocumentsParams{AppID: auth.appID, Limit: 1, Offset: offset})
+					if err != nil {
+						return err
+					}
+					docs = append(docs, resp.Items...)
+				}
+			} else {
+				resp, err := tenantClient.ListDocuments(cmd.Context(), collection, clientpkg.ListDocumentsParams{AppID: auth.appID, Limit: n})
+				if err != nil {
+					return err
+				}
+				docs = resp.Items
+			}
+
+			return printDocumentPayloads(cmd, docs, raw)
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().IntVar(&n, "n", 10, "Number of documents to sample")
+	cmd.Flags().BoolVar(&random, "random", false, "Pick documents from random offsets instead of the newest")
+	cmd.Flags().Int64Var(&seed, "seed", 0, "Seed for --random to make samples reproducible")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the sampled payloads as a JSON array")
+	return cmd
+}
+
+// printDocumentPayloads pretty-prints each document's data under a short
+// header, or the payloads as one JSON array when raw is set.
+func printDocumentPayloads(cmd *cobra.Command, docs []clientpkg.Document, raw bool) error {
+	if raw {
+		payloads := make([]any, 0, len(docs))
+		for _, doc := range docs {
+			payloads = append(payloads, jsonStringToInterface(doc.Data))
+		}
+		return printJSON(cmd, payloads)
+	}
+	if len(docs) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), tr("No documents found"))
+		return nil
+	}
+	for i, doc := range docs {
+		if i > 0 {
+			fmt.Fprintln(cmd.OutOrStdout())
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "# %s (key: %s, created: %s, updated: %s)\n", doc.ID, optional(&doc.Key), formatTime(doc.CreatedAt), formatTime(doc.UpdatedAt))
+		if err := printJSON(cmd, jsonStringToInterface(doc.Data)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// randomSampleOffsets picks up to n distinct offsets in [0, total) in ascending order.
+func randomSampleOffsets(total int64, n int, rng *rand.Rand) []int {
+	if total <= 0 || n <= 0 {
+		return nil
+	}
+	if int64(n) >= total {
+		offsets := make([]int, total)
+		for i := range offsets {
+			offsets[i] = i
+		}
+		return offsets
+	}
+	picked := make(map[int]struct{}, n)
+	offsets := make([]int, 0, n)
+	for len(offsets) < n {
+		offset := int(rng.Int63n(total))
+		if _, ok := picked[offset]; ok {
+			continue
+		}
+		picked[offset] = struct{}{}
+		offsets = append(offsets, offset)
+	}
+	sort.Ints(offsets)
+	return offsets
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestRandomSampleOffsets(t *testing.T) {
+	offsets := randomSampleOffsets(100, 5, rand.New(rand.NewSource(1)))
+	if len(offsets) != 5 {
+		t.Fatalf("expected 5 offsets, got %d", len(offsets))
+	}
+	seen := map[int]struct{}{}
+	for i, offset := range offsets {
+		if offset < 0 || offset >= 100 {
+			t.Fatalf("offset %d out of range", offset)
+		}
+		if _, ok := seen[offset]; ok {
+			t.Fatalf("duplicate offset %d", offset)
+		}
+		seen[offset] = struct{}{}
+		if i > 0 && offsets[i-1] > offset {
+			t.Fatalf("offsets not sorted: %v", offsets)
+		}
+	}
+	again := randomSampleOffsets(100, 5, rand.New(rand.NewSource(1)))
+	if !reflect.DeepEqual(offsets, again) {
+		t.Fatalf("expected same seed to produce same offsets, got %v and %v", offsets, again)
+	}
+}
+
+func TestRandomSampleOffsets_SmallCollection(t *testing.T) {
+	offsets := randomSampleOffsets(3, 10, rand.New(rand.NewSource(1)))
+	if !reflect.DeepEqual(offsets, []int{0, 1, 2}) {
+		t.Fatalf("expected all offsets, got %v", offsets)
+	}
+	if got := randomSampleOffsets(0, 10, rand.New(rand.NewSource(1))); got != nil {
+		t.Fatalf("expected nil for empty collection, got %v", got)
+	}
+}
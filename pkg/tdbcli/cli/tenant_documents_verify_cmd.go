@@ -0,0 +1,483 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	canonicalpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/canonical"
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	versionpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/version"
+)
+
+const documentChecksumsKind = "tdb.document-checksums"
+
+// documentChecksums is the manifest written by 'documents verify --write': the
+// SHA-256 of every document's canonical data, keyed by document ID.
+type documentChecksums struct {
+	Kind       string            `json:"kind"`
+	Source     lineageLocation   `json:"source"`
+	Algorithm  string            `json:"algorithm"`
+	CreatedAt  time.Time         `json:"created_at"`
+	Documents  map[string]string `json:"documents"`
+	CLIVersion string            `json:"cli_version,omitempty"`
+}
+
+// documentVerifyReport is the outcome of comparing live documents with a
+// checksum manifest. Extra is only filled when the whole collection is checked.
+type documentVerifyReport struct {
+	Collection string   `json:"collection"`
+	Manifest   string   `json:"manifest"`
+	Total      int      `json:"total"`
+	Checked    int      `json:"checked"`
+	Sampled    bool     `json:"sampled"`
+	Matched    int      `json:"matched"`
+	Mismatched []string `json:"mismatched"`
+	Missing    []string `json:"missing"`
+	Extra      []string `json:"extra"`
+	Failed     []string `json:"failed"`
+}
+
+func newTenantDocumentsVerifyCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var manifestPath string
+	var write bool
+	var concurrency int
+	var sample string
+	var seed int64
+	var show int
+	var raw bool
+	stats := newOperationStats()
+
+	cmd := &cobra.Command{
+		Use:   "verify <collection>",
+		Short: "Compare live documents with a checksum manifest",
+		Long: `Check a collection against a checksum manifest holding the SHA-256 of every
+document's canonical data.
+
+With --write, the collection is read once and the manifest is created, e.g.
+right after a migration or restore. Without it, every document listed in the
+manifest is re-fetched (--concurrency at a time) and reported as mismatched
+when its data changed or missing when it was deleted. The collection is
+then listed to report extra documents that the manifest does not know.
+
+For very large collections, --sample checks a random subset of the manifest
+(5% or a number of documents, reproducible with --seed); extra documents are
+not looked for in that mode. The command fails when any document mismatched,
+is missing or extra, or could not be fetched.
+
+--manifest also accepts the lineage manifest of 'documents export --manifest'
+or the <file>.sha256.json sidecar of 'documents export --checksum-every'. The
+export is checked against it first, then its records are hashed; export with
+--out and --include-meta so that each record carries its document ID.`,
+		Example: `  # Record the state of a collection
+  tdb tenant documents verify users --manifest users.checksums.json --write
+
+  # Later: re-fetch every document and compare
+  tdb tenant documents verify users --manifest users.checksums.json --concurrency 8
+
+  # Spot-check 5% of a huge collection
+  tdb tenant documents verify events --manifest events.checksums.json --sample 5%
+
+  # Compare the collection with a backup taken by export
+  tdb tenant documents verify users --manifest users.jsonl.sha256.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			collection := strings.TrimSpace(args[0])
+			if collection == "" {
+				return errors.New(tr("collection name cannot be empty"))
+			}
+			path := strings.TrimSpace(manifestPath)
+			if path == "" {
+				return errors.New("--manifest is required")
+			}
+			if concurrency < 1 {
+				return errors.New("--concurrency must be at least 1")
+			}
+			if write && strings.TrimSpace(sample) != "" {
+				return errors.New("--sample cannot be combined with --write")
+			}
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, tenantID, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+
+			if write {
+				manifest := documentChecksums{
+					Kind:       documentChecksumsKind,
+					Source:     lineageLocation{Endpoint: strings.TrimSpace(envCtx.Config.Endpoint), TenantID: tenantID, AppID: auth.appID, Collection: collection},
+					Algorithm:  "sha256",
+					CreatedAt:  time.Now().UTC(),
+					Documents:  map[string]string{},
+					CLIVersion: versionpkg.Display(),
+				}
+				tracker := startProgress(cmd, "checksum", 0)
+				docs := tenantClient.DocumentsIterator(cmd.Context(), collection, clientpkg.ListDocumentsParams{AppID: auth.appID})
+				for docs.Next() {
+					doc := docs.Document()
+					manifest.Documents[doc.ID] = documentContentHash(doc.Data)
+					tracker.Item()
+				}
+				if err := docs.Err(); err != nil {
+					tracker.Done(err)
+					return err
+				}
+				tracker.Done(nil)
+				stats.count("documents", len(manifest.Documents))
+				if err := writeJSONFileAtomic(path, &manifest); err != nil {
+					return fmt.Errorf("write checksum manifest: %w", err)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Wrote checksums of %d documents in %s to %s\n", len(manifest.Documents), collection, path)
+				return nil
+			}
+
+			manifest, err := loadDocumentChecksums(path)
+			if err != nil {
+				return err
+			}
+			if manifest.Source.Collection != "" && manifest.Source.Collection != collection {
+				return fmt.Errorf("%s was written for collection %q, not %q", path, manifest.Source.Collection, collection)
+			}
+			ids := make([]string, 0, len(manifest.Documents))
+			for id := range manifest.Documents {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			report := documentVerifyReport{Collection: collection, Manifest: path, Total: len(ids), Mismatched: []string{}, Missing: []string{}, Extra: []string{}, Failed: []string{}}
+			if strings.TrimSpace(sample) != "" {
+				n, err := parseVerifySample(sample, len(ids))
+				if err != nil {
+					return err
+				}
+				if !cmd.Flags().Changed("seed") {
+					seed = time.Now().UnixNano()
+				}
+				picked := make([]string, 0, n)
+				for _, offset := range randomSampleOffsets(int64(len(ids)), n, rand.New(rand.NewSource(seed))) {
+					picked = append(picked, ids[offset])
+				}
+				ids = picked
+				report.Sampled = true
+			}
+			report.Checked = len(ids)
+
+			outcomes := make([]string, len(ids))
+			errs := make([]error, len(ids))
+			tracker := startProgress(cmd, "verify", int64(len(ids)))
+			sem := make(chan struct{}, concurrency)
+			var wg sync.WaitGroup
+			for i, id := range ids {
+				wg.Add(1)
+				sem <- struct{}{}
+				go func() {
+					defer wg.Done()
+					defer func() { <-sem }()
+					defer tracker.Item()
+					doc, err := tenantClient.GetDocument(cmd.Context(), collection, id, auth.appID)
+					switch {
+					case isNotFoundError(err):
+						outcomes[i] = "missing"
+					case err != nil:
+						outcomes[i], errs[i] = "failed", err
+					case doc.DeletedAt != nil:
+						outcomes[i] = "missing"
+					case documentContentHash(doc.Data) != manifest.Documents[id]:
+						outcomes[i] = "mismatched"
+					default:
+						outcomes[i] = "matched"
+					}
+				}()
+			}
+			wg.Wait()
+			for i, id := range ids {
+				switch outcomes[i] {
+				case "matched":
+					report.Matched++
+				case "mismatched":
+					report.Mismatched = append(report.Mismatched, id)
+				case "missing":
+					report.Missing = append(report.Missing, id)
+				default:
+					stats.errorf(cmd.ErrOrStderr(), "fetch %s failed: %v\n", id, errs[i])
+					report.Failed = append(report.Failed, id)
+				}
+			}
+
+			if !report.Sampled {
+				params := clientpkg.ListDocumentsParams{AppID: auth.appID, SelectFields: []string{"id"}}
+				docs := tenantClient.DocumentsIterator(cmd.Context(), collection, params)
+				for docs.Next() {
+					if id := docs.Document().ID; manifest.Documents[id] == "" {
+						report.Extra = append(report.Extra, id)
+					}
+				}
+				if err := docs.Err(); err != nil {
+					tracker.Done(err)
+					return fmt.Errorf("list documents for extra check: %w", err)
+				}
+				sort.Strings(report.Extra)
+			}
+			tracker.Done(nil)
+
+			for name, value := range map[string]int{"checked": report.Checked, "matched": report.Matched, "mismatched": len(report.Mismatched), "missing": len(report.Missing), "extra": len(report.Extra), "failed": len(report.Failed)} {
+				stats.count(name, value)
+			}
+			if raw {
+				if err := printJSON(cmd, report); err != nil {
+					return err
+				}
+			} else {
+				renderDocumentVerifyReport(cmd, report, show)
+			}
+			if problems := len(report.Mismatched) + len(report.Missing) + len(report.Extra) + len(report.Failed); problems > 0 {
+				return fmt.Errorf("verification failed: %d mismatched, %d missing, %d extra, %d failed", len(report.Mismatched), len(report.Missing), len(report.Extra), len(report.Failed))
+			}
+			return nil
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().StringVar(&manifestPath, "manifest", "", "Checksum manifest to verify against (or to create with --write)")
+	cmd.Flags().BoolVar(&write, "write", false, "Read the collection and write the checksum manifest instead of verifying")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 8, "Documents fetched in parallel")
+	cmd.Flags().StringVar(&sample, "sample", "", "Check a random subset of the manifest: a percentage (5%) or a number of documents")
+	cmd.Flags().Int64Var(&seed, "seed", 0, "Seed for --sample to make spot checks reproducible")
+	cmd.Flags().IntVar(&show, "show", 20, "Document IDs listed per problem (0 for all)")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the verification report as JSON")
+	bindStatsJSON(cmd, stats)
+	return cmd
+}
+
+// documentContentHash hashes a document's data in canonical form, so key
+// order and whitespace do not count as changes.
+func documentContentHash(data string) string {
+	raw := []byte(data)
+	if canonical, err := canonicalpkg.Canonicalize(raw); err == nil {
+		raw = canonical
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// parseVerifySample turns --sample (5%, 0.5% or 200) into a document count of
+// at least one and at most total.
+func parseVerifySample(raw string, total int) (int, error) {
+	trimmed := strings.TrimSpace(raw)
+	var n int
+	if percent, ok := strings.CutSuffix(trimmed, "%"); ok {
+		value, err := strconv.ParseFloat(strings.TrimSpace(percent), 64)
+		if err != nil || value <= 0 || value > 100 {
+			return 0, fmt.Errorf("invalid --sample %q: use a percentage between 0 and 100 or a document count", raw)
+		}
+		n = int(math.Ceil(float64(total) * value / 100))
+	} else {
+		value, err := strconv.Atoi(trimmed)
+		if err != nil || value <= 0 {
+			return 0, fmt.Errorf("invalid --sample %q: use a percentage between 0 and 100 or a document count", raw)
+		}
+		n = value
+	}
+	return max(1, min(n, total)), nil
+}
+
+// loadDocumentChecksums reads the manifest to verify against. Besides the
+// file written by --write, it accepts a lineage manifest from 'documents
+// export --manifest' and a .sha256.json sidecar from 'documents export
+// --checksum-every': the export they describe is checked against them, then
+// its records are hashed.
+func loadDocumentChecksums(path string) (*documentChecksums, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read checksum manifest: %w", err)
+	}
+	var probe struct {
+		Kind     string `json:"kind"`
+		Interval int    `json:"interval"`
+	}
+	if err := json.Unmarshal(raw, &probe); err != nil {
+		return nil, fmt.Errorf("decode checksum manifest %s: %w", path, err)
+	}
+	switch {
+	case probe.Kind == lineageManifestKind:
+		return lineageDocumentChecksums(path)
+	case probe.Kind == "" && probe.Interval > 0:
+		return sidecarDocumentChecksums(path)
+	}
+	var manifest documentChecksums
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return nil, fmt.Errorf("decode checksum manifest %s: %w", path, err)
+	}
+	if manifest.Kind != documentChecksumsKind || !strings.EqualFold(manifest.Algorithm, "sha256") {
+		return nil, fmt.Errorf("%s is not a document checksum manifest (kind %q, algorithm %q)", path, manifest.Kind, manifest.Algorithm)
+	}
+	return &manifest, nil
+}
+
+// lineageDocumentChecksums hashes the documents of the export described by
+// the lineage manifest at path, or of each of its parts for a split export.
+func lineageDocumentChecksums(path string) (*documentChecksums, error) {
+	manifest, err := loadLineageManifest(path)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(manifest.Output) == "" {
+		return nil, fmt.Errorf("lineage manifest %s describes an export to stdout; verify against a copy exported with --out", path)
+	}
+	files := []string{manifest.Output}
+	if len(manifest.Parts) > 0 {
+		files = files[:0]
+		for _, part := range manifest.Parts {
+			files = append(files, filepath.Join(filepath.Dir(manifest.Output), part.File))
+		}
+	}
+	checksums := &documentChecksums{Kind: documentChecksumsKind, Source: manifest.Source, Algorithm: "sha256", CreatedAt: manifest.FinishedAt, Documents: map[string]string{}}
+	for _, file := range files {
+		content, err := readExportFile(file)
+		if err != nil {
+			return nil, err
+		}
+		records, err := decodeExportRecords(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if _, err := verifyLineage(manifest, file, content, len(records)); err != nil {
+			return nil, fmt.Errorf("%s failed verification against lineage manifest %s: %w", file, path, err)
+		}
+		if err := addExportRecordChecksums(checksums, records); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return checksums, nil
+}
+
+// sidecarDocumentChecksums hashes the documents of the export next to the
+// checksum sidecar at path.
+func sidecarDocumentChecksums(path string) (*documentChecksums, error) {
+	file, ok := strings.CutSuffix(path, ".sha256.json")
+	if !ok {
+		return nil, fmt.Errorf("%s looks like an export checksum sidecar but is not named <export>.sha256.json", path)
+	}
+	sums, _, err := loadExportChecksums(file, path)
+	if err != nil {
+		return nil, err
+	}
+	content, err := readExportFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := sums.verify(content); err != nil {
+		return nil, fmt.Errorf("%s failed verification against %s: %w", file, path, err)
+	}
+	records, err := decodeExportRecords(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	checksums := &documentChecksums{Kind: documentChecksumsKind, Source: lineageLocation{Collection: sums.Collection}, Algorithm: "sha256", CreatedAt: sums.CreatedAt, Documents: map[string]string{}}
+	if err := addExportRecordChecksums(checksums, records); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return checksums, nil
+}
+
+// readExportFile reads an export as it was written, decompressing .gz and
+// .zst files.
+func readExportFile(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open export: %w", err)
+	}
+	defer file.Close()
+	return readPayloadSource(path, file)
+}
+
+// decodeExportRecords splits an export into its records: a JSON array or one
+// JSON object per line.
+func decodeExportRecords(content []byte) ([]json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(content)
+	var records []json.RawMessage
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &records); err != nil {
+			return nil, fmt.Errorf("decode exported array: %w", err)
+		}
+		return records, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	for {
+		var record json.RawMessage
+		if err := dec.Decode(&record); err == io.EOF {
+			return records, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("decode exported record %d: %w", len(records)+1, err)
+		}
+		records = append(records, record)
+	}
+}
+
+// addExportRecordChecksums hashes each record's data under its document ID.
+// Only exports made with --include-meta carry the IDs.
+func addExportRecordChecksums(checksums *documentChecksums, records []json.RawMessage) error {
+	for i, raw := range records {
+		var record struct {
+			ID        string          `json:"id"`
+			Data      json.RawMessage `json:"data"`
+			DeletedAt string          `json:"deleted_at"`
+		}
+		if err := json.Unmarshal(raw, &record); err != nil || record.ID == "" || record.Data == nil {
+			return fmt.Errorf("record %d has no document id and data; export with --include-meta to verify against it", i+1)
+		}
+		if record.DeletedAt != "" {
+			continue
+		}
+		checksums.Documents[record.ID] = documentContentHash(string(record.Data))
+	}
+	return nil
+}
+
+func renderDocumentVerifyReport(cmd *cobra.Command, report documentVerifyReport, show int) {
+	out := cmd.OutOrStdout()
+	scope := fmt.Sprintf("%d documents", report.Checked)
+	if report.Sampled {
+		scope = fmt.Sprintf("a sample of %d of %d documents", report.Checked, report.Total)
+	}
+	fmt.Fprintf(out, "Verified %s of %s against %s\n", scope, report.Collection, report.Manifest)
+	rows := [][]string{
+		{"matched", strconv.Itoa(report.Matched), ""},
+		{"mismatched", strconv.Itoa(len(report.Mismatched)), listVerifyIDs(report.Mismatched, show)},
+		{"missing", strconv.Itoa(len(report.Missing)), listVerifyIDs(report.Missing, show)},
+	}
+	if report.Sampled {
+		rows = append(rows, []string{"extra", "-", "not checked with --sample"})
+	} else {
+		rows = append(rows, []string{"extra", strconv.Itoa(len(report.Extra)), listVerifyIDs(report.Extra, show)})
+	}
+	if len(report.Failed) > 0 {
+		rows = append(rows, []string{"failed", strconv.Itoa(len(report.Failed)), listVerifyIDs(report.Failed, show)})
+	}
+	renderTable(cmd, []string{"RESULT", "COUNT", "DOCUMENTS"}, rows)
+}
+
+func listVerifyIDs(ids []string, show int) string {
+	if show > 0 && len(ids) > show {
+		return strings.Join(ids[:show], ", ") + fmt.Sprintf(", +%d more", len(ids)-show)
+	}
+	return strings.Join(ids, ", ")
+}
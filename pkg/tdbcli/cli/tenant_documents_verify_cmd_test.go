@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestDocumentsVerifyReportsDrift(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	var ids []string
+	for i := 0; i < 6; i++ {
+		ids = append(ids, srv.AddDocument("users", map[string]any{"n": i, "name": fmt.Sprintf("user-%d", i)}).ID)
+	}
+	manifestPath := filepath.Join(t.TempDir(), "users.checksums.json")
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+	run := func(args ...string) (string, error) {
+		out, _, err := runCommandWithEnv(t, env, newTenantDocumentsVerifyCommand, append([]string{"users", "--manifest", manifestPath}, args...)...)
+		return out, err
+	}
+
+	if out, err := run("--write"); err != nil || !strings.Contains(out, "Wrote checksums of 6 documents") {
+		t.Fatalf("write failed: %v\n%s", err, out)
+	}
+	if out, err := run("--concurrency", "3"); err != nil || !strings.Contains(out, "Verified 6 documents of users") {
+		t.Fatalf("expected a clean verification, got %v\n%s", err, out)
+	}
+
+	tenantClient := srv.TenantClient(t)
+	ctx := context.Background()
+	if _, err := tenantClient.PatchDocument(ctx, "users", ids[1], json.RawMessage(`{"name":"changed"}`), ""); err != nil {
+		t.Fatalf("patch: %v", err)
+	}
+	if err := tenantClient.DeleteDocument(ctx, "users", ids[2], ""); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	extra := srv.AddDocument("users", map[string]any{"n": 99}).ID
+
+	out, err := run("--raw")
+	if err == nil || !strings.Contains(err.Error(), "1 mismatched, 1 missing, 1 extra, 0 failed") {
+		t.Fatalf("expected drift to fail verification, got %v\n%s", err, out)
+	}
+	var report documentVerifyReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("decode report: %v\n%s", err, out)
+	}
+	if report.Matched != 4 || report.Mismatched[0] != ids[1] || report.Missing[0] != ids[2] || report.Extra[0] != extra {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	out, _ = run("--sample", "50%", "--seed", "7")
+	if !strings.Contains(out, "a sample of 3 of 6 documents") || !strings.Contains(out, "not checked with --sample") {
+		t.Fatalf("unexpected sample output:\n%s", out)
+	}
+}
+
+func TestDocumentsVerifyAgainstExportManifests(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	var ids []string
+	for i := 0; i < 5; i++ {
+		ids = append(ids, srv.AddDocument("users", map[string]any{"n": i}).ID)
+	}
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "users.jsonl")
+	lineagePath := filepath.Join(dir, "users.lineage.json")
+	if _, _, err := runCommand(t, srv, newTenantDocumentsExportCommand, "users", "--include-meta", "--out", outPath, "--checksum-every", "2", "--manifest", lineagePath); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	plainPath := filepath.Join(dir, "plain.jsonl")
+	if _, _, err := runCommand(t, srv, newTenantDocumentsExportCommand, "users", "--out", plainPath, "--checksum-every", "2"); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	for _, manifest := range []string{checksumSidecarPath(outPath), lineagePath} {
+		if out, _, err := runCommand(t, srv, newTenantDocumentsVerifyCommand, "users", "--manifest", manifest); err != nil || !strings.Contains(out, "Verified 5 documents of users") {
+			t.Fatalf("expected %s to verify, got %v\n%s", manifest, err, out)
+		}
+	}
+	if _, _, err := runCommand(t, srv, newTenantDocumentsVerifyCommand, "accounts", "--manifest", lineagePath); err == nil || !strings.Contains(err.Error(), `written for collection "users"`) {
+		t.Fatalf("expected a collection mismatch, got %v", err)
+	}
+	if _, _, err := runCommand(t, srv, newTenantDocumentsVerifyCommand, "users", "--manifest", checksumSidecarPath(plainPath)); err == nil || !strings.Contains(err.Error(), "--include-meta") {
+		t.Fatalf("expected an export without ids to be rejected, got %v", err)
+	}
+
+	if _, err := srv.TenantClient(t).PatchDocument(context.Background(), "users", ids[3], json.RawMessage(`{"n":30}`), ""); err != nil {
+		t.Fatalf("patch: %v", err)
+	}
+	if _, _, err := runCommand(t, srv, newTenantDocumentsVerifyCommand, "users", "--manifest", lineagePath); err == nil || !strings.Contains(err.Error(), "1 mismatched, 0 missing, 0 extra") {
+		t.Fatalf("expected the changed document to be reported, got %v", err)
+	}
+
+	if err := os.WriteFile(outPath, []byte("{}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, manifest := range []string{checksumSidecarPath(outPath), lineagePath} {
+		if _, _, err := runCommand(t, srv, newTenantDocumentsVerifyCommand, "users", "--manifest", manifest); err == nil || !strings.Contains(err.Error(), "failed verification") {
+			t.Fatalf("expected the modified export to fail against %s, got %v", manifest, err)
+		}
+	}
+}
+
+func TestParseVerifySample(t *testing.T) {
+	cases := []struct {
+		raw   string
+		total int
+		want  int
+	}{
+		{"5%", 1000, 50},
+		{"0.5%", 10, 1},
+		{"200", 50, 50},
+		{"3", 50, 3},
+	}
+	for _, tc := range cases {
+		got, err := parseVerifySample(tc.raw, tc.total)
+		if err != nil || got != tc.want {
+			t.Fatalf("parseVerifySample(%q, %d) = %d, %v; want %d", tc.raw, tc.total, got, err, tc.want)
+		}
+	}
+	for _, raw := range []string{"0%", "150%", "-2", "lots"} {
+		if _, err := parseVerifySample(raw, 10); err == nil {
+			t.Fatalf("expected %q to be rejected", raw)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestDiffDocumentData(t *testing.T) {
+	changes, err := diffDocumentData(
+		`{"status":"pending","address":{"city":"Phnom Penh","zip":"12000"},"tags":["a"],"note":"x"}`,
+		`{"status":"active","address":{"city":"Phnom Penh"},"tags":["a","b"],"owner":"ops"}`,
+	)
+	if err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+	var got []string
+	for _, c := range changes {
+		got = append(got, string(c.Op)+" "+c.Path)
+	}
+	want := "removed address.zip,removed note,added owner,changed status,changed tags"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("unexpected changes %v", got)
+	}
+}
+
+func TestDocumentsWatchReportsChangesUntilDeleted(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "orders"})
+	doc := srv.AddDocument("orders", map[string]any{"status": "pending"})
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+
+	cmd := newTenantDocumentsWatchCommand(env)
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"orders", doc.ID, "--tenant", "tn_test", "--api-key", clienttest.APIKey, "--interval", "5ms", "--timeout", "5s", "--raw"})
+	var out syncBuffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	done := make(chan error, 1)
+	go func() { done <- cmd.Execute() }()
+
+	client := srv.TenantClient(t)
+	time.Sleep(20 * time.Millisecond)
+	if _, err := client.PatchDocument(context.Background(), "orders", doc.ID, []byte(`{"status":"shipped"}`), ""); err != nil {
+		t.Fatalf("patch: %v", err)
+	}
+	deadline := time.Now().Add(3 * time.Second)
+	for !strings.Contains(out.String(), `"version":2`) {
+		if time.Now().After(deadline) {
+			t.Fatalf("change was not reported, output: %s", out.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := client.DeleteDocument(context.Background(), "orders", doc.ID, ""); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("watch failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a change and a delete event, got %q", out.String())
+	}
+	var change documentWatchEvent
+	if err := json.Unmarshal([]byte(lines[0]), &change); err != nil {
+		t.Fatalf("decode event: %v", err)
+	}
+	if change.PreviousVersion != 1 || len(change.Changes) != 1 || change.Changes[0].Path != "status" || change.Changes[0].New != "shipped" {
+		t.Fatalf("unexpected change event %+v", change)
+	}
+	if !strings.Contains(lines[1], `"deleted":true`) {
+		t.Fatalf("expected delete event, got %s", lines[1])
+	}
+}
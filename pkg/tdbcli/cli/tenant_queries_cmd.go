@@ -1,9 +1,12 @@
 package cli
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -174,6 +177,7 @@ By default, fetches by ID. Use --by-name to fetch by query name instead.`,
 	auth.bindWithApp(cmd)
 	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON document")
 	cmd.Flags().BoolVar(&byName, "by-name", false, "Treat the identifier as the saved query name")
+	cmd.ValidArgsFunction = savedQueryCompletion(env, &auth)
 	return cmd
 }
 
@@ -317,30 +321,84 @@ func newTenantQueriesExecuteCommand(env *Environment) *cobra.Command {
 	var paramsStdin bool
 	var byName bool
 	var raw bool
+	var stream bool
+	var explainServer bool
 	cmd := &cobra.Command{
-		Use:   "execute <id_or_name>",
-		Short: "Execute a saved query",
-		Args:  cobra.ExactArgs(1),
+		Use:     "execute <id_or_name|@favorite>",
+		Aliases: []string{"run"},
+		Short:   "Execute a saved query",
+		Example: `  # Execute by name
+  tdb tenant queries execute active-users --by-name
+
+  # Execute a favorite saved with queries fav add
+  tdb q run @rev
+
+  # Show the server's plan to find out why a query is slow
+  tdb tenant queries execute monthly-revenue --by-name --explain-server`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			envCtx, err := requireEnvironment(env)
 			if err != nil {
 				return err
 			}
-			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
-			if err != nil {
-				return err
-			}
 			target := strings.TrimSpace(args[0])
 			if target == "" {
 				return errors.New("identifier cannot be empty")
 			}
+			paramsSet := cmd.Flags().Lookup("params").Changed || cmd.Flags().Lookup("params-file").Changed || cmd.Flags().Lookup("params-stdin").Changed
+			if strings.HasPrefix(target, favoritePrefix) {
+				tenantID, err := resolveTenantID(envCtx, auth.tenantID)
+				if err != nil {
+					return err
+				}
+				fav, err := resolveQueryFavorite(envCtx, tenantID, target)
+				if err != nil {
+					return err
+				}
+				target, byName = fav.Query, true
+				if strings.TrimSpace(auth.appID) == "" && strings.TrimSpace(auth.appRef) == "" {
+					auth.appID = fav.AppID
+				}
+				if !paramsSet && fav.Params != "" {
+					params, paramsSet = fav.Params, true
+				}
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
 			var payload []byte
-			if cmd.Flags().Lookup("params").Changed || cmd.Flags().Lookup("params-file").Changed || cmd.Flags().Lookup("params-stdin").Changed {
+			if paramsSet {
 				payload, err = readJSONPayload(cmd, params, paramsFile, paramsStdin, false)
 				if err != nil {
 					return err
 				}
 			}
+			if explainServer {
+				if stream {
+					return errors.New("--explain-server cannot be combined with --stream")
+				}
+				if payload, err = withExplainRequest(payload); err != nil {
+					return err
+				}
+			}
+			if stream {
+				body, headers, err := tenantClient.StreamSavedQuery(cmd.Context(), target, byName, payload, auth.appID)
+				if err != nil {
+					return err
+				}
+				defer body.Close()
+				out := bufio.NewWriter(cmd.OutOrStdout())
+				rows, err := writeSavedQueryStream(out, body, headers.Get("Content-Type"))
+				if flushErr := out.Flush(); err == nil {
+					err = flushErr
+				}
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "Streamed %d rows\n", rows)
+				return nil
+			}
 			var result *clientpkg.SavedQueryExecutionResult
 			if byName {
 				result, err = tenantClient.ExecuteSavedQueryByName(cmd.Context(), target, payload, auth.appID)
@@ -353,7 +411,13 @@ func newTenantQueriesExecuteCommand(env *Environment) *cobra.Command {
 			if raw {
 				return printJSON(cmd, result)
 			}
-			return renderSavedQueryResult(cmd, result)
+			if err := renderSavedQueryResult(cmd, result); err != nil {
+				return err
+			}
+			if explainServer {
+				renderQueryExplain(cmd, result.Explain)
+			}
+			return nil
 		},
 	}
 	auth.bindWithApp(cmd)
@@ -362,9 +426,95 @@ func newTenantQueriesExecuteCommand(env *Environment) *cobra.Command {
 	cmd.Flags().BoolVar(&paramsStdin, "params-stdin", false, "Read JSON parameters from stdin")
 	cmd.Flags().BoolVar(&byName, "by-name", false, "Execute using the saved query name")
 	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON result")
+	cmd.Flags().BoolVar(&stream, "stream", false, "Write result rows as NDJSON as they arrive instead of buffering")
+	cmd.Flags().BoolVar(&explainServer, "explain-server", false, explainServerFlagUsage)
+	cmd.ValidArgsFunction = savedQueryCompletion(env, &auth)
 	return cmd
 }
 
+// writeSavedQueryStream copies saved query result rows to w as NDJSON. NDJSON
+// responses are passed through line by line; JSON envelopes ({"items":[...]}) or
+// bare arrays are decoded element by element so the full result is never held in
+// memory.
+func writeSavedQueryStream(w io.Writer, body io.Reader, contentType string) (int, error) {
+	if strings.Contains(strings.ToLower(contentType), "ndjson") || strings.Contains(strings.ToLower(contentType), "jsonl") {
+		reader := bufio.NewReader(body)
+		rows := 0
+		for {
+			line, readErr := reader.ReadBytes('\n')
+			if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+				if _, err := w.Write(append(trimmed, '\n')); err != nil {
+					return rows, err
+				}
+				rows++
+			}
+			if readErr != nil {
+				if readErr == io.EOF {
+					return rows, nil
+				}
+				return rows, readErr
+			}
+		}
+	}
+
+	dec := json.NewDecoder(body)
+	tok, err := dec.Token()
+	if err != nil {
+		return 0, fmt.Errorf("decode response: %w", err)
+	}
+	switch tok {
+	case json.Delim('['):
+		return streamJSONArrayElements(w, dec)
+	case json.Delim('{'):
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return 0, fmt.Errorf("decode response: %w", err)
+			}
+			if key, _ := keyTok.(string); key == "items" {
+				next, err := dec.Token()
+				if err != nil {
+					return 0, fmt.Errorf("decode response: %w", err)
+				}
+				if next == nil {
+					return 0, nil
+				}
+				if next != json.Delim('[') {
+					return 0, errors.New("decode response: items is not an array")
+				}
+				return streamJSONArrayElements(w, dec)
+			}
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return 0, fmt.Errorf("decode response: %w", err)
+			}
+		}
+		return 0, nil
+	default:
+		return 0, errors.New("decode response: unexpected result payload")
+	}
+}
+
+func streamJSONArrayElements(w io.Writer, dec *json.Decoder) (int, error) {
+	rows := 0
+	for dec.More() {
+		var row json.RawMessage
+		if err := dec.Decode(&row); err != nil {
+			return rows, fmt.Errorf("decode row %d: %w", rows+1, err)
+		}
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, row); err != nil {
+			return rows, err
+		}
+		compact.WriteByte('\n')
+		if _, err := w.Write(compact.Bytes()); err != nil {
+			return rows, err
+		}
+		rows++
+	}
+	return rows, nil
+}
+
 func newTenantQueriesDeleteCommand(env *Environment) *cobra.Command {
 	var auth authFlags
 	var byName bool
@@ -389,7 +539,14 @@ func newTenantQueriesDeleteCommand(env *Environment) *cobra.Command {
 				return errors.New("identifier cannot be empty")
 			}
 			if purge && !confirm {
-				return errors.New("use --confirm to acknowledge irreversible purge")
+				return errors.New(tr("use --confirm to acknowledge irreversible purge"))
+			}
+			action := "delete saved query " + target
+			if purge {
+				action = "permanently purge saved query " + target
+			}
+			if err := confirmDestructive(cmd, envCtx, action); err != nil {
+				return err
 			}
 			if byName {
 				if err := tenantClient.DeleteSavedQueryByName(cmd.Context(), target, purge, auth.appID, confirm); err != nil {
@@ -413,6 +570,8 @@ func newTenantQueriesDeleteCommand(env *Environment) *cobra.Command {
 	cmd.Flags().BoolVar(&byName, "by-name", false, "Treat the identifier as the saved query name")
 	cmd.Flags().BoolVar(&purge, "purge", false, "Permanently purge the saved query document")
 	cmd.Flags().BoolVar(&confirm, "confirm", false, "Confirm irreversible purge")
+	bindSafeModeYes(cmd)
+	cmd.ValidArgsFunction = savedQueryCompletion(env, &auth)
 	return cmd
 }
 
@@ -510,8 +669,15 @@ func renderSavedQueryResult(cmd *cobra.Command, result *clientpkg.SavedQueryExec
 		fmt.Fprintln(cmd.OutOrStdout(), "No rows returned")
 		return nil
 	}
+	headers := savedQueryResultColumns(result.Items)
+	renderTable(cmd, headers, savedQueryResultRows(result.Items, headers))
+	return nil
+}
+
+// savedQueryResultColumns returns the sorted union of keys across result rows.
+func savedQueryResultColumns(items []map[string]any) []string {
 	columns := make(map[string]struct{})
-	for _, row := range result.Items {
+	for _, row := range items {
 		for key := range row {
 			columns[key] = struct{}{}
 		}
@@ -521,16 +687,19 @@ func renderSavedQueryResult(cmd *cobra.Command, result *clientpkg.SavedQueryExec
 		headers = append(headers, key)
 	}
 	sort.Strings(headers)
-	rows := make([][]string, 0, len(result.Items))
-	for _, row := range result.Items {
+	return headers
+}
+
+func savedQueryResultRows(items []map[string]any, headers []string) [][]string {
+	rows := make([][]string, 0, len(items))
+	for _, row := range items {
 		cells := make([]string, len(headers))
 		for i, header := range headers {
 			cells[i] = stringifyValue(row[header])
 		}
 		rows = append(rows, cells)
 	}
-	renderTable(cmd, headers, rows)
-	return nil
+	return rows
 }
 
 func stringifyValue(v any) string {
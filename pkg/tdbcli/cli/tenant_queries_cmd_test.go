@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteSavedQueryStream_Envelope(t *testing.T) {
+	body := strings.NewReader(`{"query":"q","items":[{"a": 1}, {"a": 2}],"count":2}`)
+	var out bytes.Buffer
+	rows, err := writeSavedQueryStream(&out, body, "application/json")
+	if err != nil {
+		t.Fatalf("writeSavedQueryStream returned error: %v", err)
+	}
+	if rows != 2 {
+		t.Fatalf("expected 2 rows, got %d", rows)
+	}
+	if out.String() != "{\"a\":1}\n{\"a\":2}\n" {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestWriteSavedQueryStream_NDJSON(t *testing.T) {
+	body := strings.NewReader("{\"a\":1}\n\n{\"a\":2}")
+	var out bytes.Buffer
+	rows, err := writeSavedQueryStream(&out, body, "application/x-ndjson")
+	if err != nil {
+		t.Fatalf("writeSavedQueryStream returned error: %v", err)
+	}
+	if rows != 2 || out.String() != "{\"a\":1}\n{\"a\":2}\n" {
+		t.Fatalf("unexpected output (%d rows): %q", rows, out.String())
+	}
+}
+
+func TestWriteSavedQueryStream_NullItems(t *testing.T) {
+	var out bytes.Buffer
+	rows, err := writeSavedQueryStream(&out, strings.NewReader(`{"items":null}`), "application/json")
+	if err != nil || rows != 0 {
+		t.Fatalf("expected no rows without error, got %d (%v)", rows, err)
+	}
+}
@@ -0,0 +1,290 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const snippetAPIKeyEnv = "TDB_API_KEY"
+
+// savedQuerySnippet describes the HTTP call used to execute a saved query so it
+// can be rendered as a curl command or a code snippet. Socket is set for
+// endpoints served on a unix domain socket; URL then uses localhost as host.
+type savedQuerySnippet struct {
+	URL    string
+	Socket string
+	AppID  string
+	Body   string
+}
+
+func newTenantQueriesExportCurlCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var lang string
+	var params string
+	var paramsFile string
+	var paramsStdin bool
+
+	cmd := &cobra.Command{
+		Use:   "export-curl <name>",
+		Short: "Print a curl command or code snippet that executes a saved query",
+		Long: `Print an equivalent HTTP call for executing a saved query by name.
+
+The API key is never embedded in the output; snippets read it from the
+TDB_API_KEY environment variable instead. When no params are supplied, a
+params template derived from the saved query is used as the request body.
+
+For a unix socket endpoint, curl is given --unix-socket and the Go snippet
+dials the socket; python and js snippets are refused.
+
+The snippet language is chosen with --snippet-lang; the global --lang flag sets
+the language of the CLI's own messages. The old --lang spelling of this flag
+still selects the snippet language but is deprecated.`,
+		Example: `  # Print a curl command
+  tdb tenant queries export-curl active-users
+
+  # Generate a Python snippet with explicit params
+  tdb tenant queries export-curl active-users --snippet-lang python --params '{"params":{"status":"active"}}'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			endpoint, err := ensureEndpoint(envCtx)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			name := strings.TrimSpace(args[0])
+			if name == "" {
+				return errors.New("saved query name cannot be empty")
+			}
+			doc, err := tenantClient.GetSavedQueryByName(cmd.Context(), name, auth.appID)
+			if err != nil {
+				return err
+			}
+			sq, err := parseSavedQueryDocument(*doc)
+			if err != nil {
+				return err
+			}
+			var body []byte
+			if cmd.Flags().Lookup("params").Changed || cmd.Flags().Lookup("params-file").Changed || cmd.Flags().Lookup("params-stdin").Changed {
+				body, err = readJSONPayload(cmd, params, paramsFile, paramsStdin, false)
+				if err != nil {
+					return err
+				}
+			} else {
+				body, err = json.Marshal(map[string]any{"params": buildParamsTemplate(sq)})
+				if err != nil {
+					return err
+				}
+			}
+			snippet, err := newSavedQuerySnippet(endpoint, name, auth.appID, body)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("lang") {
+				logWarn(cmd.ErrOrStderr(), "--lang is deprecated for export-curl; use --snippet-lang instead")
+			}
+			out, err := renderSavedQuerySnippet(lang, snippet)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), out)
+			return nil
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().StringVar(&lang, "snippet-lang", "curl", "Snippet language: curl, go, python, or js")
+	// Before --snippet-lang existed this flag was --lang, shadowing the global
+	// message language flag; the alias keeps old scripts working.
+	cmd.Flags().StringVar(&lang, "lang", "curl", "Snippet language (deprecated alias for --snippet-lang)")
+	_ = cmd.Flags().MarkHidden("lang")
+	cmd.Flags().StringVar(&params, "params", "", "Inline JSON parameters to embed (wrapped in {\"params\":{...}})")
+	cmd.Flags().StringVar(&paramsFile, "params-file", "", "Path to JSON parameters to embed")
+	cmd.Flags().BoolVar(&paramsStdin, "params-stdin", false, "Read JSON parameters from stdin")
+	return cmd
+}
+
+func newSavedQuerySnippet(endpoint, name, appID string, body []byte) (savedQuerySnippet, error) {
+	base := strings.TrimSpace(endpoint)
+	if base == "" {
+		return savedQuerySnippet{}, errors.New("endpoint cannot be empty")
+	}
+	socket, unixBase, isUnix, err := clientpkg.UnixSocketEndpoint(base)
+	if err != nil {
+		return savedQuerySnippet{}, err
+	}
+	if isUnix {
+		base = "http://localhost" + strings.TrimPrefix(unixBase, "http://unix")
+	} else if !strings.Contains(base, "://") {
+		base = "http://" + base
+	}
+	target := strings.TrimRight(base, "/") + "/api/queries/name/" + url.PathEscape(name) + "/execute"
+	appID = strings.TrimSpace(appID)
+	if appID != "" {
+		target += "?" + url.Values{"app_id": []string{appID}}.Encode()
+	}
+	var pretty strings.Builder
+	if len(body) > 0 {
+		var decoded any
+		if err := json.Unmarshal(body, &decoded); err != nil {
+			return savedQuerySnippet{}, fmt.Errorf("invalid params JSON: %w", err)
+		}
+		encoded, err := json.MarshalIndent(decoded, "", "  ")
+		if err != nil {
+			return savedQuerySnippet{}, err
+		}
+		pretty.Write(encoded)
+	}
+	return savedQuerySnippet{URL: target, Socket: socket, AppID: appID, Body: pretty.String()}, nil
+}
+
+func renderSavedQuerySnippet(lang string, s savedQuerySnippet) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(lang)) {
+	case "", "curl", "sh", "shell":
+		return renderCurlSnippet(s), nil
+	case "go", "golang":
+		return renderGoSnippet(s), nil
+	case "python", "py":
+		if s.Socket != "" {
+			return "", fmt.Errorf("--snippet-lang %s does not support unix socket endpoints; use curl or go", lang)
+		}
+		return renderPythonSnippet(s), nil
+	case "js", "javascript", "node":
+		if s.Socket != "" {
+			return "", fmt.Errorf("--snippet-lang %s does not support unix socket endpoints; use curl or go", lang)
+		}
+		return renderJSSnippet(s), nil
+	default:
+		return "", fmt.Errorf("unsupported --snippet-lang %q (expected curl, go, python, or js)", lang)
+	}
+}
+
+func renderCurlSnippet(s savedQuerySnippet) string {
+	var b strings.Builder
+	b.WriteString("curl -sS ")
+	if s.Socket != "" {
+		b.WriteString("--unix-socket " + shellQuote(s.Socket) + " ")
+	}
+	b.WriteString("-X POST " + shellQuote(s.URL) + " \\\n")
+	b.WriteString("  -H \"X-API-Key: $" + snippetAPIKeyEnv + "\" \\\n")
+	if s.AppID != "" {
+		b.WriteString("  -H " + shellQuote("X-App-ID: "+s.AppID) + " \\\n")
+	}
+	b.WriteString("  -H 'Content-Type: application/json'")
+	if s.Body != "" {
+		b.WriteString(" \\\n  -d " + shellQuote(s.Body))
+	}
+	return b.String()
+}
+
+func renderGoSnippet(s savedQuerySnippet) string {
+	var b strings.Builder
+	b.WriteString("package main\n\n")
+	if s.Socket != "" {
+		b.WriteString("import (\n\t\"context\"\n\t\"fmt\"\n\t\"io\"\n\t\"net\"\n\t\"net/http\"\n\t\"os\"\n\t\"strings\"\n)\n\n")
+	} else {
+		b.WriteString("import (\n\t\"fmt\"\n\t\"io\"\n\t\"net/http\"\n\t\"os\"\n\t\"strings\"\n)\n\n")
+	}
+	b.WriteString("func main() {\n")
+	b.WriteString("\tbody := strings.NewReader(" + goRawString(s.Body) + ")\n")
+	b.WriteString("\treq, err := http.NewRequest(http.MethodPost, " + strconv.Quote(s.URL) + ", body)\n")
+	b.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+	b.WriteString("\treq.Header.Set(\"Content-Type\", \"application/json\")\n")
+	b.WriteString("\treq.Header.Set(\"X-API-Key\", os.Getenv(" + strconv.Quote(snippetAPIKeyEnv) + "))\n")
+	if s.AppID != "" {
+		b.WriteString("\treq.Header.Set(\"X-App-ID\", " + strconv.Quote(s.AppID) + ")\n")
+	}
+	if s.Socket != "" {
+		b.WriteString("\tclient := &http.Client{Transport: &http.Transport{\n")
+		b.WriteString("\t\tDialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {\n")
+		b.WriteString("\t\t\tvar d net.Dialer\n")
+		b.WriteString("\t\t\treturn d.DialContext(ctx, \"unix\", " + strconv.Quote(s.Socket) + ")\n")
+		b.WriteString("\t\t},\n")
+		b.WriteString("\t}}\n")
+		b.WriteString("\tresp, err := client.Do(req)\n")
+	} else {
+		b.WriteString("\tresp, err := http.DefaultClient.Do(req)\n")
+	}
+	b.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+	b.WriteString("\tdefer resp.Body.Close()\n")
+	b.WriteString("\tout, _ := io.ReadAll(resp.Body)\n")
+	b.WriteString("\tfmt.Println(resp.Status)\n")
+	b.WriteString("\tfmt.Println(string(out))\n")
+	b.WriteString("}")
+	return b.String()
+}
+
+func renderPythonSnippet(s savedQuerySnippet) string {
+	var b strings.Builder
+	b.WriteString("import json\nimport os\nimport urllib.request\n\n")
+	b.WriteString("payload = " + pythonRawString(s.Body) + "\n")
+	b.WriteString("req = urllib.request.Request(\n")
+	b.WriteString("    " + strconv.Quote(s.URL) + ",\n")
+	b.WriteString("    data=payload.encode(\"utf-8\"),\n")
+	b.WriteString("    method=\"POST\",\n")
+	b.WriteString("    headers={\n")
+	b.WriteString("        \"Content-Type\": \"application/json\",\n")
+	b.WriteString("        \"X-API-Key\": os.environ[" + strconv.Quote(snippetAPIKeyEnv) + "],\n")
+	if s.AppID != "" {
+		b.WriteString("        \"X-App-ID\": " + strconv.Quote(s.AppID) + ",\n")
+	}
+	b.WriteString("    },\n)\n")
+	b.WriteString("with urllib.request.urlopen(req) as resp:\n")
+	b.WriteString("    print(json.dumps(json.load(resp), indent=2))")
+	return b.String()
+}
+
+func renderJSSnippet(s savedQuerySnippet) string {
+	var b strings.Builder
+	b.WriteString("const body = " + jsTemplateString(s.Body) + ";\n\n")
+	b.WriteString("const res = await fetch(" + strconv.Quote(s.URL) + ", {\n")
+	b.WriteString("  method: \"POST\",\n")
+	b.WriteString("  headers: {\n")
+	b.WriteString("    \"Content-Type\": \"application/json\",\n")
+	b.WriteString("    \"X-API-Key\": process.env." + snippetAPIKeyEnv + ",\n")
+	if s.AppID != "" {
+		b.WriteString("    \"X-App-ID\": " + strconv.Quote(s.AppID) + ",\n")
+	}
+	b.WriteString("  },\n")
+	b.WriteString("  body,\n")
+	b.WriteString("});\n")
+	b.WriteString("console.log(res.status, await res.json());")
+	return b.String()
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+func goRawString(value string) string {
+	if strings.Contains(value, "`") {
+		return strconv.Quote(value)
+	}
+	return "`" + value + "`"
+}
+
+func pythonRawString(value string) string {
+	if strings.Contains(value, `"""`) || strings.HasSuffix(value, `"`) || strings.Contains(value, `\`) {
+		return strconv.Quote(value)
+	}
+	return `"""` + value + `"""`
+}
+
+func jsTemplateString(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "`", "\\`", "${", "\\${")
+	return "`" + replacer.Replace(value) + "`"
+}
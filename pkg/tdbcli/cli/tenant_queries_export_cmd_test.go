@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestNewSavedQuerySnippet_BuildsURL(t *testing.T) {
+	snippet, err := newSavedQuerySnippet("api.example.com/", "active users", "app_1", []byte(`{"params":{"status":"active"}}`))
+	if err != nil {
+		t.Fatalf("newSavedQuerySnippet returned error: %v", err)
+	}
+	want := "http://api.example.com/api/queries/name/active%20users/execute?app_id=app_1"
+	if snippet.URL != want {
+		t.Fatalf("expected url %s, got %s", want, snippet.URL)
+	}
+	if !strings.Contains(snippet.Body, "\"status\": \"active\"") {
+		t.Fatalf("expected pretty body, got %s", snippet.Body)
+	}
+}
+
+func TestNewSavedQuerySnippet_InvalidJSON(t *testing.T) {
+	if _, err := newSavedQuerySnippet("https://api.example.com", "q", "", []byte(`{`)); err == nil {
+		t.Fatalf("expected error for invalid params JSON")
+	}
+}
+
+func TestRenderSavedQuerySnippet_Curl(t *testing.T) {
+	snippet := savedQuerySnippet{URL: "https://api.example.com/api/queries/name/q/execute", AppID: "app_1", Body: `{"name":"O'Brien"}`}
+	out, err := renderSavedQuerySnippet("curl", snippet)
+	if err != nil {
+		t.Fatalf("renderSavedQuerySnippet returned error: %v", err)
+	}
+	if !strings.Contains(out, `-H "X-API-Key: $TDB_API_KEY"`) {
+		t.Fatalf("expected api key placeholder, got %s", out)
+	}
+	if !strings.Contains(out, `-H 'X-App-ID: app_1'`) {
+		t.Fatalf("expected app scope header, got %s", out)
+	}
+	if !strings.Contains(out, `-d '{"name":"O'\''Brien"}'`) {
+		t.Fatalf("expected shell-quoted body, got %s", out)
+	}
+}
+
+func TestRenderSavedQuerySnippet_Languages(t *testing.T) {
+	snippet := savedQuerySnippet{URL: "https://api.example.com/x", Body: `{}`}
+	cases := map[string]string{
+		"go":     `os.Getenv("TDB_API_KEY")`,
+		"python": `os.environ["TDB_API_KEY"]`,
+		"js":     `process.env.TDB_API_KEY`,
+	}
+	for lang, marker := range cases {
+		out, err := renderSavedQuerySnippet(lang, snippet)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", lang, err)
+		}
+		if !strings.Contains(out, marker) {
+			t.Fatalf("%s: expected %q in output, got %s", lang, marker, out)
+		}
+	}
+	if _, err := renderSavedQuerySnippet("ruby", snippet); err == nil {
+		t.Fatalf("expected error for unsupported language")
+	}
+}
+
+func TestSavedQuerySnippet_UnixSocket(t *testing.T) {
+	snippet, err := newSavedQuerySnippet("http+unix://%2Fvar%2Frun%2Ftinydb.sock/tdb", "q", "", []byte(`{}`))
+	if err != nil {
+		t.Fatalf("newSavedQuerySnippet returned error: %v", err)
+	}
+	if snippet.URL != "http://localhost/tdb/api/queries/name/q/execute" || snippet.Socket != "/var/run/tinydb.sock" {
+		t.Fatalf("unexpected socket snippet %+v", snippet)
+	}
+	out, err := renderSavedQuerySnippet("curl", snippet)
+	if err != nil || !strings.HasPrefix(out, "curl -sS --unix-socket '/var/run/tinydb.sock' -X POST 'http://localhost/tdb/api/queries/name/q/execute'") {
+		t.Fatalf("expected curl over the socket, got %s (%v)", out, err)
+	}
+	out, err = renderSavedQuerySnippet("go", snippet)
+	if err != nil {
+		t.Fatalf("go: unexpected error: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "main.go", out, 0); err != nil || !strings.Contains(out, `d.DialContext(ctx, "unix", "/var/run/tinydb.sock")`) {
+		t.Fatalf("expected a Go program dialing the socket, got %v\n%s", err, out)
+	}
+	for _, lang := range []string{"python", "js"} {
+		if _, err := renderSavedQuerySnippet(lang, snippet); err == nil || !strings.Contains(err.Error(), "unix socket") {
+			t.Fatalf("%s: expected socket endpoints to be rejected, got %v", lang, err)
+		}
+	}
+
+	snippet, err = newSavedQuerySnippet("unix:///var/run/tinydb.sock", "q", "", nil)
+	if err != nil || snippet.URL != "http://localhost/api/queries/name/q/execute" || snippet.Socket != "/var/run/tinydb.sock" {
+		t.Fatalf("unexpected unix:// snippet %+v (%v)", snippet, err)
+	}
+}
+
+func TestQueriesExportCurlSnippetLangFlag(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddSavedQuery(clientpkg.SavedQuery{Name: "active", Collection: "users"})
+
+	out, _, err := runCommand(t, srv, newTenantQueriesExportCurlCommand, "active", "--snippet-lang", "python")
+	if err != nil {
+		t.Fatalf("export-curl failed: %v", err)
+	}
+	if !strings.Contains(out, `os.environ["TDB_API_KEY"]`) {
+		t.Fatalf("expected a python snippet, got %s", out)
+	}
+
+	out, errOut, err := runCommand(t, srv, newTenantQueriesExportCurlCommand, "active", "--lang", "js")
+	if err != nil {
+		t.Fatalf("export-curl with the deprecated flag failed: %v", err)
+	}
+	if !strings.Contains(out, "process.env.TDB_API_KEY") {
+		t.Fatalf("expected the deprecated --lang to still pick the snippet language, got %s", out)
+	}
+	if !strings.Contains(errOut, "--snippet-lang") {
+		t.Fatalf("expected a deprecation notice pointing at --snippet-lang, got %q", errOut)
+	}
+}
@@ -0,0 +1,343 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+// favoritePrefix marks a query argument as a local favorite alias.
+const favoritePrefix = "@"
+
+// savedQueryMatch is one saved query found by queries search.
+type savedQueryMatch struct {
+	Name       string `json:"name"`
+	Type       string `json:"type,omitempty"`
+	Collection string `json:"collection,omitempty"`
+	ID         string `json:"id"`
+	MatchedIn  string `json:"matched_in"`
+	Score      int    `json:"score"`
+}
+
+func newTenantQueriesSearchCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var limit int
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "search <term>",
+		Short: "Fuzzy search saved queries by name, collection, and query text",
+		Long: `Find saved queries whose name, target collection, or SQL/DSL text fuzzy-matches
+the term. Characters of the term must appear in order but not necessarily next
+to each other; name matches and contiguous matches rank first.`,
+		Example: `  # Find queries about signups
+  tdb tenant queries search signup
+
+  # Abbreviations work too
+  tdb q search dlyrev`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			term := strings.TrimSpace(args[0])
+			if term == "" {
+				return errors.New("search term cannot be empty")
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			docs, err := tenantClient.ListSavedQueries(cmd.Context(), auth.appID)
+			if err != nil {
+				return err
+			}
+			var matches []savedQueryMatch
+			for _, doc := range docs {
+				sq, err := parseSavedQueryDocument(doc)
+				if err != nil {
+					continue
+				}
+				fields := []struct{ label, text string }{
+					{"name", sq.Name},
+					{"collection", sq.Collection},
+					{"sql", sq.SQL},
+					{"dsl", string(sq.DSL)},
+				}
+				best := savedQueryMatch{Name: sq.Name, Type: sq.Type, Collection: sq.Collection, ID: doc.ID}
+				for i, field := range fields {
+					score, ok := fuzzyScore(term, field.text)
+					if !ok {
+						continue
+					}
+					// Earlier fields outrank later ones at equal quality.
+					score += (len(fields) - i) * 10
+					if score > best.Score {
+						best.Score = score
+						best.MatchedIn = field.label
+					}
+				}
+				if best.MatchedIn != "" {
+					matches = append(matches, best)
+				}
+			}
+			sort.SliceStable(matches, func(i, j int) bool {
+				if matches[i].Score != matches[j].Score {
+					return matches[i].Score > matches[j].Score
+				}
+				return matches[i].Name < matches[j].Name
+			})
+			if limit > 0 && len(matches) > limit {
+				matches = matches[:limit]
+			}
+			if raw {
+				return printJSON(cmd, matches)
+			}
+			if len(matches) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "No saved queries match %q\n", term)
+				return nil
+			}
+			rows := make([][]string, 0, len(matches))
+			for _, match := range matches {
+				rows = append(rows, []string{match.Name, valueOrDash(match.Type), valueOrDash(match.Collection), match.MatchedIn, match.ID})
+			}
+			renderTable(cmd, []string{"NAME", "TYPE", "COLLECTION", "MATCHED IN", "ID"}, rows)
+			return nil
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().IntVar(&limit, "limit", 20, "Maximum number of matches to show (0 for all)")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print matches as JSON")
+	return cmd
+}
+
+// fuzzyScore reports whether every rune of term appears in text in order,
+// ignoring case, and scores the match: contiguous substrings score highest,
+// then subsequences with fewer and shorter gaps, with a bonus for matches at
+// the start of text or of a word.
+func fuzzyScore(term, text string) (int, bool) {
+	needle := []rune(strings.ToLower(strings.TrimSpace(term)))
+	haystack := []rune(strings.ToLower(text))
+	if len(needle) == 0 || len(haystack) == 0 {
+		return 0, false
+	}
+	if idx := strings.Index(string(haystack), string(needle)); idx >= 0 {
+		score := 1000
+		if idx == 0 {
+			score += 200
+		} else if start := len([]rune(string(haystack)[:idx])); isWordBoundary(haystack, start) {
+			score += 100
+		}
+		return score - min(len(haystack), 100), true
+	}
+	score := 500
+	n := 0
+	last := -1
+	for i, r := range haystack {
+		if n == len(needle) {
+			break
+		}
+		if r != needle[n] {
+			continue
+		}
+		if last >= 0 {
+			score -= min(i-last-1, 20)
+		}
+		if isWordBoundary(haystack, i) {
+			score += 5
+		}
+		last = i
+		n++
+	}
+	if n < len(needle) {
+		return 0, false
+	}
+	return max(score-min(len(haystack), 100), 1), true
+}
+
+func isWordBoundary(text []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev := text[i-1]
+	return !unicode.IsLetter(prev) && !unicode.IsDigit(prev)
+}
+
+func valueOrDash(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return "-"
+	}
+	return value
+}
+
+func newTenantQueriesFavoritesCommand(env *Environment) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "fav",
+		Aliases: []string{"favorites"},
+		Short:   "Manage local saved query favorites",
+		Long: `Favorites are local aliases for saved queries, stored per tenant in the CLI
+config. Execute one with its @-prefixed alias: tdb q run @daily.`,
+	}
+	cmd.AddCommand(newTenantQueriesFavoritesAddCommand(env))
+	cmd.AddCommand(newTenantQueriesFavoritesListCommand(env))
+	cmd.AddCommand(newTenantQueriesFavoritesRemoveCommand(env))
+	return cmd
+}
+
+func newTenantQueriesFavoritesAddCommand(env *Environment) *cobra.Command {
+	var tenantID string
+	var appID string
+	var params string
+
+	cmd := &cobra.Command{
+		Use:   "add <alias> <query_name>",
+		Short: "Save a favorite alias for a saved query",
+		Example: `  # Run daily-revenue-by-region as @rev
+  tdb tenant queries fav add rev daily-revenue-by-region
+  tdb q run @rev
+
+  # Remember default parameters
+  tdb tenant queries fav add eu-rev daily-revenue-by-region --params '{"region":"eu"}'`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenant, err := resolveTenantID(envCtx, tenantID)
+			if err != nil {
+				return err
+			}
+			alias := strings.TrimPrefix(strings.TrimSpace(args[0]), favoritePrefix)
+			query := strings.TrimSpace(args[1])
+			if alias == "" || query == "" {
+				return errors.New("alias and query name cannot be empty")
+			}
+			if trimmed := strings.TrimSpace(params); trimmed != "" {
+				if !json.Valid([]byte(trimmed)) {
+					return errors.New("--params must be valid JSON")
+				}
+			}
+			tc := envCtx.Config.EnsureTenant(tenant)
+			if tc.Favorites == nil {
+				tc.Favorites = make(map[string]configpkg.QueryFavorite)
+			}
+			tc.Favorites[alias] = configpkg.QueryFavorite{Query: query, AppID: strings.TrimSpace(appID), Params: strings.TrimSpace(params)}
+			envCtx.Config.UpdateTenant(tenant, tc)
+			if err := envCtx.Save(); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Saved favorite %s%s → %s\n", favoritePrefix, alias, query)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&tenantID, "tenant", "", "Tenant ID (defaults to configured value)")
+	cmd.Flags().StringVar(&appID, "app-id", "", "Application ID or name to execute the query under")
+	cmd.Flags().StringVar(&params, "params", "", "Default JSON parameters used when run without --params")
+	return cmd
+}
+
+func newTenantQueriesFavoritesListCommand(env *Environment) *cobra.Command {
+	var tenantID string
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List saved query favorites",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenant, err := resolveTenantID(envCtx, tenantID)
+			if err != nil {
+				return err
+			}
+			favorites := envCtx.Config.Tenants[tenant].Favorites
+			if raw {
+				if favorites == nil {
+					favorites = map[string]configpkg.QueryFavorite{}
+				}
+				return printJSON(cmd, favorites)
+			}
+			if len(favorites) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No favorites saved")
+				return nil
+			}
+			aliases := make([]string, 0, len(favorites))
+			for alias := range favorites {
+				aliases = append(aliases, alias)
+			}
+			sort.Strings(aliases)
+			rows := make([][]string, 0, len(aliases))
+			for _, alias := range aliases {
+				fav := favorites[alias]
+				rows = append(rows, []string{favoritePrefix + alias, fav.Query, valueOrDash(fav.AppID), valueOrDash(fav.Params)})
+			}
+			renderTable(cmd, []string{"ALIAS", "QUERY", "APP", "PARAMS"}, rows)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&tenantID, "tenant", "", "Tenant ID (defaults to configured value)")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print favorites as JSON")
+	return cmd
+}
+
+func newTenantQueriesFavoritesRemoveCommand(env *Environment) *cobra.Command {
+	var tenantID string
+
+	cmd := &cobra.Command{
+		Use:     "remove <alias>",
+		Aliases: []string{"rm"},
+		Short:   "Remove a saved query favorite",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenant, err := resolveTenantID(envCtx, tenantID)
+			if err != nil {
+				return err
+			}
+			alias := strings.TrimPrefix(strings.TrimSpace(args[0]), favoritePrefix)
+			tc, ok := envCtx.Config.Tenants[tenant]
+			if _, exists := tc.Favorites[alias]; !ok || !exists {
+				return fmt.Errorf("favorite %s%s not found", favoritePrefix, alias)
+			}
+			delete(tc.Favorites, alias)
+			envCtx.Config.UpdateTenant(tenant, tc)
+			if err := envCtx.Save(); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed favorite %s%s\n", favoritePrefix, alias)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&tenantID, "tenant", "", "Tenant ID (defaults to configured value)")
+	return cmd
+}
+
+// resolveQueryFavorite looks up an @alias argument for tenantID.
+func resolveQueryFavorite(env *Environment, tenantID, target string) (configpkg.QueryFavorite, error) {
+	alias := strings.TrimPrefix(target, favoritePrefix)
+	if env != nil && env.Config != nil {
+		if fav, ok := env.Config.Tenants[tenantID].Favorites[alias]; ok {
+			return fav, nil
+		}
+	}
+	return configpkg.QueryFavorite{}, fmt.Errorf("favorite %s%s not found (see `tdb tenant queries fav list`)", favoritePrefix, alias)
+}
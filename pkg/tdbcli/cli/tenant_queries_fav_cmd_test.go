@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	cases := []struct {
+		term, text string
+		ok         bool
+	}{
+		{"rev", "daily-revenue", true},
+		{"dlyrev", "daily-revenue", true},
+		{"DAILY", "daily-revenue", true},
+		{"revd", "daily-revenue", false},
+		{"x", "", false},
+	}
+	for _, tc := range cases {
+		if _, ok := fuzzyScore(tc.term, tc.text); ok != tc.ok {
+			t.Fatalf("fuzzyScore(%q, %q) matched=%v, want %v", tc.term, tc.text, ok, tc.ok)
+		}
+	}
+	prefix, _ := fuzzyScore("rev", "revenue")
+	word, _ := fuzzyScore("rev", "daily-revenue")
+	inner, _ := fuzzyScore("rev", "prevent")
+	scattered, _ := fuzzyScore("rev", "r-e-v")
+	if !(prefix > word && word > inner && inner > scattered) {
+		t.Fatalf("unexpected ranking: prefix=%d word=%d inner=%d scattered=%d", prefix, word, inner, scattered)
+	}
+}
+
+func TestQueriesSearchRanksNameMatchesFirst(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddSavedQuery(clientpkg.SavedQuery{Name: "order-totals", Type: "sql", SQL: "select sum(revenue) from orders"})
+	srv.AddSavedQuery(clientpkg.SavedQuery{Name: "daily-revenue", Type: "sql", Collection: "orders"})
+	srv.AddSavedQuery(clientpkg.SavedQuery{Name: "active-users", Type: "sql", Collection: "users"})
+
+	out, errOut, err := runCommand(t, srv, newTenantQueriesSearchCommand, "revenue", "--raw")
+	if err != nil {
+		t.Fatalf("queries search failed: %v\n%s", err, errOut)
+	}
+	var matches []savedQueryMatch
+	if err := json.Unmarshal([]byte(out), &matches); err != nil {
+		t.Fatalf("decode matches: %v\n%s", err, out)
+	}
+	if len(matches) != 2 || matches[0].Name != "daily-revenue" || matches[0].MatchedIn != "name" || matches[1].MatchedIn != "sql" {
+		t.Fatalf("unexpected matches: %+v", matches)
+	}
+}
+
+func TestQueryFavoritesRunByAlias(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddSavedQuery(clientpkg.SavedQuery{Name: "daily-revenue", Type: "sql", Collection: "orders"})
+	srv.SetQueryResult("daily-revenue", []map[string]any{{"day": "2024-06-01", "total": 42}})
+
+	env := &Environment{
+		Config:     &configpkg.Config{Endpoint: srv.URL, DefaultTenant: "tn_test"},
+		ConfigPath: filepath.Join(t.TempDir(), "config.yaml"),
+	}
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := newTenantQueriesGroupCommand(env)
+		cmd.SetArgs(args)
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("%v failed: %v\n%s", args, err, out.String())
+		}
+		return out.String()
+	}
+
+	run("fav", "add", "rev", "daily-revenue")
+	saved, err := configpkg.Load(env.ConfigPath)
+	if err != nil || saved.Tenants["tn_test"].Favorites["rev"].Query != "daily-revenue" {
+		t.Fatalf("favorite not persisted: %+v (%v)", saved, err)
+	}
+	if out := run("fav", "list"); !strings.Contains(out, "@rev") || !strings.Contains(out, "daily-revenue") {
+		t.Fatalf("unexpected favorites list:\n%s", out)
+	}
+	if out := run("run", "@rev", "--api-key", clienttest.APIKey); !strings.Contains(out, "2024-06-01") {
+		t.Fatalf("expected favorite results:\n%s", out)
+	}
+
+	cmd := newTenantQueriesGroupCommand(env)
+	cmd.SetArgs([]string{"run", "@missing", "--api-key", clienttest.APIKey})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "favorite @missing not found") {
+		t.Fatalf("expected missing favorite error, got %v", err)
+	}
+
+	run("fav", "rm", "@rev")
+	if out := run("fav", "list"); !strings.Contains(out, "No favorites saved") {
+		t.Fatalf("expected favorite to be removed:\n%s", out)
+	}
+}
@@ -0,0 +1,427 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// pipelineInsertBatch is the number of rows written per bulk request when a
+// step stores its results in a collection.
+const pipelineInsertBatch = 500
+
+// pipelineFile is the definition executed by tdb tenant queries pipeline.
+type pipelineFile struct {
+	Steps []pipelineStep `yaml:"steps"`
+}
+
+// pipelineStep executes one saved query. Params may reference the results of
+// earlier steps; Into stores the rows in a new collection that later steps
+// can query.
+type pipelineStep struct {
+	Name    string         `yaml:"name"`
+	Query   string         `yaml:"query"`
+	QueryID string         `yaml:"query_id"`
+	Params  map[string]any `yaml:"params"`
+	Into    string         `yaml:"into"`
+	Output  string         `yaml:"output"`
+	OnError string         `yaml:"on_error"`
+	Retries int            `yaml:"retries"`
+	Extra   map[string]any `yaml:",inline"`
+}
+
+// pipelineStepResult records what a step did, for the summary and for ${...}
+// references from later steps.
+type pipelineStepResult struct {
+	Name       string `json:"name"`
+	Query      string `json:"query"`
+	Status     string `json:"status"`
+	Rows       int    `json:"rows"`
+	Attempts   int    `json:"attempts"`
+	Into       string `json:"into,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+
+	captured map[string]any
+}
+
+func newTenantQueriesPipelineCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var file string
+	var outputDir string
+	var keepTemp bool
+	var dryRun bool
+	var asJSON bool
+	stats := newOperationStats()
+
+	cmd := &cobra.Command{
+		Use:   "pipeline -f <pipeline.yaml>",
+		Short: "Run saved queries in sequence, feeding results into later steps",
+		Long: `Execute a multi-stage report from a YAML file. Each entry under "steps" runs a
+saved query (query: <name> or query_id: <id>) with optional params.
+
+Params may reference earlier steps with ${<step>.<path>}, where path is
+count, rows, first.<column> (the first row), or values.<column> (that column
+across all rows, as a list). A value that is exactly one reference keeps the
+referenced type, so "ids: ${top.values.customer_id}" passes a list.
+
+A step with "into: <collection>" also writes its rows to that collection so
+later saved queries can read them. The collection must not exist yet; it is
+deleted when the pipeline finishes unless --keep-temp is given.
+
+By default a failing step stops the pipeline. "retries: N" retries a step,
+and "on_error: continue" records the failure and moves on; later steps that
+reference a failed step fail too. "output: <file>" writes a step's rows to a
+.json or .jsonl file, and --output-dir captures every step.`,
+		Example: `  # pipeline.yaml
+  steps:
+    - name: top
+      query: top-customers
+      params: {since: "2024-01-01", limit: 50}
+    - name: orders
+      query: orders-by-customer
+      params: {customer_ids: "${top.values.customer_id}"}
+      retries: 2
+      output: orders.jsonl
+    - name: staged
+      query: large-orders
+      into: tmp_large_orders
+    - name: summary
+      query: summarize-tmp-large-orders
+      on_error: continue
+
+  tdb tenant queries pipeline -f pipeline.yaml --output-dir pipeline-output/`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(file) == "" {
+				return errors.New("--file is required")
+			}
+			steps, err := loadPipelineFile(file)
+			if err != nil {
+				return err
+			}
+			if dryRun {
+				rows := make([][]string, 0, len(steps))
+				for i, step := range steps {
+					rows = append(rows, []string{strconv.Itoa(i + 1), step.Name, step.queryLabel(), step.Into, step.OnError, strconv.Itoa(step.Retries)})
+				}
+				renderTable(cmd, []string{"#", "NAME", "QUERY", "INTO", "ON ERROR", "RETRIES"}, rows)
+				return nil
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			if outputDir != "" {
+				if err := os.MkdirAll(outputDir, 0o755); err != nil {
+					return err
+				}
+			}
+
+			results := make([]*pipelineStepResult, len(steps))
+			captured := map[string]map[string]any{}
+			var created []string
+			var runErr error
+			for i, step := range steps {
+				result := &pipelineStepResult{Name: step.Name, Query: step.queryLabel(), Into: step.Into, Status: "skipped"}
+				results[i] = result
+				if runErr != nil {
+					continue
+				}
+				started := time.Now()
+				var err error
+				for result.Attempts = 1; ; result.Attempts++ {
+					err = executePipelineStep(cmd.Context(), tenantClient, auth.appID, step, captured, result)
+					if err == nil || result.Attempts > step.Retries || cmd.Context().Err() != nil {
+						break
+					}
+					fmt.Fprintf(cmd.ErrOrStderr(), "Step %s failed (attempt %d of %d): %v\n", step.Name, result.Attempts, step.Retries+1, err)
+				}
+				if err == nil && step.Into != "" {
+					var createdInto bool
+					createdInto, err = storePipelineRows(cmd.Context(), tenantClient, auth.appID, step.Into, result.captured["rows"].([]map[string]any))
+					if createdInto {
+						created = append(created, step.Into)
+					}
+				}
+				if err == nil && step.Output != "" {
+					err = writePipelineOutput(step.Output, result.captured["rows"].([]map[string]any))
+				}
+				result.DurationMS = time.Since(started).Milliseconds()
+				if err != nil {
+					result.Status = "failed"
+					result.Error = err.Error()
+					stats.addError(fmt.Sprintf("step %s failed: %v", step.Name, err))
+					if step.OnError != "continue" {
+						runErr = fmt.Errorf("step %d (%s) failed: %w", i+1, step.Name, err)
+					}
+					continue
+				}
+				result.Status = "ok"
+				captured[step.Name] = result.captured
+				if outputDir != "" {
+					if err := writeRunCapture(outputDir, i+1, step.Name, result.captured); err != nil {
+						return err
+					}
+				}
+			}
+
+			if !keepTemp {
+				// Clean up even when the run was interrupted.
+				cleanupCtx := context.WithoutCancel(cmd.Context())
+				for _, name := range created {
+					if err := tenantClient.DeleteCollection(cleanupCtx, name, auth.appID); err != nil {
+						logWarn(cmd.ErrOrStderr(), fmt.Sprintf("failed to delete temporary collection %s: %v", name, err))
+					}
+				}
+			}
+
+			succeeded := 0
+			for _, result := range results {
+				if result.Status == "ok" {
+					succeeded++
+				}
+			}
+			stats.count("steps", len(steps))
+			stats.count("succeeded", succeeded)
+
+			if asJSON {
+				if err := printJSON(cmd, results); err != nil {
+					return err
+				}
+			} else {
+				rows := make([][]string, 0, len(results))
+				for i, result := range results {
+					status := result.Status
+					if result.Error != "" {
+						status += ": " + result.Error
+					}
+					rows = append(rows, []string{strconv.Itoa(i + 1), result.Name, result.Query, strconv.Itoa(result.Rows), result.Into, status, strconv.Itoa(result.Attempts), fmt.Sprintf("%dms", result.DurationMS)})
+				}
+				renderTable(cmd, []string{"#", "NAME", "QUERY", "ROWS", "INTO", "STATUS", "ATTEMPTS", "DURATION"}, rows)
+				fmt.Fprintf(cmd.OutOrStdout(), "%d of %d steps succeeded\n", succeeded, len(steps))
+				if keepTemp && len(created) > 0 {
+					fmt.Fprintf(cmd.OutOrStdout(), "Kept temporary collections: %s\n", strings.Join(created, ", "))
+				}
+			}
+			return runErr
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().StringVarP(&file, "file", "f", "", "YAML file listing the pipeline steps")
+	cmd.Flags().StringVar(&outputDir, "output-dir", "", "Write each step's results to <dir>/<NN>-<name>.json")
+	cmd.Flags().BoolVar(&keepTemp, "keep-temp", false, "Keep the collections written by \"into\" steps")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate the file and list the steps without running them")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the summary as JSON")
+	bindStatsJSON(cmd, stats)
+	return cmd
+}
+
+func (step pipelineStep) queryLabel() string {
+	if step.QueryID != "" {
+		return "id:" + step.QueryID
+	}
+	return step.Query
+}
+
+// loadPipelineFile reads and validates a pipeline definition.
+func loadPipelineFile(path string) ([]pipelineStep, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var parsed pipelineFile
+	if err := yaml.Unmarshal(raw, &parsed); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(parsed.Steps) == 0 {
+		return nil, fmt.Errorf("%s: no steps defined", path)
+	}
+	seen := map[string]bool{}
+	intos := map[string]bool{}
+	for i := range parsed.Steps {
+		step := &parsed.Steps[i]
+		if strings.TrimSpace(step.Name) == "" {
+			step.Name = fmt.Sprintf("step%d", i+1)
+		}
+		if seen[step.Name] {
+			return nil, fmt.Errorf("%s: duplicate step name %q", path, step.Name)
+		}
+		seen[step.Name] = true
+		if err := validatePipelineStep(*step); err != nil {
+			return nil, fmt.Errorf("%s: step %d (%s): %w", path, i+1, step.Name, err)
+		}
+		if step.OnError == "" {
+			step.OnError = "fail"
+		}
+		if step.Into != "" {
+			if intos[step.Into] {
+				return nil, fmt.Errorf("%s: step %d (%s): collection %s is already written by an earlier step", path, i+1, step.Name, step.Into)
+			}
+			intos[step.Into] = true
+		}
+	}
+	return parsed.Steps, nil
+}
+
+func validatePipelineStep(step pipelineStep) error {
+	for key := range step.Extra {
+		return fmt.Errorf("unknown field %q", key)
+	}
+	if (strings.TrimSpace(step.Query) == "") == (strings.TrimSpace(step.QueryID) == "") {
+		return errors.New("exactly one of query or query_id is required")
+	}
+	switch step.OnError {
+	case "", "fail", "continue":
+	default:
+		return fmt.Errorf("unsupported on_error %q (supported: fail, continue)", step.OnError)
+	}
+	if step.Retries < 0 {
+		return errors.New("retries must be zero or positive")
+	}
+	if step.Output != "" {
+		if lower := strings.ToLower(step.Output); !strings.HasSuffix(lower, ".json") && !strings.HasSuffix(lower, ".jsonl") {
+			return fmt.Errorf("output %s must end in .json or .jsonl", step.Output)
+		}
+	}
+	return nil
+}
+
+// executePipelineStep resolves references in the step's params, runs its
+// saved query, and records the rows in result.
+func executePipelineStep(ctx context.Context, tenantClient *clientpkg.TenantClient, appID string, step pipelineStep, captured map[string]map[string]any, result *pipelineStepResult) error {
+	var params any = map[string]any{}
+	if step.Params != nil {
+		resolved, err := resolveRunReferences(map[string]any(step.Params), captured)
+		if err != nil {
+			return err
+		}
+		params = resolved
+	}
+	payload, err := json.Marshal(map[string]any{"params": params})
+	if err != nil {
+		return fmt.Errorf("encode params: %w", err)
+	}
+	var resp *clientpkg.SavedQueryExecutionResult
+	if step.QueryID != "" {
+		resp, err = tenantClient.ExecuteSavedQueryByID(ctx, step.QueryID, payload, appID)
+	} else {
+		resp, err = tenantClient.ExecuteSavedQueryByName(ctx, step.Query, payload, appID)
+	}
+	if err != nil {
+		return err
+	}
+	rows := resp.Items
+	if rows == nil {
+		rows = []map[string]any{}
+	}
+	result.Rows = len(rows)
+	result.captured = pipelineCapture(rows)
+	return nil
+}
+
+// pipelineCapture exposes a step's rows to ${...} references.
+func pipelineCapture(rows []map[string]any) map[string]any {
+	values := map[string]any{}
+	var columns []string
+	for _, row := range rows {
+		for column := range row {
+			if _, ok := values[column]; !ok {
+				values[column] = []any{}
+				columns = append(columns, column)
+			}
+		}
+	}
+	sort.Strings(columns)
+	for _, column := range columns {
+		list := make([]any, 0, len(rows))
+		for _, row := range rows {
+			if value, ok := row[column]; ok {
+				list = append(list, value)
+			}
+		}
+		values[column] = list
+	}
+	captured := map[string]any{"rows": rows, "count": len(rows), "values": values}
+	if len(rows) > 0 {
+		captured["first"] = rows[0]
+	}
+	return captured
+}
+
+// storePipelineRows creates collection with a schema inferred from rows and
+// inserts them, reporting whether the collection was created. An existing
+// collection is never written to.
+func storePipelineRows(ctx context.Context, tenantClient *clientpkg.TenantClient, appID, collection string, rows []map[string]any) (bool, error) {
+	if _, err := tenantClient.GetCollection(ctx, collection, appID); err == nil {
+		return false, fmt.Errorf("collection %s already exists; choose another name for into", collection)
+	} else if !isNotFoundError(err) {
+		return false, fmt.Errorf("check collection %s: %w", collection, err)
+	}
+	samples := make([]any, len(rows))
+	for i, row := range rows {
+		samples[i] = row
+	}
+	schema, err := json.Marshal(inferSchemaFromSamples(samples, false))
+	if err != nil {
+		return false, err
+	}
+	if _, err := tenantClient.CreateCollection(ctx, clientpkg.CreateCollectionRequest{Name: collection, Schema: string(schema), AppID: appID}); err != nil {
+		return false, fmt.Errorf("create collection %s: %w", collection, err)
+	}
+	if len(rows) == 0 {
+		return true, nil
+	}
+	payload, err := json.Marshal(rows)
+	if err != nil {
+		return true, err
+	}
+	batches, err := chunkJSONArray(payload, pipelineInsertBatch)
+	if err != nil {
+		return true, err
+	}
+	for _, batch := range batches {
+		if _, err := tenantClient.BulkCreateDocuments(ctx, collection, batch, appID); err != nil {
+			return true, fmt.Errorf("insert into %s: %w", collection, err)
+		}
+	}
+	return true, nil
+}
+
+// writePipelineOutput writes rows as a JSON array or, for .jsonl files, one
+// row per line.
+func writePipelineOutput(path string, rows []map[string]any) error {
+	var data []byte
+	if strings.HasSuffix(strings.ToLower(path), ".jsonl") {
+		for _, row := range rows {
+			line, err := json.Marshal(row)
+			if err != nil {
+				return err
+			}
+			data = append(append(data, line...), '\n')
+		}
+	} else {
+		encoded, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return err
+		}
+		data = append(encoded, '\n')
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func writePipeline(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write pipeline: %v", err)
+	}
+}
+
+func TestQueriesPipelineChainsSteps(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "orders"})
+	srv.AddDocument("orders", map[string]any{"customer_id": "c1", "total": 120})
+	srv.AddDocument("orders", map[string]any{"customer_id": "c2", "total": 80})
+	srv.AddSavedQuery(clientpkg.SavedQuery{Name: "top-customers", Type: "dsl", Collection: "orders"})
+	srv.SetQueryResult("top-customers", []map[string]any{{"customer_id": "c1"}, {"customer_id": "c2"}})
+	srv.AddSavedQuery(clientpkg.SavedQuery{Name: "orders-by-customer", Type: "dsl", Collection: "orders"})
+	srv.AddSavedQuery(clientpkg.SavedQuery{Name: "staged-orders", Type: "dsl", Collection: "tmp_orders"})
+
+	var sent []map[string]any
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/execute") {
+			var body map[string]any
+			var buf bytes.Buffer
+			_, _ = buf.ReadFrom(r.Body)
+			_ = json.Unmarshal(buf.Bytes(), &body)
+			sent = append(sent, body)
+			r.Body = http.NoBody
+		}
+		tenantRoutes.ServeHTTP(w, r)
+	})
+
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "staged.jsonl")
+	pipeline := filepath.Join(dir, "pipeline.yaml")
+	writePipeline(t, pipeline, `steps:
+  - name: top
+    query: top-customers
+  - name: orders
+    query: orders-by-customer
+    params: {ids: "${top.values.customer_id}", first: "${top.first.customer_id}", n: "${top.count}"}
+    into: tmp_orders
+  - name: staged
+    query: staged-orders
+    output: `+outPath+`
+  - name: broken
+    query: missing-query
+    on_error: continue
+`)
+
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+	run := func(path string) (string, error) {
+		out, _, err := runCommandWithEnv(t, env, newTenantQueriesPipelineCommand, "-f", path)
+		return out, err
+	}
+
+	out, err := run(pipeline)
+	if err != nil {
+		t.Fatalf("pipeline failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "3 of 4 steps succeeded") {
+		t.Fatalf("unexpected summary:\n%s", out)
+	}
+	want := map[string]any{"ids": []any{"c1", "c2"}, "first": "c1", "n": float64(2)}
+	if len(sent) != 4 || !reflect.DeepEqual(sent[1]["params"], want) {
+		t.Fatalf("expected step params from the first step, got %v", sent)
+	}
+	staged, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(staged)), "\n"); len(lines) != 2 || !strings.Contains(lines[0], `"customer_id"`) {
+		t.Fatalf("expected the temporary collection rows in the output, got:\n%s", staged)
+	}
+	if _, err := srv.TenantClient(t).GetCollection(context.Background(), "tmp_orders", ""); err == nil {
+		t.Fatalf("expected the temporary collection to be deleted")
+	}
+
+	failing := filepath.Join(dir, "failing.yaml")
+	writePipeline(t, failing, `steps:
+  - name: broken
+    query: missing-query
+    retries: 1
+  - name: top
+    query: top-customers
+`)
+	sent = nil
+	out, err = run(failing)
+	if err == nil || !strings.Contains(err.Error(), "step 1 (broken) failed") {
+		t.Fatalf("expected the first step to stop the pipeline, got %v", err)
+	}
+	if len(sent) != 2 || !strings.Contains(out, "skipped") {
+		t.Fatalf("expected two attempts and a skipped step, got %d requests:\n%s", len(sent), out)
+	}
+}
+
+func TestStorePipelineRowsStopsWhenCollectionLookupFails(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	routes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/collections/tmp_orders") {
+			http.Error(w, `{"error":"unavailable"}`, http.StatusServiceUnavailable)
+			return
+		}
+		routes.ServeHTTP(w, r)
+	})
+
+	created, err := storePipelineRows(context.Background(), srv.TenantClient(t), "", "tmp_orders", []map[string]any{{"n": 1}})
+	if err == nil || created || !strings.Contains(err.Error(), "check collection tmp_orders") {
+		t.Fatalf("expected the lookup failure to stop the step, got created=%v err=%v", created, err)
+	}
+	for _, request := range srv.Requests() {
+		if strings.HasPrefix(request, http.MethodPost) {
+			t.Fatalf("expected no collection to be created, got %v", srv.Requests())
+		}
+	}
+}
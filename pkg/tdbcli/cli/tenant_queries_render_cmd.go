@@ -0,0 +1,287 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	vegaScriptURL      = "https://cdn.jsdelivr.net/npm/vega@5"
+	vegaLiteScriptURL  = "https://cdn.jsdelivr.net/npm/vega-lite@5"
+	vegaEmbedScriptURL = "https://cdn.jsdelivr.net/npm/vega-embed@6"
+	vegaLiteSchemaURL  = "https://vega.github.io/schema/vega-lite/v5.json"
+)
+
+// dashboardSection holds one executed saved query as rendered in the report.
+type dashboardSection struct {
+	Name      string
+	Headers   []string
+	Rows      [][]string
+	RowCount  int
+	Truncated bool
+	ChartID   string
+	Chart     map[string]any
+	Error     string
+}
+
+type dashboardPage struct {
+	Title     string
+	Generated string
+	Sections  []dashboardSection
+	HasCharts bool
+	Scripts   []string
+}
+
+func newTenantQueriesRenderCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var queries string
+	var outPath string
+	var title string
+	var maxRows int
+	var noCharts bool
+
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Render saved query results into a static HTML dashboard",
+		Long: `Execute saved queries by name and render their results into a single static
+HTML page with a table per query and, where the rows have a label column and a
+numeric column, a vega-lite chart.
+
+The page has no server dependency, so it can be emailed or published as a CI
+artifact. Charts load vega-lite from a CDN; use --no-charts for a fully
+self-contained page. Failed queries are shown in the report and make the
+command exit non-zero once the page has been written.`,
+		Example: `  # Render three queries into report.html
+  tdb tenant queries render --queries daily-signups,top-products,churn --out report.html
+
+  # Tables only, capped at 100 rows per query
+  tdb tenant queries render --queries top-products --max-rows 100 --no-charts --out report.html`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			names := splitCommaList(queries)
+			if len(names) == 0 {
+				return errors.New("--queries is required")
+			}
+			if maxRows <= 0 {
+				return errors.New("--max-rows must be positive")
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+
+			page := dashboardPage{Title: strings.TrimSpace(title), Generated: formatTime(time.Now())}
+			if page.Title == "" {
+				page.Title = "Saved query report"
+			}
+			failed := 0
+			for i, name := range names {
+				section := dashboardSection{Name: name, ChartID: fmt.Sprintf("chart-%d", i)}
+				result, err := tenantClient.ExecuteSavedQueryByName(cmd.Context(), name, nil, auth.appID)
+				if err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Query %s failed: %v\n", name, err)
+					section.Error = err.Error()
+					failed++
+				} else {
+					section = buildDashboardSection(section, result.Items, maxRows, !noCharts)
+				}
+				if section.Chart != nil {
+					page.HasCharts = true
+				}
+				page.Sections = append(page.Sections, section)
+			}
+			if page.HasCharts {
+				page.Scripts = []string{vegaScriptURL, vegaLiteScriptURL, vegaEmbedScriptURL}
+			}
+
+			var buf bytes.Buffer
+			if err := renderDashboardHTML(&buf, page); err != nil {
+				return err
+			}
+			if trimmed := strings.TrimSpace(outPath); trimmed != "" {
+				clean := filepath.Clean(trimmed)
+				if dir := filepath.Dir(clean); dir != "." && dir != "" {
+					if err := os.MkdirAll(dir, 0o755); err != nil {
+						return err
+					}
+				}
+				if err := os.WriteFile(clean, buf.Bytes(), 0o644); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "Rendered %d queries to %s\n", len(names), clean)
+			} else if _, err := cmd.OutOrStdout().Write(buf.Bytes()); err != nil {
+				return err
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d queries failed", failed, len(names))
+			}
+			return nil
+		},
+	}
+
+	auth.bindWithApp(cmd)
+	cmd.Flags().StringVar(&queries, "queries", "", "Comma-separated saved query names to execute")
+	cmd.Flags().StringVar(&outPath, "out", "", "Write the HTML report to the specified file (defaults to stdout)")
+	cmd.Flags().StringVar(&title, "title", "", "Report title")
+	cmd.Flags().IntVar(&maxRows, "max-rows", 500, "Maximum table rows rendered per query")
+	cmd.Flags().BoolVar(&noCharts, "no-charts", false, "Render tables only, without vega-lite charts")
+	return cmd
+}
+
+func buildDashboardSection(section dashboardSection, items []map[string]any, maxRows int, charts bool) dashboardSection {
+	section.RowCount = len(items)
+	section.Headers = savedQueryResultColumns(items)
+	shown := items
+	if len(shown) > maxRows {
+		shown = shown[:maxRows]
+		section.Truncated = true
+	}
+	section.Rows = savedQueryResultRows(shown, section.Headers)
+	if charts {
+		section.Chart = dashboardChartSpec(items, section.Headers, maxRows)
+	}
+	return section
+}
+
+// dashboardChartSpec picks the first non-numeric column as the label axis and
+// the first numeric column as the value axis. It returns nil when the rows do
+// not have that shape. Date-like labels produce a line chart, others a bar
+// chart.
+func dashboardChartSpec(items []map[string]any, headers []string, maxRows int) map[string]any {
+	if len(items) == 0 {
+		return nil
+	}
+	var labelField, valueField string
+	for _, header := range headers {
+		if isNumericColumn(items, header) {
+			if valueField == "" {
+				valueField = header
+			}
+		} else if labelField == "" {
+			labelField = header
+		}
+	}
+	if labelField == "" || valueField == "" {
+		return nil
+	}
+	values := make([]map[string]any, 0, len(items))
+	temporal := true
+	for i, row := range items {
+		if i >= maxRows {
+			break
+		}
+		label := stringifyValue(row[labelField])
+		if !isDateLike(label) {
+			temporal = false
+		}
+		values = append(values, map[string]any{labelField: label, valueField: row[valueField]})
+	}
+	mark, labelType := "bar", "nominal"
+	if temporal {
+		mark, labelType = "line", "temporal"
+	}
+	return map[string]any{
+		"$schema": vegaLiteSchemaURL,
+		"width":   "container",
+		"height":  240,
+		"data":    map[string]any{"values": values},
+		"mark":    map[string]any{"type": mark, "tooltip": true},
+		"encoding": map[string]any{
+			"x": map[string]any{"field": labelField, "type": labelType, "sort": nil},
+			"y": map[string]any{"field": valueField, "type": "quantitative"},
+		},
+	}
+}
+
+func isNumericColumn(items []map[string]any, header string) bool {
+	seen := false
+	for _, row := range items {
+		switch row[header].(type) {
+		case nil:
+		case float64, int, int64:
+			seen = true
+		default:
+			return false
+		}
+	}
+	return seen
+}
+
+func isDateLike(value string) bool {
+	for _, layout := range []string{time.RFC3339, "2006-01-02", "2006-01"} {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+var dashboardTemplate = template.Must(template.New("dashboard").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+{{- range .Scripts}}
+<script src="{{.}}"></script>
+{{- end}}
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem auto; max-width: 1100px; padding: 0 1rem; color: #1f2328; }
+h1 { margin-bottom: 0.25rem; }
+.meta { color: #656d76; margin-top: 0; }
+section { margin: 2rem 0; }
+.chart { width: 100%; margin-bottom: 1rem; }
+.error { color: #cf222e; }
+table { border-collapse: collapse; width: 100%; font-size: 0.9rem; }
+th, td { border: 1px solid #d0d7de; padding: 0.35rem 0.6rem; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+tr:nth-child(even) td { background: #fbfcfd; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="meta">Generated {{.Generated}}</p>
+{{- range .Sections}}
+<section id="{{.Name}}">
+<h2>{{.Name}}</h2>
+{{- if .Error}}
+<p class="error">Query failed: {{.Error}}</p>
+{{- else if not .Rows}}
+<p class="meta">No rows returned</p>
+{{- else}}
+<p class="meta">{{.RowCount}} rows{{if .Truncated}} (showing first {{len .Rows}}){{end}}</p>
+{{- if .Chart}}
+<div class="chart" id="{{.ChartID}}"></div>
+<script>vegaEmbed("#{{.ChartID}}", {{.Chart}}, {actions: false});</script>
+{{- end}}
+<table>
+<thead><tr>{{range .Headers}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{- range .Rows}}
+<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+</section>
+{{- end}}
+</body>
+</html>
+`))
+
+func renderDashboardHTML(w io.Writer, page dashboardPage) error {
+	return dashboardTemplate.Execute(w, page)
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestDashboardChartSpec(t *testing.T) {
+	items := []map[string]any{
+		{"day": "2024-05-01", "signups": 3.0, "note": "a"},
+		{"day": "2024-05-02", "signups": 5.0, "note": nil},
+	}
+	spec := dashboardChartSpec(items, savedQueryResultColumns(items), 10)
+	if spec == nil {
+		t.Fatalf("expected a chart spec")
+	}
+	encoding := spec["encoding"].(map[string]any)
+	x := encoding["x"].(map[string]any)
+	y := encoding["y"].(map[string]any)
+	if x["field"] != "day" || x["type"] != "temporal" || y["field"] != "signups" {
+		t.Fatalf("unexpected encoding %+v", encoding)
+	}
+	if mark := spec["mark"].(map[string]any); mark["type"] != "line" {
+		t.Fatalf("expected line chart for dates, got %+v", mark)
+	}
+
+	labels := []map[string]any{{"product": "widget", "sold": 4.0}}
+	if spec := dashboardChartSpec(labels, savedQueryResultColumns(labels), 10); spec["mark"].(map[string]any)["type"] != "bar" {
+		t.Fatalf("expected bar chart for labels, got %+v", spec)
+	}
+	textOnly := []map[string]any{{"name": "Ada"}}
+	if spec := dashboardChartSpec(textOnly, []string{"name"}, 10); spec != nil {
+		t.Fatalf("expected no chart without numeric column, got %+v", spec)
+	}
+}
+
+func TestQueriesRenderCommandWritesReport(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddSavedQuery(clientpkg.SavedQuery{Name: "top-products", Collection: "orders"})
+	srv.SetQueryResult("top-products", []map[string]any{
+		{"product": "<widget>", "sold": 4.0},
+		{"product": "gadget", "sold": 2.0},
+	})
+
+	outPath := filepath.Join(t.TempDir(), "report.html")
+	out, errOut, err := runCommand(t, srv, newTenantQueriesRenderCommand, "--queries", "top-products,missing", "--max-rows", "1", "--out", outPath)
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 queries failed") {
+		t.Fatalf("expected partial failure, got %v\n%s%s", err, out, errOut)
+	}
+
+	html, readErr := os.ReadFile(outPath)
+	if readErr != nil {
+		t.Fatalf("read report: %v", readErr)
+	}
+	page := string(html)
+	for _, want := range []string{
+		"<h2>top-products</h2>",
+		"2 rows (showing first 1)",
+		"&lt;widget&gt;",
+		"vegaEmbed(\"#chart-0\"",
+		vegaLiteScriptURL,
+		"<h2>missing</h2>",
+		"Query failed:",
+	} {
+		if !strings.Contains(page, want) {
+			t.Fatalf("report missing %q:\n%s", want, page)
+		}
+	}
+	if strings.Contains(page, "<widget>") {
+		t.Fatalf("expected row values to be escaped")
+	}
+}
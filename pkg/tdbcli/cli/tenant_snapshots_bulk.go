@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// collectionSnapshotResult records the outcome of one snapshot created by
+// snapshots create --all-collections.
+type collectionSnapshotResult struct {
+	Collection   string              `json:"collection"`
+	CollectionID string              `json:"collection_id"`
+	Snapshot     *clientpkg.Snapshot `json:"snapshot,omitempty"`
+	Error        string              `json:"error,omitempty"`
+}
+
+type snapshotCreateFunc func(ctx context.Context, request clientpkg.CreateSnapshotRequest) (*clientpkg.Snapshot, error)
+
+// excludeCollections drops collections matched by any of the exclude
+// patterns (names, IDs, globs, or re: regular expressions).
+func excludeCollections(all []clientpkg.Collection, exclude []string) ([]clientpkg.Collection, error) {
+	patterns, err := parseCollectionPatterns(exclude)
+	if err != nil {
+		return nil, err
+	}
+	if len(patterns) == 0 {
+		return all, nil
+	}
+	selected := make([]clientpkg.Collection, 0, len(all))
+	for _, col := range all {
+		skip := false
+		for _, pattern := range patterns {
+			if pattern.matches(col) {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			selected = append(selected, col)
+		}
+	}
+	return selected, nil
+}
+
+// collectionSnapshotName joins the shared prefix and the collection name.
+func collectionSnapshotName(prefix, collection string) string {
+	return strings.TrimSpace(prefix) + "-" + collection
+}
+
+// createCollectionSnapshots creates one snapshot per collection using up to
+// concurrency parallel requests. Results are sorted by collection name.
+func createCollectionSnapshots(ctx context.Context, create snapshotCreateFunc, collections []clientpkg.Collection, base clientpkg.CreateSnapshotRequest, concurrency int) []collectionSnapshotResult {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	results := make([]collectionSnapshotResult, len(collections))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				col := collections[idx]
+				req := base
+				req.CollectionID = col.ID
+				req.Name = collectionSnapshotName(base.Name, col.Name)
+				result := collectionSnapshotResult{Collection: col.Name, CollectionID: col.ID}
+				snapshot, err := create(ctx, req)
+				if err != nil {
+					result.Error = err.Error()
+				} else {
+					result.Snapshot = snapshot
+				}
+				results[idx] = result
+			}
+		}()
+	}
+	for idx := range collections {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Collection < results[j].Collection
+	})
+	return results
+}
+
+// reportCollectionSnapshots prints the consolidated summary and returns an
+// error when any snapshot failed.
+func reportCollectionSnapshots(cmd *cobra.Command, results []collectionSnapshotResult, raw bool) error {
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+	}
+	if raw {
+		if err := printJSON(cmd, results); err != nil {
+			return err
+		}
+	} else {
+		rows := make([][]string, 0, len(results))
+		for _, result := range results {
+			if result.Error != "" {
+				rows = append(rows, []string{result.Collection, "-", "-", "-", "-", "FAILED: " + result.Error})
+				continue
+			}
+			snap := result.Snapshot
+			rows = append(rows, []string{
+				result.Collection,
+				snap.ID,
+				snap.Name,
+				fmt.Sprintf("%d", snap.DocumentCount),
+				formatBytes(snap.SizeBytes),
+				"OK",
+			})
+		}
+		renderTable(cmd, []string{"COLLECTION", "SNAPSHOT ID", "NAME", "DOCS", "SIZE", "STATUS"}, rows)
+		fmt.Fprintf(cmd.OutOrStdout(), "\n%d of %d snapshots created\n", len(results)-failed, len(results))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d snapshots failed", failed, len(results))
+	}
+	return nil
+}
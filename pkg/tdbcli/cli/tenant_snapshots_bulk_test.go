@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+func TestExcludeCollections(t *testing.T) {
+	all := []clientpkg.Collection{{ID: "c1", Name: "users"}, {ID: "c2", Name: "orders"}, {ID: "c3", Name: "audit"}}
+	got, err := excludeCollections(all, []string{"audit", "c2"})
+	if err != nil || len(got) != 1 || got[0].Name != "users" {
+		t.Fatalf("unexpected selection: %+v (%v)", got, err)
+	}
+	if got, _ := excludeCollections(all, nil); len(got) != 3 {
+		t.Fatalf("expected all collections, got %d", len(got))
+	}
+	if got, _ := excludeCollections(all, []string{"*s"}); len(got) != 1 || got[0].Name != "audit" {
+		t.Fatalf("unexpected glob exclusion: %+v", got)
+	}
+}
+
+func TestCreateCollectionSnapshots(t *testing.T) {
+	collections := []clientpkg.Collection{{ID: "c2", Name: "orders"}, {ID: "c1", Name: "users"}, {ID: "c3", Name: "broken"}}
+	var calls int32
+	create := func(ctx context.Context, req clientpkg.CreateSnapshotRequest) (*clientpkg.Snapshot, error) {
+		atomic.AddInt32(&calls, 1)
+		if req.CollectionID == "c3" {
+			return nil, errors.New("boom")
+		}
+		if !req.Encrypt {
+			t.Errorf("expected base request options to be kept")
+		}
+		return &clientpkg.Snapshot{ID: "snap-" + req.CollectionID, Name: req.Name}, nil
+	}
+	base := clientpkg.CreateSnapshotRequest{Name: "nightly", Encrypt: true}
+	results := createCollectionSnapshots(context.Background(), create, collections, base, 2)
+	if calls != 3 {
+		t.Fatalf("expected 3 create calls, got %d", calls)
+	}
+	if len(results) != 3 || results[0].Collection != "broken" || results[1].Collection != "orders" || results[2].Collection != "users" {
+		t.Fatalf("unexpected result order: %+v", results)
+	}
+	if results[0].Error != "boom" || results[0].Snapshot != nil {
+		t.Fatalf("expected failure recorded, got %+v", results[0])
+	}
+	if results[1].Snapshot == nil || results[1].Snapshot.Name != "nightly-orders" {
+		t.Fatalf("expected prefixed snapshot name, got %+v", results[1].Snapshot)
+	}
+}
@@ -1,7 +1,10 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -21,6 +24,9 @@ func newTenantSnapshotsCommand(env *Environment) *cobra.Command {
 	cmd.AddCommand(newTenantSnapshotsRestoreCommand(env))
 	cmd.AddCommand(newTenantSnapshotsDeleteCommand(env))
 	cmd.AddCommand(newTenantSnapshotsGetCommand(env))
+	cmd.AddCommand(newTenantSnapshotsVerifyFreshnessCommand(env))
+	cmd.AddCommand(newTenantSnapshotsDiffCommand(env))
+	cmd.AddCommand(newTenantSnapshotsKeysCommand(env))
 
 	return cmd
 }
@@ -125,13 +131,24 @@ func newTenantSnapshotsCreateCommand(env *Environment) *cobra.Command {
 	var incremental bool
 	var parentSnapshotID string
 	var encrypt bool
+	var encryptionKeyID string
 	var storageProvider string
+	var allCollections bool
+	var collectionPatterns string
+	var exclude string
+	var dryRun bool
+	var concurrency int
 	var raw bool
 
 	cmd := &cobra.Command{
 		Use:   "create --collection COLLECTION_ID --name NAME",
 		Short: "Create a new snapshot",
-		Long:  "Create a full or incremental snapshot of a collection",
+		Long: `Create a full or incremental snapshot of a collection.
+
+With --all-collections a snapshot is created for every collection in the tenant,
+concurrently, each named "<name>-<collection>", followed by a summary table.
+--collections does the same for the collections matching a list of names, IDs,
+globs, or re: regular expressions; add --dry-run to list the matches first.`,
 		Example: `  # Create a full snapshot
   tdb tenant snapshots create --api-key $API_KEY --collection my-coll --name "Daily backup"
 
@@ -139,16 +156,47 @@ func newTenantSnapshotsCreateCommand(env *Environment) *cobra.Command {
   tdb tenant snapshots create --api-key $API_KEY --collection my-coll --name "Production backup" \
     --encrypt --storage s3
 
+  # Encrypt with a specific key (see "snapshots keys")
+  tdb tenant snapshots create --api-key $API_KEY --collection my-coll --name "Production backup" \
+    --encryption-key-id key_123
+
   # Create an incremental snapshot
   tdb tenant snapshots create --api-key $API_KEY --collection my-coll --name "Incremental" \
-    --incremental --parent-snapshot parent-id`,
+    --incremental --parent-snapshot parent-id
+
+  # Back up every collection except audit logs in one command
+  tdb tenant snapshots create --api-key $API_KEY --all-collections --name nightly-2024-06-01 \
+    --exclude audit_logs --encrypt
+
+  # Preview which event collections a pattern selects
+  tdb tenant snapshots create --api-key $API_KEY --collections 'events_*' --name nightly --dry-run`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if collectionID == "" {
+			if allCollections && collectionPatterns != "" {
+				return fmt.Errorf("--collections cannot be combined with --all-collections")
+			}
+			multi := allCollections || collectionPatterns != ""
+			if multi && collectionID != "" {
+				return fmt.Errorf("--collection cannot be combined with --all-collections or --collections")
+			}
+			if !multi && collectionID == "" {
 				return fmt.Errorf("--collection is required")
 			}
+			if !multi && exclude != "" {
+				return fmt.Errorf("--exclude requires --all-collections or --collections")
+			}
+			if !multi && dryRun {
+				return fmt.Errorf("--dry-run requires --all-collections or --collections")
+			}
+			if multi && (incremental || parentSnapshotID != "") {
+				return fmt.Errorf("--incremental cannot be combined with --all-collections or --collections")
+			}
 			if name == "" {
 				return fmt.Errorf("--name is required")
 			}
+			encryptionKeyID = strings.TrimSpace(encryptionKeyID)
+			if encryptionKeyID != "" {
+				encrypt = true
+			}
 
 			envCtx, err := requireEnvironment(env)
 			if err != nil {
@@ -159,6 +207,35 @@ func newTenantSnapshotsCreateCommand(env *Environment) *cobra.Command {
 				return err
 			}
 
+			if multi {
+				collections, err := tenantClient.ListCollections(cmd.Context(), "")
+				if err != nil {
+					return fmt.Errorf("failed to list collections: %w", err)
+				}
+				selected, err := selectCollections(collections, splitCommaList(collectionPatterns))
+				if err != nil {
+					return err
+				}
+				if selected, err = excludeCollections(selected, splitCommaList(exclude)); err != nil {
+					return err
+				}
+				if len(selected) == 0 {
+					return fmt.Errorf("no collections to snapshot")
+				}
+				if dryRun {
+					return printCollectionSelection(cmd, selected, raw)
+				}
+				base := clientpkg.CreateSnapshotRequest{
+					Name:            name,
+					Description:     description,
+					Encrypt:         encrypt,
+					EncryptionKeyID: encryptionKeyID,
+					StorageProvider: storageProvider,
+				}
+				results := createCollectionSnapshots(cmd.Context(), tenantClient.CreateSnapshot, selected, base, concurrency)
+				return reportCollectionSnapshots(cmd, results, raw)
+			}
+
 			req := clientpkg.CreateSnapshotRequest{
 				CollectionID:     collectionID,
 				Name:             name,
@@ -166,6 +243,7 @@ func newTenantSnapshotsCreateCommand(env *Environment) *cobra.Command {
 				Incremental:      incremental,
 				ParentSnapshotID: parentSnapshotID,
 				Encrypt:          encrypt,
+				EncryptionKeyID:  encryptionKeyID,
 				StorageProvider:  storageProvider,
 			}
 
@@ -186,7 +264,11 @@ func newTenantSnapshotsCreateCommand(env *Environment) *cobra.Command {
 			fmt.Fprintf(cmd.OutOrStdout(), "  Documents:   %d\n", snapshot.DocumentCount)
 			fmt.Fprintf(cmd.OutOrStdout(), "  Size:        %s\n", formatBytes(snapshot.SizeBytes))
 			if snapshot.Encrypted {
-				fmt.Fprintf(cmd.OutOrStdout(), "  Encrypted:   yes\n")
+				encrypted := "yes"
+				if snapshot.EncryptionKeyID != "" {
+					encrypted += " (key " + snapshot.EncryptionKeyID + ")"
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "  Encrypted:   %s\n", encrypted)
 			}
 			if snapshot.StorageProvider != "" && snapshot.StorageProvider != "local" {
 				fmt.Fprintf(cmd.OutOrStdout(), "  Storage:     %s\n", snapshot.StorageProvider)
@@ -204,11 +286,17 @@ func newTenantSnapshotsCreateCommand(env *Environment) *cobra.Command {
 	cmd.Flags().BoolVar(&incremental, "incremental", false, "Create incremental snapshot")
 	cmd.Flags().StringVar(&parentSnapshotID, "parent-snapshot", "", "Parent snapshot ID for incremental snapshots")
 	cmd.Flags().BoolVar(&encrypt, "encrypt", false, "Encrypt snapshot data")
+	cmd.Flags().StringVar(&encryptionKeyID, "encryption-key-id", "", "Encrypt with this snapshot key (implies --encrypt)")
 	cmd.Flags().StringVar(&storageProvider, "storage", "", "Storage provider (local, s3, gcs)")
+	cmd.Flags().BoolVar(&allCollections, "all-collections", false, "Snapshot every collection, using --name as the name prefix")
+	cmd.Flags().StringVar(&collectionPatterns, "collections", "", "Snapshot the matching collections, using --name as the name prefix: "+collectionPatternsUsage)
+	cmd.Flags().StringVar(&exclude, "exclude", "", "Comma-separated collections to skip with --all-collections or --collections (patterns allowed)")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 4, "Number of snapshots created in parallel with --all-collections or --collections")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the collections that would be snapshotted without creating anything")
 	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON response")
 
-	cmd.MarkFlagRequired("collection")
 	cmd.MarkFlagRequired("name")
+	_ = cmd.RegisterFlagCompletionFunc("encryption-key-id", snapshotKeyIDCompletion(env, &auth))
 
 	return cmd
 }
@@ -218,21 +306,49 @@ func newTenantSnapshotsRestoreCommand(env *Environment) *cobra.Command {
 	var auth authFlags
 	var snapshotID string
 	var targetCollectionID string
+	var conflict string
+	var encryptionKeyID string
+	var dryRun bool
+	var skipVerify bool
 	var raw bool
 
 	cmd := &cobra.Command{
 		Use:   "restore --snapshot SNAPSHOT_ID [--target-collection COLLECTION_ID]",
 		Short: "Restore a snapshot",
-		Long:  "Restore a snapshot to its original collection or a different collection",
+		Long: `Restore a snapshot to its original collection or a different collection.
+
+--conflict decides what happens to snapshot documents that already exist in the
+target: overwrite them (default), skip them, or fail the restore. --dry-run asks
+the server how many documents would be created, overwritten, or skipped without
+changing anything. It is refused unless the server advertises the
+restore_dry_run feature (see "tdb capabilities"), since a server that ignores
+dry_run would perform the restore.
+
+After a restore the target's document count is checked: it must hold at least
+as many documents as the snapshot, and, when the server reports how many
+documents it created, exactly that many more than before. The check fails when
+the restored collection cannot be found. Use --skip-verify to turn it off.
+
+Encrypted snapshots are decrypted with the key recorded on the snapshot. Pass
+--encryption-key-id to use a different key, for example the rotated key of a
+snapshot taken before "snapshots keys rotate".`,
 		Example: `  # Restore to original collection
   tdb tenant snapshots restore --api-key $API_KEY --snapshot snap-123
 
-  # Restore to a different collection
-  tdb tenant snapshots restore --api-key $API_KEY --snapshot snap-123 --target-collection new-coll`,
+  # Preview a restore into a different collection, keeping existing documents
+  tdb tenant snapshots restore --api-key $API_KEY --snapshot snap-123 --target-collection new-coll --conflict skip --dry-run
+
+  # Restore an encrypted snapshot with a specific key
+  tdb tenant snapshots restore --api-key $API_KEY --snapshot snap-123 --encryption-key-id key_123`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if snapshotID == "" {
 				return fmt.Errorf("--snapshot is required")
 			}
+			switch conflict {
+			case clientpkg.RestoreConflictSkip, clientpkg.RestoreConflictOverwrite, clientpkg.RestoreConflictFail:
+			default:
+				return fmt.Errorf("unsupported --conflict %q (supported: skip, overwrite, fail)", conflict)
+			}
 
 			envCtx, err := requireEnvironment(env)
 			if err != nil {
@@ -243,37 +359,171 @@ func newTenantSnapshotsRestoreCommand(env *Environment) *cobra.Command {
 				return err
 			}
 
+			if dryRun {
+				caps, err := currentCapabilities(cmd.Context(), envCtx, tenantClient)
+				if err != nil {
+					return fmt.Errorf("check dry-run support: %w", err)
+				}
+				if !caps.Detected || !caps.Capabilities.Supports(clientpkg.FeatureRestoreDryRun) {
+					return fmt.Errorf("the server does not advertise %s; refusing --dry-run because it could perform the restore (run \"tdb capabilities --refresh\" after upgrading the server)", clientpkg.FeatureRestoreDryRun)
+				}
+			}
+
+			snapshot, err := tenantClient.GetSnapshot(cmd.Context(), snapshotID)
+			if err != nil {
+				return fmt.Errorf("failed to load snapshot: %w", err)
+			}
+			target, err := resolveRestoreTarget(cmd.Context(), tenantClient, snapshot, targetCollectionID)
+			if err != nil {
+				return err
+			}
+			var countBefore int64 = -1
+			if target != nil {
+				if countBefore, err = tenantClient.CountDocuments(cmd.Context(), target.Name, ""); err != nil {
+					return fmt.Errorf("count target documents: %w", err)
+				}
+			}
+
 			req := clientpkg.RestoreSnapshotRequest{
 				TargetCollectionID: targetCollectionID,
+				ConflictPolicy:     conflict,
+				EncryptionKeyID:    strings.TrimSpace(encryptionKeyID),
+				DryRun:             dryRun,
+			}
+			if target != nil && targetCollectionID != "" {
+				req.TargetCollectionID = target.ID
 			}
 
 			result, err := tenantClient.RestoreSnapshot(cmd.Context(), snapshotID, req)
 			if err != nil {
+				var conflictErr *clientpkg.ConflictError
+				if conflict == clientpkg.RestoreConflictFail && errors.As(err, &conflictErr) {
+					return fmt.Errorf("failed to restore snapshot: target already contains snapshot documents (use --conflict skip or overwrite): %w", err)
+				}
 				return fmt.Errorf("failed to restore snapshot: %w", err)
 			}
-
-			if raw {
-				return printJSON(cmd, result)
+			if dryRun && !result.DryRun {
+				return fmt.Errorf("the server did not confirm the dry run; snapshot %s may have been restored into %s", snapshot.ID, result.CollectionID)
 			}
 
-			fmt.Fprintf(cmd.OutOrStdout(), "✓ Snapshot restored successfully\n\n")
-			fmt.Fprintf(cmd.OutOrStdout(), "  Collection:       %s\n", result.CollectionID)
-			fmt.Fprintf(cmd.OutOrStdout(), "  Documents restored: %d\n", result.DocumentsRestored)
+			var verifyErr error
+			var countAfter int64 = -1
+			if !dryRun && !skipVerify {
+				if target == nil {
+					// The server created the target, so it started out empty.
+					if target, err = resolveRestoreTarget(cmd.Context(), tenantClient, snapshot, result.CollectionID); err != nil {
+						return fmt.Errorf("verify restore: %w", err)
+					}
+					countBefore = 0
+				}
+				if target == nil {
+					verifyErr = fmt.Errorf("restore verification failed: restored collection %q not found (use --skip-verify to skip the check)", result.CollectionID)
+				} else {
+					countAfter, err = tenantClient.CountDocuments(cmd.Context(), target.Name, "")
+					if err != nil {
+						return fmt.Errorf("verify restore: %w", err)
+					}
+					verifyErr = verifyRestoreCount(snapshot.DocumentCount, countBefore, countAfter, result)
+				}
+			}
 
-			return nil
+			if raw {
+				if err := printJSON(cmd, result); err != nil {
+					return err
+				}
+				return verifyErr
+			}
+
+			out := cmd.OutOrStdout()
+			if dryRun {
+				fmt.Fprintf(out, "Dry run: snapshot %s (%d documents) would be restored with --conflict %s\n\n", snapshot.ID, snapshot.DocumentCount, conflict)
+			} else {
+				fmt.Fprintf(out, "✓ Snapshot restored successfully\n\n")
+			}
+			fmt.Fprintf(out, "  Collection:       %s\n", result.CollectionID)
+			if countBefore >= 0 {
+				fmt.Fprintf(out, "  Documents before: %d\n", countBefore)
+			}
+			if !dryRun {
+				fmt.Fprintf(out, "  Documents restored: %d\n", result.DocumentsRestored)
+			}
+			for _, item := range []struct {
+				label string
+				value *int
+			}{
+				{"created", result.DocumentsCreated},
+				{"overwritten", result.DocumentsOverwritten},
+				{"skipped", result.DocumentsSkipped},
+			} {
+				if item.value == nil {
+					continue
+				}
+				verb := "  Documents %s:"
+				if dryRun {
+					verb = "  Would be %s:"
+				}
+				fmt.Fprintf(out, verb+" %d\n", item.label, *item.value)
+			}
+			if dryRun && result.DocumentsCreated == nil && result.DocumentsOverwritten == nil && result.DocumentsSkipped == nil {
+				fmt.Fprintln(out, "  The server did not report a per-document breakdown.")
+			}
+			if countAfter >= 0 && verifyErr == nil {
+				fmt.Fprintf(out, "  Verified:         %d documents in target\n", countAfter)
+			}
+			return verifyErr
 		},
 	}
 
 	auth.bind(cmd)
 	cmd.Flags().StringVar(&snapshotID, "snapshot", "", "Snapshot ID (required)")
-	cmd.Flags().StringVar(&targetCollectionID, "target-collection", "", "Target collection ID (defaults to original)")
+	cmd.Flags().StringVar(&targetCollectionID, "target-collection", "", "Target collection ID or name (defaults to original)")
+	cmd.Flags().StringVar(&conflict, "conflict", clientpkg.RestoreConflictOverwrite, "What to do with documents that already exist in the target: skip, overwrite, or fail")
+	cmd.Flags().StringVar(&encryptionKeyID, "encryption-key-id", "", "Decrypt with this snapshot key instead of the one recorded on the snapshot")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report how many documents would be created, overwritten, or skipped without restoring")
+	cmd.Flags().BoolVar(&skipVerify, "skip-verify", false, "Skip the post-restore document count check")
 	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON response")
 
 	cmd.MarkFlagRequired("snapshot")
+	_ = cmd.RegisterFlagCompletionFunc("snapshot", snapshotIDCompletion(env, &auth))
+	_ = cmd.RegisterFlagCompletionFunc("encryption-key-id", snapshotKeyIDCompletion(env, &auth))
 
 	return cmd
 }
 
+// resolveRestoreTarget finds the collection a snapshot will be restored into,
+// matching --target-collection by ID or name. It returns nil when the target
+// does not exist yet, in which case the server creates it.
+func resolveRestoreTarget(ctx context.Context, tenantClient *clientpkg.TenantClient, snapshot *clientpkg.Snapshot, target string) (*clientpkg.Collection, error) {
+	collections, err := tenantClient.ListCollections(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("list collections: %w", err)
+	}
+	ref := strings.TrimSpace(target)
+	if ref == "" {
+		ref = snapshot.CollectionID
+	}
+	for i := range collections {
+		if collections[i].ID == ref || (target != "" && collections[i].Name == ref) {
+			return &collections[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// verifyRestoreCount checks the target's document count after a restore.
+// Every snapshot document must be present afterwards, and when the server
+// reports how many documents it created, the count must have grown by exactly
+// that many.
+func verifyRestoreCount(snapshotDocuments int, before, after int64, result *clientpkg.RestoreSnapshotResponse) error {
+	if after < int64(snapshotDocuments) {
+		return fmt.Errorf("restore verification failed: target holds %d documents but the snapshot has %d", after, snapshotDocuments)
+	}
+	if result.DocumentsCreated != nil && before >= 0 && after != before+int64(*result.DocumentsCreated) {
+		return fmt.Errorf("restore verification failed: expected %d documents (%d before + %d created), found %d", before+int64(*result.DocumentsCreated), before, *result.DocumentsCreated, after)
+	}
+	return nil
+}
+
 // newTenantSnapshotsDeleteCommand deletes a snapshot
 func newTenantSnapshotsDeleteCommand(env *Environment) *cobra.Command {
 	var auth authFlags
@@ -304,6 +554,16 @@ func newTenantSnapshotsDeleteCommand(env *Environment) *cobra.Command {
 				return err
 			}
 
+			if envCtx.Config.SafeMode {
+				snapshot, err := tenantClient.GetSnapshot(cmd.Context(), snapshotID)
+				if err != nil {
+					return err
+				}
+				if err := confirmDestructive(cmd, envCtx, "delete snapshot "+snapshotID, "Name: "+snapshot.Name, fmt.Sprintf("Documents: %d", snapshot.DocumentCount)); err != nil {
+					return err
+				}
+			}
+
 			err = tenantClient.DeleteSnapshot(cmd.Context(), snapshotID)
 			if err != nil {
 				return fmt.Errorf("failed to delete snapshot: %w", err)
@@ -318,8 +578,10 @@ func newTenantSnapshotsDeleteCommand(env *Environment) *cobra.Command {
 	auth.bind(cmd)
 	cmd.Flags().StringVar(&snapshotID, "snapshot", "", "Snapshot ID (required)")
 	cmd.Flags().BoolVar(&force, "force", false, "Force deletion without confirmation")
+	bindSafeModeYes(cmd)
 
 	cmd.MarkFlagRequired("snapshot")
+	_ = cmd.RegisterFlagCompletionFunc("snapshot", snapshotIDCompletion(env, &auth))
 
 	return cmd
 }
@@ -398,6 +660,7 @@ func newTenantSnapshotsGetCommand(env *Environment) *cobra.Command {
 	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON response")
 
 	cmd.MarkFlagRequired("snapshot")
+	_ = cmd.RegisterFlagCompletionFunc("snapshot", snapshotIDCompletion(env, &auth))
 
 	return cmd
 }
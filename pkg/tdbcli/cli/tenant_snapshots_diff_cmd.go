@@ -0,0 +1,253 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	canonicalpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/canonical"
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const snapshotDiffPageSize = 500
+
+// snapshotDiff summarizes how a collection changed since a snapshot.
+// Documents are matched by primary key.
+type snapshotDiff struct {
+	SnapshotID  string    `json:"snapshot_id"`
+	Collection  string    `json:"collection"`
+	SnapshotAt  time.Time `json:"snapshot_at"`
+	Added       []string  `json:"added"`
+	Removed     []string  `json:"removed"`
+	Changed     []string  `json:"changed"`
+	Unchanged   int       `json:"unchanged"`
+	ScratchName string    `json:"scratch_collection,omitempty"`
+}
+
+func newTenantSnapshotsDiffCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var snapshotID string
+	var scratch string
+	var keepScratch bool
+	var show int
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "diff --snapshot SNAPSHOT_ID",
+		Short: "Show what changed in a collection since a snapshot",
+		Long: `Compare a snapshot with the live collection it was taken from and summarize
+the documents added, removed, and changed since then.
+
+The snapshot is restored into a new scratch collection, and both sides are matched by primary key and
+compared by a hash of their canonical JSON content. For collections without a
+primary key the document ID is used, which only lines up when restores keep
+document IDs.
+
+The generated scratch collection is deleted afterwards unless --keep-scratch is
+given. A collection named with --scratch-collection is always kept, and the
+diff stops without deleting anything if the server restores into a collection
+other than the requested one.`,
+		Example: `  # What changed since last night's backup?
+  tdb tenant snapshots diff --snapshot snap-123
+
+  # List every changed key as JSON
+  tdb tenant snapshots diff --snapshot snap-123 --raw | jq -r '.changed[]'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if snapshotID == "" {
+				return fmt.Errorf("--snapshot is required")
+			}
+			if show < 0 {
+				return fmt.Errorf("--show cannot be negative")
+			}
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+
+			snapshot, err := tenantClient.GetSnapshot(ctx, snapshotID)
+			if err != nil {
+				return fmt.Errorf("failed to load snapshot: %w", err)
+			}
+			live, err := resolveRestoreTarget(ctx, tenantClient, snapshot, "")
+			if err != nil {
+				return err
+			}
+			if live == nil {
+				return fmt.Errorf("collection %s of snapshot %s no longer exists", snapshot.CollectionName, snapshot.ID)
+			}
+			scratchName := strings.TrimSpace(scratch)
+			generated := scratchName == ""
+			if generated {
+				scratchName = fmt.Sprintf("%s_snapdiff_%d", live.Name, time.Now().Unix())
+			}
+			if _, err := tenantClient.GetCollection(ctx, scratchName, ""); err == nil {
+				return fmt.Errorf("scratch collection %s already exists; pass another --scratch-collection", scratchName)
+			} else if !isNotFoundError(err) {
+				return fmt.Errorf("check scratch collection %s: %w", scratchName, err)
+			}
+
+			restored, err := tenantClient.RestoreSnapshot(ctx, snapshot.ID, clientpkg.RestoreSnapshotRequest{
+				TargetCollectionID: scratchName,
+				ConflictPolicy:     clientpkg.RestoreConflictFail,
+			})
+			if err != nil {
+				return fmt.Errorf("restore snapshot into %s: %w", scratchName, err)
+			}
+			// Only a collection the restore provably created under the
+			// requested name is read as the snapshot side, let alone deleted.
+			if restored.CollectionID != "" && restored.CollectionID != scratchName {
+				target, err := resolveRestoreTarget(ctx, tenantClient, snapshot, restored.CollectionID)
+				if err != nil {
+					return err
+				}
+				if target == nil || target.Name != scratchName {
+					into := restored.CollectionID
+					if target != nil {
+						into = target.Name
+					}
+					return fmt.Errorf("the server restored snapshot %s into %s instead of scratch collection %s; nothing was deleted, check that collection by hand", snapshot.ID, into, scratchName)
+				}
+			}
+			kept := keepScratch || !generated
+			if !kept {
+				defer func() {
+					if err := tenantClient.DeleteCollection(context.WithoutCancel(ctx), scratchName, ""); err != nil {
+						fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to delete scratch collection %s: %v\n", scratchName, err)
+					}
+				}()
+			} else if !keepScratch {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: kept scratch collection %s; only generated scratch collections are deleted automatically\n", scratchName)
+			}
+
+			before, err := collectionContentHashes(ctx, tenantClient, scratchName)
+			if err != nil {
+				return fmt.Errorf("read snapshot contents: %w", err)
+			}
+			after, err := collectionContentHashes(ctx, tenantClient, live.Name)
+			if err != nil {
+				return fmt.Errorf("read %s: %w", live.Name, err)
+			}
+			diff := diffContentHashes(before, after)
+			diff.SnapshotID = snapshot.ID
+			diff.Collection = live.Name
+			diff.SnapshotAt = snapshot.CreatedAt
+			if kept {
+				diff.ScratchName = scratchName
+			}
+			if raw {
+				return printJSON(cmd, diff)
+			}
+			printSnapshotDiff(cmd, diff, show)
+			return nil
+		},
+	}
+
+	auth.bind(cmd)
+	cmd.Flags().StringVar(&snapshotID, "snapshot", "", "Snapshot ID (required)")
+	cmd.Flags().StringVar(&scratch, "scratch-collection", "", "New collection to restore the snapshot into for comparison; kept afterwards (default: <collection>_snapdiff_<timestamp>, deleted afterwards)")
+	cmd.Flags().BoolVar(&keepScratch, "keep-scratch", false, "Keep the scratch collection instead of deleting it afterwards")
+	cmd.Flags().IntVar(&show, "show", 20, "Keys to list per category (0 lists none)")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the full diff as JSON")
+
+	cmd.MarkFlagRequired("snapshot")
+	_ = cmd.RegisterFlagCompletionFunc("snapshot", snapshotIDCompletion(env, &auth))
+
+	return cmd
+}
+
+func printSnapshotDiff(cmd *cobra.Command, diff snapshotDiff, show int) {
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Snapshot %s (%s) vs live %s\n\n", diff.SnapshotID, formatTime(diff.SnapshotAt), diff.Collection)
+	fmt.Fprintf(out, "  Added:     %d\n", len(diff.Added))
+	fmt.Fprintf(out, "  Removed:   %d\n", len(diff.Removed))
+	fmt.Fprintf(out, "  Changed:   %d\n", len(diff.Changed))
+	fmt.Fprintf(out, "  Unchanged: %d\n", diff.Unchanged)
+	if diff.ScratchName != "" {
+		fmt.Fprintf(out, "  Scratch:   %s (kept)\n", diff.ScratchName)
+	}
+	for _, group := range []struct {
+		marker string
+		keys   []string
+	}{
+		{"+", diff.Added},
+		{"-", diff.Removed},
+		{"~", diff.Changed},
+	} {
+		if show == 0 || len(group.keys) == 0 {
+			continue
+		}
+		fmt.Fprintln(out)
+		for i, key := range group.keys {
+			if i == show {
+				fmt.Fprintf(out, "  … and %d more\n", len(group.keys)-show)
+				break
+			}
+			fmt.Fprintf(out, "  %s %s\n", group.marker, key)
+		}
+	}
+}
+
+// collectionContentHashes maps the primary key (or ID) of every document in
+// collection to a hash of its canonical JSON data.
+func collectionContentHashes(ctx context.Context, tenantClient *clientpkg.TenantClient, collection string) (map[string]string, error) {
+	hashes := map[string]string{}
+	params := clientpkg.ListDocumentsParams{Limit: snapshotDiffPageSize, Sort: []string{"created_at", "id"}}
+	for {
+		resp, err := tenantClient.ListDocuments(ctx, collection, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, doc := range resp.Items {
+			key := doc.Key
+			if key == "" {
+				key = doc.ID
+			}
+			data := []byte(doc.Data)
+			if canonical, err := canonicalpkg.Canonicalize(data); err == nil {
+				data = canonical
+			}
+			sum := sha256.Sum256(data)
+			hashes[key] = hex.EncodeToString(sum[:])
+		}
+		if len(resp.Items) < params.Limit {
+			return hashes, nil
+		}
+		params.Offset += len(resp.Items)
+	}
+}
+
+// diffContentHashes compares snapshot (before) and live (after) hashes.
+func diffContentHashes(before, after map[string]string) snapshotDiff {
+	diff := snapshotDiff{Added: []string{}, Removed: []string{}, Changed: []string{}}
+	for key, hash := range after {
+		old, ok := before[key]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, key)
+		case old != hash:
+			diff.Changed = append(diff.Changed, key)
+		default:
+			diff.Unchanged++
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			diff.Removed = append(diff.Removed, key)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Changed)
+	return diff
+}
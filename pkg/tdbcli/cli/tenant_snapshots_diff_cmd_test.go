@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestSnapshotsDiffComparesSnapshotWithLiveCollection(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	users := srv.AddCollection(clientpkg.Collection{Name: "users", PrimaryKeyField: "email"})
+	srv.AddDocument("users", map[string]any{"email": "ada@example.com", "role": "admin"})
+	srv.AddDocument("users", map[string]any{"email": "bob@example.com", "role": "editor"})
+	srv.AddDocument("users", map[string]any{"email": "eve@example.com", "role": "viewer"})
+
+	restoreIntoLive := false
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/snapshots/snap_1":
+			_ = json.NewEncoder(w).Encode(clientpkg.Snapshot{ID: "snap_1", CollectionID: users.ID, CollectionName: "users", DocumentCount: 3})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/snapshots/snap_1/restore":
+			var req clientpkg.RestoreSnapshotRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if restoreIntoLive {
+				_ = json.NewEncoder(w).Encode(clientpkg.RestoreSnapshotResponse{CollectionID: users.ID, DocumentsRestored: 3})
+				return
+			}
+			scratch := srv.AddCollection(clientpkg.Collection{Name: req.TargetCollectionID, PrimaryKeyField: "email"})
+			srv.AddDocument(scratch.Name, map[string]any{"role": "admin", "email": "ada@example.com"})
+			srv.AddDocument(scratch.Name, map[string]any{"email": "bob@example.com", "role": "viewer"})
+			srv.AddDocument(scratch.Name, map[string]any{"email": "cy@example.com", "role": "viewer"})
+			_ = json.NewEncoder(w).Encode(clientpkg.RestoreSnapshotResponse{CollectionID: scratch.ID, DocumentsRestored: 3})
+		default:
+			tenantRoutes.ServeHTTP(w, r)
+		}
+	})
+
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+	run := func(args ...string) (string, error) {
+		out, _, err := runCommandWithEnv(t, env, newTenantSnapshotsDiffCommand, append([]string{"--snapshot", "snap_1"}, args...)...)
+		return out, err
+	}
+	deletes := func() []string {
+		var out []string
+		for _, request := range srv.Requests() {
+			if strings.HasPrefix(request, "DELETE ") {
+				out = append(out, request)
+			}
+		}
+		return out
+	}
+
+	out, err := run("--raw")
+	if err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+	var diff snapshotDiff
+	if err := json.Unmarshal([]byte(out), &diff); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, out)
+	}
+	if !slices.Equal(diff.Added, []string{"eve@example.com"}) || !slices.Equal(diff.Removed, []string{"cy@example.com"}) ||
+		!slices.Equal(diff.Changed, []string{"bob@example.com"}) || diff.Unchanged != 1 || diff.ScratchName != "" {
+		t.Fatalf("unexpected diff %+v", diff)
+	}
+	if got := deletes(); len(got) != 1 || !strings.HasPrefix(got[0], "DELETE /api/collections/users_snapdiff_") {
+		t.Fatalf("expected only the generated scratch collection to be deleted, got %v", got)
+	}
+
+	out, err = run("--scratch-collection", "users_scratch", "--raw")
+	if err != nil {
+		t.Fatalf("diff with --scratch-collection failed: %v", err)
+	}
+	if !strings.Contains(out, `"scratch_collection": "users_scratch"`) || len(deletes()) != 1 {
+		t.Fatalf("expected a named scratch collection to be kept:\n%s\n%v", out, deletes())
+	}
+	if _, err := run("--scratch-collection", "users_scratch"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected an existing scratch collection to be refused, got %v", err)
+	}
+
+	restoreIntoLive = true
+	if _, err := run(); err == nil || !strings.Contains(err.Error(), "instead of scratch collection") {
+		t.Fatalf("expected a restore into the live collection to stop the diff, got %v", err)
+	}
+	if got := deletes(); len(got) != 1 {
+		t.Fatalf("expected nothing else to be deleted, got %v", got)
+	}
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// snapshotFreshness describes the most recent snapshot of a collection.
+type snapshotFreshness struct {
+	Collection   string     `json:"collection"`
+	CollectionID string     `json:"collection_id"`
+	SnapshotID   string     `json:"snapshot_id,omitempty"`
+	LatestAt     *time.Time `json:"latest_snapshot_at,omitempty"`
+	AgeSeconds   int64      `json:"age_seconds,omitempty"`
+	Status       string     `json:"status"`
+}
+
+// newTenantSnapshotsVerifyFreshnessCommand fails when any collection's latest snapshot is too old
+func newTenantSnapshotsVerifyFreshnessCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var maxAge string
+	var collections string
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "verify-freshness",
+		Short: "Fail when collections have no recent snapshot",
+		Long: `Check the latest snapshot of each collection and exit non-zero when any is older
+than --max-age or has never been snapshotted. Intended for cron jobs and CI so
+alerts fire when backups silently stop running.`,
+		Example: `  # Require a snapshot within the last day for every collection
+  tdb tenant snapshots verify-freshness --api-key $API_KEY --max-age 24h
+
+  # Only check selected collections
+  tdb tenant snapshots verify-freshness --api-key $API_KEY --max-age 7d --collections users,orders
+
+  # Check every events_* collection
+  tdb tenant snapshots verify-freshness --api-key $API_KEY --collections 'events_*'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			threshold, err := parseFlexibleDurationArg(maxAge)
+			if err != nil {
+				return fmt.Errorf("invalid --max-age: %w", err)
+			}
+			if threshold <= 0 {
+				return errors.New("--max-age must be positive")
+			}
+
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+
+			all, err := tenantClient.ListCollections(cmd.Context(), "")
+			if err != nil {
+				return fmt.Errorf("failed to list collections: %w", err)
+			}
+			selected, err := selectCollections(all, splitCommaList(collections))
+			if err != nil {
+				return err
+			}
+
+			latest := make(map[string]clientpkg.Snapshot, len(selected))
+			for _, col := range selected {
+				snapshot, ok, err := latestCollectionSnapshot(cmd, tenantClient, col.ID)
+				if err != nil {
+					return fmt.Errorf("failed to list snapshots for %s: %w", col.Name, err)
+				}
+				if ok {
+					latest[col.ID] = snapshot
+				}
+			}
+
+			report := evaluateSnapshotFreshness(selected, latest, threshold, time.Now())
+			if raw {
+				if err := printJSON(cmd, report); err != nil {
+					return err
+				}
+			} else {
+				rows := make([][]string, 0, len(report))
+				for _, item := range report {
+					latestAt, age := "-", "-"
+					if item.LatestAt != nil {
+						latestAt = formatTime(*item.LatestAt)
+						age = formatRelativeTime(*item.LatestAt, "-")
+					}
+					rows = append(rows, []string{item.Collection, latestAt, age, strings.ToUpper(item.Status)})
+				}
+				renderTable(cmd, []string{"COLLECTION", "LATEST SNAPSHOT", "AGE", "STATUS"}, rows)
+			}
+
+			var failing []string
+			for _, item := range report {
+				if item.Status != "ok" {
+					failing = append(failing, fmt.Sprintf("%s (%s)", item.Collection, item.Status))
+				}
+			}
+			if len(failing) > 0 {
+				return fmt.Errorf("%d collection(s) without a snapshot in the last %s: %s", len(failing), maxAge, strings.Join(failing, ", "))
+			}
+			return nil
+		},
+	}
+
+	auth.bind(cmd)
+	cmd.Flags().StringVar(&maxAge, "max-age", "24h", "Maximum allowed age of the latest snapshot (e.g. 24h, 7d)")
+	cmd.Flags().StringVar(&collections, "collections", "", "Comma-separated collections to check (defaults to all): "+collectionPatternsUsage)
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the freshness report as JSON")
+
+	return cmd
+}
+
+// latestCollectionSnapshot pages through a collection's snapshots and returns
+// the most recently created one.
+func latestCollectionSnapshot(cmd *cobra.Command, tenantClient *clientpkg.TenantClient, collectionID string) (clientpkg.Snapshot, bool, error) {
+	const page = 100
+	var latest clientpkg.Snapshot
+	found := false
+	offset := 0
+	for {
+		snapshots, err := tenantClient.ListSnapshots(cmd.Context(), collectionID, page, offset)
+		if err != nil {
+			return latest, false, err
+		}
+		for _, snapshot := range snapshots {
+			if !found || snapshot.CreatedAt.After(latest.CreatedAt) {
+				latest = snapshot
+				found = true
+			}
+		}
+		if len(snapshots) < page {
+			return latest, found, nil
+		}
+		offset += len(snapshots)
+	}
+}
+
+// evaluateSnapshotFreshness classifies each collection as ok, stale, or missing
+// relative to now. Failing collections sort first.
+func evaluateSnapshotFreshness(collections []clientpkg.Collection, latest map[string]clientpkg.Snapshot, maxAge time.Duration, now time.Time) []snapshotFreshness {
+	report := make([]snapshotFreshness, 0, len(collections))
+	for _, col := range collections {
+		item := snapshotFreshness{Collection: col.Name, CollectionID: col.ID, Status: "missing"}
+		if snapshot, ok := latest[col.ID]; ok {
+			created := snapshot.CreatedAt
+			age := now.Sub(created)
+			item.SnapshotID = snapshot.ID
+			item.LatestAt = &created
+			item.AgeSeconds = int64(age / time.Second)
+			item.Status = "ok"
+			if age > maxAge {
+				item.Status = "stale"
+			}
+		}
+		report = append(report, item)
+	}
+	sort.SliceStable(report, func(i, j int) bool {
+		if (report[i].Status == "ok") != (report[j].Status == "ok") {
+			return report[i].Status != "ok"
+		}
+		return report[i].Collection < report[j].Collection
+	})
+	return report
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+func TestEvaluateSnapshotFreshness(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	collections := []clientpkg.Collection{
+		{ID: "c1", Name: "users"},
+		{ID: "c2", Name: "orders"},
+		{ID: "c3", Name: "events"},
+	}
+	latest := map[string]clientpkg.Snapshot{
+		"c1": {ID: "s1", CreatedAt: now.Add(-2 * time.Hour)},
+		"c2": {ID: "s2", CreatedAt: now.Add(-48 * time.Hour)},
+	}
+
+	report := evaluateSnapshotFreshness(collections, latest, 24*time.Hour, now)
+	if len(report) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(report))
+	}
+	got := map[string]string{}
+	for _, item := range report {
+		got[item.Collection] = item.Status
+	}
+	want := map[string]string{"users": "ok", "orders": "stale", "events": "missing"}
+	for name, status := range want {
+		if got[name] != status {
+			t.Fatalf("%s: expected %s, got %s", name, status, got[name])
+		}
+	}
+	if report[len(report)-1].Status != "ok" {
+		t.Fatalf("expected failing collections first, got %+v", report)
+	}
+	if report[0].Collection != "events" {
+		t.Fatalf("expected failing collections sorted by name, got %s", report[0].Collection)
+	}
+}
@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+func newTenantSnapshotsKeysCommand(env *Environment) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "keys",
+		Aliases: []string{"key"},
+		Short:   "Manage snapshot encryption keys",
+		Long: `List, create, and rotate the keys used to encrypt snapshots.
+
+Pass a key to "snapshots create --encryption-key-id" to encrypt with it. After
+a rotation new snapshots use the replacement key, while snapshots taken before
+it still need the rotated key: restore them with
+"snapshots restore --encryption-key-id <old key>" if the server cannot pick it
+up from the snapshot itself.`,
+	}
+
+	cmd.AddCommand(newTenantSnapshotKeysListCommand(env))
+	cmd.AddCommand(newTenantSnapshotKeysCreateCommand(env))
+	cmd.AddCommand(newTenantSnapshotKeysRotateCommand(env))
+
+	return cmd
+}
+
+// newTenantSnapshotKeysListCommand lists snapshot encryption keys
+func newTenantSnapshotKeysListCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List snapshot encryption keys",
+		Example: `  # List keys and how many snapshots each one protects
+  tdb tenant snapshots keys list --api-key $API_KEY`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+
+			keys, err := tenantClient.ListSnapshotKeys(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to list snapshot keys: %w", err)
+			}
+			if raw {
+				return printJSON(cmd, keys)
+			}
+			if len(keys) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No snapshot keys found")
+				return nil
+			}
+
+			rows := make([][]string, 0, len(keys))
+			for _, key := range keys {
+				rotated := ""
+				if key.RotatedAt != nil {
+					rotated = formatTime(*key.RotatedAt)
+					if key.ReplacedBy != "" {
+						rotated += " → " + key.ReplacedBy
+					}
+				}
+				rows = append(rows, []string{
+					key.ID,
+					key.Name,
+					key.Algorithm,
+					key.Status,
+					strconv.Itoa(key.Version),
+					strconv.Itoa(key.SnapshotCount),
+					formatTime(key.CreatedAt),
+					rotated,
+				})
+			}
+			renderTable(cmd, []string{"ID", "NAME", "ALGORITHM", "STATUS", "VERSION", "SNAPSHOTS", "CREATED", "ROTATED"}, rows)
+			return nil
+		},
+	}
+
+	auth.bind(cmd)
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON response")
+
+	return cmd
+}
+
+// newTenantSnapshotKeysCreateCommand creates a snapshot encryption key
+func newTenantSnapshotKeysCreateCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var name string
+	var algorithm string
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "create --name NAME",
+		Short: "Create a snapshot encryption key",
+		Long: `Create a new key for encrypting snapshots. Key material is generated and kept
+by the server; only the key ID is ever shown.`,
+		Example: `  # Create a key and use it for a nightly backup
+  tdb tenant snapshots keys create --api-key $API_KEY --name prod-backups
+  tdb tenant snapshots create --api-key $API_KEY --all-collections --name nightly \
+    --encryption-key-id key_123`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+
+			key, err := tenantClient.CreateSnapshotKey(cmd.Context(), clientpkg.CreateSnapshotKeyRequest{
+				Name:      name,
+				Algorithm: strings.TrimSpace(algorithm),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create snapshot key: %w", err)
+			}
+			if raw {
+				return printJSON(cmd, key)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Snapshot key created\n\n")
+			fmt.Fprintf(cmd.OutOrStdout(), "  ID:         %s\n", key.ID)
+			fmt.Fprintf(cmd.OutOrStdout(), "  Name:       %s\n", key.Name)
+			if key.Algorithm != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "  Algorithm:  %s\n", key.Algorithm)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "  Created:    %s\n", formatTime(key.CreatedAt))
+			return nil
+		},
+	}
+
+	auth.bind(cmd)
+	cmd.Flags().StringVar(&name, "name", "", "Key name (required)")
+	cmd.Flags().StringVar(&algorithm, "algorithm", "", "Encryption algorithm (defaults to the server's, e.g. aes-256-gcm)")
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON response")
+
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+// newTenantSnapshotKeysRotateCommand replaces a snapshot key with a new one
+func newTenantSnapshotKeysRotateCommand(env *Environment) *cobra.Command {
+	var auth authFlags
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "rotate KEY_ID",
+		Short: "Rotate a snapshot encryption key",
+		Long: `Replace a snapshot key with a new active key. New snapshots created with the
+old key ID are encrypted with the replacement; existing snapshots are not
+re-encrypted and remain restorable with the rotated key.`,
+		Example: `  # Rotate a key
+  tdb tenant snapshots keys rotate key_123 --api-key $API_KEY`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keyID := strings.TrimSpace(args[0])
+			if keyID == "" {
+				return fmt.Errorf("key ID cannot be empty")
+			}
+
+			envCtx, err := requireEnvironment(env)
+			if err != nil {
+				return err
+			}
+			tenantClient, _, _, err := auth.resolveTenantClient(envCtx, cmd)
+			if err != nil {
+				return err
+			}
+
+			if envCtx.Config.SafeMode {
+				if err := confirmDestructive(cmd, envCtx, "rotate snapshot key "+keyID); err != nil {
+					return err
+				}
+			}
+
+			resp, err := tenantClient.RotateSnapshotKey(cmd.Context(), keyID)
+			if err != nil {
+				return fmt.Errorf("failed to rotate snapshot key: %w", err)
+			}
+			if raw {
+				return printJSON(cmd, resp)
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "✓ Snapshot key %s rotated\n\n", keyID)
+			fmt.Fprintf(out, "  Previous:   %s (v%d, %s)\n", resp.Previous.ID, resp.Previous.Version, resp.Previous.Status)
+			fmt.Fprintf(out, "  Current:    %s (v%d, %s)\n", resp.Current.ID, resp.Current.Version, resp.Current.Status)
+			if resp.Previous.SnapshotCount > 0 {
+				fmt.Fprintf(out, "\n%d existing snapshot(s) remain encrypted with %s; keep it until they expire or are deleted.\n", resp.Previous.SnapshotCount, resp.Previous.ID)
+			}
+			return nil
+		},
+	}
+
+	auth.bind(cmd)
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print raw JSON response")
+	bindSafeModeYes(cmd)
+	cmd.ValidArgsFunction = snapshotKeyIDCompletion(env, &auth)
+
+	return cmd
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+	configpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/config"
+)
+
+func TestSnapshotKeysLifecycle(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	users := srv.AddCollection(clientpkg.Collection{Name: "users"})
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	keys := []clientpkg.SnapshotKey{}
+	var createReq clientpkg.CreateSnapshotRequest
+	var restoreReq clientpkg.RestoreSnapshotRequest
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/snapshot-keys":
+			_ = json.NewEncoder(w).Encode(clientpkg.SnapshotKeyListResponse{Items: keys})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/snapshot-keys":
+			var req clientpkg.CreateSnapshotKeyRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			key := clientpkg.SnapshotKey{ID: "key_1", Name: req.Name, Algorithm: "aes-256-gcm", Status: clientpkg.SnapshotKeyActive, Version: 1, CreatedAt: created}
+			keys = append(keys, key)
+			_ = json.NewEncoder(w).Encode(key)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/snapshot-keys/key_1/rotate":
+			rotated := created.Add(time.Hour)
+			keys[0].Status, keys[0].ReplacedBy, keys[0].RotatedAt, keys[0].SnapshotCount = clientpkg.SnapshotKeyRotated, "key_2", &rotated, 1
+			keys = append(keys, clientpkg.SnapshotKey{ID: "key_2", Name: keys[0].Name, Algorithm: "aes-256-gcm", Status: clientpkg.SnapshotKeyActive, Version: 2, CreatedAt: rotated})
+			_ = json.NewEncoder(w).Encode(clientpkg.RotateSnapshotKeyResponse{Previous: keys[0], Current: keys[1]})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/snapshots":
+			_ = json.NewDecoder(r.Body).Decode(&createReq)
+			_ = json.NewEncoder(w).Encode(clientpkg.Snapshot{ID: "snap_1", CollectionName: "users", Name: createReq.Name, Encrypted: true, EncryptionKeyID: createReq.EncryptionKeyID, CreatedAt: created})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/snapshots/snap_1":
+			_ = json.NewEncoder(w).Encode(clientpkg.Snapshot{ID: "snap_1", CollectionID: users.ID, CollectionName: "users", Encrypted: true, EncryptionKeyID: "key_1"})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/capabilities":
+			_ = json.NewEncoder(w).Encode(clientpkg.ServerCapabilities{Features: []string{clientpkg.FeatureRestoreDryRun}})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/snapshots/snap_1/restore":
+			_ = json.NewDecoder(r.Body).Decode(&restoreReq)
+			_ = json.NewEncoder(w).Encode(clientpkg.RestoreSnapshotResponse{CollectionID: users.ID, DryRun: true})
+		default:
+			tenantRoutes.ServeHTTP(w, r)
+		}
+	})
+
+	env := &Environment{Config: &configpkg.Config{Endpoint: srv.URL}}
+	run := func(command func(*Environment) *cobra.Command, args ...string) string {
+		t.Helper()
+		out, _, err := runCommandWithEnv(t, env, command, args...)
+		if err != nil {
+			t.Fatalf("%v failed: %v", args, err)
+		}
+		return out
+	}
+
+	if out := run(newTenantSnapshotKeysListCommand); !strings.Contains(out, "No snapshot keys found") {
+		t.Fatalf("unexpected empty list output:\n%s", out)
+	}
+	if out := run(newTenantSnapshotKeysCreateCommand, "--name", "prod-backups"); !strings.Contains(out, "ID:         key_1") {
+		t.Fatalf("unexpected create output:\n%s", out)
+	}
+
+	out := run(newTenantSnapshotsCreateCommand, "--collection", users.ID, "--name", "nightly", "--encryption-key-id", "key_1")
+	if !createReq.Encrypt || createReq.EncryptionKeyID != "key_1" {
+		t.Fatalf("expected an encrypted snapshot request with key_1, got %+v", createReq)
+	}
+	if !strings.Contains(out, "Encrypted:   yes (key key_1)") {
+		t.Fatalf("expected the key in create output:\n%s", out)
+	}
+
+	out = run(newTenantSnapshotKeysRotateCommand, "key_1")
+	for _, want := range []string{"Previous:   key_1 (v1, rotated)", "Current:    key_2 (v2, active)", "1 existing snapshot(s) remain encrypted with key_1"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in rotate output:\n%s", want, out)
+		}
+	}
+	out = run(newTenantSnapshotKeysListCommand)
+	if !strings.Contains(out, "→ key_2") {
+		t.Fatalf("expected the rotation in list output:\n%s", out)
+	}
+
+	run(newTenantSnapshotsRestoreCommand, "--snapshot", "snap_1", "--encryption-key-id", "key_1", "--dry-run")
+	if restoreReq.EncryptionKeyID != "key_1" {
+		t.Fatalf("expected restore to send the key, got %+v", restoreReq)
+	}
+}
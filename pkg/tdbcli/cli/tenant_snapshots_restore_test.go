@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func TestSnapshotsRestoreDryRunConflictAndVerify(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	users := srv.AddCollection(clientpkg.Collection{Name: "users"})
+	srv.AddDocument("users", map[string]any{"name": "ada"})
+
+	var requests []clientpkg.RestoreSnapshotRequest
+	addOnRestore := 0
+	features := []string{}
+	ignoreDryRun := false
+	restoredInto := users.ID
+	tenantRoutes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/capabilities":
+			_ = json.NewEncoder(w).Encode(clientpkg.ServerCapabilities{Features: features})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/snapshots/snap_1":
+			_ = json.NewEncoder(w).Encode(clientpkg.Snapshot{ID: "snap_1", CollectionID: users.ID, CollectionName: "users", DocumentCount: 3})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/snapshots/snap_1/restore":
+			var req clientpkg.RestoreSnapshotRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			requests = append(requests, req)
+			if req.ConflictPolicy == clientpkg.RestoreConflictFail {
+				http.Error(w, "1 document already exists", http.StatusConflict)
+				return
+			}
+			created, overwritten := 2, 1
+			if ignoreDryRun {
+				req.DryRun = false
+			}
+			if !req.DryRun {
+				for i := 0; i < addOnRestore; i++ {
+					srv.AddDocument("users", map[string]any{"restored": i})
+				}
+			}
+			_ = json.NewEncoder(w).Encode(clientpkg.RestoreSnapshotResponse{CollectionID: restoredInto, DocumentsRestored: 3, DocumentsCreated: &created, DocumentsOverwritten: &overwritten, DryRun: req.DryRun})
+		default:
+			tenantRoutes.ServeHTTP(w, r)
+		}
+	})
+
+	run := func(args ...string) (string, error) {
+		out, _, err := runCommand(t, srv, newTenantSnapshotsRestoreCommand, append([]string{"--snapshot", "snap_1"}, args...)...)
+		return out, err
+	}
+
+	if _, err := run("--dry-run"); err == nil || !strings.Contains(err.Error(), "refusing --dry-run") || len(requests) != 0 {
+		t.Fatalf("expected --dry-run to be refused without server support, got %v (%d restores)", err, len(requests))
+	}
+
+	features = []string{clientpkg.FeatureRestoreDryRun}
+	out, err := run("--dry-run", "--conflict", "skip", "--target-collection", "users")
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if !strings.Contains(out, "Would be created: 2") || !strings.Contains(out, "Documents before: 1") {
+		t.Fatalf("unexpected dry-run output:\n%s", out)
+	}
+	if got := requests[0]; !got.DryRun || got.ConflictPolicy != "skip" || got.TargetCollectionID != users.ID {
+		t.Fatalf("unexpected restore request %+v", got)
+	}
+	if n := len(srv.Documents("users")); n != 1 {
+		t.Fatalf("expected dry run to leave the target untouched, got %d documents", n)
+	}
+
+	ignoreDryRun = true
+	if _, err := run("--dry-run"); err == nil || !strings.Contains(err.Error(), "did not confirm the dry run") {
+		t.Fatalf("expected an ignored dry run to be reported, got %v", err)
+	}
+	ignoreDryRun = false
+
+	if _, err := run("--conflict", "fail"); err == nil || !strings.Contains(err.Error(), "use --conflict skip or overwrite") {
+		t.Fatalf("expected conflict failure, got %v", err)
+	}
+
+	addOnRestore = 1
+	if _, err := run(); err == nil || !strings.Contains(err.Error(), "restore verification failed") {
+		t.Fatalf("expected verification to catch the missing document, got %v", err)
+	}
+
+	addOnRestore = 2
+	out, err = run()
+	if err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	if !strings.Contains(out, "Verified:         4 documents in target") {
+		t.Fatalf("unexpected restore output:\n%s", out)
+	}
+
+	restoredInto = "col_missing"
+	if _, err := run("--target-collection", "archive"); err == nil || !strings.Contains(err.Error(), `restored collection "col_missing" not found`) {
+		t.Fatalf("expected verification to fail when the restored collection is unknown, got %v", err)
+	}
+	if _, err := run("--target-collection", "archive", "--skip-verify"); err != nil {
+		t.Fatalf("expected --skip-verify to skip the check, got %v", err)
+	}
+}
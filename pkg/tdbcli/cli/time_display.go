@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+const (
+	timeFormatLocal    = "local"
+	timeFormatRFC3339  = "rfc3339"
+	timeFormatUnix     = "unix"
+	timeFormatRelative = "relative"
+
+	localTimeLayout = "2006 Jan 02 03:04 PM"
+)
+
+// timeDisplay controls how timestamps are rendered in tables and exports.
+// The root command resolves it once per invocation from --time-format and
+// --timezone, falling back to the time_format and timezone config fields.
+type timeDisplay struct {
+	format string
+	// location is nil when no timezone was chosen, in which case tables use
+	// the machine's local zone and exports keep the offset the server sent.
+	location *time.Location
+}
+
+var activeTimeDisplay = timeDisplay{format: timeFormatLocal}
+
+func parseTimeFormat(value string) (string, error) {
+	switch format := strings.ToLower(strings.TrimSpace(value)); format {
+	case "", timeFormatLocal:
+		return timeFormatLocal, nil
+	case timeFormatRFC3339, timeFormatUnix, timeFormatRelative:
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid time format %q: expected rfc3339, unix, relative, or local", value)
+	}
+}
+
+func parseTimezone(value string) (*time.Location, error) {
+	name := strings.TrimSpace(value)
+	if name == "" {
+		return nil, nil
+	}
+	if strings.EqualFold(name, "local") {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: use an IANA name such as UTC or Asia/Phnom_Penh", value)
+	}
+	return loc, nil
+}
+
+// resolveTimeDisplay prefers the flag values and falls back to the config
+// defaults when a flag is empty.
+func resolveTimeDisplay(flagFormat, flagTimezone, cfgFormat, cfgTimezone string) (timeDisplay, error) {
+	formatValue := flagFormat
+	if strings.TrimSpace(formatValue) == "" {
+		formatValue = cfgFormat
+	}
+	format, err := parseTimeFormat(formatValue)
+	if err != nil {
+		return timeDisplay{}, err
+	}
+	zoneValue := flagTimezone
+	if strings.TrimSpace(zoneValue) == "" {
+		zoneValue = cfgTimezone
+	}
+	loc, err := parseTimezone(zoneValue)
+	if err != nil {
+		return timeDisplay{}, err
+	}
+	return timeDisplay{format: format, location: loc}, nil
+}
+
+func (d timeDisplay) in(t time.Time) time.Time {
+	if d.location == nil {
+		return t.Local()
+	}
+	return t.In(d.location)
+}
+
+// table renders t for human-facing output.
+func (d timeDisplay) table(t time.Time) string {
+	switch d.format {
+	case timeFormatRFC3339:
+		return d.in(t).Format(time.RFC3339)
+	case timeFormatUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	case timeFormatRelative:
+		return humanize.Time(t)
+	default:
+		return d.in(t).Format(localTimeLayout)
+	}
+}
+
+// export renders t for exported documents. Exports stay machine-readable, so
+// local and relative fall back to RFC3339 with nanoseconds.
+func (d timeDisplay) export(t time.Time) any {
+	if d.format == timeFormatUnix {
+		return t.Unix()
+	}
+	if d.location != nil {
+		t = t.In(d.location)
+	}
+	return t.Format(time.RFC3339Nano)
+}
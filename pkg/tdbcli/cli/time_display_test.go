@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveTimeDisplay(t *testing.T) {
+	display, err := resolveTimeDisplay("", "", "rfc3339", "Asia/Phnom_Penh")
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if display.format != timeFormatRFC3339 || display.location.String() != "Asia/Phnom_Penh" {
+		t.Fatalf("expected config defaults, got %+v", display)
+	}
+	display, err = resolveTimeDisplay("unix", "UTC", "rfc3339", "Asia/Phnom_Penh")
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if display.format != timeFormatUnix || display.location != time.UTC {
+		t.Fatalf("expected flags to win, got %+v", display)
+	}
+	if _, err := resolveTimeDisplay("iso", "", "", ""); err == nil {
+		t.Fatal("expected invalid format error")
+	}
+	if _, err := resolveTimeDisplay("", "Mars/Olympus", "", ""); err == nil {
+		t.Fatal("expected invalid timezone error")
+	}
+}
+
+func TestTimeDisplayFormats(t *testing.T) {
+	phnomPenh, err := time.LoadLocation("Asia/Phnom_Penh")
+	if err != nil {
+		t.Skipf("timezone database unavailable: %v", err)
+	}
+	ts := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	cases := []struct {
+		display timeDisplay
+		table   string
+		export  any
+	}{
+		{timeDisplay{format: timeFormatLocal, location: time.UTC}, "2025 Mar 01 09:30 AM", "2025-03-01T09:30:00Z"},
+		{timeDisplay{format: timeFormatLocal, location: phnomPenh}, "2025 Mar 01 04:30 PM", "2025-03-01T16:30:00+07:00"},
+		{timeDisplay{format: timeFormatRFC3339, location: phnomPenh}, "2025-03-01T16:30:00+07:00", "2025-03-01T16:30:00+07:00"},
+		{timeDisplay{format: timeFormatUnix}, "1740821400", int64(1740821400)},
+		{timeDisplay{format: timeFormatRFC3339}, ts.Local().Format(time.RFC3339), "2025-03-01T09:30:00Z"},
+	}
+	for _, tc := range cases {
+		if got := tc.display.table(ts); got != tc.table {
+			t.Errorf("%s/%v table: got %q, want %q", tc.display.format, tc.display.location, got, tc.table)
+		}
+		if got := tc.display.export(ts); got != tc.export {
+			t.Errorf("%s/%v export: got %v, want %v", tc.display.format, tc.display.location, got, tc.export)
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"archive/zip"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -30,11 +32,16 @@ const (
 	defaultBinaryPerm = os.FileMode(0o755)
 )
 
+// upgradeAPIBase is the GitHub API root used to look up releases.
+var upgradeAPIBase = "https://api.github.com"
+
 type githubRelease struct {
-	TagName string `json:"tag_name"`
-	Name    string `json:"name"`
-	Body    string `json:"body"`
-	Assets  []struct {
+	TagName    string `json:"tag_name"`
+	Name       string `json:"name"`
+	Body       string `json:"body"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+	Assets     []struct {
 		Name               string `json:"name"`
 		BrowserDownloadURL string `json:"browser_download_url"`
 	} `json:"assets"`
@@ -107,26 +114,63 @@ func logError(out io.Writer, message string) {
 	logWith(out, "✖", colorError, message, colorError)
 }
 
+const (
+	upgradeChannelStable = "stable"
+	upgradeChannelBeta   = "beta"
+)
+
+// upgradeOptions holds the flags of the upgrade command.
+type upgradeOptions struct {
+	checkOnly    bool
+	channel      string
+	version      string
+	rollback     bool
+	skipChecksum bool
+}
+
 func newUpgradeCommand() *cobra.Command {
-	var checkOnly bool
+	var opts upgradeOptions
 	cmd := &cobra.Command{
 		Use:   "upgrade",
 		Short: "Check for a newer CLI release and install it",
 		Long: "Checks GitHub releases for a newer tdb CLI binary. If a newer release is available, " +
-			"downloads the appropriate archive for your platform and replaces the current executable.",
+			"downloads the appropriate archive for your platform, verifies it against the release's " +
+			"SHA-256 checksums file, and replaces the current executable. The replaced binary is kept " +
+			"next to the executable so --rollback can restore it.",
+		Example: `  # Install the latest stable release
+  tdb upgrade
+
+  # Try the newest pre-release
+  tdb upgrade --channel beta
+
+  # Pin (or downgrade to) a specific release
+  tdb upgrade --version v1.4.2
+
+  # Go back to the binary that was replaced by the last upgrade
+  tdb upgrade --rollback`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			if ctx == nil {
 				ctx = context.Background()
 			}
-			return runUpgrade(ctx, cmd, checkOnly)
+			if opts.rollback {
+				if opts.checkOnly || strings.TrimSpace(opts.version) != "" {
+					return errors.New("--rollback cannot be combined with --check or --version")
+				}
+				return runUpgradeRollback(cmd)
+			}
+			return runUpgrade(ctx, cmd, opts)
 		},
 	}
-	cmd.Flags().BoolVar(&checkOnly, "check", false, "Only check for updates without installing")
+	cmd.Flags().BoolVar(&opts.checkOnly, "check", false, "Only check for updates without installing")
+	cmd.Flags().StringVar(&opts.channel, "channel", upgradeChannelStable, "Release channel: stable or beta (includes pre-releases)")
+	cmd.Flags().StringVar(&opts.version, "version", "", "Install this release tag (e.g. v1.4.2), even if it is older")
+	cmd.Flags().BoolVar(&opts.rollback, "rollback", false, "Restore the binary replaced by the last upgrade")
+	cmd.Flags().BoolVar(&opts.skipChecksum, "skip-checksum", false, "Install even when the release has no checksums file (not recommended)")
 	return cmd
 }
 
-func runUpgrade(ctx context.Context, cmd *cobra.Command, checkOnly bool) error {
+func runUpgrade(ctx context.Context, cmd *cobra.Command, opts upgradeOptions) error {
 	current := versionpkg.Number()
 	if current == "dev" {
 		logWarn(cmd.ErrOrStderr(), "You are running a development build. Upgrade via source control or a release build.")
@@ -134,9 +178,10 @@ func runUpgrade(ctx context.Context, cmd *cobra.Command, checkOnly bool) error {
 	statusOut := cmd.ErrOrStderr()
 	stdout := cmd.OutOrStdout()
 
-	release, err := fetchLatestRelease(ctx)
+	pinned := strings.TrimSpace(opts.version) != ""
+	release, err := resolveUpgradeRelease(ctx, opts.channel, opts.version)
 	if err != nil {
-		return fmt.Errorf("fetch latest release: %w", err)
+		return fmt.Errorf("fetch release: %w", err)
 	}
 	latest := sanitizeVersion(release.TagName)
 	cmp, err := compareVersions(current, latest)
@@ -144,13 +189,19 @@ func runUpgrade(ctx context.Context, cmd *cobra.Command, checkOnly bool) error {
 		return fmt.Errorf("compare versions: %w", err)
 	}
 
-	if cmp >= 0 {
+	switch {
+	case pinned && current == latest:
+		logSuccess(stdout, fmt.Sprintf("tdb CLI %s is already installed.", latest))
+		return nil
+	case pinned:
+		logStep(stdout, fmt.Sprintf("Installing requested version %s (current %s)", latest, current))
+	case cmp >= 0:
 		logSuccess(stdout, fmt.Sprintf("You are running the latest tdb CLI (%s).", versionpkg.Display()))
 		return nil
+	default:
+		logStep(stdout, fmt.Sprintf("New version available: %s (current %s)", latest, current))
 	}
-
-	logStep(stdout, fmt.Sprintf("New version available: %s (current %s)", latest, current))
-	if checkOnly {
+	if opts.checkOnly {
 		logInfo(stdout, "Run without --check to download and install the update.")
 		return nil
 	}
@@ -172,6 +223,16 @@ func runUpgrade(ctx context.Context, cmd *cobra.Command, checkOnly bool) error {
 		return fmt.Errorf("download asset: %w", err)
 	}
 	logSuccess(statusOut, "Download complete")
+	if checksums := checksumsAsset(release); checksums != nil {
+		if err := verifyReleaseChecksum(ctx, checksums.BrowserDownloadURL, archivePath, asset.Name); err != nil {
+			return err
+		}
+		logSuccess(statusOut, "Checksum verified")
+	} else if opts.skipChecksum {
+		logWarn(statusOut, "Release has no checksums file; installing without verification (--skip-checksum)")
+	} else {
+		return fmt.Errorf("release %s has no checksums file; re-run with --skip-checksum to install it unverified", release.TagName)
+	}
 	logStep(statusOut, "Extracting archive")
 
 	newBinary, err := extractBinary(archivePath, asset.Name, tmpDir)
@@ -190,9 +251,47 @@ func runUpgrade(ctx context.Context, cmd *cobra.Command, checkOnly bool) error {
 	}
 
 	logSuccess(stdout, fmt.Sprintf("Successfully updated tdb to %s.", latest))
+	logInfo(stdout, "The previous binary was kept; run \"tdb upgrade --rollback\" to restore it.")
 	return nil
 }
 
+// runUpgradeRollback reinstalls the binary kept by the last upgrade. The
+// current binary becomes the new previous one, so a rollback can be undone by
+// running it again.
+func runUpgradeRollback(cmd *cobra.Command) error {
+	exePath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("determine executable path: %w", err)
+	}
+	previous := previousBinaryPath(exePath)
+	if _, err := os.Stat(previous); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no previous binary found at %s; rollback is available after an upgrade", previous)
+		}
+		return err
+	}
+	tmpDir, err := os.MkdirTemp("", "tdb-rollback-")
+	if err != nil {
+		return fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	staged := filepath.Join(tmpDir, binaryName())
+	if err := copyFile(previous, staged, defaultBinaryPerm); err != nil {
+		return fmt.Errorf("stage previous binary: %w", err)
+	}
+	logStep(cmd.ErrOrStderr(), "Restoring previous binary")
+	if err := installBinary(staged, exePath, cmd); err != nil {
+		return err
+	}
+	logSuccess(cmd.OutOrStdout(), fmt.Sprintf("Rolled back %s to the previous binary.", exePath))
+	return nil
+}
+
+// previousBinaryPath is where an upgrade keeps the binary it replaced.
+func previousBinaryPath(exePath string) string {
+	return exePath + ".previous"
+}
+
 func scheduleUpgradeNotice(cmd *cobra.Command) {
 	if cmd == nil {
 		return
@@ -243,30 +342,146 @@ func detectAvailableUpdate(ctx context.Context) (string, string, error) {
 }
 
 func fetchLatestRelease(ctx context.Context) (*githubRelease, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", upgradeRepoOwner, upgradeRepoName)
+	var release githubRelease
+	if err := fetchGitHubJSON(ctx, "/releases/latest", &release); err != nil {
+		return nil, err
+	}
+	if sanitizeVersion(release.TagName) == "" {
+		return nil, errors.New("latest release missing tag name")
+	}
+	return &release, nil
+}
+
+// resolveUpgradeRelease picks the release to install: the given tag when
+// version is set, otherwise the newest release of the channel. The beta
+// channel includes pre-releases; stable uses GitHub's latest release.
+func resolveUpgradeRelease(ctx context.Context, channel, version string) (*githubRelease, error) {
+	if tag := strings.TrimSpace(version); tag != "" {
+		if !strings.HasPrefix(tag, "v") && !strings.HasPrefix(tag, "V") {
+			tag = "v" + tag
+		}
+		var release githubRelease
+		if err := fetchGitHubJSON(ctx, "/releases/tags/"+tag, &release); err != nil {
+			return nil, fmt.Errorf("release %s: %w", tag, err)
+		}
+		return &release, nil
+	}
+	switch strings.ToLower(strings.TrimSpace(channel)) {
+	case "", upgradeChannelStable:
+		return fetchLatestRelease(ctx)
+	case upgradeChannelBeta:
+		var releases []githubRelease
+		if err := fetchGitHubJSON(ctx, "/releases?per_page=30", &releases); err != nil {
+			return nil, err
+		}
+		var newest *githubRelease
+		for i := range releases {
+			if releases[i].Draft || sanitizeVersion(releases[i].TagName) == "" {
+				continue
+			}
+			if newest == nil {
+				newest = &releases[i]
+				continue
+			}
+			if cmp, err := compareVersions(newest.TagName, releases[i].TagName); err == nil && cmp < 0 {
+				newest = &releases[i]
+			}
+		}
+		if newest == nil {
+			return nil, errors.New("no published releases found")
+		}
+		return newest, nil
+	default:
+		return nil, fmt.Errorf("invalid --channel %q: expected stable or beta", channel)
+	}
+}
+
+func fetchGitHubJSON(ctx context.Context, path string, out any) error {
+	url := fmt.Sprintf("%s/repos/%s/%s%s", strings.TrimRight(upgradeAPIBase, "/"), upgradeRepoOwner, upgradeRepoName, path)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	req.Header.Set("Accept", "application/vnd.github+json")
 	req.Header.Set("User-Agent", versionpkg.UserAgent())
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
-		return nil, fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body)))
 	}
-	var release githubRelease
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return nil, err
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// checksumsAsset returns the release's SHA-256 checksums file, if any.
+func checksumsAsset(release *githubRelease) *struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+} {
+	for i := range release.Assets {
+		name := strings.ToLower(strings.TrimSpace(release.Assets[i].Name))
+		if name == "checksums.txt" || name == "sha256sums" || name == "sha256sums.txt" || strings.HasSuffix(name, "_checksums.txt") {
+			return &release.Assets[i]
+		}
 	}
-	if sanitizeVersion(release.TagName) == "" {
-		return nil, errors.New("latest release missing tag name")
+	return nil
+}
+
+// verifyReleaseChecksum downloads a "<sha256>  <file>" checksums list and
+// compares the entry for assetName with the downloaded archive.
+func verifyReleaseChecksum(ctx context.Context, checksumsURL, archivePath, assetName string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, checksumsURL, nil)
+	if err != nil {
+		return err
 	}
-	return &release, nil
+	req.Header.Set("User-Agent", versionpkg.UserAgent())
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("download checksums: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download checksums: %s", resp.Status)
+	}
+	list, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("download checksums: %w", err)
+	}
+	var expected string
+	for _, line := range strings.Split(string(list), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == assetName {
+			expected = strings.ToLower(fields[0])
+			break
+		}
+	}
+	if expected == "" {
+		return fmt.Errorf("checksums file has no entry for %s", assetName)
+	}
+	actual, err := fileSHA256(archivePath)
+	if err != nil {
+		return err
+	}
+	if actual != expected {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s; not installing", assetName, expected, actual)
+	}
+	return nil
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
 func selectAsset(release *githubRelease) (*struct {
@@ -555,18 +770,27 @@ func installOnUnix(newBinary, exePath string) error {
 				_ = os.Rename(backup, exePath)
 				return fmt.Errorf("install new binary (copy fallback): %w", copyErr)
 			}
-			_ = os.Remove(backup)
+			keepPreviousBinary(backup, exePath)
 			return nil
 		}
 		_ = os.Rename(backup, exePath)
 		return fmt.Errorf("install new binary: %w", err)
 	}
-	// cleanup backup
-	_ = os.Remove(backup)
+	keepPreviousBinary(backup, exePath)
 	_ = os.Chmod(exePath, targetMode)
 	return nil
 }
 
+// keepPreviousBinary moves the backup of the replaced binary to the
+// rollback location, dropping it when that fails.
+func keepPreviousBinary(backup, exePath string) {
+	previous := previousBinaryPath(exePath)
+	_ = os.Remove(previous)
+	if err := os.Rename(backup, previous); err != nil {
+		_ = os.Remove(backup)
+	}
+}
+
 func installOnWindows(newBinary, exePath string, cmd *cobra.Command) error {
 	if err := os.Chmod(newBinary, 0o755); err != nil {
 		return err
@@ -588,10 +812,12 @@ func installOnWindows(newBinary, exePath string, cmd *cobra.Command) error {
 	script := fmt.Sprintf(`$ErrorActionPreference = 'Stop'
 $target = '%s'
 $source = '%s'
+$previous = '%s'
 $pid = %d
 while (Get-Process -Id $pid -ErrorAction SilentlyContinue) { Start-Sleep -Milliseconds 200 }
+Copy-Item -Force -Path $target -Destination $previous
 Move-Item -Force -Path $source -Destination $target
-`, powershellEscape(exePath), powershellEscape(pending), pid)
+`, powershellEscape(exePath), powershellEscape(pending), powershellEscape(previousBinaryPath(exePath)), pid)
 	cmdPS := exec.Command(psPath, "-NoProfile", "-WindowStyle", "Hidden", "-Command", script)
 	// No SysProcAttr for cross-platform compatibility
 	if err := cmdPS.Start(); err != nil {
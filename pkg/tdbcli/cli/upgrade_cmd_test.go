@@ -1,6 +1,19 @@
 package cli
 
-import "testing"
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
 
 func TestCompareVersions(t *testing.T) {
 	cases := []struct {
@@ -61,3 +74,94 @@ func TestSanitizeVersion(t *testing.T) {
 		}
 	}
 }
+
+func TestResolveUpgradeRelease(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		base := "/repos/cubetiqlabs/tdb-cli"
+		switch r.URL.Path {
+		case base + "/releases/latest":
+			_ = json.NewEncoder(w).Encode(githubRelease{TagName: "v1.2.0"})
+		case base + "/releases":
+			_ = json.NewEncoder(w).Encode([]githubRelease{
+				{TagName: "v1.4.0-beta.1", Draft: true},
+				{TagName: "v1.2.0"},
+				{TagName: "v1.3.0-beta.2", Prerelease: true},
+			})
+		case base + "/releases/tags/v1.1.0":
+			_ = json.NewEncoder(w).Encode(githubRelease{TagName: "v1.1.0"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	previous := upgradeAPIBase
+	upgradeAPIBase = srv.URL
+	t.Cleanup(func() { upgradeAPIBase = previous })
+
+	cases := []struct{ channel, version, want string }{
+		{"stable", "", "v1.2.0"},
+		{"beta", "", "v1.3.0-beta.2"},
+		{"stable", "1.1.0", "v1.1.0"},
+	}
+	for _, tc := range cases {
+		release, err := resolveUpgradeRelease(context.Background(), tc.channel, tc.version)
+		if err != nil {
+			t.Fatalf("%s/%s: %v", tc.channel, tc.version, err)
+		}
+		if release.TagName != tc.want {
+			t.Fatalf("%s/%s: got %s, want %s", tc.channel, tc.version, release.TagName, tc.want)
+		}
+	}
+	if _, err := resolveUpgradeRelease(context.Background(), "nightly", ""); err == nil {
+		t.Fatal("expected invalid channel error")
+	}
+	if _, err := resolveUpgradeRelease(context.Background(), "stable", "v9.9.9"); err == nil {
+		t.Fatal("expected missing tag error")
+	}
+}
+
+func TestVerifyReleaseChecksum(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "tdb_linux_amd64.tar.gz")
+	if err := os.WriteFile(archive, []byte("release bytes"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte("release bytes"))
+	list := fmt.Sprintf("%s  tdb_linux_amd64.tar.gz\n%s  tdb_darwin_arm64.zip\n", hex.EncodeToString(sum[:]), strings.Repeat("0", 64))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, list)
+	}))
+	defer srv.Close()
+
+	if err := verifyReleaseChecksum(context.Background(), srv.URL, archive, "tdb_linux_amd64.tar.gz"); err != nil {
+		t.Fatalf("expected checksum to match: %v", err)
+	}
+	if err := verifyReleaseChecksum(context.Background(), srv.URL, archive, "tdb_darwin_arm64.zip"); err == nil || !strings.Contains(err.Error(), "mismatch") {
+		t.Fatalf("expected checksum mismatch, got %v", err)
+	}
+	if err := verifyReleaseChecksum(context.Background(), srv.URL, archive, "tdb_windows_amd64.zip"); err == nil {
+		t.Fatal("expected missing entry error")
+	}
+}
+
+func TestInstallKeepsPreviousBinary(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("windows installs are completed by a helper process")
+	}
+	dir := t.TempDir()
+	exePath := filepath.Join(dir, "tdb")
+	newBinary := filepath.Join(dir, "tdb-new")
+	if err := os.WriteFile(exePath, []byte("old"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(newBinary, []byte("new"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := installOnUnix(newBinary, exePath); err != nil {
+		t.Fatalf("install: %v", err)
+	}
+	current, _ := os.ReadFile(exePath)
+	kept, _ := os.ReadFile(previousBinaryPath(exePath))
+	if string(current) != "new" || string(kept) != "old" {
+		t.Fatalf("expected new binary with old kept, got %q and %q", current, kept)
+	}
+}
@@ -73,6 +73,32 @@ func (c *AdminClient) CreateTenant(ctx context.Context, request CreateTenantRequ
 	return &tenant, nil, nil
 }
 
+// UpdateTenant applies limit changes to a tenant and returns the updated record.
+func (c *AdminClient) UpdateTenant(ctx context.Context, tenantID string, request UpdateTenantRequest) (*Tenant, error) {
+	path := fmt.Sprintf("/admin/tenants/%s", url.PathEscape(tenantID))
+	req, err := c.newJSONRequest(ctx, http.MethodPatch, path, request)
+	if err != nil {
+		return nil, err
+	}
+	c.authorize(req)
+	var tenant Tenant
+	if err := c.do(req, &tenant); err != nil {
+		return nil, err
+	}
+	return &tenant, nil
+}
+
+// DeleteTenant permanently deletes a tenant and all of its data.
+func (c *AdminClient) DeleteTenant(ctx context.Context, tenantID string) error {
+	path := fmt.Sprintf("/admin/tenants/%s", url.PathEscape(tenantID))
+	req, err := c.newJSONRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	c.authorize(req)
+	return c.do(req, nil)
+}
+
 // GenerateKey creates an API key for a tenant or application depending on the request payload.
 func (c *AdminClient) GenerateKey(ctx context.Context, tenantID string, request CreateAPIKeyRequest) (*GeneratedKey, error) {
 	path := fmt.Sprintf("/admin/tenants/%s/keys", url.PathEscape(tenantID))
@@ -116,3 +142,20 @@ func (c *AdminClient) RevokeKey(ctx context.Context, prefix string) error {
 	c.authorize(req)
 	return c.do(req, nil)
 }
+
+// ReassignKey moves the API key with the given prefix to another application
+// scope in place. A nil appID makes the key tenant-wide. Servers without
+// in-place reassignment answer 404 or 405.
+func (c *AdminClient) ReassignKey(ctx context.Context, prefix string, appID *string) (*APIKey, error) {
+	path := fmt.Sprintf("/admin/keys/%s", url.PathEscape(prefix))
+	req, err := c.newJSONRequest(ctx, http.MethodPatch, path, map[string]any{"app_id": appID})
+	if err != nil {
+		return nil, err
+	}
+	c.authorize(req)
+	var resp APIKey
+	if err := c.do(req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminClientUpdateTenant(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("expected PATCH method, got %s", r.Method)
+		}
+		if r.URL.Path != "/admin/tenants/tn-1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("X-Admin-Secret"); got != "secret" {
+			t.Errorf("expected admin secret header, got %q", got)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		if body["rate_limit_per_minute"] != float64(600) {
+			t.Errorf("expected rate_limit_per_minute=600, got %v", body["rate_limit_per_minute"])
+		}
+		if _, ok := body["request_daily_limit"]; ok {
+			t.Errorf("expected request_daily_limit to be omitted, got %v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"tn-1","rate_limit_per_minute":600}`))
+	}))
+	defer ts.Close()
+
+	client, err := NewAdminClient(ts.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewAdminClient: %v", err)
+	}
+	rate := 600
+	tenant, err := client.UpdateTenant(context.Background(), "tn-1", UpdateTenantRequest{RateLimitPerMinute: &rate})
+	if err != nil {
+		t.Fatalf("UpdateTenant: %v", err)
+	}
+	if tenant.RateLimitPerMinute == nil || *tenant.RateLimitPerMinute != 600 {
+		t.Fatalf("unexpected tenant: %+v", tenant)
+	}
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// AuditLogPager walks audit log history one page at a time, following cursor
+// or offset pagination transparently.
+//
+//	pages := c.AuditLogPages(ctx, ListAuditLogsParams{Limit: 500})
+//	for pages.Next() {
+//		for _, entry := range pages.Page() { ... }
+//	}
+//	if err := pages.Err(); err != nil { ... }
+type AuditLogPager struct {
+	ctx    context.Context
+	client *TenantClient
+	params ListAuditLogsParams
+
+	page []AuditLog
+	done bool
+	err  error
+
+	// first and last entry IDs of the previous page, to notice a server
+	// that ignores the offset and keeps returning the same page.
+	firstID, lastID uint
+}
+
+// AuditLogPages returns a pager over the audit entries matching params.
+// params.Limit sets the page size (default 100). Pages are fetched lazily.
+func (c *TenantClient) AuditLogPages(ctx context.Context, params ListAuditLogsParams) *AuditLogPager {
+	if params.Limit <= 0 {
+		params.Limit = defaultIteratorPageSize
+	}
+	return &AuditLogPager{ctx: ctx, client: c, params: params}
+}
+
+// Next fetches the next non-empty page. It returns false once the history is
+// exhausted or an error occurred; check Err afterwards.
+func (p *AuditLogPager) Next() bool {
+	if p.done || p.err != nil {
+		return false
+	}
+	if err := p.ctx.Err(); err != nil {
+		p.err = err
+		return false
+	}
+	resp, err := p.client.ListAuditLogsPage(p.ctx, p.params)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	next := ""
+	if resp.Pagination != nil {
+		next = strings.TrimSpace(resp.Pagination.NextCursor)
+	}
+	if len(resp.Items) > 0 {
+		first, last := resp.Items[0].ID, resp.Items[len(resp.Items)-1].ID
+		if p.page != nil && first == p.firstID && last == p.lastID {
+			p.err = fmt.Errorf("audit log paging is not advancing: the server returned entries %d-%d again", first, last)
+			return false
+		}
+		p.firstID, p.lastID = first, last
+	}
+	if next != "" && next == p.params.Cursor {
+		p.err = fmt.Errorf("audit log paging is not advancing: the server returned cursor %q again", next)
+		return false
+	}
+	p.page = resp.Items
+	switch {
+	case len(resp.Items) == 0:
+		p.done = true
+	case next != "":
+		p.params.Cursor = next
+	case p.params.Cursor != "":
+		// The last cursor page carries no next cursor.
+		p.done = true
+	default:
+		p.params.Offset += len(resp.Items)
+		if len(resp.Items) < p.params.Limit {
+			p.done = true
+		}
+	}
+	return len(resp.Items) > 0
+}
+
+// Page returns the entries of the current page.
+func (p *AuditLogPager) Page() []AuditLog {
+	return p.page
+}
+
+// Err returns the error that stopped paging, if any.
+func (p *AuditLogPager) Err() error {
+	return p.err
+}
+
+// SetPageSize changes the size of the pages fetched from now on, e.g. to
+// avoid over-fetching when only a few more entries are needed.
+func (p *AuditLogPager) SetPageSize(size int) {
+	if size > 0 {
+		p.params.Limit = size
+	}
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func collectAuditIDs(t *testing.T, pages *AuditLogPager) []uint {
+	t.Helper()
+	var ids []uint
+	for pages.Next() {
+		for _, entry := range pages.Page() {
+			ids = append(ids, entry.ID)
+		}
+	}
+	if err := pages.Err(); err != nil {
+		t.Fatalf("pager error: %v", err)
+	}
+	return ids
+}
+
+func TestAuditLogPagesFollowsCursor(t *testing.T) {
+	var queries []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		resp := AuditLogListResponse{}
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			resp.Items = []AuditLog{{ID: 1}, {ID: 2}}
+			resp.Pagination = &DocumentPagination{Limit: 2, NextCursor: "c2"}
+		case "c2":
+			resp.Items = []AuditLog{{ID: 3}, {ID: 4}}
+			resp.Pagination = &DocumentPagination{Limit: 2}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer ts.Close()
+
+	client, err := NewTenantClient(ts.URL, "secret")
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	ids := collectAuditIDs(t, client.AuditLogPages(context.Background(), ListAuditLogsParams{Limit: 2, Fields: []string{"id", "operation"}}))
+	if !reflect.DeepEqual(ids, []uint{1, 2, 3, 4}) {
+		t.Fatalf("unexpected entries %v", ids)
+	}
+	want := []string{"fields=id%2Coperation&limit=2", "cursor=c2&fields=id%2Coperation&limit=2"}
+	if !reflect.DeepEqual(queries, want) {
+		t.Fatalf("unexpected queries %v", queries)
+	}
+}
+
+func TestAuditLogPagesFollowsOffset(t *testing.T) {
+	var offsets []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset := r.URL.Query().Get("offset")
+		offsets = append(offsets, offset)
+		resp := AuditLogListResponse{}
+		switch offset {
+		case "":
+			resp.Items = []AuditLog{{ID: 1}, {ID: 2}}
+		case "2":
+			resp.Items = []AuditLog{{ID: 3}}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer ts.Close()
+
+	client, err := NewTenantClient(ts.URL, "secret")
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	ids := collectAuditIDs(t, client.AuditLogPages(context.Background(), ListAuditLogsParams{Limit: 2}))
+	if !reflect.DeepEqual(ids, []uint{1, 2, 3}) || !reflect.DeepEqual(offsets, []string{"", "2"}) {
+		t.Fatalf("unexpected entries %v after offsets %v", ids, offsets)
+	}
+}
+
+func TestAuditLogPagesStopsWhenOffsetIsIgnored(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_ = json.NewEncoder(w).Encode(AuditLogListResponse{Items: []AuditLog{{ID: 1}, {ID: 2}}})
+	}))
+	defer ts.Close()
+
+	client, err := NewTenantClient(ts.URL, "secret")
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	pages := client.AuditLogPages(context.Background(), ListAuditLogsParams{Limit: 2})
+	var ids []uint
+	for pages.Next() {
+		for _, entry := range pages.Page() {
+			ids = append(ids, entry.ID)
+		}
+	}
+	if pages.Err() == nil || !reflect.DeepEqual(ids, []uint{1, 2}) || requests != 2 {
+		t.Fatalf("expected paging to stop with an error after the repeated page, got %v after %d requests (%v)", ids, requests, pages.Err())
+	}
+}
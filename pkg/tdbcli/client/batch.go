@@ -0,0 +1,206 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrBatchAborted is the result of operations that were never started
+// because an earlier operation failed while StopOnError was set.
+var ErrBatchAborted = errors.New("batch aborted after an earlier operation failed")
+
+// BatchOperation is one unit of work run by a BatchExecutor. Operations that
+// produce values should store them in variables they close over.
+type BatchOperation func(ctx context.Context) error
+
+// BatchResult is the outcome of the operation at Index.
+type BatchResult struct {
+	Index    int
+	Err      error
+	Attempts int
+	Duration time.Duration
+}
+
+// BatchExecutor runs operations with bounded concurrency, an optional rate
+// limit, and retries of transient failures. The zero value runs operations
+// one at a time, as fast as possible, without retries.
+//
+//	exec := client.BatchExecutor{Concurrency: 8, RatePerSecond: 50, MaxRetries: 3}
+//	results := exec.Execute(ctx, ops)
+//	for _, r := range results {
+//		if r.Err != nil { ... }
+//	}
+type BatchExecutor struct {
+	// Concurrency is the maximum number of operations in flight (default 1).
+	Concurrency int
+	// RatePerSecond caps how many operations (including retries) start per
+	// second. Zero means unlimited.
+	RatePerSecond float64
+	// MaxRetries is how many times a failed operation is retried when
+	// Retryable reports its error as transient.
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry, doubled for every
+	// further attempt (default 500ms).
+	RetryBackoff time.Duration
+	// Retryable decides whether an error is worth retrying (default
+	// IsTransientError).
+	Retryable func(error) bool
+	// StopOnError skips the operations not yet started once one fails; they
+	// report ErrBatchAborted.
+	StopOnError bool
+	// OnResult, when set, is called once per operation as it finishes. Calls
+	// are serialized, so the callback does not need its own locking.
+	OnResult func(BatchResult)
+}
+
+// Execute runs ops and returns one result per operation, in the order of
+// ops. When ctx is cancelled, operations that have not started report the
+// context error.
+func (e BatchExecutor) Execute(ctx context.Context, ops []BatchOperation) []BatchResult {
+	results := make([]BatchResult, len(ops))
+	if len(ops) == 0 {
+		return results
+	}
+	workers := max(e.Concurrency, 1)
+	workers = min(workers, len(ops))
+	limiter := newBatchLimiter(e.RatePerSecond)
+	defer limiter.stop()
+
+	var (
+		mu      sync.Mutex
+		aborted bool
+	)
+	finish := func(result BatchResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		results[result.Index] = result
+		if result.Err != nil && e.StopOnError {
+			aborted = true
+		}
+		if e.OnResult != nil {
+			e.OnResult(result)
+		}
+	}
+	isAborted := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return aborted
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indexes {
+				switch {
+				case ctx.Err() != nil:
+					finish(BatchResult{Index: idx, Err: ctx.Err()})
+				case isAborted():
+					finish(BatchResult{Index: idx, Err: ErrBatchAborted})
+				default:
+					finish(e.run(ctx, idx, ops[idx], limiter))
+				}
+			}
+		}()
+	}
+	for idx := range ops {
+		indexes <- idx
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+func (e BatchExecutor) run(ctx context.Context, idx int, op BatchOperation, limiter *batchLimiter) BatchResult {
+	retryable := e.Retryable
+	if retryable == nil {
+		retryable = IsTransientError
+	}
+	backoff := e.RetryBackoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+	started := time.Now()
+	result := BatchResult{Index: idx}
+	for attempt := 0; ; attempt++ {
+		if err := limiter.wait(ctx); err != nil {
+			result.Err = err
+			break
+		}
+		result.Attempts++
+		result.Err = op(ctx)
+		if result.Err == nil || attempt >= e.MaxRetries || ctx.Err() != nil || !retryable(result.Err) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff * time.Duration(1<<attempt)):
+		}
+	}
+	result.Duration = time.Since(started)
+	return result
+}
+
+// IsTransientError reports whether err looks temporary: a network timeout or
+// a rate-limited or unavailable server. Conflicts, validation errors, and
+// cancelled contexts are not transient.
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var conflict *ConflictError
+	if errors.As(err, &conflict) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, marker := range []string{"too many requests", "rate limit", "bad gateway", "service unavailable", "gateway timeout", "connection reset", "connection refused", "unexpected eof"} {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// batchLimiter spaces operation starts evenly to honour a per-second rate.
+type batchLimiter struct {
+	ticker *time.Ticker
+}
+
+func newBatchLimiter(perSecond float64) *batchLimiter {
+	if perSecond <= 0 {
+		return &batchLimiter{}
+	}
+	interval := time.Duration(float64(time.Second) / perSecond)
+	if interval <= 0 {
+		return &batchLimiter{}
+	}
+	return &batchLimiter{ticker: time.NewTicker(interval)}
+}
+
+func (l *batchLimiter) wait(ctx context.Context) error {
+	if l.ticker == nil {
+		return ctx.Err()
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-l.ticker.C:
+		return nil
+	}
+}
+
+func (l *batchLimiter) stop() {
+	if l.ticker != nil {
+		l.ticker.Stop()
+	}
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatchExecutorBoundsConcurrencyAndRetries(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	attempts := make([]atomic.Int32, 10)
+	ops := make([]BatchOperation, len(attempts))
+	for i := range ops {
+		ops[i] = func(ctx context.Context) error {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				old := peak.Load()
+				if n <= old || peak.CompareAndSwap(old, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			switch {
+			case i == 3 && attempts[i].Add(1) < 3:
+				return errors.New("request failed: 503 Service Unavailable")
+			case i == 7:
+				attempts[i].Add(1)
+				return errors.New("request failed: invalid payload")
+			}
+			return nil
+		}
+	}
+
+	var reported int
+	exec := BatchExecutor{Concurrency: 3, MaxRetries: 2, RetryBackoff: time.Millisecond, OnResult: func(BatchResult) { reported++ }}
+	results := exec.Execute(context.Background(), ops)
+
+	if got := peak.Load(); got > 3 {
+		t.Fatalf("expected at most 3 operations in flight, saw %d", got)
+	}
+	if reported != len(ops) {
+		t.Fatalf("expected %d OnResult calls, got %d", len(ops), reported)
+	}
+	for i, result := range results {
+		if result.Index != i {
+			t.Fatalf("result %d has index %d", i, result.Index)
+		}
+	}
+	if r := results[3]; r.Err != nil || r.Attempts != 3 {
+		t.Fatalf("expected transient failure to succeed on the third attempt, got %+v", r)
+	}
+	if r := results[7]; r.Err == nil || r.Attempts != 1 {
+		t.Fatalf("expected permanent failure without retries, got %+v", r)
+	}
+}
+
+func TestBatchExecutorStopOnError(t *testing.T) {
+	var ran atomic.Int32
+	ops := make([]BatchOperation, 5)
+	for i := range ops {
+		ops[i] = func(ctx context.Context) error {
+			ran.Add(1)
+			if i == 1 {
+				return errors.New("boom")
+			}
+			return nil
+		}
+	}
+	results := BatchExecutor{StopOnError: true}.Execute(context.Background(), ops)
+	if ran.Load() != 2 {
+		t.Fatalf("expected execution to stop after the failure, ran %d", ran.Load())
+	}
+	for _, r := range results[2:] {
+		if !errors.Is(r.Err, ErrBatchAborted) {
+			t.Fatalf("expected aborted result, got %+v", r)
+		}
+	}
+}
+
+func TestBatchExecutorRateLimit(t *testing.T) {
+	ops := make([]BatchOperation, 5)
+	for i := range ops {
+		ops[i] = func(ctx context.Context) error { return nil }
+	}
+	started := time.Now()
+	BatchExecutor{Concurrency: 5, RatePerSecond: 100}.Execute(context.Background(), ops)
+	if elapsed := time.Since(started); elapsed < 40*time.Millisecond {
+		t.Fatalf("expected 5 operations at 100/s to take at least 40ms, took %s", elapsed)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -19,8 +20,16 @@ type httpDoer interface {
 }
 
 type baseClient struct {
-	baseURL    *url.URL
-	httpClient httpDoer
+	baseURL      *url.URL
+	httpClient   httpDoer
+	maxRetries   int
+	retryBackoff time.Duration
+
+	maxResponseSize int64
+	transport       transportSettings
+	observe         func(RequestMetrics)
+	readOnly        bool
+	headers         http.Header
 }
 
 type Option func(*baseClient)
@@ -39,6 +48,13 @@ func newBase(endpoint string, opts ...Option) (*baseClient, error) {
 	if trimmed == "" {
 		return nil, fmt.Errorf("endpoint is required")
 	}
+	socket, unixBase, isUnix, err := splitUnixEndpoint(trimmed)
+	if err != nil {
+		return nil, err
+	}
+	if isUnix {
+		trimmed = unixBase
+	}
 	parsed, err := url.Parse(trimmed)
 	if err != nil {
 		return nil, fmt.Errorf("invalid endpoint: %w", err)
@@ -54,12 +70,31 @@ func newBase(endpoint string, opts ...Option) (*baseClient, error) {
 		}
 	}
 	b := &baseClient{
-		baseURL:    parsed,
-		httpClient: &http.Client{Timeout: 15 * time.Second},
+		baseURL:      parsed,
+		maxRetries:   defaultMaxRetries,
+		retryBackoff: defaultRetryBackoff,
+
+		maxResponseSize: defaultMaxResponseSize,
+		transport:       defaultTransportSettings(),
+	}
+	if isUnix {
+		b.transport.dial = unixSocketDialer(socket)
 	}
 	for _, opt := range opts {
 		opt(b)
 	}
+	if b.httpClient == nil {
+		b.httpClient = &http.Client{Timeout: defaultRequestTimeout, Transport: b.transport.newTransport()}
+	}
+	if len(b.headers) > 0 {
+		b.httpClient = headerDoer{next: b.httpClient, headers: b.headers}
+	}
+	if b.observe != nil {
+		b.httpClient = observingDoer{next: b.httpClient, observe: b.observe}
+	}
+	if b.readOnly {
+		b.httpClient = readOnlyDoer{next: b.httpClient}
+	}
 	return b, nil
 }
 
@@ -115,7 +150,7 @@ func (b *baseClient) newJSONRequest(ctx context.Context, method, path string, pa
 }
 
 func (b *baseClient) do(req *http.Request, out interface{}) error {
-	resp, err := b.httpClient.Do(req)
+	resp, err := b.sendWithRetry(req)
 	if err != nil {
 		return err
 	}
@@ -126,19 +161,43 @@ func (b *baseClient) do(req *http.Request, out interface{}) error {
 		if msg == "" {
 			msg = resp.Status
 		}
+		if resp.StatusCode == http.StatusConflict || resp.StatusCode == http.StatusPreconditionFailed {
+			return newConflictError(msg)
+		}
+		if resp.StatusCode == http.StatusRequestEntityTooLarge {
+			return &PayloadTooLargeError{Body: msg, RequestBytes: req.ContentLength}
+		}
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			return &AuthError{StatusCode: resp.StatusCode, Body: msg}
+		}
 		return fmt.Errorf("request failed: %s", msg)
 	}
 
 	if out == nil || resp.StatusCode == http.StatusNoContent {
 		return nil
 	}
-	dec := json.NewDecoder(io.LimitReader(resp.Body, 4<<20)) // 4MB safety limit
+	dec := json.NewDecoder(newSizeLimitedReader(resp.Body, b.responseLimit(req.Context())))
 	if err := dec.Decode(out); err != nil {
+		var tooLarge *ResponseTooLargeError
+		if errors.As(err, &tooLarge) {
+			return err
+		}
 		return fmt.Errorf("decode response: %w", err)
 	}
 	return nil
 }
 
+// AuthError is returned for 401 Unauthorized and 403 Forbidden responses: the
+// credentials were rejected or lack access to the resource.
+type AuthError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *AuthError) Error() string {
+	return "request failed: " + e.Body
+}
+
 func readErrorBody(r io.Reader) string {
 	raw, err := io.ReadAll(io.LimitReader(r, 4<<10)) // 4KB
 	if err != nil {
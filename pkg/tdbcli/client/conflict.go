@@ -0,0 +1,118 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ConflictError is returned for 409 Conflict responses, such as a duplicate
+// primary key or a version mismatch. Details are parsed from structured error
+// bodies when the server provides them; Body always holds the raw message.
+type ConflictError struct {
+	Message    string
+	Reason     string
+	DocumentID string
+	Key        string
+	Fields     []string
+	Body       string
+}
+
+func (e *ConflictError) Error() string {
+	if !e.Structured() {
+		return "request failed: " + e.Body
+	}
+	msg := e.Message
+	if msg == "" {
+		msg = "conflict"
+	}
+	if e.Reason != "" && !strings.Contains(strings.ToLower(msg), strings.ToLower(strings.ReplaceAll(e.Reason, "_", " "))) {
+		msg = fmt.Sprintf("%s (%s)", msg, e.Reason)
+	}
+	var details []string
+	if e.DocumentID != "" {
+		details = append(details, "document "+e.DocumentID)
+	}
+	if e.Key != "" {
+		details = append(details, "key "+e.Key)
+	}
+	if len(e.Fields) > 0 {
+		details = append(details, "fields "+strings.Join(e.Fields, ", "))
+	}
+	if len(details) == 0 {
+		return "request failed: " + msg
+	}
+	return fmt.Sprintf("request failed: %s: conflicts with %s", msg, strings.Join(details, "; "))
+}
+
+// Structured reports whether the error body identified the conflicting
+// document or fields.
+func (e *ConflictError) Structured() bool {
+	return e.DocumentID != "" || e.Key != "" || len(e.Fields) > 0
+}
+
+// newConflictError parses body as a JSON error object, looking at the top
+// level and at nested "details", "conflict", or "error" objects.
+func newConflictError(body string) *ConflictError {
+	e := &ConflictError{Body: body}
+	var top map[string]any
+	if err := json.Unmarshal([]byte(body), &top); err != nil {
+		return e
+	}
+	objects := []map[string]any{top}
+	for _, name := range []string{"details", "conflict", "error"} {
+		if nested, ok := top[name].(map[string]any); ok {
+			objects = append(objects, nested)
+		}
+	}
+	for _, obj := range objects {
+		fillString(&e.Message, obj, "message", "error")
+		fillString(&e.Reason, obj, "reason", "code", "type")
+		fillString(&e.DocumentID, obj, "document_id", "existing_id", "conflicting_id")
+		fillString(&e.Key, obj, "key", "primary_key", "existing_key")
+		if len(e.Fields) == 0 {
+			e.Fields = stringList(obj["fields"])
+		}
+		if len(e.Fields) == 0 {
+			e.Fields = stringList(obj["conflicting_fields"])
+		}
+		if len(e.Fields) == 0 {
+			if field, ok := obj["field"].(string); ok && field != "" {
+				e.Fields = []string{field}
+			}
+		}
+	}
+	return e
+}
+
+func fillString(dst *string, obj map[string]any, keys ...string) {
+	if *dst != "" {
+		return
+	}
+	for _, key := range keys {
+		switch value := obj[key].(type) {
+		case string:
+			if trimmed := strings.TrimSpace(value); trimmed != "" {
+				*dst = trimmed
+				return
+			}
+		case float64:
+			*dst = fmt.Sprintf("%v", value)
+			return
+		}
+	}
+}
+
+func stringList(value any) []string {
+	items, ok := value.([]any)
+	if !ok {
+		return nil
+	}
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok && s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
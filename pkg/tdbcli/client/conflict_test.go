@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewConflictError(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "flat",
+			body: `{"error":"duplicate primary key","code":"duplicate_key","document_id":"doc_1","key":"ada@example.com","fields":["email"]}`,
+			want: "request failed: duplicate primary key (duplicate_key): conflicts with document doc_1; key ada@example.com; fields email",
+		},
+		{
+			name: "nested",
+			body: `{"error":{"message":"version mismatch","details":null},"details":{"existing_id":"doc_2","field":"version"}}`,
+			want: "request failed: version mismatch: conflicts with document doc_2; fields version",
+		},
+		{
+			name: "reason appended",
+			body: `{"message":"write rejected","reason":"version_mismatch","conflicting_id":"doc_3"}`,
+			want: "request failed: write rejected (version_mismatch): conflicts with document doc_3",
+		},
+		{
+			name: "plain text",
+			body: "document already exists",
+			want: "request failed: document already exists",
+		},
+	}
+	for _, tc := range cases {
+		if got := newConflictError(tc.body).Error(); got != tc.want {
+			t.Fatalf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestDoReturnsConflictError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"error":"duplicate primary key","document_id":"doc_1","key":"k1"}`))
+	}))
+	defer ts.Close()
+
+	client, err := NewTenantClient(ts.URL, "key", WithRetries(0, 0))
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	_, err = client.CreateDocument(context.Background(), "users", []byte(`{"id":"k1"}`), "")
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("expected ConflictError, got %T: %v", err, err)
+	}
+	if conflict.DocumentID != "doc_1" || conflict.Key != "k1" || !strings.Contains(err.Error(), "document doc_1") {
+		t.Fatalf("unexpected conflict: %+v", conflict)
+	}
+}
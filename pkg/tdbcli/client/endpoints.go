@@ -0,0 +1,95 @@
+package client
+
+import "net/http"
+
+// Endpoint describes one HTTP call made by TenantClient or AdminClient, so the
+// client can be checked against a server's API description. Path is an
+// OpenAPI-style template such as /api/collections/{name}. Request and Response
+// are zero values of the JSON types sent and decoded; nil means the body is
+// raw document JSON or absent.
+type Endpoint struct {
+	Operation string
+	Method    string
+	Path      string
+	Query     []string
+	Request   any
+	Response  any
+}
+
+// Endpoints lists the endpoints used by the client. Keep it in step with
+// tenant.go and admin.go: `tdb dev verify-api` relies on it to report drift.
+func Endpoints() []Endpoint {
+	appScope := []string{"app_id"}
+	return []Endpoint{
+		{Operation: "ListApplications", Method: http.MethodGet, Path: "/api/applications", Response: struct {
+			Items []Application `json:"items"`
+		}{}},
+		{Operation: "CreateApplication", Method: http.MethodPost, Path: "/api/applications", Request: CreateApplicationRequest{}, Response: Application{}},
+		{Operation: "GetApplication", Method: http.MethodGet, Path: "/api/applications/{id}", Response: Application{}},
+		{Operation: "UpdateApplication", Method: http.MethodPatch, Path: "/api/applications/{id}", Request: UpdateApplicationRequest{}, Response: Application{}},
+
+		{Operation: "ListCollections", Method: http.MethodGet, Path: "/api/collections", Query: []string{"app_id", "include_deleted", "only_deleted"}, Response: []Collection{}},
+		{Operation: "CountCollections", Method: http.MethodGet, Path: "/api/collections/count", Query: appScope, Response: struct {
+			Count int64 `json:"count"`
+		}{}},
+		{Operation: "CreateCollection", Method: http.MethodPost, Path: "/api/collections", Request: CreateCollectionRequest{}, Response: Collection{}},
+		{Operation: "GetCollection", Method: http.MethodGet, Path: "/api/collections/{name}", Query: appScope, Response: Collection{}},
+		{Operation: "UpdateCollection", Method: http.MethodPut, Path: "/api/collections/{name}", Query: appScope, Request: UpdateCollectionRequest{}, Response: Collection{}},
+		{Operation: "DeleteCollection", Method: http.MethodDelete, Path: "/api/collections/{name}", Query: appScope},
+		{Operation: "RestoreCollection", Method: http.MethodPost, Path: "/api/collections/{name}/restore", Query: appScope, Response: Collection{}},
+		{Operation: "GetCollectionLimits", Method: http.MethodGet, Path: "/api/collections/{name}/limits", Query: appScope, Response: CollectionLimitsResponse{}},
+		{Operation: "UpdateCollectionLimits", Method: http.MethodPut, Path: "/api/collections/{name}/limits", Query: appScope, Request: UpdateCollectionLimitsRequest{}, Response: CollectionLimitsResponse{}},
+		{Operation: "ListCollectionHooks", Method: http.MethodGet, Path: "/api/collections/{name}/hooks", Query: appScope, Response: CollectionHookListResponse{}},
+		{Operation: "PutCollectionHook", Method: http.MethodPut, Path: "/api/collections/{name}/hooks/{hook}", Query: appScope, Request: CollectionHook{}, Response: CollectionHook{}},
+		{Operation: "DeleteCollectionHook", Method: http.MethodDelete, Path: "/api/collections/{name}/hooks/{hook}", Query: appScope},
+		{Operation: "TestCollectionHook", Method: http.MethodPost, Path: "/api/collections/{name}/hooks/{hook}/test", Query: appScope, Request: CollectionHookTestRequest{}, Response: CollectionHookTestResult{}},
+
+		{Operation: "ListDocuments", Method: http.MethodGet, Path: "/api/collections/{collection}/documents", Query: []string{"app_id", "limit", "offset", "cursor", "include_deleted", "select", "select_only", "sort"}, Response: DocumentListResponse{}},
+		{Operation: "StreamExport", Method: http.MethodGet, Path: "/api/collections/{collection}/export", Query: []string{"app_id", "limit", "cursor", "select", "select_only"}},
+		{Operation: "CountDocuments", Method: http.MethodGet, Path: "/api/collections/{collection}/documents/count", Query: appScope, Response: struct {
+			Count int64 `json:"count"`
+		}{}},
+		{Operation: "GetDocument", Method: http.MethodGet, Path: "/api/collections/{collection}/documents/{id}", Query: appScope, Response: Document{}},
+		{Operation: "GetDocumentByPrimaryKey", Method: http.MethodGet, Path: "/api/collections/{collection}/documents/primary/{key}", Query: appScope, Response: Document{}},
+		{Operation: "CreateDocument", Method: http.MethodPost, Path: "/api/collections/{collection}/documents", Response: Document{}},
+		{Operation: "UpdateDocument", Method: http.MethodPut, Path: "/api/collections/{collection}/documents/{id}", Response: Document{}},
+		{Operation: "PatchDocument", Method: http.MethodPatch, Path: "/api/collections/{collection}/documents/{id}", Response: Document{}},
+		{Operation: "DeleteDocument", Method: http.MethodDelete, Path: "/api/collections/{collection}/documents/{id}"},
+		{Operation: "PurgeDocument", Method: http.MethodDelete, Path: "/api/collections/{collection}/documents/{id}/purge", Query: []string{"confirm", "app_id"}},
+		{Operation: "BulkCreateDocuments", Method: http.MethodPost, Path: "/api/collections/{collection}/documents/bulk", Response: DocumentBulkResponse{}},
+
+		{Operation: "ListSavedQueries", Method: http.MethodGet, Path: "/api/queries", Query: appScope, Response: SavedQueryListResponse{}},
+		{Operation: "CreateSavedQuery", Method: http.MethodPost, Path: "/api/queries", Response: Document{}},
+		{Operation: "GetSavedQuery", Method: http.MethodGet, Path: "/api/queries/{id}", Query: appScope, Response: Document{}},
+		{Operation: "ExecuteSavedQueryByID", Method: http.MethodPost, Path: "/api/queries/{id}/execute", Query: []string{"app_id", "format"}, Response: SavedQueryExecutionResult{}},
+		{Operation: "GetSavedQueryByName", Method: http.MethodGet, Path: "/api/queries/name/{name}", Query: appScope, Response: Document{}},
+		{Operation: "PutSavedQuery", Method: http.MethodPut, Path: "/api/queries/name/{name}", Query: appScope, Response: Document{}},
+		{Operation: "PatchSavedQuery", Method: http.MethodPatch, Path: "/api/queries/name/{name}", Query: appScope, Response: Document{}},
+		{Operation: "ExecuteSavedQueryByName", Method: http.MethodPost, Path: "/api/queries/name/{name}/execute", Query: []string{"app_id", "format"}, Response: SavedQueryExecutionResult{}},
+
+		{Operation: "ReportQuery", Method: http.MethodPost, Path: "/api/query", Query: []string{"app_id", "limit", "offset", "cursor", "select", "select_only"}, Response: ReportQueryResponse{}},
+		{Operation: "ListAuditLogs", Method: http.MethodGet, Path: "/api/audit", Query: []string{"limit", "offset", "cursor", "fields", "collection", "document_id", "operation", "actor", "since", "until", "sort"}, Response: AuditLogListResponse{}},
+		{Operation: "AuthStatus", Method: http.MethodGet, Path: "/api/me", Response: AuthStatus{}},
+		{Operation: "Capabilities", Method: http.MethodGet, Path: "/api/capabilities", Response: ServerCapabilities{}},
+
+		{Operation: "ListSnapshots", Method: http.MethodGet, Path: "/api/snapshots", Query: []string{"collection_id", "limit", "offset"}, Response: SnapshotListResponse{}},
+		{Operation: "CreateSnapshot", Method: http.MethodPost, Path: "/api/snapshots", Request: CreateSnapshotRequest{}, Response: Snapshot{}},
+		{Operation: "GetSnapshot", Method: http.MethodGet, Path: "/api/snapshots/{id}", Response: Snapshot{}},
+		{Operation: "DeleteSnapshot", Method: http.MethodDelete, Path: "/api/snapshots/{id}"},
+		{Operation: "RestoreSnapshot", Method: http.MethodPost, Path: "/api/snapshots/{id}/restore", Request: RestoreSnapshotRequest{}, Response: RestoreSnapshotResponse{}},
+		{Operation: "ListSnapshotKeys", Method: http.MethodGet, Path: "/api/snapshot-keys", Response: SnapshotKeyListResponse{}},
+		{Operation: "CreateSnapshotKey", Method: http.MethodPost, Path: "/api/snapshot-keys", Request: CreateSnapshotKeyRequest{}, Response: SnapshotKey{}},
+		{Operation: "RotateSnapshotKey", Method: http.MethodPost, Path: "/api/snapshot-keys/{id}/rotate", Response: RotateSnapshotKeyResponse{}},
+
+		{Operation: "ListTenants", Method: http.MethodGet, Path: "/admin/tenants", Response: []Tenant{}},
+		{Operation: "CreateTenant", Method: http.MethodPost, Path: "/admin/tenants", Request: CreateTenantRequest{}, Response: Tenant{}},
+		{Operation: "UpdateTenant", Method: http.MethodPatch, Path: "/admin/tenants/{id}", Request: UpdateTenantRequest{}, Response: Tenant{}},
+		{Operation: "DeleteTenant", Method: http.MethodDelete, Path: "/admin/tenants/{id}"},
+		{Operation: "GenerateKey", Method: http.MethodPost, Path: "/admin/tenants/{id}/keys", Request: CreateAPIKeyRequest{}, Response: GeneratedKey{}},
+		{Operation: "ListKeys", Method: http.MethodGet, Path: "/admin/tenants/{id}/keys", Query: appScope, Response: []APIKey{}},
+		{Operation: "RevokeKey", Method: http.MethodDelete, Path: "/admin/keys/{prefix}"},
+		{Operation: "ReassignKey", Method: http.MethodPatch, Path: "/admin/keys/{prefix}", Request: struct {
+			AppID *string `json:"app_id"`
+		}{}, Response: APIKey{}},
+	}
+}
@@ -0,0 +1,194 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+// TestEndpointsMatchClientRequests calls every client method against the fake
+// server and checks each request it sends against the Endpoints registry, so
+// a method whose path, verb or query drifts from its entry fails here.
+func TestEndpointsMatchClientRequests(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	type sent struct {
+		method, path string
+		query        []string
+	}
+	var requests []sent
+	routes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var query []string
+		for key := range r.URL.Query() {
+			query = append(query, key)
+		}
+		requests = append(requests, sent{r.Method, r.URL.Path, query})
+		routes.ServeHTTP(w, r)
+	})
+
+	tenant := srv.TenantClient(t)
+	admin, err := clientpkg.NewAdminClient(srv.URL, "admin-secret", clientpkg.WithRetries(0, 0))
+	if err != nil {
+		t.Fatalf("NewAdminClient: %v", err)
+	}
+	ctx := context.Background()
+	doc := []byte(`{"name":"Ann"}`)
+	appID := "app_1"
+
+	calls := []struct {
+		operation string
+		call      func()
+	}{
+		{"ListApplications", func() { _, _ = tenant.ListApplications(ctx) }},
+		{"CreateApplication", func() {
+			_, _, _ = tenant.CreateApplication(ctx, clientpkg.CreateApplicationRequest{Name: "web"})
+		}},
+		{"GetApplication", func() { _, _ = tenant.GetApplication(ctx, "app_1") }},
+		{"UpdateApplication", func() { _, _ = tenant.UpdateApplication(ctx, "app_1", clientpkg.UpdateApplicationRequest{}) }},
+
+		{"ListCollections", func() { _, _ = tenant.ListCollections(ctx, appID) }},
+		{"ListCollections", func() {
+			_, _ = tenant.ListCollectionsWithParams(ctx, clientpkg.ListCollectionsParams{AppID: appID, IncludeDeleted: true, OnlyDeleted: true})
+		}},
+		{"CountCollections", func() { _, _ = tenant.CountCollections(ctx, appID) }},
+		{"CreateCollection", func() { _, _ = tenant.CreateCollection(ctx, clientpkg.CreateCollectionRequest{Name: "users"}) }},
+		{"GetCollection", func() { _, _ = tenant.GetCollection(ctx, "users", appID) }},
+		{"UpdateCollection", func() {
+			_, _ = tenant.UpdateCollection(ctx, "users", appID, clientpkg.UpdateCollectionRequest{})
+		}},
+		{"GetCollectionLimits", func() { _, _ = tenant.GetCollectionLimits(ctx, "users", appID) }},
+		{"UpdateCollectionLimits", func() {
+			_, _ = tenant.UpdateCollectionLimits(ctx, "users", appID, clientpkg.UpdateCollectionLimitsRequest{})
+		}},
+		{"ListCollectionHooks", func() { _, _ = tenant.ListCollectionHooks(ctx, "users", appID) }},
+		{"PutCollectionHook", func() {
+			_, _ = tenant.PutCollectionHook(ctx, "users", appID, clientpkg.CollectionHook{Name: "notify", Event: "document.created", Action: "webhook", Target: "https://example.com"})
+		}},
+		{"TestCollectionHook", func() {
+			_, _ = tenant.TestCollectionHook(ctx, "users", "notify", appID, clientpkg.CollectionHookTestRequest{})
+		}},
+		{"DeleteCollectionHook", func() { _ = tenant.DeleteCollectionHook(ctx, "users", "notify", appID) }},
+
+		{"CreateDocument", func() { _, _ = tenant.CreateDocument(ctx, "users", doc, appID) }},
+		{"ListDocuments", func() {
+			_, _ = tenant.ListDocuments(ctx, "users", clientpkg.ListDocumentsParams{AppID: appID, Limit: 10, Offset: 1, SelectFields: []string{"name"}, SelectOnly: true, Sort: []string{"name"}})
+		}},
+		{"StreamExport", func() {
+			if body, _, err := tenant.StreamExport(ctx, "users", []string{"name"}, true, "c1", 10, appID); err == nil {
+				_ = body.Close()
+			}
+		}},
+		{"CountDocuments", func() { _, _ = tenant.CountDocuments(ctx, "users", appID) }},
+		{"GetDocument", func() { _, _ = tenant.GetDocument(ctx, "users", "doc_1", appID) }},
+		{"GetDocumentByPrimaryKey", func() { _, _ = tenant.GetDocumentByPrimaryKey(ctx, "users", "ann", appID) }},
+		{"UpdateDocument", func() { _, _ = tenant.UpdateDocument(ctx, "users", "doc_1", doc, appID) }},
+		{"PatchDocument", func() { _, _ = tenant.PatchDocument(ctx, "users", "doc_1", doc, appID) }},
+		{"PatchDocument", func() { _, _ = tenant.PatchDocumentIfVersion(ctx, "users", "doc_1", doc, 1, appID) }},
+		{"PatchDocument", func() {
+			_, _ = tenant.ApplyJSONPatch(ctx, "users", "doc_1", []byte(`[{"op":"add","path":"/a","value":1}]`), appID)
+		}},
+		{"DeleteDocument", func() { _ = tenant.DeleteDocument(ctx, "users", "doc_1", appID) }},
+		{"PurgeDocument", func() { _ = tenant.PurgeDocument(ctx, "users", "doc_1", true, appID) }},
+		{"BulkCreateDocuments", func() { _, _ = tenant.BulkCreateDocuments(ctx, "users", []byte(`[{"name":"Bo"}]`), appID) }},
+		{"DeleteCollection", func() { _ = tenant.DeleteCollection(ctx, "users", appID) }},
+		{"RestoreCollection", func() { _, _ = tenant.RestoreCollection(ctx, "users", appID) }},
+
+		{"ListSavedQueries", func() { _, _ = tenant.ListSavedQueries(ctx, appID) }},
+		{"CreateSavedQuery", func() { _, _ = tenant.CreateSavedQuery(ctx, []byte(`{"name":"active"}`), appID) }},
+		{"GetSavedQuery", func() { _, _ = tenant.GetSavedQuery(ctx, "q_1", appID) }},
+		{"GetSavedQueryByName", func() { _, _ = tenant.GetSavedQueryByName(ctx, "active", appID) }},
+		{"PutSavedQuery", func() { _, _ = tenant.PutSavedQuery(ctx, "active", []byte(`{"collection":"users"}`), appID) }},
+		{"PatchSavedQuery", func() { _, _ = tenant.PatchSavedQuery(ctx, "active", []byte(`{"collection":"users"}`), appID) }},
+		{"ExecuteSavedQueryByID", func() { _, _ = tenant.ExecuteSavedQueryByID(ctx, "q_1", nil, appID) }},
+		{"ExecuteSavedQueryByName", func() { _, _ = tenant.ExecuteSavedQueryByName(ctx, "active", nil, appID) }},
+		{"ExecuteSavedQueryByName", func() {
+			if body, _, err := tenant.StreamSavedQuery(ctx, "active", true, nil, appID); err == nil {
+				_ = body.Close()
+			}
+		}},
+		{"DeleteDocument", func() { _ = tenant.DeleteSavedQueryByID(ctx, "q_1", false, appID, false) }},
+
+		{"ReportQuery", func() {
+			_, _ = tenant.ReportQuery(ctx, clientpkg.ReportQueryParams{Collection: "users", AppID: appID, Limit: 10, Offset: 1})
+		}},
+		{"ListAuditLogs", func() {
+			_, _ = tenant.ListAuditLogs(ctx, clientpkg.ListAuditLogsParams{AppID: appID, Limit: 10, CollectionID: "col_1", Operation: "create", Sort: []string{"created_at"}})
+		}},
+		{"ListAuditLogs", func() { _, _ = tenant.ListAuditLogsPage(ctx, clientpkg.ListAuditLogsParams{Cursor: "c1"}) }},
+		{"AuthStatus", func() { _, _ = tenant.AuthStatus(ctx, "") }},
+		{"Capabilities", func() { _, _ = tenant.Capabilities(ctx) }},
+
+		{"ListSnapshots", func() { _, _ = tenant.ListSnapshots(ctx, "col_1", 10, 1) }},
+		{"CreateSnapshot", func() { _, _ = tenant.CreateSnapshot(ctx, clientpkg.CreateSnapshotRequest{}) }},
+		{"GetSnapshot", func() { _, _ = tenant.GetSnapshot(ctx, "snap_1") }},
+		{"RestoreSnapshot", func() { _, _ = tenant.RestoreSnapshot(ctx, "snap_1", clientpkg.RestoreSnapshotRequest{}) }},
+		{"DeleteSnapshot", func() { _ = tenant.DeleteSnapshot(ctx, "snap_1") }},
+		{"ListSnapshotKeys", func() { _, _ = tenant.ListSnapshotKeys(ctx) }},
+		{"CreateSnapshotKey", func() { _, _ = tenant.CreateSnapshotKey(ctx, clientpkg.CreateSnapshotKeyRequest{}) }},
+		{"RotateSnapshotKey", func() { _, _ = tenant.RotateSnapshotKey(ctx, "key_1") }},
+
+		{"ListTenants", func() { _, _ = admin.ListTenants(ctx) }},
+		{"CreateTenant", func() { _, _, _ = admin.CreateTenant(ctx, clientpkg.CreateTenantRequest{Name: "Acme"}) }},
+		{"UpdateTenant", func() { _, _ = admin.UpdateTenant(ctx, "tn_1", clientpkg.UpdateTenantRequest{}) }},
+		{"DeleteTenant", func() { _ = admin.DeleteTenant(ctx, "tn_1") }},
+		{"GenerateKey", func() { _, _ = admin.GenerateKey(ctx, "tn_1", clientpkg.CreateAPIKeyRequest{}) }},
+		{"ListKeys", func() { _, _ = admin.ListKeys(ctx, "tn_1", &appID) }},
+		{"RevokeKey", func() { _ = admin.RevokeKey(ctx, "tdb_abc") }},
+		{"ReassignKey", func() { _, _ = admin.ReassignKey(ctx, "tdb_abc", &appID) }},
+	}
+
+	registry := map[string]clientpkg.Endpoint{}
+	for _, ep := range clientpkg.Endpoints() {
+		registry[ep.Operation] = ep
+	}
+	covered := map[string]bool{}
+	for _, c := range calls {
+		ep, ok := registry[c.operation]
+		if !ok {
+			t.Errorf("%s is not in Endpoints()", c.operation)
+			continue
+		}
+		covered[c.operation] = true
+		requests = requests[:0]
+		c.call()
+		if len(requests) == 0 {
+			t.Errorf("%s: the client sent no request", c.operation)
+			continue
+		}
+		last := requests[len(requests)-1]
+		if last.method != ep.Method || !endpointPathPattern(ep.Path).MatchString(last.path) {
+			t.Errorf("%s: client sent %s %s, registry has %s %s", c.operation, last.method, last.path, ep.Method, ep.Path)
+		}
+		for _, key := range last.query {
+			if !containsString(ep.Query, key) {
+				t.Errorf("%s: client sent query parameter %q, registry lists %v", c.operation, key, ep.Query)
+			}
+		}
+	}
+	for _, ep := range clientpkg.Endpoints() {
+		if !covered[ep.Operation] {
+			t.Errorf("%s (%s %s) is registered but no client call exercises it", ep.Operation, ep.Method, ep.Path)
+		}
+	}
+}
+
+// endpointPathPattern turns a path template such as /api/collections/{name}
+// into an anchored pattern matching one path segment per placeholder.
+func endpointPathPattern(template string) *regexp.Regexp {
+	pattern := regexp.MustCompile(`\\\{[^/]+\\\}`).ReplaceAllString(regexp.QuoteMeta(template), `[^/]+`)
+	return regexp.MustCompile("^" + pattern + "$")
+}
+
+func containsString(values []string, want string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, want) {
+			return true
+		}
+	}
+	return false
+}
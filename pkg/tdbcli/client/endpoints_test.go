@@ -0,0 +1,20 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEndpointsAreUnique(t *testing.T) {
+	seen := map[string]string{}
+	for _, ep := range Endpoints() {
+		if !strings.HasPrefix(ep.Path, "/api/") && !strings.HasPrefix(ep.Path, "/admin/") {
+			t.Errorf("%s: unexpected path %s", ep.Operation, ep.Path)
+		}
+		key := ep.Method + " " + ep.Path
+		if other, ok := seen[key]; ok {
+			t.Errorf("%s and %s both register %s", other, ep.Operation, key)
+		}
+		seen[key] = ep.Operation
+	}
+}
@@ -0,0 +1,39 @@
+package client
+
+import "net/http"
+
+// WithHeaders sends extra headers with every request, e.g. a token required by
+// a gateway in front of the API. Headers the client sets itself, such as the
+// API key and content type, are never replaced.
+func WithHeaders(headers http.Header) Option {
+	return func(b *baseClient) {
+		if len(headers) == 0 {
+			return
+		}
+		if b.headers == nil {
+			b.headers = http.Header{}
+		}
+		for name, values := range headers {
+			for _, value := range values {
+				b.headers.Add(name, value)
+			}
+		}
+	}
+}
+
+type headerDoer struct {
+	next    httpDoer
+	headers http.Header
+}
+
+func (d headerDoer) Do(req *http.Request) (*http.Response, error) {
+	for name, values := range d.headers {
+		if req.Header.Get(name) != "" {
+			continue
+		}
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	return d.next.Do(req)
+}
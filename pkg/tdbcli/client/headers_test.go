@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithHeadersAddsToEveryRequest(t *testing.T) {
+	var got []http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Clone())
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	headers := http.Header{}
+	headers.Set("X-Org-Token", "abc")
+	headers.Set("X-API-Key", "spoofed")
+	c, err := NewTenantClient(ts.URL, "secret", WithHeaders(headers))
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := c.GetCollection(ctx, "users", ""); err != nil {
+		t.Fatalf("GetCollection: %v", err)
+	}
+	if _, err := c.CreateCollection(ctx, CreateCollectionRequest{Name: "users"}); err != nil {
+		t.Fatalf("CreateCollection: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(got))
+	}
+	for _, h := range got {
+		if h.Get("X-Org-Token") != "abc" {
+			t.Fatalf("expected the extra header on every request, got %v", h)
+		}
+		if h.Get("X-API-Key") != "secret" {
+			t.Fatalf("extra headers must not replace the API key, got %q", h.Get("X-API-Key"))
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ListCollectionHooks returns the hooks configured on a collection.
+func (c *TenantClient) ListCollectionHooks(ctx context.Context, collection, appID string) ([]CollectionHook, error) {
+	var resp CollectionHookListResponse
+	if err := c.collectionHooks(ctx, http.MethodGet, collectionHooksPath(collection, "", "", appID), appID, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// PutCollectionHook creates the hook, or replaces the hook with the same name.
+func (c *TenantClient) PutCollectionHook(ctx context.Context, collection, appID string, hook CollectionHook) (*CollectionHook, error) {
+	name := strings.TrimSpace(hook.Name)
+	if name == "" {
+		return nil, fmt.Errorf("hook name is required")
+	}
+	var resp CollectionHook
+	if err := c.collectionHooks(ctx, http.MethodPut, collectionHooksPath(collection, name, "", appID), appID, hook, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteCollectionHook removes a hook by name.
+func (c *TenantClient) DeleteCollectionHook(ctx context.Context, collection, name, appID string) error {
+	return c.collectionHooks(ctx, http.MethodDelete, collectionHooksPath(collection, name, "", appID), appID, nil, nil)
+}
+
+// TestCollectionHook runs a hook once with a sample document and reports the
+// result. Nothing is written to the collection.
+func (c *TenantClient) TestCollectionHook(ctx context.Context, collection, name, appID string, reqBody CollectionHookTestRequest) (*CollectionHookTestResult, error) {
+	var resp CollectionHookTestResult
+	if err := c.collectionHooks(ctx, http.MethodPost, collectionHooksPath(collection, name, "test", appID), appID, reqBody, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func collectionHooksPath(collection, name, action, appID string) string {
+	path := fmt.Sprintf("/api/collections/%s/hooks", url.PathEscape(collection))
+	if name != "" {
+		path += "/" + url.PathEscape(name)
+	}
+	if action != "" {
+		path += "/" + action
+	}
+	if trimmed := strings.TrimSpace(appID); trimmed != "" {
+		path += "?" + url.Values{"app_id": {trimmed}}.Encode()
+	}
+	return path
+}
+
+func (c *TenantClient) collectionHooks(ctx context.Context, method, path, appID string, body, out interface{}) error {
+	req, err := c.newJSONRequest(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	c.authorize(req)
+	c.applyAppScope(req, appID)
+	return c.do(req, out)
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// IdempotencyKeyHeader is sent with create requests so the server can
+// deduplicate retried operations.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+const (
+	defaultMaxRetries   = 2
+	defaultRetryBackoff = 500 * time.Millisecond
+)
+
+type idempotencyKeyCtx struct{}
+
+// WithIdempotencyKey returns a context that makes create requests carry the
+// given Idempotency-Key instead of a generated one.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtx{}, key)
+}
+
+// IdempotencyKeyFrom returns the idempotency key stored in ctx, if any.
+func IdempotencyKeyFrom(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	key, _ := ctx.Value(idempotencyKeyCtx{}).(string)
+	return key
+}
+
+// NewIdempotencyKey generates a random key for a single logical operation.
+func NewIdempotencyKey() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("idem-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
+
+// WithRetries configures how many times idempotency-keyed requests are retried
+// after transport errors or retryable status codes. Zero disables retries.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(b *baseClient) {
+		if maxRetries < 0 {
+			maxRetries = 0
+		}
+		b.maxRetries = maxRetries
+		if backoff > 0 {
+			b.retryBackoff = backoff
+		}
+	}
+}
+
+// applyIdempotencyKey tags req with the context key, generating one when the
+// caller did not supply it. The same key is reused for every retry of req.
+func applyIdempotencyKey(req *http.Request) {
+	key := IdempotencyKeyFrom(req.Context())
+	if key == "" {
+		key = NewIdempotencyKey()
+	}
+	req.Header.Set(IdempotencyKeyHeader, key)
+}
+
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// sendWithRetry performs req, retrying idempotency-keyed requests on transport
+// errors and retryable status codes.
+func (b *baseClient) sendWithRetry(req *http.Request) (*http.Response, error) {
+	retries := 0
+	if req.Header.Get(IdempotencyKeyHeader) != "" {
+		retries = b.maxRetries
+	}
+	for attempt := 0; ; attempt++ {
+		resp, err := b.httpClient.Do(req)
+		if attempt >= retries || req.Context().Err() != nil || isReadOnlyError(err) {
+			return resp, err
+		}
+		if err == nil && !isRetryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			req.Body = body
+		}
+		wait := b.retryBackoff * time.Duration(1<<attempt)
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
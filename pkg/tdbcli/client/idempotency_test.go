@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCreateDocumentRetriesWithSameIdempotencyKey(t *testing.T) {
+	var keys []string
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"doc_1"}`))
+	}))
+	defer ts.Close()
+
+	client, err := NewTenantClient(ts.URL, "secret", WithRetries(2, time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	doc, err := client.CreateDocument(context.Background(), "users", []byte(`{"name":"a"}`), "")
+	if err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+	if doc.ID != "doc_1" {
+		t.Fatalf("expected doc_1, got %s", doc.ID)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(keys))
+	}
+	if keys[0] == "" || keys[0] != keys[1] {
+		t.Fatalf("expected the same non-empty key on retry, got %q and %q", keys[0], keys[1])
+	}
+	if bodies[0] != bodies[1] {
+		t.Fatalf("expected body to be replayed, got %q and %q", bodies[0], bodies[1])
+	}
+}
+
+func TestCreateDocumentUsesContextIdempotencyKey(t *testing.T) {
+	var got string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(IdempotencyKeyHeader)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"doc_1"}`))
+	}))
+	defer ts.Close()
+
+	client, err := NewTenantClient(ts.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	ctx := WithIdempotencyKey(context.Background(), "order-42")
+	if _, err := client.CreateDocument(ctx, "orders", []byte(`{}`), ""); err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+	if got != "order-42" {
+		t.Fatalf("expected caller-supplied key, got %q", got)
+	}
+}
@@ -0,0 +1,265 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const defaultIteratorPageSize = 100
+
+// DocumentIterator walks every document matching a listing, following offset or
+// cursor pagination transparently. Full scans (no filters, sort, deleted
+// documents, or starting position) read the streaming export endpoint instead
+// when the server supports it.
+//
+//	it := c.DocumentsIterator(ctx, "users", ListDocumentsParams{})
+//	for it.Next() {
+//		doc := it.Document()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type DocumentIterator struct {
+	ctx        context.Context
+	client     *TenantClient
+	collection string
+	params     ListDocumentsParams
+	streaming  bool
+	onPage     func(size int)
+
+	page    []Document
+	index   int
+	done    bool
+	current Document
+	err     error
+
+	stream     io.ReadCloser
+	scanner    *bufio.Scanner
+	streamed   bool
+	nextCursor string
+}
+
+// IteratorOption customises a DocumentIterator.
+type IteratorOption func(*DocumentIterator)
+
+// WithoutStreaming forces paginated listing even for full scans.
+func WithoutStreaming() IteratorOption {
+	return func(it *DocumentIterator) {
+		it.streaming = false
+	}
+}
+
+// WithPageHook registers fn to be called with the item count of every page
+// fetched while paging. Streamed full scans do not call it.
+func WithPageHook(fn func(size int)) IteratorOption {
+	return func(it *DocumentIterator) {
+		it.onPage = fn
+	}
+}
+
+// DocumentsIterator returns an iterator over the documents of collection.
+// params.Limit sets the page size (default 100). Pages are fetched lazily.
+func (c *TenantClient) DocumentsIterator(ctx context.Context, collection string, params ListDocumentsParams, opts ...IteratorOption) *DocumentIterator {
+	if params.Limit <= 0 {
+		params.Limit = defaultIteratorPageSize
+	}
+	it := &DocumentIterator{ctx: ctx, client: c, collection: collection, params: params, streaming: isFullScan(params)}
+	for _, opt := range opts {
+		opt(it)
+	}
+	return it
+}
+
+func isFullScan(params ListDocumentsParams) bool {
+	return len(params.Filters) == 0 && len(params.Sort) == 0 && !params.IncludeDeleted && params.Offset == 0 && strings.TrimSpace(params.Cursor) == "" &&
+		params.CreatedSince == nil && params.CreatedBefore == nil && params.UpdatedSince == nil
+}
+
+// Next advances to the next document. It returns false once the listing is
+// exhausted or an error occurred; check Err afterwards.
+func (it *DocumentIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.fail(err)
+		return false
+	}
+	if it.streaming {
+		if it.nextStreamed() {
+			return true
+		}
+		// Fall back to paging only when the server has no export endpoint.
+		if it.err != nil || it.streamed {
+			return false
+		}
+		it.streaming = false
+	}
+	for it.index >= len(it.page) {
+		if it.done || !it.fetchPage() {
+			return false
+		}
+	}
+	it.current = it.page[it.index]
+	it.index++
+	return true
+}
+
+// Document returns the document at the current position.
+func (it *DocumentIterator) Document() Document {
+	return it.current
+}
+
+// Err returns the error that stopped iteration, if any.
+func (it *DocumentIterator) Err() error {
+	return it.err
+}
+
+// Close releases any open stream. It is safe to call more than once and is
+// called automatically when iteration finishes.
+func (it *DocumentIterator) Close() error {
+	if it.stream == nil {
+		return nil
+	}
+	err := it.stream.Close()
+	it.stream = nil
+	return err
+}
+
+func (it *DocumentIterator) fail(err error) {
+	it.err = err
+	_ = it.Close()
+}
+
+func (it *DocumentIterator) fetchPage() bool {
+	resp, err := it.client.ListDocuments(it.ctx, it.collection, it.params)
+	if err != nil {
+		it.fail(err)
+		return false
+	}
+	it.page = resp.Items
+	it.index = 0
+	if it.onPage != nil {
+		it.onPage(len(resp.Items))
+	}
+	if next := strings.TrimSpace(resp.Pagination.NextCursor); next != "" {
+		it.params.Cursor = next
+		it.params.Offset = 0
+	} else {
+		it.params.Offset += len(resp.Items)
+		if len(resp.Items) < it.params.Limit {
+			it.done = true
+		}
+	}
+	if len(resp.Items) == 0 {
+		it.done = true
+	}
+	return len(resp.Items) > 0
+}
+
+// nextStreamed reads the export stream, requesting the next page with the
+// X-Next-Cursor of the previous one until a page comes back without one.
+func (it *DocumentIterator) nextStreamed() bool {
+	for {
+		if it.scanner == nil && !it.openStream() {
+			return false
+		}
+		for it.scanner.Scan() {
+			line := strings.TrimSpace(it.scanner.Text())
+			if line == "" {
+				continue
+			}
+			doc, err := decodeStreamedDocument([]byte(line))
+			if err != nil {
+				it.fail(err)
+				return false
+			}
+			it.current = doc
+			return true
+		}
+		if err := it.scanner.Err(); err != nil {
+			it.fail(err)
+			return false
+		}
+		_ = it.Close()
+		it.scanner = nil
+		if it.nextCursor == "" {
+			it.done = true
+			return false
+		}
+	}
+}
+
+func (it *DocumentIterator) openStream() bool {
+	if it.done {
+		return false
+	}
+	cursor := it.nextCursor
+	body, headers, err := it.client.StreamExport(it.ctx, it.collection, it.params.SelectFields, it.params.SelectOnly, cursor, it.params.Limit, it.params.AppID)
+	if err != nil {
+		if !it.streamed && isExportUnsupported(err) {
+			return false
+		}
+		it.fail(err)
+		return false
+	}
+	it.streamed = true
+	it.stream = body
+	it.nextCursor = strings.TrimSpace(headers.Get("X-Next-Cursor"))
+	if it.nextCursor != "" && it.nextCursor == cursor {
+		it.fail(fmt.Errorf("export cursor %q did not advance", cursor))
+		return false
+	}
+	it.scanner = bufio.NewScanner(body)
+	it.scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	return true
+}
+
+// isExportUnsupported reports whether err means the server has no streaming
+// export endpoint, as opposed to a failed request.
+func isExportUnsupported(err error) bool {
+	msg := err.Error()
+	for _, status := range []string{"404 ", "405 ", "501 "} {
+		if strings.Contains(msg, "export request failed: "+status) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeStreamedDocument converts an export stream line into a Document. Lines
+// are either document envelopes with a data field (object or JSON string) or the
+// bare document payload.
+func decodeStreamedDocument(line []byte) (Document, error) {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(line, &envelope); err != nil {
+		return Document{}, err
+	}
+	data, ok := envelope["data"]
+	if !ok {
+		var doc Document
+		if raw, ok := envelope["id"]; ok {
+			_ = json.Unmarshal(raw, &doc.ID)
+		}
+		doc.Data = string(line)
+		return doc, nil
+	}
+	delete(envelope, "data")
+	meta, err := json.Marshal(envelope)
+	if err != nil {
+		return Document{}, err
+	}
+	var doc Document
+	if err := json.Unmarshal(meta, &doc); err != nil {
+		return Document{}, err
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		doc.Data = text
+	} else {
+		doc.Data = string(data)
+	}
+	return doc, nil
+}
@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func collectIDs(t *testing.T, it *DocumentIterator) []string {
+	t.Helper()
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Document().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("iterator error: %v", err)
+	}
+	return ids
+}
+
+func TestDocumentsIteratorFollowsCursor(t *testing.T) {
+	var cursors []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/collections/users/documents" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+		resp := DocumentListResponse{}
+		switch cursor {
+		case "":
+			resp.Items = []Document{{ID: "a"}, {ID: "b"}}
+			resp.Pagination.NextCursor = "c2"
+		case "c2":
+			resp.Items = []Document{{ID: "c"}}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer ts.Close()
+
+	client, err := NewTenantClient(ts.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	params := ListDocumentsParams{Limit: 2, Filters: map[string]string{"status": "active"}}
+	var pages []int
+	ids := collectIDs(t, client.DocumentsIterator(context.Background(), "users", params, WithPageHook(func(size int) { pages = append(pages, size) })))
+	if fmt.Sprint(ids) != "[a b c]" {
+		t.Fatalf("unexpected ids %v", ids)
+	}
+	if fmt.Sprint(cursors) != "[ c2]" {
+		t.Fatalf("unexpected cursors %v", cursors)
+	}
+	if fmt.Sprint(pages) != "[2 1]" {
+		t.Fatalf("unexpected page sizes %v", pages)
+	}
+}
+
+func TestDocumentsIteratorStreamsFullScans(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/collections/users/export" {
+			t.Errorf("expected streaming export, got %s", r.URL.Path)
+		}
+		_, _ = io.WriteString(w, "{\"id\":\"a\",\"data\":{\"name\":\"Ada\"}}\n\n{\"id\":\"b\",\"data\":\"{\\\"name\\\":\\\"Bob\\\"}\"}\n{\"name\":\"bare\"}\n")
+	}))
+	defer ts.Close()
+
+	client, err := NewTenantClient(ts.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	it := client.DocumentsIterator(context.Background(), "users", ListDocumentsParams{})
+	var data []string
+	for it.Next() {
+		data = append(data, it.Document().ID+"="+it.Document().Data)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("iterator error: %v", err)
+	}
+	want := `[a={"name":"Ada"} b={"name":"Bob"} ={"name":"bare"}]`
+	if fmt.Sprint(data) != want {
+		t.Fatalf("unexpected documents %v", data)
+	}
+}
+
+func TestDocumentsIteratorFallsBackToPaging(t *testing.T) {
+	var listCalls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/collections/users/export":
+			http.NotFound(w, r)
+		case "/api/collections/users/documents":
+			listCalls++
+			resp := DocumentListResponse{}
+			if r.URL.Query().Get("offset") == "" {
+				resp.Items = []Document{{ID: "a"}, {ID: "b"}}
+			} else {
+				resp.Items = []Document{{ID: "c"}}
+			}
+			_ = json.NewEncoder(w).Encode(resp)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewTenantClient(ts.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	ids := collectIDs(t, client.DocumentsIterator(context.Background(), "users", ListDocumentsParams{Limit: 2}))
+	if fmt.Sprint(ids) != "[a b c]" || listCalls != 2 {
+		t.Fatalf("unexpected ids %v after %d list calls", ids, listCalls)
+	}
+}
+
+func TestDocumentsIteratorWithoutStreamingAndError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/collections/users/documents" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		http.Error(w, `{"error":"boom"}`, http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	client, err := NewTenantClient(ts.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	it := client.DocumentsIterator(context.Background(), "users", ListDocumentsParams{}, WithoutStreaming())
+	if it.Next() {
+		t.Fatalf("expected no documents")
+	}
+	if it.Err() == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestDocumentsIteratorStreamsEveryExportPage(t *testing.T) {
+	var cursors []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+		switch cursor {
+		case "":
+			w.Header().Set("X-Next-Cursor", "p2")
+			_, _ = io.WriteString(w, "{\"id\":\"a\",\"data\":{}}\n{\"id\":\"b\",\"data\":{}}\n")
+		case "p2":
+			w.Header().Set("X-Next-Cursor", "p3")
+			_, _ = io.WriteString(w, "{\"id\":\"c\",\"data\":{}}\n")
+		default:
+			http.Error(w, `{"error":"boom"}`, http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewTenantClient(ts.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	it := client.DocumentsIterator(context.Background(), "users", ListDocumentsParams{Limit: 2})
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Document().ID)
+	}
+	if fmt.Sprint(ids) != "[a b c]" || fmt.Sprint(cursors) != "[ p2 p3]" {
+		t.Fatalf("unexpected ids %v after cursors %v", ids, cursors)
+	}
+	if it.Err() == nil {
+		t.Fatalf("expected the failed third page to be reported")
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"boom"}`, http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	client, err = NewTenantClient(failing.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	it = client.DocumentsIterator(context.Background(), "users", ListDocumentsParams{})
+	if it.Next() || it.Err() == nil {
+		t.Fatalf("expected a failed export to be reported instead of an empty scan")
+	}
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// defaultMaxResponseSize caps how many bytes of a JSON response body are
+// decoded, as a safety limit against runaway responses.
+const defaultMaxResponseSize int64 = 4 << 20
+
+// ResponseTooLargeError reports a response body that exceeded the configured
+// size limit.
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response body exceeds the %d byte size limit", e.Limit)
+}
+
+// WithMaxResponseSize overrides the maximum decoded response size. Zero or a
+// negative value removes the limit.
+func WithMaxResponseSize(limit int64) Option {
+	return func(b *baseClient) {
+		b.maxResponseSize = limit
+	}
+}
+
+// PayloadTooLargeError is returned for 413 Payload Too Large responses.
+// RequestBytes is the size of the rejected request body, or -1 if unknown.
+type PayloadTooLargeError struct {
+	Body         string
+	RequestBytes int64
+}
+
+func (e *PayloadTooLargeError) Error() string {
+	return "request failed: " + e.Body
+}
+
+type maxResponseSizeCtx struct{}
+
+// WithMaxDocumentSize returns a context that limits the response size of
+// requests made with it, taking precedence over the client-wide limit.
+func WithMaxDocumentSize(ctx context.Context, limit int64) context.Context {
+	return context.WithValue(ctx, maxResponseSizeCtx{}, limit)
+}
+
+// MaxDocumentSizeFrom returns the size limit stored in ctx, if any.
+func MaxDocumentSizeFrom(ctx context.Context) (int64, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	limit, ok := ctx.Value(maxResponseSizeCtx{}).(int64)
+	return limit, ok
+}
+
+func (b *baseClient) responseLimit(ctx context.Context) int64 {
+	if limit, ok := MaxDocumentSizeFrom(ctx); ok {
+		return limit
+	}
+	return b.maxResponseSize
+}
+
+// sizeLimitedReader fails with ResponseTooLargeError once more than limit
+// bytes have been read, instead of silently truncating like io.LimitReader.
+type sizeLimitedReader struct {
+	r         io.Reader
+	limit     int64
+	remaining int64
+}
+
+func newSizeLimitedReader(r io.Reader, limit int64) io.Reader {
+	if limit <= 0 {
+		return r
+	}
+	return &sizeLimitedReader{r: r, limit: limit, remaining: limit}
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			return 0, &ResponseTooLargeError{Limit: l.limit}
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
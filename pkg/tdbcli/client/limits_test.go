@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResponseSizeLimit(t *testing.T) {
+	data := strings.Repeat("x", 2048)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id":"doc_1","data":%q}`, data)
+	}))
+	defer ts.Close()
+
+	client, err := NewTenantClient(ts.URL, "secret", WithMaxResponseSize(1024))
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	_, err = client.GetDocument(context.Background(), "users", "doc_1", "")
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != 1024 {
+		t.Fatalf("expected ResponseTooLargeError, got %v", err)
+	}
+
+	ctx := WithMaxDocumentSize(context.Background(), 0)
+	doc, err := client.GetDocument(ctx, "users", "doc_1", "")
+	if err != nil {
+		t.Fatalf("expected unlimited context to succeed: %v", err)
+	}
+	if len(doc.Data) != len(data) {
+		t.Fatalf("unexpected data length %d", len(doc.Data))
+	}
+
+	ctx = WithMaxDocumentSize(context.Background(), 4096)
+	if _, err := client.GetDocument(ctx, "users", "doc_1", ""); err != nil {
+		t.Fatalf("expected raised limit to succeed: %v", err)
+	}
+}
+
+func TestDefaultResponseSizeLimit(t *testing.T) {
+	data := strings.Repeat("x", int(defaultMaxResponseSize))
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id":"doc_1","data":%q}`, data)
+	}))
+	defer ts.Close()
+
+	client, err := NewTenantClient(ts.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	_, err = client.GetDocument(context.Background(), "users", "doc_1", "")
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != 4<<20 {
+		t.Fatalf("expected the default 4MB limit, got %v", err)
+	}
+}
+
+func TestPayloadTooLargeError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "body exceeds 1KB", http.StatusRequestEntityTooLarge)
+	}))
+	defer ts.Close()
+
+	c, err := NewTenantClient(ts.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	payload := []byte(`[{"n":"` + strings.Repeat("x", 2048) + `"}]`)
+	_, err = c.BulkCreateDocuments(context.Background(), "users", payload, "")
+	var tooLarge *PayloadTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("expected PayloadTooLargeError, got %v", err)
+	}
+	if tooLarge.RequestBytes < int64(len(payload)) || !strings.Contains(err.Error(), "body exceeds 1KB") {
+		t.Fatalf("unexpected error %+v", tooLarge)
+	}
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RequestMetrics describes one completed HTTP exchange.
+type RequestMetrics struct {
+	Method     string
+	Path       string
+	StatusCode int
+	// Duration covers sending the request, waiting for the response, and
+	// reading its body. Time spent decoding between body reads is excluded.
+	Duration time.Duration
+	// Bytes is the number of response body bytes read.
+	Bytes int64
+	Err   error
+}
+
+// WithRequestObserver calls observe after every HTTP exchange made by the
+// client, once the response body is closed. It also applies when
+// WithHTTPClient supplies the client.
+func WithRequestObserver(observe func(RequestMetrics)) Option {
+	return func(b *baseClient) {
+		b.observe = observe
+	}
+}
+
+type observingDoer struct {
+	next    httpDoer
+	observe func(RequestMetrics)
+}
+
+func (d observingDoer) Do(req *http.Request) (*http.Response, error) {
+	metrics := RequestMetrics{Method: req.Method, Path: req.URL.Path}
+	started := time.Now()
+	resp, err := d.next.Do(req)
+	metrics.Duration = time.Since(started)
+	if err != nil {
+		metrics.Err = err
+		d.observe(metrics)
+		return resp, err
+	}
+	metrics.StatusCode = resp.StatusCode
+	resp.Body = &observedBody{ReadCloser: resp.Body, metrics: metrics, observe: d.observe}
+	return resp, nil
+}
+
+// observedBody counts bytes and time spent reading, and reports the exchange
+// when closed.
+type observedBody struct {
+	io.ReadCloser
+	metrics RequestMetrics
+	observe func(RequestMetrics)
+	once    sync.Once
+}
+
+func (b *observedBody) Read(p []byte) (int, error) {
+	started := time.Now()
+	n, err := b.ReadCloser.Read(p)
+	b.metrics.Duration += time.Since(started)
+	b.metrics.Bytes += int64(n)
+	return n, err
+}
+
+func (b *observedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.observe(b.metrics) })
+	return err
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestObserver(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/collections/missing" {
+			http.Error(w, "collection not found", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"col_1","name":"users"}`))
+	}))
+	defer ts.Close()
+
+	var observed []RequestMetrics
+	c, err := NewTenantClient(ts.URL, "secret", WithRequestObserver(func(m RequestMetrics) {
+		observed = append(observed, m)
+	}))
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	if _, err := c.GetCollection(context.Background(), "users", ""); err != nil {
+		t.Fatalf("GetCollection: %v", err)
+	}
+	if _, err := c.GetCollection(context.Background(), "missing", ""); err == nil {
+		t.Fatalf("expected missing collection to fail")
+	}
+	if len(observed) != 2 {
+		t.Fatalf("expected 2 observed requests, got %d", len(observed))
+	}
+	first := observed[0]
+	if first.Method != http.MethodGet || first.Path != "/api/collections/users" || first.StatusCode != http.StatusOK || first.Bytes != 29 || first.Duration <= 0 {
+		t.Fatalf("unexpected metrics %+v", first)
+	}
+	if observed[1].StatusCode != http.StatusNotFound {
+		t.Fatalf("unexpected metrics for failed request %+v", observed[1])
+	}
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ReadOnlyError is returned, without contacting the server, for a request that
+// could modify data when the client was built WithReadOnly.
+type ReadOnlyError struct {
+	Method string
+	Path   string
+}
+
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("read-only mode: refusing %s %s", e.Method, e.Path)
+}
+
+// WithReadOnly rejects every POST, PUT, PATCH, and DELETE request with a
+// *ReadOnlyError before it is sent. POST requests that only read data (ad-hoc
+// and saved query execution) are still allowed.
+func WithReadOnly() Option {
+	return func(b *baseClient) {
+		b.readOnly = true
+	}
+}
+
+type readOnlyDoer struct {
+	next httpDoer
+}
+
+func (d readOnlyDoer) Do(req *http.Request) (*http.Response, error) {
+	if !isReadRequest(req.Method, req.URL.Path) {
+		return nil, &ReadOnlyError{Method: req.Method, Path: req.URL.Path}
+	}
+	return d.next.Do(req)
+}
+
+// isReadRequest reports whether a request leaves server data unchanged. The
+// path may carry the endpoint's base path, so POST reads are matched by suffix.
+func isReadRequest(method, path string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		if strings.HasSuffix(path, "/api/query") {
+			return true
+		}
+		if strings.Contains(path, "/api/queries/") && strings.HasSuffix(path, "/execute") {
+			return true
+		}
+	}
+	return false
+}
+
+func isReadOnlyError(err error) bool {
+	var readOnly *ReadOnlyError
+	return errors.As(err, &readOnly)
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReadOnlyRejectsWrites(t *testing.T) {
+	var served []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served = append(served, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewTenantClient(ts.URL+"/tdb", "secret", WithReadOnly())
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := c.GetCollection(ctx, "users", ""); err != nil {
+		t.Fatalf("GetCollection: %v", err)
+	}
+	if _, err := c.ExecuteSavedQueryByName(ctx, "active", nil, ""); err != nil {
+		t.Fatalf("ExecuteSavedQueryByName: %v", err)
+	}
+	if _, err := c.ReportQuery(ctx, ReportQueryParams{Collection: "users"}); err != nil {
+		t.Fatalf("ReportQuery: %v", err)
+	}
+
+	_, err = c.CreateCollection(ctx, CreateCollectionRequest{Name: "users"})
+	var readOnly *ReadOnlyError
+	if !errors.As(err, &readOnly) || readOnly.Method != http.MethodPost || readOnly.Path != "/tdb/api/collections" {
+		t.Fatalf("expected a read-only error for CreateCollection, got %v", err)
+	}
+	if err := c.DeleteDocument(ctx, "users", "doc_1", ""); !errors.As(err, &readOnly) {
+		t.Fatalf("expected a read-only error for DeleteDocument, got %v", err)
+	}
+
+	want := []string{"GET /tdb/api/collections/users", "POST /tdb/api/queries/name/active/execute", "POST /tdb/api/query"}
+	if len(served) != len(want) {
+		t.Fatalf("expected only reads to reach the server, got %v", served)
+	}
+	for i := range want {
+		if served[i] != want[i] {
+			t.Fatalf("expected only reads to reach the server, got %v", served)
+		}
+	}
+}
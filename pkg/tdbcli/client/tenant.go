@@ -60,11 +60,22 @@ func (c *TenantClient) ListApplications(ctx context.Context) ([]Application, err
 
 // ListCollections lists collections for the tenant, optionally scoped to an application.
 func (c *TenantClient) ListCollections(ctx context.Context, appID string) ([]Collection, error) {
+	return c.ListCollectionsWithParams(ctx, ListCollectionsParams{AppID: appID})
+}
+
+// ListCollectionsWithParams lists collections with optional soft-delete filters.
+func (c *TenantClient) ListCollectionsWithParams(ctx context.Context, params ListCollectionsParams) ([]Collection, error) {
 	path := "/api/collections"
 	values := url.Values{}
-	if trimmed := strings.TrimSpace(appID); trimmed != "" {
+	if trimmed := strings.TrimSpace(params.AppID); trimmed != "" {
 		values.Set("app_id", trimmed)
 	}
+	if params.IncludeDeleted || params.OnlyDeleted {
+		values.Set("include_deleted", "true")
+	}
+	if params.OnlyDeleted {
+		values.Set("only_deleted", "true")
+	}
 	if encoded := values.Encode(); encoded != "" {
 		path += "?" + encoded
 	}
@@ -73,11 +84,20 @@ func (c *TenantClient) ListCollections(ctx context.Context, appID string) ([]Col
 		return nil, err
 	}
 	c.authorize(req)
-	c.applyAppScope(req, appID)
+	c.applyAppScope(req, params.AppID)
 	var cols []Collection
 	if err := c.do(req, &cols); err != nil {
 		return nil, err
 	}
+	if params.OnlyDeleted {
+		filtered := cols[:0]
+		for _, col := range cols {
+			if col.DeletedAt != nil {
+				filtered = append(filtered, col)
+			}
+		}
+		cols = filtered
+	}
 	return cols, nil
 }
 
@@ -186,6 +206,67 @@ func (c *TenantClient) DeleteCollection(ctx context.Context, name, appID string)
 	return c.do(req, nil)
 }
 
+// RestoreCollection undeletes a soft-deleted collection.
+func (c *TenantClient) RestoreCollection(ctx context.Context, name, appID string) (*Collection, error) {
+	values := url.Values{}
+	if trimmed := strings.TrimSpace(appID); trimmed != "" {
+		values.Set("app_id", trimmed)
+	}
+	path := fmt.Sprintf("/api/collections/%s/restore", url.PathEscape(name))
+	if encoded := values.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+	req, err := c.newJSONRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.authorize(req)
+	c.applyAppScope(req, appID)
+	var col Collection
+	if err := c.do(req, &col); err != nil {
+		return nil, err
+	}
+	return &col, nil
+}
+
+// GetCollectionLimits returns the limits of a collection with its current usage.
+func (c *TenantClient) GetCollectionLimits(ctx context.Context, name, appID string) (*CollectionLimitsResponse, error) {
+	return c.collectionLimits(ctx, http.MethodGet, name, appID, nil)
+}
+
+// UpdateCollectionLimits sets or clears the limits of a collection.
+func (c *TenantClient) UpdateCollectionLimits(ctx context.Context, name, appID string, reqBody UpdateCollectionLimitsRequest) (*CollectionLimitsResponse, error) {
+	return c.collectionLimits(ctx, http.MethodPut, name, appID, reqBody)
+}
+
+func (c *TenantClient) collectionLimits(ctx context.Context, method, name, appID string, body interface{}) (*CollectionLimitsResponse, error) {
+	values := url.Values{}
+	if trimmed := strings.TrimSpace(appID); trimmed != "" {
+		values.Set("app_id", trimmed)
+	}
+	path := fmt.Sprintf("/api/collections/%s/limits", url.PathEscape(name))
+	if encoded := values.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+	req, err := c.newJSONRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	c.authorize(req)
+	c.applyAppScope(req, appID)
+	var resp CollectionLimitsResponse
+	if err := c.do(req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func setTimeParam(values url.Values, name string, ts *time.Time) {
+	if ts != nil && !ts.IsZero() {
+		values.Set(name, ts.UTC().Format(time.RFC3339))
+	}
+}
+
 // ListDocuments retrieves documents for a collection with optional filters.
 func (c *TenantClient) ListDocuments(ctx context.Context, collection string, params ListDocumentsParams) (*DocumentListResponse, error) {
 	values := url.Values{}
@@ -218,6 +299,9 @@ func (c *TenantClient) ListDocuments(ctx context.Context, collection string, par
 			values.Set("f."+trimmed, value)
 		}
 	}
+	setTimeParam(values, "created_since", params.CreatedSince)
+	setTimeParam(values, "created_before", params.CreatedBefore)
+	setTimeParam(values, "updated_since", params.UpdatedSince)
 	path := fmt.Sprintf("/api/collections/%s/documents", url.PathEscape(collection))
 	if encoded := values.Encode(); encoded != "" {
 		path += "?" + encoded
@@ -327,12 +411,14 @@ func (c *TenantClient) GetDocumentByPrimaryKey(ctx context.Context, collection,
 	return &doc, nil
 }
 
-// CreateDocument inserts a new document into a collection.
+// CreateDocument inserts a new document into a collection. The request carries an
+// Idempotency-Key (from ctx or generated) so transient failures can be retried safely.
 func (c *TenantClient) CreateDocument(ctx context.Context, collection string, payload []byte, appID string) (*Document, error) {
 	req, err := c.newJSONRequest(ctx, http.MethodPost, fmt.Sprintf("/api/collections/%s/documents", url.PathEscape(collection)), jsonRaw(payload))
 	if err != nil {
 		return nil, err
 	}
+	applyIdempotencyKey(req)
 	c.authorize(req)
 	c.applyAppScope(req, appID)
 	var doc Document
@@ -372,6 +458,44 @@ func (c *TenantClient) PatchDocument(ctx context.Context, collection, id string,
 	return &doc, nil
 }
 
+// PatchDocumentIfVersion applies a partial update only while the document is
+// still at version. The request carries an If-Match precondition, and a
+// document changed in the meantime fails with a ConflictError.
+func (c *TenantClient) PatchDocumentIfVersion(ctx context.Context, collection, id string, payload []byte, version int64, appID string) (*Document, error) {
+	req, err := c.newJSONRequest(ctx, http.MethodPatch, fmt.Sprintf("/api/collections/%s/documents/%s", url.PathEscape(collection), url.PathEscape(id)), jsonRaw(payload))
+	if err != nil {
+		return nil, err
+	}
+	c.authorize(req)
+	c.applyAppScope(req, appID)
+	req.Header.Set("If-Match", strconv.Quote(strconv.FormatInt(version, 10)))
+	var doc Document
+	if err := c.do(req, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// JSONPatchContentType is the media type of RFC 6902 JSON Patch documents.
+const JSONPatchContentType = "application/json-patch+json"
+
+// ApplyJSONPatch applies an RFC 6902 operation array to a document. A failed
+// "test" operation is reported by the server as a conflict.
+func (c *TenantClient) ApplyJSONPatch(ctx context.Context, collection, id string, operations []byte, appID string) (*Document, error) {
+	req, err := c.newJSONRequest(ctx, http.MethodPatch, fmt.Sprintf("/api/collections/%s/documents/%s", url.PathEscape(collection), url.PathEscape(id)), jsonRaw(operations))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", JSONPatchContentType)
+	c.authorize(req)
+	c.applyAppScope(req, appID)
+	var doc Document
+	if err := c.do(req, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
 // DeleteDocument soft-deletes a document.
 func (c *TenantClient) DeleteDocument(ctx context.Context, collection, id, appID string) error {
 	req, err := c.newJSONRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/collections/%s/documents/%s", url.PathEscape(collection), url.PathEscape(id)), nil)
@@ -405,12 +529,14 @@ func (c *TenantClient) PurgeDocument(ctx context.Context, collection, id string,
 	return c.do(req, nil)
 }
 
-// BulkCreateDocuments inserts multiple documents in one request.
+// BulkCreateDocuments inserts multiple documents in one request. Like CreateDocument,
+// it is sent with an Idempotency-Key and retried on transient failures.
 func (c *TenantClient) BulkCreateDocuments(ctx context.Context, collection string, payload []byte, appID string) (*DocumentBulkResponse, error) {
 	req, err := c.newJSONRequest(ctx, http.MethodPost, fmt.Sprintf("/api/collections/%s/documents/bulk", url.PathEscape(collection)), jsonRaw(payload))
 	if err != nil {
 		return nil, err
 	}
+	applyIdempotencyKey(req)
 	c.authorize(req)
 	c.applyAppScope(req, appID)
 	var resp DocumentBulkResponse
@@ -604,6 +730,46 @@ func (c *TenantClient) ExecuteSavedQueryByName(ctx context.Context, name string,
 	return &result, nil
 }
 
+// StreamSavedQuery executes a saved query and returns the unbuffered response body.
+// The server is asked for NDJSON; callers should check the Content-Type header since
+// servers without streaming support reply with the regular JSON envelope.
+func (c *TenantClient) StreamSavedQuery(ctx context.Context, target string, byName bool, payload []byte, appID string) (io.ReadCloser, http.Header, error) {
+	values := url.Values{}
+	if trimmed := strings.TrimSpace(appID); trimmed != "" {
+		values.Set("app_id", trimmed)
+	}
+	values.Set("format", "ndjson")
+	path := fmt.Sprintf("/api/queries/%s/execute", url.PathEscape(target))
+	if byName {
+		path = fmt.Sprintf("/api/queries/name/%s/execute", url.PathEscape(target))
+	}
+	path += "?" + values.Encode()
+	var body interface{}
+	if len(payload) > 0 {
+		body = jsonRaw(payload)
+	}
+	req, err := c.newJSONRequest(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/x-ndjson, application/json")
+	c.authorize(req)
+	c.applyAppScope(req, appID)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		msg := readErrorBody(resp.Body)
+		if msg == "" {
+			msg = resp.Status
+		}
+		return nil, nil, fmt.Errorf("request failed: %s", msg)
+	}
+	return resp.Body, resp.Header, nil
+}
+
 // DeleteSavedQueryByID deletes or purges a saved query document by ID.
 func (c *TenantClient) DeleteSavedQueryByID(ctx context.Context, id string, purge bool, appID string, confirm bool) error {
 	if strings.TrimSpace(id) == "" {
@@ -672,6 +838,9 @@ func (c *TenantClient) ReportQuery(ctx context.Context, params ReportQueryParams
 			payload["selectOnly"] = true
 		}
 	}
+	if params.Explain {
+		payload["explain"] = true
+	}
 	encodedBody, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
@@ -718,12 +887,33 @@ func (c *TenantClient) ReportQuery(ctx context.Context, params ReportQueryParams
 	return &resp, nil
 }
 
-// ListAuditLogs retrieves audit log entries for the tenant with optional filters.
+// ListAuditLogs retrieves one page of audit log entries for the tenant with
+// optional filters. Use ListAuditLogsPage for the pagination metadata or
+// AuditLogPages to walk every page.
 func (c *TenantClient) ListAuditLogs(ctx context.Context, params ListAuditLogsParams) ([]AuditLog, error) {
+	resp, err := c.ListAuditLogsPage(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// ListAuditLogsPage retrieves one page of audit log entries together with the
+// server's pagination metadata.
+func (c *TenantClient) ListAuditLogsPage(ctx context.Context, params ListAuditLogsParams) (*AuditLogListResponse, error) {
 	values := url.Values{}
 	if params.Limit > 0 {
 		values.Set("limit", strconv.Itoa(params.Limit))
 	}
+	if params.Offset > 0 {
+		values.Set("offset", strconv.Itoa(params.Offset))
+	}
+	if trimmed := strings.TrimSpace(params.Cursor); trimmed != "" {
+		values.Set("cursor", trimmed)
+	}
+	if len(params.Fields) > 0 {
+		values.Set("fields", strings.Join(params.Fields, ","))
+	}
 	if trimmed := strings.TrimSpace(params.CollectionID); trimmed != "" {
 		values.Set("collection", trimmed)
 	}
@@ -759,7 +949,7 @@ func (c *TenantClient) ListAuditLogs(ctx context.Context, params ListAuditLogsPa
 	if err := c.do(req, &resp); err != nil {
 		return nil, err
 	}
-	return resp.Items, nil
+	return &resp, nil
 }
 
 // AuthStatus retrieves the authentication context for the current API key via /api/me.
@@ -778,6 +968,20 @@ func (c *TenantClient) AuthStatus(ctx context.Context, appID string) (*AuthStatu
 	return &status, nil
 }
 
+// Capabilities retrieves server limits and supported features via /api/capabilities.
+func (c *TenantClient) Capabilities(ctx context.Context) (*ServerCapabilities, error) {
+	req, err := c.newJSONRequest(ctx, http.MethodGet, "/api/capabilities", nil)
+	if err != nil {
+		return nil, err
+	}
+	c.authorize(req)
+	var caps ServerCapabilities
+	if err := c.do(req, &caps); err != nil {
+		return nil, err
+	}
+	return &caps, nil
+}
+
 type jsonRaw []byte
 
 func (r jsonRaw) MarshalJSON() ([]byte, error) {
@@ -827,6 +1031,21 @@ func (c *TenantClient) GetApplication(ctx context.Context, id string) (*Applicat
 	return &app, nil
 }
 
+// UpdateApplication renames an application or changes its description.
+func (c *TenantClient) UpdateApplication(ctx context.Context, id string, request UpdateApplicationRequest) (*Application, error) {
+	path := fmt.Sprintf("/api/applications/%s", url.PathEscape(id))
+	req, err := c.newJSONRequest(ctx, http.MethodPatch, path, request)
+	if err != nil {
+		return nil, err
+	}
+	c.authorize(req)
+	var app Application
+	if err := c.do(req, &app); err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
 // ===== SNAPSHOT OPERATIONS =====
 
 // ListSnapshots retrieves snapshots for the tenant
@@ -918,3 +1137,50 @@ func (c *TenantClient) DeleteSnapshot(ctx context.Context, snapshotID string) er
 
 	return c.do(req, nil)
 }
+
+// ListSnapshotKeys retrieves the tenant's snapshot encryption keys
+func (c *TenantClient) ListSnapshotKeys(ctx context.Context) ([]SnapshotKey, error) {
+	req, err := c.newJSONRequest(ctx, http.MethodGet, "/api/snapshot-keys", nil)
+	if err != nil {
+		return nil, err
+	}
+	c.authorize(req)
+
+	var resp SnapshotKeyListResponse
+	if err := c.do(req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// CreateSnapshotKey creates a new snapshot encryption key
+func (c *TenantClient) CreateSnapshotKey(ctx context.Context, request CreateSnapshotKeyRequest) (*SnapshotKey, error) {
+	req, err := c.newJSONRequest(ctx, http.MethodPost, "/api/snapshot-keys", request)
+	if err != nil {
+		return nil, err
+	}
+	c.authorize(req)
+
+	var key SnapshotKey
+	if err := c.do(req, &key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// RotateSnapshotKey replaces a snapshot key with a new active key. Existing
+// snapshots stay readable with the previous key.
+func (c *TenantClient) RotateSnapshotKey(ctx context.Context, keyID string) (*RotateSnapshotKeyResponse, error) {
+	path := fmt.Sprintf("/api/snapshot-keys/%s/rotate", url.PathEscape(keyID))
+	req, err := c.newJSONRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.authorize(req)
+
+	var resp RotateSnapshotKeyResponse
+	if err := c.do(req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTenantClientListCollectionsOnlyDeleted(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/collections" {
+			t.Errorf("expected path /api/collections, got %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if got := query.Get("include_deleted"); got != "true" {
+			t.Errorf("expected include_deleted=true, got %q", got)
+		}
+		if got := query.Get("only_deleted"); got != "true" {
+			t.Errorf("expected only_deleted=true, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"name":"live"},{"name":"gone","deleted_at":"2025-01-01T00:00:00Z"}]`))
+	}))
+	defer ts.Close()
+
+	client, err := NewTenantClient(ts.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	cols, err := client.ListCollectionsWithParams(context.Background(), ListCollectionsParams{OnlyDeleted: true})
+	if err != nil {
+		t.Fatalf("ListCollectionsWithParams: %v", err)
+	}
+	if len(cols) != 1 || cols[0].Name != "gone" {
+		t.Fatalf("expected only the deleted collection, got %+v", cols)
+	}
+}
+
+func TestTenantClientRestoreCollection(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/collections/old-logs/restore" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"old-logs"}`))
+	}))
+	defer ts.Close()
+
+	client, err := NewTenantClient(ts.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	col, err := client.RestoreCollection(context.Background(), "old-logs", "")
+	if err != nil {
+		t.Fatalf("RestoreCollection: %v", err)
+	}
+	if col.Name != "old-logs" {
+		t.Fatalf("expected restored collection old-logs, got %s", col.Name)
+	}
+}
@@ -1,93 +1,49 @@
-package client
+package client_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"io"
 	"net/http"
-	"net/http/httptest"
+	"net/url"
 	"testing"
+
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
 )
 
 func TestTenantClientReportQuery(t *testing.T) {
-	t.Helper()
-
-	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			t.Errorf("expected POST method, got %s", r.Method)
-		}
-		if r.URL.Path != "/api/query" {
-			t.Errorf("expected path /api/query, got %s", r.URL.Path)
-		}
-		if got := r.Header.Get("X-API-Key"); got != "secret" {
-			t.Errorf("missing api key header, got %q", got)
-		}
-		if got := r.Header.Get("X-App-ID"); got != "app123" {
-			t.Errorf("missing app id header, got %q", got)
-		}
-		query := r.URL.Query()
-		if got := query.Get("limit"); got != "5" {
-			t.Errorf("expected limit query 5, got %q", got)
-		}
-		if got := query.Get("offset"); got != "3" {
-			t.Errorf("expected offset query 3, got %q", got)
-		}
-		if got := query.Get("cursor"); got != "cursor123" {
-			t.Errorf("expected cursor query cursor123, got %q", got)
-		}
-		if got := query.Get("select"); got != "category,total" {
-			t.Errorf("expected select query category,total, got %q", got)
-		}
-		if got := query.Get("app_id"); got != "app123" {
-			t.Errorf("expected app_id query app123, got %q", got)
-		}
+	srv := clienttest.NewServer(t)
+	srv.AddDocument("orders", map[string]any{"category": "books", "price": 12.0})
+	srv.AddDocument("orders", map[string]any{"category": "games", "price": 5.0})
+	srv.AddDocument("orders", map[string]any{"category": "books", "price": 30.0})
 
+	var request string
+	var apiKey, appHeader string
+	var query url.Values
+	var payload map[string]any
+	routes := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r.Method + " " + r.URL.Path
+		apiKey, appHeader, query = r.Header.Get("X-API-Key"), r.Header.Get("X-App-ID"), r.URL.Query()
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
-			t.Fatalf("reading body: %v", err)
+			t.Errorf("reading body: %v", err)
 		}
-		defer r.Body.Close()
-		var payload map[string]any
+		payload = nil
 		if err := json.Unmarshal(body, &payload); err != nil {
-			t.Fatalf("unmarshal body: %v", err)
+			t.Errorf("unmarshal body %s: %v", body, err)
 		}
-		if payload["collection"] != "orders" {
-			t.Errorf("expected collection orders, got %v", payload["collection"])
-		}
-		if payload["cursor"] != "cursor123" {
-			t.Errorf("expected cursor in body cursor123, got %v", payload["cursor"])
-		}
-		if payload["limit"] != float64(5) {
-			t.Errorf("expected limit in body 5, got %v", payload["limit"])
-		}
-		if payload["offset"] != float64(3) {
-			t.Errorf("expected offset in body 3, got %v", payload["offset"])
-		}
-		if _, ok := payload["aggregate"]; !ok {
-			t.Errorf("expected aggregate clause in payload")
-		}
-		selectRaw := payload["select"]
-		selectSlice, okCast := selectRaw.([]any)
-		if !okCast || len(selectSlice) != 2 {
-			t.Errorf("expected select array in payload, got %T with value %v", selectRaw, selectRaw)
-		}
-
-		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`{"data":[{"category":"books","total":42}],"pagination":{"limit":5,"offset":3,"total":1,"next_cursor":"next"}}`))
-	}))
-	defer ts.Close()
-
-	client, err := NewTenantClient(ts.URL, "secret")
-	if err != nil {
-		t.Fatalf("NewTenantClient: %v", err)
-	}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		routes.ServeHTTP(w, r)
+	})
 
-	resp, err := client.ReportQuery(context.Background(), ReportQueryParams{
+	c := srv.TenantClient(t)
+	params := client.ReportQueryParams{
 		AppID:        "app123",
 		Collection:   "orders",
-		Limit:        5,
-		Offset:       3,
-		Cursor:       "cursor123",
+		Limit:        1,
 		SelectFields: []string{"category", "total"},
 		Body: map[string]any{
 			"aggregate": []any{
@@ -95,31 +51,71 @@ func TestTenantClientReportQuery(t *testing.T) {
 			},
 			"groupBy": []any{"category"},
 		},
-	})
+	}
+	resp, err := c.ReportQuery(context.Background(), params)
 	if err != nil {
 		t.Fatalf("ReportQuery: %v", err)
 	}
+	if request != "POST /api/query" {
+		t.Fatalf("expected POST /api/query, got %s", request)
+	}
+	if apiKey != clienttest.APIKey {
+		t.Fatalf("expected api key header %q, got %q", clienttest.APIKey, apiKey)
+	}
+	if appHeader != "app123" || query.Get("app_id") != "app123" || query.Get("limit") != "1" || query.Get("select") != "category,total" {
+		t.Fatalf("unexpected app scope or query: header %q, query %v", appHeader, query)
+	}
+	if payload["collection"] != "orders" || payload["limit"] != float64(1) {
+		t.Fatalf("expected collection and limit in body, got %v", payload)
+	}
+	if _, ok := payload["aggregate"]; !ok {
+		t.Fatalf("expected aggregate clause in body, got %v", payload)
+	}
+	if selected, ok := payload["select"].([]any); !ok || len(selected) != 2 {
+		t.Fatalf("expected select array in body, got %T with value %v", payload["select"], payload["select"])
+	}
+	if got := resp.Pagination; got.Limit != 1 || got.Offset != 0 || got.Total != 2 || got.NextCursor == "" {
+		t.Fatalf("unexpected pagination %+v", got)
+	}
+	if len(resp.Data) != 1 || resp.Data[0]["category"] != "books" || resp.Data[0]["total"] != float64(42) {
+		t.Fatalf("unexpected first page %v", resp.Data)
+	}
 
-	if resp.Pagination.Limit != 5 {
-		t.Errorf("expected pagination limit 5, got %d", resp.Pagination.Limit)
+	params.Cursor = resp.Pagination.NextCursor
+	resp, err = c.ReportQuery(context.Background(), params)
+	if err != nil {
+		t.Fatalf("ReportQuery with cursor: %v", err)
+	}
+	if query.Get("cursor") != params.Cursor || payload["cursor"] != params.Cursor {
+		t.Fatalf("expected cursor %q in query and body, got %v and %v", params.Cursor, query, payload)
 	}
-	if resp.Pagination.Offset != 3 {
-		t.Errorf("expected pagination offset 3, got %d", resp.Pagination.Offset)
+	if len(resp.Data) != 1 || resp.Data[0]["category"] != "games" || resp.Data[0]["total"] != float64(5) || resp.Pagination.NextCursor != "" {
+		t.Fatalf("unexpected second page %+v", resp)
 	}
-	if resp.Pagination.Total != 1 {
-		t.Errorf("expected pagination total 1, got %d", resp.Pagination.Total)
+
+	params.Cursor, params.Offset = "", 1
+	resp, err = c.ReportQuery(context.Background(), params)
+	if err != nil {
+		t.Fatalf("ReportQuery with offset: %v", err)
 	}
-	if resp.Pagination.NextCursor != "next" {
-		t.Errorf("expected next cursor 'next', got %q", resp.Pagination.NextCursor)
+	if payload["offset"] != float64(1) {
+		t.Fatalf("expected offset in body, got %v", payload)
 	}
-	if len(resp.Data) != 1 {
-		t.Fatalf("expected 1 data row, got %d", len(resp.Data))
+	if query.Get("offset") != "1" || resp.Pagination.Offset != 1 || len(resp.Data) != 1 || resp.Data[0]["category"] != "games" {
+		t.Fatalf("unexpected offset page %+v (query %v)", resp, query)
 	}
-	row := resp.Data[0]
-	if row["category"] != "books" {
-		t.Errorf("expected category books, got %v", row["category"])
+}
+
+func TestTenantClientReportQueryExplain(t *testing.T) {
+	srv := clienttest.NewServer(t)
+	srv.AddDocument("orders", map[string]any{"status": "paid"})
+	srv.AddDocument("orders", map[string]any{"status": "open"})
+
+	resp, err := srv.TenantClient(t).ReportQuery(context.Background(), client.ReportQueryParams{Collection: "orders", Explain: true})
+	if err != nil {
+		t.Fatalf("ReportQuery: %v", err)
 	}
-	if row["total"] != float64(42) {
-		t.Errorf("expected total 42, got %v", row["total"])
+	if resp.Explain == nil || resp.Explain.Plan.Operation != "collection_scan" || resp.Explain.Plan.RowsScanned != 2 || resp.Explain.Plan.RowsReturned != 2 {
+		t.Fatalf("unexpected explain: %+v", resp.Explain)
 	}
 }
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	defaultRequestTimeout      = 15 * time.Second
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 32
+)
+
+// transportSettings tunes the default HTTP transport. The standard library
+// keeps only two idle connections per host, which throttles the concurrent
+// batch commands (import, export, sync), so the defaults here are higher.
+type transportSettings struct {
+	maxIdleConns        int
+	maxIdleConnsPerHost int
+	maxConnsPerHost     int
+	forceHTTP2          bool
+	dial                DialFunc
+}
+
+func defaultTransportSettings() transportSettings {
+	return transportSettings{
+		maxIdleConns:        defaultMaxIdleConns,
+		maxIdleConnsPerHost: defaultMaxIdleConnsPerHost,
+	}
+}
+
+// WithMaxIdleConns sets how many idle connections are kept across all hosts
+// and per host. It has no effect when WithHTTPClient supplies the client.
+func WithMaxIdleConns(n int) Option {
+	return func(b *baseClient) {
+		if n < 0 {
+			n = 0
+		}
+		b.transport.maxIdleConns = n
+		b.transport.maxIdleConnsPerHost = n
+	}
+}
+
+// WithMaxConnsPerHost caps the total connections per host, including those in
+// use. Zero means no limit. It has no effect when WithHTTPClient supplies the
+// client.
+func WithMaxConnsPerHost(n int) Option {
+	return func(b *baseClient) {
+		if n < 0 {
+			n = 0
+		}
+		b.transport.maxConnsPerHost = n
+	}
+}
+
+// WithForceHTTP2 restricts the transport to HTTP/2, including cleartext
+// HTTP/2 (h2c) for http:// endpoints, so requests are multiplexed over a
+// single connection. It has no effect when WithHTTPClient supplies the client.
+func WithForceHTTP2() Option {
+	return func(b *baseClient) {
+		b.transport.forceHTTP2 = true
+	}
+}
+
+func (s transportSettings) newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = s.maxIdleConns
+	transport.MaxIdleConnsPerHost = s.maxIdleConnsPerHost
+	transport.MaxConnsPerHost = s.maxConnsPerHost
+	transport.ForceAttemptHTTP2 = true
+	if s.dial != nil {
+		transport.DialContext = s.dial
+		transport.Proxy = nil
+	}
+	if s.forceHTTP2 {
+		protocols := new(http.Protocols)
+		protocols.SetHTTP2(true)
+		protocols.SetUnencryptedHTTP2(true)
+		transport.Protocols = protocols
+	}
+	return transport
+}
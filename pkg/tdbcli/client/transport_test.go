@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTransportOptions(t *testing.T) {
+	c, err := NewTenantClient("http://localhost:8080", "secret", WithMaxIdleConns(8), WithMaxConnsPerHost(4))
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	transport := c.httpClient.(*http.Client).Transport.(*http.Transport)
+	if transport.MaxIdleConns != 8 || transport.MaxIdleConnsPerHost != 8 || transport.MaxConnsPerHost != 4 {
+		t.Fatalf("unexpected transport limits: idle=%d idlePerHost=%d perHost=%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.MaxConnsPerHost)
+	}
+
+	c, err = NewTenantClient("http://localhost:8080", "secret")
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	transport = c.httpClient.(*http.Client).Transport.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != defaultMaxIdleConnsPerHost || transport.MaxConnsPerHost != 0 {
+		t.Fatalf("unexpected default transport limits: idlePerHost=%d perHost=%d", transport.MaxIdleConnsPerHost, transport.MaxConnsPerHost)
+	}
+
+	custom := &http.Client{}
+	c, err = NewTenantClient("http://localhost:8080", "secret", WithHTTPClient(custom), WithForceHTTP2())
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	if c.httpClient != custom {
+		t.Fatalf("expected custom HTTP client to be kept")
+	}
+}
+
+func TestForceHTTP2UsesCleartextHTTP2(t *testing.T) {
+	var proto string
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proto = r.Proto
+		_, _ = w.Write([]byte(`{"id":"doc_1","data":"{}"}`))
+	}))
+	ts.Config.Protocols = new(http.Protocols)
+	ts.Config.Protocols.SetHTTP1(true)
+	ts.Config.Protocols.SetUnencryptedHTTP2(true)
+	ts.Start()
+	defer ts.Close()
+
+	c, err := NewTenantClient(ts.URL, "secret", WithForceHTTP2())
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	if _, err := c.GetDocument(context.Background(), "users", "doc_1", ""); err != nil {
+		t.Fatalf("GetDocument: %v", err)
+	}
+	if proto != "HTTP/2.0" {
+		t.Fatalf("expected HTTP/2.0, got %s", proto)
+	}
+}
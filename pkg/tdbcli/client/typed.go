@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// DecodeDocument unmarshals the document payload into a value of type T.
+func DecodeDocument[T any](doc Document) (T, error) {
+	var value T
+	if strings.TrimSpace(doc.Data) == "" {
+		return value, fmt.Errorf("document %s has no data", doc.ID)
+	}
+	if err := json.Unmarshal([]byte(doc.Data), &value); err != nil {
+		return value, fmt.Errorf("decode document %s: %w", doc.ID, err)
+	}
+	return value, nil
+}
+
+// GetDocumentAs fetches a document by ID and decodes its payload into T.
+func GetDocumentAs[T any](ctx context.Context, c *TenantClient, collection, id, appID string) (T, error) {
+	doc, err := c.GetDocument(ctx, collection, id, appID)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return DecodeDocument[T](*doc)
+}
+
+// GetDocumentByPrimaryKeyAs fetches a document by primary key and decodes its payload into T.
+func GetDocumentByPrimaryKeyAs[T any](ctx context.Context, c *TenantClient, collection, key, appID string) (T, error) {
+	doc, err := c.GetDocumentByPrimaryKey(ctx, collection, key, appID)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return DecodeDocument[T](*doc)
+}
+
+// ListDocumentsAs fetches a single page of documents and decodes each payload into T.
+func ListDocumentsAs[T any](ctx context.Context, c *TenantClient, collection string, params ListDocumentsParams) ([]T, error) {
+	resp, err := c.ListDocuments(ctx, collection, params)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]T, 0, len(resp.Items))
+	for _, doc := range resp.Items {
+		value, err := DecodeDocument[T](doc)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// DocumentsAs iterates over every document matching params using
+// DocumentsIterator and yields each payload decoded into T. Iteration stops
+// after the first error, which is yielded with the zero value of T.
+//
+//	for user, err := range client.DocumentsAs[User](ctx, c, "users", client.ListDocumentsParams{}) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func DocumentsAs[T any](ctx context.Context, c *TenantClient, collection string, params ListDocumentsParams, opts ...IteratorOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		it := c.DocumentsIterator(ctx, collection, params, opts...)
+		defer it.Close()
+		for it.Next() {
+			value, err := DecodeDocument[T](it.Document())
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(value, nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+type typedUser struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func TestGetDocumentAs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/collections/users/documents/doc-1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id":"doc-1","data":"{\"name\":\"Ada\",\"age\":36}"}`))
+	}))
+	defer ts.Close()
+
+	client, err := NewTenantClient(ts.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	user, err := GetDocumentAs[typedUser](context.Background(), client, "users", "doc-1", "")
+	if err != nil {
+		t.Fatalf("GetDocumentAs: %v", err)
+	}
+	if user.Name != "Ada" || user.Age != 36 {
+		t.Fatalf("unexpected user %+v", user)
+	}
+}
+
+func TestDecodeDocumentErrors(t *testing.T) {
+	if _, err := DecodeDocument[typedUser](Document{ID: "empty"}); err == nil {
+		t.Fatalf("expected error for empty data")
+	}
+	if _, err := DecodeDocument[typedUser](Document{ID: "bad", Data: `{"age":"old"}`}); err == nil {
+		t.Fatalf("expected error for mismatched type")
+	}
+}
+
+func TestDocumentsAsPaginates(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/collections/users/documents" {
+			http.NotFound(w, r)
+			return
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		items := []Document{}
+		for i := offset; i < 3 && i < offset+2; i++ {
+			items = append(items, Document{ID: strconv.Itoa(i), Data: fmt.Sprintf(`{"name":"user-%d","age":%d}`, i, i)})
+		}
+		_ = json.NewEncoder(w).Encode(DocumentListResponse{Items: items})
+	}))
+	defer ts.Close()
+
+	client, err := NewTenantClient(ts.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	var names []string
+	for user, err := range DocumentsAs[typedUser](context.Background(), client, "users", ListDocumentsParams{Limit: 2}) {
+		if err != nil {
+			t.Fatalf("DocumentsAs: %v", err)
+		}
+		names = append(names, user.Name)
+	}
+	if fmt.Sprint(names) != "[user-0 user-1 user-2]" {
+		t.Fatalf("unexpected names %v", names)
+	}
+
+	count := 0
+	for range DocumentsAs[typedUser](context.Background(), client, "users", ListDocumentsParams{Limit: 2}) {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Fatalf("expected early break to stop iteration, got %d", count)
+	}
+}
@@ -50,6 +50,73 @@ type Collection struct {
 	DeletedAt       *time.Time `json:"deleted_at"`
 	DocumentCount   int64      `json:"document_count"`
 	StorageBytes    int64      `json:"storage_bytes"`
+	// SortableFields lists data fields the server can sort documents by
+	// (usually indexed fields), without the data. prefix.
+	SortableFields []string `json:"sortable_fields,omitempty"`
+	// TTLField is the timestamp data field the server expires documents by,
+	// empty when the collection has no TTL configured.
+	TTLField string `json:"ttl_field,omitempty"`
+	// Limits is present when the server reports per-collection quotas.
+	Limits *CollectionLimits `json:"limits,omitempty"`
+}
+
+// CollectionLimits caps the size of a collection. Nil fields are unlimited.
+type CollectionLimits struct {
+	MaxDocuments    *int64 `json:"max_documents"`
+	MaxStorageBytes *int64 `json:"max_storage_bytes"`
+}
+
+// CollectionLimitsResponse reports a collection's limits with its current usage.
+type CollectionLimitsResponse struct {
+	Collection    string           `json:"collection"`
+	Limits        CollectionLimits `json:"limits"`
+	DocumentCount int64            `json:"document_count"`
+	StorageBytes  int64            `json:"storage_bytes"`
+}
+
+// UpdateCollectionLimitsRequest changes collection limits. Nil fields are left
+// unchanged; Clear names limits to remove ("max_documents", "max_storage_bytes").
+type UpdateCollectionLimitsRequest struct {
+	MaxDocuments    *int64   `json:"max_documents,omitempty"`
+	MaxStorageBytes *int64   `json:"max_storage_bytes,omitempty"`
+	Clear           []string `json:"clear,omitempty"`
+}
+
+// CollectionHook is a server-side trigger that runs an action when documents
+// in a collection change, such as calling a webhook on document.created.
+type CollectionHook struct {
+	ID         string          `json:"id,omitempty"`
+	Name       string          `json:"name"`
+	Collection string          `json:"collection,omitempty"`
+	Event      string          `json:"event"`
+	Action     string          `json:"action"`
+	Target     string          `json:"target"`
+	Config     json.RawMessage `json:"config,omitempty"`
+	Enabled    *bool           `json:"enabled,omitempty"`
+	CreatedAt  *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt  *time.Time      `json:"updated_at,omitempty"`
+}
+
+// CollectionHookListResponse is returned when listing collection hooks.
+type CollectionHookListResponse struct {
+	Items []CollectionHook `json:"items"`
+}
+
+// CollectionHookTestRequest invokes a hook once with a sample document.
+type CollectionHookTestRequest struct {
+	Event    string          `json:"event,omitempty"`
+	Document json.RawMessage `json:"document,omitempty"`
+}
+
+// CollectionHookTestResult reports the outcome of a test invocation.
+type CollectionHookTestResult struct {
+	Hook       string `json:"hook"`
+	Event      string `json:"event"`
+	Success    bool   `json:"success"`
+	StatusCode int    `json:"status_code,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+	Output     string `json:"output,omitempty"`
+	Error      string `json:"error,omitempty"`
 }
 
 // PrimaryKeySpec configures a collection primary key.
@@ -111,6 +178,8 @@ type DocumentPagination struct {
 	Limit  int   `json:"limit"`
 	Offset int   `json:"offset"`
 	Count  int64 `json:"count"`
+	// NextCursor is set by servers that paginate with cursors.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // DocumentListResponse is returned by list endpoints.
@@ -119,9 +188,11 @@ type DocumentListResponse struct {
 	Pagination DocumentPagination `json:"pagination"`
 }
 
-// AuditLogListResponse wraps audit log list responses.
+// AuditLogListResponse wraps audit log list responses. Pagination is omitted
+// by servers that return a single page.
 type AuditLogListResponse struct {
-	Items []AuditLog `json:"items"`
+	Items      []AuditLog          `json:"items"`
+	Pagination *DocumentPagination `json:"pagination,omitempty"`
 }
 
 // DocumentBulkResponse is returned by bulk create endpoints.
@@ -133,6 +204,7 @@ type DocumentBulkResponse struct {
 type ReportQueryResponse struct {
 	Data       []map[string]any      `json:"data"`
 	Pagination ReportQueryPagination `json:"pagination"`
+	Explain    *QueryExplain         `json:"explain,omitempty"`
 }
 
 // ReportQueryPagination describes pagination metadata for report queries.
@@ -159,7 +231,29 @@ type SavedQueryListResponse struct {
 
 // SavedQueryExecutionResult contains the result rows when executing a saved query.
 type SavedQueryExecutionResult struct {
-	Items []map[string]any `json:"items"`
+	Items   []map[string]any `json:"items"`
+	Explain *QueryExplain    `json:"explain,omitempty"`
+}
+
+// QueryExplain is the execution plan returned alongside the results when a
+// report or saved query is sent with "explain": true.
+type QueryExplain struct {
+	PlanningMS  float64       `json:"planning_ms,omitempty"`
+	ExecutionMS float64       `json:"execution_ms,omitempty"`
+	IndexesUsed []string      `json:"indexes_used,omitempty"`
+	Plan        QueryPlanNode `json:"plan"`
+}
+
+// QueryPlanNode is one step of a query plan, such as an index or collection
+// scan, filter, group, or sort, with the steps that feed it as children.
+type QueryPlanNode struct {
+	Operation    string          `json:"operation"`
+	Index        string          `json:"index,omitempty"`
+	Detail       string          `json:"detail,omitempty"`
+	RowsScanned  int64           `json:"rows_scanned"`
+	RowsReturned int64           `json:"rows_returned"`
+	DurationMS   float64         `json:"duration_ms"`
+	Children     []QueryPlanNode `json:"children,omitempty"`
 }
 
 // SavedQueryPatchRequest is used when partially updating a saved query by name.
@@ -176,6 +270,43 @@ type AuthStatus struct {
 	CreatedAt  *time.Time `json:"created_at,omitempty"`
 	LastUsed   *time.Time `json:"last_used,omitempty"`
 	Scope      string     `json:"scope,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	// Usage counters are only present when the server exposes key usage.
+	RequestCount       *int64           `json:"request_count,omitempty"`
+	RequestsByEndpoint map[string]int64 `json:"requests_by_endpoint,omitempty"`
+}
+
+// ServerCapabilities describes server-side limits and features returned by GET /api/capabilities.
+type ServerCapabilities struct {
+	Version         string   `json:"version,omitempty"`
+	DefaultPageSize int      `json:"default_page_size,omitempty"`
+	MaxPageSize     int      `json:"max_page_size,omitempty"`
+	MaxBulkSize     int      `json:"max_bulk_size,omitempty"`
+	StreamingExport bool     `json:"streaming_export"`
+	Endpoints       []string `json:"endpoints,omitempty"`
+	Features        []string `json:"features,omitempty"`
+}
+
+// FeatureRestoreDryRun is advertised by servers that honor dry_run on
+// snapshot restores.
+const FeatureRestoreDryRun = "restore_dry_run"
+
+// Supports reports whether the server advertises the named feature.
+func (c ServerCapabilities) Supports(feature string) bool {
+	for _, name := range c.Features {
+		if name == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// ListCollectionsParams configures collection list queries.
+type ListCollectionsParams struct {
+	AppID          string
+	IncludeDeleted bool
+	OnlyDeleted    bool
 }
 
 // ListDocumentsParams configures document list queries.
@@ -189,6 +320,9 @@ type ListDocumentsParams struct {
 	SelectOnly     bool
 	Filters        map[string]string
 	Sort           []string
+	CreatedSince   *time.Time
+	CreatedBefore  *time.Time
+	UpdatedSince   *time.Time
 }
 
 // ReportQueryParams configures report query requests.
@@ -200,13 +334,20 @@ type ReportQueryParams struct {
 	Cursor       string
 	SelectFields []string
 	SelectOnly   bool
-	Body         map[string]any
+	// Explain asks the server to return its execution plan with the results.
+	Explain bool
+	Body    map[string]any
 }
 
-// ListAuditLogsParams configures audit log retrieval.
+// ListAuditLogsParams configures audit log retrieval. Limit is the page size;
+// Offset or Cursor select the page. Fields restricts the returned attributes,
+// e.g. to skip the old_data/new_data payloads.
 type ListAuditLogsParams struct {
 	AppID        string
 	Limit        int
+	Offset       int
+	Cursor       string
+	Fields       []string
 	CollectionID string
 	DocumentID   string
 	Operation    string
@@ -236,6 +377,13 @@ type CreateTenantRequest struct {
 	WithAPIKey  bool   `json:"with_api_key,omitempty"`
 }
 
+// UpdateTenantRequest updates tenant limits. Nil fields are left unchanged.
+type UpdateTenantRequest struct {
+	RateLimitPerMinute *int   `json:"rate_limit_per_minute,omitempty"`
+	RequestDailyLimit  *int   `json:"request_daily_limit,omitempty"`
+	StorageBytesLimit  *int64 `json:"storage_bytes_limit,omitempty"`
+}
+
 // CreateTenantResponse is the response when WithAPIKey is enabled.
 type CreateTenantResponse struct {
 	Tenant *Tenant `json:"tenant"`
@@ -266,6 +414,12 @@ type CreateApplicationRequest struct {
 	WithAPIKey  bool   `json:"with_api_key,omitempty"`
 }
 
+// UpdateApplicationRequest updates an application. Nil fields are left unchanged.
+type UpdateApplicationRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
 // CreateApplicationResponse is returned when requesting an API key for the new app.
 type CreateApplicationResponse struct {
 	App    *Application `json:"app"`
@@ -304,18 +458,36 @@ type CreateSnapshotRequest struct {
 	Incremental      bool   `json:"incremental,omitempty"`
 	ParentSnapshotID string `json:"parent_snapshot_id,omitempty"`
 	Encrypt          bool   `json:"encrypt,omitempty"`
+	EncryptionKeyID  string `json:"encryption_key_id,omitempty"`
 	StorageProvider  string `json:"storage_provider,omitempty"`
 }
 
-// RestoreSnapshotRequest is the payload for restoring a snapshot
+// RestoreSnapshotRequest is the payload for restoring a snapshot. With DryRun
+// the server only reports what the restore would do.
 type RestoreSnapshotRequest struct {
 	TargetCollectionID string `json:"target_collection_id,omitempty"`
+	ConflictPolicy     string `json:"conflict_policy,omitempty"`
+	EncryptionKeyID    string `json:"encryption_key_id,omitempty"`
+	DryRun             bool   `json:"dry_run,omitempty"`
 }
 
-// RestoreSnapshotResponse is the response from restoring a snapshot
+// Conflict policies for documents that already exist in the restore target.
+const (
+	RestoreConflictSkip      = "skip"
+	RestoreConflictOverwrite = "overwrite"
+	RestoreConflictFail      = "fail"
+)
+
+// RestoreSnapshotResponse is the response from restoring a snapshot. The
+// created/overwritten/skipped breakdown is omitted by servers that do not
+// report it.
 type RestoreSnapshotResponse struct {
-	CollectionID      string `json:"collection_id"`
-	DocumentsRestored int    `json:"documents_restored"`
+	CollectionID         string `json:"collection_id"`
+	DocumentsRestored    int    `json:"documents_restored"`
+	DocumentsCreated     *int   `json:"documents_created,omitempty"`
+	DocumentsOverwritten *int   `json:"documents_overwritten,omitempty"`
+	DocumentsSkipped     *int   `json:"documents_skipped,omitempty"`
+	DryRun               bool   `json:"dry_run,omitempty"`
 }
 
 // SnapshotListResponse wraps snapshot list responses
@@ -323,3 +495,41 @@ type SnapshotListResponse struct {
 	Items      []Snapshot         `json:"items"`
 	Pagination DocumentPagination `json:"pagination"`
 }
+
+// SnapshotKey is an encryption key used for snapshots. Rotating a key creates
+// a new active key; the old one stays available to decrypt the snapshots it
+// protects and records its replacement in ReplacedBy.
+type SnapshotKey struct {
+	ID            string     `json:"id"`
+	Name          string     `json:"name"`
+	Algorithm     string     `json:"algorithm"`
+	Status        string     `json:"status"`
+	Version       int        `json:"version"`
+	ReplacedBy    string     `json:"replaced_by,omitempty"`
+	SnapshotCount int        `json:"snapshot_count"`
+	CreatedAt     time.Time  `json:"created_at"`
+	RotatedAt     *time.Time `json:"rotated_at,omitempty"`
+}
+
+// Snapshot key statuses.
+const (
+	SnapshotKeyActive  = "active"
+	SnapshotKeyRotated = "rotated"
+)
+
+// CreateSnapshotKeyRequest is the payload for creating a snapshot key
+type CreateSnapshotKeyRequest struct {
+	Name      string `json:"name"`
+	Algorithm string `json:"algorithm,omitempty"`
+}
+
+// RotateSnapshotKeyResponse is the response from rotating a snapshot key
+type RotateSnapshotKeyResponse struct {
+	Previous SnapshotKey `json:"previous"`
+	Current  SnapshotKey `json:"current"`
+}
+
+// SnapshotKeyListResponse wraps snapshot key list responses
+type SnapshotKeyListResponse struct {
+	Items []SnapshotKey `json:"items"`
+}
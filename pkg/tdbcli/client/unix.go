@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// unixHost is the placeholder host of requests sent over a unix socket.
+const unixHost = "unix"
+
+// DialFunc opens the connection for a request, like net.Dialer.DialContext.
+type DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// WithDialer opens every connection with dial instead of dialing the
+// endpoint's host over TCP, e.g. to reach a sidecar through a tunnel. Proxy
+// settings from the environment are ignored, since dial decides where the
+// connection goes. It has no effect when WithHTTPClient supplies the client.
+func WithDialer(dial DialFunc) Option {
+	return func(b *baseClient) {
+		if dial != nil {
+			b.transport.dial = dial
+		}
+	}
+}
+
+// unixSocketDialer connects to the socket at path whatever address is asked for.
+func unixSocketDialer(path string) DialFunc {
+	var dialer net.Dialer
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return dialer.DialContext(ctx, "unix", path)
+	}
+}
+
+// UnixSocketEndpoint reports whether endpoint is served on a unix domain
+// socket, returning the socket path and the http:// base URL requests use.
+func UnixSocketEndpoint(endpoint string) (socket, base string, ok bool, err error) {
+	return splitUnixEndpoint(strings.TrimSpace(endpoint))
+}
+
+// splitUnixEndpoint recognises endpoints served on a unix domain socket:
+//
+//	unix:///var/run/tinydb.sock
+//	http+unix://%2Fvar%2Frun%2Ftinydb.sock/prefix
+//
+// The first form uses the whole path as the socket; the second takes the
+// percent-encoded socket as its host so the API can sit under a path prefix.
+// It returns the socket and an http:// base URL for building request URLs.
+func splitUnixEndpoint(endpoint string) (socket, base string, ok bool, err error) {
+	lower := strings.ToLower(endpoint)
+	switch {
+	case strings.HasPrefix(lower, "unix://"):
+		socket = endpoint[len("unix://"):]
+		if socket == "" {
+			return "", "", true, fmt.Errorf("invalid endpoint %q: missing socket path", endpoint)
+		}
+		return socket, "http://" + unixHost, true, nil
+	case strings.HasPrefix(lower, "http+unix://"):
+		rest := endpoint[len("http+unix://"):]
+		path := ""
+		if idx := strings.Index(rest, "/"); idx != -1 {
+			rest, path = rest[:idx], rest[idx:]
+		}
+		socket, err = url.PathUnescape(rest)
+		if err != nil || socket == "" {
+			return "", "", true, fmt.Errorf("invalid endpoint %q: expected a percent-encoded socket path as the host", endpoint)
+		}
+		return socket, "http://" + unixHost + path, true, nil
+	}
+	return "", "", false, nil
+}
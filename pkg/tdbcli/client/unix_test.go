@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnixSocketEndpoints(t *testing.T) {
+	dir, err := os.MkdirTemp("", "tdb")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "tinydb.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	var paths []string
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{"id":"doc_1","data":"{}"}`))
+	}))
+	ts.Listener = listener
+	ts.Start()
+	defer ts.Close()
+
+	for _, endpoint := range []string{
+		"unix://" + socket,
+		"http+unix://" + url.PathEscape(socket) + "/tdb",
+	} {
+		c, err := NewTenantClient(endpoint, "secret")
+		if err != nil {
+			t.Fatalf("NewTenantClient(%q): %v", endpoint, err)
+		}
+		if _, err := c.GetDocument(context.Background(), "users", "doc_1", ""); err != nil {
+			t.Fatalf("GetDocument via %q: %v", endpoint, err)
+		}
+	}
+	if len(paths) != 2 || paths[0] != "/api/collections/users/documents/doc_1" || paths[1] != "/tdb/api/collections/users/documents/doc_1" {
+		t.Fatalf("unexpected request paths %v", paths)
+	}
+
+	var dialed string
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = addr
+		return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+	}
+	c, err := NewTenantClient("http://sidecar:9000", "secret", WithDialer(dial))
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	if _, err := c.GetDocument(context.Background(), "users", "doc_1", ""); err != nil {
+		t.Fatalf("GetDocument with dialer: %v", err)
+	}
+	if dialed != "sidecar:9000" {
+		t.Fatalf("expected the dialer to be asked for sidecar:9000, got %q", dialed)
+	}
+
+	for _, endpoint := range []string{"unix://", "http+unix:///api"} {
+		if _, err := NewTenantClient(endpoint, "secret"); err == nil || !strings.Contains(err.Error(), "invalid endpoint") {
+			t.Fatalf("expected %q to be rejected, got %v", endpoint, err)
+		}
+	}
+}
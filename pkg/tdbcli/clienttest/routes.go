@@ -0,0 +1,731 @@
+package clienttest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+
+	if r.Header.Get("X-API-Key") != APIKey {
+		writeError(w, http.StatusUnauthorized, "invalid api key")
+		return
+	}
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api"), "/")
+	parts := strings.Split(path, "/")
+	switch {
+	case parts[0] == "collections":
+		s.serveCollections(w, r, parts[1:])
+	case parts[0] == "queries":
+		s.serveQueries(w, r, parts[1:])
+	case parts[0] == "applications":
+		s.serveApplications(w, r, parts[1:])
+	case parts[0] == "me" && len(parts) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.auth)
+	case parts[0] == "query" && len(parts) == 1 && r.Method == http.MethodPost:
+		s.serveReportQuery(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "route %s not found", r.URL.Path)
+	}
+}
+
+func (s *Server) serveCollections(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		includeDeleted := r.URL.Query().Get("include_deleted") == "true"
+		appID := r.URL.Query().Get("app_id")
+		cols := []clientpkg.Collection{}
+		for _, col := range s.collections {
+			if (col.DeletedAt != nil && !includeDeleted) || col.Name == SavedQueriesCollection {
+				continue
+			}
+			if appID != "" && (col.AppID == nil || *col.AppID != appID) {
+				continue
+			}
+			cols = append(cols, *col)
+		}
+		sortCollections(cols)
+		writeJSON(w, http.StatusOK, cols)
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var req clientpkg.CreateCollectionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+			writeError(w, http.StatusBadRequest, "collection name is required")
+			return
+		}
+		if _, exists := s.collections[req.Name]; exists {
+			writeError(w, http.StatusConflict, "collection %s already exists", req.Name)
+			return
+		}
+		col := clientpkg.Collection{Name: req.Name, SchemaJSON: req.Schema}
+		if appID := strings.TrimSpace(req.AppID); appID != "" {
+			col.AppID = &appID
+		}
+		if req.PrimaryKey != nil {
+			col.PrimaryKeyField = req.PrimaryKey.Field
+			col.PrimaryKeyType = req.PrimaryKey.Type
+			col.PrimaryKeyAuto = req.PrimaryKey.Auto != nil && *req.PrimaryKey.Auto
+		}
+		writeJSON(w, http.StatusCreated, s.addCollection(col))
+	case len(parts) == 1 && parts[0] == "count" && r.Method == http.MethodGet:
+		count := 0
+		for _, col := range s.collections {
+			if col.DeletedAt == nil && col.Name != SavedQueriesCollection {
+				count++
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]int{"count": count})
+	case len(parts) >= 2 && (parts[1] == "documents" || parts[1] == "export"):
+		if _, ok := s.collections[parts[0]]; !ok && parts[0] != SavedQueriesCollection {
+			writeError(w, http.StatusNotFound, "collection %s not found", parts[0])
+			return
+		}
+		if parts[1] == "export" {
+			s.serveExport(w, r, parts[0])
+			return
+		}
+		s.serveDocuments(w, r, parts[0], parts[2:])
+	case len(parts) >= 1:
+		s.serveCollection(w, r, parts)
+	default:
+		writeError(w, http.StatusNotFound, "route %s not found", r.URL.Path)
+	}
+}
+
+func (s *Server) serveCollection(w http.ResponseWriter, r *http.Request, parts []string) {
+	col, ok := s.collections[parts[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "collection %s not found", parts[0])
+		return
+	}
+	switch {
+	case len(parts) == 2 && parts[1] == "restore" && r.Method == http.MethodPost:
+		col.DeletedAt = nil
+		writeJSON(w, http.StatusOK, col)
+	case len(parts) == 2 && parts[1] == "limits" && (r.Method == http.MethodGet || r.Method == http.MethodPut):
+		if r.Method == http.MethodPut {
+			var req clientpkg.UpdateCollectionLimitsRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid JSON body: %v", err)
+				return
+			}
+			limits := clientpkg.CollectionLimits{}
+			if col.Limits != nil {
+				limits = *col.Limits
+			}
+			for _, name := range req.Clear {
+				switch name {
+				case "max_documents":
+					limits.MaxDocuments = nil
+				case "max_storage_bytes":
+					limits.MaxStorageBytes = nil
+				default:
+					writeError(w, http.StatusBadRequest, "unknown limit %s", name)
+					return
+				}
+			}
+			if req.MaxDocuments != nil {
+				limits.MaxDocuments = req.MaxDocuments
+			}
+			if req.MaxStorageBytes != nil {
+				limits.MaxStorageBytes = req.MaxStorageBytes
+			}
+			col.Limits = &limits
+		}
+		resp := clientpkg.CollectionLimitsResponse{Collection: col.Name, DocumentCount: col.DocumentCount, StorageBytes: col.StorageBytes}
+		if col.Limits != nil {
+			resp.Limits = *col.Limits
+		}
+		writeJSON(w, http.StatusOK, resp)
+	case len(parts) >= 2 && parts[1] == "hooks":
+		s.serveHooks(w, r, col.Name, parts[2:])
+	case len(parts) != 1:
+		writeError(w, http.StatusNotFound, "route %s not found", r.URL.Path)
+	case r.Method == http.MethodGet:
+		if col.DeletedAt != nil {
+			writeError(w, http.StatusNotFound, "collection %s not found", col.Name)
+			return
+		}
+		writeJSON(w, http.StatusOK, col)
+	case r.Method == http.MethodPut:
+		var req clientpkg.UpdateCollectionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON body: %v", err)
+			return
+		}
+		if req.Schema != "" {
+			col.SchemaJSON = req.Schema
+		}
+		if req.PrimaryKey != nil {
+			col.PrimaryKeyField = req.PrimaryKey.Field
+			col.PrimaryKeyType = req.PrimaryKey.Type
+			col.PrimaryKeyAuto = req.PrimaryKey.Auto != nil && *req.PrimaryKey.Auto
+		}
+		col.UpdatedAt = s.now()
+		writeJSON(w, http.StatusOK, col)
+	case r.Method == http.MethodDelete:
+		now := s.now()
+		col.DeletedAt = &now
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+	}
+}
+
+func (s *Server) serveDocuments(w http.ResponseWriter, r *http.Request, collection string, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		docs := s.listDocuments(collection, r.URL.Query())
+		items := make([]clientpkg.Document, 0, len(docs))
+		for _, doc := range docs {
+			items = append(items, *doc)
+		}
+		writeJSON(w, http.StatusOK, clientpkg.DocumentListResponse{Items: items, Pagination: clientpkg.DocumentPagination{Count: int64(len(items))}})
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		raw, _ := io.ReadAll(r.Body)
+		doc, err := s.insertDocument(collection, raw)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "%v", err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, doc)
+	case len(parts) == 1 && parts[0] == "bulk" && r.Method == http.MethodPost:
+		var payloads []json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&payloads); err != nil {
+			writeError(w, http.StatusBadRequest, "bulk payload must be a JSON array: %v", err)
+			return
+		}
+		resp := clientpkg.DocumentBulkResponse{Items: []clientpkg.Document{}}
+		for _, raw := range payloads {
+			doc, err := s.insertDocument(collection, raw)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "%v", err)
+				return
+			}
+			resp.Items = append(resp.Items, *doc)
+		}
+		writeJSON(w, http.StatusCreated, resp)
+	case len(parts) == 1 && parts[0] == "count" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]int{"count": len(s.listDocuments(collection, nil))})
+	case len(parts) == 2 && parts[0] == "primary" && r.Method == http.MethodGet:
+		doc := s.findByKey(collection, parts[1])
+		if doc == nil {
+			writeError(w, http.StatusNotFound, "document %s not found", parts[1])
+			return
+		}
+		writeJSON(w, http.StatusOK, doc)
+	case len(parts) == 2 && parts[1] == "purge" && r.Method == http.MethodDelete:
+		if r.URL.Query().Get("confirm") != "true" {
+			writeError(w, http.StatusBadRequest, "purge requires confirm=true")
+			return
+		}
+		docs := s.documents[collection]
+		for i, doc := range docs {
+			if doc.ID == parts[0] {
+				s.documents[collection] = append(docs[:i], docs[i+1:]...)
+				if col, ok := s.collections[collection]; ok {
+					col.DocumentCount--
+					col.StorageBytes -= doc.DataSize
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "document %s not found", parts[0])
+	case len(parts) == 1:
+		s.serveDocument(w, r, collection, parts[0])
+	default:
+		writeError(w, http.StatusNotFound, "route %s not found", r.URL.Path)
+	}
+}
+
+func (s *Server) serveDocument(w http.ResponseWriter, r *http.Request, collection, id string) {
+	doc := s.findByID(collection, id)
+	if doc == nil || (doc.DeletedAt != nil && r.Method != http.MethodGet) {
+		writeError(w, http.StatusNotFound, "document %s not found", id)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, doc)
+	case http.MethodPut, http.MethodPatch:
+		if match := r.Header.Get("If-Match"); match != "" && strings.Trim(match, `"`) != strconv.FormatInt(doc.Version, 10) {
+			writeError(w, http.StatusPreconditionFailed, "document %s is at version %d, not %s", id, doc.Version, match)
+			return
+		}
+		data, err := decodeObject(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "%v", err)
+			return
+		}
+		if r.Method == http.MethodPatch {
+			var current map[string]any
+			_ = json.Unmarshal([]byte(doc.Data), &current)
+			for key, value := range data {
+				current[key] = value
+			}
+			data = current
+		}
+		encoded, _ := json.Marshal(data)
+		doc.Data = string(encoded)
+		doc.DataSize = int64(len(encoded))
+		doc.Version++
+		doc.UpdatedAt = s.now()
+		writeJSON(w, http.StatusOK, doc)
+	case http.MethodDelete:
+		now := s.now()
+		doc.DeletedAt = &now
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+	}
+}
+
+// serveExport streams live documents as NDJSON envelopes with the payload under
+// "data".
+// serveExport streams documents as NDJSON. With a limit, at most that many
+// are written and X-Next-Cursor points at the rest.
+func (s *Server) serveExport(w http.ResponseWriter, r *http.Request, collection string) {
+	docs := s.listDocuments(collection, nil)
+	query := r.URL.Query()
+	start := 0
+	if cursor := query.Get("cursor"); cursor != "" {
+		offset, err := strconv.Atoi(strings.TrimPrefix(cursor, "exp_"))
+		if err != nil || offset < 0 || offset > len(docs) {
+			writeError(w, http.StatusBadRequest, "invalid cursor %q", cursor)
+			return
+		}
+		start = offset
+	}
+	end := len(docs)
+	if limit, _ := strconv.Atoi(query.Get("limit")); limit > 0 && start+limit < end {
+		end = start + limit
+		w.Header().Set("X-Next-Cursor", "exp_"+strconv.Itoa(end))
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	for _, doc := range docs[start:end] {
+		_ = encoder.Encode(map[string]any{
+			"id":         doc.ID,
+			"key":        doc.Key,
+			"version":    doc.Version,
+			"created_at": doc.CreatedAt,
+			"updated_at": doc.UpdatedAt,
+			"data":       json.RawMessage(doc.Data),
+		})
+	}
+}
+
+func (s *Server) serveApplications(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]any{"items": s.applications})
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		var req clientpkg.CreateApplicationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+			writeError(w, http.StatusBadRequest, "application name is required")
+			return
+		}
+		for _, app := range s.applications {
+			if app.Name == req.Name {
+				writeError(w, http.StatusConflict, "application %s already exists", req.Name)
+				return
+			}
+		}
+		now := s.now()
+		app := clientpkg.Application{ID: s.newID("app"), Name: req.Name, Description: req.Description, CreatedAt: now, UpdatedAt: now}
+		s.applications = append(s.applications, app)
+		if req.WithAPIKey {
+			writeJSON(w, http.StatusCreated, clientpkg.CreateApplicationResponse{App: &app, APIKey: "key_" + app.ID, Prefix: app.ID})
+			return
+		}
+		writeJSON(w, http.StatusCreated, app)
+	case len(parts) == 1:
+		idx := -1
+		for i := range s.applications {
+			if s.applications[i].ID == parts[0] {
+				idx = i
+			}
+		}
+		if idx < 0 {
+			writeError(w, http.StatusNotFound, "application %s not found", parts[0])
+			return
+		}
+		app := &s.applications[idx]
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPatch:
+			var req clientpkg.UpdateApplicationRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid application payload")
+				return
+			}
+			if req.Name != nil {
+				app.Name = *req.Name
+			}
+			if req.Description != nil {
+				app.Description = *req.Description
+			}
+			app.UpdatedAt = s.now()
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+			return
+		}
+		writeJSON(w, http.StatusOK, app)
+	default:
+		writeError(w, http.StatusNotFound, "route %s not found", r.URL.Path)
+	}
+}
+
+func (s *Server) serveQueries(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		items := []clientpkg.Document{}
+		for _, doc := range s.listDocuments(SavedQueriesCollection, nil) {
+			items = append(items, *doc)
+		}
+		writeJSON(w, http.StatusOK, clientpkg.SavedQueryListResponse{Items: items})
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		s.upsertQuery(w, r, "", false)
+	case len(parts) >= 2 && parts[0] == "name":
+		doc := s.findQueryByName(parts[1])
+		if len(parts) == 3 && parts[2] == "execute" && r.Method == http.MethodPost {
+			s.executeQuery(w, doc, parts[1])
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			if doc == nil {
+				writeError(w, http.StatusNotFound, "saved query %s not found", parts[1])
+				return
+			}
+			writeJSON(w, http.StatusOK, doc)
+		case http.MethodPut:
+			s.upsertQuery(w, r, parts[1], false)
+		case http.MethodPatch:
+			s.upsertQuery(w, r, parts[1], true)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+		}
+	case len(parts) >= 1:
+		doc := s.findByID(SavedQueriesCollection, parts[0])
+		if doc != nil && doc.DeletedAt != nil {
+			doc = nil
+		}
+		if len(parts) == 2 && parts[1] == "execute" && r.Method == http.MethodPost {
+			s.executeQuery(w, doc, parts[0])
+			return
+		}
+		if doc == nil || r.Method != http.MethodGet {
+			writeError(w, http.StatusNotFound, "saved query %s not found", parts[0])
+			return
+		}
+		writeJSON(w, http.StatusOK, doc)
+	}
+}
+
+// upsertQuery creates or replaces a saved query; with merge set, fields are
+// merged into the existing definition.
+func (s *Server) upsertQuery(w http.ResponseWriter, r *http.Request, name string, merge bool) {
+	data, err := decodeObject(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	if name == "" {
+		name, _ = data["name"].(string)
+	}
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "saved query name is required")
+		return
+	}
+	data["name"] = name
+	existing := s.findQueryByName(name)
+	if existing == nil {
+		if merge {
+			writeError(w, http.StatusNotFound, "saved query %s not found", name)
+			return
+		}
+		encoded, _ := json.Marshal(data)
+		doc, err := s.insertDocument(SavedQueriesCollection, encoded)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "%v", err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, doc)
+		return
+	}
+	if merge {
+		var current map[string]any
+		_ = json.Unmarshal([]byte(existing.Data), &current)
+		for key, value := range data {
+			current[key] = value
+		}
+		data = current
+	}
+	encoded, _ := json.Marshal(data)
+	existing.Data = string(encoded)
+	existing.Version++
+	existing.UpdatedAt = s.now()
+	writeJSON(w, http.StatusOK, existing)
+}
+
+func (s *Server) executeQuery(w http.ResponseWriter, doc *clientpkg.Document, target string) {
+	if doc == nil {
+		writeError(w, http.StatusNotFound, "saved query %s not found", target)
+		return
+	}
+	var query clientpkg.SavedQuery
+	_ = json.Unmarshal([]byte(doc.Data), &query)
+	if rows, ok := s.queryResults[strings.ToLower(query.Name)]; ok {
+		writeJSON(w, http.StatusOK, clientpkg.SavedQueryExecutionResult{Items: rows})
+		return
+	}
+	rows := []map[string]any{}
+	for _, item := range s.listDocuments(query.Collection, nil) {
+		var data map[string]any
+		if err := json.Unmarshal([]byte(item.Data), &data); err == nil {
+			rows = append(rows, data)
+		}
+	}
+	writeJSON(w, http.StatusOK, clientpkg.SavedQueryExecutionResult{Items: rows})
+}
+
+// serveHooks implements the collection hook routes. Test invocations always
+// succeed without running the hook.
+func (s *Server) serveHooks(w http.ResponseWriter, r *http.Request, collection string, parts []string) {
+	hooks := s.hooks[collection]
+	index := -1
+	if len(parts) > 0 {
+		for i, hook := range hooks {
+			if hook.Name == parts[0] {
+				index = i
+			}
+		}
+	}
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, clientpkg.CollectionHookListResponse{Items: append([]clientpkg.CollectionHook{}, hooks...)})
+	case len(parts) == 1 && r.Method == http.MethodPut:
+		var hook clientpkg.CollectionHook
+		if err := json.NewDecoder(r.Body).Decode(&hook); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON body: %v", err)
+			return
+		}
+		now := s.now()
+		hook.Name, hook.Collection, hook.UpdatedAt = parts[0], collection, &now
+		if index == -1 {
+			hook.ID, hook.CreatedAt = s.newID("hook"), &now
+			s.hooks[collection] = append(hooks, hook)
+		} else {
+			hook.ID, hook.CreatedAt = hooks[index].ID, hooks[index].CreatedAt
+			hooks[index] = hook
+		}
+		writeJSON(w, http.StatusOK, hook)
+	case index == -1:
+		writeError(w, http.StatusNotFound, "hook %s not found", strings.Join(parts, "/"))
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		s.hooks[collection] = append(hooks[:index:index], hooks[index+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	case len(parts) == 2 && parts[1] == "test" && r.Method == http.MethodPost:
+		var req clientpkg.CollectionHookTestRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON body: %v", err)
+			return
+		}
+		event := req.Event
+		if event == "" {
+			event = hooks[index].Event
+		}
+		writeJSON(w, http.StatusOK, clientpkg.CollectionHookTestResult{Hook: parts[0], Event: event, Success: true, StatusCode: http.StatusOK})
+	default:
+		writeError(w, http.StatusNotFound, "route %s not found", r.URL.Path)
+	}
+}
+
+// serveReportQuery runs a report query over the live documents of a
+// collection. It supports groupBy with count, sum, avg, min, and max
+// aggregates, select, offset, limit, and "q_<offset>" cursors; explain
+// reports a single collection scan.
+func (s *Server) serveReportQuery(w http.ResponseWriter, r *http.Request) {
+	body, err := decodeObject(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	collection, _ := body["collection"].(string)
+	if _, ok := s.collections[collection]; !ok {
+		writeError(w, http.StatusNotFound, "collection %s not found", collection)
+		return
+	}
+	var rows []map[string]any
+	for _, doc := range s.documents[collection] {
+		if doc.DeletedAt != nil {
+			continue
+		}
+		var data map[string]any
+		_ = json.Unmarshal([]byte(doc.Data), &data)
+		rows = append(rows, data)
+	}
+	scanned := len(rows)
+	groupBy := stringList(body["groupBy"])
+	if specs, _ := body["aggregate"].([]any); len(groupBy) > 0 || len(specs) > 0 {
+		rows = aggregateRows(rows, groupBy, specs)
+	}
+	if fields := stringList(body["select"]); len(fields) > 0 {
+		for i, row := range rows {
+			projected := make(map[string]any, len(fields))
+			for _, field := range fields {
+				if value, ok := row[field]; ok {
+					projected[field] = value
+				}
+			}
+			rows[i] = projected
+		}
+	}
+
+	offset := intValue(body["offset"])
+	if cursor, _ := body["cursor"].(string); cursor != "" {
+		n, err := strconv.Atoi(strings.TrimPrefix(cursor, "q_"))
+		if !strings.HasPrefix(cursor, "q_") || err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid cursor %q", cursor)
+			return
+		}
+		offset = n
+	}
+	limit := intValue(body["limit"])
+	resp := clientpkg.ReportQueryResponse{
+		Data:       []map[string]any{},
+		Pagination: clientpkg.ReportQueryPagination{Limit: limit, Offset: offset, Total: int64(len(rows))},
+	}
+	if offset < len(rows) {
+		page := rows[offset:]
+		if limit > 0 && limit < len(page) {
+			page = page[:limit]
+			resp.Pagination.NextCursor = "q_" + strconv.Itoa(offset+limit)
+		}
+		resp.Data = page
+	}
+	if explain, _ := body["explain"].(bool); explain {
+		resp.Explain = &clientpkg.QueryExplain{Plan: clientpkg.QueryPlanNode{
+			Operation:    "collection_scan",
+			Detail:       collection,
+			RowsScanned:  int64(scanned),
+			RowsReturned: int64(len(resp.Data)),
+		}}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// aggregateRows groups rows by the groupBy fields, in order of first
+// appearance, and computes one column per aggregate spec.
+func aggregateRows(rows []map[string]any, groupBy []string, specs []any) []map[string]any {
+	var order []string
+	groups := map[string][]map[string]any{}
+	if len(groupBy) == 0 {
+		order = []string{""}
+		groups[""] = rows
+	} else {
+		for _, row := range rows {
+			parts := make([]string, len(groupBy))
+			for i, field := range groupBy {
+				parts[i] = stringValue(row[field])
+			}
+			key := strings.Join(parts, "\x00")
+			if _, ok := groups[key]; !ok {
+				order = append(order, key)
+			}
+			groups[key] = append(groups[key], row)
+		}
+	}
+	out := make([]map[string]any, 0, len(order))
+	for _, key := range order {
+		members := groups[key]
+		result := map[string]any{}
+		for _, field := range groupBy {
+			result[field] = members[0][field]
+		}
+		for _, raw := range specs {
+			spec, _ := raw.(map[string]any)
+			field, _ := spec["field"].(string)
+			op, _ := spec["operation"].(string)
+			alias, _ := spec["alias"].(string)
+			if alias == "" {
+				alias = strings.Trim(op+"_"+field, "_")
+			}
+			distinct, _ := spec["distinct"].(bool)
+			result[alias] = aggregateValue(op, field, distinct, members)
+		}
+		out = append(out, result)
+	}
+	return out
+}
+
+func aggregateValue(op, field string, distinct bool, rows []map[string]any) any {
+	var numbers []float64
+	seen := map[string]bool{}
+	count := 0
+	for _, row := range rows {
+		value, ok := row[field]
+		if field != "" && (!ok || value == nil) {
+			continue
+		}
+		if distinct {
+			key := stringValue(value)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		count++
+		if n, ok := value.(float64); ok {
+			numbers = append(numbers, n)
+		}
+	}
+	if op == "count" {
+		return count
+	}
+	if len(numbers) == 0 {
+		return nil
+	}
+	result := numbers[0]
+	for _, n := range numbers[1:] {
+		switch op {
+		case "sum", "avg":
+			result += n
+		case "min":
+			result = min(result, n)
+		case "max":
+			result = max(result, n)
+		}
+	}
+	if op == "avg" {
+		result /= float64(len(numbers))
+	}
+	return result
+}
+
+func stringList(value any) []string {
+	items, _ := value.([]any)
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if text, ok := item.(string); ok && text != "" {
+			out = append(out, text)
+		}
+	}
+	return out
+}
+
+func intValue(value any) int {
+	n, _ := value.(float64)
+	return int(n)
+}
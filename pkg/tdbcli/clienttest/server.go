@@ -0,0 +1,407 @@
+// Package clienttest provides an in-memory fake TinyDB server for tests.
+//
+// The server implements the REST routes used by the client package for
+// applications, collections, documents, saved queries, report queries, and
+// the key status, so code built on
+// the client (or the sdk package) can be exercised without a real backend:
+//
+//	srv := clienttest.NewServer(t)
+//	srv.AddCollection(client.Collection{Name: "users", PrimaryKeyField: "email"})
+//	srv.AddDocument("users", map[string]any{"email": "ada@example.com"})
+//	tenant := srv.TenantClient(t)
+package clienttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// SavedQueriesCollection is the collection saved queries are stored in.
+const SavedQueriesCollection = "saved_queries"
+
+// APIKey is the key accepted by servers created with NewServer.
+const APIKey = "test-api-key"
+
+// Server is an in-memory TinyDB server backed by httptest.
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	collections  map[string]*clientpkg.Collection
+	documents    map[string][]*clientpkg.Document
+	queryResults map[string][]map[string]any
+	hooks        map[string][]clientpkg.CollectionHook
+	applications []clientpkg.Application
+	auth         clientpkg.AuthStatus
+	requests     []string
+	nextID       int
+	now          func() time.Time
+}
+
+// NewServer starts a fake server that is closed when the test finishes.
+func NewServer(tb testing.TB) *Server {
+	tb.Helper()
+	s := &Server{
+		collections:  map[string]*clientpkg.Collection{},
+		documents:    map[string][]*clientpkg.Document{},
+		queryResults: map[string][]map[string]any{},
+		hooks:        map[string][]clientpkg.CollectionHook{},
+		auth:         clientpkg.AuthStatus{TenantID: "tn_test", TenantName: "Test Tenant", Status: "active", Scope: "tenant"},
+		now:          func() time.Time { return time.Now().UTC() },
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	tb.Cleanup(s.Close)
+	return s
+}
+
+// TenantClient returns a client configured for the server.
+func (s *Server) TenantClient(tb testing.TB) *clientpkg.TenantClient {
+	tb.Helper()
+	c, err := clientpkg.NewTenantClient(s.URL, APIKey, clientpkg.WithRetries(0, 0))
+	if err != nil {
+		tb.Fatalf("NewTenantClient: %v", err)
+	}
+	return c
+}
+
+// SetClock overrides the time source used for timestamps.
+func (s *Server) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// SetAuthStatus sets the answer to GET /api/me. By default the key belongs
+// to tenant tn_test and is not scoped to an application.
+func (s *Server) SetAuthStatus(status clientpkg.AuthStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.auth = status
+}
+
+// AddCollection registers a collection. Missing IDs and timestamps are filled in.
+func (s *Server) AddCollection(col clientpkg.Collection) clientpkg.Collection {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.addCollection(col)
+}
+
+// AddApplication registers an application. A missing ID is filled in.
+func (s *Server) AddApplication(app clientpkg.Application) clientpkg.Application {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if app.ID == "" {
+		s.nextID++
+		app.ID = fmt.Sprintf("app_%d", s.nextID)
+	}
+	if app.CreatedAt.IsZero() {
+		app.CreatedAt = s.now()
+		app.UpdatedAt = app.CreatedAt
+	}
+	s.applications = append(s.applications, app)
+	return app
+}
+
+// AddDocument stores a document whose payload is data (raw JSON or any
+// JSON-encodable value), creating the collection when needed.
+func (s *Server) AddDocument(collection string, data any) clientpkg.Document {
+	var raw []byte
+	switch typed := data.(type) {
+	case string:
+		raw = []byte(typed)
+	case []byte:
+		raw = typed
+	default:
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			panic(fmt.Sprintf("clienttest: encode document: %v", err))
+		}
+		raw = encoded
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	doc, err := s.insertDocument(collection, raw)
+	if err != nil {
+		panic(fmt.Sprintf("clienttest: %v", err))
+	}
+	return *doc
+}
+
+// AddSavedQuery stores a saved query definition.
+func (s *Server) AddSavedQuery(query clientpkg.SavedQuery) clientpkg.Document {
+	query.Name = strings.ToLower(strings.TrimSpace(query.Name))
+	return s.AddDocument(SavedQueriesCollection, query)
+}
+
+// SetQueryResult fixes the rows returned when the named saved query executes.
+// Without a fixed result, executing a query returns the live documents of its
+// collection.
+func (s *Server) SetQueryResult(name string, rows []map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queryResults[strings.ToLower(strings.TrimSpace(name))] = rows
+}
+
+// Documents returns a copy of the documents in collection, including
+// soft-deleted ones.
+func (s *Server) Documents(collection string) []clientpkg.Document {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	docs := make([]clientpkg.Document, 0, len(s.documents[collection]))
+	for _, doc := range s.documents[collection] {
+		docs = append(docs, *doc)
+	}
+	return docs
+}
+
+// Hooks returns the hooks configured on a collection.
+func (s *Server) Hooks(collection string) []clientpkg.CollectionHook {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]clientpkg.CollectionHook(nil), s.hooks[collection]...)
+}
+
+// Requests returns the "METHOD /path" lines of every request served so far.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s_%d", prefix, s.nextID)
+}
+
+func (s *Server) addCollection(col clientpkg.Collection) *clientpkg.Collection {
+	if existing, ok := s.collections[col.Name]; ok {
+		return existing
+	}
+	now := s.now()
+	if col.ID == "" {
+		col.ID = s.newID("col")
+	}
+	if col.PrimaryKeyField == "" {
+		col.PrimaryKeyField = "id"
+	}
+	if col.PrimaryKeyType == "" {
+		col.PrimaryKeyType = "string"
+	}
+	if col.CreatedAt.IsZero() {
+		col.CreatedAt = now
+	}
+	if col.UpdatedAt.IsZero() {
+		col.UpdatedAt = col.CreatedAt
+	}
+	stored := col
+	s.collections[col.Name] = &stored
+	return &stored
+}
+
+func (s *Server) insertDocument(collection string, raw []byte) (*clientpkg.Document, error) {
+	col := s.addCollection(clientpkg.Collection{Name: collection})
+	var data map[string]any
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("document must be a JSON object: %w", err)
+	}
+	if col.Limits != nil && col.Limits.MaxDocuments != nil && col.DocumentCount >= *col.Limits.MaxDocuments {
+		return nil, fmt.Errorf("collection %s has reached its limit of %d documents", collection, *col.Limits.MaxDocuments)
+	}
+	now := s.now()
+	doc := &clientpkg.Document{
+		ID:           s.newID("doc"),
+		TenantID:     "tenant_test",
+		CollectionID: col.ID,
+		Version:      1,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	doc.Key = stringValue(data[col.PrimaryKeyField])
+	if doc.Key == "" {
+		doc.Key = doc.ID
+	}
+	if existing := s.findByKey(collection, doc.Key); existing != nil {
+		return nil, fmt.Errorf("document with key %s already exists", doc.Key)
+	}
+	encoded, _ := json.Marshal(data)
+	doc.Data = string(encoded)
+	doc.DataSize = int64(len(encoded))
+	s.documents[collection] = append(s.documents[collection], doc)
+	col.DocumentCount++
+	col.StorageBytes += doc.DataSize
+	return doc, nil
+}
+
+func (s *Server) findByID(collection, id string) *clientpkg.Document {
+	for _, doc := range s.documents[collection] {
+		if doc.ID == id {
+			return doc
+		}
+	}
+	return nil
+}
+
+func (s *Server) findByKey(collection, key string) *clientpkg.Document {
+	for _, doc := range s.documents[collection] {
+		if doc.Key == key && doc.DeletedAt == nil {
+			return doc
+		}
+	}
+	return nil
+}
+
+func (s *Server) findQueryByName(name string) *clientpkg.Document {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, doc := range s.documents[SavedQueriesCollection] {
+		if doc.DeletedAt != nil {
+			continue
+		}
+		var query clientpkg.SavedQuery
+		if err := json.Unmarshal([]byte(doc.Data), &query); err == nil && strings.ToLower(query.Name) == name {
+			return doc
+		}
+	}
+	return nil
+}
+
+func stringValue(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	default:
+		encoded, _ := json.Marshal(typed)
+		return string(encoded)
+	}
+}
+
+func timeParam(values []string) time.Time {
+	ts, _ := time.Parse(time.RFC3339, first(values))
+	return ts
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	writeJSON(w, status, map[string]string{"error": fmt.Sprintf(format, args...)})
+}
+
+func decodeObject(r *http.Request) (map[string]any, error) {
+	var data map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("invalid JSON body: %w", err)
+	}
+	return data, nil
+}
+
+// listDocuments applies the include_deleted, f.<field>, created_since,
+// created_before, updated_since, sort, offset, and limit query parameters.
+func (s *Server) listDocuments(collection string, query map[string][]string) []*clientpkg.Document {
+	includeDeleted := first(query["include_deleted"]) == "true"
+	createdSince := timeParam(query["created_since"])
+	createdBefore := timeParam(query["created_before"])
+	updatedSince := timeParam(query["updated_since"])
+	filters := map[string]string{}
+	for name, values := range query {
+		if field, ok := strings.CutPrefix(name, "f."); ok {
+			filters[field] = first(values)
+		}
+	}
+	var docs []*clientpkg.Document
+	for _, doc := range s.documents[collection] {
+		if doc.DeletedAt != nil && !includeDeleted {
+			continue
+		}
+		if (!createdSince.IsZero() && doc.CreatedAt.Before(createdSince)) ||
+			(!createdBefore.IsZero() && !doc.CreatedAt.Before(createdBefore)) ||
+			(!updatedSince.IsZero() && doc.UpdatedAt.Before(updatedSince)) {
+			continue
+		}
+		if len(filters) > 0 {
+			var data map[string]any
+			_ = json.Unmarshal([]byte(doc.Data), &data)
+			match := true
+			for field, want := range filters {
+				if stringValue(data[field]) != want {
+					match = false
+					break
+				}
+			}
+			if !match {
+				continue
+			}
+		}
+		docs = append(docs, doc)
+	}
+	if sortSpec := first(query["sort"]); sortSpec != "" {
+		fields := strings.Split(sortSpec, ",")
+		sort.SliceStable(docs, func(i, j int) bool {
+			for _, field := range fields {
+				desc := strings.HasPrefix(field, "-")
+				field = strings.TrimPrefix(field, "-")
+				a, b := sortValue(docs[i], field), sortValue(docs[j], field)
+				if a == b {
+					continue
+				}
+				if desc {
+					return a > b
+				}
+				return a < b
+			}
+			return false
+		})
+	}
+	offset, _ := strconv.Atoi(first(query["offset"]))
+	if offset > len(docs) {
+		offset = len(docs)
+	}
+	docs = docs[offset:]
+	if limit, _ := strconv.Atoi(first(query["limit"])); limit > 0 && limit < len(docs) {
+		docs = docs[:limit]
+	}
+	return docs
+}
+
+func sortValue(doc *clientpkg.Document, field string) string {
+	switch field {
+	case "id":
+		return doc.ID
+	case "key":
+		return doc.Key
+	case "created_at":
+		return doc.CreatedAt.Format(time.RFC3339Nano)
+	case "updated_at":
+		return doc.UpdatedAt.Format(time.RFC3339Nano)
+	}
+	var data map[string]any
+	_ = json.Unmarshal([]byte(doc.Data), &data)
+	return stringValue(data[strings.TrimPrefix(field, "data.")])
+}
+
+func sortCollections(cols []clientpkg.Collection) {
+	sort.Slice(cols, func(i, j int) bool { return cols[i].Name < cols[j].Name })
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
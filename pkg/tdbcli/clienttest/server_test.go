@@ -0,0 +1,150 @@
+package clienttest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+func TestServerDocumentLifecycle(t *testing.T) {
+	srv := NewServer(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users", PrimaryKeyField: "email"})
+	c := srv.TenantClient(t)
+	ctx := context.Background()
+
+	created, err := c.CreateDocument(ctx, "users", []byte(`{"email":"ada@example.com","name":"Ada"}`), "")
+	if err != nil {
+		t.Fatalf("CreateDocument: %v", err)
+	}
+	if created.Key != "ada@example.com" {
+		t.Fatalf("expected primary key to be derived, got %q", created.Key)
+	}
+	if _, err := c.CreateDocument(ctx, "users", []byte(`{"email":"ada@example.com"}`), ""); err == nil {
+		t.Fatalf("expected duplicate key error")
+	}
+	patched, err := c.PatchDocument(ctx, "users", created.ID, []byte(`{"role":"admin"}`), "")
+	if err != nil {
+		t.Fatalf("PatchDocument: %v", err)
+	}
+	if !strings.Contains(patched.Data, `"name":"Ada"`) || !strings.Contains(patched.Data, `"role":"admin"`) || patched.Version != 2 {
+		t.Fatalf("unexpected patched document %+v", patched)
+	}
+	byKey, err := c.GetDocumentByPrimaryKey(ctx, "users", "ada@example.com", "")
+	if err != nil || byKey.ID != created.ID {
+		t.Fatalf("GetDocumentByPrimaryKey: %+v (%v)", byKey, err)
+	}
+
+	if err := c.DeleteDocument(ctx, "users", created.ID, ""); err != nil {
+		t.Fatalf("DeleteDocument: %v", err)
+	}
+	if count, err := c.CountDocuments(ctx, "users", ""); err != nil || count != 0 {
+		t.Fatalf("expected no live documents, got %d (%v)", count, err)
+	}
+	resp, err := c.ListDocuments(ctx, "users", clientpkg.ListDocumentsParams{IncludeDeleted: true})
+	if err != nil || len(resp.Items) != 1 || resp.Items[0].DeletedAt == nil {
+		t.Fatalf("expected soft-deleted document in listing, got %+v (%v)", resp, err)
+	}
+	if err := c.PurgeDocument(ctx, "users", created.ID, true, ""); err != nil {
+		t.Fatalf("PurgeDocument: %v", err)
+	}
+	if docs := srv.Documents("users"); len(docs) != 0 {
+		t.Fatalf("expected purged document to be gone, got %+v", docs)
+	}
+}
+
+func TestServerListFiltersSortAndPaging(t *testing.T) {
+	srv := NewServer(t)
+	for _, name := range []string{"carol", "alice", "bob"} {
+		srv.AddDocument("people", map[string]any{"name": name, "team": "a"})
+	}
+	srv.AddDocument("people", map[string]any{"name": "dave", "team": "b"})
+	c := srv.TenantClient(t)
+
+	resp, err := c.ListDocuments(context.Background(), "people", clientpkg.ListDocumentsParams{
+		Filters: map[string]string{"team": "a"},
+		Sort:    []string{"name"},
+		Offset:  1,
+		Limit:   1,
+	})
+	if err != nil {
+		t.Fatalf("ListDocuments: %v", err)
+	}
+	if len(resp.Items) != 1 || !strings.Contains(resp.Items[0].Data, `"bob"`) {
+		t.Fatalf("unexpected page %+v", resp.Items)
+	}
+
+	var names []string
+	it := c.DocumentsIterator(context.Background(), "people", clientpkg.ListDocumentsParams{})
+	for it.Next() {
+		names = append(names, it.Document().Data)
+	}
+	if it.Err() != nil || len(names) != 4 {
+		t.Fatalf("expected streamed full scan of 4 documents, got %v (%v)", names, it.Err())
+	}
+	exports := len(srv.Requests())
+	it = c.DocumentsIterator(context.Background(), "people", clientpkg.ListDocumentsParams{Limit: 3})
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if it.Err() != nil || count != 4 || len(srv.Requests())-exports != 2 {
+		t.Fatalf("expected 4 documents over 2 export pages, got %d (%v) in %v", count, it.Err(), srv.Requests()[exports:])
+	}
+}
+
+func TestServerCollectionsAndQueries(t *testing.T) {
+	srv := NewServer(t)
+	c := srv.TenantClient(t)
+	ctx := context.Background()
+
+	if _, err := c.CreateCollection(ctx, clientpkg.CreateCollectionRequest{Name: "orders", Schema: `{"type":"object"}`}); err != nil {
+		t.Fatalf("CreateCollection: %v", err)
+	}
+	srv.AddDocument("orders", map[string]any{"id": "o1", "total": 10})
+	if _, err := c.PutSavedQuery(ctx, "All-Orders", []byte(`{"type":"dsl","collection":"orders"}`), ""); err != nil {
+		t.Fatalf("PutSavedQuery: %v", err)
+	}
+	cols, err := c.ListCollections(ctx, "")
+	if err != nil || len(cols) != 1 || cols[0].Name != "orders" {
+		t.Fatalf("expected saved queries collection to be hidden, got %+v (%v)", cols, err)
+	}
+	result, err := c.ExecuteSavedQueryByName(ctx, "all-orders", nil, "")
+	if err != nil || len(result.Items) != 1 || result.Items[0]["id"] != "o1" {
+		t.Fatalf("unexpected execution result %+v (%v)", result, err)
+	}
+	srv.SetQueryResult("all-orders", []map[string]any{{"total": 42}})
+	result, err = c.ExecuteSavedQueryByName(ctx, "all-orders", nil, "")
+	if err != nil || len(result.Items) != 1 || result.Items[0]["total"] != float64(42) {
+		t.Fatalf("expected fixed result, got %+v (%v)", result, err)
+	}
+	if err := c.DeleteSavedQueryByName(ctx, "all-orders", false, "", false); err != nil {
+		t.Fatalf("DeleteSavedQueryByName: %v", err)
+	}
+	if _, err := c.GetSavedQueryByName(ctx, "all-orders", ""); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found after delete, got %v", err)
+	}
+
+	if err := c.DeleteCollection(ctx, "orders", ""); err != nil {
+		t.Fatalf("DeleteCollection: %v", err)
+	}
+	if _, err := c.GetCollection(ctx, "orders", ""); err == nil {
+		t.Fatalf("expected deleted collection to be hidden")
+	}
+	if _, err := c.RestoreCollection(ctx, "orders", ""); err != nil {
+		t.Fatalf("RestoreCollection: %v", err)
+	}
+}
+
+func TestServerRejectsUnknownKey(t *testing.T) {
+	srv := NewServer(t)
+	c, err := clientpkg.NewTenantClient(srv.URL, "wrong")
+	if err != nil {
+		t.Fatalf("NewTenantClient: %v", err)
+	}
+	_, err = c.ListCollections(context.Background(), "")
+	if err == nil || !strings.Contains(err.Error(), "invalid api key") {
+		t.Fatalf("expected auth error, got %v", err)
+	}
+}
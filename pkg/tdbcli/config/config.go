@@ -16,14 +16,34 @@ type Config struct {
 	Endpoint      string                  `yaml:"endpoint"`
 	AdminSecret   string                  `yaml:"admin_secret"`
 	DefaultTenant string                  `yaml:"default_tenant,omitempty"`
+	TimeFormat    string                  `yaml:"time_format,omitempty"`
+	Timezone      string                  `yaml:"timezone,omitempty"`
+	Language      string                  `yaml:"language,omitempty"`
+	SafeMode      bool                    `yaml:"safe_mode,omitempty"`
+	ReadOnly      bool                    `yaml:"read_only,omitempty"`
+	WarnLatency   string                  `yaml:"warn_latency,omitempty"`
+	WarnSize      string                  `yaml:"warn_size,omitempty"`
+	Hooks         map[string]string       `yaml:"hooks,omitempty"`
+	ExtraHeaders  map[string]string       `yaml:"extra_headers,omitempty"`
 	Tenants       map[string]TenantConfig `yaml:"tenants,omitempty"`
 }
 
 // TenantConfig stores API credentials cached for a tenant.
 type TenantConfig struct {
-	Name       string                 `yaml:"name,omitempty"`
-	DefaultKey string                 `yaml:"default_key,omitempty"`
-	Keys       map[string]APIKeyEntry `yaml:"keys,omitempty"`
+	Name       string                   `yaml:"name,omitempty"`
+	DefaultKey string                   `yaml:"default_key,omitempty"`
+	Keys       map[string]APIKeyEntry   `yaml:"keys,omitempty"`
+	Favorites  map[string]QueryFavorite `yaml:"query_favorites,omitempty"`
+	// ReadOnly refuses API calls that could modify this tenant's data.
+	ReadOnly bool `yaml:"read_only,omitempty"`
+}
+
+// QueryFavorite is a local shortcut to a saved query, executed with
+// `tdb q run @alias`.
+type QueryFavorite struct {
+	Query  string `yaml:"query"`
+	AppID  string `yaml:"app_id,omitempty"`
+	Params string `yaml:"params,omitempty"`
 }
 
 // APIKeyEntry stores a named API key for either tenant- or app-scoped access.
@@ -145,11 +165,28 @@ func (c *Config) ResolveKey(tenantID, keyName string) (APIKeyEntry, error) {
 
 // MaskedAdminSecret returns a masked representation for display.
 func (c *Config) MaskedAdminSecret() string {
-	if c.AdminSecret == "" {
+	return maskSecret(c.AdminSecret)
+}
+
+// MaskedExtraHeaders returns extra_headers with the values masked, since they
+// usually carry gateway tokens.
+func (c *Config) MaskedExtraHeaders() map[string]string {
+	if len(c.ExtraHeaders) == 0 {
+		return nil
+	}
+	masked := make(map[string]string, len(c.ExtraHeaders))
+	for name, value := range c.ExtraHeaders {
+		masked[name] = maskSecret(value)
+	}
+	return masked
+}
+
+func maskSecret(secret string) string {
+	if secret == "" {
 		return ""
 	}
-	if len(c.AdminSecret) <= 6 {
-		return strings.Repeat("*", len(c.AdminSecret))
+	if len(secret) <= 6 {
+		return strings.Repeat("*", len(secret))
 	}
-	return c.AdminSecret[:3] + strings.Repeat("*", len(c.AdminSecret)-6) + c.AdminSecret[len(c.AdminSecret)-3:]
+	return secret[:3] + strings.Repeat("*", len(secret)-6) + secret[len(secret)-3:]
 }
@@ -0,0 +1,239 @@
+// Package diff compares JSON documents field by field and renders the
+// differences as aligned, optionally colored text or as JSON.
+//
+// Nested objects are compared per field and reported under dotted paths such
+// as "address.city". Arrays, scalars, and empty objects are compared as whole
+// values, so a changed array is one change at its own path.
+//
+//	changes, err := diff.JSON(before, after)
+//	if err != nil { ... }
+//	diff.Write(os.Stdout, changes, diff.Options{Color: true, Indent: "  "})
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Op describes how a field changed.
+type Op string
+
+const (
+	// Added marks a field that only exists in the new document.
+	Added Op = "added"
+	// Removed marks a field that only exists in the old document.
+	Removed Op = "removed"
+	// Changed marks a field whose value differs between the documents.
+	Changed Op = "changed"
+)
+
+// RootPath is the path reported when the documents themselves are not
+// objects and differ as a whole.
+const RootPath = "$"
+
+// Change is one difference between two documents.
+type Change struct {
+	Path string `json:"path"`
+	Op   Op     `json:"op"`
+	Old  any    `json:"old"`
+	New  any    `json:"new"`
+}
+
+// Summary counts the changes of a diff, e.g. for a JSON report.
+type Summary struct {
+	Added   int `json:"added"`
+	Removed int `json:"removed"`
+	Changed int `json:"changed"`
+}
+
+// JSON decodes two JSON documents and compares them. Empty or whitespace-only
+// input stands for a missing document, so every field of the other one is
+// reported as added or removed. Numbers keep their original text.
+func JSON(before, after []byte) ([]Change, error) {
+	old, err := decode(before)
+	if err != nil {
+		return nil, fmt.Errorf("decode old document: %w", err)
+	}
+	updated, err := decode(after)
+	if err != nil {
+		return nil, fmt.Errorf("decode new document: %w", err)
+	}
+	return Values(old, updated), nil
+}
+
+// Values compares two decoded JSON values, as produced by encoding/json. A nil
+// value stands for a missing document. Changes are sorted by path.
+func Values(before, after any) []Change {
+	old := map[string]any{}
+	updated := map[string]any{}
+	flatten(old, "", before)
+	flatten(updated, "", after)
+
+	paths := make([]string, 0, len(old)+len(updated))
+	for path := range old {
+		paths = append(paths, path)
+	}
+	for path := range updated {
+		if _, ok := old[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var changes []Change
+	for _, path := range paths {
+		oldValue, hadOld := old[path]
+		newValue, hasNew := updated[path]
+		switch {
+		case !hadOld:
+			changes = append(changes, Change{Path: path, Op: Added, New: newValue})
+		case !hasNew:
+			changes = append(changes, Change{Path: path, Op: Removed, Old: oldValue})
+		case !equal(oldValue, newValue):
+			changes = append(changes, Change{Path: path, Op: Changed, Old: oldValue, New: newValue})
+		}
+	}
+	return changes
+}
+
+// Summarize counts changes by operation.
+func Summarize(changes []Change) Summary {
+	var summary Summary
+	for _, change := range changes {
+		switch change.Op {
+		case Added:
+			summary.Added++
+		case Removed:
+			summary.Removed++
+		default:
+			summary.Changed++
+		}
+	}
+	return summary
+}
+
+// String renders the summary as e.g. "1 added, 2 changed", or "no changes".
+func (s Summary) String() string {
+	var parts []string
+	for _, part := range []struct {
+		count int
+		label string
+	}{{s.Added, "added"}, {s.Removed, "removed"}, {s.Changed, "changed"}} {
+		if part.count > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", part.count, part.label))
+		}
+	}
+	if len(parts) == 0 {
+		return "no changes"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Options controls how Write renders a diff.
+type Options struct {
+	// Color wraps each line in ANSI colors: green for added, red for removed,
+	// and yellow for changed fields.
+	Color bool
+	// Indent is written at the start of every line.
+	Indent string
+	// MaxValueWidth truncates rendered values longer than this many
+	// characters. Zero means no limit.
+	MaxValueWidth int
+}
+
+// Write renders one line per change, marked with +, -, or ~ and with the
+// values aligned after the longest path:
+//
+//	~ status      "pending" → "shipped"
+//	+ shipped_at  "2025-01-15"
+//	- note        "call first"
+func Write(w io.Writer, changes []Change, opts Options) error {
+	width := 0
+	for _, change := range changes {
+		width = max(width, utf8.RuneCountInString(change.Path))
+	}
+	for _, change := range changes {
+		marker, color := "~", "33"
+		value := formatValue(change.Old, opts.MaxValueWidth) + " → " + formatValue(change.New, opts.MaxValueWidth)
+		switch change.Op {
+		case Added:
+			marker, color = "+", "32"
+			value = formatValue(change.New, opts.MaxValueWidth)
+		case Removed:
+			marker, color = "-", "31"
+			value = formatValue(change.Old, opts.MaxValueWidth)
+		}
+		padding := strings.Repeat(" ", width-utf8.RuneCountInString(change.Path))
+		line := marker + " " + change.Path + padding + "  " + value
+		if opts.Color {
+			line = "\x1b[" + color + "m" + line + "\x1b[0m"
+		}
+		if _, err := fmt.Fprintln(w, opts.Indent+line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decode(raw []byte) (any, error) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// flatten writes the fields of value into out under dotted paths. Non-empty
+// objects are descended into; everything else is kept as a value. A nil
+// document contributes nothing.
+func flatten(out map[string]any, prefix string, value any) {
+	obj, ok := value.(map[string]any)
+	if !ok || (len(obj) == 0 && prefix != "") {
+		if prefix == "" {
+			if value != nil {
+				out[RootPath] = value
+			}
+			return
+		}
+		out[prefix] = value
+		return
+	}
+	for key, nested := range obj {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		flatten(out, path, nested)
+	}
+}
+
+func equal(a, b any) bool {
+	left, errA := json.Marshal(a)
+	right, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(left, right)
+}
+
+func formatValue(value any, maxWidth int) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	text := fmt.Sprint(value)
+	if err := enc.Encode(value); err == nil {
+		text = strings.TrimSuffix(buf.String(), "\n")
+	}
+	if maxWidth > 0 && utf8.RuneCountInString(text) > maxWidth {
+		runes := []rune(text)
+		text = string(runes[:max(maxWidth-1, 1)]) + "…"
+	}
+	return text
+}
@@ -0,0 +1,88 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	changes, err := JSON(
+		[]byte(`{"status":"pending","address":{"city":"Phnom Penh","zip":"12000"},"tags":["a"],"note":"x","total":10.50}`),
+		[]byte(`{"status":"active","address":{"city":"Phnom Penh"},"tags":["a","b"],"owner":"ops","total":10.50}`),
+	)
+	if err != nil {
+		t.Fatalf("JSON returned error: %v", err)
+	}
+	var got []string
+	for _, c := range changes {
+		got = append(got, string(c.Op)+" "+c.Path)
+	}
+	want := "removed address.zip,removed note,added owner,changed status,changed tags"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("unexpected changes %v", got)
+	}
+	if summary := Summarize(changes).String(); summary != "1 added, 2 removed, 2 changed" {
+		t.Fatalf("unexpected summary %q", summary)
+	}
+
+	encoded, err := json.Marshal(changes[3])
+	if err != nil || string(encoded) != `{"path":"status","op":"changed","old":"pending","new":"active"}` {
+		t.Fatalf("unexpected JSON change %s (%v)", encoded, err)
+	}
+}
+
+func TestJSONKeepsNullValues(t *testing.T) {
+	changes, err := JSON([]byte(`{"owner":"ops"}`), []byte(`{"owner":null}`))
+	if err != nil || len(changes) != 1 {
+		t.Fatalf("expected one change, got %+v (%v)", changes, err)
+	}
+	encoded, err := json.Marshal(changes[0])
+	if err != nil || string(encoded) != `{"path":"owner","op":"changed","old":"ops","new":null}` {
+		t.Fatalf("unexpected JSON change %s (%v)", encoded, err)
+	}
+}
+
+func TestJSONMissingAndScalarDocuments(t *testing.T) {
+	changes, err := JSON(nil, []byte(`{"name":"Ann","meta":{}}`))
+	if err != nil || len(changes) != 2 || changes[0].Op != Added || changes[0].Path != "meta" {
+		t.Fatalf("expected every field to be added, got %+v (%v)", changes, err)
+	}
+	changes, err = JSON([]byte(`1`), []byte(`2`))
+	if err != nil || len(changes) != 1 || changes[0].Path != RootPath || changes[0].Op != Changed {
+		t.Fatalf("expected a root change, got %+v (%v)", changes, err)
+	}
+	if _, err := JSON([]byte(`{`), nil); err == nil || !strings.Contains(err.Error(), "decode old document") {
+		t.Fatalf("expected a decode error, got %v", err)
+	}
+	if changes, _ := JSON([]byte(`{"a":1}`), []byte(` {"a": 1} `)); len(changes) != 0 {
+		t.Fatalf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	changes := Values(
+		map[string]any{"status": "pending", "note": "<call first>"},
+		map[string]any{"status": "shipped", "shipped_at": "2025-01-15"},
+	)
+	var out bytes.Buffer
+	if err := Write(&out, changes, Options{Indent: "  "}); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	want := `  - note        "<call first>"
+  + shipped_at  "2025-01-15"
+  ~ status      "pending" → "shipped"
+`
+	if out.String() != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	if err := Write(&out, changes[:1], Options{Color: true, MaxValueWidth: 6}); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if got := out.String(); got != "\x1b[31m- note  \"<cal…\x1b[0m\n" {
+		t.Fatalf("unexpected colored output %q", got)
+	}
+}
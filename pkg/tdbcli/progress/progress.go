@@ -0,0 +1,157 @@
+// Package progress defines structured progress events emitted by long-running
+// operations such as export, sync, and bulk-create.
+//
+// The CLI renders these events as progress bars; programs embedding the CLI
+// can attach their own Reporter to the command context to consume them:
+//
+//	ctx := progress.WithReporter(context.Background(), progress.Func(func(e progress.Event) {
+//		log.Printf("%s %s: %d/%d", e.Operation, e.Type, e.Count, e.Total)
+//	}))
+//	root := cli.NewRootCommand()
+//	root.SetArgs([]string{"tenant", "documents", "export", "users", "--out", "users.jsonl"})
+//	err := root.ExecuteContext(ctx)
+package progress
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// EventType identifies a progress event.
+type EventType string
+
+const (
+	// Started is emitted once when an operation begins.
+	Started EventType = "started"
+	// PageFetched is emitted after a page or batch has been read or sent.
+	PageFetched EventType = "page_fetched"
+	// ItemWritten is emitted after each item has been processed.
+	ItemWritten EventType = "item_written"
+	// Completed is emitted once when an operation ends; Err is set on failure.
+	Completed EventType = "completed"
+)
+
+// Event describes the state of an operation at a point in time.
+type Event struct {
+	Type      EventType
+	Operation string
+	// Total is the expected number of items, or zero when unknown.
+	Total int64
+	// Count is the number of items processed so far.
+	Count int64
+	// Page is the 1-based page or batch number for PageFetched events.
+	Page int
+	// PageSize is the number of items in the page for PageFetched events.
+	PageSize int
+	Err      error
+	Time     time.Time
+}
+
+// Reporter receives progress events.
+type Reporter interface {
+	Report(Event)
+}
+
+// Func adapts a function to the Reporter interface.
+type Func func(Event)
+
+// Report calls f(e).
+func (f Func) Report(e Event) {
+	f(e)
+}
+
+// Nop discards all events.
+var Nop Reporter = Func(func(Event) {})
+
+type contextKey struct{}
+
+// WithReporter returns a context carrying r.
+func WithReporter(ctx context.Context, r Reporter) context.Context {
+	return context.WithValue(ctx, contextKey{}, r)
+}
+
+// FromContext returns the Reporter stored in ctx, if any.
+func FromContext(ctx context.Context) (Reporter, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	r, ok := ctx.Value(contextKey{}).(Reporter)
+	return r, ok && r != nil
+}
+
+// Tracker emits a consistent event sequence for one operation. It is safe for
+// concurrent use.
+type Tracker struct {
+	mu        sync.Mutex
+	reporter  Reporter
+	operation string
+	total     int64
+	count     int64
+	page      int
+	done      bool
+}
+
+// Start emits a Started event and returns a Tracker for the operation.
+func Start(r Reporter, operation string, total int64) *Tracker {
+	if r == nil {
+		r = Nop
+	}
+	t := &Tracker{reporter: r, operation: operation, total: total}
+	t.emit(Event{Type: Started})
+	return t
+}
+
+// SetTotal updates the expected number of items once it becomes known.
+func (t *Tracker) SetTotal(total int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.total = total
+}
+
+// Page records that a page or batch of size items was fetched or sent.
+func (t *Tracker) Page(size int) {
+	t.mu.Lock()
+	t.page++
+	page := t.page
+	t.mu.Unlock()
+	t.emit(Event{Type: PageFetched, Page: page, PageSize: size})
+}
+
+// Item records that one item was processed.
+func (t *Tracker) Item() {
+	t.Add(1)
+}
+
+// Add records that n items were processed.
+func (t *Tracker) Add(n int) {
+	if n <= 0 {
+		return
+	}
+	t.mu.Lock()
+	t.count += int64(n)
+	t.mu.Unlock()
+	t.emit(Event{Type: ItemWritten})
+}
+
+// Done emits the Completed event. Only the first call has an effect.
+func (t *Tracker) Done(err error) {
+	t.mu.Lock()
+	if t.done {
+		t.mu.Unlock()
+		return
+	}
+	t.done = true
+	t.mu.Unlock()
+	t.emit(Event{Type: Completed, Err: err})
+}
+
+func (t *Tracker) emit(e Event) {
+	t.mu.Lock()
+	e.Operation = t.operation
+	e.Total = t.total
+	e.Count = t.count
+	t.mu.Unlock()
+	e.Time = time.Now()
+	t.reporter.Report(e)
+}
@@ -0,0 +1,44 @@
+package progress
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTrackerEventSequence(t *testing.T) {
+	var events []Event
+	tracker := Start(Func(func(e Event) { events = append(events, e) }), "export", 3)
+	tracker.Page(2)
+	tracker.Item()
+	tracker.Add(2)
+	failure := errors.New("boom")
+	tracker.Done(failure)
+	tracker.Done(nil)
+
+	want := []EventType{Started, PageFetched, ItemWritten, ItemWritten, Completed}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %d: %+v", len(want), len(events), events)
+	}
+	for i, typ := range want {
+		if events[i].Type != typ || events[i].Operation != "export" || events[i].Total != 3 {
+			t.Fatalf("event %d: unexpected %+v", i, events[i])
+		}
+	}
+	if events[1].Page != 1 || events[1].PageSize != 2 {
+		t.Fatalf("unexpected page event %+v", events[1])
+	}
+	if events[3].Count != 3 || events[4].Err != failure {
+		t.Fatalf("unexpected final events %+v %+v", events[3], events[4])
+	}
+}
+
+func TestReporterContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Fatalf("expected no reporter in empty context")
+	}
+	ctx := WithReporter(context.Background(), Nop)
+	if r, ok := FromContext(ctx); !ok || r == nil {
+		t.Fatalf("expected reporter from context")
+	}
+}
@@ -0,0 +1,25 @@
+// Package sdk is a high-level Go client for TinyDB built on the same transport
+// the tdb CLI uses.
+//
+// The lower-level client package mirrors the HTTP API one call at a time. This
+// package wraps it with a resource-oriented API, automatic pagination, and
+// context-aware streaming so services can embed TinyDB access without copying
+// CLI code:
+//
+//	db, err := sdk.New("https://api.tinydb.example", apiKey, sdk.WithAppID("app_123"))
+//	if err != nil {
+//		return err
+//	}
+//	users := db.Collection("users").Documents()
+//	it := users.List(ctx, sdk.ListOptions{Filters: map[string]string{"status": "active"}})
+//	for it.Next() {
+//		doc := it.Document()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+//
+// The exported API of this package follows semantic versioning together with
+// the CLI module; use Client.Tenant for endpoints not yet covered here.
+package sdk
@@ -0,0 +1,239 @@
+package sdk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+const defaultPageSize = 100
+
+// Documents is the document API of one collection.
+type Documents struct {
+	client     *Client
+	collection string
+}
+
+// ListOptions configures List.
+type ListOptions struct {
+	// PageSize is the number of documents fetched per request (default 100).
+	PageSize int
+	// Limit caps the total number of documents returned; zero means no limit.
+	Limit int
+	// Filters match field=value pairs.
+	Filters map[string]string
+	// Sort lists fields to order by; prefix a field with - for descending order.
+	Sort []string
+	// Select projects the returned fields.
+	Select         []string
+	IncludeDeleted bool
+}
+
+// Get fetches a document by ID.
+func (d *Documents) Get(ctx context.Context, id string) (*clientpkg.Document, error) {
+	return d.client.tenant.GetDocument(ctx, d.collection, id, d.client.appID)
+}
+
+// GetByKey fetches a document by its primary key value.
+func (d *Documents) GetByKey(ctx context.Context, key string) (*clientpkg.Document, error) {
+	return d.client.tenant.GetDocumentByPrimaryKey(ctx, d.collection, key, d.client.appID)
+}
+
+// Create inserts a document. data may be raw JSON or any JSON-encodable value.
+func (d *Documents) Create(ctx context.Context, data any) (*clientpkg.Document, error) {
+	payload, err := encodePayload(data)
+	if err != nil {
+		return nil, err
+	}
+	return d.client.tenant.CreateDocument(ctx, d.collection, payload, d.client.appID)
+}
+
+// Update replaces the document with the given ID.
+func (d *Documents) Update(ctx context.Context, id string, data any) (*clientpkg.Document, error) {
+	payload, err := encodePayload(data)
+	if err != nil {
+		return nil, err
+	}
+	return d.client.tenant.UpdateDocument(ctx, d.collection, id, payload, d.client.appID)
+}
+
+// Patch merges fields into the document with the given ID.
+func (d *Documents) Patch(ctx context.Context, id string, data any) (*clientpkg.Document, error) {
+	payload, err := encodePayload(data)
+	if err != nil {
+		return nil, err
+	}
+	return d.client.tenant.PatchDocument(ctx, d.collection, id, payload, d.client.appID)
+}
+
+// Delete soft-deletes the document with the given ID.
+func (d *Documents) Delete(ctx context.Context, id string) error {
+	return d.client.tenant.DeleteDocument(ctx, d.collection, id, d.client.appID)
+}
+
+// Count returns the number of documents in the collection.
+func (d *Documents) Count(ctx context.Context) (int64, error) {
+	return d.client.tenant.CountDocuments(ctx, d.collection, d.client.appID)
+}
+
+// List returns an iterator over the documents matching opts. Pages are fetched
+// lazily as the iterator advances; unfiltered full scans use the streaming
+// export endpoint when the server supports it.
+func (d *Documents) List(ctx context.Context, opts ListOptions) *DocumentIterator {
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	inner := d.client.tenant.DocumentsIterator(ctx, d.collection, clientpkg.ListDocumentsParams{
+		AppID:          d.client.appID,
+		Limit:          pageSize,
+		IncludeDeleted: opts.IncludeDeleted,
+		SelectFields:   opts.Select,
+		Filters:        opts.Filters,
+		Sort:           opts.Sort,
+	})
+	return &DocumentIterator{inner: inner, limit: opts.Limit}
+}
+
+// DocumentIterator walks a paginated document listing:
+//
+//	for it.Next() {
+//		doc := it.Document()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type DocumentIterator struct {
+	inner    *clientpkg.DocumentIterator
+	limit    int
+	returned int
+}
+
+// Next advances to the next document, fetching another page when needed. It
+// returns false when the listing is exhausted, the limit is reached, or an
+// error occurred.
+func (it *DocumentIterator) Next() bool {
+	if it.limit > 0 && it.returned >= it.limit {
+		_ = it.inner.Close()
+		return false
+	}
+	if !it.inner.Next() {
+		return false
+	}
+	it.returned++
+	return true
+}
+
+// Document returns the document at the current position.
+func (it *DocumentIterator) Document() clientpkg.Document {
+	return it.inner.Document()
+}
+
+// Err returns the error that stopped iteration, if any.
+func (it *DocumentIterator) Err() error {
+	return it.inner.Err()
+}
+
+// Close releases resources held by an iterator that is abandoned early.
+func (it *DocumentIterator) Close() error {
+	return it.inner.Close()
+}
+
+// All drains the iterator into a slice.
+func (it *DocumentIterator) All() ([]clientpkg.Document, error) {
+	var docs []clientpkg.Document
+	for it.Next() {
+		docs = append(docs, it.Document())
+	}
+	return docs, it.Err()
+}
+
+// StreamOptions configures Stream.
+type StreamOptions struct {
+	// Select projects the returned fields; SelectOnly drops implicit metadata.
+	Select     []string
+	SelectOnly bool
+	// Cursor resumes a previous stream.
+	Cursor string
+	// Limit hints the number of records the server should return.
+	Limit int
+}
+
+// Stream opens the NDJSON export stream of the collection. Cancelling ctx
+// aborts the stream; callers must Close it when done.
+func (d *Documents) Stream(ctx context.Context, opts StreamOptions) (*DocumentStream, error) {
+	body, headers, err := d.client.tenant.StreamExport(ctx, d.collection, opts.Select, opts.SelectOnly, opts.Cursor, opts.Limit, d.client.appID)
+	if err != nil {
+		return nil, err
+	}
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	return &DocumentStream{
+		ctx:        ctx,
+		body:       body,
+		scanner:    scanner,
+		nextCursor: strings.TrimSpace(headers.Get("X-Next-Cursor")),
+	}, nil
+}
+
+// DocumentStream reads records from an export stream one line at a time.
+type DocumentStream struct {
+	ctx        context.Context
+	body       io.ReadCloser
+	scanner    *bufio.Scanner
+	current    json.RawMessage
+	nextCursor string
+	err        error
+}
+
+// Next advances to the next record.
+func (s *DocumentStream) Next() bool {
+	if s.err != nil {
+		return false
+	}
+	for s.scanner.Scan() {
+		if err := s.ctx.Err(); err != nil {
+			s.err = err
+			return false
+		}
+		line := strings.TrimSpace(s.scanner.Text())
+		if line == "" {
+			continue
+		}
+		s.current = json.RawMessage(line)
+		return true
+	}
+	if err := s.scanner.Err(); err != nil {
+		s.err = err
+	} else if err := s.ctx.Err(); err != nil {
+		s.err = err
+	}
+	return false
+}
+
+// Record returns the current raw record.
+func (s *DocumentStream) Record() json.RawMessage {
+	return s.current
+}
+
+// Decode unmarshals the current record into v.
+func (s *DocumentStream) Decode(v any) error {
+	return json.Unmarshal(s.current, v)
+}
+
+// NextCursor returns the continuation cursor reported by the server, if any.
+func (s *DocumentStream) NextCursor() string {
+	return s.nextCursor
+}
+
+// Err returns the error that stopped the stream, if any.
+func (s *DocumentStream) Err() error {
+	return s.err
+}
+
+// Close releases the underlying connection.
+func (s *DocumentStream) Close() error {
+	return s.body.Close()
+}
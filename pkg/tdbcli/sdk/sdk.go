@@ -0,0 +1,183 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+)
+
+// Client is the entry point of the SDK. It is safe for concurrent use.
+type Client struct {
+	tenant *clientpkg.TenantClient
+	appID  string
+}
+
+type settings struct {
+	appID   string
+	options []clientpkg.Option
+}
+
+// Option configures a Client created with New.
+type Option func(*settings)
+
+// WithAppID scopes every request to the given application.
+func WithAppID(appID string) Option {
+	return func(s *settings) {
+		s.appID = strings.TrimSpace(appID)
+	}
+}
+
+// WithHTTPClient overrides the HTTP client used for requests.
+func WithHTTPClient(h *http.Client) Option {
+	return func(s *settings) {
+		if h != nil {
+			s.options = append(s.options, clientpkg.WithHTTPClient(h))
+		}
+	}
+}
+
+// WithRetries configures retries of idempotent writes after transient failures.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(s *settings) {
+		s.options = append(s.options, clientpkg.WithRetries(maxRetries, backoff))
+	}
+}
+
+// WithMaxIdleConns sets how many idle connections are kept per host.
+func WithMaxIdleConns(n int) Option {
+	return func(s *settings) {
+		s.options = append(s.options, clientpkg.WithMaxIdleConns(n))
+	}
+}
+
+// WithMaxConnsPerHost caps the connections opened to the endpoint; zero means
+// no limit.
+func WithMaxConnsPerHost(n int) Option {
+	return func(s *settings) {
+		s.options = append(s.options, clientpkg.WithMaxConnsPerHost(n))
+	}
+}
+
+// WithForceHTTP2 multiplexes requests over HTTP/2, including cleartext h2c.
+func WithForceHTTP2() Option {
+	return func(s *settings) {
+		s.options = append(s.options, clientpkg.WithForceHTTP2())
+	}
+}
+
+// New creates a Client for the given endpoint and tenant API key.
+func New(endpoint, apiKey string, opts ...Option) (*Client, error) {
+	var cfg settings
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	tenant, err := clientpkg.NewTenantClient(endpoint, apiKey, cfg.options...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{tenant: tenant, appID: cfg.appID}, nil
+}
+
+// NewFromTenantClient wraps an existing low-level tenant client.
+func NewFromTenantClient(tenant *clientpkg.TenantClient, appID string) *Client {
+	return &Client{tenant: tenant, appID: strings.TrimSpace(appID)}
+}
+
+// Tenant returns the underlying low-level client for endpoints the SDK does not
+// wrap yet.
+func (c *Client) Tenant() *clientpkg.TenantClient {
+	return c.tenant
+}
+
+// AppID returns the application scope applied to requests, if any.
+func (c *Client) AppID() string {
+	return c.appID
+}
+
+// Collections lists the collections visible to the API key.
+func (c *Client) Collections(ctx context.Context) ([]clientpkg.Collection, error) {
+	return c.tenant.ListCollections(ctx, c.appID)
+}
+
+// Collection returns a handle for the named collection. No request is made.
+func (c *Client) Collection(name string) *Collection {
+	return &Collection{client: c, name: strings.TrimSpace(name)}
+}
+
+// Queries returns a handle for saved queries.
+func (c *Client) Queries() *Queries {
+	return &Queries{client: c}
+}
+
+// Collection is a handle for one collection.
+type Collection struct {
+	client *Client
+	name   string
+}
+
+// Name returns the collection name.
+func (c *Collection) Name() string {
+	return c.name
+}
+
+// Get fetches the collection definition.
+func (c *Collection) Get(ctx context.Context) (*clientpkg.Collection, error) {
+	return c.client.tenant.GetCollection(ctx, c.name, c.client.appID)
+}
+
+// Documents returns the document API of the collection.
+func (c *Collection) Documents() *Documents {
+	return &Documents{client: c.client, collection: c.name}
+}
+
+// Queries executes saved queries.
+type Queries struct {
+	client *Client
+}
+
+// Execute runs the saved query with the given name. params is encoded as the
+// JSON request body and may be nil.
+func (q *Queries) Execute(ctx context.Context, name string, params any) ([]map[string]any, error) {
+	var payload []byte
+	if params != nil {
+		encoded, err := encodePayload(params)
+		if err != nil {
+			return nil, err
+		}
+		payload = encoded
+	}
+	result, err := q.client.tenant.ExecuteSavedQueryByName(ctx, name, payload, q.client.appID)
+	if err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
+
+// encodePayload accepts raw JSON ([]byte, json.RawMessage, string) or any value
+// that can be marshalled to JSON.
+func encodePayload(value any) ([]byte, error) {
+	var raw []byte
+	switch typed := value.(type) {
+	case json.RawMessage:
+		raw = typed
+	case []byte:
+		raw = typed
+	case string:
+		raw = []byte(typed)
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("encode payload: %w", err)
+		}
+		return encoded, nil
+	}
+	if !json.Valid(raw) {
+		return nil, fmt.Errorf("payload is not valid JSON")
+	}
+	return raw, nil
+}
@@ -0,0 +1,122 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	clientpkg "github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/client"
+	"github.com/cubetiqlabs/tdb-cli/pkg/tdbcli/clienttest"
+)
+
+func newTestClient(t *testing.T) (*Client, *clienttest.Server) {
+	t.Helper()
+	srv := clienttest.NewServer(t)
+	db, err := New(srv.URL, clienttest.APIKey, WithRetries(0, 0))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return db, srv
+}
+
+func TestDocumentIteratorPaginates(t *testing.T) {
+	db, srv := newTestClient(t)
+	for i := 0; i < 5; i++ {
+		srv.AddDocument("users", map[string]any{"id": fmt.Sprintf("u%d", i), "status": "active"})
+	}
+	srv.AddDocument("users", map[string]any{"id": "u9", "status": "disabled"})
+
+	it := db.Collection("users").Documents().List(context.Background(), ListOptions{PageSize: 2, Filters: map[string]string{"status": "active"}})
+	docs, err := it.All()
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+	if len(docs) != 5 || docs[4].Key != "u4" {
+		t.Fatalf("unexpected documents: %+v", docs)
+	}
+	pages := 0
+	for _, req := range srv.Requests() {
+		if req == "GET /api/collections/users/documents" {
+			pages++
+		}
+	}
+	if pages != 3 {
+		t.Fatalf("expected 3 page requests, got %d (%v)", pages, srv.Requests())
+	}
+}
+
+func TestDocumentIteratorLimitAndError(t *testing.T) {
+	db, srv := newTestClient(t)
+	srv.AddDocument("users", map[string]any{"id": "a"})
+	srv.AddDocument("users", map[string]any{"id": "b"})
+
+	docs, err := db.Collection("users").Documents().List(context.Background(), ListOptions{PageSize: 2, Limit: 1}).All()
+	if err != nil || len(docs) != 1 {
+		t.Fatalf("expected one document, got %d (%v)", len(docs), err)
+	}
+	if _, err := db.Collection("missing").Documents().List(context.Background(), ListOptions{Sort: []string{"id"}}).All(); err == nil {
+		t.Fatalf("expected error for unknown collection")
+	}
+}
+
+func TestDocumentsCreateEncodesValues(t *testing.T) {
+	db, srv := newTestClient(t)
+	srv.AddCollection(clientpkg.Collection{Name: "users"})
+
+	doc, err := db.Collection("users").Documents().Create(context.Background(), struct {
+		Name string `json:"name"`
+	}{Name: "Ada"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	stored := srv.Documents("users")
+	if len(stored) != 1 || stored[0].ID != doc.ID || !strings.Contains(stored[0].Data, `"name":"Ada"`) {
+		t.Fatalf("unexpected stored documents %+v", stored)
+	}
+	if _, err := db.Collection("users").Documents().Create(context.Background(), "{not json"); err == nil {
+		t.Fatalf("expected invalid JSON error")
+	}
+}
+
+func TestDocumentStream(t *testing.T) {
+	db, srv := newTestClient(t)
+	srv.AddDocument("events", map[string]any{"id": "a"})
+	srv.AddDocument("events", map[string]any{"id": "b"})
+
+	stream, err := db.Collection("events").Documents().Stream(context.Background(), StreamOptions{})
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	defer stream.Close()
+	var ids []string
+	for stream.Next() {
+		var record struct {
+			Key string `json:"key"`
+		}
+		if err := stream.Decode(&record); err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		ids = append(ids, record.Key)
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+	if fmt.Sprint(ids) != "[a b]" {
+		t.Fatalf("unexpected stream result %v", ids)
+	}
+}
+
+func TestQueriesExecute(t *testing.T) {
+	db, srv := newTestClient(t)
+	srv.AddSavedQuery(clientpkg.SavedQuery{Name: "active", Type: "dsl", Collection: "users"})
+	srv.SetQueryResult("active", []map[string]any{{"id": "u1"}})
+
+	rows, err := db.Queries().Execute(context.Background(), "active", map[string]any{"limit": 10})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if len(rows) != 1 || rows[0]["id"] != "u1" {
+		t.Fatalf("unexpected rows %v", rows)
+	}
+}